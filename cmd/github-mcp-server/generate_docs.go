@@ -11,6 +11,7 @@ import (
 
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/scim"
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v74/github"
@@ -47,6 +48,11 @@ func mockGetRawClient(_ context.Context) (*raw.Client, error) {
 	return nil, nil
 }
 
+// mockGetSCIMClient returns a mock SCIM client for documentation generation
+func mockGetSCIMClient(_ context.Context) (*scim.Client, error) {
+	return nil, nil
+}
+
 func generateAllDocs() error {
 	if err := generateReadmeDocs("README.md"); err != nil {
 		return fmt.Errorf("failed to generate README docs: %w", err)
@@ -64,7 +70,7 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, mockGetSCIMClient, t, github.AssetsConfig{}, github.TokenPermissionsConfig{}, nil, nil, nil)
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(tsg)
@@ -205,6 +211,8 @@ func formatToolsetName(name string) string {
 		return "Secret Protection"
 	case "orgs":
 		return "Organizations"
+	case "scim":
+		return "SCIM"
 	default:
 		// Fallback: capitalize first letter and replace underscores with spaces
 		parts := strings.Split(name, "_")
@@ -302,7 +310,7 @@ func generateRemoteToolsetsDoc() string {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, mockGetSCIMClient, t, github.AssetsConfig{}, github.TokenPermissionsConfig{}, nil, nil, nil)
 
 	// Generate table header
 	buf.WriteString("| Name           | Description                                      | API URL                                               | 1-Click Install (VS Code)                                                                                                                                                                                                 | Read-only Link                                                                                                 | 1-Click Read-only Install (VS Code)                                                                                                                                                                                                 |\n")
@@ -311,6 +319,10 @@ func generateRemoteToolsetsDoc() string {
 	// Get all toolsets
 	toolsetNames := make([]string, 0, len(tsg.Toolsets))
 	for name := range tsg.Toolsets {
+		// The remote server targets github.com, where the GitHub Enterprise Server toolset is not available
+		if name == github.EnterpriseToolsetName {
+			continue
+		}
 		if name != "context" && name != "dynamic" { // Skip context and dynamic toolsets as they're handled separately
 			toolsetNames = append(toolsetNames, name)
 		}
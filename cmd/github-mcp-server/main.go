@@ -1,13 +1,13 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/webhook"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -18,6 +18,10 @@ var version = "version"
 var commit = "commit"
 var date = "date"
 
+// oauthClientID is the client ID of the app `stdio --auth login` logs in with by default. Builds
+// that publish an app set it using ldflags.
+var oauthClientID = ""
+
 var (
 	rootCmd = &cobra.Command{
 		Use:     "server",
@@ -29,7 +33,7 @@ var (
 	httpCmd = &cobra.Command{
 		Use:   "http",
 		Short: "Start HTTP server",
-		Long:  `Start a server that communicates via HTTP using the MCP protocol.`,
+		Long:  `Start a server that communicates via HTTP using the MCP protocol, over the streamable HTTP transport or, for older clients, the SSE transport.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			token := viper.GetString("personal_access_token")
 
@@ -38,19 +42,138 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
-			httpServerConfig := ghmcp.HTTPServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				Port:                 viper.GetInt("port"),
+			tlsClientAuthMode, err := ghmcp.ParseTLSClientAuthMode(viper.GetString("tls_client_auth"))
+			if err != nil {
+				return err
+			}
+
+			// Per-query TTLs are unmarshalled for the same reason as the toolsets
+			var graphQLCacheTTLSpecs []string
+			if err := viper.UnmarshalKey("graphql_cache_query_ttl", &graphQLCacheTTLSpecs); err != nil {
+				return fmt.Errorf("failed to unmarshal GraphQL cache query TTLs: %w", err)
+			}
+			graphQLCacheQueryTTLs, err := github.ParseGraphQLCacheTTLs(graphQLCacheTTLSpecs)
+			if err != nil {
+				return err
+			}
+
+			tokenValidation, err := ghmcp.ParseTokenValidation(viper.GetString("token_validation"))
+			if err != nil {
+				return err
+			}
+
+			var corsConfig ghmcp.CORSConfig
+			if err := viper.UnmarshalKey("cors_allowed_origins", &corsConfig.AllowedOrigins); err != nil {
+				return fmt.Errorf("failed to unmarshal cors allowed origins: %w", err)
+			}
+			if err := viper.UnmarshalKey("cors_allowed_methods", &corsConfig.AllowedMethods); err != nil {
+				return fmt.Errorf("failed to unmarshal cors allowed methods: %w", err)
+			}
+			if err := viper.UnmarshalKey("cors_allowed_headers", &corsConfig.AllowedHeaders); err != nil {
+				return fmt.Errorf("failed to unmarshal cors allowed headers: %w", err)
+			}
+
+			var webhookEvents []string
+			if err := viper.UnmarshalKey("webhook_events", &webhookEvents); err != nil {
+				return fmt.Errorf("failed to unmarshal webhook events: %w", err)
+			}
+			if err := webhook.ValidateEvents(webhookEvents); err != nil {
+				return err
+			}
+
+			switch transport := viper.GetString("transport"); transport {
+			case "streamable-http":
+				httpServerConfig := ghmcp.HTTPServerConfig{
+					Version:                   version,
+					Host:                      viper.GetString("host"),
+					Token:                     token,
+					EnabledToolsets:           enabledToolsets,
+					DynamicToolsets:           viper.GetBool("dynamic_toolsets"),
+					ReadOnly:                  viper.GetBool("read-only"),
+					ExportTranslations:        viper.GetBool("export-translations"),
+					ExportToolsPath:           viper.GetString("export_tools"),
+					ToolStats:                 viper.GetBool("tool_stats"),
+					ToolStatsPath:             viper.GetString("tool_stats_file"),
+					EnableCommandLogging:      viper.GetBool("enable-command-logging"),
+					LogFilePath:               viper.GetString("log-file"),
+					Port:                      viper.GetInt("port"),
+					BindAddress:               viper.GetString("bind_address"),
+					AssetsRepository:          viper.GetString("assets_repo"),
+					AssetsBranch:              viper.GetString("assets_branch"),
+					DefaultOwner:              viper.GetString("default_owner"),
+					DefaultRepo:               viper.GetString("default_repo"),
+					WebhookSecret:             viper.GetString("webhook_secret"),
+					WebhookEvents:             webhookEvents,
+					RequirePerRequestToken:    viper.GetBool("require_per_request_token"),
+					AdminToken:                viper.GetString("admin_token"),
+					SkipTokenProbe:            viper.GetBool("skip_token_probe"),
+					BatchConcurrency:          viper.GetInt("batch_concurrency"),
+					SecondaryRateLimitMaxWait: viper.GetDuration("secondary_rate_limit_max_wait"),
+					MaxRequestsPerSession:     viper.GetInt("max_requests_per_session"),
+					MaxRequestsPerMinute:      viper.GetInt("max_requests_per_minute"),
+					UserAgentSuffix:           viper.GetString("user_agent_suffix"),
+					APIVersion:                viper.GetString("api_version"),
+					GraphQLCacheTTL:           viper.GetDuration("graphql_cache_ttl"),
+					GraphQLCacheQueryTTLs:     graphQLCacheQueryTTLs,
+					TLSCertFile:               viper.GetString("tls_cert_file"),
+					TLSKeyFile:                viper.GetString("tls_key_file"),
+					TLSClientCACert:           viper.GetString("tls_client_ca_cert"),
+					TLSClientAuthMode:         tlsClientAuthMode,
+					TLSLogClientCertificates:  viper.GetBool("tls_log_client_cert"),
+					TokenValidation:           tokenValidation,
+					AllowTokenQueryParam:      viper.GetBool("allow_token_query_param"),
+					CORS:                      corsConfig,
+					MaxRequestBodySize:        viper.GetInt64("max_request_body_size"),
+				}
+				return ghmcp.RunHTTPServer(httpServerConfig)
+			case "sse":
+				sseServerConfig := ghmcp.SSEServerConfig{
+					Version:                   version,
+					Host:                      viper.GetString("host"),
+					Token:                     token,
+					EnabledToolsets:           enabledToolsets,
+					DynamicToolsets:           viper.GetBool("dynamic_toolsets"),
+					ReadOnly:                  viper.GetBool("read-only"),
+					ExportTranslations:        viper.GetBool("export-translations"),
+					ExportToolsPath:           viper.GetString("export_tools"),
+					ToolStats:                 viper.GetBool("tool_stats"),
+					ToolStatsPath:             viper.GetString("tool_stats_file"),
+					EnableCommandLogging:      viper.GetBool("enable-command-logging"),
+					LogFilePath:               viper.GetString("log-file"),
+					Port:                      viper.GetInt("port"),
+					BindAddress:               viper.GetString("bind_address"),
+					AssetsRepository:          viper.GetString("assets_repo"),
+					AssetsBranch:              viper.GetString("assets_branch"),
+					DefaultOwner:              viper.GetString("default_owner"),
+					DefaultRepo:               viper.GetString("default_repo"),
+					WebhookSecret:             viper.GetString("webhook_secret"),
+					WebhookEvents:             webhookEvents,
+					BaseURL:                   viper.GetString("base_url"),
+					RequirePerRequestToken:    viper.GetBool("require_per_request_token"),
+					AdminToken:                viper.GetString("admin_token"),
+					SkipTokenProbe:            viper.GetBool("skip_token_probe"),
+					BatchConcurrency:          viper.GetInt("batch_concurrency"),
+					SecondaryRateLimitMaxWait: viper.GetDuration("secondary_rate_limit_max_wait"),
+					MaxRequestsPerSession:     viper.GetInt("max_requests_per_session"),
+					MaxRequestsPerMinute:      viper.GetInt("max_requests_per_minute"),
+					UserAgentSuffix:           viper.GetString("user_agent_suffix"),
+					APIVersion:                viper.GetString("api_version"),
+					GraphQLCacheTTL:           viper.GetDuration("graphql_cache_ttl"),
+					GraphQLCacheQueryTTLs:     graphQLCacheQueryTTLs,
+					TLSCertFile:               viper.GetString("tls_cert_file"),
+					TLSKeyFile:                viper.GetString("tls_key_file"),
+					TLSClientCACert:           viper.GetString("tls_client_ca_cert"),
+					TLSClientAuthMode:         tlsClientAuthMode,
+					TLSLogClientCertificates:  viper.GetBool("tls_log_client_cert"),
+					TokenValidation:           tokenValidation,
+					AllowTokenQueryParam:      viper.GetBool("allow_token_query_param"),
+					CORS:                      corsConfig,
+					MaxRequestBodySize:        viper.GetInt64("max_request_body_size"),
+				}
+				return ghmcp.RunSSEServer(sseServerConfig)
+			default:
+				return fmt.Errorf("unknown transport %q, must be streamable-http or sse", transport)
 			}
-			return ghmcp.RunHTTPServer(httpServerConfig)
 		},
 	}
 
@@ -59,11 +182,21 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			token := viper.GetString("personal_access_token")
-			if token == "" {
-				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+			switch auth := viper.GetString("auth"); auth {
+			case "":
+			case "login":
+				return ghmcp.Login(ghmcp.LoginConfig{
+					Host:     viper.GetString("host"),
+					ClientID: viper.GetString("oauth_client_id"),
+					Prompt:   os.Stderr,
+				})
+			default:
+				return fmt.Errorf("unknown auth mode %q, must be login", auth)
 			}
 
+			// Without a personal access token, the token stored by --auth login is used
+			token := viper.GetString("personal_access_token")
+
 			// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
 			// it's because viper doesn't handle comma-separated values correctly for env
 			// vars when using GetStringSlice.
@@ -73,16 +206,46 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			// Per-query TTLs are unmarshalled for the same reason as the toolsets
+			var graphQLCacheTTLSpecs []string
+			if err := viper.UnmarshalKey("graphql_cache_query_ttl", &graphQLCacheTTLSpecs); err != nil {
+				return fmt.Errorf("failed to unmarshal GraphQL cache query TTLs: %w", err)
+			}
+			graphQLCacheQueryTTLs, err := github.ParseGraphQLCacheTTLs(graphQLCacheTTLSpecs)
+			if err != nil {
+				return err
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
+				Version:                   version,
+				Host:                      viper.GetString("host"),
+				Token:                     token,
+				EnabledToolsets:           enabledToolsets,
+				DynamicToolsets:           viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                  viper.GetBool("read-only"),
+				ExportTranslations:        viper.GetBool("export-translations"),
+				ExportToolsPath:           viper.GetString("export_tools"),
+				ToolStats:                 viper.GetBool("tool_stats"),
+				ToolStatsPath:             viper.GetString("tool_stats_file"),
+				EnableCommandLogging:      viper.GetBool("enable-command-logging"),
+				LogFilePath:               viper.GetString("log-file"),
+				AssetsRepository:          viper.GetString("assets_repo"),
+				AssetsBranch:              viper.GetString("assets_branch"),
+				DefaultOwner:              viper.GetString("default_owner"),
+				DefaultRepo:               viper.GetString("default_repo"),
+				AdminToken:                viper.GetString("admin_token"),
+				SkipTokenProbe:            viper.GetBool("skip_token_probe"),
+				BatchConcurrency:          viper.GetInt("batch_concurrency"),
+				SecondaryRateLimitMaxWait: viper.GetDuration("secondary_rate_limit_max_wait"),
+				MaxRequestsPerSession:     viper.GetInt("max_requests_per_session"),
+				MaxRequestsPerMinute:      viper.GetInt("max_requests_per_minute"),
+				UserAgentSuffix:           viper.GetString("user_agent_suffix"),
+				APIVersion:                viper.GetString("api_version"),
+				GraphQLCacheTTL:           viper.GetDuration("graphql_cache_ttl"),
+				GraphQLCacheQueryTTLs:     graphQLCacheQueryTTLs,
+				UseStoredCredentials:      token == "",
+				InputFD:                   viper.GetInt("input_fd"),
+				OutputFD:                  viper.GetInt("output_fd"),
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -102,7 +265,24 @@ func init() {
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
+	rootCmd.PersistentFlags().Bool("tool-stats", false, "Record how often each tool is called, fails and how long it takes, and log a summary on shutdown. In HTTP mode the statistics are served at /debug/toolstats. Nothing leaves the server")
+	rootCmd.PersistentFlags().String("tool-stats-file", "", "Path to write the tool usage statistics to as JSON on shutdown, implies --tool-stats")
+	rootCmd.PersistentFlags().String("export-tools", "", "Write the name, description, input schema and toolset of the tools the server would offer with the other flags to this JSON file, and exit")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().String("assets-repo", "", "Repository (owner/repo) uploaded attachments are committed to, defaults to the repository they are uploaded for")
+	rootCmd.PersistentFlags().String("assets-branch", github.DefaultAssetsBranch, "Branch uploaded attachments are committed to")
+	rootCmd.PersistentFlags().String("default-owner", "", "Owner that tools use when called without one, making their owner parameter optional")
+	rootCmd.PersistentFlags().String("default-repo", "", "Repository of --default-owner that tools use when called without one, making their repo parameter optional")
+	rootCmd.PersistentFlags().String("admin-token", "", "Token that enables the admin_enable_toolset and admin_disable_toolset tools, which must be called with it. The admin tools are disabled when empty")
+	rootCmd.PersistentFlags().Bool("skip-token-probe", false, "Do not probe the repository permissions of fine-grained personal access tokens in get_me")
+	rootCmd.PersistentFlags().Int("batch-concurrency", github.DefaultBatchConcurrency, "Number of calls the batch_tool_calls tool runs at once")
+	rootCmd.PersistentFlags().Duration("secondary-rate-limit-max-wait", 0, "Longest wait after a GitHub secondary rate limit to retry requests after (0 reports the limit instead)")
+	rootCmd.PersistentFlags().Int("max-requests-per-session", 0, "Number of GitHub API requests each MCP session may make (0 for no limit)")
+	rootCmd.PersistentFlags().Int("max-requests-per-minute", 0, "Number of GitHub API requests each MCP session may make per minute (0 for no limit)")
+	rootCmd.PersistentFlags().String("user-agent-suffix", "", "Identifier appended to the User-Agent of the requests made to GitHub")
+	rootCmd.PersistentFlags().String("api-version", ghmcp.DefaultAPIVersion, "GitHub REST API version to pin requests to with the X-GitHub-Api-Version header (empty for GitHub's default)")
+	rootCmd.PersistentFlags().Duration("graphql-cache-ttl", 0, "How long to answer repeated GraphQL queries from a cache (0 disables the cache)")
+	rootCmd.PersistentFlags().StringSlice("graphql-cache-query-ttl", nil, "TTL of the GraphQL queries whose first field is field, as field=duration (e.g. search=0s), overriding --graphql-cache-ttl")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -111,14 +291,74 @@ func init() {
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
+	_ = viper.BindPFlag("export_tools", rootCmd.PersistentFlags().Lookup("export-tools"))
+	_ = viper.BindPFlag("tool_stats", rootCmd.PersistentFlags().Lookup("tool-stats"))
+	_ = viper.BindPFlag("tool_stats_file", rootCmd.PersistentFlags().Lookup("tool-stats-file"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("assets_repo", rootCmd.PersistentFlags().Lookup("assets-repo"))
+	_ = viper.BindPFlag("assets_branch", rootCmd.PersistentFlags().Lookup("assets-branch"))
+	_ = viper.BindPFlag("default_owner", rootCmd.PersistentFlags().Lookup("default-owner"))
+	_ = viper.BindPFlag("default_repo", rootCmd.PersistentFlags().Lookup("default-repo"))
+	_ = viper.BindPFlag("admin_token", rootCmd.PersistentFlags().Lookup("admin-token"))
+	_ = viper.BindPFlag("skip_token_probe", rootCmd.PersistentFlags().Lookup("skip-token-probe"))
+	_ = viper.BindPFlag("batch_concurrency", rootCmd.PersistentFlags().Lookup("batch-concurrency"))
+	_ = viper.BindPFlag("secondary_rate_limit_max_wait", rootCmd.PersistentFlags().Lookup("secondary-rate-limit-max-wait"))
+	_ = viper.BindPFlag("max_requests_per_session", rootCmd.PersistentFlags().Lookup("max-requests-per-session"))
+	_ = viper.BindPFlag("max_requests_per_minute", rootCmd.PersistentFlags().Lookup("max-requests-per-minute"))
+	_ = viper.BindPFlag("user_agent_suffix", rootCmd.PersistentFlags().Lookup("user-agent-suffix"))
+	_ = viper.BindPFlag("api_version", rootCmd.PersistentFlags().Lookup("api-version"))
+	_ = viper.BindPFlag("graphql_cache_ttl", rootCmd.PersistentFlags().Lookup("graphql-cache-ttl"))
+	_ = viper.BindPFlag("graphql_cache_query_ttl", rootCmd.PersistentFlags().Lookup("graphql-cache-query-ttl"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
 	rootCmd.AddCommand(httpCmd)
 
-	httpCmd.Flags().Int("port", 8080, "Port to listen on for HTTP server")
+	stdioCmd.Flags().String("auth", "", "Set to login to log in to GitHub with the OAuth device flow and store the token for later runs without GITHUB_PERSONAL_ACCESS_TOKEN, instead of starting the server")
+	_ = viper.BindPFlag("auth", stdioCmd.Flags().Lookup("auth"))
+	stdioCmd.Flags().String("oauth-client-id", oauthClientID, "Client ID of the OAuth or GitHub App --auth login logs in with")
+	_ = viper.BindPFlag("oauth_client_id", stdioCmd.Flags().Lookup("oauth-client-id"))
+	stdioCmd.Flags().Int("input-fd", 0, "File descriptor to read messages from instead of stdin")
+	_ = viper.BindPFlag("input_fd", stdioCmd.Flags().Lookup("input-fd"))
+	stdioCmd.Flags().Int("output-fd", 1, "File descriptor to write messages to instead of stdout")
+	_ = viper.BindPFlag("output_fd", stdioCmd.Flags().Lookup("output-fd"))
+
+	httpCmd.Flags().Int("port", 8080, "Port to listen on for HTTP server, 0 to let the system pick a free port")
 	_ = viper.BindPFlag("port", httpCmd.Flags().Lookup("port"))
+	httpCmd.Flags().String("bind-address", "", "Address of the interface to listen on, such as 127.0.0.1, defaults to all interfaces")
+	_ = viper.BindPFlag("bind_address", httpCmd.Flags().Lookup("bind-address"))
+	httpCmd.Flags().String("webhook-secret", "", "Secret used to validate GitHub webhooks received at /webhook. The webhook receiver is only enabled when set")
+	_ = viper.BindPFlag("webhook_secret", httpCmd.Flags().Lookup("webhook-secret"))
+	httpCmd.Flags().StringSlice("webhook-events", nil, "Comma separated webhook event types forwarded to the sessions subscribed to their repository, defaults to all of issues, pull_request, push, workflow_run and check_suite")
+	_ = viper.BindPFlag("webhook_events", httpCmd.Flags().Lookup("webhook-events"))
+	httpCmd.Flags().String("transport", "streamable-http", "HTTP transport to serve MCP over, streamable-http or sse")
+	_ = viper.BindPFlag("transport", httpCmd.Flags().Lookup("transport"))
+	httpCmd.Flags().String("base-url", "", "Public URL of the server, used by the sse transport to advertise its message endpoint")
+	_ = viper.BindPFlag("base_url", httpCmd.Flags().Lookup("base-url"))
+	httpCmd.Flags().Bool("require-per-request-token", false, "Reject tool calls without a token in the Authorization header instead of falling back to GITHUB_PERSONAL_ACCESS_TOKEN")
+	_ = viper.BindPFlag("require_per_request_token", httpCmd.Flags().Lookup("require-per-request-token"))
+	httpCmd.Flags().String("tls-cert-file", "", "Path to a PEM certificate to serve HTTPS with, together with --tls-key-file")
+	_ = viper.BindPFlag("tls_cert_file", httpCmd.Flags().Lookup("tls-cert-file"))
+	httpCmd.Flags().String("tls-key-file", "", "Path to the PEM private key of --tls-cert-file")
+	_ = viper.BindPFlag("tls_key_file", httpCmd.Flags().Lookup("tls-key-file"))
+	httpCmd.Flags().String("tls-client-ca-cert", "", "Path to a PEM CA certificate to verify client certificates against. When set, clients must present a certificate signed by it")
+	_ = viper.BindPFlag("tls_client_ca_cert", httpCmd.Flags().Lookup("tls-client-ca-cert"))
+	httpCmd.Flags().String("tls-client-auth", "", "How client certificates are requested: none, request, require, verify-if-given or require-and-verify. Defaults to require-and-verify when --tls-client-ca-cert is set, none otherwise")
+	_ = viper.BindPFlag("tls_client_auth", httpCmd.Flags().Lookup("tls-client-auth"))
+	httpCmd.Flags().Bool("tls-log-client-cert", false, "Log the subject common name and alternative names of the client certificate of each request")
+	_ = viper.BindPFlag("tls_log_client_cert", httpCmd.Flags().Lookup("tls-log-client-cert"))
+	httpCmd.Flags().String("token-validation", "none", "How strictly tokens in the Authorization header are checked before reaching tools: none, lenient (reject tokens that are too short or contain invalid characters) or strict (also require a GitHub token prefix such as ghp_ or github_pat_)")
+	_ = viper.BindPFlag("token_validation", httpCmd.Flags().Lookup("token-validation"))
+	httpCmd.Flags().Bool("allow-token-query-param", false, "Accept the token in the token or bearer_token query parameter from clients that cannot set the Authorization header. Query parameters can end up in access logs")
+	_ = viper.BindPFlag("allow_token_query_param", httpCmd.Flags().Lookup("allow-token-query-param"))
+	httpCmd.Flags().StringSlice("cors-allowed-origins", nil, "Comma separated origins browser-based clients may call the server from, or * for any origin. CORS is disabled when empty")
+	_ = viper.BindPFlag("cors_allowed_origins", httpCmd.Flags().Lookup("cors-allowed-origins"))
+	httpCmd.Flags().StringSlice("cors-allowed-methods", nil, "Comma separated methods allowed in CORS requests, defaults to GET, POST and DELETE")
+	_ = viper.BindPFlag("cors_allowed_methods", httpCmd.Flags().Lookup("cors-allowed-methods"))
+	httpCmd.Flags().StringSlice("cors-allowed-headers", nil, "Comma separated request headers allowed in CORS requests, defaults to the headers MCP clients send")
+	_ = viper.BindPFlag("cors_allowed_headers", httpCmd.Flags().Lookup("cors-allowed-headers"))
+	httpCmd.Flags().Int64("max-request-body-size", ghmcp.DefaultMaxRequestBodySize, "Largest request body accepted by the MCP endpoint, in bytes. Larger requests are rejected with 413")
+	_ = viper.BindPFlag("max_request_body_size", httpCmd.Flags().Lookup("max-request-body-size"))
 }
 
 func initConfig() {
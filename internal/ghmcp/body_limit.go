@@ -0,0 +1,52 @@
+package ghmcp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxRequestBodySize is the largest request body the HTTP transports accept by default, 10 MiB.
+// It leaves room for large tool arguments, such as base64 encoded attachments.
+const DefaultMaxRequestBodySize int64 = 10 << 20
+
+// withMaxRequestBodySize rejects requests whose body is larger than limit bytes with 413 Request
+// Entity Too Large, before they reach next. Requests that declare their length are rejected without
+// reading the body; others are read up to the limit. A limit of 0 or less uses DefaultMaxRequestBodySize.
+func withMaxRequestBodySize(next http.Handler, limit int64) http.Handler {
+	if limit <= 0 {
+		limit = DefaultMaxRequestBodySize
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			rejectRequestBody(w, limit)
+			return
+		}
+		if r.ContentLength >= 0 || r.Body == nil || r.Body == http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// The length of chunked bodies is only known once they are read, and the MCP handler does not
+		// report read errors as 413, so read the body here.
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				rejectRequestBody(w, limit)
+				return
+			}
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func rejectRequestBody(w http.ResponseWriter, limit int64) {
+	http.Error(w, fmt.Sprintf("request body too large, the limit is %d bytes", limit), http.StatusRequestEntityTooLarge)
+}
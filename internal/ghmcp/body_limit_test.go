@@ -0,0 +1,91 @@
+package ghmcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxRequestBodySize(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          int64
+		body           string
+		chunked        bool
+		expectedStatus int
+	}{
+		{
+			name:           "passes bodies within the limit on",
+			limit:          16,
+			body:           strings.Repeat("a", 16),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects bodies over the limit",
+			limit:          16,
+			body:           strings.Repeat("a", 17),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "passes chunked bodies within the limit on",
+			limit:          16,
+			body:           strings.Repeat("a", 16),
+			chunked:        true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects chunked bodies over the limit",
+			limit:          16,
+			body:           strings.Repeat("a", 17),
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "uses the default limit when unset",
+			body:           strings.Repeat("a", int(DefaultMaxRequestBodySize)+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var received string
+			handler := withMaxRequestBodySize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				received = string(body)
+				w.WriteHeader(http.StatusOK)
+			}), tc.limit)
+
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			var body io.Reader = strings.NewReader(tc.body)
+			if tc.chunked {
+				// Hiding the reader's length makes the client send the body chunked, without a Content-Length.
+				body = io.MultiReader(body)
+			}
+			req, err := http.NewRequest(http.MethodPost, srv.URL+"/mcp", body)
+			require.NoError(t, err)
+			if tc.chunked {
+				require.Equal(t, int64(0), req.ContentLength)
+			}
+
+			resp, err := srv.Client().Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.body, received)
+			} else {
+				assert.Empty(t, received, "rejected requests must not reach the MCP server")
+			}
+		})
+	}
+}
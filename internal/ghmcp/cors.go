@@ -0,0 +1,98 @@
+package ghmcp
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSConfig configures the CORS headers that let browser-based MCP clients call the server from
+// other origins. CORS is disabled when AllowedOrigins is empty.
+type CORSConfig struct {
+	// AllowedOrigins are the origins, such as https://agent.example.com, that may call the server.
+	// "*" allows any origin.
+	AllowedOrigins []string
+
+	// AllowedMethods are the methods preflight requests may ask for, defaultCORSMethods if empty.
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers preflight requests may ask for, defaultCORSHeaders if empty.
+	AllowedHeaders []string
+}
+
+var (
+	// defaultCORSMethods are the methods the MCP transports use.
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodDelete}
+
+	// defaultCORSHeaders are the request headers MCP clients send.
+	defaultCORSHeaders = []string{"Authorization", "Content-Type", "Accept", "Last-Event-ID", "Mcp-Session-Id", "Mcp-Protocol-Version"}
+
+	// corsExposedHeaders are the response headers browser clients need to read to keep a session.
+	corsExposedHeaders = []string{"Mcp-Session-Id"}
+)
+
+// corsPreflightMaxAge is how long, in seconds, browsers may cache the result of a preflight request.
+const corsPreflightMaxAge = 600
+
+// allowsOrigin reports whether origin may call the server.
+func (c CORSConfig) allowsOrigin(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// withCORS adds the CORS headers of cfg to the responses to requests from allowed origins and
+// answers their preflight requests. Preflight requests from other origins are rejected with 403
+// Forbidden, and their other requests are passed on without CORS headers, so browsers do not
+// expose the responses.
+func withCORS(next http.Handler, cfg CORSConfig) http.Handler {
+	if len(cfg.AllowedOrigins) == 0 {
+		return next
+	}
+
+	methods := cfg.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := cfg.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		isPreflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if !cfg.allowsOrigin(origin) {
+			if isPreflight {
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if !isPreflight {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsPreflightMaxAge))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,122 @@
+package ghmcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCORS(t *testing.T) {
+	allowExample := CORSConfig{AllowedOrigins: []string{"https://agent.example.com/"}}
+
+	tests := []struct {
+		name                 string
+		cfg                  CORSConfig
+		method               string
+		origin               string
+		requestMethod        string
+		expectedStatus       int
+		expectedAllowOrigin  string
+		expectedAllowMethods string
+		expectedAllowHeaders string
+		expectReached        bool
+	}{
+		{
+			name:                "allows configured origins",
+			cfg:                 allowExample,
+			method:              http.MethodPost,
+			origin:              "https://agent.example.com",
+			expectedStatus:      http.StatusOK,
+			expectedAllowOrigin: "https://agent.example.com",
+			expectReached:       true,
+		},
+		{
+			name:           "omits headers for other origins",
+			cfg:            allowExample,
+			method:         http.MethodPost,
+			origin:         "https://evil.example.com",
+			expectedStatus: http.StatusOK,
+			expectReached:  true,
+		},
+		{
+			name:           "passes same-origin requests on",
+			cfg:            allowExample,
+			method:         http.MethodPost,
+			expectedStatus: http.StatusOK,
+			expectReached:  true,
+		},
+		{
+			name:                 "answers preflight requests with the defaults",
+			cfg:                  allowExample,
+			method:               http.MethodOptions,
+			origin:               "https://agent.example.com",
+			requestMethod:        http.MethodPost,
+			expectedStatus:       http.StatusNoContent,
+			expectedAllowOrigin:  "https://agent.example.com",
+			expectedAllowMethods: "GET, POST, DELETE",
+			expectedAllowHeaders: "Authorization, Content-Type, Accept, Last-Event-ID, Mcp-Session-Id, Mcp-Protocol-Version",
+		},
+		{
+			name: "answers preflight requests with the configured methods and headers",
+			cfg: CORSConfig{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"POST"},
+				AllowedHeaders: []string{"Authorization", "Content-Type"},
+			},
+			method:               http.MethodOptions,
+			origin:               "https://anywhere.example.com",
+			requestMethod:        http.MethodPost,
+			expectedStatus:       http.StatusNoContent,
+			expectedAllowOrigin:  "https://anywhere.example.com",
+			expectedAllowMethods: "POST",
+			expectedAllowHeaders: "Authorization, Content-Type",
+		},
+		{
+			name:           "rejects preflight requests from other origins",
+			cfg:            allowExample,
+			method:         http.MethodOptions,
+			origin:         "https://evil.example.com",
+			requestMethod:  http.MethodPost,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "is disabled by default",
+			method:         http.MethodOptions,
+			origin:         "https://agent.example.com",
+			requestMethod:  http.MethodPost,
+			expectedStatus: http.StatusOK,
+			expectReached:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reached := false
+			handler := withCORS(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				reached = true
+				w.WriteHeader(http.StatusOK)
+			}), tc.cfg)
+
+			req := httptest.NewRequest(tc.method, "/mcp", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tc.requestMethod)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectReached, reached)
+			assert.Equal(t, tc.expectedAllowOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tc.expectedAllowMethods, rec.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tc.expectedAllowHeaders, rec.Header().Get("Access-Control-Allow-Headers"))
+			if tc.expectedAllowOrigin != "" && tc.expectReached {
+				assert.Equal(t, "Mcp-Session-Id", rec.Header().Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
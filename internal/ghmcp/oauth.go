@@ -0,0 +1,422 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultOAuthScopes are the scopes the device flow requests, which cover the tools of the server
+// for OAuth apps. GitHub Apps ignore them in favor of the permissions of the app.
+const defaultOAuthScopes = "repo read:org workflow gist notifications project"
+
+// tokenRefreshMargin is how long before it expires a stored token is refreshed, so that it does not
+// expire during a request.
+const tokenRefreshMargin = time.Minute
+
+// errLoginRequired is wrapped by the errors for missing or expired stored credentials.
+var errLoginRequired = errors.New("run `github-mcp-server stdio --auth login` to log in to GitHub")
+
+// errNoStoredToken is returned when no token was stored for a host.
+var errNoStoredToken = errors.New("no GitHub token is stored")
+
+// LoginConfig configures logging in to GitHub with the OAuth device flow.
+type LoginConfig struct {
+	// GitHub Host to log in to (e.g. github.com or github.enterprise.com)
+	Host string
+
+	// ClientID is the client ID of the OAuth or GitHub App to log in with.
+	ClientID string
+
+	// Prompt receives the user code and the URL to enter it at. In stdio mode, stdout carries the
+	// MCP protocol, so this is stderr.
+	Prompt io.Writer
+}
+
+// storedCredentials are the credentials the device flow obtained for a host. Zero expiry times
+// are for tokens that do not expire.
+type storedCredentials struct {
+	ClientID              string    `json:"client_id"`
+	AccessToken           string    `json:"access_token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// oauthError is the error GitHub returns, with a 200 status, from the OAuth endpoints.
+type oauthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (e oauthError) err() error {
+	if e.ErrorDescription != "" {
+		return fmt.Errorf("%s: %s", e.Error, e.ErrorDescription)
+	}
+	return errors.New(e.Error)
+}
+
+type deviceCodeResponse struct {
+	oauthError
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+type accessTokenResponse struct {
+	oauthError
+	AccessToken           string `json:"access_token"`
+	ExpiresIn             int    `json:"expires_in"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresIn int    `json:"refresh_token_expires_in"`
+	// Interval is the new polling interval of slow_down errors.
+	Interval int `json:"interval"`
+}
+
+// oauthClient calls the OAuth endpoints of a GitHub host for an app.
+type oauthClient struct {
+	baseURL    *url.URL
+	clientID   string
+	httpClient *http.Client
+}
+
+// post posts form to the OAuth endpoint at path and decodes its JSON response into v.
+func (c *oauthClient) post(ctx context.Context, path string, form url.Values, v any) error {
+	form.Set("client_id", c.clientID)
+	endpoint := c.baseURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		// The body is not included, as it could contain credentials
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// requestDeviceCode starts the device flow.
+func (c *oauthClient) requestDeviceCode(ctx context.Context, scope string) (deviceCodeResponse, error) {
+	var code deviceCodeResponse
+	if err := c.post(ctx, "login/device/code", url.Values{"scope": {scope}}, &code); err != nil {
+		return deviceCodeResponse{}, err
+	}
+	if code.Error != "" {
+		return deviceCodeResponse{}, fmt.Errorf("failed to start the device flow: %w", code.err())
+	}
+	return code, nil
+}
+
+// pollAccessToken polls for the token of code until the user authorizes or denies it, or the code expires.
+func (c *oauthClient) pollAccessToken(ctx context.Context, code deviceCodeResponse) (storedCredentials, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	var deadline time.Time
+	if code.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return storedCredentials{}, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var token accessTokenResponse
+		form := url.Values{
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		}
+		if err := c.post(ctx, "login/oauth/access_token", form, &token); err != nil {
+			return storedCredentials{}, err
+		}
+
+		switch token.Error {
+		case "":
+			return c.credentials(token)
+		case "authorization_pending":
+		case "slow_down":
+			if token.Interval > 0 {
+				interval = time.Duration(token.Interval) * time.Second
+			} else {
+				interval += 5 * time.Second
+			}
+		case "expired_token":
+			return storedCredentials{}, errors.New("the code expired before it was entered, log in again")
+		case "access_denied":
+			return storedCredentials{}, errors.New("the login was cancelled")
+		default:
+			return storedCredentials{}, fmt.Errorf("failed to get an access token: %w", token.err())
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return storedCredentials{}, errors.New("the code expired before it was entered, log in again")
+		}
+	}
+}
+
+// refresh exchanges refreshToken for a new access token. The refresh token can only be used once.
+func (c *oauthClient) refresh(ctx context.Context, refreshToken string) (storedCredentials, error) {
+	var token accessTokenResponse
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+	if err := c.post(ctx, "login/oauth/access_token", form, &token); err != nil {
+		return storedCredentials{}, err
+	}
+	if token.Error != "" {
+		return storedCredentials{}, token.err()
+	}
+	return c.credentials(token)
+}
+
+func (c *oauthClient) credentials(token accessTokenResponse) (storedCredentials, error) {
+	if token.AccessToken == "" {
+		return storedCredentials{}, errors.New("the response did not contain an access token")
+	}
+	now := time.Now()
+	creds := storedCredentials{
+		ClientID:     c.clientID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	}
+	if token.ExpiresIn > 0 {
+		creds.ExpiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.RefreshTokenExpiresIn > 0 {
+		creds.RefreshTokenExpiresAt = now.Add(time.Duration(token.RefreshTokenExpiresIn) * time.Second)
+	}
+	return creds, nil
+}
+
+// credentialsFile is the format of the file credentials are stored in, keyed by host.
+type credentialsFile struct {
+	Hosts map[string]storedCredentials `json:"hosts"`
+}
+
+// credentialStore stores credentials in a file only the user can read.
+type credentialStore struct {
+	path string
+}
+
+// defaultCredentialStore returns the store in the config directory of the user, such as
+// ~/.config/github-mcp-server/credentials.json on Linux.
+func defaultCredentialStore() (*credentialStore, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return &credentialStore{path: filepath.Join(dir, "github-mcp-server", "credentials.json")}, nil
+}
+
+func (s *credentialStore) read() (credentialsFile, error) {
+	file := credentialsFile{Hosts: map[string]storedCredentials{}}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return credentialsFile{}, fmt.Errorf("failed to read stored credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return credentialsFile{}, fmt.Errorf("failed to parse stored credentials in %s: %w", s.path, err)
+	}
+	if file.Hosts == nil {
+		file.Hosts = map[string]storedCredentials{}
+	}
+	return file, nil
+}
+
+// load returns the credentials stored for host, if any.
+func (s *credentialStore) load(host string) (storedCredentials, bool, error) {
+	file, err := s.read()
+	if err != nil {
+		return storedCredentials{}, false, err
+	}
+	creds, ok := file.Hosts[host]
+	return creds, ok, nil
+}
+
+// save stores the credentials of host, keeping those of other hosts. The file is replaced
+// atomically and is only readable by the user.
+func (s *credentialStore) save(host string, creds storedCredentials) error {
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	file.Hosts[host] = creds
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	// CreateTemp creates the file with mode 0600
+	tmp, err := os.CreateTemp(dir, "credentials-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to store credentials: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to store credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to store credentials: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to store credentials: %w", err)
+	}
+	return nil
+}
+
+// Login logs in to GitHub with the OAuth device flow and stores the token for later runs of the
+// stdio server, which use it when no personal access token is set.
+func Login(cfg LoginConfig) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.ClientID == "" {
+		return errors.New("no OAuth client ID to log in with, set --oauth-client-id or GITHUB_OAUTH_CLIENT_ID")
+	}
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to parse API host: %w", err)
+	}
+	store, err := defaultCredentialStore()
+	if err != nil {
+		return err
+	}
+
+	client := &oauthClient{baseURL: apiHost.oauthURL, clientID: cfg.ClientID, httpClient: http.DefaultClient}
+	return login(ctx, client, store, apiHost.oauthURL.Host, cfg.Prompt)
+}
+
+func login(ctx context.Context, client *oauthClient, store *credentialStore, host string, prompt io.Writer) error {
+	code, err := client.requestDeviceCode(ctx, defaultOAuthScopes)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(prompt, "First copy your one-time code: %s\nThen open %s in your browser to authorize the GitHub MCP Server.\n", code.UserCode, code.VerificationURI)
+
+	creds, err := client.pollAccessToken(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to log in to %s: %w", host, err)
+	}
+	if err := store.save(host, creds); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(prompt, "Logged in to %s. The token is stored in %s.\n", host, store.path)
+	return nil
+}
+
+// storedTokenSource provides the stored token of a host, refreshing it when it is about to expire.
+type storedTokenSource struct {
+	mu         sync.Mutex
+	host       string
+	store      *credentialStore
+	baseURL    *url.URL
+	httpClient *http.Client
+	creds      storedCredentials
+}
+
+// loadStoredTokenSource returns the source of the token stored for the host of the API at host.
+func loadStoredTokenSource(host string) (*storedTokenSource, error) {
+	apiHost, err := parseAPIHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+	store, err := defaultCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+	return newStoredTokenSource(store, apiHost.oauthURL, http.DefaultClient)
+}
+
+func newStoredTokenSource(store *credentialStore, baseURL *url.URL, httpClient *http.Client) (*storedTokenSource, error) {
+	creds, ok, err := store.load(baseURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w for %s, %w", errNoStoredToken, baseURL.Host, errLoginRequired)
+	}
+	return &storedTokenSource{
+		host:       baseURL.Host,
+		store:      store,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		creds:      creds,
+	}, nil
+}
+
+// Token returns the stored token, first refreshing it if it expires within tokenRefreshMargin.
+func (s *storedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.creds.ExpiresAt.IsZero() || s.creds.ExpiresAt.Sub(now) > tokenRefreshMargin {
+		return s.creds.AccessToken, nil
+	}
+	if s.creds.RefreshToken == "" || (!s.creds.RefreshTokenExpiresAt.IsZero() && now.After(s.creds.RefreshTokenExpiresAt)) {
+		return "", fmt.Errorf("the GitHub token stored for %s has expired, %w", s.host, errLoginRequired)
+	}
+
+	// The app that issued the token refreshes it
+	client := &oauthClient{baseURL: s.baseURL, clientID: s.creds.ClientID, httpClient: s.httpClient}
+	creds, err := client.refresh(ctx, s.creds.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh the GitHub token stored for %s, %w: %w", s.host, errLoginRequired, err)
+	}
+	if err := s.store.save(s.host, creds); err != nil {
+		return "", err
+	}
+	s.creds = creds
+	return creds.AccessToken, nil
+}
+
+// tokenSourceTransport authenticates requests with the token source returns for them.
+type tokenSourceTransport struct {
+	transport http.RoundTripper
+	source    func(context.Context) (string, error)
+}
+
+func (t *tokenSourceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.transport.RoundTrip(req)
+}
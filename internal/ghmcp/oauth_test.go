@@ -0,0 +1,216 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOAuthServer starts a server for the OAuth endpoints of GitHub that answers token requests with
+// tokenResponses in turn. It returns the URL of the server and the forms of the token requests.
+func newOAuthServer(t *testing.T, tokenResponses ...map[string]any) (*url.URL, *[]url.Values) {
+	t.Helper()
+
+	var tokenRequests []url.Values
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/login/device/code":
+			assert.Equal(t, defaultOAuthScopes, r.PostForm.Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "device-code",
+				"user_code":        "ABCD-1234",
+				"verification_uri": "https://github.com/login/device",
+				"expires_in":       900,
+				"interval":         0,
+			})
+		case "/login/oauth/access_token":
+			tokenRequests = append(tokenRequests, r.PostForm)
+			i := int(atomic.AddInt32(&calls, 1)) - 1
+			require.Less(t, i, len(tokenResponses), "unexpected token request")
+			_ = json.NewEncoder(w).Encode(tokenResponses[i])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	return u, &tokenRequests
+}
+
+func TestLogin(t *testing.T) {
+	t.Run("stores the token once the code is entered", func(t *testing.T) {
+		baseURL, tokenRequests := newOAuthServer(t,
+			map[string]any{"error": "authorization_pending"},
+			map[string]any{
+				"access_token":             "ghu_access",
+				"expires_in":               28800,
+				"refresh_token":            "ghr_refresh",
+				"refresh_token_expires_in": 15897600,
+			},
+		)
+		store := &credentialStore{path: filepath.Join(t.TempDir(), "github-mcp-server", "credentials.json")}
+		client := &oauthClient{baseURL: baseURL, clientID: "client-id", httpClient: http.DefaultClient}
+
+		var prompt bytes.Buffer
+		require.NoError(t, login(context.Background(), client, store, "github.com", &prompt))
+
+		assert.Contains(t, prompt.String(), "ABCD-1234")
+		assert.Contains(t, prompt.String(), "https://github.com/login/device")
+		assert.NotContains(t, prompt.String(), "ghu_access")
+
+		require.Len(t, *tokenRequests, 2)
+		assert.Equal(t, "device-code", (*tokenRequests)[0].Get("device_code"))
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", (*tokenRequests)[0].Get("grant_type"))
+
+		creds, ok, err := store.load("github.com")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "client-id", creds.ClientID)
+		assert.Equal(t, "ghu_access", creds.AccessToken)
+		assert.Equal(t, "ghr_refresh", creds.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(8*time.Hour), creds.ExpiresAt, time.Minute)
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(store.path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("fails when the login is denied", func(t *testing.T) {
+		baseURL, _ := newOAuthServer(t, map[string]any{"error": "access_denied"})
+		store := &credentialStore{path: filepath.Join(t.TempDir(), "credentials.json")}
+		client := &oauthClient{baseURL: baseURL, clientID: "client-id", httpClient: http.DefaultClient}
+
+		err := login(context.Background(), client, store, "github.com", &bytes.Buffer{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the login was cancelled")
+		_, statErr := os.Stat(store.path)
+		assert.True(t, os.IsNotExist(statErr))
+	})
+}
+
+func TestStoredTokenSource(t *testing.T) {
+	newStore := func(t *testing.T, host string, creds storedCredentials) *credentialStore {
+		store := &credentialStore{path: filepath.Join(t.TempDir(), "credentials.json")}
+		require.NoError(t, store.save(host, creds))
+		// Credentials of other hosts are kept
+		require.NoError(t, store.save("ghe.example.com", storedCredentials{AccessToken: "other"}))
+		return store
+	}
+
+	t.Run("returns tokens that do not expire", func(t *testing.T) {
+		baseURL, tokenRequests := newOAuthServer(t)
+		store := newStore(t, baseURL.Host, storedCredentials{ClientID: "client-id", AccessToken: "gho_access"})
+
+		source, err := newStoredTokenSource(store, baseURL, http.DefaultClient)
+		require.NoError(t, err)
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "gho_access", token)
+		assert.Empty(t, *tokenRequests)
+	})
+
+	t.Run("refreshes tokens about to expire", func(t *testing.T) {
+		baseURL, tokenRequests := newOAuthServer(t, map[string]any{
+			"access_token":             "ghu_new",
+			"expires_in":               28800,
+			"refresh_token":            "ghr_new",
+			"refresh_token_expires_in": 15897600,
+		})
+		store := newStore(t, baseURL.Host, storedCredentials{
+			ClientID:              "client-id",
+			AccessToken:           "ghu_old",
+			ExpiresAt:             time.Now().Add(30 * time.Second),
+			RefreshToken:          "ghr_old",
+			RefreshTokenExpiresAt: time.Now().Add(24 * time.Hour),
+		})
+
+		source, err := newStoredTokenSource(store, baseURL, http.DefaultClient)
+		require.NoError(t, err)
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "ghu_new", token)
+
+		require.Len(t, *tokenRequests, 1)
+		assert.Equal(t, "refresh_token", (*tokenRequests)[0].Get("grant_type"))
+		assert.Equal(t, "ghr_old", (*tokenRequests)[0].Get("refresh_token"))
+
+		// The new token is stored and used without refreshing again
+		creds, ok, err := store.load(baseURL.Host)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "ghu_new", creds.AccessToken)
+		assert.Equal(t, "ghr_new", creds.RefreshToken)
+		other, ok, err := store.load("ghe.example.com")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "other", other.AccessToken)
+
+		token, err = source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "ghu_new", token)
+		assert.Len(t, *tokenRequests, 1)
+	})
+
+	t.Run("asks to log in again when the refresh token expired", func(t *testing.T) {
+		baseURL, tokenRequests := newOAuthServer(t)
+		store := newStore(t, baseURL.Host, storedCredentials{
+			ClientID:              "client-id",
+			AccessToken:           "ghu_old",
+			ExpiresAt:             time.Now().Add(-time.Hour),
+			RefreshToken:          "ghr_old",
+			RefreshTokenExpiresAt: time.Now().Add(-time.Minute),
+		})
+
+		source, err := newStoredTokenSource(store, baseURL, http.DefaultClient)
+		require.NoError(t, err)
+		_, err = source.Token(context.Background())
+		require.ErrorIs(t, err, errLoginRequired)
+		assert.Empty(t, *tokenRequests)
+	})
+
+	t.Run("asks to log in when no token is stored", func(t *testing.T) {
+		baseURL, _ := newOAuthServer(t)
+		store := &credentialStore{path: filepath.Join(t.TempDir(), "credentials.json")}
+
+		_, err := newStoredTokenSource(store, baseURL, http.DefaultClient)
+		require.ErrorIs(t, err, errLoginRequired)
+	})
+}
+
+func TestTokenSourceTransport(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &tokenSourceTransport{
+		transport: http.DefaultTransport,
+		source:    func(context.Context) (string, error) { return "ghu_token", nil },
+	}}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "Bearer ghu_token", authorization)
+}
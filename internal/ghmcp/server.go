@@ -2,14 +2,21 @@ package ghmcp
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -18,11 +25,15 @@ import (
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/scim"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhook"
 	gogithub "github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
 )
 
 type MCPServerConfig struct {
@@ -35,6 +46,14 @@ type MCPServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// TokenSource, if set, provides the token of each request instead of Token, so that tokens that
+	// expire can be refreshed.
+	TokenSource func(ctx context.Context) (string, error)
+
+	// SessionTokens lets clients supply the token of their session in the experimental githubToken
+	// capability of the initialize request. It takes precedence over Token and TokenSource.
+	SessionTokens bool
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -46,21 +65,151 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// AssetsRepository is the repository, in the form owner/repo, uploaded assets are committed to.
+	// If empty, assets are committed to the repository they are uploaded for.
+	AssetsRepository string
+
+	// AssetsBranch is the branch uploaded assets are committed to
+	AssetsBranch string
+
+	// DefaultOwner and DefaultRepo are the owner and repository tools use when they are called
+	// without them. Their owner and repo parameters are then optional. DefaultRepo requires DefaultOwner.
+	DefaultOwner string
+	DefaultRepo  string
+
+	// RequirePerRequestToken makes tool calls fail when no token was provided with the request,
+	// instead of falling back to Token. Used in HTTP mode so that callers cannot act with the server's token.
+	RequirePerRequestToken bool
+
+	// AdminToken enables the admin tools that enable and disable toolsets at runtime. Callers must
+	// pass it to them. If empty, the admin tools are not registered.
+	AdminToken string
+
+	// SkipTokenProbe stops get_me from probing the repository permissions of fine-grained personal
+	// access tokens.
+	SkipTokenProbe bool
+
+	// BatchConcurrency is the number of calls batch_tool_calls runs at once. If 0 or less,
+	// github.DefaultBatchConcurrency is used.
+	BatchConcurrency int
+
+	// SecondaryRateLimitMaxWait is the longest GitHub may ask to wait after a secondary rate limit
+	// for the request to be retried. If 0, secondary rate limits are reported to the caller instead.
+	SecondaryRateLimitMaxWait time.Duration
+
+	// MaxRequestsPerSession is the number of GitHub API requests each MCP session may make. Requests
+	// over the budget fail without being sent. If 0, it is not limited.
+	MaxRequestsPerSession int
+
+	// MaxRequestsPerMinute is the number of GitHub API requests each MCP session may make in any
+	// minute. If 0, it is not limited.
+	MaxRequestsPerMinute int
+
+	// UserAgentSuffix is appended to the User-Agent of the requests made to GitHub, to identify the
+	// platform the server runs on.
+	UserAgentSuffix string
+
+	// APIVersion is sent in the X-GitHub-Api-Version header of REST API requests, to pin the
+	// behavior of the API. If empty, the header is not sent and GitHub's default version is used.
+	APIVersion string
+
+	// GraphQLCacheTTL is how long the responses to GraphQL queries are kept, so that asking the same
+	// again costs no rate limit. Changes made through the server drop the responses they may affect.
+	// If 0, and GraphQLCacheQueryTTLs sets no TTL either, GraphQL queries are not cached.
+	GraphQLCacheTTL time.Duration
+
+	// GraphQLCacheQueryTTLs sets the TTL of the queries whose first field is a key, such as search,
+	// instead of GraphQLCacheTTL. A TTL of 0 stops those queries from being cached.
+	GraphQLCacheQueryTTLs map[string]time.Duration
+
+	// WebhookSubscriptions, when set, registers the subscribe_to_repo_events tool, with which sessions
+	// subscribe to the webhook events received for a repository. It is only set by the HTTP transports.
+	WebhookSubscriptions *webhook.Subscriptions
+
+	// ToolStats, when set, records how often each tool is called, fails and how long it takes.
+	ToolStats *github.ToolStats
+
+	// ToolCatalog, when set, is given the toolsets of the server, so that the tools it offers can be
+	// exported along with their schemas.
+	ToolCatalog *github.ToolCatalog
+
+	// Transport, when set, sends the requests to GitHub instead of http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// CheckTokenScopes asks GitHub for the scopes of Token when the server is created, and logs a
+	// warning to Logger if tools that write are offered but the token has no scopes that allow writing.
+	CheckTokenScopes bool
+
+	// Logger receives warnings about the configuration. If nil, they are discarded.
+	Logger *slog.Logger
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 }
 
+// tokenScopeCheckTimeout bounds the request CheckTokenScopes makes, so that an unreachable GitHub
+// does not hold up startup.
+const tokenScopeCheckTimeout = 10 * time.Second
+
 const stdioServerLogPrefix = "stdioserver"
 
+// DefaultAPIVersion is the current stable version of the GitHub REST API.
+const DefaultAPIVersion = "2022-11-28"
+
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	// Both clients record the rate limits GitHub reports for each resource category, so that the
+	// REST and GraphQL pools can be told apart, and wait out short secondary rate limits if allowed.
+	// Requests over the budget of their session are refused before they use any of the rate limit,
+	// and commits and blobs fetched by SHA again are served from a cache without counting against it.
+	baseTransport := cfg.Transport
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+	var transport http.RoundTripper = &github.RateLimitTrackingTransport{
+		Transport: &github.SecondaryRateLimitTransport{
+			Transport: baseTransport,
+			MaxWait:   cfg.SecondaryRateLimitMaxWait,
+		},
+	}
+	requestBudget := github.NewRequestBudget(cfg.MaxRequestsPerSession, cfg.MaxRequestsPerMinute)
+	if requestBudget != nil {
+		transport = &github.RequestBudgetTransport{Transport: transport, Budget: requestBudget}
+	}
+	transport = &github.ImmutableCacheTransport{
+		Transport: transport,
+		Cache:     github.NewImmutableCache(github.DefaultImmutableCacheEntries),
+		RawURL:    apiHost.rawURL,
+	}
+	// GraphQL queries asked again shortly are answered from a cache, if enabled
+	graphQLCache := github.NewGraphQLCache(cfg.GraphQLCacheTTL, cfg.GraphQLCacheQueryTTLs, github.DefaultGraphQLCacheEntries)
+	if graphQLCache != nil {
+		transport = &github.GraphQLCacheTransport{Transport: transport, Cache: graphQLCache, GraphQLURL: apiHost.graphqlURL}
+	}
+
+	// userAgent appends the configured suffix, if any, to a User-Agent
+	userAgent := func(agent string) string {
+		if cfg.UserAgentSuffix != "" {
+			agent += " " + cfg.UserAgentSuffix
+		}
+		return agent
+	}
+	// The User-Agent is set by the transport that all clients share, including those made for the
+	// token of a request, to the one of the session the request is made for.
+	agents := newUserAgents(userAgent(fmt.Sprintf("github-mcp-server/%s", cfg.Version)))
+	transport = &userAgentTransport{transport: transport, agents: agents}
+	// Only the REST API is versioned
+	restTransport := &apiVersionTransport{transport: transport, version: cfg.APIVersion}
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
-	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
+	restClient := gogithub.NewClient(&http.Client{Transport: restTransport}).WithAuthToken(cfg.Token)
+	if cfg.TokenSource != nil {
+		restClient = gogithub.NewClient(&http.Client{Transport: &tokenSourceTransport{transport: restTransport, source: cfg.TokenSource}})
+	}
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
 
@@ -69,31 +218,35 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
+			transport: transport,
 			token:     cfg.Token,
 		},
-	} // We're going to wrap the Transport later in beforeInit
+	}
+	if cfg.TokenSource != nil {
+		gqlHTTPClient.Transport = &tokenSourceTransport{transport: transport, source: cfg.TokenSource}
+	}
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
 
-	// When a client send an initialize request, update the user agent to include the client info.
-	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
-		userAgent := fmt.Sprintf(
+	// When a client send an initialize request, update the user agent of its session to include the
+	// client info.
+	beforeInit := func(ctx context.Context, _ any, message *mcp.InitializeRequest) {
+		agents.set(ctx, userAgent(fmt.Sprintf(
 			"github-mcp-server/%s (%s/%s)",
 			cfg.Version,
 			message.Params.ClientInfo.Name,
 			message.Params.ClientInfo.Version,
-		)
-
-		restClient.UserAgent = userAgent
+		)))
+	}
 
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
-		}
+	var sessionTokens *sessionTokens
+	initHooks := []server.OnBeforeInitializeFunc{beforeInit}
+	if cfg.SessionTokens {
+		sessionTokens = newSessionTokens()
+		initHooks = append(initHooks, sessionTokens.beforeInitialize)
 	}
 
 	hooks := &server.Hooks{
-		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnBeforeInitialize: initHooks,
 		OnBeforeAny: []server.BeforeAnyHookFunc{
 			func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
 				// Ensure the context is cleared of any previous errors
@@ -102,6 +255,22 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 			},
 		},
 	}
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		agents.forget(session.SessionID())
+	})
+	if requestBudget != nil {
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			requestBudget.Forget(session.SessionID())
+		})
+	}
+	if graphQLCache != nil {
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			graphQLCache.Forget(session.SessionID())
+		})
+	}
+	if cfg.ToolStats != nil {
+		cfg.ToolStats.AddHooks(hooks)
+	}
 
 	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
 
@@ -116,38 +285,40 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
+	// requestToken returns the token of the request or of its session, if any, which is used
+	// instead of the token of the server.
+	requestToken := func(ctx context.Context) (string, bool) {
+		if token, ok := tokenFromContext(ctx); ok {
+			return token, true
+		}
+		return sessionTokens.fromContext(ctx)
+	}
+
 	getClient := func(ctx context.Context) (*gogithub.Client, error) {
-		if tokenVal := ctx.Value(githubTokenKey{}); tokenVal != nil {
-			if token, ok := tokenVal.(string); ok && token != "" {
-				client := gogithub.NewClient(nil).WithAuthToken(token)
-				client.UserAgent = restClient.UserAgent
-				client.BaseURL = apiHost.baseRESTURL
-				client.UploadURL = apiHost.uploadURL
-				return client, nil
-			}
+		if token, ok := requestToken(ctx); ok {
+			client := gogithub.NewClient(&http.Client{Transport: restTransport}).WithAuthToken(token)
+			client.BaseURL = apiHost.baseRESTURL
+			client.UploadURL = apiHost.uploadURL
+			return client, nil
+		}
+		if cfg.RequirePerRequestToken {
+			return nil, errMissingRequestToken
 		}
 		return restClient, nil
 	}
 
 	getGQLClient := func(ctx context.Context) (*githubv4.Client, error) {
-		if tokenVal := ctx.Value(githubTokenKey{}); tokenVal != nil {
-			if token, ok := tokenVal.(string); ok && token != "" {
-				httpClient := &http.Client{
-					Transport: &bearerAuthTransport{
-						transport: http.DefaultTransport,
-						token:     token,
-					},
-				}
-				if gqlHTTPClient.Transport != nil {
-					if uaTransport, ok := gqlHTTPClient.Transport.(*userAgentTransport); ok {
-						httpClient.Transport = &userAgentTransport{
-							transport: httpClient.Transport,
-							agent:     uaTransport.agent,
-						}
-					}
-				}
-				return githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), httpClient), nil
+		if token, ok := requestToken(ctx); ok {
+			httpClient := &http.Client{
+				Transport: &bearerAuthTransport{
+					transport: transport,
+					token:     token,
+				},
 			}
+			return githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), httpClient), nil
+		}
+		if cfg.RequirePerRequestToken {
+			return nil, errMissingRequestToken
 		}
 		return gqlClient, nil
 	}
@@ -160,18 +331,150 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
 	}
 
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator)
+	getSCIMClient := func(ctx context.Context) (*scim.Client, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		return scim.NewClient(client, apiHost.scimURL), nil // closing over client
+	}
+
+	assets, err := github.ParseAssetsConfig(cfg.AssetsRepository, cfg.AssetsBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse assets configuration: %w", err)
+	}
+
+	repoDefaults, err := github.NewRepositoryDefaults(cfg.DefaultOwner, cfg.DefaultRepo)
+	if err != nil {
+		return nil, err
+	}
+	repoDefaults.AddHooks(hooks)
+
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, getSCIMClient, cfg.Translator, assets, github.TokenPermissionsConfig{SkipProbe: cfg.SkipTokenProbe}, requestBudget, graphQLCache, repoDefaults)
+	if apiHost.kind != hostKindGHES {
+		// The site administrator APIs only exist on GitHub Enterprise Server
+		delete(tsg.Toolsets, github.EnterpriseToolsetName)
+		if slices.Contains(enabledToolsets, github.EnterpriseToolsetName) {
+			if cfg.Logger != nil {
+				cfg.Logger.Warn("the enterprise toolset is only available on GitHub Enterprise Server hosts, ignoring it")
+			}
+			enabledToolsets = slices.DeleteFunc(slices.Clone(enabledToolsets), func(name string) bool {
+				return name == github.EnterpriseToolsetName
+			})
+		}
+	}
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
 
+	if cfg.CheckTokenScopes && !cfg.ReadOnly && cfg.Token != "" {
+		logger := cfg.Logger
+		if logger == nil {
+			logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), tokenScopeCheckTimeout)
+		lacksWriteScopes, err := tokenLacksWriteScopes(ctx, restClient)
+		cancel()
+		switch {
+		case err != nil:
+			logger.Debug("could not check the scopes of the token", "error", err)
+		case lacksWriteScopes:
+			logger.Warn("the token has no scopes that allow writing, so tools that write will fail; use --read-only to only offer read-only tools")
+		}
+	}
+
+	for _, toolset := range tsg.Toolsets {
+		repoDefaults.Apply(toolset)
+	}
 	tsg.RegisterAll(ghServer)
 
+	// catalogToolsets are the toolsets whose tools the server offers, once enabled
+	catalogToolsets := make([]*toolsets.Toolset, 0, len(tsg.Toolsets)+5)
+	for _, toolset := range tsg.Toolsets {
+		catalogToolsets = append(catalogToolsets, toolset)
+	}
+
+	// Tell clients which toolsets are enabled when they initialize, so that they can show them without
+	// listing the tools. It is computed on each initialize as toolsets can be enabled at runtime.
+	hooks.AddAfterInitialize(func(_ context.Context, _ any, _ *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		if result.Capabilities.Experimental == nil {
+			result.Capabilities.Experimental = map[string]any{}
+		}
+		result.Capabilities.Experimental["toolsets"] = tsg.EnabledToolsetsInfo()
+	})
+
 	if cfg.DynamicToolsets {
 		dynamic := github.InitDynamicToolset(ghServer, tsg, cfg.Translator)
+		tsg.WrapTools(dynamic)
 		dynamic.RegisterTools(ghServer)
+		catalogToolsets = append(catalogToolsets, dynamic)
+	}
+
+	// The toolsets below are not in the group, but their arguments are validated like those of the
+	// group's tools
+	batch := github.InitBatchToolset(ghServer, cfg.BatchConcurrency, cfg.ReadOnly, cfg.Translator)
+	tsg.WrapTools(batch)
+	batch.RegisterTools(ghServer)
+	catalogToolsets = append(catalogToolsets, batch)
+
+	// Watches poll for the sessions that cannot receive webhooks, such as local stdio clients
+	watches := github.NewWatches(ghServer)
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		watches.Forget(session.SessionID())
+	})
+	watch := github.InitWatchToolset(watches, getClient, cfg.Translator)
+	repoDefaults.Apply(watch)
+	tsg.WrapTools(watch)
+	watch.RegisterTools(ghServer)
+	catalogToolsets = append(catalogToolsets, watch)
+
+	if cfg.AdminToken != "" {
+		admin := github.InitAdminToolset(ghServer, tsg, cfg.AdminToken, cfg.Translator)
+		tsg.WrapTools(admin)
+		admin.RegisterTools(ghServer)
+		catalogToolsets = append(catalogToolsets, admin)
+	}
+
+	if cfg.WebhookSubscriptions != nil {
+		cfg.WebhookSubscriptions.Attach(ghServer)
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			cfg.WebhookSubscriptions.Forget(session.SessionID())
+		})
+
+		// Subscriptions are kept with the token that was checked to read their repositories, and
+		// dropped when the session goes on with another token
+		getTokenID := func(ctx context.Context) string {
+			token, ok := requestToken(ctx)
+			if !ok {
+				return ""
+			}
+			sum := sha256.Sum256([]byte(token))
+			return hex.EncodeToString(sum[:])
+		}
+		checkToken := func(ctx context.Context) {
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				cfg.WebhookSubscriptions.CheckToken(session.SessionID(), getTokenID(ctx))
+			}
+		}
+		hooks.AddBeforeAny(func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
+			checkToken(ctx)
+		})
+		// A session token is replaced when initializing, after the hooks before any request
+		hooks.AddAfterInitialize(func(ctx context.Context, _ any, _ *mcp.InitializeRequest, _ *mcp.InitializeResult) {
+			checkToken(ctx)
+		})
+
+		webhooks := github.InitWebhookToolset(getClient, getTokenID, cfg.WebhookSubscriptions, cfg.Translator)
+		repoDefaults.Apply(webhooks)
+		tsg.WrapTools(webhooks)
+		webhooks.RegisterTools(ghServer)
+		catalogToolsets = append(catalogToolsets, webhooks)
+	}
+
+	if cfg.ToolCatalog != nil {
+		cfg.ToolCatalog.Add(tsg, catalogToolsets...)
 	}
 
 	return ghServer, nil
@@ -179,6 +482,15 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 
 type githubTokenKey struct{}
 
+// errMissingRequestToken is returned for tool calls without a token when a token is required with every request.
+var errMissingRequestToken = fmt.Errorf("no GitHub token was provided with the request, set the Authorization header to \"Bearer <token>\"")
+
+// tokenFromContext returns the GitHub token extracted from the request, if any.
+func tokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(githubTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
 type HTTPServerConfig struct {
 	Version              string
 	Host                 string
@@ -190,6 +502,98 @@ type HTTPServerConfig struct {
 	EnableCommandLogging bool
 	LogFilePath          string
 	Port                 int
+	BindAddress          string
+	AssetsRepository     string
+	AssetsBranch         string
+	DefaultOwner         string
+	DefaultRepo          string
+	WebhookSecret        string
+
+	// WebhookEvents are the webhook event types forwarded to subscribed sessions. If empty, all
+	// supported event types are.
+	WebhookEvents []string
+
+	// OnListen, when set, is called with the address the server listens on once it is bound, such
+	// as to learn the port the system picked when Port is 0.
+	OnListen func(addr net.Addr)
+
+	// ExportToolsPath, when set, makes the server write the catalog of the tools it offers to this
+	// path as JSON and exit instead of serving. The catalog is also served at /tools.json.
+	ExportToolsPath string
+
+	// ToolStats records the usage of each tool, serves it at /debug/toolstats and logs a summary on
+	// shutdown. Nothing is sent anywhere else.
+	ToolStats bool
+
+	// ToolStatsPath, when set, is the path the usage of each tool is written to as JSON on shutdown.
+	// It implies ToolStats.
+	ToolStatsPath string
+
+	// RequirePerRequestToken rejects tool calls without a token in the Authorization header,
+	// instead of falling back to Token.
+	RequirePerRequestToken bool
+
+	// AdminToken enables the admin tools that enable and disable toolsets at runtime.
+	AdminToken string
+
+	// SkipTokenProbe stops get_me from probing the permissions of fine-grained personal access tokens.
+	SkipTokenProbe bool
+
+	// BatchConcurrency is the number of calls batch_tool_calls runs at once.
+	BatchConcurrency int
+
+	// SecondaryRateLimitMaxWait is the longest secondary rate limit wait requests are retried after.
+	SecondaryRateLimitMaxWait time.Duration
+
+	// MaxRequestsPerSession is the number of GitHub API requests each MCP session may make.
+	MaxRequestsPerSession int
+
+	// MaxRequestsPerMinute is the number of GitHub API requests each MCP session may make per minute.
+	MaxRequestsPerMinute int
+
+	// UserAgentSuffix is appended to the User-Agent of the requests made to GitHub.
+	UserAgentSuffix string
+
+	// APIVersion is the REST API version requests are pinned to. If empty, GitHub's default is used.
+	APIVersion string
+
+	// GraphQLCacheTTL is how long the responses to GraphQL queries are kept. If 0, they are not cached.
+	GraphQLCacheTTL time.Duration
+
+	// GraphQLCacheQueryTTLs sets the TTL of the queries whose first field is a key.
+	GraphQLCacheQueryTTLs map[string]time.Duration
+
+	// TLSCertFile and TLSKeyFile are the PEM certificate and key to serve HTTPS with.
+	// If both are empty, the server serves plain HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// TLSClientCACert is the path to a PEM CA certificate that client certificates are verified
+	// against. When set, clients must present a certificate signed by it unless TLSClientAuthMode
+	// says otherwise.
+	TLSClientCACert string
+
+	// TLSClientAuthMode is how client certificates are requested and verified, tls.NoClientCert by default.
+	TLSClientAuthMode tls.ClientAuthType
+
+	// TLSLogClientCertificates logs the subject of the client certificate of each request.
+	TLSLogClientCertificates bool
+
+	// TokenValidation is how strictly tokens in the Authorization header are checked before
+	// requests reach the MCP server. Malformed tokens are rejected with 401 Unauthorized.
+	TokenValidation TokenValidation
+
+	// AllowTokenQueryParam accepts the token in the token or bearer_token query parameter from
+	// requests without an Authorization header, for clients that cannot set headers. Query
+	// parameters can end up in access logs, so it is disabled by default.
+	AllowTokenQueryParam bool
+
+	// CORS configures the CORS headers for browser-based clients. CORS is disabled by default.
+	CORS CORSConfig
+
+	// MaxRequestBodySize is the largest request body accepted by the MCP endpoint, in bytes. Larger
+	// requests are rejected with 413 Request Entity Too Large. 0 uses DefaultMaxRequestBodySize.
+	MaxRequestBodySize int64
 }
 
 type StdioServerConfig struct {
@@ -217,11 +621,184 @@ type StdioServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
+	// ExportToolsPath, when set, makes the server write the catalog of the tools it offers to this
+	// path as JSON and exit instead of serving.
+	ExportToolsPath string
+
+	// ToolStats records the usage of each tool and logs a summary on shutdown. Nothing is sent anywhere.
+	ToolStats bool
+
+	// ToolStatsPath, when set, is the path the usage of each tool is written to as JSON on shutdown.
+	// It implies ToolStats.
+	ToolStatsPath string
+
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
 	// Path to the log file if not stderr
 	LogFilePath string
+
+	// AssetsRepository is the repository, in the form owner/repo, uploaded assets are committed to
+	AssetsRepository string
+
+	// AssetsBranch is the branch uploaded assets are committed to
+	AssetsBranch string
+
+	// DefaultOwner and DefaultRepo are the owner and repository tools use when they are called without them
+	DefaultOwner string
+	DefaultRepo  string
+
+	// AdminToken enables the admin tools that enable and disable toolsets at runtime.
+	AdminToken string
+
+	// SkipTokenProbe stops get_me from probing the permissions of fine-grained personal access tokens.
+	SkipTokenProbe bool
+
+	// BatchConcurrency is the number of calls batch_tool_calls runs at once.
+	BatchConcurrency int
+
+	// SecondaryRateLimitMaxWait is the longest secondary rate limit wait requests are retried after.
+	SecondaryRateLimitMaxWait time.Duration
+
+	// MaxRequestsPerSession is the number of GitHub API requests each MCP session may make.
+	MaxRequestsPerSession int
+
+	// MaxRequestsPerMinute is the number of GitHub API requests each MCP session may make per minute.
+	MaxRequestsPerMinute int
+
+	// UserAgentSuffix is appended to the User-Agent of the requests made to GitHub.
+	UserAgentSuffix string
+
+	// APIVersion is the REST API version requests are pinned to. If empty, GitHub's default is used.
+	APIVersion string
+
+	// GraphQLCacheTTL is how long the responses to GraphQL queries are kept. If 0, they are not cached.
+	GraphQLCacheTTL time.Duration
+
+	// GraphQLCacheQueryTTLs sets the TTL of the queries whose first field is a key.
+	GraphQLCacheQueryTTLs map[string]time.Duration
+
+	// UseStoredCredentials authenticates with the token stored by `stdio --auth login` instead of
+	// Token, refreshing it when it expires.
+	UseStoredCredentials bool
+
+	// InputFD is the file descriptor messages are read from, for parents that set up a pipe other
+	// than stdin. If 0, stdin is used.
+	InputFD int
+
+	// OutputFD is the file descriptor messages are written to. If 0 or 1, stdout is used.
+	OutputFD int
+}
+
+// SSEServerConfig configures a server using the SSE transport, which predates streamable HTTP
+// but is still the only HTTP transport some MCP clients support.
+type SSEServerConfig struct {
+	Version              string
+	Host                 string
+	Token                string
+	EnabledToolsets      []string
+	DynamicToolsets      bool
+	ReadOnly             bool
+	ExportTranslations   bool
+	EnableCommandLogging bool
+	LogFilePath          string
+	Port                 int
+	BindAddress          string
+	AssetsRepository     string
+	AssetsBranch         string
+	DefaultOwner         string
+	DefaultRepo          string
+	WebhookSecret        string
+
+	// WebhookEvents are the webhook event types forwarded to subscribed sessions. If empty, all
+	// supported event types are.
+	WebhookEvents []string
+
+	// OnListen, when set, is called with the address the server listens on once it is bound, such
+	// as to learn the port the system picked when Port is 0.
+	OnListen func(addr net.Addr)
+
+	// ExportToolsPath, when set, makes the server write the catalog of the tools it offers to this
+	// path as JSON and exit instead of serving. The catalog is also served at /tools.json.
+	ExportToolsPath string
+
+	// ToolStats records the usage of each tool, serves it at /debug/toolstats and logs a summary on
+	// shutdown. Nothing is sent anywhere else.
+	ToolStats bool
+
+	// ToolStatsPath, when set, is the path the usage of each tool is written to as JSON on shutdown.
+	// It implies ToolStats.
+	ToolStatsPath string
+
+	// RequirePerRequestToken rejects tool calls without a token in the Authorization header,
+	// instead of falling back to Token.
+	RequirePerRequestToken bool
+
+	// AdminToken enables the admin tools that enable and disable toolsets at runtime.
+	AdminToken string
+
+	// SkipTokenProbe stops get_me from probing the permissions of fine-grained personal access tokens.
+	SkipTokenProbe bool
+
+	// BatchConcurrency is the number of calls batch_tool_calls runs at once.
+	BatchConcurrency int
+
+	// SecondaryRateLimitMaxWait is the longest secondary rate limit wait requests are retried after.
+	SecondaryRateLimitMaxWait time.Duration
+
+	// MaxRequestsPerSession is the number of GitHub API requests each MCP session may make.
+	MaxRequestsPerSession int
+
+	// MaxRequestsPerMinute is the number of GitHub API requests each MCP session may make per minute.
+	MaxRequestsPerMinute int
+
+	// UserAgentSuffix is appended to the User-Agent of the requests made to GitHub.
+	UserAgentSuffix string
+
+	// APIVersion is the REST API version requests are pinned to. If empty, GitHub's default is used.
+	APIVersion string
+
+	// GraphQLCacheTTL is how long the responses to GraphQL queries are kept. If 0, they are not cached.
+	GraphQLCacheTTL time.Duration
+
+	// GraphQLCacheQueryTTLs sets the TTL of the queries whose first field is a key.
+	GraphQLCacheQueryTTLs map[string]time.Duration
+
+	// BaseURL is the public URL of the server, used to advertise the message endpoint to clients.
+	// If empty, the message endpoint is advertised as a path relative to the SSE endpoint.
+	BaseURL string
+
+	// TLSCertFile and TLSKeyFile are the PEM certificate and key to serve HTTPS with.
+	// If both are empty, the server serves plain HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// TLSClientCACert is the path to a PEM CA certificate that client certificates are verified
+	// against. When set, clients must present a certificate signed by it unless TLSClientAuthMode
+	// says otherwise.
+	TLSClientCACert string
+
+	// TLSClientAuthMode is how client certificates are requested and verified, tls.NoClientCert by default.
+	TLSClientAuthMode tls.ClientAuthType
+
+	// TLSLogClientCertificates logs the subject of the client certificate of each request.
+	TLSLogClientCertificates bool
+
+	// TokenValidation is how strictly tokens in the Authorization header are checked before
+	// requests reach the MCP server. Malformed tokens are rejected with 401 Unauthorized.
+	TokenValidation TokenValidation
+
+	// AllowTokenQueryParam accepts the token in the token or bearer_token query parameter from
+	// requests without an Authorization header, for clients that cannot set headers. Query
+	// parameters can end up in access logs, so it is disabled by default.
+	AllowTokenQueryParam bool
+
+	// CORS configures the CORS headers for browser-based clients. CORS is disabled by default.
+	CORS CORSConfig
+
+	// MaxRequestBodySize is the largest request body accepted by the MCP endpoint, in bytes. Larger
+	// requests are rejected with 413 Request Entity Too Large. 0 uses DefaultMaxRequestBodySize.
+	MaxRequestBodySize int64
 }
 
 func RunHTTPServer(cfg HTTPServerConfig) error {
@@ -230,28 +807,50 @@ func RunHTTPServer(cfg HTTPServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
+	var subscriptions *webhook.Subscriptions
+	if cfg.WebhookSecret != "" {
+		subscriptions = webhook.NewSubscriptions(webhook.DefaultQueueSize)
+	}
+	catalog := github.NewToolCatalog()
+	toolStats := newToolStats(cfg.ToolStats, cfg.ToolStatsPath)
+
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
+		Version:                   cfg.Version,
+		Host:                      cfg.Host,
+		Token:                     cfg.Token,
+		EnabledToolsets:           cfg.EnabledToolsets,
+		DynamicToolsets:           cfg.DynamicToolsets,
+		ReadOnly:                  cfg.ReadOnly,
+		AssetsRepository:          cfg.AssetsRepository,
+		AssetsBranch:              cfg.AssetsBranch,
+		DefaultOwner:              cfg.DefaultOwner,
+		DefaultRepo:               cfg.DefaultRepo,
+		RequirePerRequestToken:    cfg.RequirePerRequestToken,
+		AdminToken:                cfg.AdminToken,
+		SkipTokenProbe:            cfg.SkipTokenProbe,
+		BatchConcurrency:          cfg.BatchConcurrency,
+		SecondaryRateLimitMaxWait: cfg.SecondaryRateLimitMaxWait,
+		MaxRequestsPerSession:     cfg.MaxRequestsPerSession,
+		MaxRequestsPerMinute:      cfg.MaxRequestsPerMinute,
+		UserAgentSuffix:           cfg.UserAgentSuffix,
+		APIVersion:                cfg.APIVersion,
+		GraphQLCacheTTL:           cfg.GraphQLCacheTTL,
+		GraphQLCacheQueryTTLs:     cfg.GraphQLCacheQueryTTLs,
+		WebhookSubscriptions:      subscriptions,
+		ToolCatalog:               catalog,
+		ToolStats:                 toolStats,
+		Translator:                t,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
+	if cfg.ExportToolsPath != "" {
+		return exportToolCatalog(catalog, cfg.ExportToolsPath)
+	}
 
-	logrusLogger := logrus.New()
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
-		}
-
-		logrusLogger.SetLevel(logrus.DebugLevel)
-		logrusLogger.SetOutput(file)
+	logrusLogger, err := newHTTPLogger(cfg.LogFilePath)
+	if err != nil {
+		return err
 	}
 
 	httpOptions := []server.StreamableHTTPOption{
@@ -266,17 +865,193 @@ func RunHTTPServer(cfg HTTPServerConfig) error {
 		dumpTranslations()
 	}
 
-	addr := fmt.Sprintf(":%d", cfg.Port)
+	tlsConfig, err := newServerTLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCACert, cfg.TLSClientAuthMode)
+	if err != nil {
+		return err
+	}
+
+	// CORS wraps token validation so that browsers can read the responses rejecting a token.
+	var handler http.Handler = withCORS(withToolStats(withToolCatalog(withWebhookReceiver(withTokenQueryParam(withTokenValidation(withMaxRequestBodySize(httpServer, cfg.MaxRequestBodySize), cfg.TokenValidation), cfg.AllowTokenQueryParam), cfg.WebhookSecret, cfg.WebhookEvents, ghServer, subscriptions), catalog), toolStats), cfg.CORS)
+	if cfg.TLSLogClientCertificates {
+		handler = withClientCertificateLogging(handler, logrusLogger)
+	}
+
+	addr, err := listenAddress(cfg.BindAddress, cfg.Port)
+	if err != nil {
+		return err
+	}
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: httpServer,
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+	}
+
+	if err := serveHTTP(ctx, logrusLogger, "HTTP", srv, srv.Shutdown, cfg.WebhookSecret != "", cfg.OnListen); err != nil {
+		return err
 	}
+	return reportToolStats(toolStats, logrusLogger.Out, cfg.ToolStatsPath)
+}
 
-	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on HTTP at %s\n", addr)
+func RunSSEServer(cfg SSEServerConfig) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	t, dumpTranslations := translations.TranslationHelper()
+
+	var subscriptions *webhook.Subscriptions
+	if cfg.WebhookSecret != "" {
+		subscriptions = webhook.NewSubscriptions(webhook.DefaultQueueSize)
+	}
+	catalog := github.NewToolCatalog()
+	toolStats := newToolStats(cfg.ToolStats, cfg.ToolStatsPath)
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:                   cfg.Version,
+		Host:                      cfg.Host,
+		Token:                     cfg.Token,
+		EnabledToolsets:           cfg.EnabledToolsets,
+		DynamicToolsets:           cfg.DynamicToolsets,
+		ReadOnly:                  cfg.ReadOnly,
+		AssetsRepository:          cfg.AssetsRepository,
+		AssetsBranch:              cfg.AssetsBranch,
+		DefaultOwner:              cfg.DefaultOwner,
+		DefaultRepo:               cfg.DefaultRepo,
+		RequirePerRequestToken:    cfg.RequirePerRequestToken,
+		AdminToken:                cfg.AdminToken,
+		SkipTokenProbe:            cfg.SkipTokenProbe,
+		BatchConcurrency:          cfg.BatchConcurrency,
+		SecondaryRateLimitMaxWait: cfg.SecondaryRateLimitMaxWait,
+		MaxRequestsPerSession:     cfg.MaxRequestsPerSession,
+		MaxRequestsPerMinute:      cfg.MaxRequestsPerMinute,
+		UserAgentSuffix:           cfg.UserAgentSuffix,
+		APIVersion:                cfg.APIVersion,
+		GraphQLCacheTTL:           cfg.GraphQLCacheTTL,
+		GraphQLCacheQueryTTLs:     cfg.GraphQLCacheQueryTTLs,
+		WebhookSubscriptions:      subscriptions,
+		ToolCatalog:               catalog,
+		ToolStats:                 toolStats,
+		Translator:                t,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	if cfg.ExportToolsPath != "" {
+		return exportToolCatalog(catalog, cfg.ExportToolsPath)
+	}
+
+	logrusLogger, err := newHTTPLogger(cfg.LogFilePath)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := newServerTLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCACert, cfg.TLSClientAuthMode)
+	if err != nil {
+		return err
+	}
+
+	addr, err := listenAddress(cfg.BindAddress, cfg.Port)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:      addr,
+		TLSConfig: tlsConfig,
+	}
+	// The SSE server owns srv so that shutting it down also closes the open event streams.
+	sseServer := newSSEServer(ghServer, cfg.BaseURL, server.WithHTTPServer(srv))
+	srv.Handler = withCORS(withToolStats(withToolCatalog(withWebhookReceiver(withTokenQueryParam(withTokenValidation(withMaxRequestBodySize(sseServer, cfg.MaxRequestBodySize), cfg.TokenValidation), cfg.AllowTokenQueryParam), cfg.WebhookSecret, cfg.WebhookEvents, ghServer, subscriptions), catalog), toolStats), cfg.CORS)
+	if cfg.TLSLogClientCertificates {
+		srv.Handler = withClientCertificateLogging(srv.Handler, logrusLogger)
+	}
+
+	if cfg.ExportTranslations {
+		dumpTranslations()
+	}
+
+	if err := serveHTTP(ctx, logrusLogger, "SSE", srv, sseServer.Shutdown, cfg.WebhookSecret != "", cfg.OnListen); err != nil {
+		return err
+	}
+	return reportToolStats(toolStats, logrusLogger.Out, cfg.ToolStatsPath)
+}
+
+// newSSEServer serves ghServer over SSE, taking the GitHub token of each request from its Authorization header.
+func newSSEServer(ghServer *server.MCPServer, baseURL string, opts ...server.SSEOption) *server.SSEServer {
+	sseOptions := []server.SSEOption{
+		server.WithBaseURL(baseURL),
+		server.WithKeepAlive(true),
+		server.WithKeepAliveInterval(30 * time.Second),
+		server.WithSSEContextFunc(extractTokenFromAuthHeader),
+	}
+	return server.NewSSEServer(ghServer, append(sseOptions, opts...)...)
+}
+
+// newHTTPLogger returns the logger of the HTTP transports, which logs to logFilePath when set.
+func newHTTPLogger(logFilePath string) (*logrus.Logger, error) {
+	logrusLogger := logrus.New()
+	if logFilePath != "" {
+		file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+
+		logrusLogger.SetLevel(logrus.DebugLevel)
+		logrusLogger.SetOutput(file)
+	}
+	return logrusLogger, nil
+}
+
+// withWebhookReceiver serves the webhook receiver alongside MCP when a webhook secret is set,
+// so events can be forwarded to the sessions subscribed to them.
+func withWebhookReceiver(mcpHandler http.Handler, webhookSecret string, webhookEvents []string, ghServer *server.MCPServer, subscriptions *webhook.Subscriptions) http.Handler {
+	if webhookSecret == "" {
+		return mcpHandler
+	}
+	mux := http.NewServeMux()
+	mux.Handle(webhook.Path, webhook.NewHandler(webhookSecret, webhookEvents, ghServer, subscriptions))
+	mux.Handle("/", mcpHandler)
+	return mux
+}
+
+// listenAddress returns the address to listen on for port on bindAddress, or on all interfaces if
+// bindAddress is empty. A port of 0 lets the system pick a free port.
+func listenAddress(bindAddress string, port int) (string, error) {
+	if port < 0 || port > 65535 {
+		return "", fmt.Errorf("invalid port %d: must be between 0 and 65535", port)
+	}
+	return net.JoinHostPort(bindAddress, strconv.Itoa(port)), nil
+}
+
+// serveHTTP serves srv until ctx is cancelled, then gracefully shuts it down with shutdown. The
+// address srv listens on is logged and passed to onListen, if set, once it is bound.
+func serveHTTP(ctx context.Context, logrusLogger *logrus.Logger, transport string, srv *http.Server, shutdown func(context.Context) error, webhooks bool, onListen func(net.Addr)) error {
+	if srv.TLSConfig != nil {
+		transport += " with TLS"
+		if srv.TLSConfig.ClientAuth != tls.NoClientCert {
+			transport += " and client certificates"
+		}
+	}
+	// Bind before serving so that the port the system picked for port 0 is known
+	listener, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", srv.Addr, err)
+	}
+	addr := listener.Addr()
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on %s at %s\n", transport, addr)
+	if webhooks {
+		_, _ = fmt.Fprintf(os.Stderr, "Receiving GitHub webhooks at %s%s\n", addr, webhook.Path)
+	}
+	if onListen != nil {
+		onListen(addr)
+	}
 
 	errC := make(chan error, 1)
 	go func() {
-		errC <- srv.ListenAndServe()
+		if srv.TLSConfig != nil {
+			// The certificate is already loaded into srv.TLSConfig.
+			errC <- srv.ServeTLS(listener, "", "")
+			return
+		}
+		errC <- srv.Serve(listener)
 	}()
 
 	select {
@@ -284,7 +1059,7 @@ func RunHTTPServer(cfg HTTPServerConfig) error {
 		logrusLogger.Infof("shutting down server...")
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		return srv.Shutdown(shutdownCtx)
+		return shutdown(shutdownCtx)
 	case err := <-errC:
 		if err != nil && err != http.ErrServerClosed {
 			return fmt.Errorf("error running server: %w", err)
@@ -302,21 +1077,25 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
+	var tokenSource func(context.Context) (string, error)
+	// Exporting the tools needs no credentials
+	if cfg.UseStoredCredentials && cfg.ExportToolsPath == "" {
+		source, err := loadStoredTokenSource(cfg.Host)
+		switch {
+		case stderrors.Is(err, errNoStoredToken):
+			// Clients can still supply the token of their session when initializing
+			_, _ = fmt.Fprintf(os.Stderr, "GITHUB_PERSONAL_ACCESS_TOKEN not set and %s, clients must supply a token when initializing\n", err)
+		case err != nil:
+			return err
+		default:
+			// Fail now rather than on the first tool call if the token expired and cannot be refreshed
+			if _, err := source.Token(ctx); err != nil {
+				return err
+			}
+			tokenSource = source.Token
+		}
 	}
 
-	stdioServer := server.NewStdioServer(ghServer)
-
 	var slogHandler slog.Handler
 	var logOutput io.Writer
 	if cfg.LogFilePath != "" {
@@ -331,6 +1110,47 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelInfo})
 	}
 	logger := slog.New(slogHandler)
+
+	catalog := github.NewToolCatalog()
+	toolStats := newToolStats(cfg.ToolStats, cfg.ToolStatsPath)
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:                   cfg.Version,
+		Host:                      cfg.Host,
+		Token:                     cfg.Token,
+		TokenSource:               tokenSource,
+		SessionTokens:             true,
+		EnabledToolsets:           cfg.EnabledToolsets,
+		DynamicToolsets:           cfg.DynamicToolsets,
+		ReadOnly:                  cfg.ReadOnly,
+		AssetsRepository:          cfg.AssetsRepository,
+		AssetsBranch:              cfg.AssetsBranch,
+		DefaultOwner:              cfg.DefaultOwner,
+		DefaultRepo:               cfg.DefaultRepo,
+		AdminToken:                cfg.AdminToken,
+		SkipTokenProbe:            cfg.SkipTokenProbe,
+		BatchConcurrency:          cfg.BatchConcurrency,
+		SecondaryRateLimitMaxWait: cfg.SecondaryRateLimitMaxWait,
+		MaxRequestsPerSession:     cfg.MaxRequestsPerSession,
+		MaxRequestsPerMinute:      cfg.MaxRequestsPerMinute,
+		UserAgentSuffix:           cfg.UserAgentSuffix,
+		APIVersion:                cfg.APIVersion,
+		GraphQLCacheTTL:           cfg.GraphQLCacheTTL,
+		GraphQLCacheQueryTTLs:     cfg.GraphQLCacheQueryTTLs,
+		CheckTokenScopes:          cfg.ExportToolsPath == "",
+		Logger:                    logger,
+		ToolCatalog:               catalog,
+		ToolStats:                 toolStats,
+		Translator:                t,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	if cfg.ExportToolsPath != "" {
+		return exportToolCatalog(catalog, cfg.ExportToolsPath)
+	}
+
+	stdioServer := server.NewStdioServer(ghServer)
+
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly)
 	stdLogger := log.New(logOutput, stdioServerLogPrefix, 0)
 	stdioServer.SetErrorLogger(stdLogger)
@@ -343,7 +1163,8 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	// Start listening for messages
 	errC := make(chan error, 1)
 	go func() {
-		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
+		inFile, outFile := stdioFiles(cfg.InputFD, cfg.OutputFD)
+		in, out := io.Reader(inFile), io.Writer(outFile)
 
 		if cfg.EnableCommandLogging {
 			loggedIO := mcplog.NewIOLogger(in, out, logger)
@@ -368,14 +1189,39 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		}
 	}
 
-	return nil
+	return reportToolStats(toolStats, logOutput, cfg.ToolStatsPath)
 }
 
+// stdioFiles returns the files the stdio server reads messages from and writes them to.
+func stdioFiles(inputFD, outputFD int) (*os.File, *os.File) {
+	in, out := os.Stdin, os.Stdout
+	if inputFD != 0 {
+		in = os.NewFile(uintptr(inputFD), "input")
+	}
+	if outputFD != 0 && outputFD != 1 {
+		out = os.NewFile(uintptr(outputFD), "output")
+	}
+	return in, out
+}
+
+// hostKind is the kind of GitHub deployment a host is.
+type hostKind int
+
+const (
+	hostKindDotcom hostKind = iota
+	hostKindGHEC
+	hostKindGHES
+)
+
 type apiHost struct {
+	kind        hostKind
 	baseRESTURL *url.URL
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	scimURL     *url.URL
+	// oauthURL is the web host that serves the OAuth endpoints, such as the device flow.
+	oauthURL *url.URL
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -399,11 +1245,24 @@ func newDotcomHost() (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse dotcom Raw URL: %w", err)
 	}
 
+	scimURL, err := url.Parse("https://api.github.com/scim/v2/")
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse dotcom SCIM URL: %w", err)
+	}
+
+	oauthURL, err := url.Parse("https://github.com/")
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse dotcom OAuth URL: %w", err)
+	}
+
 	return apiHost{
+		kind:        hostKindDotcom,
 		baseRESTURL: baseRestURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		scimURL:     scimURL,
+		oauthURL:    oauthURL,
 	}, nil
 }
 
@@ -438,11 +1297,24 @@ func newGHECHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC Raw URL: %w", err)
 	}
 
+	scimURL, err := url.Parse(fmt.Sprintf("https://api.%s/scim/v2/", u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHEC SCIM URL: %w", err)
+	}
+
+	oauthURL, err := url.Parse(fmt.Sprintf("https://%s/", u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHEC OAuth URL: %w", err)
+	}
+
 	return apiHost{
+		kind:        hostKindGHEC,
 		baseRESTURL: restURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		scimURL:     scimURL,
+		oauthURL:    oauthURL,
 	}, nil
 }
 
@@ -471,11 +1343,24 @@ func newGHESHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Raw URL: %w", err)
 	}
 
+	scimURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/scim/v2/", u.Scheme, u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHES SCIM URL: %w", err)
+	}
+
+	oauthURL, err := url.Parse(fmt.Sprintf("%s://%s/", u.Scheme, u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHES OAuth URL: %w", err)
+	}
+
 	return apiHost{
+		kind:        hostKindGHES,
 		baseRESTURL: restURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		scimURL:     scimURL,
+		oauthURL:    oauthURL,
 	}, nil
 }
 
@@ -505,14 +1390,20 @@ func parseAPIHost(s string) (apiHost, error) {
 	return newGHESHost(s)
 }
 
-type userAgentTransport struct {
+// apiVersionTransport sets the X-GitHub-Api-Version header to version, or removes it if version
+// is empty, since go-github otherwise sends a version of its own.
+type apiVersionTransport struct {
 	transport http.RoundTripper
-	agent     string
+	version   string
 }
 
-func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req = req.Clone(req.Context())
-	req.Header.Set("User-Agent", t.agent)
+	if t.version != "" {
+		req.Header.Set("X-GitHub-Api-Version", t.version)
+	} else {
+		req.Header.Del("X-GitHub-Api-Version")
+	}
 	return t.transport.RoundTrip(req)
 }
 
@@ -0,0 +1,610 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEServer(t *testing.T) {
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"context"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(newSSEServer(ghServer, ""))
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mcpClient, err := client.NewSSEMCPClient(ts.URL+"/sse", transport.WithHeaders(map[string]string{
+		"Authorization": "Bearer client-token",
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mcpClient.Close() })
+
+	require.NoError(t, mcpClient.Start(ctx))
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{Name: "sse-test-client", Version: "1.0.0"}
+
+	result, err := mcpClient.Initialize(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, "github-mcp-server", result.ServerInfo.Name)
+	assert.Equal(t, "test", result.ServerInfo.Version)
+	assert.NotNil(t, result.Capabilities.Tools)
+
+	tools, err := mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Contains(t, names, "get_me")
+}
+
+// roundTripperFunc records the requests the server makes to the GitHub API instead of sending them.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRequirePerRequestToken(t *testing.T) {
+	tests := []struct {
+		name                   string
+		requirePerRequestToken bool
+		authorizationHeader    string
+		expectError            bool
+		expectedAuthorization  string
+	}{
+		{
+			name:                  "falls back to the server token without a request token",
+			expectedAuthorization: "Bearer server-token",
+		},
+		{
+			name:                  "uses the request token",
+			authorizationHeader:   "Bearer client-token",
+			expectedAuthorization: "Bearer client-token",
+		},
+		{
+			name:                   "rejects requests without a token in strict mode",
+			requirePerRequestToken: true,
+			expectError:            true,
+		},
+		{
+			name:                   "uses the request token in strict mode",
+			requirePerRequestToken: true,
+			authorizationHeader:    "Bearer client-token",
+			expectedAuthorization:  "Bearer client-token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var authorization string
+			defaultTransport := http.DefaultTransport
+			http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				authorization = r.Header.Get("Authorization")
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"login": "octocat"}`)),
+					Request:    r,
+				}, nil
+			})
+			t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+			ghServer, err := NewMCPServer(MCPServerConfig{
+				Version:                "test",
+				Token:                  "server-token",
+				EnabledToolsets:        []string{"context"},
+				RequirePerRequestToken: tc.requirePerRequestToken,
+				Translator:             translations.NullTranslationHelper,
+			})
+			require.NoError(t, err)
+
+			httpRequest := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.authorizationHeader != "" {
+				httpRequest.Header.Set("Authorization", tc.authorizationHeader)
+			}
+			ctx := extractTokenFromAuthHeader(context.Background(), httpRequest)
+
+			response := ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_me","arguments":{}}}`))
+
+			// Tools report client errors either as a JSON-RPC error or as an error result.
+			var errorMessage string
+			switch response := response.(type) {
+			case mcp.JSONRPCError:
+				errorMessage = response.Error.Message
+			case mcp.JSONRPCResponse:
+				result, ok := response.Result.(mcp.CallToolResult)
+				require.True(t, ok, "expected a tool result, got %T", response.Result)
+				if result.IsError {
+					require.NotEmpty(t, result.Content)
+					textContent, ok := result.Content[0].(mcp.TextContent)
+					require.True(t, ok)
+					errorMessage = textContent.Text
+				}
+			default:
+				t.Fatalf("unexpected response %T", response)
+			}
+
+			if tc.expectError {
+				assert.Contains(t, errorMessage, "no GitHub token was provided with the request")
+				assert.Empty(t, authorization, "no request should be made to GitHub")
+				return
+			}
+
+			assert.Empty(t, errorMessage)
+			assert.Equal(t, tc.expectedAuthorization, authorization)
+		})
+	}
+}
+
+func TestUserAgentSuffix(t *testing.T) {
+	// userAgents records the User-Agent of the last REST, GraphQL and raw request
+	userAgents := map[string]string{}
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Host == "raw.githubusercontent.com":
+			userAgents["raw"] = r.Header.Get("User-Agent")
+		case r.URL.Path == "/graphql":
+			userAgents["graphql"] = r.Header.Get("User-Agent")
+		default:
+			userAgents["rest"] = r.Header.Get("User-Agent")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"login": "octocat", "sha": "c0ffee"}`)),
+			Request:    r,
+		}, nil
+	})
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"context", "repos"},
+		UserAgentSuffix: "acme-platform/2.1",
+		Translator:      translations.NullTranslationHelper,
+		Transport:       transport,
+	})
+	require.NoError(t, err)
+	ctx := ghServer.WithContext(context.Background(), testSession{})
+
+	// Files are fetched at a different SHA each time, as contents at a SHA are cached
+	callTools := func(sha string) {
+		userAgents = map[string]string{}
+		for _, call := range []string{
+			`{"name":"get_me","arguments":{}}`,
+			`{"name":"get_team_members","arguments":{"org":"octo-org","team_slug":"octo-team"}}`,
+			`{"name":"get_file_contents","arguments":{"owner":"octo-org","repo":"octo-repo","path":"README.md","sha":"` + sha + `"}}`,
+		} {
+			_ = ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+call+`}`))
+		}
+	}
+
+	callTools("3f786850e387550fdab836ed7e6dc881de23001b")
+	assert.Equal(t, map[string]string{
+		"rest":    "github-mcp-server/test acme-platform/2.1",
+		"graphql": "github-mcp-server/test acme-platform/2.1",
+		"raw":     "github-mcp-server/test acme-platform/2.1",
+	}, userAgents)
+
+	response := ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test-client","version":"1.0"},"capabilities":{}}}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %#v", response)
+
+	callTools("89e6c98d92887913cadf06b2adb97f26cde4849b")
+	assert.Equal(t, map[string]string{
+		"rest":    "github-mcp-server/test (test-client/1.0) acme-platform/2.1",
+		"graphql": "github-mcp-server/test (test-client/1.0) acme-platform/2.1",
+		"raw":     "github-mcp-server/test (test-client/1.0) acme-platform/2.1",
+	}, userAgents)
+}
+
+// namedSession is a session with a given ID, for servers with several sessions.
+type namedSession struct {
+	testSession
+	id string
+}
+
+func (s namedSession) SessionID() string { return s.id }
+
+func TestUserAgentOfRequestTokenClients(t *testing.T) {
+	// requests records the User-Agent of the REST and GraphQL requests made with each token
+	type request struct{ kind, authorization string }
+	requests := map[request]string{}
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"context"},
+		Translator:      translations.NullTranslationHelper,
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			kind := "rest"
+			if r.URL.Path == "/graphql" {
+				kind = "graphql"
+			}
+			requests[request{kind, r.Header.Get("Authorization")}] = r.Header.Get("User-Agent")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"login": "octocat"}`)),
+				Request:    r,
+			}, nil
+		}),
+	})
+	require.NoError(t, err)
+
+	// Two sessions of different clients, each calling with its own token
+	sessionContext := func(id, token string) context.Context {
+		httpRequest := httptest.NewRequest(http.MethodPost, "/", nil)
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+		return ghServer.WithContext(extractTokenFromAuthHeader(context.Background(), httpRequest), namedSession{id: id})
+	}
+	ctxA := sessionContext("a", "token-a")
+	ctxB := sessionContext("b", "token-b")
+	for ctx, clientName := range map[context.Context]string{ctxA: "client-a", ctxB: "client-b"} {
+		response := ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"`+clientName+`","version":"1.0"},"capabilities":{}}}`))
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "expected a successful response, got %#v", response)
+	}
+
+	for _, ctx := range []context.Context{ctxA, ctxB} {
+		for _, call := range []string{
+			`{"name":"get_me","arguments":{}}`,
+			`{"name":"get_team_members","arguments":{"org":"octo-org","team_slug":"octo-team"}}`,
+		} {
+			_ = ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+call+`}`))
+		}
+	}
+
+	assert.Equal(t, map[request]string{
+		{"rest", "Bearer token-a"}:    "github-mcp-server/test (client-a/1.0)",
+		{"graphql", "Bearer token-a"}: "github-mcp-server/test (client-a/1.0)",
+		{"rest", "Bearer token-b"}:    "github-mcp-server/test (client-b/1.0)",
+		{"graphql", "Bearer token-b"}: "github-mcp-server/test (client-b/1.0)",
+	}, requests)
+}
+
+func TestInitializeReportsEnabledToolsets(t *testing.T) {
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"issues", "context"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1.0"},"capabilities":{}}}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %#v", response)
+
+	// Decode the result the way clients see it
+	payload, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var result struct {
+		Capabilities struct {
+			Experimental struct {
+				Toolsets []struct {
+					Name        string `json:"name"`
+					Description string `json:"description"`
+					ToolCount   int    `json:"tool_count"`
+				} `json:"toolsets"`
+			} `json:"experimental"`
+		} `json:"capabilities"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &result))
+
+	toolsets := result.Capabilities.Experimental.Toolsets
+	require.Len(t, toolsets, 2)
+	assert.Equal(t, "context", toolsets[0].Name)
+	assert.Equal(t, "issues", toolsets[1].Name)
+
+	toolsResponse := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))
+	tools, ok := toolsResponse.(mcp.JSONRPCResponse).Result.(mcp.ListToolsResult)
+	require.True(t, ok)
+	totalTools := 0
+	for _, toolset := range toolsets {
+		assert.NotEmpty(t, toolset.Description)
+		assert.Positive(t, toolset.ToolCount)
+		totalTools += toolset.ToolCount
+	}
+	// batch_tool_calls and the three watch tools are registered outside of the toolsets
+	assert.Equal(t, len(tools.Tools)-4, totalTools, "the tool counts should add up to the listed tools")
+}
+
+func TestEnterpriseToolsetOnlyOnGHES(t *testing.T) {
+	tests := []struct {
+		name            string
+		host            string
+		enabledToolsets []string
+		expectTools     bool
+	}{
+		{name: "GHES, enabled by name", host: "https://ghes.example.com", enabledToolsets: []string{"context", "enterprise"}, expectTools: true},
+		{name: "GHES, all toolsets", host: "https://ghes.example.com", enabledToolsets: []string{"all"}},
+		{name: "github.com, enabled by name", host: "", enabledToolsets: []string{"context", "enterprise"}},
+		{name: "GHEC, enabled by name", host: "https://octocorp.ghe.com", enabledToolsets: []string{"context", "enterprise"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ghServer, err := NewMCPServer(MCPServerConfig{
+				Version:         "test",
+				Host:            tc.host,
+				Token:           "server-token",
+				EnabledToolsets: tc.enabledToolsets,
+				Translator:      translations.NullTranslationHelper,
+			})
+			require.NoError(t, err)
+
+			response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+			tools, ok := response.(mcp.JSONRPCResponse).Result.(mcp.ListToolsResult)
+			require.True(t, ok)
+			found := false
+			for _, tool := range tools.Tools {
+				if tool.Name == "get_enterprise_admin_stats" {
+					found = true
+				}
+			}
+			assert.Equal(t, tc.expectTools, found)
+		})
+	}
+}
+
+// stdioTestFiles are the files TestStdioFiles opens, which must outlive it.
+var stdioTestFiles []*os.File
+
+func TestStdioFiles(t *testing.T) {
+	t.Run("defaults to stdin and stdout", func(t *testing.T) {
+		in, out := stdioFiles(0, 1)
+		assert.Same(t, os.Stdin, in)
+		assert.Same(t, os.Stdout, out)
+
+		// Configs that leave OutputFD unset use stdout too
+		_, out = stdioFiles(0, 0)
+		assert.Same(t, os.Stdout, out)
+	})
+
+	t.Run("uses the given file descriptors", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+
+		in, out := stdioFiles(int(r.Fd()), int(w.Fd()))
+		// The files share their descriptors, so closing some would leave the others to close
+		// descriptors that other tests may have opened again by then, when garbage collected.
+		// None is closed, and they are kept referenced so that they are not collected.
+		stdioTestFiles = append(stdioTestFiles, r, w, in, out)
+		assert.Equal(t, r.Fd(), in.Fd())
+		assert.Equal(t, w.Fd(), out.Fd())
+
+		_, err = out.Write([]byte("{}\n"))
+		require.NoError(t, err)
+		buf := make([]byte, 3)
+		_, err = io.ReadFull(in, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "{}\n", string(buf))
+	})
+}
+
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiVersion string
+		expected   string
+	}{
+		{name: "default version", apiVersion: DefaultAPIVersion, expected: "2022-11-28"},
+		{name: "other version", apiVersion: "2026-03-10", expected: "2026-03-10"},
+		{name: "no version", apiVersion: "", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// versions records the X-GitHub-Api-Version of the last REST and GraphQL request
+			versions := map[string]string{}
+			defaultTransport := http.DefaultTransport
+			http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				kind := "rest"
+				if r.URL.Path == "/graphql" {
+					kind = "graphql"
+				}
+				versions[kind] = r.Header.Get("X-GitHub-Api-Version")
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"login": "octocat"}`)),
+					Request:    r,
+				}, nil
+			})
+			t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+			ghServer, err := NewMCPServer(MCPServerConfig{
+				Version:         "test",
+				Token:           "server-token",
+				EnabledToolsets: []string{"context", "repos"},
+				APIVersion:      tc.apiVersion,
+				Translator:      translations.NullTranslationHelper,
+			})
+			require.NoError(t, err)
+
+			for _, call := range []string{
+				`{"name":"get_me","arguments":{}}`,
+				`{"name":"get_team_members","arguments":{"org":"octo-org","team_slug":"octo-team"}}`,
+			} {
+				_ = ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+call+`}`))
+			}
+
+			// GraphQL is not versioned
+			assert.Equal(t, map[string]string{"rest": tc.expected, "graphql": ""}, versions)
+		})
+	}
+}
+
+func TestRepositoryDefaults(t *testing.T) {
+	// paths records the paths of the REST requests made
+	var paths []string
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"number": 42}`)),
+			Request:    r,
+		}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	_, err := NewMCPServer(MCPServerConfig{
+		Version:     "test",
+		Token:       "server-token",
+		DefaultRepo: "octo-repo",
+		Translator:  translations.NullTranslationHelper,
+	})
+	require.EqualError(t, err, "a default repository requires a default owner")
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"issues"},
+		DefaultOwner:    "octo-org",
+		DefaultRepo:     "octo-repo",
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	tools, ok := response.(mcp.JSONRPCResponse).Result.(mcp.ListToolsResult)
+	require.True(t, ok)
+	found := false
+	for _, tool := range tools.Tools {
+		if tool.Name == "get_issue" {
+			found = true
+			assert.ElementsMatch(t, []string{"issue_number"}, tool.InputSchema.Required)
+		}
+	}
+	require.True(t, found)
+
+	for _, call := range []string{
+		`{"name":"get_issue","arguments":{"issue_number":42}}`,
+		`{"name":"get_issue","arguments":{"owner":"octo-org","repo":"other-repo","issue_number":42}}`,
+	} {
+		response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+call+`}`))
+		result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		require.False(t, result.IsError, "%s failed: %#v", call, result.Content)
+	}
+	assert.Equal(t, []string{"/repos/octo-org/octo-repo/issues/42", "/repos/octo-org/other-repo/issues/42"}, paths)
+}
+
+func TestListenAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		bindAddress string
+		port        int
+		expected    string
+		expectError bool
+	}{
+		{name: "all interfaces", port: 8080, expected: ":8080"},
+		{name: "IPv4 address", bindAddress: "127.0.0.1", port: 8080, expected: "127.0.0.1:8080"},
+		{name: "IPv6 address", bindAddress: "::1", port: 8080, expected: "[::1]:8080"},
+		{name: "port picked by the system", bindAddress: "127.0.0.1", port: 0, expected: "127.0.0.1:0"},
+		{name: "negative port", port: -1, expectError: true},
+		{name: "port out of range", port: 65536, expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, err := listenAddress(tc.bindAddress, tc.port)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, addr)
+		})
+	}
+}
+
+func TestServeHTTPOnPickedPort(t *testing.T) {
+	srv := &http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listening := make(chan net.Addr, 1)
+	errC := make(chan error, 1)
+	go func() {
+		errC <- serveHTTP(ctx, logrus.New(), "HTTP", srv, srv.Shutdown, false, func(addr net.Addr) { listening <- addr })
+	}()
+
+	var addr net.Addr
+	select {
+	case addr = <-listening:
+	case err := <-errC:
+		t.Fatalf("server stopped before listening: %v", err)
+	}
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	require.True(t, ok)
+	assert.Equal(t, "127.0.0.1", tcpAddr.IP.String())
+	assert.NotZero(t, tcpAddr.Port)
+
+	client := &http.Client{Transport: &http.Transport{}}
+	resp, err := client.Get("http://" + addr.String() + "/mcp")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+	cancel()
+	require.NoError(t, <-errC)
+}
+
+func TestArgumentsOfToolsOutsideTheGroupAreValidated(t *testing.T) {
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"context"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	callTool := func(arguments string) string {
+		response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"watch_resource","arguments":`+arguments+`}}`))
+		result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		require.True(t, result.IsError)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	// The number passed as a string is converted, and the call only fails for want of a session
+	assert.Equal(t, "watching requires a session to notify",
+		callTool(`{"kind":"issue_comments","owner":"octo-org","repo":"octo-repo","number":42,"interval_seconds":"120"}`))
+	assert.Contains(t,
+		callTool(`{"kind":"issue_comments","owner":"octo-org","repo":"octo-repo","number":42,"interval_seconds":"often"}`),
+		"interval_seconds")
+}
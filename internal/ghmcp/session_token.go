@@ -0,0 +1,76 @@
+package ghmcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/tokens"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// sessionTokenCapability is the experimental client capability clients supply the GitHub token of
+// their session in when initializing, as in {"experimental": {"githubToken": {"token": "..."}}}.
+// Wrappers that multiplex users over one stdio server use it to act as each user.
+const sessionTokenCapability = "githubToken"
+
+// sessionTokens holds the GitHub tokens clients supplied for their sessions.
+type sessionTokens struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func newSessionTokens() *sessionTokens {
+	return &sessionTokens{tokens: make(map[string]string)}
+}
+
+// sessionTokenFromCapabilities returns the token in the githubToken capability, if any.
+func sessionTokenFromCapabilities(capabilities mcp.ClientCapabilities) string {
+	capability, ok := capabilities.Experimental[sessionTokenCapability].(map[string]any)
+	if !ok {
+		return ""
+	}
+	token, _ := capability["token"].(string)
+	return token
+}
+
+// beforeInitialize stores the token the client supplied for its session. Initializing again
+// replaces the token, or removes it when none is supplied.
+func (s *sessionTokens) beforeInitialize(ctx context.Context, _ any, message *mcp.InitializeRequest) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	s.set(session.SessionID(), sessionTokenFromCapabilities(message.Params.Capabilities))
+}
+
+func (s *sessionTokens) set(sessionID, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// What was learned about the previous token must not be reported for the new one
+	if previous := s.tokens[sessionID]; previous != "" && previous != token {
+		tokens.DefaultCache.Delete(previous)
+	}
+	if token == "" {
+		delete(s.tokens, sessionID)
+		return
+	}
+	s.tokens[sessionID] = token
+}
+
+// fromContext returns the token supplied for the session of ctx, if any.
+func (s *sessionTokens) fromContext(ctx context.Context) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return "", false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[session.SessionID()]
+	return token, ok
+}
@@ -0,0 +1,99 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/tokens"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is the single session of a stdio server.
+type testSession struct{}
+
+func (testSession) Initialize()                                         {}
+func (testSession) Initialized() bool                                   { return true }
+func (testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (testSession) SessionID() string                                   { return "stdio" }
+
+func TestSessionTokens(t *testing.T) {
+	var authorization string
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		authorization = r.Header.Get("Authorization")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"login": "octocat"}`)),
+			Request:    r,
+		}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		SessionTokens:   true,
+		EnabledToolsets: []string{"context"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	ctx := ghServer.WithContext(context.Background(), testSession{})
+
+	initialize := func(t *testing.T, experimental map[string]any) {
+		t.Helper()
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "initialize",
+			"params": map[string]any{
+				"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+				"clientInfo":      map[string]any{"name": "wrapper", "version": "1.0.0"},
+				"capabilities":    map[string]any{"experimental": experimental},
+			},
+		})
+		require.NoError(t, err)
+		_, ok := ghServer.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+		require.True(t, ok, "initialize failed")
+	}
+	getMe := func(t *testing.T) string {
+		t.Helper()
+		authorization = ""
+		response := ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_me","arguments":{}}}`))
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "get_me failed")
+		return authorization
+	}
+
+	t.Run("uses the server token without a session token", func(t *testing.T) {
+		initialize(t, nil)
+		assert.Equal(t, "Bearer server-token", getMe(t))
+	})
+
+	t.Run("uses the token supplied when initializing", func(t *testing.T) {
+		initialize(t, map[string]any{"githubToken": map[string]any{"token": "user-one-token"}})
+		assert.Equal(t, "Bearer user-one-token", getMe(t))
+	})
+
+	t.Run("switching tokens forgets what was cached for the previous one", func(t *testing.T) {
+		tokens.DefaultCache.Set("user-one-token", &tokens.Permissions{ProbedAt: time.Now()})
+
+		initialize(t, map[string]any{"githubToken": map[string]any{"token": "user-two-token"}})
+		assert.Equal(t, "Bearer user-two-token", getMe(t))
+		_, ok := tokens.DefaultCache.Get("user-one-token")
+		assert.False(t, ok)
+	})
+
+	t.Run("initializing without a token falls back to the server token", func(t *testing.T) {
+		initialize(t, map[string]any{})
+		assert.Equal(t, "Bearer server-token", getMe(t))
+	})
+}
@@ -0,0 +1,120 @@
+package ghmcp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// tlsClientAuthModes maps the names accepted by ParseTLSClientAuthMode to client authentication types.
+var tlsClientAuthModes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify-if-given":    tls.VerifyClientCertIfGiven,
+	"require-and-verify": tls.RequireAndVerifyClientCert,
+}
+
+// ParseTLSClientAuthMode parses the name of a client certificate authentication mode: none,
+// request, require, verify-if-given or require-and-verify. An empty name is none.
+func ParseTLSClientAuthMode(name string) (tls.ClientAuthType, error) {
+	if name == "" {
+		return tls.NoClientCert, nil
+	}
+	mode, ok := tlsClientAuthModes[name]
+	if !ok {
+		return tls.NoClientCert, fmt.Errorf("unknown TLS client auth mode %q, must be none, request, require, verify-if-given or require-and-verify", name)
+	}
+	return mode, nil
+}
+
+// newServerTLSConfig builds the TLS configuration of the HTTP server, or returns nil when no
+// certificate is configured and the server should serve plain HTTP. When clientCACert is set,
+// client certificates are verified against it and, unless clientAuthMode says otherwise,
+// required.
+func newServerTLSConfig(certFile, keyFile, clientCACert string, clientAuthMode tls.ClientAuthType) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCACert != "" || clientAuthMode != tls.NoClientCert {
+			return nil, errors.New("client certificate authentication requires a TLS certificate and key")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both a TLS certificate and key are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		ClientAuth:   clientAuthMode,
+	}
+
+	if clientCACert == "" {
+		// Verifying client certificates against the system roots would accept any publicly issued certificate.
+		if clientAuthMode == tls.VerifyClientCertIfGiven || clientAuthMode == tls.RequireAndVerifyClientCert {
+			return nil, errors.New("verifying client certificates requires a client CA certificate")
+		}
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(clientCACert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA certificate: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in client CA certificate %s", clientCACert)
+	}
+	tlsConfig.ClientCAs = clientCAs
+	if clientAuthMode == tls.NoClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// withClientCertificateLogging logs the subject of the client certificate of each request, for auditing
+// who connected over mutual TLS.
+func withClientCertificateLogging(next http.Handler, logger *logrus.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := logrus.Fields{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"remote": r.RemoteAddr,
+		}
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			cert := r.TLS.PeerCertificates[0]
+			fields["client_cn"] = cert.Subject.CommonName
+			if sans := certificateSANs(cert); len(sans) > 0 {
+				fields["client_san"] = strings.Join(sans, ",")
+			}
+			logger.WithFields(fields).Info("client certificate")
+		} else {
+			logger.WithFields(fields).Info("no client certificate")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// certificateSANs returns the subject alternative names of cert.
+func certificateSANs(cert *x509.Certificate) []string {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.IPAddresses)+len(cert.URIs))
+	sans = append(sans, cert.DNSNames...)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
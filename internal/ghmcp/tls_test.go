@@ -0,0 +1,298 @@
+package ghmcp
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCertificate issues a certificate from template, signed by parent or self-signed when parent is nil.
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCertificate{cert: cert, key: key, der: der}
+}
+
+// writePEM writes the certificate and key of c to dir and returns their paths.
+func (c *testCertificate) writePEM(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	certFile = filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600))
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	keyFile = filepath.Join(dir, name+"-key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return certFile, keyFile
+}
+
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func newTestCA(t *testing.T, name string) *testCertificate {
+	return newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+}
+
+func TestParseTLSClientAuthMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    tls.ClientAuthType
+		expectError bool
+	}{
+		{name: "", expected: tls.NoClientCert},
+		{name: "none", expected: tls.NoClientCert},
+		{name: "request", expected: tls.RequestClientCert},
+		{name: "require", expected: tls.RequireAnyClientCert},
+		{name: "verify-if-given", expected: tls.VerifyClientCertIfGiven},
+		{name: "require-and-verify", expected: tls.RequireAndVerifyClientCert},
+		{name: "always", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := ParseTLSClientAuthMode(tc.name)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "test CA")
+	caFile, _ := ca.writePEM(t, dir, "ca")
+	serverCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	certFile, keyFile := serverCert.writePEM(t, dir, "server")
+	notPEM := filepath.Join(dir, "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+	tests := []struct {
+		name               string
+		certFile           string
+		keyFile            string
+		clientCACert       string
+		clientAuthMode     tls.ClientAuthType
+		expectNil          bool
+		expectedClientAuth tls.ClientAuthType
+		expectedErrMsg     string
+	}{
+		{
+			name:      "plain HTTP without a certificate",
+			expectNil: true,
+		},
+		{
+			name:               "TLS without client certificates",
+			certFile:           certFile,
+			keyFile:            keyFile,
+			expectedClientAuth: tls.NoClientCert,
+		},
+		{
+			name:               "client CA requires verified client certificates by default",
+			certFile:           certFile,
+			keyFile:            keyFile,
+			clientCACert:       caFile,
+			expectedClientAuth: tls.RequireAndVerifyClientCert,
+		},
+		{
+			name:               "client CA with an explicit mode",
+			certFile:           certFile,
+			keyFile:            keyFile,
+			clientCACert:       caFile,
+			clientAuthMode:     tls.VerifyClientCertIfGiven,
+			expectedClientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			name:           "client CA without a server certificate",
+			clientCACert:   caFile,
+			expectedErrMsg: "client certificate authentication requires a TLS certificate and key",
+		},
+		{
+			name:           "certificate without a key",
+			certFile:       certFile,
+			expectedErrMsg: "both a TLS certificate and key are required",
+		},
+		{
+			name:           "verification without a client CA",
+			certFile:       certFile,
+			keyFile:        keyFile,
+			clientAuthMode: tls.RequireAndVerifyClientCert,
+			expectedErrMsg: "verifying client certificates requires a client CA certificate",
+		},
+		{
+			name:           "client CA file without certificates",
+			certFile:       certFile,
+			keyFile:        keyFile,
+			clientCACert:   notPEM,
+			expectedErrMsg: "no PEM certificates found",
+		},
+		{
+			name:           "missing client CA file",
+			certFile:       certFile,
+			keyFile:        keyFile,
+			clientCACert:   filepath.Join(dir, "missing.pem"),
+			expectedErrMsg: "failed to read client CA certificate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig, err := newServerTLSConfig(tc.certFile, tc.keyFile, tc.clientCACert, tc.clientAuthMode)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectNil {
+				assert.Nil(t, tlsConfig)
+				return
+			}
+			require.NotNil(t, tlsConfig)
+			assert.Len(t, tlsConfig.Certificates, 1)
+			assert.Equal(t, tc.expectedClientAuth, tlsConfig.ClientAuth)
+			assert.Equal(t, tc.clientCACert != "", tlsConfig.ClientCAs != nil)
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "test CA")
+	caFile, _ := ca.writePEM(t, dir, "ca")
+	serverCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	certFile, keyFile := serverCert.writePEM(t, dir, "server")
+	clientCert := newTestCertificate(t, &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "octo-agent"},
+		DNSNames:       []string{"agent.example.com"},
+		EmailAddresses: []string{"agent@example.com"},
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+	untrustedClientCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "intruder"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, newTestCA(t, "other CA"))
+
+	tlsConfig, err := newServerTLSConfig(certFile, keyFile, caFile, tls.NoClientCert)
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	srv := &http.Server{
+		Handler: withClientCertificateLogging(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}), logger),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 5 * time.Second,
+		// The rejected handshakes are expected.
+		ErrorLog: log.New(io.Discard, "", 0),
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	get := func(certificates ...tls.Certificate) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      rootCAs,
+				Certificates: certificates,
+				MinVersion:   tls.VersionTLS12,
+			},
+		}}
+		defer client.CloseIdleConnections()
+		return client.Get("https://" + listener.Addr().String() + "/mcp")
+	}
+
+	t.Run("accepts a client certificate signed by the CA", func(t *testing.T) {
+		resp, err := get(clientCert.tlsCertificate())
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Contains(t, logs.String(), "client_cn=octo-agent")
+		assert.Contains(t, logs.String(), `client_san="agent.example.com,agent@example.com"`)
+		assert.Contains(t, logs.String(), "path=/mcp")
+	})
+
+	t.Run("rejects clients without a certificate", func(t *testing.T) {
+		resp, err := get()
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		require.Error(t, err)
+	})
+
+	t.Run("rejects a client certificate signed by another CA", func(t *testing.T) {
+		resp, err := get(untrustedClientCert.tlsCertificate())
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		require.Error(t, err)
+		assert.NotContains(t, logs.String(), "intruder")
+	})
+}
@@ -0,0 +1,183 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+// TokenValidation is how strictly the tokens clients send in the Authorization header are checked
+// before their requests reach the MCP server.
+type TokenValidation string
+
+const (
+	// TokenValidationNone accepts any token, leaving GitHub to reject invalid ones.
+	TokenValidationNone TokenValidation = "none"
+	// TokenValidationLenient rejects tokens that cannot be a GitHub token in any format: too
+	// short, or containing characters GitHub never puts in a token.
+	TokenValidationLenient TokenValidation = "lenient"
+	// TokenValidationStrict additionally requires one of the prefixes of current GitHub tokens,
+	// which rejects the unprefixed tokens of older GitHub Enterprise Server versions.
+	TokenValidationStrict TokenValidation = "strict"
+)
+
+// minTokenLength is the length of the shortest GitHub tokens, such as the 40 hexadecimal characters
+// of legacy tokens and the 40 characters of a ghp_ token.
+const minTokenLength = 40
+
+// githubTokenPrefixes are the prefixes of the GitHub tokens that can authenticate API requests.
+var githubTokenPrefixes = []string{
+	"github_pat_", // fine-grained personal access token
+	"ghp_",        // personal access token (classic)
+	"gho_",        // OAuth access token
+	"ghu_",        // GitHub App user access token
+	"ghs_",        // GitHub App installation access token
+}
+
+// ParseTokenValidation parses the name of a token validation mode. An empty name is none.
+func ParseTokenValidation(name string) (TokenValidation, error) {
+	switch mode := TokenValidation(name); mode {
+	case "":
+		return TokenValidationNone, nil
+	case TokenValidationNone, TokenValidationLenient, TokenValidationStrict:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown token validation mode %q, must be none, lenient or strict", name)
+	}
+}
+
+// validateToken returns why token is not a well-formed GitHub token under mode, or an empty string if it is.
+func validateToken(token string, mode TokenValidation) string {
+	if mode == TokenValidationNone || mode == "" {
+		return ""
+	}
+
+	if len(token) < minTokenLength {
+		return "the token is too short to be a GitHub token"
+	}
+	for _, r := range token {
+		isAlphanumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		// Installation tokens of older GitHub Enterprise Server versions have a "v1." prefix.
+		if !isAlphanumeric && r != '_' && r != '.' {
+			return "the token contains characters that do not appear in GitHub tokens"
+		}
+	}
+
+	if mode == TokenValidationStrict {
+		for _, prefix := range githubTokenPrefixes {
+			if strings.HasPrefix(token, prefix) {
+				return ""
+			}
+		}
+		return "the token does not start with the prefix of a GitHub token, such as ghp_ or github_pat_"
+	}
+	return ""
+}
+
+// withTokenValidation rejects requests whose Authorization header does not carry a well-formed
+// GitHub token with 401 Unauthorized, before they reach the MCP server. Requests without the
+// header are passed on, as they use the server's token.
+func withTokenValidation(next http.Handler, mode TokenValidation) http.Handler {
+	if mode == TokenValidationNone || mode == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok {
+			rejectToken(w, "the Authorization header must use the Bearer scheme")
+			return
+		}
+		if reason := validateToken(token, mode); reason != "" {
+			rejectToken(w, reason)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// tokenQueryParams are the query parameters withTokenQueryParam takes a token from, in order of preference.
+var tokenQueryParams = []string{"token", "bearer_token"}
+
+// withTokenQueryParam lets clients that cannot set headers pass their token in the token or
+// bearer_token query parameter instead of the Authorization header. The parameter is removed from
+// the URL and, unless the request has an Authorization header, its token is moved to the header, so
+// that it is validated and extracted like any other. If allow is false, next is returned as is.
+func withTokenQueryParam(next http.Handler, allow bool) http.Handler {
+	if !allow {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var token string
+		found := false
+		for _, name := range tokenQueryParams {
+			if !query.Has(name) {
+				continue
+			}
+			if token == "" {
+				token = query.Get(name)
+			}
+			query.Del(name)
+			found = true
+		}
+		if !found {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r = r.Clone(r.Context())
+		r.URL.RawQuery = query.Encode()
+		r.RequestURI = r.URL.RequestURI()
+		if token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func rejectToken(w http.ResponseWriter, reason string) {
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer error=\"invalid_token\", error_description=%q", reason))
+	http.Error(w, "invalid GitHub token: "+reason, http.StatusUnauthorized)
+}
+
+// writeScopes are the OAuth scopes of classic tokens that allow at least some of the tools to write.
+// Scopes starting with "write:" or "admin:" also do.
+var writeScopes = map[string]bool{
+	"repo":        true,
+	"public_repo": true,
+	"workflow":    true,
+	"gist":        true,
+	"project":     true,
+	"delete_repo": true,
+	"user":        true,
+}
+
+// tokenLacksWriteScopes reports whether the token of client is a classic token without any scope that
+// allows writing. Other tokens do not report their scopes, so they are assumed not to lack them.
+func tokenLacksWriteScopes(ctx context.Context, client *gogithub.Client) (bool, error) {
+	_, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return false, fmt.Errorf("failed to get the authenticated user: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	if _, classic := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; !classic {
+		return false, nil
+	}
+	for _, scope := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		scope = strings.TrimSpace(scope)
+		if writeScopes[scope] || strings.HasPrefix(scope, "write:") || strings.HasPrefix(scope, "admin:") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,277 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTokenValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    TokenValidation
+		expectError bool
+	}{
+		{name: "", expected: TokenValidationNone},
+		{name: "none", expected: TokenValidationNone},
+		{name: "lenient", expected: TokenValidationLenient},
+		{name: "strict", expected: TokenValidationStrict},
+		{name: "paranoid", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := ParseTokenValidation(tc.name)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	classic := "ghp_" + strings.Repeat("a1B2", 9)
+	fineGrained := "github_pat_11ABCDEFG0" + strings.Repeat("x", 72)
+	installation := "ghs_" + strings.Repeat("Z9", 18)
+	legacy := strings.Repeat("0123456789abcdef", 2) + "01234567"
+	legacyInstallation := "v1." + legacy
+
+	tests := []struct {
+		name          string
+		token         string
+		validLenient  bool
+		validStrict   bool
+		expectedError string
+	}{
+		{name: "personal access token (classic)", token: classic, validLenient: true, validStrict: true},
+		{name: "fine-grained personal access token", token: fineGrained, validLenient: true, validStrict: true},
+		{name: "installation access token", token: installation, validLenient: true, validStrict: true},
+		{name: "OAuth access token", token: "gho_" + strings.Repeat("q", 36), validLenient: true, validStrict: true},
+		{name: "user access token", token: "ghu_" + strings.Repeat("q", 36), validLenient: true, validStrict: true},
+		{
+			name:          "legacy token",
+			token:         legacy,
+			validLenient:  true,
+			expectedError: "does not start with the prefix of a GitHub token",
+		},
+		{
+			name:          "legacy installation token",
+			token:         legacyInstallation,
+			validLenient:  true,
+			expectedError: "does not start with the prefix of a GitHub token",
+		},
+		{
+			name:          "refresh token",
+			token:         "ghr_" + strings.Repeat("q", 36),
+			validLenient:  true,
+			expectedError: "does not start with the prefix of a GitHub token",
+		},
+		{name: "placeholder", token: "<github-token>", expectedError: "too short"},
+		{name: "empty", token: "", expectedError: "too short"},
+		{name: "truncated token", token: classic[:20], expectedError: "too short"},
+		{name: "token with whitespace", token: classic + " extra", expectedError: "characters that do not appear"},
+		{name: "token with quotes", token: `"` + classic + `"`, expectedError: "characters that do not appear"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Empty(t, validateToken(tc.token, TokenValidationNone))
+
+			lenient := validateToken(tc.token, TokenValidationLenient)
+			strict := validateToken(tc.token, TokenValidationStrict)
+			if tc.validLenient {
+				assert.Empty(t, lenient)
+			} else {
+				assert.Contains(t, lenient, tc.expectedError)
+			}
+			if tc.validStrict {
+				assert.Empty(t, strict)
+			} else {
+				assert.Contains(t, strict, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestWithTokenValidation(t *testing.T) {
+	validToken := "ghp_" + strings.Repeat("a1B2", 9)
+
+	tests := []struct {
+		name           string
+		mode           TokenValidation
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "passes well-formed tokens on",
+			mode:           TokenValidationStrict,
+			authorization:  "Bearer " + validToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "passes requests without a token on",
+			mode:           TokenValidationStrict,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects malformed tokens",
+			mode:           TokenValidationLenient,
+			authorization:  "Bearer undefined",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "invalid GitHub token: the token is too short to be a GitHub token",
+		},
+		{
+			name:           "rejects other authorization schemes",
+			mode:           TokenValidationLenient,
+			authorization:  "token " + validToken,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "invalid GitHub token: the Authorization header must use the Bearer scheme",
+		},
+		{
+			name:           "accepts anything when disabled",
+			mode:           TokenValidationNone,
+			authorization:  "Bearer undefined",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reached := false
+			handler := withTokenValidation(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				reached = true
+				w.WriteHeader(http.StatusOK)
+			}), tc.mode)
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedStatus == http.StatusOK, reached, "the request should only reach the MCP server when it is accepted")
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, rec.Body.String(), tc.expectedBody)
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+			}
+		})
+	}
+}
+
+func TestWithTokenQueryParam(t *testing.T) {
+	tests := []struct {
+		name                string
+		allow               bool
+		target              string
+		authorization       string
+		expectedURI         string
+		expectedToken       string
+		expectedTokenExists bool
+	}{
+		{
+			name:                "moves the token parameter to the context",
+			allow:               true,
+			target:              "/mcp?token=ghp_query&sessionId=abc",
+			expectedURI:         "/mcp?sessionId=abc",
+			expectedToken:       "ghp_query",
+			expectedTokenExists: true,
+		},
+		{
+			name:                "accepts the bearer_token parameter",
+			allow:               true,
+			target:              "/sse?bearer_token=ghp_query",
+			expectedURI:         "/sse",
+			expectedToken:       "ghp_query",
+			expectedTokenExists: true,
+		},
+		{
+			name:                "prefers the Authorization header",
+			allow:               true,
+			target:              "/mcp?token=ghp_query",
+			authorization:       "Bearer ghp_header",
+			expectedURI:         "/mcp",
+			expectedToken:       "ghp_header",
+			expectedTokenExists: true,
+		},
+		{
+			name:        "ignores the parameter when not allowed",
+			target:      "/mcp?token=ghp_query",
+			expectedURI: "/mcp?token=ghp_query",
+		},
+		{
+			name:        "leaves requests without the parameter alone",
+			allow:       true,
+			target:      "/mcp?sessionId=abc",
+			expectedURI: "/mcp?sessionId=abc",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var reachedURI, reachedToken string
+			var reachedTokenExists bool
+			handler := withTokenQueryParam(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reachedURI = r.RequestURI
+				reachedToken, reachedTokenExists = tokenFromContext(extractTokenFromAuthHeader(r.Context(), r))
+				w.WriteHeader(http.StatusOK)
+			}), tc.allow)
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.expectedURI, reachedURI)
+			assert.Equal(t, tc.expectedTokenExists, reachedTokenExists)
+			assert.Equal(t, tc.expectedToken, reachedToken)
+		})
+	}
+}
+
+func TestTokenLacksWriteScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   *string
+		expected bool
+	}{
+		{name: "classic token with repo", scopes: gogithub.Ptr("read:org, repo"), expected: false},
+		{name: "classic token with a write scope", scopes: gogithub.Ptr("write:packages"), expected: false},
+		{name: "classic token with read scopes only", scopes: gogithub.Ptr("read:org, read:user"), expected: true},
+		{name: "classic token without scopes", scopes: gogithub.Ptr(""), expected: true},
+		{name: "fine-grained token", scopes: nil, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/user", r.URL.Path)
+				if tc.scopes != nil {
+					w.Header().Set("X-OAuth-Scopes", *tc.scopes)
+				}
+				_, _ = w.Write([]byte(`{"login": "octocat"}`))
+			}))
+			defer srv.Close()
+
+			client := gogithub.NewClient(nil)
+			client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+			lacksWriteScopes, err := tokenLacksWriteScopes(context.Background(), client)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, lacksWriteScopes)
+		})
+	}
+}
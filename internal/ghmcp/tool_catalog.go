@@ -0,0 +1,49 @@
+package ghmcp
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/github/github-mcp-server/pkg/github"
+)
+
+// ToolCatalogPath is the path the HTTP transports serve the tool catalog at.
+const ToolCatalogPath = "/tools.json"
+
+// withToolCatalog serves the catalog of the tools the server offers at ToolCatalogPath, and passes
+// other requests to next. The catalog is computed on each request, as toolsets can be enabled and
+// disabled at runtime.
+func withToolCatalog(next http.Handler, catalog *github.ToolCatalog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != ToolCatalogPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := catalog.MarshalIndent()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// exportToolCatalog writes the catalog of the tools the server offers to path.
+func exportToolCatalog(catalog *github.ToolCatalog, path string) error {
+	data, err := catalog.MarshalIndent()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // The catalog holds no secrets
+		return fmt.Errorf("failed to write tool catalog: %w", err)
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Exported the tool catalog to %s\n", path)
+	return nil
+}
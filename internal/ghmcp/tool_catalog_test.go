@@ -0,0 +1,111 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolCatalogReflectsConfiguration(t *testing.T) {
+	catalog := github.NewToolCatalog()
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"issues"},
+		ReadOnly:        true,
+		ToolCatalog:     catalog,
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	tools, err := catalog.Tools()
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+		assert.True(t, tool.ReadOnly, "%s is not read-only", tool.Name)
+		assert.Contains(t, []string{"issues", "batch", "watches"}, tool.Toolset, "%s is in an unexpected toolset", tool.Name)
+		assert.NotEmpty(t, tool.Description)
+		assert.True(t, json.Valid(tool.InputSchema))
+	}
+	assert.True(t, sort.StringsAreSorted(names), "the tools should be sorted by name")
+	assert.Contains(t, names, "get_issue")
+	assert.NotContains(t, names, "create_issue")
+
+	// The catalog lists the tools the server offers
+	response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	listed, ok := response.(mcp.JSONRPCResponse).Result.(mcp.ListToolsResult)
+	require.True(t, ok)
+	var listedNames []string
+	for _, tool := range listed.Tools {
+		listedNames = append(listedNames, tool.Name)
+	}
+	assert.ElementsMatch(t, listedNames, names)
+}
+
+func TestWithToolCatalog(t *testing.T) {
+	catalog := github.NewToolCatalog()
+	_, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"context"},
+		ToolCatalog:     catalog,
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := withToolCatalog(next, catalog)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ToolCatalogPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var tools []github.CatalogTool
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &tools))
+	assert.NotEmpty(t, tools)
+	expected, err := catalog.MarshalIndent()
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ToolCatalogPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+}
+
+func TestExportToolCatalog(t *testing.T) {
+	catalog := github.NewToolCatalog()
+	_, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "server-token",
+		EnabledToolsets: []string{"context"},
+		ToolCatalog:     catalog,
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "tools.json")
+	require.NoError(t, exportToolCatalog(catalog, path))
+	exported, err := os.ReadFile(path)
+	require.NoError(t, err)
+	expected, err := catalog.MarshalIndent()
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(exported))
+}
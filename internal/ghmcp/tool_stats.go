@@ -0,0 +1,71 @@
+package ghmcp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/github/github-mcp-server/pkg/github"
+)
+
+// ToolStatsPath is the path the HTTP transports serve the tool usage statistics at, when recorded.
+const ToolStatsPath = "/debug/toolstats"
+
+// newToolStats returns a tool usage recorder if usage is to be recorded, either because it was
+// asked for or because a report is to be written, and nil otherwise.
+func newToolStats(enabled bool, reportPath string) *github.ToolStats {
+	if !enabled && reportPath == "" {
+		return nil
+	}
+	return github.NewToolStats()
+}
+
+// withToolStats serves the tool usage statistics as JSON at ToolStatsPath when they are recorded,
+// and passes other requests to next.
+func withToolStats(next http.Handler, stats *github.ToolStats) http.Handler {
+	if stats == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != ToolStatsPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := stats.MarshalIndent()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// reportToolStats writes the tool usage summary table to out on shutdown and, if reportPath is
+// set, the JSON report to reportPath. It does nothing if usage was not recorded.
+func reportToolStats(stats *github.ToolStats, out io.Writer, reportPath string) error {
+	if stats == nil {
+		return nil
+	}
+	_, _ = fmt.Fprintln(out, "Tool usage:")
+	if err := stats.WriteTable(out); err != nil {
+		return fmt.Errorf("failed to write tool stats: %w", err)
+	}
+	if reportPath == "" {
+		return nil
+	}
+	data, err := stats.MarshalIndent()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(reportPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write tool stats report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package ghmcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithToolStats(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	// Without a recorder, the path is not served
+	recorder := httptest.NewRecorder()
+	withToolStats(next, newToolStats(false, "")).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ToolStatsPath, nil))
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+
+	handler := withToolStats(next, newToolStats(true, ""))
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ToolStatsPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var report github.ToolStatsReport
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Empty(t, report.Tools)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ToolStatsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+}
+
+func TestReportToolStats(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, reportToolStats(nil, &out, ""))
+	assert.Empty(t, out.String())
+
+	path := filepath.Join(t.TempDir(), "toolstats.json")
+	stats := newToolStats(false, path)
+	require.NotNil(t, stats)
+	require.NoError(t, reportToolStats(stats, &out, path))
+	assert.Equal(t, "Tool usage:\nNo tools were called\n", out.String())
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report github.ToolStatsReport
+	require.NoError(t, json.Unmarshal(written, &report))
+	assert.Empty(t, report.Tools)
+}
@@ -0,0 +1,68 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// userAgents holds the User-Agent of the requests made for each session, which names the client
+// of the session once it initialized, so that the client info of one session is not sent with the
+// requests of another.
+type userAgents struct {
+	mu sync.Mutex
+	// defaultAgent is sent with the requests of sessions that did not initialize, and of no session.
+	defaultAgent string
+	agents       map[string]string
+}
+
+func newUserAgents(defaultAgent string) *userAgents {
+	return &userAgents{defaultAgent: defaultAgent, agents: make(map[string]string)}
+}
+
+// set stores the User-Agent of the session of ctx, if any.
+func (u *userAgents) set(ctx context.Context, agent string) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.agents[session.SessionID()] = agent
+}
+
+// forget drops the User-Agent of a session, when it ends.
+func (u *userAgents) forget(sessionID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.agents, sessionID)
+}
+
+// fromContext returns the User-Agent of the session of ctx, or the default one.
+func (u *userAgents) fromContext(ctx context.Context) string {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return u.defaultAgent
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if agent, ok := u.agents[session.SessionID()]; ok {
+		return agent
+	}
+	return u.defaultAgent
+}
+
+// userAgentTransport sets the User-Agent of the session a request is made for, which the clients
+// of the server and those made for the token of a request all share.
+type userAgentTransport struct {
+	transport http.RoundTripper
+	agents    *userAgents
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.agents.fromContext(req.Context()))
+	return t.transport.RoundTrip(req)
+}
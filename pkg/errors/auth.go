@@ -0,0 +1,150 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/tokens"
+	"github.com/google/go-github/v74/github"
+)
+
+// authErrorHint explains an authentication, permission or rate limit failure so that it can be acted
+// on, based on the status and headers of the response. It returns an empty string for other failures.
+func authErrorHint(resp *github.Response, err error) string {
+	// go-github fails requests it knows will be rate limited without sending them, so check its errors
+	// before the response, which then carries no headers.
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitHint(strconv.Itoa(rateLimitErr.Rate.Limit), rateLimitErr.Rate.Reset.Time)
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) && abuseErr.RetryAfter != nil {
+		return secondaryRateLimitHint(*abuseErr.RetryAfter)
+	}
+
+	if resp == nil || resp.Response == nil {
+		return ""
+	}
+	header := resp.Header
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return "The GitHub token is invalid or expired. Check that it is set correctly, or create a new one."
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				return rateLimitHint(header.Get("X-RateLimit-Limit"), time.Unix(reset, 0))
+			}
+		}
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+			return secondaryRateLimitHint(time.Duration(seconds) * time.Second)
+		}
+		if isSecondaryRateLimitError(err) {
+			// GitHub asks to wait at least a minute when it does not say how long
+			return secondaryRateLimitHint(time.Minute)
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return "The GitHub API rate limit was exceeded. Wait before retrying."
+		}
+	default:
+		return ""
+	}
+
+	if sso := header.Get("X-GitHub-SSO"); strings.HasPrefix(sso, "required") {
+		if _, url, ok := strings.Cut(sso, "url="); ok {
+			return fmt.Sprintf("The organization requires SAML single sign-on. Authorize the token for the organization at %s.", url)
+		}
+		return "The organization requires SAML single sign-on. Authorize the token for the organization."
+	}
+	if token := tokens.FromRequest(resp.Request); tokens.IsFineGrained(token) {
+		return fineGrainedPermissionsHint(token, header)
+	}
+	if hint := missingScopesHint(header); hint != "" {
+		return hint
+	}
+	if permissions := header.Get("X-Accepted-GitHub-Permissions"); permissions != "" {
+		return fmt.Sprintf("The token lacks the permissions this action requires: %s.", permissions)
+	}
+	return "The token does not have permission to perform this action. Check the token's scopes or permissions and its access to the repository or organization."
+}
+
+// fineGrainedPermissionsHint explains a permission failure of a fine-grained personal access token,
+// which has repository permissions instead of scopes, with the permissions it was found to have.
+func fineGrainedPermissionsHint(token string, header http.Header) string {
+	hint := "The fine-grained token lacks a permission this action requires, or was not granted access to the repository."
+	if permissions := header.Get("X-Accepted-GitHub-Permissions"); permissions != "" {
+		hint = fmt.Sprintf("The fine-grained token lacks the permissions this action requires: %s.", permissions)
+	}
+	if permissions, ok := tokens.DefaultCache.Get(token); ok && permissions.Repository != "" {
+		return fmt.Sprintf("%s On %s it has: %s.", hint, permissions.Repository, permissions.Summary())
+	}
+	return hint + " Use get_me to see the permissions it has."
+}
+
+// missingScopesHint reports the OAuth scopes a classic token needs when it has none of those the
+// endpoint accepts.
+func missingScopesHint(header http.Header) string {
+	// Only classic tokens report their scopes, even when they have none.
+	if _, classic := header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; !classic {
+		return ""
+	}
+	accepted := splitScopes(header.Get("X-Accepted-OAuth-Scopes"))
+	if len(accepted) == 0 {
+		return ""
+	}
+	scopes := splitScopes(header.Get("X-OAuth-Scopes"))
+	for _, scope := range accepted {
+		if slices.Contains(scopes, scope) {
+			return ""
+		}
+	}
+
+	has := "none"
+	if len(scopes) > 0 {
+		has = strings.Join(scopes, ", ")
+	}
+	if len(accepted) == 1 {
+		return fmt.Sprintf("The token lacks the %s scope (it has: %s).", accepted[0], has)
+	}
+	return fmt.Sprintf("The token lacks a required scope, one of %s (it has: %s).", strings.Join(accepted, ", "), has)
+}
+
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func rateLimitHint(limit string, reset time.Time) string {
+	return fmt.Sprintf("The GitHub API rate limit of %s requests was exceeded. It resets at %s.", limit, reset.UTC().Format(time.RFC3339))
+}
+
+func secondaryRateLimitHint(retryAfter time.Duration) string {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	return fmt.Sprintf("A GitHub secondary rate limit was exceeded, retry after %d seconds. Make fewer requests at once.", seconds)
+}
+
+// isSecondaryRateLimitError reports whether err is GitHub refusing a request for exceeding a secondary
+// rate limit. go-github only recognizes these by their documentation URL, so the message is checked too.
+func isSecondaryRateLimitError(err error) bool {
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return true
+	}
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		return false
+	}
+	message := strings.ToLower(errorResponse.Message)
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection")
+}
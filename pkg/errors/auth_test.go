@@ -0,0 +1,199 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/tokens"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthErrorHint(t *testing.T) {
+	reset := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	probedToken := "github_pat_probed"
+	tokens.DefaultCache.Set(probedToken, &tokens.Permissions{
+		Repository: "octo/repo",
+		Permissions: []tokens.Permission{
+			{Name: "metadata", Access: tokens.AccessRead},
+			{Name: "issues", Access: tokens.AccessRead},
+			{Name: "actions", Access: tokens.AccessNone},
+		},
+		ProbedAt: time.Now(),
+	})
+
+	tests := []struct {
+		name     string
+		status   int
+		headers  map[string]string
+		token    string
+		err      error
+		expected string
+	}{
+		{
+			name:     "invalid or expired token",
+			status:   http.StatusUnauthorized,
+			expected: "The GitHub token is invalid or expired. Check that it is set correctly, or create a new one.",
+		},
+		{
+			name:   "primary rate limit",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     fmt.Sprint(reset.Unix()),
+			},
+			expected: "The GitHub API rate limit of 5000 requests was exceeded. It resets at 2025-06-01T12:30:00Z.",
+		},
+		{
+			name:   "primary rate limit with 429",
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "60",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     fmt.Sprint(reset.Unix()),
+			},
+			expected: "The GitHub API rate limit of 60 requests was exceeded. It resets at 2025-06-01T12:30:00Z.",
+		},
+		{
+			name:     "secondary rate limit",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"Retry-After": "60"},
+			expected: "A GitHub secondary rate limit was exceeded, retry after 60 seconds. Make fewer requests at once.",
+		},
+		{
+			name:     "too many requests without headers",
+			status:   http.StatusTooManyRequests,
+			expected: "The GitHub API rate limit was exceeded. Wait before retrying.",
+		},
+		{
+			name:     "rate limit known before the request",
+			status:   http.StatusForbidden,
+			err:      &github.RateLimitError{Rate: github.Rate{Limit: 5000, Reset: github.Timestamp{Time: reset}}},
+			expected: "The GitHub API rate limit of 5000 requests was exceeded. It resets at 2025-06-01T12:30:00Z.",
+		},
+		{
+			name:     "secondary rate limit error",
+			status:   http.StatusForbidden,
+			err:      &github.AbuseRateLimitError{RetryAfter: github.Ptr(30 * time.Second)},
+			expected: "A GitHub secondary rate limit was exceeded, retry after 30 seconds. Make fewer requests at once.",
+		},
+		{
+			name:     "secondary rate limit recognized by its message",
+			status:   http.StatusForbidden,
+			err:      &github.ErrorResponse{Message: "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."},
+			expected: "A GitHub secondary rate limit was exceeded, retry after 60 seconds. Make fewer requests at once.",
+		},
+		{
+			name:   "missing scope",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-Accepted-OAuth-Scopes": "admin:org",
+				"X-OAuth-Scopes":          "repo, read:org",
+			},
+			expected: "The token lacks the admin:org scope (it has: repo, read:org).",
+		},
+		{
+			name:   "missing one of several scopes",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-Accepted-OAuth-Scopes": "repo, public_repo",
+				"X-OAuth-Scopes":          "",
+			},
+			expected: "The token lacks a required scope, one of repo, public_repo (it has: none).",
+		},
+		{
+			name:     "missing fine-grained permission",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"X-Accepted-GitHub-Permissions": "contents=write"},
+			expected: "The token lacks the permissions this action requires: contents=write.",
+		},
+		{
+			name:     "SAML single sign-on",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"X-GitHub-SSO": "required; url=https://github.com/orgs/octo/sso?authorization_request=abc"},
+			expected: "The organization requires SAML single sign-on. Authorize the token for the organization at https://github.com/orgs/octo/sso?authorization_request=abc.",
+		},
+		{
+			name:   "forbidden with the accepted scope",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-Accepted-OAuth-Scopes": "repo",
+				"X-OAuth-Scopes":          "repo",
+			},
+			expected: "The token does not have permission to perform this action. Check the token's scopes or permissions and its access to the repository or organization.",
+		},
+		{
+			name:     "fine-grained token without probed permissions",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"X-Accepted-OAuth-Scopes": "repo"},
+			token:    "github_pat_unprobed",
+			expected: "The fine-grained token lacks a permission this action requires, or was not granted access to the repository. Use get_me to see the permissions it has.",
+		},
+		{
+			name:     "fine-grained token with probed permissions",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"X-Accepted-GitHub-Permissions": "actions=read"},
+			token:    probedToken,
+			expected: "The fine-grained token lacks the permissions this action requires: actions=read. On octo/repo it has: metadata=read, issues=read, actions=none.",
+		},
+		{
+			name:     "accepted scopes without token scopes",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"X-Accepted-OAuth-Scopes": "repo"},
+			expected: "The token does not have permission to perform this action. Check the token's scopes or permissions and its access to the repository or organization.",
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tc.headers {
+				header.Set(key, value)
+			}
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/octo/repo/actions/runs", nil)
+			require.NoError(t, err)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			resp := &github.Response{Response: &http.Response{StatusCode: tc.status, Header: header, Request: req}}
+
+			assert.Equal(t, tc.expected, authErrorHint(resp, tc.err))
+		})
+	}
+
+	t.Run("no response", func(t *testing.T) {
+		assert.Empty(t, authErrorHint(nil, fmt.Errorf("connection refused")))
+	})
+}
+
+func TestNewGitHubAPIErrorResponseExplainsAuthFailures(t *testing.T) {
+	ctx := ContextWithGitHubErrors(context.Background())
+	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}}
+
+	result := NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, fmt.Errorf("401 Bad credentials"))
+
+	require.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "failed to get issue: 401 Bad credentials\nThe GitHub token is invalid or expired. Check that it is set correctly, or create a new one.", result.Content[0].(mcp.TextContent).Text)
+
+	// The error retained for middleware is unchanged
+	apiErrors, err := GetGitHubAPIErrors(ctx)
+	require.NoError(t, err)
+	require.Len(t, apiErrors, 1)
+	assert.Equal(t, "failed to get issue: 401 Bad credentials", apiErrors[0].Error())
+}
@@ -3,6 +3,9 @@ package errors
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -44,7 +47,11 @@ func (e *GitHubGraphQLError) Error() string {
 }
 
 type GitHubErrorKey struct{}
+
+// GitHubCtxErrors holds the errors of a request. Handlers may record errors from several goroutines
+// at once, such as the tasks of a batch tool, so it is guarded by a mutex.
 type GitHubCtxErrors struct {
+	mu      sync.Mutex
 	api     []*GitHubAPIError
 	graphQL []*GitHubGraphQLError
 }
@@ -56,8 +63,10 @@ func ContextWithGitHubErrors(ctx context.Context) context.Context {
 	}
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
 		// If the context already has GitHubCtxErrors, we just empty the slices to start fresh
+		val.mu.Lock()
 		val.api = []*GitHubAPIError{}
 		val.graphQL = []*GitHubGraphQLError{}
+		val.mu.Unlock()
 	} else {
 		// If not, we create a new GitHubCtxErrors and set it in the context
 		ctx = context.WithValue(ctx, GitHubErrorKey{}, &GitHubCtxErrors{})
@@ -69,7 +78,9 @@ func ContextWithGitHubErrors(ctx context.Context) context.Context {
 // GetGitHubAPIErrors retrieves the slice of GitHubAPIErrors from the context.
 func GetGitHubAPIErrors(ctx context.Context) ([]*GitHubAPIError, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
-		return val.api, nil // return the slice of API errors from the context
+		val.mu.Lock()
+		defer val.mu.Unlock()
+		return slices.Clone(val.api), nil // return a copy of the API errors, which may still be added to
 	}
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
 }
@@ -77,7 +88,9 @@ func GetGitHubAPIErrors(ctx context.Context) ([]*GitHubAPIError, error) {
 // GetGitHubGraphQLErrors retrieves the slice of GitHubGraphQLErrors from the context.
 func GetGitHubGraphQLErrors(ctx context.Context) ([]*GitHubGraphQLError, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
-		return val.graphQL, nil // return the slice of GraphQL errors from the context
+		val.mu.Lock()
+		defer val.mu.Unlock()
+		return slices.Clone(val.graphQL), nil // return a copy of the GraphQL errors, which may still be added to
 	}
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
 }
@@ -92,6 +105,8 @@ func NewGitHubAPIErrorToCtx(ctx context.Context, message string, resp *github.Re
 
 func addGitHubAPIErrorToContext(ctx context.Context, err *GitHubAPIError) (context.Context, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
+		val.mu.Lock()
+		defer val.mu.Unlock()
 		val.api = append(val.api, err) // append the error to the existing slice in the context
 		return ctx, nil
 	}
@@ -100,19 +115,46 @@ func addGitHubAPIErrorToContext(ctx context.Context, err *GitHubAPIError) (conte
 
 func addGitHubGraphQLErrorToContext(ctx context.Context, err *GitHubGraphQLError) (context.Context, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
+		val.mu.Lock()
+		defer val.mu.Unlock()
 		val.graphQL = append(val.graphQL, err) // append the error to the existing slice in the context
 		return ctx, nil
 	}
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
 }
 
-// NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
+// NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware.
+// Authentication, permission and rate limit failures are followed by an explanation of how to resolve them,
+// and validation failures by the fields that were rejected.
+// The result also carries the error as a ToolError in its structured content.
 func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
 	apiErr := newGitHubAPIError(message, resp, err)
 	if ctx != nil {
 		_, _ = addGitHubAPIErrorToContext(ctx, apiErr) // Explicitly ignore error for graceful handling
 	}
-	return mcp.NewToolResultErrorFromErr(message, err)
+
+	var hints []string
+	for _, hint := range []string{authErrorHint(resp, err), validationErrorHint(err)} {
+		if hint != "" {
+			hints = append(hints, hint)
+		}
+	}
+
+	var result *mcp.CallToolResult
+	switch hint := strings.Join(hints, "\n"); {
+	case hint != "" && err == nil:
+		result = mcp.NewToolResultError(message + "\n" + hint)
+	case hint != "":
+		result = mcp.NewToolResultErrorFromErr(message, fmt.Errorf("%w\n%s", err, hint))
+	default:
+		result = mcp.NewToolResultErrorFromErr(message, err)
+	}
+	toolErr := toolErrorResult{Error: NewToolError(resp, err)}
+	result.StructuredContent = toolErr
+	// The version of mcp-go in use does not send structuredContent to clients, so the error is also
+	// sent in _meta.
+	result.Meta = map[string]any{"error": toolErr.Error}
+	return result
 }
 
 // NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/google/go-github/v74/github"
@@ -97,6 +98,34 @@ func TestGitHubErrorContext(t *testing.T) {
 		assert.Equal(t, "graphql error", gqlErrors[0].Message)
 	})
 
+	t.Run("errors can be added from several goroutines at once", func(t *testing.T) {
+		// Given a context with GitHub error tracking enabled
+		ctx := ContextWithGitHubErrors(context.Background())
+
+		// When many goroutines add errors at once, as the tasks of batch tools do
+		resp := &github.Response{Response: &http.Response{StatusCode: 500}}
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = NewGitHubAPIErrorToCtx(ctx, fmt.Sprintf("error %d", i), resp, fmt.Errorf("server error"))
+				_ = NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("error %d", i), fmt.Errorf("query failed"))
+				_, _ = GetGitHubAPIErrors(ctx)
+			}()
+		}
+		wg.Wait()
+
+		// Then every error is kept
+		apiErrors, err := GetGitHubAPIErrors(ctx)
+		require.NoError(t, err)
+		assert.Len(t, apiErrors, 20)
+
+		gqlErrors, err := GetGitHubGraphQLErrors(ctx)
+		require.NoError(t, err)
+		assert.Len(t, gqlErrors, 20)
+	})
+
 	t.Run("context pointer sharing allows middleware to inspect errors without context propagation", func(t *testing.T) {
 		// This test demonstrates the key behavior: even when the context itself
 		// isn't propagated through function calls, the pointer to the error slice
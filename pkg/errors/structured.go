@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// Codes of ToolError, derived from the status of the GitHub API response.
+const (
+	CodeBadRequest       = "bad_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeValidationFailed = "validation_failed"
+	CodeRateLimited      = "rate_limited"
+	CodeServerError      = "server_error"
+	// CodeRequestFailed is for requests that got no response, such as on network errors.
+	CodeRequestFailed = "request_failed"
+	// CodeBudgetExhausted is for requests the server did not make because the session used up its
+	// request budget.
+	CodeBudgetExhausted = "budget_exhausted"
+	CodeUnknown         = "unknown"
+)
+
+// ToolError describes a failed GitHub API request so that callers can decide whether to retry, fix
+// their input or ask the user. It is the structured content of tool error results.
+type ToolError struct {
+	Code             string `json:"code"`
+	Message          string `json:"message"`
+	HTTPStatus       int    `json:"http_status,omitempty"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+	Retryable        bool   `json:"retryable"`
+	// RetryAfterSeconds is how long to wait before retrying, when known.
+	RetryAfterSeconds int          `json:"retry_after_seconds,omitempty"`
+	FieldErrors       []FieldError `json:"field_errors,omitempty"`
+}
+
+// toolErrorer is implemented by errors that describe themselves as a ToolError, such as those of
+// requests the server refused to make.
+type toolErrorer interface {
+	error
+	ToolError() ToolError
+}
+
+// FieldError is a problem with one field of a request GitHub rejected as invalid.
+type FieldError struct {
+	Resource string `json:"resource,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Code     string `json:"code"`
+	Message  string `json:"message,omitempty"`
+}
+
+// toolErrorResult is the structured content of tool error results.
+type toolErrorResult struct {
+	Error ToolError `json:"error"`
+}
+
+// NewToolError derives the structured description of a failed GitHub API request from its response
+// and the error go-github returned for it.
+func NewToolError(resp *github.Response, err error) ToolError {
+	toolErr := ToolError{Code: CodeUnknown}
+	if err != nil {
+		toolErr.Message = err.Error()
+	}
+	if resp != nil && resp.Response != nil {
+		toolErr.HTTPStatus = resp.StatusCode
+	}
+
+	var toolErrorer toolErrorer
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	var errorResponse *github.ErrorResponse
+	switch {
+	case errors.As(err, &toolErrorer):
+		return toolErrorer.ToolError()
+	case errors.As(err, &rateLimitErr):
+		toolErr.Message = rateLimitErr.Message
+		toolErr.Code = CodeRateLimited
+		toolErr.Retryable = true
+		return toolErr
+	case errors.As(err, &abuseErr):
+		toolErr.Message = abuseErr.Message
+		toolErr.Code = CodeRateLimited
+		toolErr.Retryable = true
+		return toolErr
+	case errors.As(err, &errorResponse):
+		toolErr.Message = errorResponse.Message
+		toolErr.DocumentationURL = errorResponse.DocumentationURL
+		for _, fieldErr := range errorResponse.Errors {
+			toolErr.FieldErrors = append(toolErr.FieldErrors, FieldError{
+				Resource: fieldErr.Resource,
+				Field:    fieldErr.Field,
+				Code:     fieldErr.Code,
+				Message:  fieldErr.Message,
+			})
+		}
+		if errorResponse.Response != nil {
+			toolErr.HTTPStatus = errorResponse.Response.StatusCode
+		}
+		if isSecondaryRateLimitError(err) {
+			toolErr.Code = CodeRateLimited
+			toolErr.Retryable = true
+			return toolErr
+		}
+	}
+
+	if toolErr.HTTPStatus == 0 {
+		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			toolErr.Code = CodeRequestFailed
+			toolErr.Retryable = true
+		}
+		return toolErr
+	}
+
+	switch status := toolErr.HTTPStatus; {
+	case status == http.StatusBadRequest:
+		toolErr.Code = CodeBadRequest
+	case status == http.StatusUnauthorized:
+		toolErr.Code = CodeUnauthorized
+	case status == http.StatusForbidden:
+		toolErr.Code = CodeForbidden
+	case status == http.StatusNotFound:
+		toolErr.Code = CodeNotFound
+	case status == http.StatusConflict:
+		toolErr.Code = CodeConflict
+	case status == http.StatusUnprocessableEntity:
+		toolErr.Code = CodeValidationFailed
+	case status == http.StatusTooManyRequests:
+		toolErr.Code = CodeRateLimited
+		toolErr.Retryable = true
+	case status >= http.StatusInternalServerError:
+		toolErr.Code = CodeServerError
+		// 501 Not Implemented will not succeed on retry
+		toolErr.Retryable = status != http.StatusNotImplemented
+	}
+	return toolErr
+}
@@ -0,0 +1,161 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getIssueError gets an issue from a server that responds with status and body, and returns the
+// response and error go-github reports.
+func getIssueError(t *testing.T, status int, body string) (*github.Response, error) {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	_, resp, err := client.Issues.Get(context.Background(), "owner", "repo", 1)
+	require.Error(t, err)
+	return resp, err
+}
+
+// structuredError returns the structured content of a tool result, after checking that clients
+// receive the same error in _meta.
+func structuredError(t *testing.T, result *mcp.CallToolResult) ToolError {
+	t.Helper()
+
+	structured, ok := result.StructuredContent.(toolErrorResult)
+	require.True(t, ok, "unexpected structured content %T", result.StructuredContent)
+
+	payload, err := json.Marshal(result)
+	require.NoError(t, err)
+	var decoded struct {
+		Meta struct {
+			Error ToolError `json:"error"`
+		} `json:"_meta"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, structured.Error, decoded.Meta.Error)
+
+	return structured.Error
+}
+
+func TestNewGitHubAPIErrorResponseStructuredContent(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		resp, err := getIssueError(t, http.StatusNotFound, `{"message": "Not Found", "documentation_url": "https://docs.github.com/rest/issues/issues#get-an-issue"}`)
+
+		result := NewGitHubAPIErrorResponse(context.Background(), "failed to get issue", resp, err)
+
+		require.True(t, result.IsError)
+		// The text is unchanged for clients that do not read structured content
+		assert.Equal(t, fmt.Sprintf("failed to get issue: %s", err), result.Content[0].(mcp.TextContent).Text)
+		assert.Equal(t, ToolError{
+			Code:             CodeNotFound,
+			Message:          "Not Found",
+			HTTPStatus:       http.StatusNotFound,
+			DocumentationURL: "https://docs.github.com/rest/issues/issues#get-an-issue",
+			Retryable:        false,
+		}, structuredError(t, result))
+	})
+
+	t.Run("validation failed with field errors", func(t *testing.T) {
+		resp, err := getIssueError(t, http.StatusUnprocessableEntity, `{
+			"message": "Validation Failed",
+			"errors": [
+				{"resource": "Issue", "field": "title", "code": "missing_field"},
+				{"resource": "Issue", "field": "assignees", "code": "custom", "message": "octocat cannot be assigned"}
+			],
+			"documentation_url": "https://docs.github.com/rest/issues/issues#create-an-issue"
+		}`)
+
+		result := NewGitHubAPIErrorResponse(context.Background(), "failed to create issue", resp, err)
+
+		require.True(t, result.IsError)
+		assert.Equal(t, ToolError{
+			Code:             CodeValidationFailed,
+			Message:          "Validation Failed",
+			HTTPStatus:       http.StatusUnprocessableEntity,
+			DocumentationURL: "https://docs.github.com/rest/issues/issues#create-an-issue",
+			Retryable:        false,
+			FieldErrors: []FieldError{
+				{Resource: "Issue", Field: "title", Code: "missing_field"},
+				{Resource: "Issue", Field: "assignees", Code: "custom", Message: "octocat cannot be assigned"},
+			},
+		}, structuredError(t, result))
+	})
+}
+
+func TestNewToolError(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            int
+		body              string
+		expectedCode      string
+		expectedRetryable bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, body: `{"message": "Problems parsing JSON"}`, expectedCode: CodeBadRequest},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{"message": "Bad credentials"}`, expectedCode: CodeUnauthorized},
+		{name: "forbidden", status: http.StatusForbidden, body: `{"message": "Resource not accessible by integration"}`, expectedCode: CodeForbidden},
+		{name: "conflict", status: http.StatusConflict, body: `{"message": "Git Repository is empty."}`, expectedCode: CodeConflict},
+		{name: "too many requests", status: http.StatusTooManyRequests, body: `{"message": "Too many requests"}`, expectedCode: CodeRateLimited, expectedRetryable: true},
+		{name: "secondary rate limit", status: http.StatusForbidden, body: `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`, expectedCode: CodeRateLimited, expectedRetryable: true},
+		{name: "server error", status: http.StatusBadGateway, body: `{"message": "Server Error"}`, expectedCode: CodeServerError, expectedRetryable: true},
+		{name: "not implemented", status: http.StatusNotImplemented, body: `{"message": "Not Implemented"}`, expectedCode: CodeServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := getIssueError(t, tc.status, tc.body)
+
+			toolErr := NewToolError(resp, err)
+			assert.Equal(t, tc.expectedCode, toolErr.Code)
+			assert.Equal(t, tc.status, toolErr.HTTPStatus)
+			assert.Equal(t, tc.expectedRetryable, toolErr.Retryable)
+			assert.NotEmpty(t, toolErr.Message)
+		})
+	}
+
+	t.Run("primary rate limit", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo/issues/1", nil)
+		require.NoError(t, err)
+		rateLimitErr := &github.RateLimitError{
+			Response: &http.Response{StatusCode: http.StatusForbidden, Request: req},
+			Message:  "API rate limit exceeded",
+		}
+		resp := &github.Response{Response: rateLimitErr.Response}
+
+		toolErr := NewToolError(resp, rateLimitErr)
+		assert.Equal(t, CodeRateLimited, toolErr.Code)
+		assert.Equal(t, http.StatusForbidden, toolErr.HTTPStatus)
+		assert.True(t, toolErr.Retryable)
+		assert.Equal(t, "API rate limit exceeded", toolErr.Message)
+	})
+
+	t.Run("request without response", func(t *testing.T) {
+		toolErr := NewToolError(nil, fmt.Errorf("dial tcp: connection refused"))
+		assert.Equal(t, CodeRequestFailed, toolErr.Code)
+		assert.Zero(t, toolErr.HTTPStatus)
+		assert.True(t, toolErr.Retryable)
+	})
+
+	t.Run("cancelled request", func(t *testing.T) {
+		toolErr := NewToolError(nil, fmt.Errorf("request failed: %w", context.Canceled))
+		assert.Equal(t, CodeUnknown, toolErr.Code)
+		assert.False(t, toolErr.Retryable)
+	})
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// fieldErrorCodes explain the codes of the field errors of GitHub validation failures.
+var fieldErrorCodes = map[string]string{
+	"missing":        "the resource does not exist",
+	"missing_field":  "the field is required but was not set",
+	"invalid":        "the value is not formatted correctly",
+	"already_exists": "another resource already has this value",
+	"unprocessable":  "the value is not valid",
+}
+
+// validationErrorHint lists the fields GitHub rejected a request for, with why, so that the input can
+// be fixed. It returns an empty string when the error has no field errors.
+func validationErrorHint(err error) string {
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) || len(errorResponse.Errors) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("GitHub rejected these fields:")
+	for _, fieldErr := range errorResponse.Errors {
+		b.WriteString("\n- ")
+		switch {
+		case fieldErr.Field != "" && fieldErr.Resource != "":
+			b.WriteString(fieldErr.Resource + "." + fieldErr.Field)
+		case fieldErr.Field != "":
+			b.WriteString(fieldErr.Field)
+		case fieldErr.Resource != "":
+			b.WriteString(fieldErr.Resource)
+		default:
+			b.WriteString("request")
+		}
+		b.WriteString(": " + fieldErr.Code)
+		if explanation, ok := fieldErrorCodes[fieldErr.Code]; ok {
+			b.WriteString(" (" + explanation + ")")
+		}
+		if fieldErr.Message != "" {
+			b.WriteString(": " + fieldErr.Message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrorHint(t *testing.T) {
+	t.Run("lists every rejected field", func(t *testing.T) {
+		resp, err := getIssueError(t, http.StatusUnprocessableEntity, `{
+			"message": "Validation Failed",
+			"errors": [
+				{"resource": "Issue", "field": "title", "code": "missing_field"},
+				{"resource": "Label", "field": "name", "code": "already_exists"},
+				{"resource": "Issue", "field": "assignees", "code": "custom", "message": "octocat cannot be assigned"},
+				{"code": "custom", "message": "Only 10 labels are allowed"}
+			]
+		}`)
+
+		result := NewGitHubAPIErrorResponse(context.Background(), "failed to create issue", resp, err)
+
+		require.True(t, result.IsError)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "failed to create issue: ")
+		assert.Contains(t, text, "\nGitHub rejected these fields:\n"+
+			"- Issue.title: missing_field (the field is required but was not set)\n"+
+			"- Label.name: already_exists (another resource already has this value)\n"+
+			"- Issue.assignees: custom: octocat cannot be assigned\n"+
+			"- request: custom: Only 10 labels are allowed")
+	})
+
+	t.Run("adds nothing without field errors", func(t *testing.T) {
+		resp, err := getIssueError(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`)
+		assert.Empty(t, validationErrorHint(err))
+
+		result := NewGitHubAPIErrorResponse(context.Background(), "failed to create issue", resp, err)
+		assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "rejected")
+	})
+}
@@ -1,15 +1,19 @@
 package github
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,10 +25,54 @@ const (
 	DescriptionRepositoryName  = "Repository name"
 )
 
-// ListWorkflows creates a tool to list workflows in a repository
+// WorkflowSummary is a workflow of a repository. State is active, deleted, or one of the disabled
+// states: disabled_fork, disabled_inactivity and disabled_manually.
+type WorkflowSummary struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	State    string `json:"state"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+func newWorkflowSummary(workflow *github.Workflow) WorkflowSummary {
+	return WorkflowSummary{
+		ID:       workflow.GetID(),
+		Name:     workflow.GetName(),
+		Path:     workflow.GetPath(),
+		State:    workflow.GetState(),
+		Disabled: strings.HasPrefix(workflow.GetState(), "disabled"),
+	}
+}
+
+// WorkflowList is the output type of the list_workflows tool.
+type WorkflowList struct {
+	TotalCount int               `json:"total_count"`
+	Workflows  []WorkflowSummary `json:"workflows"`
+}
+
+// WorkflowDetails is the output type of the get_workflow tool.
+type WorkflowDetails struct {
+	WorkflowSummary
+	HTMLURL   string `json:"html_url"`
+	BadgeURL  string `json:"badge_url,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// WorkflowFile is the output type of the get_workflow_file tool.
+type WorkflowFile struct {
+	WorkflowSummary
+	Ref       string `json:"ref,omitempty"`
+	SHA       string `json:"sha"`
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// ListWorkflows creates a tool to list workflows in a repository, with their state
 func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflows",
-			mcp.WithDescription(t("TOOL_LIST_WORKFLOWS_DESCRIPTION", "List workflows in a repository")),
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOWS_DESCRIPTION", "List workflows in a repository, with their ID, name, path and state (active, disabled_manually, disabled_inactivity, disabled_fork or deleted)")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_WORKFLOWS_USER_TITLE", "List workflows"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -72,13 +120,282 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflows)
+			list := WorkflowList{TotalCount: workflows.GetTotalCount(), Workflows: make([]WorkflowSummary, 0, len(workflows.Workflows))}
+			for _, workflow := range workflows.Workflows {
+				list.Workflows = append(list.Workflows, newWorkflowSummary(workflow))
+			}
+
+			r, err := json.Marshal(list)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// getWorkflow gets a workflow by its numeric ID or by the name of its file.
+func getWorkflow(ctx context.Context, client *github.Client, owner, repo, workflowID string) (*github.Workflow, *github.Response, error) {
+	if id, err := strconv.ParseInt(workflowID, 10, 64); err == nil {
+		return client.Actions.GetWorkflowByID(ctx, owner, repo, id)
+	}
+	return client.Actions.GetWorkflowByFileName(ctx, owner, repo, workflowID)
+}
+
+// GetWorkflow creates a tool to get a workflow of a repository
+func GetWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_DESCRIPTION", "Get a workflow of a repository by its ID or file name, with its path and whether it is active or disabled")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_USER_TITLE", "Get workflow"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			workflow, resp, err := getWorkflow(ctx, client, owner, repo, workflowID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			details := WorkflowDetails{
+				WorkflowSummary: newWorkflowSummary(workflow),
+				HTMLURL:         workflow.GetHTMLURL(),
+				BadgeURL:        workflow.GetBadgeURL(),
+			}
+			if workflow.CreatedAt != nil {
+				details.CreatedAt = workflow.CreatedAt.Format(time.RFC3339)
+			}
+			if workflow.UpdatedAt != nil {
+				details.UpdatedAt = workflow.UpdatedAt.Format(time.RFC3339)
+			}
+			return MarshalledTextResult(details), nil
+		}
+}
+
+// GetWorkflowFile creates a tool to read the YAML definition of a workflow
+func GetWorkflowFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_file",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_FILE_DESCRIPTION", fmt.Sprintf("Read the YAML definition of a workflow by its ID or file name, up to %d bytes. Disabled workflows are read too, and reported as disabled.", raw.MaxRawContentBytes))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_FILE_USER_TITLE", "Get workflow file"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to read the workflow file at. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			workflow, resp, err := getWorkflow(ctx, client, owner, repo, workflowID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflow.GetPath(), &github.RepositoryContentGetOptions{Ref: ref})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					// Deleted workflows are still listed, but their file is gone
+					return mcp.NewToolResultError(fmt.Sprintf("workflow file %s of workflow %q (state %s) does not exist at %s", workflow.GetPath(), workflow.GetName(), workflow.GetState(), cmp.Or(ref, "the default branch"))), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow file", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, not a workflow file", workflow.GetPath())), nil
+			}
+			content, err := file.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode workflow file: %w", err)
+			}
+
+			result := WorkflowFile{
+				WorkflowSummary: newWorkflowSummary(workflow),
+				Ref:             ref,
+				SHA:             file.GetSHA(),
+			}
+			if len(content) > raw.MaxRawContentBytes {
+				content, result.Truncated = content[:raw.MaxRawContentBytes], true
+			}
+			// A character cut in half by the limit is dropped
+			result.Content = strings.ToValidUTF8(content, "")
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// WorkflowStateChange is the output type of the enable_workflow and disable_workflow tools.
+type WorkflowStateChange struct {
+	WorkflowSummary
+	PreviousState string `json:"previous_state"`
+	// Changed is false when the workflow was already in the requested state, in which case it was left as is.
+	Changed bool `json:"changed"`
+}
+
+// EnableWorkflow creates a tool to enable a workflow
+func EnableWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_workflow",
+			mcp.WithDescription(t("TOOL_ENABLE_WORKFLOW_DESCRIPTION", "Enable a disabled workflow by its ID or file name, so that its events trigger it again. Does nothing if the workflow is already active.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ENABLE_WORKFLOW_USER_TITLE", "Enable workflow"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		setWorkflowStateHandler(getClient, true)
+}
+
+// DisableWorkflow creates a tool to disable a workflow
+func DisableWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_workflow",
+			mcp.WithDescription(t("TOOL_DISABLE_WORKFLOW_DESCRIPTION", "Disable a workflow by its ID or file name, so that no event triggers it until it is enabled again. Does nothing if the workflow is already disabled.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_DISABLE_WORKFLOW_USER_TITLE", "Disable workflow"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		setWorkflowStateHandler(getClient, false)
+}
+
+// setWorkflowStateHandler returns the handler of the tools that enable and disable workflows. Workflows
+// already in the requested state are left as is, as are workflows disabled for another reason when
+// asked to disable them.
+func setWorkflowStateHandler(getClient GetClientFn, enable bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		owner, err := RequiredParam[string](request, "owner")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		repo, err := RequiredParam[string](request, "repo")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		workflowID, err := RequiredParam[string](request, "workflow_id")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		workflow, resp, err := getWorkflow(ctx, client, owner, repo, workflowID)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow", resp, err), nil
+		}
+		_ = resp.Body.Close()
+
+		change := WorkflowStateChange{WorkflowSummary: newWorkflowSummary(workflow), PreviousState: workflow.GetState()}
+		switch {
+		case change.State == "deleted":
+			return mcp.NewToolResultError(fmt.Sprintf("workflow %q was deleted, its file %s no longer exists", workflow.GetName(), workflow.GetPath())), nil
+		case enable && !change.Disabled, !enable && change.Disabled:
+			return MarshalledTextResult(change), nil
+		}
+
+		action, state := "disable", "disabled_manually"
+		if enable {
+			resp, err = client.Actions.EnableWorkflowByID(ctx, owner, repo, workflow.GetID())
+			action, state = "enable", "active"
+		} else {
+			resp, err = client.Actions.DisableWorkflowByID(ctx, owner, repo, workflow.GetID())
+		}
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to %s workflow", action), resp, err), nil
 		}
+		_ = resp.Body.Close()
+
+		workflow.State = github.Ptr(state)
+		change.WorkflowSummary = newWorkflowSummary(workflow)
+		change.Changed = true
+		return MarshalledTextResult(change), nil
+	}
 }
 
 // ListWorkflowRuns creates a tool to list workflow runs for a specific workflow
@@ -216,7 +533,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -375,6 +692,120 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
+// workflowRunPollInterval is how often wait_for_workflow_run checks a run. It is a variable so that
+// tests can shorten it.
+var workflowRunPollInterval = 10 * time.Second
+
+const (
+	// defaultWorkflowRunWait and maxWorkflowRunWait bound, in seconds, how long wait_for_workflow_run waits.
+	defaultWorkflowRunWait = 600
+	maxWorkflowRunWait     = 1800
+)
+
+// WorkflowRunWaitResult is the output type of the wait_for_workflow_run tool.
+type WorkflowRunWaitResult struct {
+	RunID      int64  `json:"run_id"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+	HTMLURL    string `json:"html_url"`
+	// Completed is false if the run did not complete before the timeout.
+	Completed     bool `json:"completed"`
+	WaitedSeconds int  `json:"waited_seconds"`
+}
+
+// WaitForWorkflowRun creates a tool to wait for a workflow run to complete
+func WaitForWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_workflow_run",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_WORKFLOW_RUN_DESCRIPTION", "Wait for a workflow run to complete and return its conclusion. Returns early with completed set to false if the run is still going after the timeout.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WAIT_FOR_WORKFLOW_RUN_USER_TITLE", "Wait for workflow run"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description(fmt.Sprintf("How long to wait for the run to complete, in seconds (default %d, max %d)", defaultWorkflowRunWait, maxWorkflowRunWait)),
+				mcp.Min(1),
+				mcp.Max(maxWorkflowRunWait),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			timeoutSeconds, err := OptionalIntParamWithDefault(request, "timeout_seconds", defaultWorkflowRunWait)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds < 1 || timeoutSeconds > maxWorkflowRunWait {
+				return mcp.NewToolResultError(fmt.Sprintf("timeout_seconds must be between 1 and %d", maxWorkflowRunWait)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			start := time.Now()
+			deadline := time.NewTimer(time.Duration(timeoutSeconds) * time.Second)
+			defer deadline.Stop()
+			for {
+				workflowRun, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil, ctx.Err()
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				result := WorkflowRunWaitResult{
+					RunID:         runID,
+					Status:        workflowRun.GetStatus(),
+					Conclusion:    workflowRun.GetConclusion(),
+					HTMLURL:       workflowRun.GetHTMLURL(),
+					Completed:     workflowRun.GetStatus() == "completed",
+					WaitedSeconds: int(time.Since(start).Seconds()),
+				}
+				if result.Completed {
+					return MarshalledTextResult(result), nil
+				}
+
+				poll := time.NewTimer(workflowRunPollInterval)
+				select {
+				case <-ctx.Done():
+					poll.Stop()
+					return nil, ctx.Err()
+				case <-deadline.C:
+					poll.Stop()
+					result.WaitedSeconds = timeoutSeconds
+					return MarshalledTextResult(result), nil
+				case <-poll.C:
+				}
+			}
+		}
+}
+
 // GetWorkflowRunLogs creates a tool to download logs for a specific workflow run
 func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_workflow_run_logs",
@@ -441,6 +872,69 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+// WorkflowJobStep is a step of a job in the output of the list_workflow_run_jobs tool.
+type WorkflowJobStep struct {
+	Number      int64  `json:"number"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Conclusion  string `json:"conclusion,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+// WorkflowJobSummary is a job in the output of the list_workflow_run_jobs tool.
+type WorkflowJobSummary struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Conclusion  string            `json:"conclusion,omitempty"`
+	RunAttempt  int64             `json:"run_attempt,omitempty"`
+	StartedAt   string            `json:"started_at,omitempty"`
+	CompletedAt string            `json:"completed_at,omitempty"`
+	HTMLURL     string            `json:"html_url,omitempty"`
+	Steps       []WorkflowJobStep `json:"steps"`
+}
+
+// WorkflowRunJobs is the output type of the list_workflow_run_jobs tool.
+type WorkflowRunJobs struct {
+	TotalCount int                  `json:"total_count"`
+	Jobs       []WorkflowJobSummary `json:"jobs"`
+}
+
+// formatTimestamp formats t as RFC 3339, or returns "" if it is not set.
+func formatTimestamp(t *github.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// newWorkflowJobSummary summarizes job with the status and conclusion of its steps.
+func newWorkflowJobSummary(job *github.WorkflowJob) WorkflowJobSummary {
+	summary := WorkflowJobSummary{
+		ID:          job.GetID(),
+		Name:        job.GetName(),
+		Status:      job.GetStatus(),
+		Conclusion:  job.GetConclusion(),
+		RunAttempt:  job.GetRunAttempt(),
+		StartedAt:   formatTimestamp(job.StartedAt),
+		CompletedAt: formatTimestamp(job.CompletedAt),
+		HTMLURL:     job.GetHTMLURL(),
+		Steps:       make([]WorkflowJobStep, 0, len(job.Steps)),
+	}
+	for _, step := range job.Steps {
+		summary.Steps = append(summary.Steps, WorkflowJobStep{
+			Number:      step.GetNumber(),
+			Name:        step.GetName(),
+			Status:      step.GetStatus(),
+			Conclusion:  step.GetConclusion(),
+			StartedAt:   formatTimestamp(step.StartedAt),
+			CompletedAt: formatTimestamp(step.CompletedAt),
+		})
+	}
+	return summary
+}
+
 // ListWorkflowJobs creates a tool to list jobs for a specific workflow run
 func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_jobs",
@@ -525,14 +1019,91 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// ListWorkflowRunJobs creates a tool to list the jobs of a workflow run with the status and
+// conclusion of their steps, to find which job and step of a run failed.
+func ListWorkflowRunJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_workflow_run_jobs",
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUN_JOBS_DESCRIPTION", "List the jobs of a workflow run with their status, conclusion, start and completion times, and the status and conclusion of each of their steps, such as to find which job and step of a run failed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WORKFLOW_RUN_JOBS_USER_TITLE", "List workflow run jobs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("latest lists the jobs of the latest attempt of the run, all lists the jobs of all its attempts. Defaults to latest"),
+				mcp.Enum("latest", "all"),
+			),
+			WithPageTokenPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPageTokenParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, int64(runID), &github.ListWorkflowJobsOptions{
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow run jobs", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := WorkflowRunJobs{
+				TotalCount: jobs.GetTotalCount(),
+				Jobs:       make([]WorkflowJobSummary, 0, len(jobs.Jobs)),
+			}
+			for _, job := range jobs.Jobs {
+				result.Jobs = append(result.Jobs, newWorkflowJobSummary(job))
+			}
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
 // GetJobLogs creates a tool to download logs for a specific workflow job or efficiently get all failed job logs for a workflow run
 func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_job_logs",
-			mcp.WithDescription(t("TOOL_GET_JOB_LOGS_DESCRIPTION", "Download logs for a specific workflow job or efficiently get all failed job logs for a workflow run")),
+			mcp.WithDescription(t("TOOL_GET_JOB_LOGS_DESCRIPTION", fmt.Sprintf("Download logs for a specific workflow job or efficiently get all failed job logs for a workflow run. Only the last %d bytes of the logs of a job are read. Logs older than the log retention period of the repository have expired.", raw.MaxRawContentBytes))),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_JOB_LOGS_USER_TITLE", "Get job logs"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -691,6 +1262,9 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 // handleSingleJobLogs gets logs for a single job
 func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int) (*mcp.CallToolResult, error) {
 	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, tailLines)
+	if errors.Is(err, errJobLogsExpired) {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil
 	}
@@ -707,6 +1281,9 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int) (map[string]any, *github.Response, error) {
 	// Get the download URL for the job logs
 	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
+	if resp != nil && resp.StatusCode == http.StatusGone {
+		return nil, resp, fmt.Errorf("job %d: %w", jobID, errJobLogsExpired)
+	}
 	if err != nil {
 		return nil, resp, fmt.Errorf("failed to get job logs for job %d: %w", jobID, err)
 	}
@@ -721,7 +1298,10 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 
 	if returnContent {
 		// Download and return the actual log content
-		content, originalLength, httpResp, err := downloadLogContent(url.String(), tailLines) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
+		content, originalLength, truncated, httpResp, err := downloadLogContent(url.String(), tailLines) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
+		if errors.Is(err, errJobLogsExpired) {
+			return nil, &github.Response{Response: httpResp}, fmt.Errorf("job %d: %w", jobID, errJobLogsExpired)
+		}
 		if err != nil {
 			// To keep the return value consistent wrap the response as a GitHub Response
 			ghRes := &github.Response{
@@ -732,6 +1312,10 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 		result["logs_content"] = content
 		result["message"] = "Job logs content retrieved successfully"
 		result["original_length"] = originalLength
+		if truncated {
+			result["truncated"] = true
+			result["note"] = fmt.Sprintf("The logs are larger than %d bytes, so only their end was read.", raw.MaxRawContentBytes)
+		}
 	} else {
 		// Return just the URL
 		result["logs_url"] = url.String()
@@ -742,28 +1326,62 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 	return result, resp, nil
 }
 
-// downloadLogContent downloads the actual log content from a GitHub logs URL
-func downloadLogContent(logURL string, tailLines int) (string, int, *http.Response, error) {
-	httpResp, err := http.Get(logURL) //nolint:gosec // URLs are provided by GitHub API and are safe
+// errJobLogsExpired is returned for the logs of jobs that are older than the log retention period
+// of the repository, which GitHub no longer has.
+var errJobLogsExpired = errors.New("the logs have expired and were deleted, as they are older than the log retention period of the repository")
+
+// downloadLogContent downloads the actual log content from a GitHub logs URL. Only the last
+// raw.MaxRawContentBytes bytes of larger logs are read, which truncated reports.
+func downloadLogContent(logURL string, tailLines int) (content string, lineCount int, truncated bool, httpResp *http.Response, err error) {
+	httpResp, err = http.Get(logURL) //nolint:gosec // URLs are provided by GitHub API and are safe
 	if err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
+		return "", 0, false, httpResp, fmt.Errorf("failed to download logs: %w", err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
+	if httpResp.StatusCode == http.StatusGone {
+		return "", 0, false, httpResp, errJobLogsExpired
+	}
 	if httpResp.StatusCode != http.StatusOK {
-		return "", 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
+		return "", 0, false, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
 	}
 
-	content, err := io.ReadAll(httpResp.Body)
+	data, truncated, err := readTail(httpResp.Body, raw.MaxRawContentBytes)
 	if err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
+		return "", 0, false, httpResp, fmt.Errorf("failed to read log content: %w", err)
 	}
 
 	// Clean up and format the log content for better readability
-	logContent := strings.TrimSpace(string(content))
+	logContent := strings.TrimSpace(string(data))
 
 	trimmedContent, lineCount := trimContent(logContent, tailLines)
-	return trimmedContent, lineCount, httpResp, nil
+	return trimmedContent, lineCount, truncated, httpResp, nil
+}
+
+// readTail reads r to its end and returns its last limit bytes, and whether there were more.
+func readTail(r io.Reader, limit int) ([]byte, bool, error) {
+	buf := make([]byte, 0, limit)
+	chunk := make([]byte, 32*1024)
+	truncated := false
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		// Drop what is over the limit once it is twice over, so that it is not copied for every chunk
+		if len(buf) > 2*limit {
+			buf = append(buf[:0], buf[len(buf)-limit:]...)
+			truncated = true
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if len(buf) > limit {
+		buf, truncated = buf[len(buf)-limit:], true
+	}
+	return buf, truncated, nil
 }
 
 // trimContent trims the content to a maximum length and returns the trimmed content and an original length
@@ -1042,7 +1660,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -0,0 +1,863 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	DescriptionOrganization    = "Organization name"
+	DescriptionOrgVisibility   = "Which repositories in the organization can access it: all, private (private and internal repositories) or selected (only the repositories in selected_repository_ids)"
+	DescriptionSelectedRepoIDs = "IDs of the repositories that can access it. Only used when visibility is selected"
+)
+
+// orgVisibilities are the accepted visibility values of organization secrets and variables.
+var orgVisibilities = []string{"all", "private", "selected"}
+
+func validOrgVisibility(visibility string) bool {
+	for _, v := range orgVisibilities {
+		if v == visibility {
+			return true
+		}
+	}
+	return false
+}
+
+// encryptSecret encrypts a secret value with a repository or organization public key,
+// as a libsodium sealed box which is the format the Actions secrets API expects.
+func encryptSecret(publicKey *github.PublicKey, value string) (string, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(publicKey.GetKey())
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if len(keyBytes) != 32 {
+		return "", fmt.Errorf("public key must be 32 bytes, got %d", len(keyBytes))
+	}
+	var key [32]byte
+	copy(key[:], keyBytes)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &key, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// ListOrgSecrets creates a tool to list the Actions secrets of an organization.
+func ListOrgSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ORG_SECRETS_DESCRIPTION", "List the GitHub Actions secrets of an organization. Secret values are never returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_SECRETS_USER_TITLE", "List organization secrets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Only return secrets with this visibility"),
+				mcp.Enum(orgVisibilities...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if visibility != "" && !validOrgVisibility(visibility) {
+				return mcp.NewToolResultError("visibility must be one of all, private or selected"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			secrets, resp, err := client.Actions.ListOrgSecrets(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list organization secrets", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if visibility != "" {
+				// The API has no visibility filter, so secrets are filtered on the requested page.
+				filtered := make([]*github.Secret, 0, len(secrets.Secrets))
+				for _, secret := range secrets.Secrets {
+					if secret.Visibility == visibility {
+						filtered = append(filtered, secret)
+					}
+				}
+				secrets.Secrets = filtered
+			}
+
+			return withNextPageToken(MarshalledTextResult(secrets), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// GetOrgSecret creates a tool to get the metadata of an organization Actions secret.
+func GetOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_secret",
+			mcp.WithDescription(t("TOOL_GET_ORG_SECRET_DESCRIPTION", "Get the metadata of a GitHub Actions secret of an organization, such as its visibility and when it was updated. The secret value is never returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_SECRET_USER_TITLE", "Get organization secret"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			secret, resp, err := client.Actions.GetOrgSecret(ctx, org, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(secret), nil
+		}
+}
+
+// CreateOrUpdateOrgSecret creates a tool to create or update an organization Actions secret.
+func CreateOrUpdateOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_org_secret",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_ORG_SECRET_DESCRIPTION", "Create or update a GitHub Actions secret of an organization. The value is encrypted with the organization's public key before it is sent to GitHub.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_ORG_SECRET_USER_TITLE", "Create or update organization secret"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("Value of the secret"),
+			),
+			mcp.WithString("visibility",
+				mcp.Required(),
+				mcp.Description(DescriptionOrgVisibility),
+				mcp.Enum(orgVisibilities...),
+			),
+			mcp.WithArray("selected_repository_ids",
+				mcp.Description(DescriptionSelectedRepoIDs),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := RequiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := RequiredParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !validOrgVisibility(visibility) {
+				return mcp.NewToolResultError("visibility must be one of all, private or selected"), nil
+			}
+			repoIDs, err := OptionalInt64ArrayParam(request, "selected_repository_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repoIDs) > 0 && visibility != "selected" {
+				return mcp.NewToolResultError("selected_repository_ids can only be used when visibility is selected"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			publicKey, resp, err := client.Actions.GetOrgPublicKey(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get organization public key", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			encrypted, err := encryptSecret(publicKey, value)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to encrypt secret: %s", err)), nil
+			}
+
+			resp, err = client.Actions.CreateOrUpdateOrgSecret(ctx, org, &github.EncryptedSecret{
+				Name:                  name,
+				KeyID:                 publicKey.GetKeyID(),
+				EncryptedValue:        encrypted,
+				Visibility:            visibility,
+				SelectedRepositoryIDs: github.SelectedRepoIDs(repoIDs),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create or update organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Secret %s has been saved", name),
+				"secret_name": name,
+				"visibility":  visibility,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
+
+// DeleteOrgSecret creates a tool to delete an organization Actions secret.
+func DeleteOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_org_secret",
+			mcp.WithDescription(t("TOOL_DELETE_ORG_SECRET_DESCRIPTION", "Delete a GitHub Actions secret of an organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_ORG_SECRET_USER_TITLE", "Delete organization secret"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.DeleteOrgSecret(ctx, org, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Secret %s has been deleted", name),
+				"secret_name": name,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
+
+// ListSelectedReposForOrgSecret creates a tool to list the repositories that can access an organization secret.
+func ListSelectedReposForOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_selected_repos_for_org_secret",
+			mcp.WithDescription(t("TOOL_LIST_SELECTED_REPOS_FOR_ORG_SECRET_DESCRIPTION", "List the repositories that can access an organization secret with selected visibility.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SELECTED_REPOS_FOR_ORG_SECRET_USER_TITLE", "List repositories for organization secret"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Actions.ListSelectedReposForOrgSecret(ctx, org, name, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list selected repositories for organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(minimalSelectedRepos(repos)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// minimalSelectedRepos trims a selected repositories list down to what is useful for managing access.
+func minimalSelectedRepos(repos *github.SelectedReposList) map[string]any {
+	minimal := make([]map[string]any, 0, len(repos.Repositories))
+	for _, repo := range repos.Repositories {
+		minimal = append(minimal, map[string]any{
+			"id":        repo.GetID(),
+			"full_name": repo.GetFullName(),
+			"private":   repo.GetPrivate(),
+		})
+	}
+	return map[string]any{
+		"total_count":  repos.GetTotalCount(),
+		"repositories": minimal,
+	}
+}
+
+// SetSelectedReposForOrgSecret creates a tool to replace the repositories that can access an organization secret.
+func SetSelectedReposForOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_selected_repos_for_org_secret",
+			mcp.WithDescription(t("TOOL_SET_SELECTED_REPOS_FOR_ORG_SECRET_DESCRIPTION", "Replace the repositories that can access an organization secret with selected visibility.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_SELECTED_REPOS_FOR_ORG_SECRET_USER_TITLE", "Set repositories for organization secret"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+			mcp.WithArray("repository_ids",
+				mcp.Required(),
+				mcp.Description("IDs of all repositories that should be able to access the secret"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["repository_ids"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: repository_ids"), nil
+			}
+			repoIDs, err := OptionalInt64ArrayParam(request, "repository_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.SetSelectedReposForOrgSecret(ctx, org, name, github.SelectedRepoIDs(repoIDs))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to set selected repositories for organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":        fmt.Sprintf("Secret %s is now available to %d repositories", name, len(repoIDs)),
+				"secret_name":    name,
+				"repository_ids": repoIDs,
+				"status_code":    resp.StatusCode,
+			}), nil
+		}
+}
+
+// AddSelectedRepoToOrgSecret creates a tool to give a repository access to an organization secret.
+func AddSelectedRepoToOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_selected_repo_to_org_secret",
+			mcp.WithDescription(t("TOOL_ADD_SELECTED_REPO_TO_ORG_SECRET_DESCRIPTION", "Give a repository access to an organization secret with selected visibility.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SELECTED_REPO_TO_ORG_SECRET_USER_TITLE", "Add repository to organization secret"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+			mcp.WithNumber("repository_id",
+				mcp.Required(),
+				mcp.Description("ID of the repository"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repoID, err := RequiredInt(request, "repository_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.AddSelectedRepoToOrgSecret(ctx, org, name, &github.Repository{ID: github.Ptr(int64(repoID))})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add repository to organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":       fmt.Sprintf("Repository %d can now access secret %s", repoID, name),
+				"secret_name":   name,
+				"repository_id": repoID,
+				"status_code":   resp.StatusCode,
+			}), nil
+		}
+}
+
+// RemoveSelectedRepoFromOrgSecret creates a tool to remove a repository's access to an organization secret.
+func RemoveSelectedRepoFromOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_selected_repo_from_org_secret",
+			mcp.WithDescription(t("TOOL_REMOVE_SELECTED_REPO_FROM_ORG_SECRET_DESCRIPTION", "Remove a repository's access to an organization secret with selected visibility.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_SELECTED_REPO_FROM_ORG_SECRET_USER_TITLE", "Remove repository from organization secret"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("Name of the secret"),
+			),
+			mcp.WithNumber("repository_id",
+				mcp.Required(),
+				mcp.Description("ID of the repository"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repoID, err := RequiredInt(request, "repository_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.RemoveSelectedRepoFromOrgSecret(ctx, org, name, &github.Repository{ID: github.Ptr(int64(repoID))})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove repository from organization secret", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":       fmt.Sprintf("Repository %d can no longer access secret %s", repoID, name),
+				"secret_name":   name,
+				"repository_id": repoID,
+				"status_code":   resp.StatusCode,
+			}), nil
+		}
+}
+
+// ListOrgVariables creates a tool to list the Actions variables of an organization.
+func ListOrgVariables(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_variables",
+			mcp.WithDescription(t("TOOL_LIST_ORG_VARIABLES_DESCRIPTION", "List the GitHub Actions variables of an organization, including their values.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_VARIABLES_USER_TITLE", "List organization variables"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variables, resp, err := client.Actions.ListOrgVariables(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list organization variables", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(variables), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// GetOrgVariable creates a tool to get an organization Actions variable.
+func GetOrgVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_variable",
+			mcp.WithDescription(t("TOOL_GET_ORG_VARIABLE_DESCRIPTION", "Get a GitHub Actions variable of an organization, including its value and visibility.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_VARIABLE_USER_TITLE", "Get organization variable"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variable, resp, err := client.Actions.GetOrgVariable(ctx, org, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get organization variable", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(variable), nil
+		}
+}
+
+// CreateOrgVariable creates a tool to create an organization Actions variable.
+func CreateOrgVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_org_variable",
+			mcp.WithDescription(t("TOOL_CREATE_ORG_VARIABLE_DESCRIPTION", "Create a GitHub Actions variable in an organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_ORG_VARIABLE_USER_TITLE", "Create organization variable"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("Value of the variable"),
+			),
+			mcp.WithString("visibility",
+				mcp.Required(),
+				mcp.Description(DescriptionOrgVisibility),
+				mcp.Enum(orgVisibilities...),
+			),
+			mcp.WithArray("selected_repository_ids",
+				mcp.Description(DescriptionSelectedRepoIDs),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := RequiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := RequiredParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !validOrgVisibility(visibility) {
+				return mcp.NewToolResultError("visibility must be one of all, private or selected"), nil
+			}
+			repoIDs, err := OptionalInt64ArrayParam(request, "selected_repository_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repoIDs) > 0 && visibility != "selected" {
+				return mcp.NewToolResultError("selected_repository_ids can only be used when visibility is selected"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variable := &github.ActionsVariable{
+				Name:       name,
+				Value:      value,
+				Visibility: github.Ptr(visibility),
+			}
+			if len(repoIDs) > 0 {
+				variable.SelectedRepositoryIDs = (*github.SelectedRepoIDs)(&repoIDs)
+			}
+
+			resp, err := client.Actions.CreateOrgVariable(ctx, org, variable)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create organization variable", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Variable %s has been created", name),
+				"name":        name,
+				"visibility":  visibility,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
+
+// UpdateOrgVariable creates a tool to update an organization Actions variable.
+func UpdateOrgVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_org_variable",
+			mcp.WithDescription(t("TOOL_UPDATE_ORG_VARIABLE_DESCRIPTION", "Update the value, visibility or selected repositories of a GitHub Actions variable in an organization. Fields that are not provided are left unchanged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ORG_VARIABLE_USER_TITLE", "Update organization variable"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+			mcp.WithString("value",
+				mcp.Description("New value of the variable"),
+			),
+			mcp.WithString("visibility",
+				mcp.Description(DescriptionOrgVisibility),
+				mcp.Enum(orgVisibilities...),
+			),
+			mcp.WithArray("selected_repository_ids",
+				mcp.Description(DescriptionSelectedRepoIDs),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, hasValue, err := OptionalParamOK[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if visibility != "" && !validOrgVisibility(visibility) {
+				return mcp.NewToolResultError("visibility must be one of all, private or selected"), nil
+			}
+			repoIDs, err := OptionalInt64ArrayParam(request, "selected_repository_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !hasValue && visibility == "" && len(repoIDs) == 0 {
+				return mcp.NewToolResultError("at least one of value, visibility or selected_repository_ids must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variable := &github.ActionsVariable{Name: name, Value: value}
+			if !hasValue {
+				// The API replaces the value on every update, so keep the current one.
+				current, resp, err := client.Actions.GetOrgVariable(ctx, org, name)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get organization variable", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				variable.Value = current.Value
+			}
+			if visibility != "" {
+				variable.Visibility = github.Ptr(visibility)
+			}
+			if len(repoIDs) > 0 {
+				variable.SelectedRepositoryIDs = (*github.SelectedRepoIDs)(&repoIDs)
+			}
+
+			resp, err := client.Actions.UpdateOrgVariable(ctx, org, variable)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update organization variable", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Variable %s has been updated", name),
+				"name":        name,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
+
+// DeleteOrgVariable creates a tool to delete an organization Actions variable.
+func DeleteOrgVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_org_variable",
+			mcp.WithDescription(t("TOOL_DELETE_ORG_VARIABLE_DESCRIPTION", "Delete a GitHub Actions variable of an organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_ORG_VARIABLE_USER_TITLE", "Delete organization variable"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.DeleteOrgVariable(ctx, org, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete organization variable", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Variable %s has been deleted", name),
+				"name":        name,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
@@ -0,0 +1,618 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func Test_ListOrgSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockSecrets := &github.Secrets{
+		TotalCount: 2,
+		Secrets: []*github.Secret{
+			{Name: "DEPLOY_KEY", Visibility: "selected"},
+			{Name: "NPM_TOKEN", Visibility: "all"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedNames  []string
+	}{
+		{
+			name: "successful secrets listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsByOrg,
+					expect(t, expectations{
+						path:        "/orgs/octo-org/actions/secrets",
+						queryParams: map[string]string{"page": "2", "per_page": "10"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSecrets),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedNames: []string{"DEPLOY_KEY", "NPM_TOKEN"},
+		},
+		{
+			name: "filters by visibility",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsSecretsByOrg,
+					mockSecrets,
+				),
+			),
+			requestArgs: map[string]any{
+				"org":        "octo-org",
+				"visibility": "selected",
+			},
+			expectedNames: []string{"DEPLOY_KEY"},
+		},
+		{
+			name:         "invalid visibility",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":        "octo-org",
+				"visibility": "public",
+			},
+			expectError:    true,
+			expectedErrMsg: "visibility must be one of all, private or selected",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization secrets",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response github.Secrets
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			names := make([]string, 0, len(response.Secrets))
+			for _, secret := range response.Secrets {
+				names = append(names, secret.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func Test_GetOrgSecret(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_secret", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "secret_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsActionsSecretsByOrgBySecretName,
+			expectPath(t, "/orgs/octo-org/actions/secrets/NPM_TOKEN").andThen(
+				mockResponse(t, http.StatusOK, &github.Secret{Name: "NPM_TOKEN", Visibility: "private"}),
+			),
+		),
+	))
+	_, handler := GetOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":         "octo-org",
+		"secret_name": "NPM_TOKEN",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var secret github.Secret
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &secret))
+	assert.Equal(t, "NPM_TOKEN", secret.Name)
+	assert.Equal(t, "private", secret.Visibility)
+}
+
+func Test_CreateOrUpdateOrgSecret(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_org_secret", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "selected_repository_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "secret_name", "value", "visibility"})
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	mockPublicKey := &github.PublicKey{
+		KeyID: github.Ptr("568250167242549743"),
+		Key:   github.Ptr(base64.StdEncoding.EncodeToString(publicKey[:])),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "encrypts and saves the secret",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsSecretsPublicKeyByOrg,
+					mockPublicKey,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsSecretsByOrgBySecretName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/orgs/octo-org/actions/secrets/DEPLOY_KEY", r.URL.Path)
+
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, "568250167242549743", body["key_id"])
+						assert.Equal(t, "selected", body["visibility"])
+						assert.Equal(t, []any{float64(1296269), float64(1296270)}, body["selected_repository_ids"])
+
+						sealed, err := base64.StdEncoding.DecodeString(body["encrypted_value"].(string))
+						require.NoError(t, err)
+						opened, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+						require.True(t, ok)
+						assert.Equal(t, "s3cr3t", string(opened))
+
+						w.WriteHeader(http.StatusCreated)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":                     "octo-org",
+				"secret_name":             "DEPLOY_KEY",
+				"value":                   "s3cr3t",
+				"visibility":              "selected",
+				"selected_repository_ids": []any{float64(1296269), float64(1296270)},
+			},
+		},
+		{
+			name:         "repository ids without selected visibility",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":                     "octo-org",
+				"secret_name":             "DEPLOY_KEY",
+				"value":                   "s3cr3t",
+				"visibility":              "all",
+				"selected_repository_ids": []any{float64(1296269)},
+			},
+			expectError:    true,
+			expectedErrMsg: "selected_repository_ids can only be used when visibility is selected",
+		},
+		{
+			name: "public key not accessible",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsPublicKeyByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":         "octo-org",
+				"secret_name": "DEPLOY_KEY",
+				"value":       "s3cr3t",
+				"visibility":  "private",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get organization public key",
+		},
+		{
+			name: "malformed public key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsSecretsPublicKeyByOrg,
+					&github.PublicKey{KeyID: github.Ptr("568250167242549743"), Key: github.Ptr("dG9vIHNob3J0")},
+				),
+			),
+			requestArgs: map[string]any{
+				"org":         "octo-org",
+				"secret_name": "DEPLOY_KEY",
+				"value":       "s3cr3t",
+				"visibility":  "private",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to encrypt secret: public key must be 32 bytes, got 9",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "DEPLOY_KEY", response["secret_name"])
+			assert.Equal(t, float64(http.StatusCreated), response["status_code"])
+		})
+	}
+}
+
+func Test_DeleteOrgSecret(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_org_secret", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "secret_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsActionsSecretsByOrgBySecretName,
+			expectPath(t, "/orgs/octo-org/actions/secrets/NPM_TOKEN").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":         "octo-org",
+		"secret_name": "NPM_TOKEN",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, "Secret NPM_TOKEN has been deleted", response["message"])
+	assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+}
+
+func Test_OrgSecretSelectedRepos(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	for _, tool := range []mcp.Tool{
+		toolOf(ListSelectedReposForOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(SetSelectedReposForOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(AddSelectedRepoToOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(RemoveSelectedRepoFromOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+	} {
+		require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	}
+
+	t.Run("list", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsActionsSecretsRepositoriesByOrgBySecretName,
+				expectPath(t, "/orgs/octo-org/actions/secrets/DEPLOY_KEY/repositories").andThen(
+					mockResponse(t, http.StatusOK, &github.SelectedReposList{
+						TotalCount: github.Ptr(1),
+						Repositories: []*github.Repository{
+							{ID: github.Ptr(int64(1296269)), FullName: github.Ptr("octo-org/hello-world"), Private: github.Ptr(true)},
+						},
+					}),
+				),
+			),
+		))
+		_, handler := ListSelectedReposForOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":         "octo-org",
+			"secret_name": "DEPLOY_KEY",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.JSONEq(t,
+			`{"total_count":1,"repositories":[{"id":1296269,"full_name":"octo-org/hello-world","private":true}]}`,
+			getTextResult(t, result).Text)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PutOrgsActionsSecretsRepositoriesByOrgBySecretName,
+				expect(t, expectations{
+					path:        "/orgs/octo-org/actions/secrets/DEPLOY_KEY/repositories",
+					requestBody: map[string]any{"selected_repository_ids": []any{float64(1296269)}},
+				}).andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		))
+		_, handler := SetSelectedReposForOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":            "octo-org",
+			"secret_name":    "DEPLOY_KEY",
+			"repository_ids": []any{float64(1296269)},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "now available to 1 repositories")
+	})
+
+	t.Run("set requires repository ids", func(t *testing.T) {
+		_, handler := SetSelectedReposForOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":         "octo-org",
+			"secret_name": "DEPLOY_KEY",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: repository_ids")
+	})
+
+	t.Run("add", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PutOrgsActionsSecretsRepositoriesByOrgBySecretNameByRepositoryId,
+				expectPath(t, "/orgs/octo-org/actions/secrets/DEPLOY_KEY/repositories/1296269").andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		))
+		_, handler := AddSelectedRepoToOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":           "octo-org",
+			"secret_name":   "DEPLOY_KEY",
+			"repository_id": float64(1296269),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.DeleteOrgsActionsSecretsRepositoriesByOrgBySecretNameByRepositoryId,
+				mockResponse(t, http.StatusConflict, `{"message": "Secret visibility is not selected"}`),
+			),
+		))
+		_, handler := RemoveSelectedRepoFromOrgSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":           "octo-org",
+			"secret_name":   "DEPLOY_KEY",
+			"repository_id": float64(1296269),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to remove repository from organization secret")
+	})
+}
+
+func Test_OrgVariables(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	for _, tool := range []mcp.Tool{
+		toolOf(ListOrgVariables(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(GetOrgVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(CreateOrgVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(UpdateOrgVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+		toolOf(DeleteOrgVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)),
+	} {
+		require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	}
+
+	mockVariable := &github.ActionsVariable{
+		Name:       "ENVIRONMENT",
+		Value:      "production",
+		Visibility: github.Ptr("all"),
+	}
+
+	t.Run("list", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsActionsVariablesByOrg,
+				expect(t, expectations{
+					path:        "/orgs/octo-org/actions/variables",
+					queryParams: map[string]string{"page": "1", "per_page": "30"},
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.ActionsVariables{
+						TotalCount: 1,
+						Variables:  []*github.ActionsVariable{mockVariable},
+					}),
+				),
+			),
+		))
+		_, handler := ListOrgVariables(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org"}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response github.ActionsVariables
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		require.Len(t, response.Variables, 1)
+		assert.Equal(t, "production", response.Variables[0].Value)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsActionsVariablesByOrgByName,
+				expectPath(t, "/orgs/octo-org/actions/variables/ENVIRONMENT").andThen(
+					mockResponse(t, http.StatusOK, mockVariable),
+				),
+			),
+		))
+		_, handler := GetOrgVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":  "octo-org",
+			"name": "ENVIRONMENT",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `"value":"production"`)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostOrgsActionsVariablesByOrg,
+				expect(t, expectations{
+					path: "/orgs/octo-org/actions/variables",
+					requestBody: map[string]any{
+						"name":                    "REGION",
+						"value":                   "eu-west-1",
+						"visibility":              "selected",
+						"selected_repository_ids": []any{float64(1296269)},
+					},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, nil),
+				),
+			),
+		))
+		_, handler := CreateOrgVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":                     "octo-org",
+			"name":                    "REGION",
+			"value":                   "eu-west-1",
+			"visibility":              "selected",
+			"selected_repository_ids": []any{float64(1296269)},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "Variable REGION has been created")
+	})
+
+	t.Run("update keeps the current value", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetOrgsActionsVariablesByOrgByName,
+				mockVariable,
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchOrgsActionsVariablesByOrgByName,
+				expect(t, expectations{
+					path: "/orgs/octo-org/actions/variables/ENVIRONMENT",
+					requestBody: map[string]any{
+						"name":       "ENVIRONMENT",
+						"value":      "production",
+						"visibility": "private",
+					},
+				}).andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		))
+		_, handler := UpdateOrgVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":        "octo-org",
+			"name":       "ENVIRONMENT",
+			"visibility": "private",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "Variable ENVIRONMENT has been updated")
+	})
+
+	t.Run("update requires a change", func(t *testing.T) {
+		_, handler := UpdateOrgVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":  "octo-org",
+			"name": "ENVIRONMENT",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "at least one of value, visibility or selected_repository_ids must be provided")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.DeleteOrgsActionsVariablesByOrgByName,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := DeleteOrgVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":  "octo-org",
+			"name": "MISSING",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to delete organization variable")
+	})
+}
+
+// toolOf returns the tool of a tool constructor's results.
+func toolOf(tool mcp.Tool, _ server.ToolHandlerFunc) mcp.Tool {
+	return tool
+}
@@ -2,13 +2,21 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,7 +67,7 @@ func Test_ListWorkflows(t *testing.T) {
 									ID:        github.Ptr(int64(456)),
 									Name:      github.Ptr("Deploy"),
 									Path:      github.Ptr(".github/workflows/deploy.yml"),
-									State:     github.Ptr("active"),
+									State:     github.Ptr("disabled_manually"),
 									CreatedAt: &github.Timestamp{},
 									UpdatedAt: &github.Timestamp{},
 									URL:       github.Ptr("https://api.github.com/repos/owner/repo/actions/workflows/456"),
@@ -115,12 +123,410 @@ func Test_ListWorkflows(t *testing.T) {
 			}
 
 			// Unmarshal and verify the result
-			var response github.Workflows
+			var response WorkflowList
 			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
-			assert.NotNil(t, response.TotalCount)
-			assert.Greater(t, *response.TotalCount, 0)
-			assert.NotEmpty(t, response.Workflows)
+			assert.Equal(t, 2, response.TotalCount)
+			assert.Equal(t, []WorkflowSummary{
+				{ID: 123, Name: "CI", Path: ".github/workflows/ci.yml", State: "active"},
+				{ID: 456, Name: "Deploy", Path: ".github/workflows/deploy.yml", State: "disabled_manually", Disabled: true},
+			}, response.Workflows)
+		})
+	}
+}
+
+// mockDisabledWorkflow is a workflow that was disabled by hand.
+var mockDisabledWorkflow = &github.Workflow{
+	ID:        github.Ptr(int64(456)),
+	Name:      github.Ptr("Deploy"),
+	Path:      github.Ptr(".github/workflows/deploy.yml"),
+	State:     github.Ptr("disabled_manually"),
+	CreatedAt: &github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	UpdatedAt: &github.Timestamp{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	HTMLURL:   github.Ptr("https://github.com/owner/repo/actions/workflows/deploy.yml"),
+}
+
+func Test_GetWorkflow(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflow(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		workflowID     string
+		expectError    bool
+		expectedErrMsg string
+		expected       WorkflowDetails
+	}{
+		{
+			name: "gets a disabled workflow by file name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					expectPath(t, "/repos/owner/repo/actions/workflows/deploy.yml").andThen(
+						mockResponse(t, http.StatusOK, mockDisabledWorkflow),
+					),
+				),
+			),
+			workflowID: "deploy.yml",
+			expected: WorkflowDetails{
+				WorkflowSummary: WorkflowSummary{ID: 456, Name: "Deploy", Path: ".github/workflows/deploy.yml", State: "disabled_manually", Disabled: true},
+				HTMLURL:         "https://github.com/owner/repo/actions/workflows/deploy.yml",
+				CreatedAt:       "2024-01-01T00:00:00Z",
+				UpdatedAt:       "2024-02-01T00:00:00Z",
+			},
+		},
+		{
+			name: "gets a workflow by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					expectPath(t, "/repos/owner/repo/actions/workflows/123").andThen(
+						mockResponse(t, http.StatusOK, &github.Workflow{
+							ID:    github.Ptr(int64(123)),
+							Name:  github.Ptr("CI"),
+							Path:  github.Ptr(".github/workflows/ci.yml"),
+							State: github.Ptr("active"),
+						}),
+					),
+				),
+			),
+			workflowID: "123",
+			expected: WorkflowDetails{
+				WorkflowSummary: WorkflowSummary{ID: 123, Name: "CI", Path: ".github/workflows/ci.yml", State: "active"},
+			},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			workflowID:     "missing.yml",
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflow(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": tc.workflowID,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned WorkflowDetails
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_GetWorkflowFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	definition := "name: Deploy\non: workflow_dispatch\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n    steps:\n      - run: ./deploy.sh\n"
+	getWorkflow := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId, mockDisabledWorkflow)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		ref            string
+		expectError    bool
+		expectedErrMsg string
+		expected       WorkflowFile
+	}{
+		{
+			name: "reads the definition of a disabled workflow",
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/.github/workflows/deploy.yml").andThen(
+						expectQueryParams(t, map[string]string{"ref": "release"}).andThen(
+							mockResponse(t, http.StatusOK, &github.RepositoryContent{
+								Type:     github.Ptr("file"),
+								Path:     github.Ptr(".github/workflows/deploy.yml"),
+								SHA:      github.Ptr("abc123"),
+								Encoding: github.Ptr("base64"),
+								Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(definition))),
+							}),
+						),
+					),
+				),
+			),
+			ref: "release",
+			expected: WorkflowFile{
+				WorkflowSummary: WorkflowSummary{ID: 456, Name: "Deploy", Path: ".github/workflows/deploy.yml", State: "disabled_manually", Disabled: true},
+				Ref:             "release",
+				SHA:             "abc123",
+				Content:         definition,
+			},
+		},
+		{
+			name: "truncates large definitions",
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(),
+				mock.WithRequestMatch(mock.GetReposContentsByOwnerByRepoByPath, &github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					SHA:      github.Ptr("abc123"),
+					Encoding: github.Ptr("base64"),
+					Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("#", raw.MaxRawContentBytes+10)))),
+				}),
+			),
+			expected: WorkflowFile{
+				WorkflowSummary: WorkflowSummary{ID: 456, Name: "Deploy", Path: ".github/workflows/deploy.yml", State: "disabled_manually", Disabled: true},
+				SHA:             "abc123",
+				Content:         strings.Repeat("#", raw.MaxRawContentBytes),
+				Truncated:       true,
+			},
+		},
+		{
+			name: "workflow file deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: `workflow file .github/workflows/deploy.yml of workflow "Deploy" (state disabled_manually) does not exist at the default branch`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "deploy.yml",
+			}
+			if tc.ref != "" {
+				args["ref"] = tc.ref
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned WorkflowFile
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_EnableDisableWorkflow(t *testing.T) {
+	// Verify tool definitions once
+	mockClient := github.NewClient(nil)
+	for _, newTool := range []func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc){EnableWorkflow, DisableWorkflow} {
+		tool, _ := newTool(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		require.NoError(t, toolsnaps.Test(tool.Name, tool))
+		assert.NotEmpty(t, tool.Description)
+		assert.False(t, *tool.Annotations.ReadOnlyHint)
+		assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+	}
+
+	activeWorkflow := &github.Workflow{
+		ID:    github.Ptr(int64(123)),
+		Name:  github.Ptr("CI"),
+		Path:  github.Ptr(".github/workflows/ci.yml"),
+		State: github.Ptr("active"),
+	}
+	getWorkflow := func(workflow *github.Workflow) mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId, workflow)
+	}
+	// unexpected fails the test if the endpoint is called, for workflows already in the requested state
+	unexpected := func(pattern mock.EndpointPattern) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(pattern, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			t.Errorf("unexpected request to %s", pattern.Pattern)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+	}
+
+	tests := []struct {
+		name           string
+		enable         bool
+		mockedClient   *http.Client
+		workflowID     string
+		expectError    bool
+		expectedErrMsg string
+		expected       WorkflowStateChange
+	}{
+		{
+			name:   "enables a disabled workflow by file name",
+			enable: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(mockDisabledWorkflow),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsWorkflowsEnableByOwnerByRepoByWorkflowId,
+					expectPath(t, "/repos/owner/repo/actions/workflows/456/enable").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			workflowID: "deploy.yml",
+			expected: WorkflowStateChange{
+				WorkflowSummary: WorkflowSummary{ID: 456, Name: "Deploy", Path: ".github/workflows/deploy.yml", State: "active"},
+				PreviousState:   "disabled_manually",
+				Changed:         true,
+			},
+		},
+		{
+			name:   "enabling an active workflow does nothing",
+			enable: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(activeWorkflow),
+				unexpected(mock.PutReposActionsWorkflowsEnableByOwnerByRepoByWorkflowId),
+			),
+			workflowID: "123",
+			expected: WorkflowStateChange{
+				WorkflowSummary: WorkflowSummary{ID: 123, Name: "CI", Path: ".github/workflows/ci.yml", State: "active"},
+				PreviousState:   "active",
+			},
+		},
+		{
+			name: "disables an active workflow by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(activeWorkflow),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsWorkflowsDisableByOwnerByRepoByWorkflowId,
+					expectPath(t, "/repos/owner/repo/actions/workflows/123/disable").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			workflowID: "123",
+			expected: WorkflowStateChange{
+				WorkflowSummary: WorkflowSummary{ID: 123, Name: "CI", Path: ".github/workflows/ci.yml", State: "disabled_manually", Disabled: true},
+				PreviousState:   "active",
+				Changed:         true,
+			},
+		},
+		{
+			name: "disabling a disabled workflow does nothing",
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(&github.Workflow{
+					ID:    github.Ptr(int64(123)),
+					Name:  github.Ptr("CI"),
+					Path:  github.Ptr(".github/workflows/ci.yml"),
+					State: github.Ptr("disabled_inactivity"),
+				}),
+				unexpected(mock.PutReposActionsWorkflowsDisableByOwnerByRepoByWorkflowId),
+			),
+			workflowID: "ci.yml",
+			expected: WorkflowStateChange{
+				WorkflowSummary: WorkflowSummary{ID: 123, Name: "CI", Path: ".github/workflows/ci.yml", State: "disabled_inactivity", Disabled: true},
+				PreviousState:   "disabled_inactivity",
+			},
+		},
+		{
+			name:   "deleted workflow",
+			enable: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(&github.Workflow{
+					ID:    github.Ptr(int64(123)),
+					Name:  github.Ptr("CI"),
+					Path:  github.Ptr(".github/workflows/ci.yml"),
+					State: github.Ptr("deleted"),
+				}),
+			),
+			workflowID:     "123",
+			expectError:    true,
+			expectedErrMsg: `workflow "CI" was deleted, its file .github/workflows/ci.yml no longer exists`,
+		},
+		{
+			name:   "workflow not found",
+			enable: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			workflowID:     "missing.yml",
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow",
+		},
+		{
+			name: "disable forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				getWorkflow(activeWorkflow),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsWorkflowsDisableByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			workflowID:     "123",
+			expectError:    true,
+			expectedErrMsg: "failed to disable workflow",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			newTool := DisableWorkflow
+			if tc.enable {
+				newTool = EnableWorkflow
+			}
+			_, handler := newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": tc.workflowID,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned WorkflowStateChange
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
 		})
 	}
 }
@@ -804,6 +1210,198 @@ func Test_GetWorkflowRunUsage(t *testing.T) {
 	}
 }
 
+func Test_ListWorkflowJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_workflow_jobs", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockJobs := &github.Jobs{
+		TotalCount: github.Ptr(1),
+		Jobs:       []*github.WorkflowJob{{ID: github.Ptr(int64(3)), Name: github.Ptr("deploy"), Status: github.Ptr("queued")}},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, mockJobs),
+	))
+	_, handler := ListWorkflowJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(42)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	// The jobs are returned as the API returns them, list_workflow_run_jobs summarizes them
+	var returned struct {
+		Jobs            *github.Jobs `json:"jobs"`
+		OptimizationTip string       `json:"optimization_tip"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, mockJobs, returned.Jobs)
+	assert.NotEmpty(t, returned.OptimizationTip)
+}
+
+func Test_ListWorkflowRunJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRunJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_workflow_run_jobs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	started := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockJobs := &github.Jobs{
+		TotalCount: github.Ptr(2),
+		Jobs: []*github.WorkflowJob{
+			{
+				ID:          github.Ptr(int64(1)),
+				Name:        github.Ptr("build"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("success"),
+				RunAttempt:  github.Ptr(int64(2)),
+				StartedAt:   &github.Timestamp{Time: started},
+				CompletedAt: &github.Timestamp{Time: started.Add(time.Minute)},
+				HTMLURL:     github.Ptr("https://github.com/owner/repo/actions/runs/42/job/1"),
+				Steps: []*github.TaskStep{
+					{Number: github.Ptr(int64(1)), Name: github.Ptr("Checkout"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+				},
+			},
+			{
+				ID:          github.Ptr(int64(2)),
+				Name:        github.Ptr("test"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("failure"),
+				RunAttempt:  github.Ptr(int64(2)),
+				StartedAt:   &github.Timestamp{Time: started},
+				CompletedAt: &github.Timestamp{Time: started.Add(2 * time.Minute)},
+				Steps: []*github.TaskStep{
+					{
+						Number:      github.Ptr(int64(1)),
+						Name:        github.Ptr("Checkout"),
+						Status:      github.Ptr("completed"),
+						Conclusion:  github.Ptr("success"),
+						StartedAt:   &github.Timestamp{Time: started},
+						CompletedAt: &github.Timestamp{Time: started.Add(5 * time.Second)},
+					},
+					{
+						Number:      github.Ptr(int64(2)),
+						Name:        github.Ptr("Run tests"),
+						Status:      github.Ptr("completed"),
+						Conclusion:  github.Ptr("failure"),
+						StartedAt:   &github.Timestamp{Time: started.Add(5 * time.Second)},
+						CompletedAt: &github.Timestamp{Time: started.Add(2 * time.Minute)},
+					},
+					{Number: github.Ptr(int64(3)), Name: github.Ptr("Upload coverage"), Status: github.Ptr("completed"), Conclusion: github.Ptr("skipped")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedJobs   []WorkflowJobSummary
+	}{
+		{
+			name: "lists the jobs of all attempts with their steps",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/42/jobs").andThen(
+						expectQueryParams(t, map[string]string{"filter": "all"}).andThen(
+							mockResponse(t, http.StatusOK, mockJobs),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(42), "filter": "all"},
+			expectedJobs: []WorkflowJobSummary{
+				{
+					ID:          1,
+					Name:        "build",
+					Status:      "completed",
+					Conclusion:  "success",
+					RunAttempt:  2,
+					StartedAt:   "2024-03-01T12:00:00Z",
+					CompletedAt: "2024-03-01T12:01:00Z",
+					HTMLURL:     "https://github.com/owner/repo/actions/runs/42/job/1",
+					Steps: []WorkflowJobStep{
+						{Number: 1, Name: "Checkout", Status: "completed", Conclusion: "success"},
+					},
+				},
+				{
+					ID:          2,
+					Name:        "test",
+					Status:      "completed",
+					Conclusion:  "failure",
+					RunAttempt:  2,
+					StartedAt:   "2024-03-01T12:00:00Z",
+					CompletedAt: "2024-03-01T12:02:00Z",
+					Steps: []WorkflowJobStep{
+						{Number: 1, Name: "Checkout", Status: "completed", Conclusion: "success", StartedAt: "2024-03-01T12:00:00Z", CompletedAt: "2024-03-01T12:00:05Z"},
+						{Number: 2, Name: "Run tests", Status: "completed", Conclusion: "failure", StartedAt: "2024-03-01T12:00:05Z", CompletedAt: "2024-03-01T12:02:00Z"},
+						{Number: 3, Name: "Upload coverage", Status: "completed", Conclusion: "skipped"},
+					},
+				},
+			},
+		},
+		{
+			name: "jobs without steps",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					&github.Jobs{
+						TotalCount: github.Ptr(1),
+						Jobs:       []*github.WorkflowJob{{ID: github.Ptr(int64(3)), Name: github.Ptr("deploy"), Status: github.Ptr("queued")}},
+					},
+				),
+			),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(42)},
+			expectedJobs: []WorkflowJobSummary{{ID: 3, Name: "deploy", Status: "queued", Steps: []WorkflowJobStep{}}},
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(42)},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow run jobs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRunJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned WorkflowRunJobs
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, len(tc.expectedJobs), returned.TotalCount)
+			assert.Equal(t, tc.expectedJobs, returned.Jobs)
+		})
+	}
+}
+
 func Test_GetJobLogs(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1167,3 +1765,254 @@ func Test_GetJobLogs_WithContentReturnAndTailLines(t *testing.T) {
 	assert.Equal(t, "Job logs content retrieved successfully", response["message"])
 	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
 }
+
+func Test_GetJobLogs_TruncatesLargeLogs(t *testing.T) {
+	// The logs are read to their end, keeping only the last MaxRawContentBytes bytes
+	logContent := strings.Repeat("a", raw.MaxRawContentBytes) + "\nlast line"
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", testServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"job_id":         float64(123),
+		"return_content": true,
+	})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response map[string]any
+	err = json.Unmarshal([]byte(textContent.Text), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, true, response["truncated"])
+	assert.Contains(t, response["note"], "only their end was read")
+	logs, ok := response["logs_content"].(string)
+	require.True(t, ok)
+	assert.Len(t, logs, raw.MaxRawContentBytes)
+	assert.True(t, strings.HasSuffix(logs, "\nlast line"))
+}
+
+func Test_GetJobLogs_Expired(t *testing.T) {
+	expiredDownload := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer expiredDownload.Close()
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+	}{
+		{
+			name: "logs API responds gone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusGone)
+						_, _ = w.Write([]byte(`{"message": "Gone"}`))
+					}),
+				),
+			),
+		},
+		{
+			name: "log download responds gone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", expiredDownload.URL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"job_id":         float64(123),
+				"return_content": true,
+			})
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+
+			errorContent := getErrorResult(t, result)
+			assert.Equal(t, "job 123: "+errJobLogsExpired.Error(), errorContent.Text)
+		})
+	}
+}
+
+func Test_WaitForWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "wait_for_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	interval := workflowRunPollInterval
+	workflowRunPollInterval = time.Millisecond
+	t.Cleanup(func() { workflowRunPollInterval = interval })
+
+	// runCompletingAfter reports the run as in progress for the first polls, then as completed
+	runCompletingAfter := func(polls int32) http.HandlerFunc {
+		var calls atomic.Int32
+		return func(w http.ResponseWriter, r *http.Request) {
+			run := &github.WorkflowRun{
+				ID:      github.Ptr(int64(12345)),
+				Status:  github.Ptr("in_progress"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/actions/runs/12345"),
+			}
+			if calls.Add(1) > polls {
+				run.Status = github.Ptr("completed")
+				run.Conclusion = github.Ptr("success")
+			}
+			mockResponse(t, http.StatusOK, run)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult WorkflowRunWaitResult
+	}{
+		{
+			name: "waits until the run completes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, runCompletingAfter(3)),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectedResult: WorkflowRunWaitResult{
+				RunID:      12345,
+				Status:     "completed",
+				Conclusion: "success",
+				HTMLURL:    "https://github.com/owner/repo/actions/runs/12345",
+				Completed:  true,
+			},
+		},
+		{
+			name: "stops waiting after the timeout",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, runCompletingAfter(1<<30)),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"timeout_seconds": float64(1),
+			},
+			expectedResult: WorkflowRunWaitResult{
+				RunID:         12345,
+				Status:        "in_progress",
+				HTMLURL:       "https://github.com/owner/repo/actions/runs/12345",
+				WaitedSeconds: 1,
+			},
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow run",
+		},
+		{
+			name:         "timeout too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"timeout_seconds": float64(7200),
+			},
+			expectError:    true,
+			expectedErrMsg: "timeout_seconds must be between 1 and 1800",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned WorkflowRunWaitResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+
+	t.Run("stops when the request is cancelled", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, runCompletingAfter(1<<30)),
+		))
+		_, handler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := handler(ctx, createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"run_id": float64(12345),
+		}))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolsetChangeResult is the output type of the admin toolset tools.
+type ToolsetChangeResult struct {
+	Message         string   `json:"message"`
+	EnabledToolsets []string `json:"enabled_toolsets"`
+}
+
+// withAdminToken adds the admin_token parameter the admin tools are protected with.
+func withAdminToken() mcp.ToolOption {
+	return mcp.WithString("admin_token",
+		mcp.Required(),
+		mcp.Description("Admin token the server was started with"),
+	)
+}
+
+// checkAdminToken reports whether the admin_token parameter of request matches adminToken.
+func checkAdminToken(request mcp.CallToolRequest, adminToken string) error {
+	token, err := RequiredParam[string](request, "admin_token")
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+		return errors.New("invalid admin token")
+	}
+	return nil
+}
+
+// AdminEnableToolset creates a tool that enables a toolset at runtime for callers with the admin token.
+func AdminEnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, adminToken string, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("admin_enable_toolset",
+			mcp.WithDescription(t("TOOL_ADMIN_ENABLE_TOOLSET_DESCRIPTION", "Enable a toolset of the GitHub MCP server for all clients, without restarting it. Requires the admin token the server was started with. Returns the toolsets enabled afterwards.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_ADMIN_ENABLE_TOOLSET_USER_TITLE", "Enable a toolset (admin)"),
+				// Not modifying GitHub data so no need to show a warning
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("toolset",
+				mcp.Required(),
+				mcp.Description("The name of the toolset to enable"),
+				ToolsetEnum(toolsetGroup),
+			),
+			withAdminToken(),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := checkAdminToken(request, adminToken); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolsetName, err := RequiredParam[string](request, "toolset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// caution: this affects the global tools and notifies all clients
+			changed, err := toolsetGroup.SetToolsetEnabled(toolsetName, true, func(toolset *toolsets.Toolset) {
+				s.AddTools(toolset.GetActiveTools()...)
+				s.AddPrompts(toolset.GetAvailablePrompts()...)
+				s.AddResourceTemplates(toolset.GetActiveResourceTemplates()...)
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !changed {
+				return MarshalledTextResult(ToolsetChangeResult{
+					Message:         fmt.Sprintf("Toolset %s is already enabled", toolsetName),
+					EnabledToolsets: toolsetGroup.EnabledToolsets(),
+				}), nil
+			}
+
+			return MarshalledTextResult(ToolsetChangeResult{
+				Message:         fmt.Sprintf("Toolset %s enabled", toolsetName),
+				EnabledToolsets: toolsetGroup.EnabledToolsets(),
+			}), nil
+		}
+}
+
+// AdminDisableToolset creates a tool that disables a toolset at runtime for callers with the admin token.
+func AdminDisableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, adminToken string, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("admin_disable_toolset",
+			mcp.WithDescription(t("TOOL_ADMIN_DISABLE_TOOLSET_DESCRIPTION", "Disable a toolset of the GitHub MCP server for all clients, without restarting it. Its tools and prompts are removed for subsequent calls. Requires the admin token the server was started with. Returns the toolsets enabled afterwards.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_ADMIN_DISABLE_TOOLSET_USER_TITLE", "Disable a toolset (admin)"),
+				// Not modifying GitHub data so no need to show a warning
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("toolset",
+				mcp.Required(),
+				mcp.Description("The name of the toolset to disable"),
+				ToolsetEnum(toolsetGroup),
+			),
+			withAdminToken(),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := checkAdminToken(request, adminToken); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolsetName, err := RequiredParam[string](request, "toolset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			changed, err := toolsetGroup.SetToolsetEnabled(toolsetName, false, func(toolset *toolsets.Toolset) {
+				toolNames := make([]string, 0, len(toolset.GetAvailableTools()))
+				for _, tool := range toolset.GetAvailableTools() {
+					toolNames = append(toolNames, tool.Tool.Name)
+				}
+				promptNames := make([]string, 0, len(toolset.GetAvailablePrompts()))
+				for _, prompt := range toolset.GetAvailablePrompts() {
+					promptNames = append(promptNames, prompt.Prompt.Name)
+				}
+				// caution: this affects the global tools and notifies all clients. The server cannot remove
+				// resource templates, so those of the toolset stay available.
+				s.DeleteTools(toolNames...)
+				s.DeletePrompts(promptNames...)
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !changed {
+				return MarshalledTextResult(ToolsetChangeResult{
+					Message:         fmt.Sprintf("Toolset %s is already disabled", toolsetName),
+					EnabledToolsets: toolsetGroup.EnabledToolsets(),
+				}), nil
+			}
+
+			return MarshalledTextResult(ToolsetChangeResult{
+				Message:         fmt.Sprintf("Toolset %s disabled", toolsetName),
+				EnabledToolsets: toolsetGroup.EnabledToolsets(),
+			}), nil
+		}
+}
+
+// InitAdminToolset creates the admin toolset, whose tools change the enabled toolsets at runtime for
+// callers with adminToken. It is kept out of the toolset group so that it cannot disable itself.
+func InitAdminToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, adminToken string, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	admin := toolsets.NewToolset("admin", "Enable and disable toolsets at runtime, protected by an admin token").
+		AddReadTools(
+			toolsets.NewServerTool(AdminEnableToolset(s, tsg, adminToken, t)),
+			toolsets.NewServerTool(AdminDisableToolset(s, tsg, adminToken, t)),
+		)
+
+	admin.Enabled = true
+	return admin
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "s3cr3t-admin-token"
+
+func newTestToolset(name string) *toolsets.Toolset {
+	return toolsets.NewToolset(name, name+" tools").
+		AddReadTools(
+			toolsets.NewServerTool(
+				mcp.NewTool(name+"_read", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+				func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					return mcp.NewToolResultText("ok"), nil
+				},
+			),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(
+				mcp.NewTool(name+"_write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)})),
+				func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					return mcp.NewToolResultText("ok"), nil
+				},
+			),
+		).
+		AddPrompts(
+			toolsets.NewServerPrompt(
+				mcp.NewPrompt(name+"_prompt"),
+				func(_ context.Context, _ mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+					return &mcp.GetPromptResult{}, nil
+				},
+			),
+		)
+}
+
+// newAdminTestServer returns a server with the alpha and beta toolsets, of which alpha is enabled.
+func newAdminTestServer(t *testing.T) (*server.MCPServer, *toolsets.ToolsetGroup) {
+	t.Helper()
+
+	s := NewServer("test")
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(newTestToolset("alpha"))
+	tsg.AddToolset(newTestToolset("beta"))
+	require.NoError(t, tsg.EnableToolsets([]string{"alpha"}))
+	tsg.RegisterAll(s)
+	InitAdminToolset(s, tsg, testAdminToken, translations.NullTranslationHelper).RegisterTools(s)
+	return s, tsg
+}
+
+// listNames lists the names of the tools or prompts the server offers through method.
+func listNames(t *testing.T, s *server.MCPServer, method string) []string {
+	t.Helper()
+
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %#v", response)
+
+	var names []string
+	switch result := resp.Result.(type) {
+	case mcp.ListToolsResult:
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+	case mcp.ListPromptsResult:
+		for _, prompt := range result.Prompts {
+			names = append(names, prompt.Name)
+		}
+	default:
+		t.Fatalf("unexpected result %T", resp.Result)
+	}
+	return names
+}
+
+func Test_AdminToolsetTools(t *testing.T) {
+	s, tsg := newAdminTestServer(t)
+
+	enableTool, _ := AdminEnableToolset(s, tsg, testAdminToken, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(enableTool.Name, enableTool))
+	assert.Equal(t, "admin_enable_toolset", enableTool.Name)
+	assert.ElementsMatch(t, enableTool.InputSchema.Required, []string{"toolset", "admin_token"})
+
+	disableTool, _ := AdminDisableToolset(s, tsg, testAdminToken, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(disableTool.Name, disableTool))
+	assert.Equal(t, "admin_disable_toolset", disableTool.Name)
+	assert.ElementsMatch(t, disableTool.InputSchema.Required, []string{"toolset", "admin_token"})
+}
+
+func Test_AdminEnableAndDisableToolset(t *testing.T) {
+	s, tsg := newAdminTestServer(t)
+	_, enable := AdminEnableToolset(s, tsg, testAdminToken, translations.NullTranslationHelper)
+	_, disable := AdminDisableToolset(s, tsg, testAdminToken, translations.NullTranslationHelper)
+
+	call := func(handler server.ToolHandlerFunc, args map[string]any) ToolsetChangeResult {
+		t.Helper()
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var change ToolsetChangeResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &change))
+		return change
+	}
+
+	assert.ElementsMatch(t, []string{"alpha_read", "alpha_write", "admin_enable_toolset", "admin_disable_toolset"}, listNames(t, s, "tools/list"))
+
+	change := call(enable, map[string]any{"toolset": "beta", "admin_token": testAdminToken})
+	assert.Equal(t, ToolsetChangeResult{Message: "Toolset beta enabled", EnabledToolsets: []string{"alpha", "beta"}}, change)
+	assert.ElementsMatch(t, []string{"alpha_read", "alpha_write", "beta_read", "beta_write", "admin_enable_toolset", "admin_disable_toolset"}, listNames(t, s, "tools/list"))
+	assert.ElementsMatch(t, []string{"alpha_prompt", "beta_prompt"}, listNames(t, s, "prompts/list"))
+
+	change = call(disable, map[string]any{"toolset": "alpha", "admin_token": testAdminToken})
+	assert.Equal(t, ToolsetChangeResult{Message: "Toolset alpha disabled", EnabledToolsets: []string{"beta"}}, change)
+	assert.ElementsMatch(t, []string{"beta_read", "beta_write", "admin_enable_toolset", "admin_disable_toolset"}, listNames(t, s, "tools/list"))
+	assert.ElementsMatch(t, []string{"beta_prompt"}, listNames(t, s, "prompts/list"))
+
+	// Calls to the tools of a disabled toolset fail
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"alpha_read","arguments":{}}}`))
+	_, ok := response.(mcp.JSONRPCError)
+	assert.True(t, ok, "expected calling a disabled tool to fail, got %#v", response)
+
+	change = call(disable, map[string]any{"toolset": "alpha", "admin_token": testAdminToken})
+	assert.Equal(t, ToolsetChangeResult{Message: "Toolset alpha is already disabled", EnabledToolsets: []string{"beta"}}, change)
+
+	change = call(enable, map[string]any{"toolset": "beta", "admin_token": testAdminToken})
+	assert.Equal(t, ToolsetChangeResult{Message: "Toolset beta is already enabled", EnabledToolsets: []string{"beta"}}, change)
+}
+
+func Test_AdminToolsetToolsRequireAdminToken(t *testing.T) {
+	s, tsg := newAdminTestServer(t)
+	_, enable := AdminEnableToolset(s, tsg, testAdminToken, translations.NullTranslationHelper)
+	_, disable := AdminDisableToolset(s, tsg, testAdminToken, translations.NullTranslationHelper)
+
+	tests := []struct {
+		name           string
+		handler        server.ToolHandlerFunc
+		requestArgs    map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name:           "enable with a wrong token",
+			handler:        enable,
+			requestArgs:    map[string]any{"toolset": "beta", "admin_token": "guess"},
+			expectedErrMsg: "invalid admin token",
+		},
+		{
+			name:           "disable with a wrong token",
+			handler:        disable,
+			requestArgs:    map[string]any{"toolset": "alpha", "admin_token": "guess"},
+			expectedErrMsg: "invalid admin token",
+		},
+		{
+			name:           "disable without a token",
+			handler:        disable,
+			requestArgs:    map[string]any{"toolset": "alpha"},
+			expectedErrMsg: "missing required parameter: admin_token",
+		},
+		{
+			name:           "unknown toolset",
+			handler:        enable,
+			requestArgs:    map[string]any{"toolset": "gamma", "admin_token": testAdminToken},
+			expectedErrMsg: "toolset gamma does not exist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+		})
+	}
+
+	assert.Equal(t, []string{"alpha"}, tsg.EnabledToolsets(), "rejected calls must not change the enabled toolsets")
+}
@@ -0,0 +1,339 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ValidateArguments wraps the handler of tool so that the arguments of calls are checked against the
+// input schema of the tool before the handler runs. Malformed arguments are reported with the
+// parameter at fault, the type or values it takes and an example, rather than as a type error from
+// deep in the handler. Numbers and booleans passed as strings, which models often do, are converted
+// to the declared type, and null optional arguments are dropped as if they were omitted.
+func ValidateArguments(tool mcp.Tool, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	schema, err := decodeInputSchema(tool)
+	if err != nil {
+		// The schema is what clients see, so a tool whose schema cannot be encoded is broken anyway
+		return handler
+	}
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := validateArguments(schema, request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		request.Params.Arguments = args
+		return handler(ctx, request)
+	}
+}
+
+// decodeInputSchema returns the input schema of tool as decoded JSON, so that it has the same shape
+// whether the tool was declared with a raw schema or built with options.
+func decodeInputSchema(tool mcp.Tool) (map[string]any, error) {
+	data := []byte(tool.RawInputSchema)
+	if len(data) == 0 {
+		var err error
+		if data, err = json.Marshal(tool.InputSchema); err != nil {
+			return nil, err
+		}
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// validateArguments checks arguments against schema, returning them with the values coerced to the
+// types of their parameters.
+func validateArguments(schema map[string]any, arguments any) (map[string]any, error) {
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+	args, ok := arguments.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("arguments must be an object, got %s", describeValue(arguments))
+	}
+	return validateObject("", schema, args)
+}
+
+func validateObject(path string, schema map[string]any, object map[string]any) (map[string]any, error) {
+	properties, _ := schema["properties"].(map[string]any)
+	additional, _ := schema["additionalProperties"].(map[string]any)
+	propertyPath := func(name string) string {
+		switch {
+		case path == "":
+			return name
+		case properties[name] != nil:
+			return path + "." + name
+		default:
+			return fmt.Sprintf("%s[%q]", path, name)
+		}
+	}
+
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		name, _ := name.(string)
+		if object[name] == nil {
+			propertySchema, _ := properties[name].(map[string]any)
+			if expected := describeType(propertySchema); expected != "" {
+				return nil, fmt.Errorf("missing required parameter: %s (%s, such as %s)", propertyPath(name), expected, exampleValue(propertySchema))
+			}
+			return nil, fmt.Errorf("missing required parameter: %s", propertyPath(name))
+		}
+	}
+
+	// Check the arguments in a stable order so that the same call always reports the same error
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make(map[string]any, len(object))
+	for _, name := range names {
+		propertySchema, ok := properties[name].(map[string]any)
+		if !ok {
+			propertySchema = additional
+		}
+		value := object[name]
+		if value == nil && !accepts(propertySchema, "null") {
+			continue
+		}
+		if propertySchema == nil {
+			// Arguments the schema does not describe are left to the handler
+			result[name] = value
+			continue
+		}
+		value, err := validateValue(propertyPath(name), propertySchema, value)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+func validateValue(path string, schema map[string]any, value any) (any, error) {
+	if types := schemaTypes(schema); len(types) > 0 {
+		coerced, ok := any(nil), false
+		for _, typ := range types {
+			if coerced, ok = coerce(value, typ); ok {
+				break
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("parameter %s must be %s, such as %s, got %s", path, describeType(schema), exampleValue(schema), describeValue(value))
+		}
+		value = coerced
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		// Values are compared encoded, as arrays and objects cannot be compared with ==
+		encoded := encodeValue(value)
+		values := make([]string, len(enum))
+		for i, allowed := range enum {
+			values[i] = encodeValue(allowed)
+		}
+		if !slices.Contains(values, encoded) {
+			return nil, fmt.Errorf("parameter %s must be one of %s, got %s", path, strings.Join(values, ", "), describeValue(value))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateObject(path, schema, v)
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return v, nil
+		}
+		result := make([]any, len(v))
+		for i, item := range v {
+			item, err := validateValue(fmt.Sprintf("%s[%d]", path, i), items, item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+// coerce returns value as the JSON schema type typ, converting the numbers and booleans models pass
+// as strings, and whether value is of or could be converted to that type.
+func coerce(value any, typ string) (any, bool) {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return value, ok
+	case "number", "integer":
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case int:
+			number = float64(v)
+		case int64:
+			number = float64(v)
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil || math.IsInf(parsed, 0) || math.IsNaN(parsed) {
+				return nil, false
+			}
+			number = parsed
+		default:
+			return nil, false
+		}
+		if typ == "integer" && number != math.Trunc(number) {
+			return nil, false
+		}
+		return number, true
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true":
+				return true, true
+			case "false":
+				return false, true
+			}
+		}
+		return nil, false
+	case "array":
+		_, ok := value.([]any)
+		return value, ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return value, ok
+	case "null":
+		return nil, value == nil
+	}
+	// Types this does not know of are left to the handler
+	return value, true
+}
+
+// schemaTypes returns the types schema allows, which JSON schema declares as a type or a list of types.
+func schemaTypes(schema map[string]any) []string {
+	switch typ := schema["type"].(type) {
+	case string:
+		return []string{typ}
+	case []any:
+		types := make([]string, 0, len(typ))
+		for _, t := range typ {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func accepts(schema map[string]any, typ string) bool {
+	return slices.Contains(schemaTypes(schema), typ)
+}
+
+// describeType returns the types schema allows as a phrase such as "a string or a number", or "" if
+// it does not declare any.
+func describeType(schema map[string]any) string {
+	types := schemaTypes(schema)
+	descriptions := make([]string, len(types))
+	for i, typ := range types {
+		switch typ {
+		case "array", "object", "integer":
+			descriptions[i] = "an " + typ
+		default:
+			descriptions[i] = "a " + typ
+		}
+	}
+	return strings.Join(descriptions, " or ")
+}
+
+// exampleValue returns a JSON encoded value schema accepts, its default if it has one.
+func exampleValue(schema map[string]any) string {
+	return encodeValue(example(schema))
+}
+
+func example(schema map[string]any) any {
+	if value, ok := schema["default"]; ok {
+		return value
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if examples, ok := schema["examples"].([]any); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	types := schemaTypes(schema)
+	if len(types) == 0 {
+		return nil
+	}
+	switch types[0] {
+	case "string":
+		return "text"
+	case "number", "integer":
+		if minimum, ok := schema["minimum"].(float64); ok {
+			return minimum
+		}
+		return 1
+	case "boolean":
+		return true
+	case "array":
+		if items, ok := schema["items"].(map[string]any); ok && len(schemaTypes(items)) > 0 {
+			return []any{example(items)}
+		}
+		return []any{}
+	case "object":
+		return map[string]any{}
+	}
+	return nil
+}
+
+// maxDescribedValueLength is the length values are truncated to in errors.
+const maxDescribedValueLength = 50
+
+// describeValue returns the JSON type of value followed by value, such as `string "ten"`.
+func describeValue(value any) string {
+	var typ string
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		typ = "string"
+	case float64, int, int64:
+		typ = "number"
+	case bool:
+		typ = "boolean"
+	case []any:
+		typ = "array"
+	case map[string]any:
+		typ = "object"
+	default:
+		typ = fmt.Sprintf("%T", value)
+	}
+	encoded := encodeValue(value)
+	if len(encoded) > maxDescribedValueLength {
+		encoded = encoded[:maxDescribedValueLength] + "..."
+	}
+	return typ + " " + encoded
+}
+
+func encodeValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
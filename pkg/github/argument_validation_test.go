@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateArguments(t *testing.T) {
+	tool := echoArgumentsTool("test_tool",
+		mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner")),
+		mcp.WithNumber("page", mcp.Min(1), mcp.Description("Page number")),
+		mcp.WithNumber("perPage", mcp.DefaultNumber(30), mcp.Description("Results per page")),
+		mcp.WithBoolean("draft", mcp.Description("Whether to create a draft")),
+		mcp.WithString("state", mcp.Enum("open", "closed"), mcp.Description("State of the issue")),
+		mcp.WithArray("labels", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Labels")),
+		mcp.WithArray("ids", mcp.Items(map[string]any{"type": "number"}), mcp.Description("IDs")),
+		mcp.WithObject("job",
+			mcp.Properties(map[string]any{
+				"id":   map[string]any{"type": "string"},
+				"runs": map[string]any{"type": "number"},
+			}),
+			mcp.Description("The job"),
+		),
+	)
+	handler := ValidateArguments(tool.Tool, tool.Handler)
+
+	tests := []struct {
+		name           string
+		args           any
+		expected       map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name:     "valid arguments are passed as they are",
+			args:     map[string]any{"owner": "octocat", "page": float64(2), "draft": true, "state": "open", "labels": []any{"bug"}},
+			expected: map[string]any{"owner": "octocat", "page": float64(2), "draft": true, "state": "open", "labels": []any{"bug"}},
+		},
+		{
+			name:     "numeric strings are converted to numbers",
+			args:     map[string]any{"owner": "octocat", "page": "2", "ids": []any{"1", float64(2)}, "job": map[string]any{"id": "42", "runs": " 3 "}},
+			expected: map[string]any{"owner": "octocat", "page": float64(2), "ids": []any{float64(1), float64(2)}, "job": map[string]any{"id": "42", "runs": float64(3)}},
+		},
+		{
+			name:     "boolean strings are converted to booleans",
+			args:     map[string]any{"owner": "octocat", "draft": "False"},
+			expected: map[string]any{"owner": "octocat", "draft": false},
+		},
+		{
+			name:     "null optional arguments are dropped",
+			args:     map[string]any{"owner": "octocat", "page": nil},
+			expected: map[string]any{"owner": "octocat"},
+		},
+		{
+			name:     "arguments the schema does not describe are passed on",
+			args:     map[string]any{"owner": "octocat", "extra": "value"},
+			expected: map[string]any{"owner": "octocat", "extra": "value"},
+		},
+		{
+			name:           "missing required parameter",
+			args:           map[string]any{"page": float64(1)},
+			expectedErrMsg: `missing required parameter: owner (a string, such as "text")`,
+		},
+		{
+			name:           "null required parameter",
+			args:           map[string]any{"owner": nil},
+			expectedErrMsg: `missing required parameter: owner (a string, such as "text")`,
+		},
+		{
+			name:           "no arguments",
+			args:           nil,
+			expectedErrMsg: `missing required parameter: owner (a string, such as "text")`,
+		},
+		{
+			name:           "arguments that are not an object",
+			args:           []any{"octocat"},
+			expectedErrMsg: `arguments must be an object, got array ["octocat"]`,
+		},
+		{
+			name:           "string that is not a number",
+			args:           map[string]any{"owner": "octocat", "page": "two"},
+			expectedErrMsg: `parameter page must be a number, such as 1, got string "two"`,
+		},
+		{
+			name:           "example is the default",
+			args:           map[string]any{"owner": "octocat", "perPage": true},
+			expectedErrMsg: `parameter perPage must be a number, such as 30, got boolean true`,
+		},
+		{
+			name:           "string that is not a boolean",
+			args:           map[string]any{"owner": "octocat", "draft": "yes"},
+			expectedErrMsg: `parameter draft must be a boolean, such as true, got string "yes"`,
+		},
+		{
+			name:           "number for a string",
+			args:           map[string]any{"owner": float64(42)},
+			expectedErrMsg: `parameter owner must be a string, such as "text", got number 42`,
+		},
+		{
+			name:           "value not in enum",
+			args:           map[string]any{"owner": "octocat", "state": "opened"},
+			expectedErrMsg: `parameter state must be one of "open", "closed", got string "opened"`,
+		},
+		{
+			name:           "string for an array",
+			args:           map[string]any{"owner": "octocat", "labels": "bug"},
+			expectedErrMsg: `parameter labels must be an array, such as ["text"], got string "bug"`,
+		},
+		{
+			name:           "invalid array item",
+			args:           map[string]any{"owner": "octocat", "ids": []any{float64(1), "two"}},
+			expectedErrMsg: `parameter ids[1] must be a number, such as 1, got string "two"`,
+		},
+		{
+			name:           "invalid object property",
+			args:           map[string]any{"owner": "octocat", "job": map[string]any{"id": float64(42)}},
+			expectedErrMsg: `parameter job.id must be a string, such as "text", got number 42`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = "test_tool"
+			request.Params.Arguments = tc.args
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ValidateArgumentsWithRawSchema(t *testing.T) {
+	tool := mcp.NewToolWithRawSchema("raw_tool", "A tool with a raw schema", json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"count": {"type": "integer"},
+			"manifests": {
+				"type": "object",
+				"additionalProperties": {"type": "object", "properties": {"scope": {"type": "string", "enum": ["runtime", "development"]}}}
+			}
+		},
+		"required": ["count"]
+	}`))
+	handler := ValidateArguments(tool, func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return MarshalledTextResult(request.GetArguments()), nil
+	})
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"count": "3"})
+	require.False(t, result.IsError)
+	assert.JSONEq(t, `{"count": 3}`, getTextResult(t, result).Text)
+
+	result = call(map[string]any{"count": 1.5})
+	require.True(t, result.IsError)
+	assert.Equal(t, "parameter count must be an integer, such as 1, got number 1.5", getErrorResult(t, result).Text)
+
+	result = call(map[string]any{"count": float64(1), "manifests": map[string]any{"go.mod": map[string]any{"scope": "test"}}})
+	require.True(t, result.IsError)
+	assert.Equal(t, `parameter manifests["go.mod"].scope must be one of "runtime", "development", got string "test"`, getErrorResult(t, result).Text)
+}
+
+func Test_DefaultToolsetGroupValidatesArguments(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), stubGetSCIMClientFn(nil), translations.NullTranslationHelper, AssetsConfig{}, TokenPermissionsConfig{}, nil, nil, nil)
+	require.NoError(t, tsg.EnableToolsets([]string{"issues"}))
+	s := server.NewMCPServer("test", "1.0")
+	tsg.RegisterAll(s)
+
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+		"params": map[string]any{
+			"name":      "get_issue",
+			"arguments": map[string]any{"owner": "octocat", "repo": "hello-world", "issue_number": "one"},
+		},
+	})
+	require.NoError(t, err)
+	response, ok := s.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result := response.Result.(mcp.CallToolResult)
+	require.True(t, result.IsError)
+	assert.Equal(t, `parameter issue_number must be a number, such as 1, got string "one"`, getErrorResult(t, &result).Text)
+}
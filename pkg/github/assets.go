@@ -0,0 +1,265 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultAssetsBranch is the branch assets are committed to when no branch is configured.
+const DefaultAssetsBranch = ".github-mcp-assets"
+
+// AssetsConfig configures where uploaded assets are stored.
+// If Owner and Repo are empty, assets are stored in the repository the tool is called for.
+type AssetsConfig struct {
+	Owner  string
+	Repo   string
+	Branch string
+}
+
+// ParseAssetsConfig builds an AssetsConfig from an optional "owner/repo" string and an optional branch name.
+func ParseAssetsConfig(repository, branch string) (AssetsConfig, error) {
+	cfg := AssetsConfig{Branch: branch}
+	if cfg.Branch == "" {
+		cfg.Branch = DefaultAssetsBranch
+	}
+	if repository == "" {
+		return cfg, nil
+	}
+
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return AssetsConfig{}, fmt.Errorf("assets repository must be in the form owner/repo: %s", repository)
+	}
+	cfg.Owner = owner
+	cfg.Repo = repo
+	return cfg, nil
+}
+
+// assetMarkdown returns a markdown snippet embedding the asset, as an image when the file looks like one.
+func assetMarkdown(filename, url string) string {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp":
+		return fmt.Sprintf("![%s](%s)", filename, url)
+	default:
+		return fmt.Sprintf("[%s](%s)", filename, url)
+	}
+}
+
+// UploadIssueAttachment creates a tool to upload an asset, such as an image, so it can be embedded in issue and pull request bodies.
+// GitHub has no public API for user attachments, so the asset is committed to a dedicated assets branch instead.
+func UploadIssueAttachment(getClient GetClientFn, getRawClient raw.GetRawClientFn, assets AssetsConfig, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_issue_attachment",
+			mcp.WithDescription(t("TOOL_UPLOAD_ISSUE_ATTACHMENT_DESCRIPTION", "Upload a file, such as a chart or screenshot, so it can be embedded in issue or pull request bodies and comments. The file is committed to a dedicated assets branch and a URL and markdown snippet are returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_ISSUE_ATTACHMENT_USER_TITLE", "Upload issue attachment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner of the issue or pull request the attachment is for"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name of the issue or pull request the attachment is for"),
+			),
+			mcp.WithString("filename",
+				mcp.Required(),
+				mcp.Description("File name of the attachment, including its extension (e.g. chart.png)"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Base64 encoded content of the attachment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filename, err := RequiredParam[string](request, "filename")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			data, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("content must be base64 encoded: %s", err)), nil
+			}
+
+			filename = path.Base(filename)
+			if filename == "." || filename == "/" {
+				return mcp.NewToolResultError("filename must not be empty"), nil
+			}
+
+			// Store the asset in the configured repository if there is one, otherwise alongside the issue.
+			assetsOwner, assetsRepo := owner, repo
+			if assets.Owner != "" && assets.Repo != "" {
+				assetsOwner, assetsRepo = assets.Owner, assets.Repo
+			}
+			branch := assets.Branch
+			if branch == "" {
+				branch = DefaultAssetsBranch
+			}
+
+			// Address assets by their content hash so uploading the same file twice is a no-op.
+			sum := sha256.Sum256(data)
+			assetPath := path.Join(owner, repo, hex.EncodeToString(sum[:8])+"-"+filename)
+			message := fmt.Sprintf("Upload %s for %s/%s", filename, owner, repo)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Git.GetRef(ctx, assetsOwner, assetsRepo, "refs/heads/"+branch)
+			switch {
+			case err == nil:
+				defer func() { _ = resp.Body.Close() }()
+				if result := commitAssetFile(ctx, client, assetsOwner, assetsRepo, branch, assetPath, message, data); result != nil {
+					return result, nil
+				}
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				if result := createAssetsBranch(ctx, client, assetsOwner, assetsRepo, branch, assetPath, message, data); result != nil {
+					return result, nil
+				}
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get assets branch",
+					resp,
+					err,
+				), nil
+			}
+
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+			url := rawClient.URLFromOpts(&raw.ContentOpts{Ref: "refs/heads/" + branch}, assetsOwner, assetsRepo, assetPath)
+
+			return MarshalledTextResult(map[string]string{
+				"url":        url,
+				"markdown":   assetMarkdown(filename, url),
+				"repository": assetsOwner + "/" + assetsRepo,
+				"branch":     branch,
+				"path":       assetPath,
+			}), nil
+		}
+}
+
+// commitAssetFile commits the asset to an existing assets branch using the contents API,
+// unless a file already exists at the content addressed path.
+// It returns a tool result only if the commit failed.
+func commitAssetFile(ctx context.Context, client *github.Client, owner, repo, branch, assetPath, message string, data []byte) *mcp.CallToolResult {
+	existing, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, assetPath, &github.RepositoryContentGetOptions{Ref: branch})
+	if err == nil && existing != nil {
+		defer func() { _ = resp.Body.Close() }()
+		return nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to check for existing asset",
+			resp,
+			err,
+		)
+	}
+
+	_, resp, err = client.Repositories.CreateFile(ctx, owner, repo, assetPath, &github.RepositoryContentFileOptions{
+		Message: github.Ptr(message),
+		Content: data,
+		Branch:  github.Ptr(branch),
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to upload asset",
+			resp,
+			err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// createAssetsBranch creates the assets branch as an orphan branch containing only the asset,
+// so assets never share history with the repository's code.
+// It returns a tool result only if creating the branch failed.
+func createAssetsBranch(ctx context.Context, client *github.Client, owner, repo, branch, assetPath, message string, data []byte) *mcp.CallToolResult {
+	blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString(data)),
+		Encoding: github.Ptr("base64"),
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create asset blob",
+			resp,
+			err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	tree, resp, err := client.Git.CreateTree(ctx, owner, repo, "", []*github.TreeEntry{
+		{
+			Path: github.Ptr(assetPath),
+			Mode: github.Ptr("100644"), // Regular file mode
+			Type: github.Ptr("blob"),
+			SHA:  blob.SHA,
+		},
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create assets tree",
+			resp,
+			err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	commit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(message),
+		Tree:    tree,
+	}, nil)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create assets commit",
+			resp,
+			err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.Ptr("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: commit.SHA},
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create assets branch",
+			resp,
+			err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
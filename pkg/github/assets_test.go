@@ -0,0 +1,272 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseAssetsConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		repository     string
+		branch         string
+		expected       AssetsConfig
+		expectedErrMsg string
+	}{
+		{
+			name:     "defaults",
+			expected: AssetsConfig{Branch: DefaultAssetsBranch},
+		},
+		{
+			name:       "repository and branch",
+			repository: "my-org/assets",
+			branch:     "main",
+			expected:   AssetsConfig{Owner: "my-org", Repo: "assets", Branch: "main"},
+		},
+		{
+			name:           "malformed repository",
+			repository:     "my-org",
+			expectedErrMsg: "assets repository must be in the form owner/repo",
+		},
+		{
+			name:           "too many path segments",
+			repository:     "my-org/assets/extra",
+			expectedErrMsg: "assets repository must be in the form owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := ParseAssetsConfig(tc.repository, tc.branch)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg)
+		})
+	}
+}
+
+func Test_UploadIssueAttachment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := UploadIssueAttachment(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), AssetsConfig{}, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_issue_attachment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "filename")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "filename", "content"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	data := []byte("fake png data")
+	sum := sha256.Sum256(data)
+	assetPath := "owner/repo/" + hex.EncodeToString(sum[:8]) + "-chart.png"
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		assets         AssetsConfig
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult map[string]string
+		expectedErrMsg string
+	}{
+		{
+			name: "upload to existing assets branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/heads/.github-mcp-assets").andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/.github-mcp-assets"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expect(t, expectations{
+						path: "/repos/owner/repo/contents/" + assetPath,
+						requestBody: map[string]any{
+							"message": "Upload chart.png for owner/repo",
+							"content": base64.StdEncoding.EncodeToString(data),
+							"branch":  ".github-mcp-assets",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepositoryContentResponse{}),
+					),
+				),
+			),
+			assets: AssetsConfig{Branch: DefaultAssetsBranch},
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"filename": "chart.png",
+				"content":  base64.StdEncoding.EncodeToString(data),
+			},
+			expectedResult: map[string]string{
+				"url":        "https://raw.githubusercontent.com/owner/repo/refs/heads/.github-mcp-assets/" + assetPath,
+				"markdown":   "![chart.png](https://raw.githubusercontent.com/owner/repo/refs/heads/.github-mcp-assets/" + assetPath + ")",
+				"repository": "owner/repo",
+				"branch":     ".github-mcp-assets",
+				"path":       assetPath,
+			},
+		},
+		{
+			name: "create assets branch in configured repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/my-org/assets/git/ref/heads/uploads").andThen(notFound),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"content":  base64.StdEncoding.EncodeToString(data),
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tree": []any{
+							map[string]any{
+								"path": assetPath,
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob123",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("tree123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("commit123")}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "refs/heads/uploads",
+						"sha": "commit123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/heads/uploads")}),
+					),
+				),
+			),
+			assets: AssetsConfig{Owner: "my-org", Repo: "assets", Branch: "uploads"},
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"filename": "nested/dir/chart.png",
+				"content":  base64.StdEncoding.EncodeToString(data),
+			},
+			expectedResult: map[string]string{
+				"url":        "https://raw.githubusercontent.com/my-org/assets/refs/heads/uploads/" + assetPath,
+				"markdown":   "![chart.png](https://raw.githubusercontent.com/my-org/assets/refs/heads/uploads/" + assetPath + ")",
+				"repository": "my-org/assets",
+				"branch":     "uploads",
+				"path":       assetPath,
+			},
+		},
+		{
+			name:         "content is not base64",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"filename": "chart.png",
+				"content":  "not base64!",
+			},
+			expectError:    true,
+			expectedErrMsg: "content must be base64 encoded",
+		},
+		{
+			name: "getting assets branch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"filename": "chart.png",
+				"content":  base64.StdEncoding.EncodeToString(data),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get assets branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+			_, handler := UploadIssueAttachment(stubGetClientFn(client), stubGetRawClientFn(rawClient), tc.assets, translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returnedResult map[string]string
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultBatchConcurrency is the number of calls batch_tool_calls runs at once by default.
+const DefaultBatchConcurrency = 5
+
+// maxBatchCalls bounds the number of calls in one batch_tool_calls call.
+const maxBatchCalls = 20
+
+const batchToolCallsName = "batch_tool_calls"
+
+// BatchCallResult is the result of one call of a batch, in the order the calls were given.
+type BatchCallResult struct {
+	ToolName string `json:"tool_name"`
+	IsError  bool   `json:"is_error"`
+	// Content is the content the tool returned, including the message of tool errors.
+	Content []mcp.Content `json:"content,omitempty"`
+	// Error is set when the tool could not be called, such as when it does not exist.
+	Error string `json:"error,omitempty"`
+}
+
+// batchCall is one call of a batch.
+type batchCall struct {
+	ToolName   string         `json:"tool_name"`
+	Parameters map[string]any `json:"parameters"`
+}
+
+// parseBatchCalls reads the calls parameter of batch_tool_calls.
+func parseBatchCalls(request mcp.CallToolRequest) ([]batchCall, error) {
+	raw, ok := request.GetArguments()["calls"]
+	if !ok {
+		return nil, errors.New("missing required parameter: calls")
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, errors.New("calls must be an array")
+	}
+	if len(items) == 0 {
+		return nil, errors.New("calls must not be empty")
+	}
+	if len(items) > maxBatchCalls {
+		return nil, fmt.Errorf("calls must not have more than %d items", maxBatchCalls)
+	}
+
+	calls := make([]batchCall, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("calls[%d] must be an object", i)
+		}
+		name, ok := fields["tool_name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("calls[%d].tool_name must be a non-empty string", i)
+		}
+		calls[i].ToolName = name
+		if parameters, ok := fields["parameters"]; ok && parameters != nil {
+			if calls[i].Parameters, ok = parameters.(map[string]any); !ok {
+				return nil, fmt.Errorf("calls[%d].parameters must be an object", i)
+			}
+		}
+	}
+	return calls, nil
+}
+
+// callTool calls a tool through the server, so that the call sees the same tools, middlewares and
+// hooks as a call from the client.
+func callTool(ctx context.Context, s *server.MCPServer, id int, call batchCall) BatchCallResult {
+	result := BatchCallResult{ToolName: call.ToolName}
+	if call.ToolName == batchToolCallsName {
+		result.IsError = true
+		result.Error = "batch_tool_calls cannot be called from a batch"
+		return result
+	}
+
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      id,
+		"method":  string(mcp.MethodToolsCall),
+		"params": map[string]any{
+			"name":      call.ToolName,
+			"arguments": call.Parameters,
+		},
+	})
+	if err != nil {
+		result.IsError = true
+		result.Error = fmt.Sprintf("failed to encode call: %s", err)
+		return result
+	}
+
+	// Each call records its GitHub errors separately, as the calls of a batch run concurrently.
+	ctx = context.WithValue(ctx, ghErrors.GitHubErrorKey{}, &ghErrors.GitHubCtxErrors{})
+
+	switch response := s.HandleMessage(ctx, message).(type) {
+	case mcp.JSONRPCResponse:
+		toolResult, ok := response.Result.(mcp.CallToolResult)
+		if !ok {
+			result.IsError = true
+			result.Error = "unexpected tool result"
+			return result
+		}
+		result.IsError = toolResult.IsError
+		result.Content = toolResult.Content
+	case mcp.JSONRPCError:
+		result.IsError = true
+		result.Error = response.Error.Message
+	default:
+		result.IsError = true
+		result.Error = "unexpected response to tool call"
+	}
+	return result
+}
+
+// BatchToolCalls creates a tool that calls several tools of the server concurrently, with at most
+// concurrency calls running at once. Failed calls do not stop the others.
+func BatchToolCalls(s *server.MCPServer, concurrency int, readOnly bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+	return mcp.NewTool(batchToolCallsName,
+			mcp.WithDescription(t("TOOL_BATCH_TOOL_CALLS_DESCRIPTION", fmt.Sprintf("Call several tools of this server at once, such as to fetch data from multiple repositories. The calls run concurrently and their results are returned in the order of calls, each with the tool's content or error. A failed call does not stop the others. At most %d calls per batch.", maxBatchCalls))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_BATCH_TOOL_CALLS_USER_TITLE", "Batch tool calls"),
+				// Calls can only reach write tools when they are registered
+				ReadOnlyHint: ToBoolPtr(readOnly),
+			}),
+			mcp.WithArray("calls",
+				mcp.Required(),
+				mcp.Description("The tool calls to make"),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"tool_name": map[string]any{
+							"type":        "string",
+							"description": "Name of the tool to call",
+						},
+						"parameters": map[string]any{
+							"type":        "object",
+							"description": "Parameters of the tool call",
+						},
+					},
+					"required": []string{"tool_name"},
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls, err := parseBatchCalls(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			results := make([]BatchCallResult, len(calls))
+			tasks := make([]func(context.Context) error, len(calls))
+			for i, call := range calls {
+				tasks[i] = func(ctx context.Context) error {
+					results[i] = callTool(ctx, s, i, call)
+					return nil
+				}
+			}
+			for i, err := range runBounded(ctx, concurrency, tasks...) {
+				if err != nil {
+					results[i] = BatchCallResult{ToolName: calls[i].ToolName, IsError: true, Error: err.Error()}
+				}
+			}
+
+			return MarshalledTextResult(results), nil
+		}
+}
+
+// InitBatchToolset creates the toolset of batch_tool_calls, which calls the other tools of the
+// server. It is kept out of the toolset group so that it is always available.
+func InitBatchToolset(s *server.MCPServer, concurrency int, readOnly bool, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	batch := toolsets.NewToolset("batch", "Call several tools at once")
+	tool := toolsets.NewServerTool(BatchToolCalls(s, concurrency, readOnly, t))
+	if readOnly {
+		batch.AddReadTools(tool)
+	} else {
+		batch.AddWriteTools(tool)
+	}
+
+	batch.Enabled = true
+	return batch
+}
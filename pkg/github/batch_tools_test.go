@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchResult is BatchCallResult with its content decoded as text.
+type batchResult struct {
+	ToolName string `json:"tool_name"`
+	IsError  bool   `json:"is_error"`
+	Content  []struct {
+		Text string `json:"text"`
+	} `json:"content"`
+	Error string `json:"error"`
+}
+
+// newBatchTestServer returns a server with an echo tool, a failing tool and a slow tool that records
+// how many of its calls ran at once, and batch_tool_calls with the given concurrency.
+func newBatchTestServer(t *testing.T, concurrency int) (*server.MCPServer, *int32) {
+	t.Helper()
+
+	var running, maxRunning int32
+	readOnly := func() mcp.ToolOption {
+		return mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})
+	}
+	testTools := toolsets.NewToolset("test", "test tools").
+		AddReadTools(
+			toolsets.NewServerTool(
+				mcp.NewTool("echo", readOnly(), mcp.WithString("value", mcp.Required())),
+				func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					value, err := RequiredParam[string](request, "value")
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					return mcp.NewToolResultText(value), nil
+				},
+			),
+			toolsets.NewServerTool(
+				mcp.NewTool("fail", readOnly()),
+				func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					return mcp.NewToolResultError("failed on purpose"), nil
+				},
+			),
+			toolsets.NewServerTool(
+				mcp.NewTool("slow", readOnly()),
+				func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						previous := atomic.LoadInt32(&maxRunning)
+						if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					return mcp.NewToolResultText("done"), nil
+				},
+			),
+		)
+
+	s := NewServer("test")
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(testTools)
+	require.NoError(t, tsg.EnableToolsets([]string{"test"}))
+	tsg.RegisterAll(s)
+	InitBatchToolset(s, concurrency, false, translations.NullTranslationHelper).RegisterTools(s)
+	return s, &maxRunning
+}
+
+// callBatch calls batch_tool_calls through the server with calls.
+func callBatch(t *testing.T, s *server.MCPServer, calls []any) *mcp.CallToolResult {
+	t.Helper()
+
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":      "batch_tool_calls",
+			"arguments": map[string]any{"calls": calls},
+		},
+	})
+	require.NoError(t, err)
+
+	response, ok := s.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response")
+	result, ok := response.Result.(mcp.CallToolResult)
+	require.True(t, ok, "unexpected result %T", response.Result)
+	return &result
+}
+
+func Test_BatchToolCalls(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := BatchToolCalls(nil, 0, false, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "batch_tool_calls", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"calls"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	readOnlyTool, _ := BatchToolCalls(nil, 0, true, translations.NullTranslationHelper)
+	assert.True(t, *readOnlyTool.Annotations.ReadOnlyHint, "only read tools can be called in read-only mode")
+
+	t.Run("returns results in order with errors inline", func(t *testing.T) {
+		s, _ := newBatchTestServer(t, 2)
+
+		result := callBatch(t, s, []any{
+			map[string]any{"tool_name": "echo", "parameters": map[string]any{"value": "first"}},
+			map[string]any{"tool_name": "fail"},
+			map[string]any{"tool_name": "missing"},
+			map[string]any{"tool_name": "echo", "parameters": map[string]any{}},
+			map[string]any{"tool_name": "batch_tool_calls", "parameters": map[string]any{"calls": []any{}}},
+			map[string]any{"tool_name": "echo", "parameters": map[string]any{"value": "last"}},
+		})
+		require.False(t, result.IsError)
+
+		var returned []batchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		require.Len(t, returned, 6)
+
+		assert.Equal(t, "echo", returned[0].ToolName)
+		assert.False(t, returned[0].IsError)
+		require.Len(t, returned[0].Content, 1)
+		assert.Equal(t, "first", returned[0].Content[0].Text)
+
+		assert.True(t, returned[1].IsError)
+		require.Len(t, returned[1].Content, 1)
+		assert.Equal(t, "failed on purpose", returned[1].Content[0].Text)
+
+		assert.True(t, returned[2].IsError)
+		assert.Contains(t, returned[2].Error, "tool 'missing' not found")
+
+		assert.True(t, returned[3].IsError)
+		assert.Equal(t, "missing required parameter: value", returned[3].Content[0].Text)
+
+		assert.True(t, returned[4].IsError)
+		assert.Equal(t, "batch_tool_calls cannot be called from a batch", returned[4].Error)
+
+		assert.False(t, returned[5].IsError)
+		assert.Equal(t, "last", returned[5].Content[0].Text)
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		s, maxRunning := newBatchTestServer(t, 2)
+
+		calls := make([]any, 8)
+		for i := range calls {
+			calls[i] = map[string]any{"tool_name": "slow"}
+		}
+		result := callBatch(t, s, calls)
+		require.False(t, result.IsError)
+
+		var returned []batchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		require.Len(t, returned, 8)
+		for _, r := range returned {
+			assert.False(t, r.IsError)
+		}
+		assert.LessOrEqual(t, atomic.LoadInt32(maxRunning), int32(2))
+		assert.Greater(t, atomic.LoadInt32(maxRunning), int32(1), "calls should run concurrently")
+	})
+
+	t.Run("invalid calls", func(t *testing.T) {
+		s, _ := newBatchTestServer(t, 0)
+
+		tooMany := make([]any, maxBatchCalls+1)
+		for i := range tooMany {
+			tooMany[i] = map[string]any{"tool_name": "echo"}
+		}
+
+		tests := []struct {
+			calls          []any
+			expectedErrMsg string
+		}{
+			{calls: []any{}, expectedErrMsg: "calls must not be empty"},
+			{calls: tooMany, expectedErrMsg: fmt.Sprintf("calls must not have more than %d items", maxBatchCalls)},
+			{calls: []any{"echo"}, expectedErrMsg: "calls[0] must be an object"},
+			{calls: []any{map[string]any{"parameters": map[string]any{}}}, expectedErrMsg: "calls[0].tool_name must be a non-empty string"},
+			{calls: []any{map[string]any{"tool_name": "echo", "parameters": "value"}}, expectedErrMsg: "calls[0].parameters must be an object"},
+		}
+		for _, tc := range tests {
+			result := callBatch(t, s, tc.calls)
+			require.True(t, result.IsError)
+			assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+		}
+	})
+}
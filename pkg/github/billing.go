@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OrgActionsBilling is the GitHub Actions usage of an organization in the current billing cycle.
+type OrgActionsBilling struct {
+	Available            bool           `json:"available"`
+	TotalMinutesUsed     float64        `json:"total_minutes_used"`
+	TotalPaidMinutesUsed float64        `json:"total_paid_minutes_used"`
+	IncludedMinutes      float64        `json:"included_minutes"`
+	IncludedMinutesLeft  float64        `json:"included_minutes_left"`
+	MinutesUsedBreakdown map[string]int `json:"minutes_used_breakdown,omitempty"`
+}
+
+// OrgPackagesBilling is the GitHub Packages data transfer of an organization in the current billing cycle.
+type OrgPackagesBilling struct {
+	Available                       bool    `json:"available"`
+	TotalGigabytesBandwidthUsed     int     `json:"total_gigabytes_bandwidth_used"`
+	TotalPaidGigabytesBandwidthUsed int     `json:"total_paid_gigabytes_bandwidth_used"`
+	IncludedGigabytesBandwidth      float64 `json:"included_gigabytes_bandwidth"`
+}
+
+// OrgStorageBilling is the estimated shared storage of an organization for Actions and Packages.
+type OrgStorageBilling struct {
+	Available                    bool    `json:"available"`
+	DaysLeftInBillingCycle       int     `json:"days_left_in_billing_cycle"`
+	EstimatedPaidStorageForMonth float64 `json:"estimated_paid_storage_for_month"`
+	EstimatedStorageForMonth     float64 `json:"estimated_storage_for_month"`
+}
+
+// BillingNotAvailable is returned in place of billing figures that GitHub does not provide for an organization.
+type BillingNotAvailable struct {
+	Available bool   `json:"available"`
+	Reason    string `json:"reason"`
+}
+
+// OrgBillingSummary is the output type for the get_org_billing_summary tool. Sections GitHub
+// does not provide for the organization are listed in NotAvailable with the reason.
+type OrgBillingSummary struct {
+	Org          string              `json:"org"`
+	Actions      *OrgActionsBilling  `json:"actions,omitempty"`
+	Packages     *OrgPackagesBilling `json:"packages,omitempty"`
+	Storage      *OrgStorageBilling  `json:"storage,omitempty"`
+	NotAvailable map[string]string   `json:"not_available,omitempty"`
+	Warnings     []string            `json:"warnings,omitempty"`
+}
+
+// billingNotAvailable reports whether a failed billing request means that the figures are not
+// available for the organization rather than that the request went wrong. GitHub answers 410
+// for organizations on plans without the endpoint and 403 when it does not share the figures
+// with the caller. Rate limits are also reported with 403 and are not mistaken for either.
+func billingNotAvailable(resp *github.Response, err error) (*BillingNotAvailable, bool) {
+	if resp == nil {
+		return nil, false
+	}
+	var rateLimitErr *github.RateLimitError
+	var abuseRateLimitErr *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseRateLimitErr) {
+		return nil, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusGone:
+		return &BillingNotAvailable{Reason: "GitHub does not provide these billing figures for the organization's plan"}, true
+	case http.StatusForbidden:
+		return &BillingNotAvailable{Reason: "GitHub did not share these billing figures, the organization's plan may not include them or the token may not be allowed to read the organization's billing"}, true
+	default:
+		return nil, false
+	}
+}
+
+func getOrgActionsBilling(ctx context.Context, client *github.Client, org string) (*OrgActionsBilling, *github.Response, error) {
+	billing, resp, err := client.Billing.GetActionsBillingOrg(ctx, org)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	return &OrgActionsBilling{
+		Available:            true,
+		TotalMinutesUsed:     billing.TotalMinutesUsed,
+		TotalPaidMinutesUsed: billing.TotalPaidMinutesUsed,
+		IncludedMinutes:      billing.IncludedMinutes,
+		IncludedMinutesLeft:  max(billing.IncludedMinutes-billing.TotalMinutesUsed, 0),
+		MinutesUsedBreakdown: billing.MinutesUsedBreakdown,
+	}, resp, nil
+}
+
+func getOrgPackagesBilling(ctx context.Context, client *github.Client, org string) (*OrgPackagesBilling, *github.Response, error) {
+	billing, resp, err := client.Billing.GetPackagesBillingOrg(ctx, org)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	return &OrgPackagesBilling{
+		Available:                       true,
+		TotalGigabytesBandwidthUsed:     billing.TotalGigabytesBandwidthUsed,
+		TotalPaidGigabytesBandwidthUsed: billing.TotalPaidGigabytesBandwidthUsed,
+		IncludedGigabytesBandwidth:      billing.IncludedGigabytesBandwidth,
+	}, resp, nil
+}
+
+func getOrgStorageBilling(ctx context.Context, client *github.Client, org string) (*OrgStorageBilling, *github.Response, error) {
+	billing, resp, err := client.Billing.GetStorageBillingOrg(ctx, org)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	return &OrgStorageBilling{
+		Available:                    true,
+		DaysLeftInBillingCycle:       billing.DaysLeftInBillingCycle,
+		EstimatedPaidStorageForMonth: billing.EstimatedPaidStorageForMonth,
+		EstimatedStorageForMonth:     billing.EstimatedStorageForMonth,
+	}, resp, nil
+}
+
+// orgBillingTool creates a tool that returns one kind of billing figures of an organization.
+func orgBillingTool[T any](
+	name, description, title, errMessage string,
+	get func(context.Context, *github.Client, string) (T, *github.Response, error),
+	getClient GetClientFn,
+) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        title,
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			billing, resp, err := get(ctx, client, org)
+			if err != nil {
+				if notAvailable, ok := billingNotAvailable(resp, err); ok {
+					return MarshalledTextResult(notAvailable), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, errMessage, resp, err), nil
+			}
+
+			return MarshalledTextResult(billing), nil
+		}
+}
+
+// GetActionsBillingOrg creates a tool to get the GitHub Actions minutes an organization has used.
+func GetActionsBillingOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return orgBillingTool("get_actions_billing_org",
+		t("TOOL_GET_ACTIONS_BILLING_ORG_DESCRIPTION", "Get the GitHub Actions minutes an organization has used in the current billing cycle, the minutes included in its plan and the minutes used per runner operating system. Returns available: false with a reason when GitHub does not provide the figures for the organization."),
+		t("TOOL_GET_ACTIONS_BILLING_ORG_USER_TITLE", "Get organization Actions billing"),
+		"failed to get Actions billing",
+		getOrgActionsBilling,
+		getClient,
+	)
+}
+
+// GetPackagesBillingOrg creates a tool to get the GitHub Packages data transfer of an organization.
+func GetPackagesBillingOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return orgBillingTool("get_packages_billing_org",
+		t("TOOL_GET_PACKAGES_BILLING_ORG_DESCRIPTION", "Get the GitHub Packages data transfer an organization has used in the current billing cycle and the amount included in its plan, in gigabytes. Returns available: false with a reason when GitHub does not provide the figures for the organization."),
+		t("TOOL_GET_PACKAGES_BILLING_ORG_USER_TITLE", "Get organization Packages billing"),
+		"failed to get Packages billing",
+		getOrgPackagesBilling,
+		getClient,
+	)
+}
+
+// GetStorageBillingOrg creates a tool to get the estimated shared storage of an organization.
+func GetStorageBillingOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return orgBillingTool("get_storage_billing_org",
+		t("TOOL_GET_STORAGE_BILLING_ORG_DESCRIPTION", "Get the estimated Actions and Packages shared storage of an organization for the month, in gigabytes, and the days left in its billing cycle. Returns available: false with a reason when GitHub does not provide the figures for the organization."),
+		t("TOOL_GET_STORAGE_BILLING_ORG_USER_TITLE", "Get organization storage billing"),
+		"failed to get storage billing",
+		getOrgStorageBilling,
+		getClient,
+	)
+}
+
+// GetOrgBillingSummary creates a tool that returns the Actions, Packages and storage billing of an organization at once.
+func GetOrgBillingSummary(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_billing_summary",
+			mcp.WithDescription(t("TOOL_GET_ORG_BILLING_SUMMARY_DESCRIPTION", "Get a summary of an organization's billing in the current cycle: the GitHub Actions minutes and Packages data transfer used against what its plan includes, the estimated shared storage and the days left in the cycle. Use this to answer questions such as whether the organization is close to its Actions minutes cap.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_BILLING_SUMMARY_USER_TITLE", "Get organization billing summary"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			summary := OrgBillingSummary{Org: org}
+			sections := []string{"actions", "packages", "storage"}
+			responses := make([]*github.Response, len(sections))
+
+			errs := runBounded(ctx, maxConcurrentRequests,
+				func(ctx context.Context) error {
+					var err error
+					summary.Actions, responses[0], err = getOrgActionsBilling(ctx, client, org)
+					return err
+				},
+				func(ctx context.Context) error {
+					var err error
+					summary.Packages, responses[1], err = getOrgPackagesBilling(ctx, client, org)
+					return err
+				},
+				func(ctx context.Context) error {
+					var err error
+					summary.Storage, responses[2], err = getOrgStorageBilling(ctx, client, org)
+					return err
+				},
+			)
+
+			failed := 0
+			for i, err := range errs {
+				if err == nil {
+					continue
+				}
+				if notAvailable, ok := billingNotAvailable(responses[i], err); ok {
+					if summary.NotAvailable == nil {
+						summary.NotAvailable = make(map[string]string)
+					}
+					summary.NotAvailable[sections[i]] = notAvailable.Reason
+					continue
+				}
+				failed++
+				summary.Warnings = append(summary.Warnings, fmt.Sprintf("failed to get %s billing: %v", sections[i], err))
+			}
+
+			// Without any of the figures there is nothing to summarize.
+			if failed == len(sections) {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get organization billing",
+					responses[0],
+					errs[0],
+				), nil
+			}
+
+			return MarshalledTextResult(summary), nil
+		}
+}
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockActionsBilling = &github.ActionBilling{
+		TotalMinutesUsed:     1800,
+		IncludedMinutes:      3000,
+		MinutesUsedBreakdown: github.MinutesUsedBreakdown{"UBUNTU": 1500, "WINDOWS": 300},
+	}
+	mockPackagesBilling = &github.PackageBilling{
+		TotalGigabytesBandwidthUsed:     12,
+		TotalPaidGigabytesBandwidthUsed: 2,
+		IncludedGigabytesBandwidth:      10,
+	}
+	mockStorageBilling = &github.StorageBilling{
+		DaysLeftInBillingCycle:   9,
+		EstimatedStorageForMonth: 4.5,
+	}
+)
+
+func rateLimitedHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}
+}
+
+func Test_OrgBillingTools(t *testing.T) {
+	tools := []struct {
+		name     string
+		newTool  func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		pattern  mock.EndpointPattern
+		path     string
+		response any
+		expected string
+	}{
+		{
+			name:     "get_actions_billing_org",
+			newTool:  GetActionsBillingOrg,
+			pattern:  mock.GetOrgsSettingsBillingActionsByOrg,
+			path:     "/orgs/octo-org/settings/billing/actions",
+			response: mockActionsBilling,
+			expected: `{"available":true,"total_minutes_used":1800,"total_paid_minutes_used":0,"included_minutes":3000,"included_minutes_left":1200,"minutes_used_breakdown":{"UBUNTU":1500,"WINDOWS":300}}`,
+		},
+		{
+			name:     "get_packages_billing_org",
+			newTool:  GetPackagesBillingOrg,
+			pattern:  mock.GetOrgsSettingsBillingPackagesByOrg,
+			path:     "/orgs/octo-org/settings/billing/packages",
+			response: mockPackagesBilling,
+			expected: `{"available":true,"total_gigabytes_bandwidth_used":12,"total_paid_gigabytes_bandwidth_used":2,"included_gigabytes_bandwidth":10}`,
+		},
+		{
+			name:     "get_storage_billing_org",
+			newTool:  GetStorageBillingOrg,
+			pattern:  mock.GetOrgsSettingsBillingSharedStorageByOrg,
+			path:     "/orgs/octo-org/settings/billing/shared-storage",
+			response: mockStorageBilling,
+			expected: `{"available":true,"days_left_in_billing_cycle":9,"estimated_paid_storage_for_month":0,"estimated_storage_for_month":4.5}`,
+		},
+	}
+
+	for _, tt := range tools {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verify tool definition once
+			tool, _ := tt.newTool(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+			require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+			assert.Equal(t, tt.name, tool.Name)
+			assert.NotEmpty(t, tool.Description)
+			assert.Contains(t, tool.InputSchema.Properties, "org")
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+			tests := []struct {
+				name           string
+				handler        http.HandlerFunc
+				expectError    bool
+				expectedErrMsg string
+				expectedResult string
+			}{
+				{
+					name:           "successful request",
+					handler:        expectPath(t, tt.path).andThen(mockResponse(t, http.StatusOK, tt.response)),
+					expectedResult: tt.expected,
+				},
+				{
+					name:           "endpoint not available for the plan",
+					handler:        mockResponse(t, http.StatusGone, `{"message": "Gone"}`),
+					expectedResult: `{"available":false,"reason":"GitHub does not provide these billing figures for the organization's plan"}`,
+				},
+				{
+					name:           "figures not shared with the caller",
+					handler:        mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+					expectedResult: `{"available":false,"reason":"GitHub did not share these billing figures, the organization's plan may not include them or the token may not be allowed to read the organization's billing"}`,
+				},
+				{
+					name:           "organization not found",
+					handler:        mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+					expectError:    true,
+					expectedErrMsg: "failed to get",
+				},
+				{
+					name:           "rate limited",
+					handler:        rateLimitedHandler(),
+					expectError:    true,
+					expectedErrMsg: "rate limit",
+				},
+			}
+
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					client := github.NewClient(mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(tt.pattern, tc.handler)))
+					_, handler := tt.newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+					result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org"}))
+					require.NoError(t, err)
+
+					if tc.expectError {
+						require.True(t, result.IsError)
+						assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+						return
+					}
+
+					require.False(t, result.IsError)
+					assert.JSONEq(t, tc.expectedResult, getTextResult(t, result).Text)
+				})
+			}
+		})
+	}
+}
+
+func Test_GetOrgBillingSummary(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetOrgBillingSummary(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_billing_summary", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       OrgBillingSummary
+	}{
+		{
+			name: "all figures available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsSettingsBillingActionsByOrg, mockActionsBilling),
+				mock.WithRequestMatch(mock.GetOrgsSettingsBillingPackagesByOrg, mockPackagesBilling),
+				mock.WithRequestMatch(mock.GetOrgsSettingsBillingSharedStorageByOrg, mockStorageBilling),
+			),
+			expected: OrgBillingSummary{
+				Org: "octo-org",
+				Actions: &OrgActionsBilling{
+					Available:            true,
+					TotalMinutesUsed:     1800,
+					IncludedMinutes:      3000,
+					IncludedMinutesLeft:  1200,
+					MinutesUsedBreakdown: map[string]int{"UBUNTU": 1500, "WINDOWS": 300},
+				},
+				Packages: &OrgPackagesBilling{
+					Available:                       true,
+					TotalGigabytesBandwidthUsed:     12,
+					TotalPaidGigabytesBandwidthUsed: 2,
+					IncludedGigabytesBandwidth:      10,
+				},
+				Storage: &OrgStorageBilling{
+					Available:                true,
+					DaysLeftInBillingCycle:   9,
+					EstimatedStorageForMonth: 4.5,
+				},
+			},
+		},
+		{
+			name: "unavailable and failed sections",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsSettingsBillingActionsByOrg, mockActionsBilling),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSettingsBillingPackagesByOrg,
+					mockResponse(t, http.StatusGone, `{"message": "Gone"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSettingsBillingSharedStorageByOrg,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+				),
+			),
+			expected: OrgBillingSummary{
+				Org: "octo-org",
+				Actions: &OrgActionsBilling{
+					Available:            true,
+					TotalMinutesUsed:     1800,
+					IncludedMinutes:      3000,
+					IncludedMinutesLeft:  1200,
+					MinutesUsedBreakdown: map[string]int{"UBUNTU": 1500, "WINDOWS": 300},
+				},
+				NotAvailable: map[string]string{
+					"packages": "GitHub does not provide these billing figures for the organization's plan",
+				},
+			},
+		},
+		{
+			name: "every section fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSettingsBillingActionsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSettingsBillingPackagesByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSettingsBillingSharedStorageByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get organization billing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgBillingSummary(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var summary OrgBillingSummary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+
+			warnings := summary.Warnings
+			summary.Warnings = nil
+			assert.Equal(t, tc.expected, summary)
+			if tc.expected.Storage == nil && tc.expected.NotAvailable["storage"] == "" {
+				require.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "failed to get storage billing")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
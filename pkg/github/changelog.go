@@ -0,0 +1,316 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxChangelogCommits is the most commits generate_changelog looks up the pull requests of, which is
+// the most commits a comparison returns. Larger ranges fall back to GitHub's generated release notes.
+const maxChangelogCommits = 250
+
+// ChangelogSection is a section of a changelog, listing the pull requests with any of its labels.
+type ChangelogSection struct {
+	Title  string   `json:"title"`
+	Labels []string `json:"labels"`
+}
+
+// defaultChangelogSections are the sections of a changelog when none are given.
+var defaultChangelogSections = []ChangelogSection{
+	{Title: "Features", Labels: []string{"feature", "enhancement"}},
+	{Title: "Bug fixes", Labels: []string{"bug", "fix"}},
+	{Title: "Documentation", Labels: []string{"documentation", "docs"}},
+}
+
+// otherChangesSection is the title of the section of pull requests without the labels of any section.
+const otherChangesSection = "Other changes"
+
+// ChangelogEntry is a pull request listed in a changelog.
+type ChangelogEntry struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title"`
+	URL     string   `json:"url"`
+	Author  string   `json:"author"`
+	Labels  []string `json:"labels,omitempty"`
+	Section string   `json:"section"`
+}
+
+// Changelog is the output type of the generate_changelog tool.
+type Changelog struct {
+	Base string `json:"base"`
+	Head string `json:"head"`
+	// Source is compare when the pull requests were found from the commits between base and head, or
+	// release_notes when the range was too large for that and GitHub generated the changelog instead.
+	Source       string           `json:"source"`
+	Commits      int              `json:"commits"`
+	PullRequests []ChangelogEntry `json:"pull_requests,omitempty"`
+	// Excluded is the number of merged pull requests left out for their labels.
+	Excluded int    `json:"excluded"`
+	Markdown string `json:"markdown"`
+}
+
+// optionalChangelogSections returns the sections parameter, or the default sections if it is not set.
+func optionalChangelogSections(request mcp.CallToolRequest) ([]ChangelogSection, error) {
+	value, ok := request.GetArguments()["sections"]
+	if !ok || value == nil {
+		return defaultChangelogSections, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("parameter sections is not of type array, is %T", value)
+	}
+	sections := make([]ChangelogSection, 0, len(items))
+	for i, item := range items {
+		object, _ := item.(map[string]any)
+		title, _ := object["title"].(string)
+		labels, _ := object["labels"].([]any)
+		if title == "" || len(labels) == 0 {
+			return nil, fmt.Errorf("sections[%d] must have a title and at least one label", i)
+		}
+		section := ChangelogSection{Title: title}
+		for _, label := range labels {
+			label, ok := label.(string)
+			if !ok {
+				return nil, fmt.Errorf("sections[%d].labels must be strings", i)
+			}
+			section.Labels = append(section.Labels, label)
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// hasAnyLabel reports whether any of labels is in want, ignoring case as GitHub does.
+func hasAnyLabel(labels []string, want []string) bool {
+	for _, label := range labels {
+		for _, w := range want {
+			if strings.EqualFold(label, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderChangelog renders entries as markdown, with a heading for each section in the order of
+// sections followed by the other changes. Sections without entries are left out.
+func renderChangelog(base, head string, sections []ChangelogSection, entries []ChangelogEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Changes from %s to %s\n", base, head)
+	if len(entries) == 0 {
+		b.WriteString("\nNo pull requests were merged.\n")
+		return b.String()
+	}
+	titles := make([]string, 0, len(sections)+1)
+	for _, section := range sections {
+		titles = append(titles, section.Title)
+	}
+	titles = append(titles, otherChangesSection)
+	for _, title := range titles {
+		first := true
+		for _, entry := range entries {
+			if entry.Section != title {
+				continue
+			}
+			if first {
+				fmt.Fprintf(&b, "\n### %s\n\n", title)
+				first = false
+			}
+			fmt.Fprintf(&b, "- %s ([#%d](%s)) by @%s\n", entry.Title, entry.Number, entry.URL, entry.Author)
+		}
+	}
+	return b.String()
+}
+
+// GenerateChangelog creates a tool to generate a changelog of the pull requests merged between two refs.
+func GenerateChangelog(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("generate_changelog",
+			mcp.WithDescription(t("TOOL_GENERATE_CHANGELOG_DESCRIPTION", fmt.Sprintf("Generate a markdown changelog of the pull requests merged between two refs, such as the latest release and the default branch, grouped into sections by their labels, with links and authors. Pull requests with an excluded label are left out. Ranges of more than %d commits fall back to GitHub's generated release notes, which group pull requests as the repository's .github/release.yml says.", maxChangelogCommits))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GENERATE_CHANGELOG_USER_TITLE", "Generate changelog"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("base",
+				mcp.Description("Ref the changelog starts after, such as a tag. Defaults to the tag of the latest release"),
+			),
+			mcp.WithString("head",
+				mcp.Description("Ref the changelog ends at. Defaults to HEAD, the default branch"),
+			),
+			mcp.WithArray("sections",
+				mcp.Description("Sections of the changelog, in order, each listing the pull requests with any of its labels. Pull requests are listed in the first section they match, or under Other changes. Defaults to Features (feature, enhancement), Bug fixes (bug, fix) and Documentation (documentation, docs)"),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"title": map[string]any{
+							"type":        "string",
+							"description": "Heading of the section",
+						},
+						"labels": map[string]any{
+							"type":        "array",
+							"description": "Labels of the pull requests listed in the section",
+							"items":       map[string]any{"type": "string"},
+						},
+					},
+					"required": []string{"title", "labels"},
+				}),
+			),
+			mcp.WithArray("exclude_labels",
+				mcp.Description("Labels of pull requests to leave out of the changelog. Defaults to skip-changelog"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := OptionalParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if head == "" {
+				head = "HEAD"
+			}
+			sections, err := optionalChangelogSections(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludeLabels, err := OptionalStringArrayParam(request, "exclude_labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["exclude_labels"]; !ok {
+				excludeLabels = []string{"skip-changelog"}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if base == "" {
+				release, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError("the repository has no releases, so base is required"), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get latest release", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				base = release.GetTagName()
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to compare refs", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			changelog := Changelog{Base: base, Head: head, Source: "compare", Commits: comparison.GetTotalCommits()}
+			if changelog.Commits > len(comparison.Commits) || len(comparison.Commits) > maxChangelogCommits {
+				// Too many commits to look up the pull requests of, GitHub generates the changelog instead
+				notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, &github.GenerateNotesOptions{
+					TagName:         head,
+					PreviousTagName: github.Ptr(base),
+					TargetCommitish: github.Ptr(head),
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to generate release notes", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				changelog.Source = "release_notes"
+				changelog.Markdown = notes.Body
+				return MarshalledTextResult(changelog), nil
+			}
+
+			// A pull request is in the range if its merge commit is, whether it was merged, squashed or
+			// rebased, which leaves out pull requests that only share commits with the range
+			inRange := make(map[string]bool, len(comparison.Commits))
+			for _, commit := range comparison.Commits {
+				inRange[commit.GetSHA()] = true
+			}
+			pulls := make([][]*github.PullRequest, len(comparison.Commits))
+			tasks := make([]func(context.Context) error, len(comparison.Commits))
+			for i, commit := range comparison.Commits {
+				tasks[i] = func(ctx context.Context) error {
+					prs, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, commit.GetSHA(), nil)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list pull requests of commit", resp, err)
+						return fmt.Errorf("failed to list pull requests of commit %s: %w", commit.GetSHA(), err)
+					}
+					_ = resp.Body.Close()
+					pulls[i] = prs
+					return nil
+				}
+			}
+			for _, err := range runBounded(ctx, maxConcurrentRequests, tasks...) {
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			seen := map[int]bool{}
+			for _, prs := range pulls {
+				for _, pr := range prs {
+					if pr.MergedAt == nil || !inRange[pr.GetMergeCommitSHA()] || seen[pr.GetNumber()] {
+						continue
+					}
+					seen[pr.GetNumber()] = true
+					labels := make([]string, 0, len(pr.Labels))
+					for _, label := range pr.Labels {
+						labels = append(labels, label.GetName())
+					}
+					if hasAnyLabel(labels, excludeLabels) {
+						changelog.Excluded++
+						continue
+					}
+					entry := ChangelogEntry{
+						Number:  pr.GetNumber(),
+						Title:   pr.GetTitle(),
+						URL:     pr.GetHTMLURL(),
+						Author:  pr.GetUser().GetLogin(),
+						Labels:  labels,
+						Section: otherChangesSection,
+					}
+					if i := slices.IndexFunc(sections, func(section ChangelogSection) bool {
+						return hasAnyLabel(labels, section.Labels)
+					}); i >= 0 {
+						entry.Section = sections[i].Title
+					}
+					changelog.PullRequests = append(changelog.PullRequests, entry)
+				}
+			}
+			sort.Slice(changelog.PullRequests, func(i, j int) bool {
+				return changelog.PullRequests[i].Number < changelog.PullRequests[j].Number
+			})
+			changelog.Markdown = renderChangelog(base, head, sections, changelog.PullRequests)
+
+			return MarshalledTextResult(changelog), nil
+		}
+}
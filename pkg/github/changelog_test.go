@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateChangelog(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GenerateChangelog(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "generate_changelog", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	merged := &github.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	pr := func(number int, title, author, mergeCommit string, labels ...string) *github.PullRequest {
+		pr := &github.PullRequest{
+			Number:         github.Ptr(number),
+			Title:          github.Ptr(title),
+			HTMLURL:        github.Ptr(fmt.Sprintf("https://github.com/owner/repo/pull/%d", number)),
+			User:           &github.User{Login: github.Ptr(author)},
+			MergeCommitSHA: github.Ptr(mergeCommit),
+		}
+		if mergeCommit != "" {
+			pr.MergedAt = merged
+		}
+		for _, label := range labels {
+			pr.Labels = append(pr.Labels, &github.Label{Name: github.Ptr(label)})
+		}
+		return pr
+	}
+	// The pull requests of each commit of the range
+	pullsOfCommits := map[string][]*github.PullRequest{
+		"aaa": {pr(10, "Add dark mode", "octocat", "aaa", "Enhancement")},
+		"bbb": {
+			pr(11, "Fix crash on start", "hubot", "bbb", "bug"),
+			// Open pull requests that contain the commit are left out, as are duplicates
+			pr(13, "Work in progress", "octocat", ""),
+			pr(10, "Add dark mode", "octocat", "aaa", "Enhancement"),
+		},
+		"ccc": {
+			pr(12, "Bump dependencies", "dependabot", "ccc", "skip-changelog"),
+			// Pull requests merged elsewhere that share the commit are left out
+			pr(14, "Backport fix", "hubot", "fff", "bug"),
+		},
+		"ddd": {pr(15, "Refactor parser", "monalisa", "ddd")},
+	}
+	comparison := &github.CommitsComparison{
+		TotalCommits: github.Ptr(4),
+		Commits: []*github.RepositoryCommit{
+			{SHA: github.Ptr("aaa")}, {SHA: github.Ptr("bbb")}, {SHA: github.Ptr("ccc")}, {SHA: github.Ptr("ddd")},
+		},
+	}
+	listPullsOfCommits := mock.WithRequestMatchHandler(
+		mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sha := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/commits/"), "/pulls")
+			mockResponse(t, http.StatusOK, pullsOfCommits[sha])(w, r)
+		}),
+	)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedSource   string
+		expectedNumbers  []int
+		expectedExcluded int
+		expectedMarkdown string
+	}{
+		{
+			name: "changelog since the latest release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, &github.RepositoryRelease{TagName: github.Ptr("v1.0.0")}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.0.0...HEAD").andThen(
+						mockResponse(t, http.StatusOK, comparison),
+					),
+				),
+				listPullsOfCommits,
+			),
+			requestArgs:      map[string]any{"owner": "owner", "repo": "repo"},
+			expectedSource:   "compare",
+			expectedNumbers:  []int{10, 11, 15},
+			expectedExcluded: 1,
+			expectedMarkdown: "## Changes from v1.0.0 to HEAD\n" +
+				"\n### Features\n\n- Add dark mode ([#10](https://github.com/owner/repo/pull/10)) by @octocat\n" +
+				"\n### Bug fixes\n\n- Fix crash on start ([#11](https://github.com/owner/repo/pull/11)) by @hubot\n" +
+				"\n### Other changes\n\n- Refactor parser ([#15](https://github.com/owner/repo/pull/15)) by @monalisa\n",
+		},
+		{
+			name: "custom sections and no excluded labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.0.0...v1.1.0").andThen(
+						mockResponse(t, http.StatusOK, comparison),
+					),
+				),
+				listPullsOfCommits,
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"base":           "v1.0.0",
+				"head":           "v1.1.0",
+				"sections":       []any{map[string]any{"title": "Maintenance", "labels": []any{"skip-changelog", "bug"}}},
+				"exclude_labels": []any{},
+			},
+			expectedSource:  "compare",
+			expectedNumbers: []int{10, 11, 12, 15},
+			expectedMarkdown: "## Changes from v1.0.0 to v1.1.0\n" +
+				"\n### Maintenance\n\n- Fix crash on start ([#11](https://github.com/owner/repo/pull/11)) by @hubot\n" +
+				"- Bump dependencies ([#12](https://github.com/owner/repo/pull/12)) by @dependabot\n" +
+				"\n### Other changes\n\n- Add dark mode ([#10](https://github.com/owner/repo/pull/10)) by @octocat\n" +
+				"- Refactor parser ([#15](https://github.com/owner/repo/pull/15)) by @monalisa\n",
+		},
+		{
+			name: "large ranges fall back to generated release notes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					&github.CommitsComparison{TotalCommits: github.Ptr(1000), Commits: comparison.Commits},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":          "v2.0.0",
+						"previous_tag_name": "v1.0.0",
+						"target_commitish":  "v2.0.0",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryReleaseNotes{
+							Name: "v2.0.0",
+							Body: "## What's Changed\n* Add dark mode by @octocat in #10\n",
+						}),
+					),
+				),
+			),
+			requestArgs:      map[string]any{"owner": "owner", "repo": "repo", "base": "v1.0.0", "head": "v2.0.0"},
+			expectedSource:   "release_notes",
+			expectedMarkdown: "## What's Changed\n* Add dark mode by @octocat in #10\n",
+		},
+		{
+			name: "no releases",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo"},
+			expectError:    true,
+			expectedErrMsg: "the repository has no releases, so base is required",
+		},
+		{
+			name:         "section without labels",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base":     "v1.0.0",
+				"sections": []any{map[string]any{"title": "Features"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "sections[0] must have a title and at least one label",
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "base": "v0.0.0"},
+			expectError:    true,
+			expectedErrMsg: "failed to compare refs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GenerateChangelog(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var changelog Changelog
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &changelog))
+			assert.Equal(t, tc.expectedSource, changelog.Source)
+			var numbers []int
+			for _, entry := range changelog.PullRequests {
+				numbers = append(numbers, entry.Number)
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers)
+			assert.Equal(t, tc.expectedExcluded, changelog.Excluded)
+			assert.Equal(t, tc.expectedMarkdown, changelog.Markdown)
+		})
+	}
+}
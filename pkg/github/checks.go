@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ChecksRollup summarizes the check runs and commit statuses of a commit.
+type ChecksRollup struct {
+	// State is "failure" if any check failed, "pending" if any has not completed, "none" if the commit
+	// has no checks, and "success" otherwise.
+	State      string         `json:"state"`
+	TotalCount int            `json:"total_count"`
+	Counts     map[string]int `json:"counts"`
+	Failing    []FailingCheck `json:"failing"`
+	Pending    []string       `json:"pending"`
+}
+
+// FailingCheck is a failing check run or commit status.
+type FailingCheck struct {
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+	URL        string `json:"url,omitempty"`
+}
+
+// getChecksRollup summarizes the check runs and commit statuses of a ref.
+func getChecksRollup(ctx context.Context, client *github.Client, owner, repo, ref string) (*ChecksRollup, error) {
+	checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs: %w", err)
+	}
+	_ = resp.Body.Close()
+	status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get combined status: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	checks := &ChecksRollup{
+		Counts:  map[string]int{},
+		Failing: []FailingCheck{},
+		Pending: []string{},
+	}
+	for _, run := range checkRuns.CheckRuns {
+		checks.TotalCount++
+		if run.GetStatus() != "completed" {
+			checks.Counts["pending"]++
+			checks.Pending = append(checks.Pending, run.GetName())
+			continue
+		}
+		conclusion := run.GetConclusion()
+		checks.Counts[conclusion]++
+		switch conclusion {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			checks.Failing = append(checks.Failing, FailingCheck{Name: run.GetName(), Conclusion: conclusion, URL: run.GetHTMLURL()})
+		}
+	}
+	for _, s := range status.Statuses {
+		checks.TotalCount++
+		state := s.GetState()
+		switch state {
+		case "pending":
+			checks.Counts["pending"]++
+			checks.Pending = append(checks.Pending, s.GetContext())
+		case "failure", "error":
+			checks.Counts["failure"]++
+			checks.Failing = append(checks.Failing, FailingCheck{Name: s.GetContext(), Conclusion: state, URL: s.GetTargetURL()})
+		default:
+			checks.Counts[state]++
+		}
+	}
+
+	switch {
+	case len(checks.Failing) > 0:
+		checks.State = "failure"
+	case len(checks.Pending) > 0:
+		checks.State = "pending"
+	case checks.TotalCount == 0:
+		checks.State = "none"
+	default:
+		checks.State = "success"
+	}
+	return checks, nil
+}
+
+// checksPollInterval is how often wait_for_checks checks a ref. It is a variable so that tests can
+// shorten it.
+var checksPollInterval = 10 * time.Second
+
+// ChecksWaitResult is the output type of the wait_for_checks tool.
+type ChecksWaitResult struct {
+	Ref string `json:"ref"`
+	// Settled is false if checks were still pending after the timeout.
+	Settled       bool `json:"settled"`
+	WaitedSeconds int  `json:"waited_seconds"`
+	*ChecksRollup
+}
+
+// WaitForChecks creates a tool to wait for the checks of a commit to settle
+func WaitForChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_checks",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_CHECKS_DESCRIPTION", "Wait until no check run or commit status of a commit is pending, and return a summary of the checks. Use this before merging. Returns early with settled set to false if checks are still pending after the timeout, and right away with state none if the commit has no checks.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WAIT_FOR_CHECKS_USER_TITLE", "Wait for checks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name to wait for the checks of"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description(fmt.Sprintf("How long to wait for the checks to settle, in seconds (default %d, max %d)", defaultWorkflowRunWait, maxWorkflowRunWait)),
+				mcp.Min(1),
+				mcp.Max(maxWorkflowRunWait),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timeoutSeconds, err := OptionalIntParamWithDefault(request, "timeout_seconds", defaultWorkflowRunWait)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds < 1 || timeoutSeconds > maxWorkflowRunWait {
+				return mcp.NewToolResultError(fmt.Sprintf("timeout_seconds must be between 1 and %d", maxWorkflowRunWait)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			start := time.Now()
+			deadline := time.NewTimer(time.Duration(timeoutSeconds) * time.Second)
+			defer deadline.Stop()
+			for {
+				rollup, err := getChecksRollup(ctx, client, owner, repo, ref)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil, ctx.Err()
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get checks", nil, err), nil
+				}
+
+				result := ChecksWaitResult{
+					Ref:           ref,
+					Settled:       len(rollup.Pending) == 0,
+					WaitedSeconds: int(time.Since(start).Seconds()),
+					ChecksRollup:  rollup,
+				}
+				if result.Settled {
+					return MarshalledTextResult(result), nil
+				}
+
+				poll := time.NewTimer(checksPollInterval)
+				select {
+				case <-ctx.Done():
+					poll.Stop()
+					return nil, ctx.Err()
+				case <-deadline.C:
+					poll.Stop()
+					result.WaitedSeconds = timeoutSeconds
+					return MarshalledTextResult(result), nil
+				case <-poll.C:
+				}
+			}
+		}
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WaitForChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "wait_for_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	interval := checksPollInterval
+	checksPollInterval = time.Millisecond
+	t.Cleanup(func() { checksPollInterval = interval })
+
+	// checksSucceedingAfter reports the build check run as in progress for the first polls, then as
+	// successful
+	checksSucceedingAfter := func(polls int32) http.HandlerFunc {
+		var calls atomic.Int32
+		return func(w http.ResponseWriter, r *http.Request) {
+			run := &github.CheckRun{Name: github.Ptr("build"), Status: github.Ptr("in_progress")}
+			if calls.Add(1) > polls {
+				run.Status = github.Ptr("completed")
+				run.Conclusion = github.Ptr("success")
+			}
+			mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+				Total:     github.Ptr(1),
+				CheckRuns: []*github.CheckRun{run},
+			})(w, r)
+		}
+	}
+	legacyStatus := mock.WithRequestMatchHandler(
+		mock.GetReposCommitsStatusByOwnerByRepoByRef,
+		mockResponse(t, http.StatusOK, &github.CombinedStatus{
+			Statuses: []*github.RepoStatus{{Context: github.Ptr("ci/legacy"), State: github.Ptr("success")}},
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult ChecksWaitResult
+	}{
+		{
+			name: "waits until the checks settle",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checksSucceedingAfter(3)),
+				legacyStatus,
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedResult: ChecksWaitResult{
+				Ref:     "main",
+				Settled: true,
+				ChecksRollup: &ChecksRollup{
+					State:      "success",
+					TotalCount: 2,
+					Counts:     map[string]int{"success": 2},
+					Failing:    []FailingCheck{},
+					Pending:    []string{},
+				},
+			},
+		},
+		{
+			name: "stops waiting after the timeout",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checksSucceedingAfter(1<<30)),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockResponse(t, http.StatusOK, &github.CombinedStatus{}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"ref":             "main",
+				"timeout_seconds": float64(1),
+			},
+			expectedResult: ChecksWaitResult{
+				Ref:           "main",
+				WaitedSeconds: 1,
+				ChecksRollup: &ChecksRollup{
+					State:      "pending",
+					TotalCount: 1,
+					Counts:     map[string]int{"pending": 1},
+					Failing:    []FailingCheck{},
+					Pending:    []string{"build"},
+				},
+			},
+		},
+		{
+			name: "commit without checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, &github.ListCheckRunsResults{Total: github.Ptr(0)}),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "abc123",
+			},
+			expectedResult: ChecksWaitResult{
+				Ref:     "abc123",
+				Settled: true,
+				ChecksRollup: &ChecksRollup{
+					State:   "none",
+					Counts:  map[string]int{},
+					Failing: []FailingCheck{},
+					Pending: []string{},
+				},
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get checks",
+		},
+		{
+			name:         "timeout too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"ref":             "main",
+				"timeout_seconds": float64(7200),
+			},
+			expectError:    true,
+			expectedErrMsg: "timeout_seconds must be between 1 and 1800",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := WaitForChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned ChecksWaitResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+
+	t.Run("stops when the request is cancelled", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checksSucceedingAfter(1<<30)),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsStatusByOwnerByRepoByRef,
+				mockResponse(t, http.StatusOK, &github.CombinedStatus{}),
+			),
+		))
+		_, handler := WaitForChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := handler(ctx, createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"ref":   "main",
+		}))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// codeownersPaths are the locations GitHub looks for a CODEOWNERS file at, in order.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeownersEntry is a rule of a CODEOWNERS file.
+type CodeownersEntry struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// Codeowners is the output type of the get_codeowners tool.
+type Codeowners struct {
+	// File is the path of the CODEOWNERS file, empty if the repository has none.
+	File    string            `json:"file,omitempty"`
+	Entries []CodeownersEntry `json:"entries,omitempty"`
+	// Path, Pattern and Owners are the owners of the requested file path, and the pattern of the
+	// entry they come from.
+	Path    string   `json:"path,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	Owners  []string `json:"owners,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// codeownersRule is a line of a CODEOWNERS file.
+type codeownersRule struct {
+	pattern string
+	owners  []string
+}
+
+// getCodeownersFile returns the path and content of the CODEOWNERS file of a repository at ref, or
+// an empty path if it has none. The response of the failed request is returned with errors.
+func getCodeownersFile(ctx context.Context, client *github.Client, owner, repo, ref string) (string, string, *github.Response, error) {
+	for _, path := range codeownersPaths {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return "", "", resp, fmt.Errorf("failed to get %s: %w", path, err)
+		}
+		_ = resp.Body.Close()
+		if file == nil {
+			continue
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return "", "", resp, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return path, content, nil, nil
+	}
+	return "", "", nil, nil
+}
+
+// parseCodeowners parses the rules of a CODEOWNERS file, skipping comments and blank lines.
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, codeownersRule{pattern: fields[0], owners: fields[1:]})
+	}
+	return rules
+}
+
+// matchCodeowners returns the rule that applies to a path. As in CODEOWNERS files, the last
+// matching rule wins.
+func matchCodeowners(rules []codeownersRule, path string) (codeownersRule, bool) {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if matchCodeownersPattern(rules[i].pattern, path) {
+			return rules[i], true
+		}
+	}
+	return codeownersRule{}, false
+}
+
+// codeownersFor returns the owners of a path. A matching rule without owners leaves the path
+// without owners.
+func codeownersFor(rules []codeownersRule, path string) []string {
+	rule, _ := matchCodeowners(rules, path)
+	return rule.owners
+}
+
+// matchCodeownersPattern reports whether a CODEOWNERS pattern, which follows gitignore rules,
+// matches a file path.
+func matchCodeownersPattern(pattern, path string) bool {
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// Patterns with a slash other than at the end are relative to the root, others match at any depth
+	if strings.Contains(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else {
+		pattern = "**/" + pattern
+	}
+
+	if !directory {
+		if matched, err := matchGlob(pattern, path); err == nil && matched {
+			return true
+		}
+	}
+	// Patterns matching a directory match everything in it
+	matched, err := matchGlob(pattern+"/**", path)
+	return err == nil && matched
+}
+
+// GetCodeowners creates a tool to get the CODEOWNERS rules of a repository, or the owners of a path.
+func GetCodeowners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codeowners",
+			mcp.WithDescription(t("TOOL_GET_CODEOWNERS_DESCRIPTION", "Get the CODEOWNERS file of a GitHub repository, which defines who reviews changes to which paths, parsed into pattern and owners entries. Looks for .github/CODEOWNERS, CODEOWNERS and docs/CODEOWNERS in that order. With file_path, returns only the owners of that path, from the last entry whose pattern matches it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEOWNERS_USER_TITLE", "Get code owners"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("file_path",
+				mcp.Description("Path of a file or directory to get the owners of, relative to the root of the repository"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to read the CODEOWNERS file at. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err := OptionalParam[string](request, "file_path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			path, content, resp, err := getCodeownersFile(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS file", resp, err), nil
+			}
+			if path == "" {
+				return MarshalledTextResult(Codeowners{
+					Message: fmt.Sprintf("%s/%s has no CODEOWNERS file in its root, .github or docs directory", owner, repo),
+				}), nil
+			}
+
+			rules := parseCodeowners(content)
+			result := Codeowners{File: path}
+			if filePath == "" {
+				result.Entries = make([]CodeownersEntry, 0, len(rules))
+				for _, rule := range rules {
+					result.Entries = append(result.Entries, CodeownersEntry{Pattern: rule.pattern, Owners: rule.owners})
+				}
+				return MarshalledTextResult(result), nil
+			}
+
+			result.Path = strings.TrimPrefix(filePath, "/")
+			rule, ok := matchCodeowners(rules, result.Path)
+			switch {
+			case !ok:
+				result.Message = fmt.Sprintf("no entry of %s matches %s, so it has no code owners", path, result.Path)
+			case len(rule.owners) == 0:
+				result.Pattern = rule.pattern
+				result.Message = fmt.Sprintf("the entry of %s matching %s has no owners, so it has no code owners", path, result.Path)
+			default:
+				result.Pattern, result.Owners = rule.pattern, rule.owners
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CodeownersFor(t *testing.T) {
+	rules := parseCodeowners(`
+# Default owners
+*                 @org/everyone
+*.js              @js-owner
+/build/logs/      @build-owner
+docs/             @docs-owner
+apps/             @apps-owner
+/scripts/**/*.sh  @scripts-owner
+/vendor/          # vendored code has no owners
+`)
+
+	tests := []struct {
+		path   string
+		owners []string
+	}{
+		{"README.md", []string{"@org/everyone"}},
+		{"web/app.js", []string{"@js-owner"}},
+		{"build/logs/out.txt", []string{"@build-owner"}},
+		{"nested/build/logs/out.txt", []string{"@org/everyone"}},
+		{"docs/guide.md", []string{"@docs-owner"}},
+		{"src/docs/guide.md", []string{"@docs-owner"}},
+		{"apps/web/index.js", []string{"@apps-owner"}},
+		{"scripts/ci/release/run.sh", []string{"@scripts-owner"}},
+		{"scripts/run.sh", []string{"@scripts-owner"}},
+		{"vendor/lib/lib.go", []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			owners := codeownersFor(rules, tc.path)
+			if len(tc.owners) == 0 {
+				assert.Empty(t, owners)
+				return
+			}
+			assert.Equal(t, tc.owners, owners)
+		})
+	}
+}
+
+func Test_GetCodeowners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeowners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codeowners", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	codeowners := `# Default owners
+*             @octo-org/everyone
+
+# Frontend
+*.js          @js-owner @octo-org/web
+/docs/        @docs-owner
+/vendor/
+`
+	// contentsAt serves the CODEOWNERS file at the given path only
+	contentsAt := func(existing string) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/repos/owner/repo/contents/"+existing {
+						mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(codeowners))),
+					})(w, r)
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		args         map[string]any
+		expected     Codeowners
+	}{
+		{
+			name:         "all entries",
+			mockedClient: contentsAt("CODEOWNERS"),
+			args:         map[string]any{},
+			expected: Codeowners{
+				File: "CODEOWNERS",
+				Entries: []CodeownersEntry{
+					{Pattern: "*", Owners: []string{"@octo-org/everyone"}},
+					{Pattern: "*.js", Owners: []string{"@js-owner", "@octo-org/web"}},
+					{Pattern: "/docs/", Owners: []string{"@docs-owner"}},
+					{Pattern: "/vendor/", Owners: []string{}},
+				},
+			},
+		},
+		{
+			name:         "owners of a path, last match wins",
+			mockedClient: contentsAt(".github/CODEOWNERS"),
+			args:         map[string]any{"file_path": "/docs/app.js"},
+			expected: Codeowners{
+				File:    ".github/CODEOWNERS",
+				Path:    "docs/app.js",
+				Pattern: "/docs/",
+				Owners:  []string{"@docs-owner"},
+			},
+		},
+		{
+			name:         "path matching an entry without owners",
+			mockedClient: contentsAt("docs/CODEOWNERS"),
+			args:         map[string]any{"file_path": "vendor/lib.js"},
+			expected: Codeowners{
+				File:    "docs/CODEOWNERS",
+				Path:    "vendor/lib.js",
+				Pattern: "/vendor/",
+				Message: "the entry of docs/CODEOWNERS matching vendor/lib.js has no owners, so it has no code owners",
+			},
+		},
+		{
+			name:         "no CODEOWNERS file",
+			mockedClient: contentsAt("nowhere"),
+			args:         map[string]any{},
+			expected:     Codeowners{Message: "owner/repo has no CODEOWNERS file in its root, .github or docs directory"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeowners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			tc.args["owner"] = "owner"
+			tc.args["repo"] = "repo"
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var owners Codeowners
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &owners))
+			assert.Equal(t, tc.expected, owners)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// commitCountMaxCommits bounds the number of commits get_commit_count pages through. The REST API
+// has no endpoint that counts commits, so every commit in the range is listed.
+const commitCountMaxCommits = 5000
+
+// CommitCount is the output type of the get_commit_count tool.
+type CommitCount struct {
+	Repository string `json:"repository"`
+	// Branch is empty when the commits of the default branch were counted.
+	Branch       string     `json:"branch,omitempty"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+	TotalCommits int        `json:"total_commits"`
+	// UniqueAuthors counts authors by GitHub login, or by email address for commits whose author has
+	// no GitHub account.
+	UniqueAuthors   int        `json:"unique_authors"`
+	Authors         []string   `json:"authors"`
+	FirstCommitDate *time.Time `json:"first_commit_date,omitempty"`
+	LastCommitDate  *time.Time `json:"last_commit_date,omitempty"`
+	// Truncated is set when more than MaxCommits commits are in the range, so only the most recent
+	// MaxCommits were counted and FirstCommitDate is the date of the oldest of those.
+	Truncated  bool `json:"truncated"`
+	MaxCommits int  `json:"max_commits"`
+}
+
+// commitAuthor identifies the author of a commit by their GitHub login, falling back to the name and
+// email address recorded in the commit.
+func commitAuthor(commit *github.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	author := commit.GetCommit().GetAuthor()
+	if author.GetEmail() != "" {
+		return fmt.Sprintf("%s <%s>", author.GetName(), author.GetEmail())
+	}
+	return author.GetName()
+}
+
+// GetCommitCount creates a tool to count the commits of a branch in a time range.
+func GetCommitCount(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_count",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_COUNT_DESCRIPTION", fmt.Sprintf("Count the commits of a branch in a GitHub repository over a time range, with the number of unique authors and the dates of the first and last commit in the range. Use this to gauge how active a repository is. Counts at most %d commits.", commitCountMaxCommits))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_COUNT_USER_TITLE", "Get commit count"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to count the commits of. Defaults to the default branch of the repository"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Count only commits made at or after this time, in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Count only commits made at or before this time, in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			untilParam, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result := CommitCount{
+				Repository: fmt.Sprintf("%s/%s", owner, repo),
+				Branch:     branch,
+				Authors:    []string{},
+				MaxCommits: commitCountMaxCommits,
+			}
+			opts := &github.CommitsListOptions{SHA: branch}
+			if sinceParam != "" {
+				since, err := parseISOTimestamp(sinceParam)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err.Error())), nil
+				}
+				result.Since, opts.Since = &since, since
+			}
+			if untilParam != "" {
+				until, err := parseISOTimestamp(untilParam)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse until: %s", err.Error())), nil
+				}
+				result.Until, opts.Until = &until, until
+			}
+			if result.Since != nil && result.Until != nil && result.Until.Before(*result.Since) {
+				return mcp.NewToolResultError("since must not be after until"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commits, truncated, resp, err := collectPages(commitCountMaxCommits, func(listOpts github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.Repositories.ListCommits(ctx, owner, repo, opts)
+			}, nil)
+			if err != nil {
+				// Listing the commits of an empty repository fails with 409 Conflict
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return MarshalledTextResult(result), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list commits", resp, err), nil
+			}
+
+			authors := map[string]bool{}
+			for _, commit := range commits {
+				if author := commitAuthor(commit); author != "" {
+					authors[author] = true
+				}
+				// Commits are listed newest first, and filtered by since and until on their committer date
+				date := commit.GetCommit().GetCommitter().GetDate().Time
+				if date.IsZero() {
+					continue
+				}
+				if result.LastCommitDate == nil || date.After(*result.LastCommitDate) {
+					result.LastCommitDate = &date
+				}
+				if result.FirstCommitDate == nil || date.Before(*result.FirstCommitDate) {
+					result.FirstCommitDate = &date
+				}
+			}
+			for author := range authors {
+				result.Authors = append(result.Authors, author)
+			}
+			sort.Strings(result.Authors)
+			result.TotalCommits = len(commits)
+			result.UniqueAuthors = len(authors)
+			result.Truncated = truncated
+
+			return MarshalledTextResult(result), nil
+		}
+}
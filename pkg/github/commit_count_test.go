@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCommitCount(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitCount(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_count", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	commit := func(login, email, date string) *github.RepositoryCommit {
+		committed, err := time.Parse(time.RFC3339, date)
+		require.NoError(t, err)
+		c := &github.RepositoryCommit{Commit: &github.Commit{
+			Author:    &github.CommitAuthor{Name: github.Ptr("Someone"), Email: github.Ptr(email)},
+			Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: committed}},
+		}}
+		if login != "" {
+			c.Author = &github.User{Login: github.Ptr(login)}
+		}
+		return c
+	}
+	firstPage := []*github.RepositoryCommit{
+		commit("alice", "alice@example.com", "2025-03-20T10:00:00Z"),
+		commit("bob", "bob@example.com", "2025-03-15T10:00:00Z"),
+	}
+	secondPage := []*github.RepositoryCommit{
+		commit("alice", "alice@example.com", "2025-03-10T10:00:00Z"),
+		commit("", "someone@example.com", "2025-03-02T10:00:00Z"),
+	}
+	commitsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "release", query.Get("sha"))
+		assert.Equal(t, "2025-03-01T00:00:00Z", query.Get("since"))
+		assert.Equal(t, "2025-03-31T00:00:00Z", query.Get("until"))
+		if query.Get("page") == "2" {
+			mockResponse(t, http.StatusOK, secondPage)(w, r)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits?page=2>; rel="next"`)
+		mockResponse(t, http.StatusOK, firstPage)(w, r)
+	})
+
+	date := func(s string) *time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &d
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       CommitCount
+	}{
+		{
+			name: "counts commits across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, commitsHandler),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "release",
+				"since":  "2025-03-01",
+				"until":  "2025-03-31T00:00:00Z",
+			},
+			expected: CommitCount{
+				Repository:      "owner/repo",
+				Branch:          "release",
+				Since:           date("2025-03-01T00:00:00Z"),
+				Until:           date("2025-03-31T00:00:00Z"),
+				TotalCommits:    4,
+				UniqueAuthors:   3,
+				Authors:         []string{"Someone <someone@example.com>", "alice", "bob"},
+				FirstCommitDate: date("2025-03-02T10:00:00Z"),
+				LastCommitDate:  date("2025-03-20T10:00:00Z"),
+				MaxCommits:      commitCountMaxCommits,
+			},
+		},
+		{
+			name: "empty repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, `{"message": "Git Repository is empty."}`),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo"},
+			expected: CommitCount{
+				Repository: "owner/repo",
+				Authors:    []string{},
+				MaxCommits: commitCountMaxCommits,
+			},
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "branch": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits",
+		},
+		{
+			name:           "invalid since",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "since": "last week"},
+			expectError:    true,
+			expectedErrMsg: "failed to parse since",
+		},
+		{
+			name:           "since after until",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "since": "2025-04-01", "until": "2025-03-01"},
+			expectError:    true,
+			expectedErrMsg: "since must not be after until",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommitCount(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned CommitCount
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
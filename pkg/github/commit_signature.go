@@ -0,0 +1,359 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mediaTypeCommitVerification asks for the signature verification of commits.
+const mediaTypeCommitVerification = "application/vnd.github.cryptographer-preview+sha"
+
+// CommitSignature is the output type of the verify_commit_signature tool.
+type CommitSignature struct {
+	SHA      string `json:"sha"`
+	Verified bool   `json:"verified"`
+	// Reason is why the signature is verified or not, such as valid, unsigned, unknown_key or bad_email.
+	Reason string `json:"reason"`
+	// SignatureType is gpg, ssh or x509, if the commit is signed.
+	SignatureType string `json:"signature_type,omitempty"`
+	// KeyID and KeyFingerprint identify the key of a GPG signature, as far as the signature says.
+	KeyID          string `json:"key_id,omitempty"`
+	KeyFingerprint string `json:"key_fingerprint,omitempty"`
+	// Signer is the committer, whose key GitHub checks the signature against.
+	Signer    *CommitSigner `json:"signer,omitempty"`
+	Signature string        `json:"signature,omitempty"`
+	Payload   string        `json:"payload,omitempty"`
+}
+
+// CommitSigner identifies the committer of a signed commit.
+type CommitSigner struct {
+	Login string `json:"login,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// signatureType returns the kind of an armored commit signature.
+func signatureType(signature string) string {
+	switch {
+	case strings.Contains(signature, "-----BEGIN PGP SIGNATURE-----"):
+		return "gpg"
+	case strings.Contains(signature, "-----BEGIN SSH SIGNATURE-----"):
+		return "ssh"
+	case strings.Contains(signature, "-----BEGIN SIGNED MESSAGE-----"):
+		return "x509"
+	case signature == "":
+		return ""
+	default:
+		return "unknown"
+	}
+}
+
+// dearmorPGPSignature decodes the packets of an ASCII armored OpenPGP signature.
+func dearmorPGPSignature(signature string) ([]byte, error) {
+	lines := strings.Split(strings.ReplaceAll(signature, "\r\n", "\n"), "\n")
+	var body strings.Builder
+	inBlock, inHeaders := false, false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "-----BEGIN PGP SIGNATURE-----":
+			inBlock, inHeaders = true, true
+		case !inBlock:
+		case strings.HasPrefix(line, "-----END"):
+			return base64.StdEncoding.DecodeString(body.String())
+		case inHeaders:
+			// Headers such as Comment: end at the first blank line, but may be left out
+			if line == "" {
+				inHeaders = false
+			} else if !strings.Contains(line, ": ") {
+				inHeaders = false
+				body.WriteString(line)
+			}
+		case strings.HasPrefix(line, "="):
+			// The checksum
+		default:
+			body.WriteString(line)
+		}
+	}
+	return nil, errors.New("signature is not armored")
+}
+
+// pgpSignatureKey returns the key ID and, if the signature includes it, the fingerprint of the key
+// that made an OpenPGP signature, in upper case hex.
+func pgpSignatureKey(packets []byte) (keyID, fingerprint string, err error) {
+	if len(packets) < 2 || packets[0]&0x80 == 0 {
+		return "", "", errors.New("not an OpenPGP packet")
+	}
+
+	// Read the packet header, in either the new or the old format
+	var tag byte
+	var length int
+	rest := packets[1:]
+	if packets[0]&0x40 != 0 {
+		tag = packets[0] & 0x3f
+		switch first := int(rest[0]); {
+		case first < 192:
+			length, rest = first, rest[1:]
+		case first < 224 && len(rest) >= 2:
+			length, rest = (first-192)<<8+int(rest[1])+192, rest[2:]
+		case first == 255 && len(rest) >= 5:
+			length, rest = int(binary.BigEndian.Uint32(rest[1:5])), rest[5:]
+		default:
+			return "", "", errors.New("unsupported packet length")
+		}
+	} else {
+		tag = (packets[0] >> 2) & 0x0f
+		switch packets[0] & 0x03 {
+		case 0:
+			length, rest = int(rest[0]), rest[1:]
+		case 1:
+			if len(rest) < 2 {
+				return "", "", errors.New("truncated packet")
+			}
+			length, rest = int(binary.BigEndian.Uint16(rest)), rest[2:]
+		case 2:
+			if len(rest) < 4 {
+				return "", "", errors.New("truncated packet")
+			}
+			length, rest = int(binary.BigEndian.Uint32(rest)), rest[4:]
+		default:
+			length = len(rest)
+		}
+	}
+	if tag != 2 {
+		return "", "", fmt.Errorf("packet is not a signature but of type %d", tag)
+	}
+	if length > len(rest) {
+		return "", "", errors.New("truncated packet")
+	}
+	body := rest[:length]
+
+	if len(body) < 1 {
+		return "", "", errors.New("empty signature packet")
+	}
+	switch body[0] {
+	case 3:
+		// Version 3 signatures have the key ID at a fixed position
+		if len(body) < 15 {
+			return "", "", errors.New("truncated signature packet")
+		}
+		return strings.ToUpper(hex.EncodeToString(body[7:15])), "", nil
+	case 4:
+	default:
+		return "", "", fmt.Errorf("unsupported signature version %d", body[0])
+	}
+
+	// Version 4 signatures have hashed and unhashed subpackets after the version, signature type,
+	// public key and hash algorithms
+	if len(body) < 6 {
+		return "", "", errors.New("truncated signature packet")
+	}
+	areas := body[4:]
+	for i := 0; i < 2; i++ {
+		if len(areas) < 2 {
+			return "", "", errors.New("truncated signature packet")
+		}
+		areaLength := int(binary.BigEndian.Uint16(areas))
+		if 2+areaLength > len(areas) {
+			return "", "", errors.New("truncated signature packet")
+		}
+		subpackets := areas[2 : 2+areaLength]
+		areas = areas[2+areaLength:]
+
+		for len(subpackets) > 0 {
+			var subpacketLength int
+			switch first := int(subpackets[0]); {
+			case first < 192:
+				subpacketLength, subpackets = first, subpackets[1:]
+			case first < 255 && len(subpackets) >= 2:
+				subpacketLength, subpackets = (first-192)<<8+int(subpackets[1])+192, subpackets[2:]
+			case first == 255 && len(subpackets) >= 5:
+				subpacketLength, subpackets = int(binary.BigEndian.Uint32(subpackets[1:5])), subpackets[5:]
+			default:
+				return "", "", errors.New("truncated subpacket")
+			}
+			if subpacketLength < 1 || subpacketLength > len(subpackets) {
+				return "", "", errors.New("truncated subpacket")
+			}
+			data := subpackets[1:subpacketLength]
+			switch subpackets[0] & 0x7f {
+			case 16: // Issuer key ID
+				if len(data) == 8 && keyID == "" {
+					keyID = strings.ToUpper(hex.EncodeToString(data))
+				}
+			case 33: // Issuer fingerprint, after the version of the key
+				if len(data) > 1 && fingerprint == "" {
+					fingerprint = strings.ToUpper(hex.EncodeToString(data[1:]))
+				}
+			}
+			subpackets = subpackets[subpacketLength:]
+		}
+	}
+
+	// The key ID of a version 4 key is the end of its fingerprint
+	if keyID == "" && len(fingerprint) == 40 {
+		keyID = fingerprint[24:]
+	}
+	if keyID == "" {
+		return "", "", errors.New("signature does not name its key")
+	}
+	return keyID, fingerprint, nil
+}
+
+// getCommitWithVerification gets a commit with the verification of its signature.
+func getCommitWithVerification(ctx context.Context, client *github.Client, owner, repo, ref string) (*github.RepositoryCommit, *github.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, ref), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", mediaTypeCommitVerification)
+	var commit github.RepositoryCommit
+	resp, err := client.Do(ctx, req, &commit)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &commit, resp, nil
+}
+
+// newCommitSignature summarizes the signature verification of a commit.
+func newCommitSignature(commit *github.RepositoryCommit) CommitSignature {
+	verification := commit.GetCommit().GetVerification()
+	result := CommitSignature{
+		SHA:       commit.GetSHA(),
+		Verified:  verification.GetVerified(),
+		Reason:    verification.GetReason(),
+		Signature: verification.GetSignature(),
+		Payload:   verification.GetPayload(),
+	}
+	result.SignatureType = signatureType(result.Signature)
+	if result.SignatureType == "gpg" {
+		// Malformed signatures are reported by GitHub in the reason, so they are only left without a key
+		if packets, err := dearmorPGPSignature(result.Signature); err == nil {
+			result.KeyID, result.KeyFingerprint, _ = pgpSignatureKey(packets)
+		}
+	}
+	if result.SignatureType != "" {
+		result.Signer = &CommitSigner{
+			Login: commit.GetCommitter().GetLogin(),
+			Name:  commit.GetCommit().GetCommitter().GetName(),
+			Email: commit.GetCommit().GetCommitter().GetEmail(),
+		}
+	}
+	return result
+}
+
+// VerifyCommitSignature creates a tool to check whether GitHub verified the signature of a commit.
+func VerifyCommitSignature(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("verify_commit_signature",
+			mcp.WithDescription(t("TOOL_VERIFY_COMMIT_SIGNATURE_DESCRIPTION", "Check whether the signature of a commit was verified by GitHub, and why not otherwise (unsigned, unknown_key, unverified_email, bad_email, malformed_signature and so on). Returns the signature and signed payload, and for GPG signatures the ID and fingerprint of the signing key.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VERIFY_COMMIT_SIGNATURE_USER_TITLE", "Verify commit signature"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name of the commit"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commit, resp, err := getCommitWithVerification(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get commit", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := newCommitSignature(commit)
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// GetCommitVerification creates a tool to get whether a commit is verified and who signed it, without
+// its signature.
+func GetCommitVerification(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_verification",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_VERIFICATION_DESCRIPTION", "Get whether a commit is verified, the reason GitHub gives, and who signed it: the committer and, for GPG signatures, the ID and fingerprint of the key. Leaves out the signature and signed payload, which verify_commit_signature returns.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_VERIFICATION_USER_TITLE", "Get commit verification"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commit, resp, err := getCommitWithVerification(ctx, client, owner, repo, sha)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get commit", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := newCommitSignature(commit)
+			result.Signature, result.Payload = "", ""
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testGPGSignature was made by gpg with an ed25519 key of fingerprint
+// E1E15E686AF0A836B9E8B4029AC3516F9FF66AC1.
+const testGPGSignature = `-----BEGIN PGP SIGNATURE-----
+
+iHUEABYIAB0WIQTh4V5oavCoNrnotAKaw1Fvn/ZqwQUCatQrwgAKCRCaw1Fvn/Zq
+wZE8AQCTX2nl4F83a5xRmZSCFuKZdf5VEIQ1dyesbG1CEl5oiAD/dAHkQSCNsLRd
+5Vmt5WCF4F2uLtB/2bNvRnvJZHYL7gU=
+=Zf93
+-----END PGP SIGNATURE-----
+`
+
+func Test_VerifyCommitSignature(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := VerifyCommitSignature(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "verify_commit_signature", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	commitWith := func(verification *github.SignatureVerification) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:       github.Ptr("abc123def456"),
+			Committer: &github.User{Login: github.Ptr("octocat")},
+			Commit: &github.Commit{
+				Message:      github.Ptr("Initial commit"),
+				Committer:    &github.CommitAuthor{Name: github.Ptr("The Octocat"), Email: github.Ptr("octocat@github.com")},
+				Verification: verification,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       CommitSignature
+	}{
+		{
+			name: "verified GPG signature",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, mediaTypeCommitVerification, r.Header.Get("Accept"))
+						mockResponse(t, http.StatusOK, commitWith(&github.SignatureVerification{
+							Verified:  github.Ptr(true),
+							Reason:    github.Ptr("valid"),
+							Signature: github.Ptr(testGPGSignature),
+							Payload:   github.Ptr("tree abc\n\nInitial commit\n"),
+						}))(w, r)
+					}),
+				),
+			),
+			expected: CommitSignature{
+				SHA:            "abc123def456",
+				Verified:       true,
+				Reason:         "valid",
+				SignatureType:  "gpg",
+				KeyID:          "9AC3516F9FF66AC1",
+				KeyFingerprint: "E1E15E686AF0A836B9E8B4029AC3516F9FF66AC1",
+				Signer:         &CommitSigner{Login: "octocat", Name: "The Octocat", Email: "octocat@github.com"},
+				Signature:      testGPGSignature,
+				Payload:        "tree abc\n\nInitial commit\n",
+			},
+		},
+		{
+			name: "unsigned commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					commitWith(&github.SignatureVerification{
+						Verified: github.Ptr(false),
+						Reason:   github.Ptr("unsigned"),
+					}),
+				),
+			),
+			expected: CommitSignature{
+				SHA:    "abc123def456",
+				Reason: "unsigned",
+			},
+		},
+		{
+			name: "SSH signature of an unknown key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					commitWith(&github.SignatureVerification{
+						Verified:  github.Ptr(false),
+						Reason:    github.Ptr("unknown_key"),
+						Signature: github.Ptr("-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n"),
+					}),
+				),
+			),
+			expected: CommitSignature{
+				SHA:           "abc123def456",
+				Reason:        "unknown_key",
+				SignatureType: "ssh",
+				Signer:        &CommitSigner{Login: "octocat", Name: "The Octocat", Email: "octocat@github.com"},
+				Signature:     "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n",
+			},
+		},
+		{
+			name: "malformed GPG signature",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					commitWith(&github.SignatureVerification{
+						Verified:  github.Ptr(false),
+						Reason:    github.Ptr("malformed_signature"),
+						Signature: github.Ptr("-----BEGIN PGP SIGNATURE-----\n\nbm90IGEgc2lnbmF0dXJl\n-----END PGP SIGNATURE-----\n"),
+					}),
+				),
+			),
+			expected: CommitSignature{
+				SHA:           "abc123def456",
+				Reason:        "malformed_signature",
+				SignatureType: "gpg",
+				Signer:        &CommitSigner{Login: "octocat", Name: "The Octocat", Email: "octocat@github.com"},
+				Signature:     "-----BEGIN PGP SIGNATURE-----\n\nbm90IGEgc2lnbmF0dXJl\n-----END PGP SIGNATURE-----\n",
+			},
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: nope"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get commit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := VerifyCommitSignature(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "abc123def456",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var signature CommitSignature
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &signature))
+			assert.Equal(t, tc.expected, signature)
+		})
+	}
+}
+
+func Test_GetCommitVerification(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitVerification(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_verification", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepoByRef,
+			expectPath(t, "/repos/owner/repo/commits/abc123def456").andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryCommit{
+					SHA:       github.Ptr("abc123def456"),
+					Committer: &github.User{Login: github.Ptr("octocat")},
+					Commit: &github.Commit{
+						Committer: &github.CommitAuthor{Name: github.Ptr("The Octocat"), Email: github.Ptr("octocat@github.com")},
+						Verification: &github.SignatureVerification{
+							Verified:  github.Ptr(true),
+							Reason:    github.Ptr("valid"),
+							Signature: github.Ptr(testGPGSignature),
+							Payload:   github.Ptr("tree abc\n\nInitial commit\n"),
+						},
+					},
+				}),
+			),
+		),
+	)
+	_, handler := GetCommitVerification(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+		"sha":   "abc123def456",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var verification CommitSignature
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &verification))
+	assert.Equal(t, CommitSignature{
+		SHA:            "abc123def456",
+		Verified:       true,
+		Reason:         "valid",
+		SignatureType:  "gpg",
+		KeyID:          "9AC3516F9FF66AC1",
+		KeyFingerprint: "E1E15E686AF0A836B9E8B4029AC3516F9FF66AC1",
+		Signer:         &CommitSigner{Login: "octocat", Name: "The Octocat", Email: "octocat@github.com"},
+	}, verification)
+}
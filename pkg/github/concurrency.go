@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"sync"
+)
+
+// maxConcurrentRequests bounds the number of GitHub API requests a single tool call makes at once,
+// to stay clear of the secondary rate limits on concurrent requests.
+const maxConcurrentRequests = 4
+
+// runBounded runs tasks concurrently with at most limit of them running at once. It waits for all
+// tasks to finish and returns their errors in the order of tasks. Tasks that have not started when
+// ctx is cancelled are skipped and report the context's error.
+func runBounded(ctx context.Context, limit int, tasks ...func(context.Context) error) []error {
+	if limit < 1 {
+		limit = 1
+	}
+
+	errs := make([]error, len(tasks))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, task func(context.Context) error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = task(ctx)
+		}(i, task)
+	}
+
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunBounded(t *testing.T) {
+	t.Run("limits concurrency and keeps errors in order", func(t *testing.T) {
+		var running, maxRunning int32
+		task := func(err error) func(context.Context) error {
+			return func(context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return err
+			}
+		}
+
+		errFailed := errors.New("failed")
+		errs := runBounded(context.Background(), 2, task(nil), task(errFailed), task(nil), task(nil), task(errFailed))
+
+		assert.Equal(t, []error{nil, errFailed, nil, nil, errFailed}, errs)
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+	})
+
+	t.Run("skips tasks after the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var ran int32
+		task := func(context.Context) error {
+			atomic.AddInt32(&ran, 1)
+			return nil
+		}
+
+		errs := runBounded(ctx, 1, task, task)
+
+		assert.Equal(t, []error{context.Canceled, context.Canceled}, errs)
+		assert.Zero(t, atomic.LoadInt32(&ran))
+	})
+}
@@ -0,0 +1,428 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryContents is the output type of the get_contents tool, either a file or a directory listing.
+type RepositoryContents struct {
+	// Type is file, dir, symlink or submodule.
+	Type string `json:"type"`
+	Path string `json:"path"`
+	SHA  string `json:"sha,omitempty"`
+	Size int    `json:"size,omitempty"`
+	// Encoding is utf-8 for text content and base64 for binary content.
+	Encoding string `json:"encoding,omitempty"`
+	Content  string `json:"content,omitempty"`
+	// Truncated is set if the file is larger than the content returned.
+	Truncated bool `json:"truncated,omitempty"`
+	// Target is the path a symlink points to.
+	Target string `json:"target,omitempty"`
+	// SubmoduleURL is the URL of the repository of a submodule.
+	SubmoduleURL string                    `json:"submodule_url,omitempty"`
+	Entries      []RepositoryContentsEntry `json:"entries,omitempty"`
+}
+
+// RepositoryContentsEntry is an entry of a directory listing.
+type RepositoryContentsEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size"`
+	SHA  string `json:"sha"`
+}
+
+// encodeFileContent returns content as text if it is UTF-8, or base64 otherwise, cut to at most
+// raw.MaxRawContentBytes bytes.
+func encodeFileContent(content []byte) (encoding, encoded string, truncated bool) {
+	if len(content) > raw.MaxRawContentBytes {
+		content, truncated = content[:raw.MaxRawContentBytes], true
+	}
+	text := content
+	if truncated {
+		// Do not mistake a character cut in half for binary content
+		for i := 0; i < utf8.UTFMax && len(text) > 0 && !utf8.Valid(text); i++ {
+			text = text[:len(text)-1]
+		}
+	}
+	if utf8.Valid(text) {
+		return "utf-8", string(text), truncated
+	}
+	return "base64", base64.StdEncoding.EncodeToString(content), truncated
+}
+
+// GetContents creates a tool to get a file or a directory listing of a repository.
+func GetContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_contents",
+			mcp.WithDescription(t("TOOL_GET_CONTENTS_DESCRIPTION", fmt.Sprintf("Get a path of a GitHub repository. For a directory, returns its entries with their names, types, sizes and SHAs; for a file, returns its content as text, or as base64 if it is binary, up to %d bytes.", raw.MaxRawContentBytes))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTENTS_USER_TITLE", "Get repository contents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path of the file or directory. Defaults to the root directory."),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to get the contents at. Defaults to the default branch."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			file, dir, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get contents", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if file == nil {
+				result := RepositoryContents{Type: "dir", Path: path, Entries: make([]RepositoryContentsEntry, 0, len(dir))}
+				for _, entry := range dir {
+					result.Entries = append(result.Entries, RepositoryContentsEntry{
+						Name: entry.GetName(),
+						Path: entry.GetPath(),
+						Type: entry.GetType(),
+						Size: entry.GetSize(),
+						SHA:  entry.GetSHA(),
+					})
+				}
+				return MarshalledTextResult(result), nil
+			}
+
+			result := RepositoryContents{
+				Type:         file.GetType(),
+				Path:         file.GetPath(),
+				SHA:          file.GetSHA(),
+				Size:         file.GetSize(),
+				Target:       file.GetTarget(),
+				SubmoduleURL: file.GetSubmoduleGitURL(),
+			}
+			if result.Type != "file" {
+				return MarshalledTextResult(result), nil
+			}
+
+			var content []byte
+			if file.GetEncoding() == "base64" {
+				decoded, err := file.GetContent()
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode file content: %w", err)
+				}
+				content = []byte(decoded)
+			} else {
+				// The contents API leaves out the content of files over 1 MB
+				rawClient, err := getRawClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
+				}
+				rawResp, err := rawClient.GetRawContent(ctx, owner, repo, path, &raw.ContentOpts{Ref: ref})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get raw content: %w", err)
+				}
+				defer func() { _ = rawResp.Body.Close() }()
+				if rawResp.StatusCode != http.StatusOK {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get raw content: unexpected status %d", rawResp.StatusCode)), nil
+				}
+				content, err = io.ReadAll(io.LimitReader(rawResp.Body, raw.MaxRawContentBytes+1))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read raw content: %w", err)
+				}
+			}
+
+			result.Encoding, result.Content, result.Truncated = encodeFileContent(content)
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// RawContentURL is the output type of the get_raw_content_url tool.
+type RawContentURL struct {
+	URL  string `json:"url"`
+	Path string `json:"path"`
+	Ref  string `json:"ref"`
+	SHA  string `json:"sha"`
+	Size int    `json:"size"`
+	Note string `json:"note,omitempty"`
+}
+
+// GetRawContentURL creates a tool to get the raw URL of a file, checking that it exists without
+// returning its content.
+func GetRawContentURL(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_raw_content_url",
+			mcp.WithDescription(t("TOOL_GET_RAW_CONTENT_URL_DESCRIPTION", "Get the raw URL of a file of a repository, to link to or download it directly rather than reading its content. The file is checked to exist at the ref first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RAW_CONTENT_URL_USER_TITLE", "Get raw content URL"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA of the file. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path = strings.TrimPrefix(path, "/")
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					at := "the default branch"
+					if ref != "" {
+						at = ref
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("%s does not exist at %s of %s/%s, or the ref does not exist", path, at, owner, repo)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get contents", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, which has no raw URL", path)), nil
+			}
+			if file.GetType() == "submodule" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a submodule, which has no raw URL; its repository is %s", path, file.GetSubmoduleGitURL())), nil
+			}
+
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
+			}
+			result := RawContentURL{
+				URL:  rawClient.URLFromOpts(&raw.ContentOpts{Ref: ref}, owner, repo, path),
+				Path: file.GetPath(),
+				Ref:  ref,
+				SHA:  file.GetSHA(),
+				Size: file.GetSize(),
+			}
+			if result.Ref == "" {
+				result.Ref = "HEAD"
+			}
+			// The download URLs of the files of private repositories carry a token of their own
+			if strings.Contains(file.GetDownloadURL(), "token=") {
+				result.Note = "The repository is private, so the URL only works with a token that can read it."
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// PutFileResult is the output type of the put_file tool.
+type PutFileResult struct {
+	Path string `json:"path"`
+	// SHA is the blob SHA of the new content of the file.
+	SHA       string `json:"sha"`
+	CommitSHA string `json:"commit_sha"`
+	// Created is set if the file did not exist before.
+	Created bool   `json:"created"`
+	URL     string `json:"url"`
+}
+
+// PutFile creates a tool to create or update a single file of a repository, looking up the SHA of
+// the file it replaces.
+func PutFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("put_file",
+			mcp.WithDescription(t("TOOL_PUT_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository with one commit. The content is given as text or as base64 for binary files. The SHA of the file being replaced is looked up unless given; if it is given and the file has changed since, the update is rejected.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PUT_FILE_USER_TITLE", "Put file"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to commit to"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("New content of the file"),
+			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding of content: utf-8 for text, or base64 for binary content"),
+				mcp.Enum("utf-8", "base64"),
+				mcp.DefaultString("utf-8"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Blob SHA of the file being replaced, to reject the update if the file has changed since. Looked up on the branch if omitted."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// An empty file is valid content
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			data := []byte(content)
+			switch encoding {
+			case "", "utf-8":
+			case "base64":
+				data, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %v", err)), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid encoding %q, must be utf-8 or base64", encoding)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if sha == "" {
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+				switch {
+				case err == nil:
+					defer func() { _ = resp.Body.Close() }()
+					if file == nil || file.GetType() != "file" {
+						return mcp.NewToolResultError(fmt.Sprintf("%s is not a file", path)), nil
+					}
+					sha = file.GetSHA()
+				case resp != nil && resp.StatusCode == http.StatusNotFound:
+					// The file does not exist yet
+				default:
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get the current file", resp, err), nil
+				}
+			}
+
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: data,
+				Branch:  github.Ptr(branch),
+			}
+			if sha != "" {
+				opts.SHA = github.Ptr(sha)
+			}
+			updated, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to put file: %s has changed since blob %s, get its current SHA and try again", path, sha),
+						resp,
+						err,
+					), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to put file", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(PutFileResult{
+				Path:      updated.GetContent().GetPath(),
+				SHA:       updated.GetContent().GetSHA(),
+				CommitSHA: updated.Commit.GetSHA(),
+				Created:   resp.StatusCode == http.StatusCreated,
+				URL:       updated.GetContent().GetHTMLURL(),
+			}), nil
+		}
+}
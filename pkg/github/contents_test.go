@@ -0,0 +1,480 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetContents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GetContents(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_contents", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	large := bytes.Repeat([]byte("a"), raw.MaxRawContentBytes+10)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       RepositoryContents
+	}{
+		{
+			name: "directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "v1.0.0"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+							{Name: github.Ptr("main.go"), Path: github.Ptr("cmd/main.go"), Type: github.Ptr("file"), Size: github.Ptr(120), SHA: github.Ptr("abc")},
+							{Name: github.Ptr("internal"), Path: github.Ptr("cmd/internal"), Type: github.Ptr("dir"), Size: github.Ptr(0), SHA: github.Ptr("def")},
+						}),
+					),
+				),
+			),
+			args: map[string]any{"owner": "owner", "repo": "repo", "path": "cmd", "ref": "v1.0.0"},
+			expected: RepositoryContents{
+				Type: "dir",
+				Path: "cmd",
+				Entries: []RepositoryContentsEntry{
+					{Name: "main.go", Path: "cmd/main.go", Type: "file", Size: 120, SHA: "abc"},
+					{Name: "internal", Path: "cmd/internal", Type: "dir", SHA: "def"},
+				},
+			},
+		},
+		{
+			name: "text file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Path:     github.Ptr("README.md"),
+						SHA:      github.Ptr("abc"),
+						Size:     github.Ptr(8),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("# Hello\n"))),
+					},
+				),
+			),
+			args: map[string]any{"owner": "owner", "repo": "repo", "path": "README.md"},
+			expected: RepositoryContents{
+				Type:     "file",
+				Path:     "README.md",
+				SHA:      "abc",
+				Size:     8,
+				Encoding: "utf-8",
+				Content:  "# Hello\n",
+			},
+		},
+		{
+			name: "binary file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Path:     github.Ptr("logo.png"),
+						SHA:      github.Ptr("def"),
+						Size:     github.Ptr(len(binary)),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString(binary)),
+					},
+				),
+			),
+			args: map[string]any{"owner": "owner", "repo": "repo", "path": "logo.png"},
+			expected: RepositoryContents{
+				Type:     "file",
+				Path:     "logo.png",
+				SHA:      "def",
+				Size:     len(binary),
+				Encoding: "base64",
+				Content:  base64.StdEncoding.EncodeToString(binary),
+			},
+		},
+		{
+			name: "file too large for the contents API",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Path:     github.Ptr("data.txt"),
+						SHA:      github.Ptr("fed"),
+						Size:     github.Ptr(len(large)),
+						Encoding: github.Ptr("none"),
+						Content:  github.Ptr(""),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					expectPath(t, "/owner/repo/main/data.txt").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							_, _ = w.Write(large)
+						},
+					),
+				),
+			),
+			args: map[string]any{"owner": "owner", "repo": "repo", "path": "data.txt", "ref": "main"},
+			expected: RepositoryContents{
+				Type:      "file",
+				Path:      "data.txt",
+				SHA:       "fed",
+				Size:      len(large),
+				Encoding:  "utf-8",
+				Content:   strings.Repeat("a", raw.MaxRawContentBytes),
+				Truncated: true,
+			},
+		},
+		{
+			name: "path not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to get contents",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetContents(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var contents RepositoryContents
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &contents))
+			assert.Equal(t, tc.expected, contents)
+		})
+	}
+}
+
+func Test_GetRawContentURL(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GetRawContentURL(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_raw_content_url", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	readme := &github.RepositoryContent{
+		Type:        github.Ptr("file"),
+		Path:        github.Ptr("docs/README.md"),
+		SHA:         github.Ptr("abc123"),
+		Size:        github.Ptr(42),
+		DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/README.md"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       RawContentURL
+	}{
+		{
+			name: "file at a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/docs/README.md").andThen(
+						expectQueryParams(t, map[string]string{"ref": "v1.0.0"}).andThen(
+							mockResponse(t, http.StatusOK, readme),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "path": "/docs/README.md", "ref": "v1.0.0"},
+			expected: RawContentURL{
+				URL:  "https://raw.example.com/owner/repo/v1.0.0/docs/README.md",
+				Path: "docs/README.md",
+				Ref:  "v1.0.0",
+				SHA:  "abc123",
+				Size: 42,
+			},
+		},
+		{
+			name: "file of a private repository on the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:        github.Ptr("file"),
+						Path:        github.Ptr("docs/README.md"),
+						SHA:         github.Ptr("abc123"),
+						Size:        github.Ptr(42),
+						DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/README.md?token=ABC"),
+					},
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "path": "docs/README.md"},
+			expected: RawContentURL{
+				URL:  "https://raw.example.com/owner/repo/HEAD/docs/README.md",
+				Path: "docs/README.md",
+				Ref:  "HEAD",
+				SHA:  "abc123",
+				Size: 42,
+				Note: "The repository is private, so the URL only works with a token that can read it.",
+			},
+		},
+		{
+			name: "directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					[]*github.RepositoryContent{readme},
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "docs"},
+			expectError:    true,
+			expectedErrMsg: "docs is a directory, which has no raw URL",
+		},
+		{
+			name: "missing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "missing.md", "ref": "main"},
+			expectError:    true,
+			expectedErrMsg: "missing.md does not exist at main of owner/repo, or the ref does not exist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetRawContentURL(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned RawContentURL
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_EncodeFileContent(t *testing.T) {
+	// A character cut in half by the limit is dropped rather than making the content binary
+	content := append(bytes.Repeat([]byte("a"), raw.MaxRawContentBytes-1), []byte("é")...)
+	encoding, encoded, truncated := encodeFileContent(content)
+	assert.Equal(t, "utf-8", encoding)
+	assert.Equal(t, strings.Repeat("a", raw.MaxRawContentBytes-1), encoded)
+	assert.True(t, truncated)
+}
+
+func Test_PutFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PutFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "put_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "branch", "message", "content"})
+
+	putResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Path:    github.Ptr("docs/README.md"),
+			SHA:     github.Ptr("new-blob"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/docs/README.md"),
+		},
+		Commit: github.Commit{SHA: github.Ptr("new-commit")},
+	}
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       PutFileResult
+	}{
+		{
+			name: "creates a file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+						mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expect(t, expectations{
+						path: "/repos/owner/repo/contents/docs/README.md",
+						requestBody: map[string]any{
+							"message": "Add docs",
+							"content": base64.StdEncoding.EncodeToString([]byte("# Docs\n")),
+							"branch":  "main",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, putResponse),
+					),
+				),
+			),
+			args: map[string]any{"owner": "owner", "repo": "repo", "path": "docs/README.md", "branch": "main", "message": "Add docs", "content": "# Docs\n"},
+			expected: PutFileResult{
+				Path:      "docs/README.md",
+				SHA:       "new-blob",
+				CommitSHA: "new-commit",
+				Created:   true,
+				URL:       "https://github.com/owner/repo/blob/main/docs/README.md",
+			},
+		},
+		{
+			name: "updates a file with the looked up SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("docs/README.md"), SHA: github.Ptr("old-blob")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]any{
+						"message": "Update docs",
+						"content": base64.StdEncoding.EncodeToString(binary),
+						"branch":  "main",
+						"sha":     "old-blob",
+					}).andThen(
+						mockResponse(t, http.StatusOK, putResponse),
+					),
+				),
+			),
+			args: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"path":     "docs/README.md",
+				"branch":   "main",
+				"message":  "Update docs",
+				"content":  base64.StdEncoding.EncodeToString(binary),
+				"encoding": "base64",
+			},
+			expected: PutFileResult{
+				Path:      "docs/README.md",
+				SHA:       "new-blob",
+				CommitSHA: "new-commit",
+				URL:       "https://github.com/owner/repo/blob/main/docs/README.md",
+			},
+		},
+		{
+			name: "stale SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]any{
+						"message": "Update docs",
+						"content": base64.StdEncoding.EncodeToString([]byte("# Docs\n")),
+						"branch":  "main",
+						"sha":     "stale-blob",
+					}).andThen(
+						mockResponse(t, http.StatusConflict, `{"message": "docs/README.md does not match stale-blob"}`),
+					),
+				),
+			),
+			args: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/README.md",
+				"branch":  "main",
+				"message": "Update docs",
+				"content": "# Docs\n",
+				"sha":     "stale-blob",
+			},
+			expectError:    true,
+			expectedErrMsg: "docs/README.md has changed since blob stale-blob",
+		},
+		{
+			name: "path is a directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					[]*github.RepositoryContent{{Type: github.Ptr("file"), Path: github.Ptr("docs/README.md")}},
+				),
+			),
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "docs", "branch": "main", "message": "Add docs", "content": "# Docs\n"},
+			expectError:    true,
+			expectedErrMsg: "docs is not a file",
+		},
+		{
+			name:           "invalid base64",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "logo.png", "branch": "main", "message": "Add logo", "content": "not base64!", "encoding": "base64"},
+			expectError:    true,
+			expectedErrMsg: "content is not valid base64",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := PutFile(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var putResult PutFileResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &putResult))
+			assert.Equal(t, tc.expected, putResult)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/tokens"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -33,10 +34,21 @@ type UserDetails struct {
 	OwnedPrivateRepos int64     `json:"owned_private_repos,omitempty"`
 }
 
-// GetMe creates a tool to get details of the authenticated user.
-func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+// meResult is the output of get_me.
+type meResult struct {
+	MinimalUser
+	// TokenPermissions are the repository permissions probed for fine-grained personal access tokens.
+	TokenPermissions *tokens.Permissions `json:"token_permissions,omitempty"`
+	// RepositoryDefaults are the owner and repository tools use when they are called without them.
+	RepositoryDefaults *RepositoryDefaults `json:"repository_defaults,omitempty"`
+}
+
+// GetMe creates a tool to get details of the authenticated user. For fine-grained personal access
+// tokens, it also reports the repository permissions the token has, unless probing is skipped, and
+// it reports the repository defaults, if any.
+func GetMe(getClient GetClientFn, permissions TokenPermissionsConfig, repoDefaults *RepositoryDefaults, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	tool := mcp.NewTool("get_me",
-		mcp.WithDescription(t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls.")),
+		mcp.WithDescription(t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls. For fine-grained personal access tokens, it also reports the repository permissions the token has. Also reports the default owner and repository that tools use when called without them, if set.")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        t("TOOL_GET_ME_USER_TITLE", "Get my user profile"),
 			ReadOnlyHint: ToBoolPtr(true),
@@ -86,7 +98,21 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 			},
 		}
 
-		return MarshalledTextResult(minimalUser), nil
+		result := meResult{MinimalUser: minimalUser, RepositoryDefaults: repoDefaults}
+		if token := tokens.FromRequest(res.Request); !permissions.SkipProbe && tokens.IsFineGrained(token) {
+			probed, ok := tokens.DefaultCache.Get(token)
+			if !ok {
+				var enabledToolsets []string
+				if permissions.EnabledToolsets != nil {
+					enabledToolsets = permissions.EnabledToolsets()
+				}
+				probed = probeTokenPermissions(ctx, client, enabledToolsets)
+				tokens.DefaultCache.Set(token, probed)
+			}
+			result.TokenPermissions = probed
+		}
+
+		return MarshalledTextResult(result), nil
 	})
 
 	return tool, handler
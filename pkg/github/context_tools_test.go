@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/tokens"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -20,7 +22,7 @@ import (
 func Test_GetMe(t *testing.T) {
 	t.Parallel()
 
-	tool, _ := GetMe(nil, translations.NullTranslationHelper)
+	tool, _ := GetMe(nil, TokenPermissionsConfig{}, nil, translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	// Verify some basic very important properties
@@ -108,7 +110,7 @@ func Test_GetMe(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			_, handler := GetMe(tc.stubbedGetClientFn, translations.NullTranslationHelper)
+			_, handler := GetMe(tc.stubbedGetClientFn, TokenPermissionsConfig{}, nil, translations.NullTranslationHelper)
 
 			request := createMCPRequest(tc.requestArgs)
 			result, err := handler(context.Background(), request)
@@ -143,6 +145,98 @@ func Test_GetMe(t *testing.T) {
 	}
 }
 
+func Test_GetMe_FineGrainedTokenPermissions(t *testing.T) {
+	t.Parallel()
+
+	mockUser := &github.User{Login: github.Ptr("testuser")}
+	newClient := func(token string, probed *int) *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetUser, mockUser, mockUser),
+			mock.WithRequestMatchHandler(
+				mock.GetUserRepos,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					*probed++
+					assert.Equal(t, "updated", r.URL.Query().Get("sort"))
+					mockResponse(t, http.StatusOK, []*github.Repository{{
+						Name:     github.Ptr("repo"),
+						FullName: github.Ptr("octo/repo"),
+						Owner:    &github.User{Login: github.Ptr("octo")},
+					}})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesByOwnerByRepo,
+				expectPath(t, "/repos/octo/repo/issues").andThen(mockResponse(t, http.StatusOK, []*github.Issue{})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by personal access token"}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCodeScanningAlertsByOwnerByRepo,
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "no analysis found"}),
+			),
+		)).WithAuthToken(token)
+	}
+	enabledToolsets := func() []string { return []string{"issues", "actions", "code_security", "context"} }
+
+	t.Run("probes and caches the permissions of fine-grained tokens", func(t *testing.T) {
+		var probed int
+		client := newClient("github_pat_getme_probe", &probed)
+		_, handler := GetMe(stubGetClientFn(client), TokenPermissionsConfig{EnabledToolsets: enabledToolsets}, nil, translations.NullTranslationHelper)
+
+		for range 2 {
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned meResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "testuser", returned.Login)
+			require.NotNil(t, returned.TokenPermissions)
+			assert.Equal(t, "octo/repo", returned.TokenPermissions.Repository)
+			assert.Equal(t, []tokens.Permission{
+				{Name: "metadata", Access: tokens.AccessRead},
+				{Name: "issues", Access: tokens.AccessRead},
+				{Name: "actions", Access: tokens.AccessNone},
+				{Name: "security_events", Access: tokens.AccessUnknown, Status: http.StatusNotFound},
+			}, returned.TokenPermissions.Permissions)
+		}
+		assert.Equal(t, 1, probed, "permissions are probed once per token")
+
+		cached, ok := tokens.DefaultCache.Get("github_pat_getme_probe")
+		require.True(t, ok)
+		assert.Equal(t, "metadata=read, issues=read, actions=none, security_events=unknown", cached.Summary())
+	})
+
+	t.Run("probing can be skipped", func(t *testing.T) {
+		var probed int
+		client := newClient("github_pat_getme_skip", &probed)
+		_, handler := GetMe(stubGetClientFn(client), TokenPermissionsConfig{SkipProbe: true, EnabledToolsets: enabledToolsets}, nil, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned meResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.Nil(t, returned.TokenPermissions)
+		assert.Zero(t, probed)
+	})
+
+	t.Run("classic tokens are not probed", func(t *testing.T) {
+		var probed int
+		client := newClient("ghp_getmeclassictoken", &probed)
+		_, handler := GetMe(stubGetClientFn(client), TokenPermissionsConfig{EnabledToolsets: enabledToolsets}, nil, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.NotContains(t, getTextResult(t, result).Text, "token_permissions")
+		assert.Zero(t, probed)
+	})
+}
+
 func Test_GetTeams(t *testing.T) {
 	t.Parallel()
 
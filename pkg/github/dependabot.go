@@ -106,6 +106,7 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Description("Filter dependabot alerts by severity"),
 				mcp.Enum("low", "medium", "high", "critical"),
 			),
+			WithPageTokenPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -124,15 +125,24 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPageTokenParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			// Dependabot alerts are paginated with after cursors rather than page numbers
 			alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{
 				State:    ToStringPtr(state),
 				Severity: ToStringPtr(severity),
+				ListCursorOptions: github.ListCursorOptions{
+					PerPage: pagination.PerPage,
+					After:   pagination.After,
+				},
 			})
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -156,6 +166,6 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
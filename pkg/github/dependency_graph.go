@@ -0,0 +1,286 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// commitSHA matches a full commit SHA, which snapshots must be submitted for.
+var commitSHA = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// DependencySnapshotSubmission is the output type of the submit_dependency_snapshot tool.
+type DependencySnapshotSubmission struct {
+	ID        int64      `json:"id"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Result is SUCCESS when the dependencies of the repository were updated, or ACCEPTED when the
+	// snapshot was stored but the dependencies were not updated, such as for a ref other than the default branch.
+	Result  string `json:"result,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// decodeObjectParam decodes the object parameter name into v, rejecting unknown fields so that
+// misspelt ones are not silently dropped from the snapshot.
+func decodeObjectParam(request mcp.CallToolRequest, name string, v any) error {
+	value, ok := request.GetArguments()[name]
+	if !ok || value == nil {
+		return fmt.Errorf("missing required parameter: %s", name)
+	}
+	if _, ok := value.(map[string]any); !ok {
+		return fmt.Errorf("parameter %s is not of type object, is %T", name, value)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("invalid parameter %s: %w", name, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid parameter %s: %w", name, err)
+	}
+	return nil
+}
+
+// validateDependencySnapshot checks the fields GitHub requires of a snapshot, so that a malformed
+// snapshot is reported with the field at fault rather than stored as INVALID.
+func validateDependencySnapshot(snapshot *github.DependencyGraphSnapshot) error {
+	if !commitSHA.MatchString(snapshot.GetSha()) {
+		return fmt.Errorf("sha must be a full 40 character commit SHA, got %q", snapshot.GetSha())
+	}
+	if !strings.HasPrefix(snapshot.GetRef(), "refs/") {
+		return fmt.Errorf("ref must be a fully qualified ref such as refs/heads/main, got %q", snapshot.GetRef())
+	}
+	job := snapshot.GetJob()
+	if job.GetCorrelator() == "" || job.GetID() == "" {
+		return fmt.Errorf("job.correlator and job.id are required")
+	}
+	detector := snapshot.GetDetector()
+	if detector.GetName() == "" || detector.GetVersion() == "" || detector.GetURL() == "" {
+		return fmt.Errorf("detector.name, detector.version and detector.url are required")
+	}
+	if len(snapshot.Manifests) == 0 {
+		return fmt.Errorf("manifests must contain at least one manifest")
+	}
+
+	// Validate in a stable order so that the same snapshot always reports the same error
+	names := make([]string, 0, len(snapshot.Manifests))
+	for name := range snapshot.Manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		manifest := snapshot.Manifests[name]
+		if manifest == nil {
+			return fmt.Errorf("manifests[%q] must be an object", name)
+		}
+		packages := make([]string, 0, len(manifest.Resolved))
+		for pkg := range manifest.Resolved {
+			packages = append(packages, pkg)
+		}
+		sort.Strings(packages)
+		for _, pkg := range packages {
+			dependency := manifest.Resolved[pkg]
+			field := fmt.Sprintf("manifests[%q].resolved[%q]", name, pkg)
+			switch {
+			case dependency == nil:
+				return fmt.Errorf("%s must be an object", field)
+			case !strings.HasPrefix(dependency.GetPackageURL(), "pkg:"):
+				return fmt.Errorf("%s.package_url must be a package URL such as pkg:npm/lodash@4.17.21, got %q", field, dependency.GetPackageURL())
+			case dependency.Relationship != nil && *dependency.Relationship != "direct" && *dependency.Relationship != "indirect":
+				return fmt.Errorf("%s.relationship must be direct or indirect, got %q", field, *dependency.Relationship)
+			case dependency.Scope != nil && *dependency.Scope != "runtime" && *dependency.Scope != "development":
+				return fmt.Errorf("%s.scope must be runtime or development, got %q", field, *dependency.Scope)
+			}
+		}
+	}
+	return nil
+}
+
+// SubmitDependencySnapshot creates a tool to submit the dependencies of a repository found at build
+// time to its dependency graph.
+func SubmitDependencySnapshot(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("submit_dependency_snapshot",
+			mcp.WithDescription(t("TOOL_SUBMIT_DEPENDENCY_SNAPSHOT_DESCRIPTION", "Submit a snapshot of the dependencies of a repository at a commit to its dependency graph, such as those resolved by a build, so that Dependabot alerts cover them. The snapshot is validated before it is submitted.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBMIT_DEPENDENCY_SNAPSHOT_USER_TITLE", "Submit dependency snapshot"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Full SHA of the commit the dependencies were found at"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Fully qualified ref of the commit, such as refs/heads/main"),
+			),
+			mcp.WithObject("job",
+				mcp.Required(),
+				mcp.Description("The job that found the dependencies. Snapshots with the same correlator replace each other"),
+				mcp.Properties(map[string]any{
+					"correlator": map[string]any{
+						"type":        "string",
+						"description": "Identifies the job across runs, such as the workflow and job name",
+					},
+					"id": map[string]any{
+						"type":        "string",
+						"description": "ID of the run of the job",
+					},
+					"html_url": map[string]any{
+						"type":        "string",
+						"description": "URL of the run of the job",
+					},
+				}),
+			),
+			mcp.WithObject("detector",
+				mcp.Required(),
+				mcp.Description("The tool that found the dependencies"),
+				mcp.Properties(map[string]any{
+					"name":    map[string]any{"type": "string", "description": "Name of the detector"},
+					"version": map[string]any{"type": "string", "description": "Version of the detector"},
+					"url":     map[string]any{"type": "string", "description": "URL of the detector"},
+				}),
+			),
+			mcp.WithObject("manifests",
+				mcp.Required(),
+				mcp.Description("The manifests the dependencies were found in, keyed by a unique name such as the path of the file"),
+				mcp.AdditionalProperties(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"name": map[string]any{
+							"type":        "string",
+							"description": "Name of the manifest. Defaults to its key",
+						},
+						"file": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"source_location": map[string]any{
+									"type":        "string",
+									"description": "Path of the manifest file in the repository",
+								},
+							},
+						},
+						"resolved": map[string]any{
+							"type":        "object",
+							"description": "The resolved dependencies, keyed by package name",
+							"additionalProperties": map[string]any{
+								"type": "object",
+								"properties": map[string]any{
+									"package_url": map[string]any{
+										"type":        "string",
+										"description": "Package URL of the dependency, such as pkg:npm/lodash@4.17.21",
+									},
+									"relationship": map[string]any{
+										"type": "string",
+										"enum": []string{"direct", "indirect"},
+									},
+									"scope": map[string]any{
+										"type": "string",
+										"enum": []string{"runtime", "development"},
+									},
+									"dependencies": map[string]any{
+										"type":        "array",
+										"description": "Package URLs of the dependencies of the dependency",
+										"items":       map[string]any{"type": "string"},
+									},
+								},
+								"required": []string{"package_url"},
+							},
+						},
+					},
+				}),
+			),
+			mcp.WithString("scanned",
+				mcp.Description("When the dependencies were found, as an ISO 8601 timestamp. Defaults to now"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scannedParam, err := OptionalParam[string](request, "scanned")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scanned := time.Now()
+			if scannedParam != "" {
+				if scanned, err = parseISOTimestamp(scannedParam); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			snapshot := &github.DependencyGraphSnapshot{
+				Sha:     github.Ptr(sha),
+				Ref:     github.Ptr(ref),
+				Scanned: &github.Timestamp{Time: scanned},
+			}
+			if err := decodeObjectParam(request, "job", &snapshot.Job); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := decodeObjectParam(request, "detector", &snapshot.Detector); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := decodeObjectParam(request, "manifests", &snapshot.Manifests); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateDependencySnapshot(snapshot); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for name, manifest := range snapshot.Manifests {
+				if manifest.GetName() == "" {
+					manifest.Name = github.Ptr(name)
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.DependencyGraph.CreateSnapshot(ctx, owner, repo, snapshot)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to submit dependency snapshot", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			submission := DependencySnapshotSubmission{
+				ID:      created.ID,
+				Result:  created.GetResult(),
+				Message: created.GetMessage(),
+			}
+			if created.CreatedAt != nil {
+				submission.CreatedAt = &created.CreatedAt.Time
+			}
+			return MarshalledTextResult(submission), nil
+		}
+}
@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SubmitDependencySnapshot(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SubmitDependencySnapshot(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "submit_dependency_snapshot", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "ref", "job", "detector", "manifests"})
+
+	sha := "ddc951f4b1293222421f2c8df679786153acf689"
+	// arguments returns valid arguments, with those of overrides replacing them
+	arguments := func(overrides map[string]any) map[string]any {
+		args := map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"sha":   sha,
+			"ref":   "refs/heads/main",
+			"job": map[string]any{
+				"correlator": "build_test",
+				"id":         "42",
+			},
+			"detector": map[string]any{
+				"name":    "gradle-detector",
+				"version": "1.0.0",
+				"url":     "https://github.com/example/gradle-detector",
+			},
+			"manifests": map[string]any{
+				"build.gradle": map[string]any{
+					"file": map[string]any{"source_location": "build.gradle"},
+					"resolved": map[string]any{
+						"guava": map[string]any{
+							"package_url":  "pkg:maven/com.google.guava/guava@33.0.0-jre",
+							"relationship": "direct",
+							"scope":        "runtime",
+						},
+					},
+				},
+			},
+			"scanned": "2024-03-01T12:00:00Z",
+		}
+		for name, value := range overrides {
+			args[name] = value
+		}
+		return args
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       DependencySnapshotSubmission
+	}{
+		{
+			name: "submits the snapshot",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDependencyGraphSnapshotsByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/dependency-graph/snapshots").andThen(
+						expectRequestBody(t, map[string]any{
+							"version": float64(0),
+							"sha":     sha,
+							"ref":     "refs/heads/main",
+							"scanned": "2024-03-01T12:00:00Z",
+							"job": map[string]any{
+								"correlator": "build_test",
+								"id":         "42",
+							},
+							"detector": map[string]any{
+								"name":    "gradle-detector",
+								"version": "1.0.0",
+								"url":     "https://github.com/example/gradle-detector",
+							},
+							"manifests": map[string]any{
+								"build.gradle": map[string]any{
+									"name": "build.gradle",
+									"file": map[string]any{"source_location": "build.gradle"},
+									"resolved": map[string]any{
+										"guava": map[string]any{
+											"package_url":  "pkg:maven/com.google.guava/guava@33.0.0-jre",
+											"relationship": "direct",
+											"scope":        "runtime",
+										},
+									},
+								},
+							},
+						}).andThen(
+							mockResponse(t, http.StatusCreated, map[string]any{
+								"id":         12,
+								"created_at": "2024-03-01T12:00:05Z",
+								"result":     "SUCCESS",
+								"message":    "Dependency results for the repo have been successfully updated.",
+							}),
+						),
+					),
+				),
+			),
+			args: arguments(nil),
+			expected: DependencySnapshotSubmission{
+				ID:        12,
+				CreatedAt: github.Ptr(time.Date(2024, 3, 1, 12, 0, 5, 0, time.UTC)),
+				Result:    "SUCCESS",
+				Message:   "Dependency results for the repo have been successfully updated.",
+			},
+		},
+		{
+			name:           "short sha",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           arguments(map[string]any{"sha": "ddc951f"}),
+			expectError:    true,
+			expectedErrMsg: `sha must be a full 40 character commit SHA, got "ddc951f"`,
+		},
+		{
+			name:           "unqualified ref",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           arguments(map[string]any{"ref": "main"}),
+			expectError:    true,
+			expectedErrMsg: `ref must be a fully qualified ref such as refs/heads/main, got "main"`,
+		},
+		{
+			name:           "job without id",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           arguments(map[string]any{"job": map[string]any{"correlator": "build_test"}}),
+			expectError:    true,
+			expectedErrMsg: "job.correlator and job.id are required",
+		},
+		{
+			name:         "misspelt detector field",
+			mockedClient: mock.NewMockedHTTPClient(),
+			args: arguments(map[string]any{"detector": map[string]any{
+				"name": "gradle-detector", "version": "1.0.0", "uri": "https://github.com/example/gradle-detector",
+			}}),
+			expectError:    true,
+			expectedErrMsg: `invalid parameter detector: json: unknown field "uri"`,
+		},
+		{
+			name:           "no manifests",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           arguments(map[string]any{"manifests": map[string]any{}}),
+			expectError:    true,
+			expectedErrMsg: "manifests must contain at least one manifest",
+		},
+		{
+			name:         "invalid relationship",
+			mockedClient: mock.NewMockedHTTPClient(),
+			args: arguments(map[string]any{"manifests": map[string]any{
+				"package-lock.json": map[string]any{
+					"resolved": map[string]any{
+						"lodash": map[string]any{"package_url": "pkg:npm/lodash@4.17.21", "relationship": "transitive"},
+					},
+				},
+			}}),
+			expectError:    true,
+			expectedErrMsg: `manifests["package-lock.json"].resolved["lodash"].relationship must be direct or indirect, got "transitive"`,
+		},
+		{
+			name:         "dependency without package URL",
+			mockedClient: mock.NewMockedHTTPClient(),
+			args: arguments(map[string]any{"manifests": map[string]any{
+				"package-lock.json": map[string]any{
+					"resolved": map[string]any{
+						"lodash": map[string]any{"package_url": "lodash@4.17.21"},
+					},
+				},
+			}}),
+			expectError:    true,
+			expectedErrMsg: `manifests["package-lock.json"].resolved["lodash"].package_url must be a package URL such as pkg:npm/lodash@4.17.21, got "lodash@4.17.21"`,
+		},
+		{
+			name: "dependency graph disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDependencyGraphSnapshotsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			args:           arguments(nil),
+			expectError:    true,
+			expectedErrMsg: "failed to submit dependency snapshot",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SubmitDependencySnapshot(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned DependencySnapshotSubmission
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
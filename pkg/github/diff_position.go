@@ -0,0 +1,422 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	diffSideLeft  = "LEFT"
+	diffSideRight = "RIGHT"
+)
+
+// diffLine is a line that appears in a file's diff, and can therefore be commented on.
+type diffLine struct {
+	// position is the line's offset from the first hunk header of the file, as used by the legacy review comment API.
+	position int
+	// hunk is the index of the hunk the line belongs to. Multi-line comments cannot span hunks.
+	hunk int
+}
+
+// diffFile is a file in a pull request diff, with the lines of each side that appear in it.
+type diffFile struct {
+	path         string
+	previousPath string
+	binary       bool
+	hunks        int
+	left         map[int]diffLine
+	right        map[int]diffLine
+}
+
+func (f *diffFile) lines(side string) map[int]diffLine {
+	if side == diffSideLeft {
+		return f.left
+	}
+	return f.right
+}
+
+// nearestLine returns the commentable line on side closest to line, preferring the earlier line on ties.
+func (f *diffFile) nearestLine(side string, line int) (int, bool) {
+	candidates := make([]int, 0, len(f.lines(side)))
+	for l := range f.lines(side) {
+		candidates = append(candidates, l)
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	sort.Ints(candidates)
+
+	nearest := candidates[0]
+	for _, l := range candidates[1:] {
+		if abs(l-line) < abs(nearest-line) {
+			nearest = l
+		}
+	}
+	return nearest, true
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// pullRequestDiff maps the files of a pull request diff by their path after the change.
+type pullRequestDiff map[string]*diffFile
+
+// parseUnifiedDiff parses a unified diff, as returned for pull requests by the GitHub API.
+func parseUnifiedDiff(diff string) pullRequestDiff {
+	files := pullRequestDiff{}
+
+	var (
+		file                   *diffFile
+		position               int
+		oldLine, newLine       int
+		oldRemain, newRemain   int
+		inHunk                 bool
+		oldPathSet, newPathSet bool
+	)
+
+	finish := func() {
+		if file == nil {
+			return
+		}
+		if file.path == "" {
+			file.path = file.previousPath
+		}
+		files[file.path] = file
+	}
+
+	for _, text := range strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n") {
+		// Hunk body lines are consumed first, since they can start with "---" or "+++" themselves.
+		if inHunk && (oldRemain > 0 || newRemain > 0) {
+			position++
+			switch {
+			case strings.HasPrefix(text, "+"):
+				file.right[newLine] = diffLine{position: position, hunk: file.hunks}
+				newLine++
+				newRemain--
+			case strings.HasPrefix(text, "-"):
+				file.left[oldLine] = diffLine{position: position, hunk: file.hunks}
+				oldLine++
+				oldRemain--
+			case strings.HasPrefix(text, `\`):
+				// "\ No newline at end of file" is part of the patch but not a line of either side.
+			default:
+				// Context lines, which some tools strip down to an empty line.
+				file.left[oldLine] = diffLine{position: position, hunk: file.hunks}
+				file.right[newLine] = diffLine{position: position, hunk: file.hunks}
+				oldLine++
+				newLine++
+				oldRemain--
+				newRemain--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "diff --git "):
+			finish()
+			file = &diffFile{left: map[int]diffLine{}, right: map[int]diffLine{}}
+			position, inHunk, oldPathSet, newPathSet = 0, false, false, false
+			if a, b, ok := splitDiffGitPaths(strings.TrimPrefix(text, "diff --git ")); ok {
+				file.previousPath, file.path = a, b
+			}
+		case file == nil:
+			continue
+		case strings.HasPrefix(text, `\`) && inHunk:
+			// "\ No newline at end of file" after the last line of a hunk.
+			position++
+		case strings.HasPrefix(text, "@@ "):
+			var ok bool
+			oldLine, oldRemain, newLine, newRemain, ok = parseHunkHeader(text)
+			if !ok {
+				inHunk = false
+				continue
+			}
+			if inHunk {
+				// Hunk headers after the first count towards the position.
+				position++
+			}
+			inHunk = true
+			file.hunks++
+		case inHunk:
+			// Trailing lines after a complete hunk, such as a final empty line.
+			continue
+		case strings.HasPrefix(text, "--- ") && !oldPathSet:
+			oldPathSet = true
+			if p := strings.TrimPrefix(text, "--- "); p != "/dev/null" {
+				file.previousPath = strings.TrimPrefix(p, "a/")
+			}
+		case strings.HasPrefix(text, "+++ ") && !newPathSet:
+			newPathSet = true
+			if p := strings.TrimPrefix(text, "+++ "); p != "/dev/null" {
+				file.path = strings.TrimPrefix(p, "b/")
+			} else {
+				file.path = ""
+			}
+		case strings.HasPrefix(text, "rename from "):
+			file.previousPath = strings.TrimPrefix(text, "rename from ")
+		case strings.HasPrefix(text, "rename to "):
+			file.path = strings.TrimPrefix(text, "rename to ")
+		case strings.HasPrefix(text, "new file mode"):
+			file.previousPath = ""
+		case strings.HasPrefix(text, "Binary files ") || strings.HasPrefix(text, "GIT binary patch"):
+			file.binary = true
+		}
+	}
+	finish()
+
+	return files
+}
+
+// splitDiffGitPaths splits the "a/<old> b/<new>" part of a "diff --git" line. The paths are only
+// ambiguous when they contain " b/", in which case the old and new paths are assumed to be equal.
+func splitDiffGitPaths(paths string) (string, string, bool) {
+	if !strings.HasPrefix(paths, "a/") {
+		return "", "", false
+	}
+	if len(paths)%2 == 1 {
+		half := (len(paths) - 1) / 2
+		if a, b := paths[:half], paths[half+1:]; strings.TrimPrefix(a, "a/") == strings.TrimPrefix(b, "b/") {
+			return a[2:], b[2:], true
+		}
+	}
+	i := strings.Index(paths, " b/")
+	if i < 0 {
+		return "", "", false
+	}
+	return paths[2:i], paths[i+3:], true
+}
+
+// parseHunkHeader parses the start and length of both sides from a hunk header such as "@@ -1,4 +1,6 @@".
+func parseHunkHeader(header string) (oldStart, oldLines, newStart, newLines int, ok bool) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, 0, false
+	}
+	if oldStart, oldLines, ok = parseHunkRange(fields[1][1:]); !ok {
+		return 0, 0, 0, 0, false
+	}
+	if newStart, newLines, ok = parseHunkRange(fields[2][1:]); !ok {
+		return 0, 0, 0, 0, false
+	}
+	return oldStart, oldLines, newStart, newLines, true
+}
+
+func parseHunkRange(r string) (int, int, bool) {
+	start, count, found := strings.Cut(r, ",")
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !found {
+		return s, 1, true
+	}
+	c, err := strconv.Atoi(count)
+	if err != nil {
+		return 0, 0, false
+	}
+	return s, c, true
+}
+
+// LineCommentability describes whether a review comment can be placed on a line of a pull request diff.
+type LineCommentability struct {
+	Path        string `json:"path"`
+	Line        int    `json:"line"`
+	Side        string `json:"side"`
+	StartLine   int    `json:"start_line,omitempty"`
+	StartSide   string `json:"start_side,omitempty"`
+	Commentable bool   `json:"commentable"`
+	Position    int    `json:"position,omitempty"`
+	NearestLine int    `json:"nearest_line,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// checkCommentable checks whether a comment can be placed on line of side in path, and for multi-line
+// comments, the range starting at startLine of startSide. startLine is 0 for single line comments.
+func (d pullRequestDiff) checkCommentable(path string, line int, side string, startLine int, startSide string) LineCommentability {
+	if side == "" {
+		side = diffSideRight
+	}
+	result := LineCommentability{Path: path, Line: line, Side: side}
+	if startLine > 0 {
+		if startSide == "" {
+			startSide = side
+		}
+		result.StartLine, result.StartSide = startLine, startSide
+	}
+
+	file, ok := d[path]
+	if !ok {
+		for _, f := range d {
+			if f.previousPath == path && f.path != path {
+				result.Reason = fmt.Sprintf("%s was renamed to %s in the pull request, comment on the new path instead", path, f.path)
+				return result
+			}
+		}
+		result.Reason = fmt.Sprintf("%s is not changed in the pull request", path)
+		return result
+	}
+	if file.binary {
+		result.Reason = fmt.Sprintf("%s is a binary file, comment on the whole file instead", path)
+		return result
+	}
+	if file.hunks == 0 {
+		result.Reason = fmt.Sprintf("%s has no changed lines, comment on the whole file instead", path)
+		return result
+	}
+
+	end, ok := file.lines(side)[line]
+	if !ok {
+		result.Reason = fmt.Sprintf("line %d on the %s side of %s is not part of the pull request diff", line, side, path)
+		if nearest, ok := file.nearestLine(side, line); ok {
+			result.NearestLine = nearest
+			result.Reason += fmt.Sprintf(", the nearest commentable line is %d", nearest)
+		}
+		return result
+	}
+	result.Position = end.position
+
+	if startLine > 0 {
+		start, ok := file.lines(startSide)[startLine]
+		if !ok {
+			result.Reason = fmt.Sprintf("start line %d on the %s side of %s is not part of the pull request diff", startLine, startSide, path)
+			if nearest, ok := file.nearestLine(startSide, startLine); ok {
+				result.Reason += fmt.Sprintf(", the nearest commentable line is %d", nearest)
+			}
+			return result
+		}
+		if start.hunk != end.hunk {
+			result.Reason = fmt.Sprintf("lines %d to %d of %s are in different hunks of the pull request diff, a multi-line comment must be within a single hunk", startLine, line, path)
+			return result
+		}
+		if start.position > end.position {
+			result.Reason = fmt.Sprintf("start line %d comes after line %d in the pull request diff", startLine, line)
+			return result
+		}
+	}
+
+	result.Commentable = true
+	return result
+}
+
+// getPullRequestDiff gets the unified diff of a pull request.
+func getPullRequestDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (string, *github.Response, error) {
+	diff, resp, err := client.PullRequests.GetRaw(ctx, owner, repo, pullNumber, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		return "", resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return diff, resp, nil
+}
+
+// CanCommentOnLine creates a tool to check whether a review comment can be placed on a line of a pull request diff.
+func CanCommentOnLine(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("can_comment_on_line",
+			mcp.WithDescription(t("TOOL_CAN_COMMENT_ON_LINE_DESCRIPTION", "Check whether a review comment can be placed on a line of the current pull request diff. Only lines in the diff can be commented on, when a line is not, the nearest commentable line is suggested. Use this before adding line comments to a review, especially after the pull request was force-pushed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CAN_COMMENT_ON_LINE_USER_TITLE", "Check if a pull request line can be commented on"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The relative path to the file"),
+			),
+			mcp.WithNumber("line",
+				mcp.Required(),
+				mcp.Description("The line of the file to comment on. For multi-line comments, the last line of the range"),
+				mcp.Min(1),
+			),
+			mcp.WithString("side",
+				mcp.Description("The side of the diff the line is on. LEFT indicates the previous state, RIGHT indicates the new state"),
+				mcp.Enum(diffSideLeft, diffSideRight),
+				mcp.DefaultString(diffSideRight),
+			),
+			mcp.WithNumber("startLine",
+				mcp.Description("For multi-line comments, the first line of the range"),
+				mcp.Min(1),
+			),
+			mcp.WithString("startSide",
+				mcp.Description("For multi-line comments, the side of the diff the first line is on. Defaults to side"),
+				mcp.Enum(diffSideLeft, diffSideRight),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			line, err := RequiredInt(request, "line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			side, err := OptionalParam[string](request, "side")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startLine, err := OptionalIntParam(request, "startLine")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startSide, err := OptionalParam[string](request, "startSide")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			diff, resp, err := getPullRequestDiff(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request diff",
+					resp,
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(parseUnifiedDiff(diff).checkCommentable(path, line, side, startLine, startSide)), nil
+		}
+}
@@ -0,0 +1,332 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readDiffFixture(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "diffs", name))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func Test_ParseUnifiedDiff(t *testing.T) {
+	files := parseUnifiedDiff(readDiffFixture(t, "pull_request.diff"))
+
+	require.Len(t, files, 7)
+
+	assert.Equal(t, "", files["added.go"].previousPath)
+	assert.Equal(t, map[int]diffLine{1: {1, 1}, 2: {2, 1}, 3: {3, 1}}, files["added.go"].right)
+	assert.Empty(t, files["added.go"].left)
+
+	assert.Equal(t, "moved.txt", files["docs/moved.txt"].previousPath)
+	assert.Zero(t, files["docs/moved.txt"].hunks)
+
+	assert.True(t, files["logo.png"].binary)
+
+	// The "\ No newline at end of file" markers count towards the position.
+	assert.Equal(t, map[int]diffLine{1: {1, 1}}, files["notes.txt"].left)
+	assert.Equal(t, map[int]diffLine{1: {3, 1}}, files["notes.txt"].right)
+
+	assert.Equal(t, map[int]diffLine{1: {1, 1}, 2: {2, 1}, 3: {3, 1}}, files["removed.go"].left)
+	assert.Empty(t, files["removed.go"].right)
+
+	server := files["server.go"]
+	assert.Equal(t, 2, server.hunks)
+	assert.Equal(t, diffLine{4, 1}, server.right[3])
+	assert.Equal(t, diffLine{3, 1}, server.left[3])
+	// The second hunk header is position 8.
+	assert.Equal(t, diffLine{9, 2}, server.right[27])
+	assert.Equal(t, diffLine{14, 2}, server.right[31])
+	assert.Equal(t, diffLine{15, 2}, server.right[32])
+	assert.Equal(t, diffLine{15, 2}, server.left[31])
+	assert.Equal(t, diffLine{19, 2}, server.left[35])
+	assert.Equal(t, diffLine{22, 2}, server.right[38])
+	assert.Len(t, server.right, 6+12)
+
+	assert.Equal(t, "config.yml", files["settings.yml"].previousPath)
+	assert.Equal(t, diffLine{5, 1}, files["settings.yml"].right[6])
+}
+
+func Test_CheckCommentable(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   string
+		path      string
+		line      int
+		side      string
+		startLine int
+		startSide string
+		expected  LineCommentability
+	}{
+		{
+			name:     "added line",
+			fixture:  "pull_request.diff",
+			path:     "server.go",
+			line:     3,
+			side:     "RIGHT",
+			expected: LineCommentability{Path: "server.go", Line: 3, Side: "RIGHT", Commentable: true, Position: 4},
+		},
+		{
+			name:     "side defaults to right",
+			fixture:  "pull_request.diff",
+			path:     "server.go",
+			line:     31,
+			expected: LineCommentability{Path: "server.go", Line: 31, Side: "RIGHT", Commentable: true, Position: 14},
+		},
+		{
+			name:     "deleted line on the left side",
+			fixture:  "pull_request.diff",
+			path:     "server.go",
+			line:     35,
+			side:     "LEFT",
+			expected: LineCommentability{Path: "server.go", Line: 35, Side: "LEFT", Commentable: true, Position: 19},
+		},
+		{
+			name:    "line between hunks suggests the nearest line",
+			fixture: "pull_request.diff",
+			path:    "server.go",
+			line:    20,
+			side:    "RIGHT",
+			expected: LineCommentability{
+				Path: "server.go", Line: 20, Side: "RIGHT", NearestLine: 27,
+				Reason: "line 20 on the RIGHT side of server.go is not part of the pull request diff, the nearest commentable line is 27",
+			},
+		},
+		{
+			name:    "deleted line on the right side",
+			fixture: "pull_request.diff",
+			path:    "removed.go",
+			line:    2,
+			side:    "RIGHT",
+			expected: LineCommentability{
+				Path: "removed.go", Line: 2, Side: "RIGHT",
+				Reason: "line 2 on the RIGHT side of removed.go is not part of the pull request diff",
+			},
+		},
+		{
+			name:      "multi-line range within a hunk",
+			fixture:   "pull_request.diff",
+			path:      "server.go",
+			line:      32,
+			startLine: 29,
+			expected:  LineCommentability{Path: "server.go", Line: 32, Side: "RIGHT", StartLine: 29, StartSide: "RIGHT", Commentable: true, Position: 15},
+		},
+		{
+			name:      "multi-line range across hunks",
+			fixture:   "pull_request.diff",
+			path:      "server.go",
+			line:      30,
+			startLine: 5,
+			expected: LineCommentability{
+				Path: "server.go", Line: 30, Side: "RIGHT", StartLine: 5, StartSide: "RIGHT", Position: 13,
+				Reason: "lines 5 to 30 of server.go are in different hunks of the pull request diff, a multi-line comment must be within a single hunk",
+			},
+		},
+		{
+			name:      "multi-line range starting outside the diff",
+			fixture:   "pull_request.diff",
+			path:      "server.go",
+			line:      28,
+			startLine: 24,
+			expected: LineCommentability{
+				Path: "server.go", Line: 28, Side: "RIGHT", StartLine: 24, StartSide: "RIGHT", Position: 10,
+				Reason: "start line 24 on the RIGHT side of server.go is not part of the pull request diff, the nearest commentable line is 27",
+			},
+		},
+		{
+			name:     "renamed file with changes",
+			fixture:  "pull_request.diff",
+			path:     "settings.yml",
+			line:     6,
+			side:     "RIGHT",
+			expected: LineCommentability{Path: "settings.yml", Line: 6, Side: "RIGHT", Commentable: true, Position: 5},
+		},
+		{
+			name:    "previous path of a renamed file",
+			fixture: "pull_request.diff",
+			path:    "config.yml",
+			line:    6,
+			side:    "LEFT",
+			expected: LineCommentability{
+				Path: "config.yml", Line: 6, Side: "LEFT",
+				Reason: "config.yml was renamed to settings.yml in the pull request, comment on the new path instead",
+			},
+		},
+		{
+			name:    "renamed file without changes",
+			fixture: "pull_request.diff",
+			path:    "docs/moved.txt",
+			line:    1,
+			expected: LineCommentability{
+				Path: "docs/moved.txt", Line: 1, Side: "RIGHT",
+				Reason: "docs/moved.txt has no changed lines, comment on the whole file instead",
+			},
+		},
+		{
+			name:    "binary file",
+			fixture: "pull_request.diff",
+			path:    "logo.png",
+			line:    1,
+			expected: LineCommentability{
+				Path: "logo.png", Line: 1, Side: "RIGHT",
+				Reason: "logo.png is a binary file, comment on the whole file instead",
+			},
+		},
+		{
+			name:    "file not in the pull request",
+			fixture: "pull_request.diff",
+			path:    "main.go",
+			line:    1,
+			expected: LineCommentability{
+				Path: "main.go", Line: 1, Side: "RIGHT",
+				Reason: "main.go is not changed in the pull request",
+			},
+		},
+		{
+			name:    "line that is no longer in the diff after a force push",
+			fixture: "force_pushed.diff",
+			path:    "server.go",
+			line:    30,
+			side:    "RIGHT",
+			expected: LineCommentability{
+				Path: "server.go", Line: 30, Side: "RIGHT", NearestLine: 6,
+				Reason: "line 30 on the RIGHT side of server.go is not part of the pull request diff, the nearest commentable line is 6",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := parseUnifiedDiff(readDiffFixture(t, tc.fixture))
+			assert.Equal(t, tc.expected, diff.checkCommentable(tc.path, tc.line, tc.side, tc.startLine, tc.startSide))
+		})
+	}
+}
+
+func Test_CanCommentOnLine(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CanCommentOnLine(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "can_comment_on_line", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "line")
+	assert.Contains(t, tool.InputSchema.Properties, "side")
+	assert.Contains(t, tool.InputSchema.Properties, "startLine")
+	assert.Contains(t, tool.InputSchema.Properties, "startSide")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "path", "line"})
+
+	diff := readDiffFixture(t, "pull_request.diff")
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult LineCommentability
+	}{
+		{
+			name: "commentable line",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+						mockResponse(t, http.StatusOK, diff),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"line":       float64(30),
+			},
+			expectedResult: LineCommentability{Path: "server.go", Line: 30, Side: "RIGHT", Commentable: true, Position: 13},
+		},
+		{
+			name: "line outside the diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, diff),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"line":       float64(12),
+				"side":       "LEFT",
+			},
+			expectedResult: LineCommentability{
+				Path: "server.go", Line: 12, Side: "LEFT", NearestLine: 6,
+				Reason: "line 12 on the LEFT side of server.go is not part of the pull request diff, the nearest commentable line is 6",
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+				"path":       "server.go",
+				"line":       float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request diff",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CanCommentOnLine(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var check LineCommentability
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &check))
+			assert.Equal(t, tc.expectedResult, check)
+		})
+	}
+}
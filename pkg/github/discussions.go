@@ -252,7 +252,7 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal discussions: %w", err)
 			}
-			return mcp.NewToolResultText(string(out)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(out)), pageInfo.nextPageToken(int(*paginationParams.First))), nil
 		}
 }
 
@@ -437,7 +437,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				return nil, fmt.Errorf("failed to marshal comments: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(out)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(out)), nextGraphQLPageToken(bool(q.Repository.Discussion.Comments.PageInfo.HasNextPage), string(q.Repository.Discussion.Comments.PageInfo.EndCursor), int(*paginationParams.First))), nil
 		}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -16,6 +17,8 @@ func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
 	for name := range toolsetGroup.Toolsets {
 		toolsetNames = append(toolsetNames, name)
 	}
+	// Sorted, so that the schema does not change with the map's iteration order
+	slices.Sort(toolsetNames)
 	return mcp.Enum(toolsetNames...)
 }
 
@@ -39,22 +42,23 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			toolset := toolsetGroup.Toolsets[toolsetName]
-			if toolset == nil {
+			if toolsetGroup.Toolsets[toolsetName] == nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
-			if toolset.Enabled {
+			changed, err := toolsetGroup.SetToolsetEnabled(toolsetName, true, func(toolset *toolsets.Toolset) {
+				// caution: this currently affects the global tools and notifies all clients:
+				//
+				// Send notification to all initialized sessions
+				// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
+				s.AddTools(toolset.GetActiveTools()...)
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !changed {
 				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
 			}
 
-			toolset.Enabled = true
-
-			// caution: this currently affects the global tools and notifies all clients:
-			//
-			// Send notification to all initialized sessions
-			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
-			s.AddTools(toolset.GetActiveTools()...)
-
 			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
 		}
 }
@@ -72,13 +76,14 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 
 			payload := []map[string]string{}
 
+			enabled := toolsetGroup.EnabledToolsets()
 			for name, ts := range toolsetGroup.Toolsets {
 				{
 					t := map[string]string{
 						"name":              name,
 						"description":       ts.Description,
 						"can_enable":        "true",
-						"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
+						"currently_enabled": fmt.Sprintf("%t", slices.Contains(enabled, name)),
 					}
 					payload = append(payload, t)
 				}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// EnterpriseToolsetName is the name of the toolset of GitHub Enterprise Server administration tools,
+// which is only offered when the server is configured for a GitHub Enterprise Server host.
+const EnterpriseToolsetName = "enterprise"
+
+// siteAdminErrorResponse returns the error result of a failed request to a site administrator API.
+// GitHub Enterprise Server answers requests of other users with forbidden or not found, which is
+// explained as such.
+func siteAdminErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+		message += ": this requires the token of a site administrator of the GitHub Enterprise Server instance"
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+}
+
+// GetEnterpriseAdminStats creates a tool to get the statistics of a GitHub Enterprise Server instance.
+func GetEnterpriseAdminStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_enterprise_admin_stats",
+			mcp.WithDescription(t("TOOL_GET_ENTERPRISE_ADMIN_STATS_DESCRIPTION", "Get the statistics of the GitHub Enterprise Server instance: the numbers of repositories, users, organizations, issues, pull requests and more. Requires a site administrator token.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ENTERPRISE_ADMIN_STATS_USER_TITLE", "Get instance statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stats, resp, err := client.Admin.GetAdminStats(ctx)
+			if err != nil {
+				return siteAdminErrorResponse(ctx, "failed to get admin stats", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(stats), nil
+		}
+}
+
+// GHESLicense is the license of a GitHub Enterprise Server instance.
+type GHESLicense struct {
+	Seats               any    `json:"seats"`
+	SeatsUsed           int    `json:"seats_used"`
+	SeatsAvailable      any    `json:"seats_available"`
+	Kind                string `json:"kind"`
+	DaysUntilExpiration int    `json:"days_until_expiration"`
+	ExpireAt            string `json:"expire_at"`
+}
+
+// GetGHESLicenseInfo creates a tool to get the license of a GitHub Enterprise Server instance.
+func GetGHESLicenseInfo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ghes_license_info",
+			mcp.WithDescription(t("TOOL_GET_GHES_LICENSE_INFO_DESCRIPTION", "Get the license of the GitHub Enterprise Server instance: its kind, the seats used and available, and when it expires. Requires a site administrator token.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GHES_LICENSE_INFO_USER_TITLE", "Get instance license"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github only supports the license endpoints of the Management Console API
+			req, err := client.NewRequest(http.MethodGet, "enterprise/settings/license", nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var license GHESLicense
+			resp, err := client.Do(ctx, req, &license)
+			if err != nil {
+				return siteAdminErrorResponse(ctx, "failed to get license information", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(license), nil
+		}
+}
+
+// InstanceOrganizations is the output type of the get_all_orgs_on_instance tool.
+type InstanceOrganizations struct {
+	Organizations []*github.Organization `json:"organizations"`
+	// NextSince is the since to pass to get the next page, if there is one.
+	NextSince int64 `json:"next_since,omitempty"`
+}
+
+// GetAllOrgsOnInstance creates a tool to list all organizations of a GitHub Enterprise Server instance.
+func GetAllOrgsOnInstance(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_all_orgs_on_instance",
+			mcp.WithDescription(t("TOOL_GET_ALL_ORGS_ON_INSTANCE_DESCRIPTION", "List all organizations of the GitHub Enterprise Server instance in the order they were created. Pass the returned next_since as since to get the next page. Only site administrators see every organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ALL_ORGS_ON_INSTANCE_USER_TITLE", "List all organizations of the instance"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("since",
+				mcp.Description("Only list organizations with an ID greater than this, as returned in next_since"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page (max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			since, err := OptionalIntParam(request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			orgs, resp, err := client.Organizations.ListAll(ctx, &github.OrganizationsListOptions{
+				Since:       int64(since),
+				ListOptions: github.ListOptions{PerPage: perPage},
+			})
+			if err != nil {
+				return siteAdminErrorResponse(ctx, "failed to list organizations", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := InstanceOrganizations{Organizations: orgs}
+			if len(orgs) == perPage {
+				result.NextSince = orgs[len(orgs)-1].GetID()
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
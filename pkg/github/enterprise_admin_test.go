@@ -0,0 +1,247 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	getEnterpriseStatsAll        = mock.EndpointPattern{Pattern: "/enterprise/stats/all", Method: "GET"}
+	getEnterpriseSettingsLicense = mock.EndpointPattern{Pattern: "/enterprise/settings/license", Method: "GET"}
+	getOrganizations             = mock.EndpointPattern{Pattern: "/organizations", Method: "GET"}
+)
+
+func Test_GetEnterpriseAdminStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnterpriseAdminStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_enterprise_admin_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	stats := &github.AdminStats{
+		Repos: &github.RepoStats{TotalRepos: github.Ptr(120), ForkRepos: github.Ptr(8)},
+		Users: &github.UserStats{TotalUsers: github.Ptr(42), AdminUsers: github.Ptr(2)},
+		Issues: &github.IssueStats{
+			TotalIssues:  github.Ptr(300),
+			OpenIssues:   github.Ptr(100),
+			ClosedIssues: github.Ptr(200),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful stats fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(getEnterpriseStatsAll, stats),
+			),
+		},
+		{
+			name: "token of a user who is not a site administrator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseStatsAll,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get admin stats: this requires the token of a site administrator of the GitHub Enterprise Server instance",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetEnterpriseAdminStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.AdminStats
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 120, returned.GetRepos().GetTotalRepos())
+			assert.Equal(t, 42, returned.GetUsers().GetTotalUsers())
+			assert.Equal(t, 300, returned.GetIssues().GetTotalIssues())
+		})
+	}
+}
+
+func Test_GetGHESLicenseInfo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGHESLicenseInfo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ghes_license_info", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedLicense GHESLicense
+	}{
+		{
+			name: "successful license fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseSettingsLicense,
+					mockResponse(t, http.StatusOK, `{"seats": "unlimited", "seats_used": 1500, "seats_available": "unlimited", "kind": "standard", "days_until_expiration": 365, "expire_at": "2027-10-18T00:00:00-07:00"}`),
+				),
+			),
+			expectedLicense: GHESLicense{
+				Seats:               "unlimited",
+				SeatsUsed:           1500,
+				SeatsAvailable:      "unlimited",
+				Kind:                "standard",
+				DaysUntilExpiration: 365,
+				ExpireAt:            "2027-10-18T00:00:00-07:00",
+			},
+		},
+		{
+			name: "token of a user who is not a site administrator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseSettingsLicense,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must be a site administrator"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get license information: this requires the token of a site administrator of the GitHub Enterprise Server instance",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGHESLicenseInfo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned GHESLicense
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedLicense, returned)
+		})
+	}
+}
+
+func Test_GetAllOrgsOnInstance(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAllOrgsOnInstance(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_all_orgs_on_instance", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	orgs := []*github.Organization{
+		{ID: github.Ptr(int64(3)), Login: github.Ptr("platform")},
+		{ID: github.Ptr(int64(7)), Login: github.Ptr("security")},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedNextSince int64
+	}{
+		{
+			name: "full page has a next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getOrganizations,
+					expectQueryParams(t, map[string]string{"since": "1", "per_page": "2"}).andThen(
+						mockResponse(t, http.StatusOK, orgs),
+					),
+				),
+			),
+			requestArgs:       map[string]any{"since": float64(1), "perPage": float64(2)},
+			expectedNextSince: 7,
+		},
+		{
+			name: "last page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getOrganizations,
+					expectQueryParams(t, map[string]string{"per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, orgs),
+					),
+				),
+			),
+			requestArgs: map[string]any{},
+		},
+		{
+			name: "token of a user who is not a site administrator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getOrganizations,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "failed to list organizations: this requires the token of a site administrator of the GitHub Enterprise Server instance",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetAllOrgsOnInstance(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned InstanceOrganizations
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned.Organizations, 2)
+			assert.Equal(t, "platform", returned.Organizations[0].GetLogin())
+			assert.Equal(t, tc.expectedNextSince, returned.NextSince)
+		})
+	}
+}
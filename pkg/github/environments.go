@@ -0,0 +1,650 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxEnvironmentWaitTimer is the longest wait timer, in minutes, GitHub allows for an environment.
+const maxEnvironmentWaitTimer = 43200
+
+// EnvironmentSecret is a secret of an environment, without its value.
+type EnvironmentSecret struct {
+	Name      string           `json:"name"`
+	CreatedAt github.Timestamp `json:"created_at"`
+	UpdatedAt github.Timestamp `json:"updated_at"`
+}
+
+// EnvironmentReviewer is a user or team that can approve deployments to an environment.
+type EnvironmentReviewer struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Login string `json:"login,omitempty"`
+	Slug  string `json:"slug,omitempty"`
+}
+
+// EnvironmentBranchPolicy is the output type of the branches and tags that can deploy to an environment.
+type EnvironmentBranchPolicy struct {
+	// Type is "protected_branches" or "custom". Without a policy, all branches can deploy.
+	Type     string   `json:"type"`
+	Branches []string `json:"branches,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// EnvironmentProtection is the output type of the get_environment_protection_rules tool.
+type EnvironmentProtection struct {
+	Environment       string                   `json:"environment"`
+	RequiredReviewers []EnvironmentReviewer    `json:"required_reviewers"`
+	PreventSelfReview bool                     `json:"prevent_self_review"`
+	WaitTimerMinutes  int                      `json:"wait_timer_minutes"`
+	CanAdminsBypass   bool                     `json:"can_admins_bypass"`
+	BranchPolicy      *EnvironmentBranchPolicy `json:"branch_policy,omitempty"`
+}
+
+// environmentErrorResponse reports a failed environment request. If the environment does not
+// exist, it lists the environments that do, so that the right one can be picked.
+func environmentErrorResponse(ctx context.Context, client *github.Client, owner, repo, environment, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+	}
+
+	environments, listResp, listErr := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if listErr != nil {
+		// The repository itself may not exist
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+	}
+	defer func() { _ = listResp.Body.Close() }()
+
+	names := make([]string, 0, len(environments.Environments))
+	for _, env := range environments.Environments {
+		if env.GetName() == environment {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+		}
+		names = append(names, env.GetName())
+	}
+	if len(names) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("environment %q not found: %s/%s has no environments", environment, owner, repo))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("environment %q not found in %s/%s, existing environments: %s", environment, owner, repo, strings.Join(names, ", ")))
+}
+
+// ListEnvironmentSecrets creates a tool to list the secrets of a deployment environment.
+func ListEnvironmentSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environment_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENT_SECRETS_DESCRIPTION", "List the names of the GitHub Actions secrets of a repository's deployment environment. Secret values are never returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENT_SECRETS_USER_TITLE", "List environment secrets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Environment name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Environment secrets are addressed by repository ID
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			secrets, resp, err := client.Actions.ListEnvSecrets(ctx, int(repository.GetID()), url.PathEscape(environment), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return environmentErrorResponse(ctx, client, owner, repo, environment, "failed to list environment secrets", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := struct {
+				TotalCount int                 `json:"total_count"`
+				Secrets    []EnvironmentSecret `json:"secrets"`
+			}{
+				TotalCount: secrets.TotalCount,
+				Secrets:    make([]EnvironmentSecret, 0, len(secrets.Secrets)),
+			}
+			for _, secret := range secrets.Secrets {
+				result.Secrets = append(result.Secrets, EnvironmentSecret{
+					Name:      secret.Name,
+					CreatedAt: secret.CreatedAt,
+					UpdatedAt: secret.UpdatedAt,
+				})
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// ListEnvironmentVariables creates a tool to list the variables of a deployment environment.
+func ListEnvironmentVariables(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environment_variables",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENT_VARIABLES_DESCRIPTION", "List the GitHub Actions variables of a repository's deployment environment, including their values.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENT_VARIABLES_USER_TITLE", "List environment variables"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Environment name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variables, resp, err := client.Actions.ListEnvVariables(ctx, owner, repo, url.PathEscape(environment), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return environmentErrorResponse(ctx, client, owner, repo, environment, "failed to list environment variables", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(variables), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// CreateEnvironmentVariable creates a tool to create a variable in a deployment environment.
+func CreateEnvironmentVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_environment_variable",
+			mcp.WithDescription(t("TOOL_CREATE_ENVIRONMENT_VARIABLE_DESCRIPTION", "Create a GitHub Actions variable in a repository's deployment environment. Fails if the environment already has a variable with the name; use update_environment_variable to change its value.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_ENVIRONMENT_VARIABLE_USER_TITLE", "Create environment variable"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Environment name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("Value of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := RequiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.CreateEnvVariable(ctx, owner, repo, url.PathEscape(environment), &github.ActionsVariable{
+				Name:  name,
+				Value: value,
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create environment variable: environment %s already has a variable named %s, use update_environment_variable to change its value", environment, name),
+						resp,
+						err,
+					), nil
+				}
+				return environmentErrorResponse(ctx, client, owner, repo, environment, "failed to create environment variable", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Variable %s has been created in environment %s", name, environment),
+				"name":        name,
+				"environment": environment,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
+
+// UpdateEnvironmentVariable creates a tool to change the value of a variable in a deployment environment.
+func UpdateEnvironmentVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_environment_variable",
+			mcp.WithDescription(t("TOOL_UPDATE_ENVIRONMENT_VARIABLE_DESCRIPTION", "Change the value of a GitHub Actions variable in a repository's deployment environment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ENVIRONMENT_VARIABLE_USER_TITLE", "Update environment variable"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Environment name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("New value of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := RequiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.UpdateEnvVariable(ctx, owner, repo, url.PathEscape(environment), &github.ActionsVariable{
+				Name:  name,
+				Value: value,
+			})
+			if err != nil {
+				return environmentErrorResponse(ctx, client, owner, repo, environment, "failed to update environment variable", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     fmt.Sprintf("Variable %s has been updated in environment %s", name, environment),
+				"name":        name,
+				"environment": environment,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
+
+// GetEnvironmentProtectionRules creates a tool to explain what a deployment to an environment waits for.
+func GetEnvironmentProtectionRules(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_environment_protection_rules",
+			mcp.WithDescription(t("TOOL_GET_ENVIRONMENT_PROTECTION_RULES_DESCRIPTION", "Get the protection rules of a repository's deployment environment: the users and teams that must approve deployments, the wait timer, and the branches and tags allowed to deploy. Use this to explain why a deployment is waiting.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ENVIRONMENT_PROTECTION_RULES_USER_TITLE", "Get environment protection rules"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Environment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, url.PathEscape(environment))
+			if err != nil {
+				return environmentErrorResponse(ctx, client, owner, repo, environment, "failed to get environment", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			protection := EnvironmentProtection{
+				Environment:       env.GetName(),
+				RequiredReviewers: []EnvironmentReviewer{},
+				CanAdminsBypass:   env.GetCanAdminsBypass(),
+			}
+			for _, rule := range env.ProtectionRules {
+				switch rule.GetType() {
+				case "required_reviewers":
+					protection.PreventSelfReview = rule.GetPreventSelfReview()
+					for _, reviewer := range rule.Reviewers {
+						resolved, err := resolveEnvironmentReviewer(ctx, client, reviewer)
+						if err != nil {
+							return nil, err
+						}
+						protection.RequiredReviewers = append(protection.RequiredReviewers, resolved)
+					}
+				case "wait_timer":
+					protection.WaitTimerMinutes = rule.GetWaitTimer()
+				}
+			}
+
+			branchPolicy, err := environmentBranchPolicy(ctx, client, owner, repo, environment, env.DeploymentBranchPolicy)
+			if err != nil {
+				return nil, err
+			}
+			protection.BranchPolicy = branchPolicy
+
+			return MarshalledTextResult(protection), nil
+		}
+}
+
+// resolveEnvironmentReviewer returns the login of a user reviewer, or the slug of a team reviewer.
+// Users are looked up by ID if GitHub did not include their login.
+func resolveEnvironmentReviewer(ctx context.Context, client *github.Client, reviewer *github.RequiredReviewer) (EnvironmentReviewer, error) {
+	switch r := reviewer.Reviewer.(type) {
+	case *github.User:
+		resolved := EnvironmentReviewer{Type: "User", ID: r.GetID(), Login: r.GetLogin()}
+		if resolved.Login == "" && resolved.ID != 0 {
+			user, resp, err := client.Users.GetByID(ctx, resolved.ID)
+			if err != nil {
+				return EnvironmentReviewer{}, fmt.Errorf("failed to get reviewer %d: %w", resolved.ID, err)
+			}
+			_ = resp.Body.Close()
+			resolved.Login = user.GetLogin()
+		}
+		return resolved, nil
+	case *github.Team:
+		return EnvironmentReviewer{Type: "Team", ID: r.GetID(), Slug: r.GetSlug()}, nil
+	default:
+		return EnvironmentReviewer{Type: reviewer.GetType()}, nil
+	}
+}
+
+// environmentBranchPolicy returns which branches and tags can deploy to an environment, or nil if
+// all can.
+func environmentBranchPolicy(ctx context.Context, client *github.Client, owner, repo, environment string, policy *github.BranchPolicy) (*EnvironmentBranchPolicy, error) {
+	switch {
+	case policy.GetProtectedBranches():
+		return &EnvironmentBranchPolicy{Type: "protected_branches"}, nil
+	case policy.GetCustomBranchPolicies():
+		policies, resp, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, url.PathEscape(environment))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployment branch policies: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		custom := &EnvironmentBranchPolicy{Type: "custom"}
+		for _, p := range policies.BranchPolicies {
+			if p.GetType() == "tag" {
+				custom.Tags = append(custom.Tags, p.GetName())
+			} else {
+				custom.Branches = append(custom.Branches, p.GetName())
+			}
+		}
+		return custom, nil
+	default:
+		return nil, nil
+	}
+}
+
+// UpdateEnvironmentProtection creates a tool to change the required reviewers and wait timer of a
+// deployment environment.
+func UpdateEnvironmentProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_environment_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_ENVIRONMENT_PROTECTION_DESCRIPTION", "Change the required reviewers and wait timer of an existing deployment environment. Settings that are not passed are kept. Pass an empty list to remove all user or team reviewers.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ENVIRONMENT_PROTECTION_USER_TITLE", "Update environment protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Environment name"),
+			),
+			mcp.WithArray("reviewer_users",
+				mcp.Description("Logins of the users that must approve deployments. Replaces the current user reviewers"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("reviewer_teams",
+				mcp.Description("Slugs of the teams of the repository owner's organization that must approve deployments. Replaces the current team reviewers"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("wait_timer",
+				mcp.Description("Minutes to wait before deployments proceed, from 0 to 43200"),
+				mcp.Min(0),
+				mcp.Max(maxEnvironmentWaitTimer),
+			),
+			mcp.WithBoolean("prevent_self_review",
+				mcp.Description("Whether the user who triggered a deployment is prevented from approving it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, usersProvided := request.GetArguments()["reviewer_users"]
+			reviewerUsers, err := OptionalStringArrayParam(request, "reviewer_users")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, teamsProvided := request.GetArguments()["reviewer_teams"]
+			reviewerTeams, err := OptionalStringArrayParam(request, "reviewer_teams")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, waitTimerProvided := request.GetArguments()["wait_timer"]
+			waitTimer, err := OptionalIntParam(request, "wait_timer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if waitTimer < 0 || waitTimer > maxEnvironmentWaitTimer {
+				return mcp.NewToolResultError(fmt.Sprintf("wait_timer must be between 0 and %d minutes", maxEnvironmentWaitTimer)), nil
+			}
+			preventSelfReview, preventSelfReviewProvided, err := OptionalParamOK[bool](request, "prevent_self_review")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !usersProvided && !teamsProvided && !waitTimerProvided && !preventSelfReviewProvided {
+				return mcp.NewToolResultError("at least one of reviewer_users, reviewer_teams, wait_timer or prevent_self_review must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Updating an environment that does not exist would create it, so it is looked up first.
+			// The update replaces every setting, so the current ones are kept unless changed.
+			env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, url.PathEscape(environment))
+			if err != nil {
+				return environmentErrorResponse(ctx, client, owner, repo, environment, "failed to get environment", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			update := &github.CreateUpdateEnvironment{
+				CanAdminsBypass:        github.Ptr(env.GetCanAdminsBypass()),
+				DeploymentBranchPolicy: env.DeploymentBranchPolicy,
+				Reviewers:              []*github.EnvReviewers{},
+			}
+			var userReviewers, teamReviewers []*github.EnvReviewers
+			for _, rule := range env.ProtectionRules {
+				switch rule.GetType() {
+				case "required_reviewers":
+					update.PreventSelfReview = github.Ptr(rule.GetPreventSelfReview())
+					for _, reviewer := range rule.Reviewers {
+						switch r := reviewer.Reviewer.(type) {
+						case *github.User:
+							userReviewers = append(userReviewers, &github.EnvReviewers{Type: github.Ptr("User"), ID: r.ID})
+						case *github.Team:
+							teamReviewers = append(teamReviewers, &github.EnvReviewers{Type: github.Ptr("Team"), ID: r.ID})
+						}
+					}
+				case "wait_timer":
+					update.WaitTimer = github.Ptr(rule.GetWaitTimer())
+				}
+			}
+
+			if usersProvided {
+				userReviewers = make([]*github.EnvReviewers, 0, len(reviewerUsers))
+				for _, login := range reviewerUsers {
+					user, resp, err := client.Users.Get(ctx, login)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get reviewer %s", login), resp, err), nil
+					}
+					_ = resp.Body.Close()
+					userReviewers = append(userReviewers, &github.EnvReviewers{Type: github.Ptr("User"), ID: user.ID})
+				}
+			}
+			if teamsProvided {
+				teamReviewers = make([]*github.EnvReviewers, 0, len(reviewerTeams))
+				for _, slug := range reviewerTeams {
+					team, resp, err := client.Teams.GetTeamBySlug(ctx, owner, slug)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get reviewer team %s", slug), resp, err), nil
+					}
+					_ = resp.Body.Close()
+					teamReviewers = append(teamReviewers, &github.EnvReviewers{Type: github.Ptr("Team"), ID: team.ID})
+				}
+			}
+			update.Reviewers = append(append(update.Reviewers, userReviewers...), teamReviewers...)
+			if waitTimerProvided {
+				update.WaitTimer = github.Ptr(waitTimer)
+			}
+			if preventSelfReviewProvided {
+				update.PreventSelfReview = github.Ptr(preventSelfReview)
+			}
+
+			updated, resp, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, url.PathEscape(environment), update)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update environment", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(updated), nil
+		}
+}
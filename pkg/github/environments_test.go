@@ -0,0 +1,612 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getEnvironmentSecretsByRepositoryID is the endpoint go-github lists environment secrets with,
+// which the mock library does not define.
+var getEnvironmentSecretsByRepositoryID = mock.EndpointPattern{
+	Pattern: "/repositories/{repository_id}/environments/{environment_name}/secrets",
+	Method:  "GET",
+}
+
+// mockProductionEnvironment is an environment as GitHub returns it, with reviewers only identified by ID
+// where GitHub omits their login.
+var mockProductionEnvironment = map[string]any{
+	"id":                42,
+	"name":              "production",
+	"can_admins_bypass": false,
+	"protection_rules": []map[string]any{
+		{
+			"id":                  1,
+			"type":                "required_reviewers",
+			"prevent_self_review": true,
+			"reviewers": []map[string]any{
+				{"type": "User", "reviewer": map[string]any{"id": 1, "login": "octocat"}},
+				{"type": "User", "reviewer": map[string]any{"id": 2}},
+				{"type": "Team", "reviewer": map[string]any{"id": 10, "slug": "release-managers"}},
+			},
+		},
+		{"id": 2, "type": "wait_timer", "wait_timer": 30},
+		{"id": 3, "type": "branch_policy"},
+	},
+	"deployment_branch_policy": map[string]any{
+		"protected_branches":     false,
+		"custom_branch_policies": true,
+	},
+}
+
+// mockEnvironmentList lists the environments of a repository without a production environment.
+var mockEnvironmentList = map[string]any{
+	"total_count": 2,
+	"environments": []map[string]any{
+		{"id": 41, "name": "staging"},
+		{"id": 43, "name": "prod"},
+	},
+}
+
+func Test_ListEnvironmentSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironmentSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_environment_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedNames  []string
+	}{
+		{
+			name: "lists secret names",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{ID: github.Ptr(int64(7))}),
+				mock.WithRequestMatchHandler(
+					getEnvironmentSecretsByRepositoryID,
+					expectPath(t, "/repositories/7/environments/production/secrets").andThen(
+						mockResponse(t, http.StatusOK, map[string]any{
+							"total_count": 2,
+							"secrets": []map[string]any{
+								{"name": "DEPLOY_KEY", "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-02-01T00:00:00Z"},
+								{"name": "API_TOKEN", "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-01T00:00:00Z"},
+							},
+						}),
+					),
+				),
+			),
+			expectedNames: []string{"DEPLOY_KEY", "API_TOKEN"},
+		},
+		{
+			name: "environment not found lists existing ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{ID: github.Ptr(int64(7))}),
+				mock.WithRequestMatchHandler(getEnvironmentSecretsByRepositoryID, mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)),
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, mockEnvironmentList),
+			),
+			expectError:    true,
+			expectedErrMsg: `environment "production" not found in owner/repo, existing environments: staging, prod`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnvironmentSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned struct {
+				TotalCount int                 `json:"total_count"`
+				Secrets    []EnvironmentSecret `json:"secrets"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 2, returned.TotalCount)
+			names := make([]string, 0, len(returned.Secrets))
+			for _, secret := range returned.Secrets {
+				names = append(names, secret.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func Test_ListEnvironmentVariables(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironmentVariables(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_environment_variables", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       *github.ActionsVariables
+	}{
+		{
+			name: "lists the variables of the environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName,
+					expectPath(t, "/repos/owner/repo/environments/production/variables").andThen(
+						mockResponse(t, http.StatusOK, &github.ActionsVariables{
+							TotalCount: 1,
+							Variables:  []*github.ActionsVariable{{Name: "REGION", Value: "eu-west-1"}},
+						}),
+					),
+				),
+			),
+			expected: &github.ActionsVariables{
+				TotalCount: 1,
+				Variables:  []*github.ActionsVariable{{Name: "REGION", Value: "eu-west-1"}},
+			},
+		},
+		{
+			name: "environment not found lists existing ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, mockEnvironmentList),
+			),
+			expectError:    true,
+			expectedErrMsg: `environment "production" not found in owner/repo, existing environments: staging, prod`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnvironmentVariables(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			var returned github.ActionsVariables
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+		})
+	}
+}
+
+func Test_CreateEnvironmentVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateEnvironmentVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_environment_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment", "name", "value"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates the variable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName,
+					expectPath(t, "/repos/owner/repo/environments/production/variables").andThen(
+						expectRequestBody(t, map[string]any{"name": "REGION", "value": "eu-west-1"}).andThen(
+							mockResponse(t, http.StatusCreated, nil),
+						),
+					),
+				),
+			),
+		},
+		{
+			name: "variable already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusConflict, `{"message": "Already exists - Variable already exists"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "environment production already has a variable named REGION, use update_environment_variable to change its value",
+		},
+		{
+			name: "environment not found lists existing ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, mockEnvironmentList),
+			),
+			expectError:    true,
+			expectedErrMsg: `environment "production" not found in owner/repo, existing environments: staging, prod`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateEnvironmentVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"name":        "REGION",
+				"value":       "eu-west-1",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "Variable REGION has been created in environment production", returned["message"])
+			assert.Equal(t, float64(http.StatusCreated), returned["status_code"])
+		})
+	}
+}
+
+func Test_UpdateEnvironmentVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateEnvironmentVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_environment_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment", "name", "value"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposEnvironmentsVariablesByOwnerByRepoByEnvironmentNameByName,
+			expectPath(t, "/repos/owner/repo/environments/production/variables/REGION").andThen(
+				expectRequestBody(t, map[string]any{"name": "REGION", "value": "us-east-1"}).andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		),
+	))
+	_, handler := UpdateEnvironmentVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"environment": "production",
+		"name":        "REGION",
+		"value":       "us-east-1",
+	}))
+	require.NoError(t, err)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "Variable REGION has been updated in environment production", returned["message"])
+}
+
+func Test_GetEnvironmentProtectionRules(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnvironmentProtectionRules(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_environment_protection_rules", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		environment        string
+		expectError        bool
+		expectedErrMsg     string
+		expectedProtection EnvironmentProtection
+	}{
+		{
+			name: "resolves reviewers and branch policies",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectPath(t, "/repos/owner/repo/environments/production").andThen(
+						mockResponse(t, http.StatusOK, mockProductionEnvironment),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/user/{account_id}", Method: "GET"},
+					expectPath(t, "/user/2").andThen(
+						mockResponse(t, http.StatusOK, &github.User{ID: github.Ptr(int64(2)), Login: github.Ptr("hubot")}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					map[string]any{
+						"total_count": 2,
+						"branch_policies": []map[string]any{
+							{"name": "main", "type": "branch"},
+							{"name": "v*", "type": "tag"},
+						},
+					},
+				),
+			),
+			environment: "production",
+			expectedProtection: EnvironmentProtection{
+				Environment: "production",
+				RequiredReviewers: []EnvironmentReviewer{
+					{Type: "User", ID: 1, Login: "octocat"},
+					{Type: "User", ID: 2, Login: "hubot"},
+					{Type: "Team", ID: 10, Slug: "release-managers"},
+				},
+				PreventSelfReview: true,
+				WaitTimerMinutes:  30,
+				BranchPolicy: &EnvironmentBranchPolicy{
+					Type:     "custom",
+					Branches: []string{"main"},
+					Tags:     []string{"v*"},
+				},
+			},
+		},
+		{
+			name: "unprotected environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					map[string]any{"name": "staging", "can_admins_bypass": true},
+				),
+			),
+			environment: "staging",
+			expectedProtection: EnvironmentProtection{
+				Environment:       "staging",
+				RequiredReviewers: []EnvironmentReviewer{},
+				CanAdminsBypass:   true,
+			},
+		},
+		{
+			name: "environment not found lists existing ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, mockEnvironmentList),
+			),
+			environment:    "production",
+			expectError:    true,
+			expectedErrMsg: `environment "production" not found in owner/repo, existing environments: staging, prod`,
+		},
+		{
+			name: "repository without environments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, map[string]any{"total_count": 0, "environments": []any{}}),
+			),
+			environment:    "production",
+			expectError:    true,
+			expectedErrMsg: `environment "production" not found: owner/repo has no environments`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetEnvironmentProtectionRules(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": tc.environment,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned EnvironmentProtection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedProtection, returned)
+		})
+	}
+}
+
+func Test_UpdateEnvironmentProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateEnvironmentProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_environment_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "reviewer_users")
+	assert.Contains(t, tool.InputSchema.Properties, "reviewer_teams")
+	assert.Contains(t, tool.InputSchema.Properties, "wait_timer")
+	assert.Contains(t, tool.InputSchema.Properties, "prevent_self_review")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "replaces user reviewers and keeps the rest",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, mockProductionEnvironment),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					expectPath(t, "/users/monalisa").andThen(
+						mockResponse(t, http.StatusOK, &github.User{ID: github.Ptr(int64(3)), Login: github.Ptr("monalisa")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer":          float64(30),
+						"prevent_self_review": true,
+						"can_admins_bypass":   false,
+						"deployment_branch_policy": map[string]any{
+							"protected_branches":     false,
+							"custom_branch_policies": true,
+						},
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(3)},
+							map[string]any{"type": "Team", "id": float64(10)},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"name": "production"}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"reviewer_users": []any{"monalisa"},
+			},
+		},
+		{
+			name: "changes the wait timer and team reviewers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, mockProductionEnvironment),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					expectPath(t, "/orgs/owner/teams/sre").andThen(
+						mockResponse(t, http.StatusOK, &github.Team{ID: github.Ptr(int64(11)), Slug: github.Ptr("sre")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer":          float64(0),
+						"prevent_self_review": false,
+						"can_admins_bypass":   false,
+						"deployment_branch_policy": map[string]any{
+							"protected_branches":     false,
+							"custom_branch_policies": true,
+						},
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(1)},
+							map[string]any{"type": "User", "id": float64(2)},
+							map[string]any{"type": "Team", "id": float64(11)},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"name": "production"}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"reviewer_teams":      []any{"sre"},
+				"wait_timer":          float64(0),
+				"prevent_self_review": false,
+			},
+		},
+		{
+			name: "environment not found is not created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, mockEnvironmentList),
+			),
+			requestArgs: map[string]any{
+				"wait_timer": float64(10),
+			},
+			expectError:    true,
+			expectedErrMsg: `environment "production" not found in owner/repo, existing environments: staging, prod`,
+		},
+		{
+			name:           "nothing to change",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "at least one of reviewer_users, reviewer_teams, wait_timer or prevent_self_review must be provided",
+		},
+		{
+			name:           "wait timer too long",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"wait_timer": float64(50000)},
+			expectError:    true,
+			expectedErrMsg: "wait_timer must be between 0 and 43200 minutes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateEnvironmentProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Environment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "production", returned.GetName())
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxEventCommits bounds the number of commits listed for a push.
+const maxEventCommits = 5
+
+// ActivityFeed is the output type of the list_repo_events, list_user_events and list_org_events tools.
+type ActivityFeed struct {
+	Events []ActivityEvent `json:"events"`
+	// Omitted is the number of events of other kinds, such as comments, that were left out.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// ActivityEvent is an event of the events API with only the fields of interest for its kind.
+type ActivityEvent struct {
+	// Kind is push, pull_request_opened, pull_request_merged, pull_request_closed, issue_opened,
+	// issue_closed, issue_reopened, release_published, fork or star.
+	Kind       string `json:"kind"`
+	Actor      string `json:"actor"`
+	Repository string `json:"repository"`
+	CreatedAt  string `json:"created_at"`
+
+	// Branch and Commits are set for pushes. Commits only lists the latest few commits.
+	Branch  string        `json:"branch,omitempty"`
+	Size    int           `json:"size,omitempty"`
+	Commits []EventCommit `json:"commits,omitempty"`
+	// Number and Title are set for pull requests and issues.
+	Number int    `json:"number,omitempty"`
+	Title  string `json:"title,omitempty"`
+	// Tag is set for releases.
+	Tag string `json:"tag,omitempty"`
+	// Fork is the repository created by a fork.
+	Fork string `json:"fork,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// EventCommit is a commit of a push, with the first line of its message.
+type EventCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+}
+
+// normalizeEvent extracts the fields of interest from an event, and reports false for events of
+// other kinds or with a payload that could not be parsed.
+func normalizeEvent(event *github.Event) (ActivityEvent, bool) {
+	result := ActivityEvent{
+		Actor:      event.GetActor().GetLogin(),
+		Repository: event.GetRepo().GetName(),
+	}
+	if event.CreatedAt != nil {
+		result.CreatedAt = event.CreatedAt.Format(time.RFC3339)
+	}
+
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return result, false
+	}
+	switch payload := payload.(type) {
+	case *github.PushEvent:
+		result.Kind = "push"
+		result.Branch = strings.TrimPrefix(payload.GetRef(), "refs/heads/")
+		result.Size = payload.GetSize()
+		commits := payload.Commits
+		if len(commits) > maxEventCommits {
+			commits = commits[len(commits)-maxEventCommits:]
+		}
+		for _, commit := range commits {
+			message, _, _ := strings.Cut(commit.GetMessage(), "\n")
+			result.Commits = append(result.Commits, EventCommit{SHA: commit.GetSHA(), Message: message})
+		}
+	case *github.PullRequestEvent:
+		switch {
+		case payload.GetAction() == "opened":
+			result.Kind = "pull_request_opened"
+		case payload.GetAction() == "closed" && payload.GetPullRequest().GetMerged():
+			result.Kind = "pull_request_merged"
+		case payload.GetAction() == "closed":
+			result.Kind = "pull_request_closed"
+		default:
+			return result, false
+		}
+		result.Number = payload.GetPullRequest().GetNumber()
+		result.Title = payload.GetPullRequest().GetTitle()
+		result.URL = payload.GetPullRequest().GetHTMLURL()
+	case *github.IssuesEvent:
+		switch payload.GetAction() {
+		case "opened", "closed", "reopened":
+			result.Kind = "issue_" + payload.GetAction()
+		default:
+			return result, false
+		}
+		result.Number = payload.GetIssue().GetNumber()
+		result.Title = payload.GetIssue().GetTitle()
+		result.URL = payload.GetIssue().GetHTMLURL()
+	case *github.ReleaseEvent:
+		if payload.GetAction() != "published" {
+			return result, false
+		}
+		result.Kind = "release_published"
+		result.Tag = payload.GetRelease().GetTagName()
+		result.Title = payload.GetRelease().GetName()
+		result.URL = payload.GetRelease().GetHTMLURL()
+	case *github.ForkEvent:
+		result.Kind = "fork"
+		result.Fork = payload.GetForkee().GetFullName()
+		result.URL = payload.GetForkee().GetHTMLURL()
+	case *github.WatchEvent:
+		// Watch events are sent when a repository is starred
+		result.Kind = "star"
+	default:
+		return result, false
+	}
+	return result, true
+}
+
+// normalizeEvents converts events to an activity feed, leaving out events of other kinds.
+func normalizeEvents(events []*github.Event) ActivityFeed {
+	feed := ActivityFeed{Events: []ActivityEvent{}}
+	for _, event := range events {
+		if normalized, ok := normalizeEvent(event); ok {
+			feed.Events = append(feed.Events, normalized)
+		} else {
+			feed.Omitted++
+		}
+	}
+	return feed
+}
+
+// activityFeedDescription is the part of the descriptions of the event tools that describes the feed.
+const activityFeedDescription = "Returns pushes, pull requests opened, merged or closed, issues opened, closed or reopened, releases published, forks and stars, newest first, with their actor and time; other events are counted as omitted. GitHub only keeps events of the last 90 days."
+
+// ListRepoEvents creates a tool to list the recent activity in a repository.
+func ListRepoEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_events",
+			mcp.WithDescription(t("TOOL_LIST_REPO_EVENTS_DESCRIPTION", "List the recent activity in a GitHub repository. "+activityFeedDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPO_EVENTS_USER_TITLE", "List repository activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			events, resp, err := client.Activity.ListRepositoryEvents(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repository events", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(normalizeEvents(events)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// ListUserEvents creates a tool to list the recent activity of a user.
+func ListUserEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_events",
+			mcp.WithDescription(t("TOOL_LIST_USER_EVENTS_DESCRIPTION", "List the recent activity of a GitHub user, including private events if it is the authenticated user. "+activityFeedDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_EVENTS_USER_TITLE", "List user activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			events, resp, err := client.Activity.ListEventsPerformedByUser(ctx, username, false, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list user events", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(normalizeEvents(events)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// ListOrgEvents creates a tool to list the recent public activity in an organization.
+func ListOrgEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_events",
+			mcp.WithDescription(t("TOOL_LIST_ORG_EVENTS_DESCRIPTION", "List the recent public activity in the repositories of a GitHub organization. "+activityFeedDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_EVENTS_USER_TITLE", "List organization activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			events, resp, err := client.Activity.ListEventsForOrganization(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list organization events", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(normalizeEvents(events)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
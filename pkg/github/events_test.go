@@ -0,0 +1,308 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadEventFixture reads an event of the events API from testdata/events.
+func loadEventFixture(t *testing.T, name string) *github.Event {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "events", name))
+	require.NoError(t, err)
+	var event github.Event
+	require.NoError(t, json.Unmarshal(content, &event))
+	return &event
+}
+
+func Test_NormalizeEvent(t *testing.T) {
+	// Each fixture in testdata/events is normalized and must keep exactly the expected fields.
+	tests := []struct {
+		fixture  string
+		expected ActivityEvent
+		omitted  bool
+	}{
+		{
+			fixture: "push.json",
+			expected: ActivityEvent{
+				Kind:       "push",
+				Actor:      "octocat",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T10:00:00Z",
+				Branch:     "main",
+				Size:       7,
+				Commits: []EventCommit{
+					{SHA: "a3", Message: "Third commit"},
+					{SHA: "a4", Message: "Fourth commit"},
+					{SHA: "a5", Message: "Fifth commit"},
+					{SHA: "a6", Message: "Sixth commit"},
+					{SHA: "a7", Message: "Seventh commit"},
+				},
+			},
+		},
+		{
+			fixture: "pull_request_opened.json",
+			expected: ActivityEvent{
+				Kind:       "pull_request_opened",
+				Actor:      "octocat",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T11:00:00Z",
+				Number:     42,
+				Title:      "Add a greeting",
+				URL:        "https://github.com/octo-org/hello-world/pull/42",
+			},
+		},
+		{
+			fixture: "pull_request_merged.json",
+			expected: ActivityEvent{
+				Kind:       "pull_request_merged",
+				Actor:      "hubot",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T12:00:00Z",
+				Number:     42,
+				Title:      "Add a greeting",
+				URL:        "https://github.com/octo-org/hello-world/pull/42",
+			},
+		},
+		{
+			fixture: "pull_request_closed.json",
+			expected: ActivityEvent{
+				Kind:       "pull_request_closed",
+				Actor:      "hubot",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T13:00:00Z",
+				Number:     43,
+				Title:      "Remove the greeting",
+				URL:        "https://github.com/octo-org/hello-world/pull/43",
+			},
+		},
+		{
+			fixture: "issue_opened.json",
+			expected: ActivityEvent{
+				Kind:       "issue_opened",
+				Actor:      "octocat",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T14:00:00Z",
+				Number:     7,
+				Title:      "The greeting is too short",
+				URL:        "https://github.com/octo-org/hello-world/issues/7",
+			},
+		},
+		{
+			fixture: "issue_closed.json",
+			expected: ActivityEvent{
+				Kind:       "issue_closed",
+				Actor:      "hubot",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T15:00:00Z",
+				Number:     7,
+				Title:      "The greeting is too short",
+				URL:        "https://github.com/octo-org/hello-world/issues/7",
+			},
+		},
+		{
+			fixture: "release_published.json",
+			expected: ActivityEvent{
+				Kind:       "release_published",
+				Actor:      "hubot",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T16:00:00Z",
+				Tag:        "v1.0.0",
+				Title:      "Version 1.0.0",
+				URL:        "https://github.com/octo-org/hello-world/releases/tag/v1.0.0",
+			},
+		},
+		{
+			fixture: "fork.json",
+			expected: ActivityEvent{
+				Kind:       "fork",
+				Actor:      "monalisa",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T17:00:00Z",
+				Fork:       "monalisa/hello-world",
+				URL:        "https://github.com/monalisa/hello-world",
+			},
+		},
+		{
+			fixture: "star.json",
+			expected: ActivityEvent{
+				Kind:       "star",
+				Actor:      "monalisa",
+				Repository: "octo-org/hello-world",
+				CreatedAt:  "2025-06-01T18:00:00Z",
+			},
+		},
+		{
+			fixture: "issue_comment.json",
+			omitted: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.fixture, func(t *testing.T) {
+			normalized, ok := normalizeEvent(loadEventFixture(t, tc.fixture))
+			if tc.omitted {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
+
+func Test_ListRepoEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repo_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	events := []*github.Event{
+		loadEventFixture(t, "star.json"),
+		loadEventFixture(t, "issue_comment.json"),
+		loadEventFixture(t, "fork.json"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       ActivityFeed
+	}{
+		{
+			name: "lists the feed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					expect(t, expectations{
+						path:        "/repos/octo-org/hello-world/events",
+						queryParams: map[string]string{"page": "2", "per_page": "3"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, events),
+					),
+				),
+			),
+			args: map[string]any{"owner": "octo-org", "repo": "hello-world", "page": float64(2), "perPage": float64(3)},
+			expected: ActivityFeed{
+				Events: []ActivityEvent{
+					{Kind: "star", Actor: "monalisa", Repository: "octo-org/hello-world", CreatedAt: "2025-06-01T18:00:00Z"},
+					{Kind: "fork", Actor: "monalisa", Repository: "octo-org/hello-world", CreatedAt: "2025-06-01T17:00:00Z", Fork: "monalisa/hello-world", URL: "https://github.com/monalisa/hello-world"},
+				},
+				Omitted: 1,
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			args:           map[string]any{"owner": "octo-org", "repo": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository events",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var feed ActivityFeed
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &feed))
+			assert.Equal(t, tc.expected, feed)
+		})
+	}
+}
+
+func Test_ListUserEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUsersEventsByUsername,
+			expectPath(t, "/users/octocat/events").andThen(
+				mockResponse(t, http.StatusOK, []*github.Event{loadEventFixture(t, "pull_request_opened.json")}),
+			),
+		),
+	)
+	_, handler := ListUserEvents(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"username": "octocat"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var feed ActivityFeed
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &feed))
+	require.Len(t, feed.Events, 1)
+	assert.Equal(t, "pull_request_opened", feed.Events[0].Kind)
+	assert.Equal(t, 42, feed.Events[0].Number)
+	assert.Zero(t, feed.Omitted)
+}
+
+func Test_ListOrgEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsEventsByOrg,
+			expectPath(t, "/orgs/octo-org/events").andThen(
+				mockResponse(t, http.StatusOK, []*github.Event{loadEventFixture(t, "release_published.json")}),
+			),
+		),
+	)
+	_, handler := ListOrgEvents(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var feed ActivityFeed
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &feed))
+	require.Len(t, feed.Events, 1)
+	assert.Equal(t, "release_published", feed.Events[0].Kind)
+	assert.Equal(t, "v1.0.0", feed.Events[0].Tag)
+}
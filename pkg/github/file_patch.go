@@ -0,0 +1,269 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxPatchFuzz is how many lines away from the line in its header a hunk of a unified diff is
+// searched for, in case the lines before it changed since the diff was made.
+const maxPatchFuzz = 100
+
+// PatchConflict is returned when a hunk of a unified diff does not match the file it is applied to.
+type PatchConflict struct {
+	// Hunk is the number of the hunk, starting at 1.
+	Hunk int
+	// Line is the line of the file the hunk was expected at, starting at 1.
+	Line int
+	// Expected are the context and removed lines of the hunk.
+	Expected []string
+	// Actual are the lines of the file at Line.
+	Actual []string
+}
+
+func (c *PatchConflict) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "hunk %d does not apply at line %d, the file has changed or the diff is wrong. The hunk expects:\n", c.Hunk, c.Line)
+	for _, line := range c.Expected {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+	if len(c.Actual) == 0 {
+		fmt.Fprintf(&b, "but the file ends before line %d\n", c.Line)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "but lines %d to %d of the file are:\n", c.Line, c.Line+len(c.Actual)-1)
+	for _, line := range c.Actual {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+	return b.String()
+}
+
+// fileLines is the content of a text file split into lines without their line endings.
+type fileLines struct {
+	lines []string
+	// finalNewline reports whether the last line ends with a newline.
+	finalNewline bool
+}
+
+func splitFileLines(content string) fileLines {
+	if content == "" {
+		return fileLines{finalNewline: true}
+	}
+	trimmed := strings.TrimSuffix(content, "\n")
+	return fileLines{lines: strings.Split(trimmed, "\n"), finalNewline: trimmed != content}
+}
+
+func (f fileLines) String() string {
+	if len(f.lines) == 0 {
+		return ""
+	}
+	s := strings.Join(f.lines, "\n")
+	if f.finalNewline {
+		s += "\n"
+	}
+	return s
+}
+
+// appendContent adds text to the end of content, on a line of its own.
+func appendContent(content, text string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content + text
+}
+
+// prependContent adds text to the start of content, on a line of its own.
+func prependContent(content, text string) string {
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text + content
+}
+
+// replaceLineRange replaces the lines from start to end of content, starting at 1 and inclusive,
+// with the lines of text. An empty text deletes the lines.
+func replaceLineRange(content string, start, end int, text string) (string, error) {
+	file := splitFileLines(content)
+	if start < 1 || end < start || end > len(file.lines) {
+		return "", fmt.Errorf("invalid line range %d to %d, the file has %d lines", start, end, len(file.lines))
+	}
+	var replacement []string
+	if text != "" {
+		replacement = splitFileLines(text).lines
+	}
+	lines := make([]string, 0, len(file.lines)-(end-start+1)+len(replacement))
+	lines = append(lines, file.lines[:start-1]...)
+	lines = append(lines, replacement...)
+	lines = append(lines, file.lines[end:]...)
+	file.lines = lines
+	return file.String(), nil
+}
+
+// patchHunk is a hunk of a unified diff.
+type patchHunk struct {
+	oldStart int
+	// old are the context and removed lines, and new the context and added lines.
+	old, new []string
+	// oldNoNewline and newNoNewline report whether the last line of the old or new side has no
+	// newline, which is only the case at the end of the file.
+	oldNoNewline, newNoNewline bool
+}
+
+// parsePatchHunks parses the hunks of a unified diff of a single file. The file headers are
+// optional.
+func parsePatchHunks(diff string) ([]patchHunk, error) {
+	var hunks []patchHunk
+	var hunk *patchHunk
+	oldLeft, newLeft := 0, 0
+	files := 0
+	// lastSide is the side of the last line of the hunk, for "\ No newline at end of file"
+	lastSide := byte(0)
+	for i, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			if line == "" {
+				// Some editors strip the space of empty context lines
+				line = " "
+			}
+			switch line[0] {
+			case ' ':
+				hunk.old = append(hunk.old, line[1:])
+				hunk.new = append(hunk.new, line[1:])
+				oldLeft--
+				newLeft--
+			case '-':
+				hunk.old = append(hunk.old, line[1:])
+				oldLeft--
+			case '+':
+				hunk.new = append(hunk.new, line[1:])
+				newLeft--
+			case '\\':
+				markNoNewline(hunk, lastSide)
+				continue
+			default:
+				return nil, fmt.Errorf("line %d of the diff is not a context, removed or added line: %q", i+1, line)
+			}
+			if oldLeft < 0 || newLeft < 0 {
+				return nil, fmt.Errorf("hunk %d has more lines than its header says", len(hunks))
+			}
+			lastSide = line[0]
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			oldStart, oldLines, _, newLines, ok := parseHunkHeader(line)
+			if !ok {
+				return nil, fmt.Errorf("line %d of the diff is not a valid hunk header: %q", i+1, line)
+			}
+			hunks = append(hunks, patchHunk{oldStart: oldStart})
+			hunk = &hunks[len(hunks)-1]
+			oldLeft, newLeft = oldLines, newLines
+		case strings.HasPrefix(line, `\`) && hunk != nil:
+			markNoNewline(hunk, lastSide)
+		case strings.HasPrefix(line, "--- "):
+			files++
+			if files > 1 {
+				return nil, errors.New("the diff changes more than one file, only diffs of the file being updated can be applied")
+			}
+		case strings.HasPrefix(line, "diff ") || strings.HasPrefix(line, "index ") || strings.HasPrefix(line, "+++ "):
+			// File headers
+		case hunk != nil && strings.TrimSpace(line) != "":
+			return nil, fmt.Errorf("hunk %d has more lines than its header says", len(hunks))
+		}
+	}
+	if len(hunks) == 0 {
+		return nil, errors.New("the diff has no hunks")
+	}
+	if oldLeft > 0 || newLeft > 0 {
+		return nil, fmt.Errorf("hunk %d has fewer lines than its header says", len(hunks))
+	}
+	return hunks, nil
+}
+
+func markNoNewline(hunk *patchHunk, side byte) {
+	switch side {
+	case '-':
+		hunk.oldNoNewline = true
+	case '+':
+		hunk.newNoNewline = true
+	case ' ':
+		hunk.oldNoNewline, hunk.newNoNewline = true, true
+	}
+}
+
+// applyUnifiedDiff applies a unified diff of a single file to content. Hunks are searched for
+// near the line in their header, so that a diff still applies if lines before it were added or
+// removed since. A hunk that is not found returns a *PatchConflict.
+func applyUnifiedDiff(content, diff string) (string, error) {
+	hunks, err := parsePatchHunks(diff)
+	if err != nil {
+		return "", err
+	}
+	file := splitFileLines(content)
+	result := fileLines{finalNewline: file.finalNewline}
+	// next is the first line of the file not yet copied to result, and drift how far the hunks
+	// were found from the lines in their header
+	next, drift := 0, 0
+	for i, hunk := range hunks {
+		start := hunk.oldStart - 1
+		if len(hunk.old) == 0 {
+			// Hunks that only add lines start after the line in their header
+			start++
+		}
+		expected := start + drift
+		at, ok := findHunk(file.lines, hunk.old, expected, next)
+		if !ok {
+			line := max(min(expected, len(file.lines)), next)
+			return "", &PatchConflict{
+				Hunk:     i + 1,
+				Line:     line + 1,
+				Expected: hunk.old,
+				Actual:   file.lines[line:min(line+max(len(hunk.old), 1), len(file.lines))],
+			}
+		}
+		result.lines = append(result.lines, file.lines[next:at]...)
+		result.lines = append(result.lines, hunk.new...)
+		next = at + len(hunk.old)
+		drift = at - start
+		if next == len(file.lines) {
+			switch {
+			case hunk.newNoNewline:
+				result.finalNewline = false
+			case hunk.oldNoNewline:
+				result.finalNewline = true
+			}
+		}
+	}
+	result.lines = append(result.lines, file.lines[next:]...)
+	return result.String(), nil
+}
+
+// findHunk returns where the old lines of a hunk are in lines, at or after from, searching
+// outwards from expected.
+func findHunk(lines, old []string, expected, from int) (int, bool) {
+	last := len(lines) - len(old)
+	for fuzz := 0; fuzz <= maxPatchFuzz; fuzz++ {
+		for _, at := range []int{expected + fuzz, expected - fuzz} {
+			if at < from || at > last {
+				continue
+			}
+			if matchLines(lines[at:at+len(old)], old) {
+				return at, true
+			}
+			if fuzz == 0 {
+				break
+			}
+		}
+	}
+	return 0, false
+}
+
+func matchLines(lines, want []string) bool {
+	for i := range want {
+		if strings.TrimSuffix(lines[i], "\r") != strings.TrimSuffix(want[i], "\r") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AppendPrependContent(t *testing.T) {
+	assert.Equal(t, "a\nb\n", appendContent("a\n", "b\n"))
+	assert.Equal(t, "a\nb", appendContent("a", "b"))
+	assert.Equal(t, "b\n", appendContent("", "b\n"))
+
+	assert.Equal(t, "b\na\n", prependContent("a\n", "b\n"))
+	assert.Equal(t, "b\na", prependContent("a", "b"))
+	assert.Equal(t, "a\n", prependContent("a\n", ""))
+}
+
+func Test_ReplaceLineRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		start, end  int
+		text        string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "replace middle lines",
+			content:  "one\ntwo\nthree\nfour\n",
+			start:    2,
+			end:      3,
+			text:     "2\n",
+			expected: "one\n2\nfour\n",
+		},
+		{
+			name:     "replace with more lines",
+			content:  "one\ntwo\n",
+			start:    1,
+			end:      1,
+			text:     "a\nb",
+			expected: "a\nb\ntwo\n",
+		},
+		{
+			name:     "delete lines",
+			content:  "one\ntwo\nthree\n",
+			start:    1,
+			end:      2,
+			text:     "",
+			expected: "three\n",
+		},
+		{
+			name:     "keep missing final newline",
+			content:  "one\ntwo",
+			start:    2,
+			end:      2,
+			text:     "2\n",
+			expected: "one\n2",
+		},
+		{
+			name:        "range past the end",
+			content:     "one\ntwo\n",
+			start:       2,
+			end:         3,
+			expectedErr: "invalid line range 2 to 3, the file has 2 lines",
+		},
+		{
+			name:        "end before start",
+			content:     "one\ntwo\n",
+			start:       2,
+			end:         1,
+			expectedErr: "invalid line range 2 to 1, the file has 2 lines",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := replaceLineRange(tc.content, tc.start, tc.end, tc.text)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func Test_ApplyUnifiedDiff(t *testing.T) {
+	content := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n"
+
+	tests := []struct {
+		name        string
+		content     string
+		diff        string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:    "diff with file headers",
+			content: content,
+			diff: "diff --git a/main.go b/main.go\n" +
+				"index 1234567..89abcde 100644\n" +
+				"--- a/main.go\n" +
+				"+++ b/main.go\n" +
+				"@@ -5,3 +5,4 @@\n" +
+				" func main() {\n" +
+				"-\tfmt.Println(\"hello\")\n" +
+				"+\tfmt.Println(\"hello\")\n" +
+				"+\tfmt.Println(\"world\")\n" +
+				" }\n",
+			expected: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n\tfmt.Println(\"world\")\n}\n",
+		},
+		{
+			name:    "several hunks",
+			content: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			diff: "@@ -1,2 +1,2 @@\n" +
+				"-1\n" +
+				"+one\n" +
+				" 2\n" +
+				"@@ -8,2 +8,3 @@\n" +
+				" 8\n" +
+				"-9\n" +
+				"+nine\n" +
+				"+ten\n",
+			expected: "one\n2\n3\n4\n5\n6\n7\n8\nnine\nten\n",
+		},
+		{
+			name:    "hunk moved by lines added before it",
+			content: "new\nlines\n1\n2\n3\n",
+			diff: "@@ -2,2 +2,2 @@\n" +
+				" 2\n" +
+				"-3\n" +
+				"+three\n",
+			expected: "new\nlines\n1\n2\nthree\n",
+		},
+		{
+			name:     "add lines to an empty file",
+			content:  "",
+			diff:     "--- /dev/null\n+++ b/a.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:    "remove final newline",
+			content: "a\nb\n",
+			diff: "@@ -1,2 +1,2 @@\n" +
+				" a\n" +
+				"-b\n" +
+				"+b\n" +
+				"\\ No newline at end of file\n",
+			expected: "a\nb",
+		},
+		{
+			name:    "add final newline",
+			content: "a\nb",
+			diff: "@@ -1,2 +1,2 @@\n" +
+				" a\n" +
+				"-b\n" +
+				"\\ No newline at end of file\n" +
+				"+b\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:    "context mismatch",
+			content: content,
+			diff: "@@ -5,3 +5,3 @@\n" +
+				" func main() {\n" +
+				"-\tfmt.Println(\"goodbye\")\n" +
+				"+\tfmt.Println(\"hi\")\n" +
+				" }\n",
+			expectedErr: "hunk 1 does not apply at line 5, the file has changed or the diff is wrong. The hunk expects:\n" +
+				"  func main() {\n" +
+				"  \tfmt.Println(\"goodbye\")\n" +
+				"  }\n" +
+				"but lines 5 to 7 of the file are:\n" +
+				"  func main() {\n" +
+				"  \tfmt.Println(\"hello\")\n" +
+				"  }\n",
+		},
+		{
+			name:        "hunk past the end of the file",
+			content:     "a\n",
+			diff:        "@@ -3,1 +3,1 @@\n-c\n+C\n",
+			expectedErr: "hunk 1 does not apply at line 2, the file has changed or the diff is wrong. The hunk expects:\n  c\nbut the file ends before line 2\n",
+		},
+		{
+			name:        "more than one file",
+			content:     content,
+			diff:        "--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-a\n+b\n--- a/b.txt\n+++ b/b.txt\n@@ -1 +1 @@\n-a\n+b\n",
+			expectedErr: "the diff changes more than one file, only diffs of the file being updated can be applied",
+		},
+		{
+			name:        "no hunks",
+			content:     content,
+			diff:        "--- a/a.txt\n+++ b/a.txt\n",
+			expectedErr: "the diff has no hunks",
+		},
+		{
+			name:        "hunk shorter than its header",
+			content:     content,
+			diff:        "@@ -1,3 +1,3 @@\n-a\n+b\n",
+			expectedErr: "hunk 1 has fewer lines than its header says",
+		},
+		{
+			name:        "hunk longer than its header",
+			content:     content,
+			diff:        "@@ -1 +1 @@\n-a\n+b\n+c\n",
+			expectedErr: "hunk 1 has more lines than its header says",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := applyUnifiedDiff(tc.content, tc.diff)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("conflicts are returned as PatchConflict", func(t *testing.T) {
+		_, err := applyUnifiedDiff("a\nb\n", "@@ -1,2 +1,2 @@\n a\n-c\n+d\n")
+		var conflict *PatchConflict
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, 1, conflict.Line)
+		assert.Equal(t, []string{"a", "c"}, conflict.Expected)
+		assert.Equal(t, []string{"a", "b"}, conflict.Actual)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -85,7 +86,7 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -153,7 +154,7 @@ func CreateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 
 			createdGist, resp, err := client.Gists.Create(ctx, gist)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create gist: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create gist", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -237,7 +238,7 @@ func UpdateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 
 			updatedGist, resp, err := client.Gists.Edit(ctx, gistID, gist)
 			if err != nil {
-				return nil, fmt.Errorf("failed to update gist: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update gist", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -0,0 +1,349 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GitBlob is the output type of the get_git_blob tool.
+type GitBlob struct {
+	SHA  string `json:"sha"`
+	Size int    `json:"size"`
+	// Encoding is the encoding of Content, utf-8 or base64.
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GetGitBlob creates a tool to get a Git blob of a repository by its SHA.
+func GetGitBlob(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_blob",
+			mcp.WithDescription(t("TOOL_GET_GIT_BLOB_DESCRIPTION", "Get the content of a Git blob in a GitHub repository by its SHA, such as a file entry of get_git_tree")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_BLOB_USER_TITLE", "Get Git blob"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the blob"),
+			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding to return the content in. Content that is not valid UTF-8 is always returned as base64"),
+				mcp.Enum("utf-8", "base64"),
+				mcp.DefaultString("utf-8"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if encoding == "" {
+				encoding = "utf-8"
+			}
+			if encoding != "utf-8" && encoding != "base64" {
+				return mcp.NewToolResultError("encoding must be utf-8 or base64"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			blob, resp, err := client.Git.GetBlob(ctx, owner, repo, sha)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get blob",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			content := blob.GetContent()
+			if blob.GetEncoding() == "base64" {
+				// The API wraps base64 content in lines
+				decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content, "\n", ""))
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode blob content: %w", err)
+				}
+				content = string(decoded)
+			}
+
+			result := GitBlob{
+				SHA:      blob.GetSHA(),
+				Size:     blob.GetSize(),
+				Encoding: "utf-8",
+				Content:  content,
+			}
+			if encoding == "base64" || !utf8.ValidString(content) {
+				result.Encoding = "base64"
+				result.Content = base64.StdEncoding.EncodeToString([]byte(content))
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// GetGitTree creates a tool to get a Git tree of a repository.
+func GetGitTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_tree",
+			mcp.WithDescription(t("TOOL_GET_GIT_TREE_DESCRIPTION", "Get a Git tree in a GitHub repository, listing the path, mode, type, SHA and size of its entries. Recursive trees list the entries of all subtrees, and are truncated by GitHub if they are too large")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_TREE_USER_TITLE", "Get Git tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tree_sha",
+				mcp.Required(),
+				mcp.Description("SHA of the tree, or a branch or tag name to get the root tree of"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("List the entries of subtrees too"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			treeSHA, err := RequiredParam[string](request, "tree_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			recursive, err := OptionalParam[bool](request, "recursive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, treeSHA, recursive)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(tree), nil
+		}
+}
+
+// GetGitCommit creates a tool to get a Git commit object of a repository by its SHA.
+func GetGitCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_commit",
+			mcp.WithDescription(t("TOOL_GET_GIT_COMMIT_DESCRIPTION", "Get a Git commit object in a GitHub repository by its SHA: its tree and parent SHAs, author, committer, message and signature verification. Unlike get_commit, it does not include the changed files")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_COMMIT_USER_TITLE", "Get Git commit"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commit, resp, err := client.Git.GetCommit(ctx, owner, repo, sha)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(commit), nil
+		}
+}
+
+// GetGitRef creates a tool to get a Git reference of a repository.
+func GetGitRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_ref",
+			mcp.WithDescription(t("TOOL_GET_GIT_REF_DESCRIPTION", "Get a Git reference in a GitHub repository, such as heads/main or tags/v1.0.0, with the type and SHA of the object it points to")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_REF_USER_TITLE", "Get Git reference"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Fully qualified reference, such as heads/main or tags/v1.0.0. The refs/ prefix is optional"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.GetRef(ctx, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(reference), nil
+		}
+}
+
+// ListGitRefs creates a tool to list the Git references of a repository.
+func ListGitRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_git_refs",
+			mcp.WithDescription(t("TOOL_LIST_GIT_REFS_DESCRIPTION", "List the Git references in a GitHub repository, optionally only those starting with a prefix such as heads/ or tags/v1.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_GIT_REFS_USER_TITLE", "List Git references"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Only list references starting with this prefix, such as heads/ or tags/. Lists all references if empty"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ReferenceListOptions{
+				Ref: namespace,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			references, resp, err := client.Git.ListMatchingRefs(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list references",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(references), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
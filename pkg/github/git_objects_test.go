@@ -0,0 +1,343 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetGitBlob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitBlob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_blob", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "encoding")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	blobResponse := func(content []byte) http.HandlerFunc {
+		// The API wraps base64 content in lines
+		encoded := base64.StdEncoding.EncodeToString(content)
+		wrapped := ""
+		for len(encoded) > 8 {
+			wrapped += encoded[:8] + "\n"
+			encoded = encoded[8:]
+		}
+		return expectPath(t, "/repos/owner/repo/git/blobs/abc123").andThen(
+			mockResponse(t, http.StatusOK, &github.Blob{
+				SHA:      github.Ptr("abc123"),
+				Size:     github.Ptr(len(content)),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(wrapped + encoded),
+			}),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       GitBlob
+	}{
+		{
+			name: "text content as utf-8",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitBlobsByOwnerByRepoByFileSha, blobResponse([]byte("# Project\n\nHello, world\n"))),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			expected: GitBlob{SHA: "abc123", Size: 24, Encoding: "utf-8", Content: "# Project\n\nHello, world\n"},
+		},
+		{
+			name: "text content as base64",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitBlobsByOwnerByRepoByFileSha, blobResponse([]byte("hello"))),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"sha":      "abc123",
+				"encoding": "base64",
+			},
+			expected: GitBlob{SHA: "abc123", Size: 5, Encoding: "base64", Content: "aGVsbG8="},
+		},
+		{
+			name: "binary content falls back to base64",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitBlobsByOwnerByRepoByFileSha, blobResponse([]byte{0x89, 'P', 'N', 'G', 0xff})),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			expected: GitBlob{SHA: "abc123", Size: 5, Encoding: "base64", Content: base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G', 0xff})},
+		},
+		{
+			name: "blob not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get blob",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitBlob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned GitBlob
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_GetGitTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_tree", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "tree_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "recursive")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tree_sha"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			expect(t, expectations{
+				path:        "/repos/owner/repo/git/trees/main",
+				queryParams: map[string]string{"recursive": "1"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Tree{
+					SHA: github.Ptr("tree1"),
+					Entries: []*github.TreeEntry{
+						{Path: github.Ptr("src"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("tree2")},
+						{Path: github.Ptr("src/main.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("blob1"), Size: github.Ptr(42)},
+					},
+					Truncated: github.Ptr(false),
+				}),
+			),
+		),
+	))
+	_, handler := GetGitTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"tree_sha":  "main",
+		"recursive": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.Tree
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "tree1", returned.GetSHA())
+	require.Len(t, returned.Entries, 2)
+	assert.Equal(t, "src/main.go", returned.Entries[1].GetPath())
+	assert.Equal(t, 42, returned.Entries[1].GetSize())
+}
+
+func Test_GetGitCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_commit", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+			expectPath(t, "/repos/owner/repo/git/commits/c0ffee").andThen(
+				mockResponse(t, http.StatusOK, &github.Commit{
+					SHA:       github.Ptr("c0ffee"),
+					Message:   github.Ptr("Fix upload retries"),
+					Author:    &github.CommitAuthor{Name: github.Ptr("Mona"), Email: github.Ptr("mona@example.com")},
+					Committer: &github.CommitAuthor{Name: github.Ptr("GitHub"), Email: github.Ptr("noreply@github.com")},
+					Tree:      &github.Tree{SHA: github.Ptr("tree1")},
+					Parents:   []*github.Commit{{SHA: github.Ptr("p1")}, {SHA: github.Ptr("p2")}},
+					Verification: &github.SignatureVerification{
+						Verified: github.Ptr(true),
+						Reason:   github.Ptr("valid"),
+					},
+				}),
+			),
+		),
+	))
+	_, handler := GetGitCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"sha":   "c0ffee",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.Commit
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "Fix upload retries", returned.GetMessage())
+	assert.Equal(t, "tree1", returned.GetTree().GetSHA())
+	require.Len(t, returned.Parents, 2)
+	assert.Equal(t, "p2", returned.Parents[1].GetSHA())
+	assert.True(t, returned.GetVerification().GetVerified())
+}
+
+func Test_GetGitRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_ref", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		ref            string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "ref with refs/ prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/main"),
+							Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr("c0ffee")},
+						}),
+					),
+				),
+			),
+			ref: "refs/heads/main",
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			ref:            "heads/missing",
+			expectError:    true,
+			expectedErrMsg: "failed to get reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   tc.ref,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "refs/heads/main", returned.GetRef())
+			assert.Equal(t, "c0ffee", returned.GetObject().GetSHA())
+		})
+	}
+}
+
+func Test_ListGitRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListGitRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_git_refs", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "namespace")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			// The mocked pattern does not match refs with a slash
+			mock.EndpointPattern{Pattern: "/repos/owner/repo/git/matching-refs/tags/v1", Method: "GET"},
+			expect(t, expectations{
+				path:        "/repos/owner/repo/git/matching-refs/tags/v1",
+				queryParams: map[string]string{"page": "2", "per_page": "5"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Reference{
+					{Ref: github.Ptr("refs/tags/v1.0.0"), Object: &github.GitObject{Type: github.Ptr("tag"), SHA: github.Ptr("t1")}},
+					{Ref: github.Ptr("refs/tags/v1.1.0"), Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr("c2")}},
+				}),
+			),
+		),
+	))
+	_, handler := ListGitRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"namespace": "tags/v1",
+		"page":      float64(2),
+		"perPage":   float64(5),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.Reference
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, "refs/tags/v1.1.0", returned[1].GetRef())
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	// githubStatusURL is the base URL of the API of the GitHub status page.
+	githubStatusURL = "https://www.githubstatus.com/api/v2/"
+	// githubStatusClient makes the requests to the GitHub status page, which does not take GitHub tokens.
+	githubStatusClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// githubStatusLevels maps the indicators of the status page to the overall status reported by the tool.
+var githubStatusLevels = map[string]string{
+	"none":     "good",
+	"minor":    "minor",
+	"major":    "major",
+	"critical": "critical",
+}
+
+// GitHubStatus is the output type of the get_github_status tool.
+type GitHubStatus struct {
+	// Status is the overall status, one of good, minor, major or critical.
+	Status      string                  `json:"status"`
+	Description string                  `json:"description"`
+	Incidents   []GitHubStatusIncident  `json:"incidents"`
+	Components  []GitHubStatusComponent `json:"components,omitempty"`
+	Warnings    []string                `json:"warnings,omitempty"`
+}
+
+// GitHubStatusIncident is an ongoing incident on the GitHub status page.
+type GitHubStatusIncident struct {
+	Name      string                       `json:"name"`
+	Status    string                       `json:"status"`
+	Impact    string                       `json:"impact"`
+	URL       string                       `json:"url,omitempty"`
+	CreatedAt string                       `json:"created_at"`
+	Updates   []GitHubStatusIncidentUpdate `json:"updates,omitempty"`
+}
+
+// GitHubStatusIncidentUpdate is an update posted about an incident, newest first.
+type GitHubStatusIncidentUpdate struct {
+	Status    string `json:"status"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GitHubStatusComponent is the status of a GitHub service, such as Git Operations or Actions.
+type GitHubStatusComponent struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// getGitHubStatusPage gets a resource of the GitHub status page API into v.
+func getGitHubStatusPage(ctx context.Context, resource string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubStatusURL+resource, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := githubStatusClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", resource, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s: HTTP %d", resource, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", resource, err)
+	}
+	return nil
+}
+
+// GetGitHubStatus creates a tool to get the status of GitHub from its status page.
+func GetGitHubStatus(t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_github_status",
+			mcp.WithDescription(t("TOOL_GET_GITHUB_STATUS_DESCRIPTION", "Get the current status of GitHub from githubstatus.com: the overall status (good, minor, major or critical), the ongoing incidents with their impact and latest updates, and the status of each service such as Git Operations, API Requests and Actions. Use this to tell whether failing tool calls are caused by a GitHub incident rather than by the configuration. Only reflects github.com, not GitHub Enterprise Server.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GITHUB_STATUS_USER_TITLE", "Get GitHub status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var status struct {
+				Status struct {
+					Indicator   string `json:"indicator"`
+					Description string `json:"description"`
+				} `json:"status"`
+			}
+			var components struct {
+				Components []struct {
+					Name   string `json:"name"`
+					Status string `json:"status"`
+					Group  bool   `json:"group"`
+				} `json:"components"`
+			}
+			var incidents struct {
+				Incidents []struct {
+					Name            string                       `json:"name"`
+					Status          string                       `json:"status"`
+					Impact          string                       `json:"impact"`
+					Shortlink       string                       `json:"shortlink"`
+					CreatedAt       string                       `json:"created_at"`
+					IncidentUpdates []GitHubStatusIncidentUpdate `json:"incident_updates"`
+				} `json:"incidents"`
+			}
+
+			errs := runBounded(ctx, maxConcurrentRequests,
+				func(ctx context.Context) error { return getGitHubStatusPage(ctx, "status.json", &status) },
+				func(ctx context.Context) error { return getGitHubStatusPage(ctx, "components.json", &components) },
+				func(ctx context.Context) error {
+					return getGitHubStatusPage(ctx, "incidents/unresolved.json", &incidents)
+				},
+			)
+			if errs[0] != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub status: %s", errs[0])), nil
+			}
+
+			level, ok := githubStatusLevels[status.Status.Indicator]
+			if !ok {
+				level = status.Status.Indicator
+			}
+			result := GitHubStatus{
+				Status:      level,
+				Description: status.Status.Description,
+				Incidents:   []GitHubStatusIncident{},
+			}
+
+			if errs[1] != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("component status unavailable: %s", errs[1]))
+			}
+			for _, component := range components.Components {
+				// Groups only summarize the components they contain
+				if component.Group {
+					continue
+				}
+				result.Components = append(result.Components, GitHubStatusComponent{Name: component.Name, Status: component.Status})
+			}
+
+			if errs[2] != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("incidents unavailable: %s", errs[2]))
+			}
+			for _, incident := range incidents.Incidents {
+				result.Incidents = append(result.Incidents, GitHubStatusIncident{
+					Name:      incident.Name,
+					Status:    incident.Status,
+					Impact:    incident.Impact,
+					URL:       incident.Shortlink,
+					CreatedAt: incident.CreatedAt,
+					Updates:   incident.IncidentUpdates,
+				})
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetGitHubStatus(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetGitHubStatus(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_github_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name           string
+		responses      map[string]string
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus GitHubStatus
+	}{
+		{
+			name: "major outage with an ongoing incident",
+			responses: map[string]string{
+				"/status.json": `{"status": {"indicator": "major", "description": "Partial System Outage"}}`,
+				"/components.json": `{"components": [
+					{"name": "Git Operations", "status": "operational", "group": false},
+					{"name": "Actions", "status": "major_outage", "group": false},
+					{"name": "Services", "status": "major_outage", "group": true}
+				]}`,
+				"/incidents/unresolved.json": `{"incidents": [{
+					"name": "Disruption with some GitHub services",
+					"status": "investigating",
+					"impact": "major",
+					"shortlink": "https://stspg.io/abc",
+					"created_at": "2026-10-18T09:00:00Z",
+					"incident_updates": [{"status": "investigating", "body": "We are investigating reports of degraded performance for Actions", "created_at": "2026-10-18T09:01:00Z"}]
+				}]}`,
+			},
+			expectedStatus: GitHubStatus{
+				Status:      "major",
+				Description: "Partial System Outage",
+				Incidents: []GitHubStatusIncident{{
+					Name:      "Disruption with some GitHub services",
+					Status:    "investigating",
+					Impact:    "major",
+					URL:       "https://stspg.io/abc",
+					CreatedAt: "2026-10-18T09:00:00Z",
+					Updates: []GitHubStatusIncidentUpdate{{
+						Status:    "investigating",
+						Body:      "We are investigating reports of degraded performance for Actions",
+						CreatedAt: "2026-10-18T09:01:00Z",
+					}},
+				}},
+				Components: []GitHubStatusComponent{
+					{Name: "Git Operations", Status: "operational"},
+					{Name: "Actions", Status: "major_outage"},
+				},
+			},
+		},
+		{
+			name: "all systems operational, components unavailable",
+			responses: map[string]string{
+				"/status.json":               `{"status": {"indicator": "none", "description": "All Systems Operational"}}`,
+				"/incidents/unresolved.json": `{"incidents": []}`,
+			},
+			expectedStatus: GitHubStatus{
+				Status:      "good",
+				Description: "All Systems Operational",
+				Incidents:   []GitHubStatusIncident{},
+				Warnings:    []string{"component status unavailable: failed to get components.json: HTTP 404"},
+			},
+		},
+		{
+			name:           "status page unavailable",
+			responses:      map[string]string{},
+			expectError:    true,
+			expectedErrMsg: "failed to get GitHub status: failed to get status.json: HTTP 404",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, ok := tc.responses[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			defer ts.Close()
+
+			originalURL := githubStatusURL
+			githubStatusURL = ts.URL + "/"
+			defer func() { githubStatusURL = originalURL }()
+
+			_, handler := GetGitHubStatus(translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned GitHubStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedStatus, returned)
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package github
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGraphQLCacheEntries is the number of responses GraphQLCache keeps by default.
+	DefaultGraphQLCacheEntries = 500
+	// maxGraphQLCacheEntryBytes bounds the size of the responses GraphQLCache keeps.
+	maxGraphQLCacheEntryBytes = 1 << 20
+)
+
+// repoAPIPath matches the REST API paths of a repository and of what it contains.
+var repoAPIPath = regexp.MustCompile(`/repos/([^/]+)/([^/]+)`)
+
+// GraphQLCache keeps the responses of GraphQL queries for a short time, so that asking the same
+// question twice in a conversation costs no rate limit. Responses are kept per token, query and
+// variables, and are dropped when the token's requests may have changed what they contain. Changes
+// made with one token leave the responses kept for other tokens, and statistics are kept per MCP
+// session. It is safe for concurrent use, and a nil GraphQLCache caches nothing.
+type GraphQLCache struct {
+	// TTL is how long responses are kept, unless TTLs sets it for the first field the query selects,
+	// such as repository or search.
+	TTL  time.Duration
+	TTLs map[string]time.Duration
+
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	// generation counts invalidations, so that the response to a query sent before one of its
+	// token is not kept. invalidated is the generation of the last invalidation of each token since
+	// floor, before which all responses are treated as invalidated, so that the map stays bounded.
+	generation  uint64
+	invalidated map[string]uint64
+	floor       uint64
+	sessions    map[string]*graphQLCacheSession
+}
+
+// graphQLCacheSession is what the cache knows of an MCP session.
+type graphQLCacheSession struct {
+	// token is the hashed token of the last request of the session.
+	token string
+	stats GraphQLCacheStats
+}
+
+type graphQLCacheEntry struct {
+	key string
+	// token is the hashed token of the query.
+	token string
+	// owner and repo are the lowercase owner and repo variables of the query, if any.
+	owner   string
+	repo    string
+	expires time.Time
+	header  http.Header
+	body    []byte
+}
+
+// GraphQLCacheStats counts how well the GraphQL cache is doing for an MCP session.
+type GraphQLCacheStats struct {
+	// Entries are the responses kept for the token the session used last.
+	Entries int `json:"entries"`
+	Hits    int `json:"hits"`
+	// Misses are the cacheable queries that were sent to GitHub.
+	Misses int `json:"misses"`
+	// Invalidations are the changes made through the server that dropped cached responses.
+	Invalidations int `json:"invalidations"`
+}
+
+// NewGraphQLCache returns a GraphQLCache that keeps at most maxEntries responses for ttl, or for the
+// TTL ttls sets for the first field of their query, or nil if no query would be kept.
+func NewGraphQLCache(ttl time.Duration, ttls map[string]time.Duration, maxEntries int) *GraphQLCache {
+	cached := ttl > 0
+	for _, fieldTTL := range ttls {
+		cached = cached || fieldTTL > 0
+	}
+	if !cached || maxEntries <= 0 {
+		return nil
+	}
+	return &GraphQLCache{
+		TTL:         ttl,
+		TTLs:        ttls,
+		maxEntries:  maxEntries,
+		now:         time.Now,
+		order:       list.New(),
+		entries:     make(map[string]*list.Element),
+		invalidated: make(map[string]uint64),
+		sessions:    make(map[string]*graphQLCacheSession),
+	}
+}
+
+// ParseGraphQLCacheTTLs parses field=duration pairs, such as search=10s, into the TTLs of the
+// GraphQL queries whose first field is field.
+func ParseGraphQLCacheTTLs(specs []string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration, len(specs))
+	for _, spec := range specs {
+		field, value, ok := strings.Cut(strings.TrimSpace(spec), "=")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid GraphQL cache TTL %q, must be field=duration", spec)
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid GraphQL cache TTL %q, must be field=duration", spec)
+		}
+		ttls[field] = ttl
+	}
+	return ttls, nil
+}
+
+// ttl returns how long the responses to queries whose first field is field are kept.
+func (c *GraphQLCache) ttl(field string) time.Duration {
+	if ttl, ok := c.TTLs[field]; ok {
+		return ttl
+	}
+	return c.TTL
+}
+
+// graphQLCacheToken hashes the Authorization header of a request, so that the cache does not keep
+// tokens.
+func graphQLCacheToken(authorization string) string {
+	token := sha256.Sum256([]byte(authorization))
+	return string(token[:])
+}
+
+// session returns what the cache knows of session, which last used token. c.mu must be held.
+func (c *GraphQLCache) session(session, token string) *graphQLCacheSession {
+	s, ok := c.sessions[session]
+	if !ok {
+		s = &graphQLCacheSession{}
+		c.sessions[session] = s
+	}
+	s.token = token
+	return s
+}
+
+// get returns the response kept for key of token, if it has not expired, and the current
+// generation.
+func (c *GraphQLCache) get(key, token, session string) (*graphQLCacheEntry, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := &c.session(session, token).stats
+	element, ok := c.entries[key]
+	if ok && c.now().Before(element.Value.(*graphQLCacheEntry).expires) {
+		c.order.MoveToFront(element)
+		stats.Hits++
+		return element.Value.(*graphQLCacheEntry), c.generation, true
+	}
+	if ok {
+		c.remove(element)
+	}
+	stats.Misses++
+	return nil, c.generation, false
+}
+
+// add keeps entry, unless its token's responses were invalidated since generation.
+func (c *GraphQLCache) add(entry *graphQLCacheEntry, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation < c.floor || c.invalidated[entry.token] > generation {
+		return
+	}
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops element. c.mu must be held.
+func (c *GraphQLCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*graphQLCacheEntry).key)
+}
+
+// invalidate drops the responses kept for token that a change made with it to repo of owner may
+// have changed: those of queries about that repository, about other repositories of owner without
+// saying which, or not about a repository of a specific owner at all. If owner is empty, all the
+// responses kept for token are dropped. The responses kept for other tokens are left, as GitHub
+// serves them data as of their own requests.
+func (c *GraphQLCache) invalidate(token, session, owner, repo string) {
+	owner, repo = strings.ToLower(owner), strings.ToLower(repo)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.invalidated[token] = c.generation
+	if len(c.invalidated) > c.maxEntries {
+		// Forget which tokens were invalidated, and treat all responses in flight as invalidated
+		clear(c.invalidated)
+		c.floor = c.generation
+	}
+	c.session(session, token).stats.Invalidations++
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*graphQLCacheEntry)
+		if entry.token == token && (owner == "" || entry.owner == "" || (entry.owner == owner && (entry.repo == "" || entry.repo == repo))) {
+			c.remove(element)
+		}
+		element = next
+	}
+}
+
+// Stats returns how often the queries of session were answered from the cache.
+func (c *GraphQLCache) Stats(session string) GraphQLCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.sessions[session]
+	if !ok {
+		return GraphQLCacheStats{}
+	}
+	stats := s.stats
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		if element.Value.(*graphQLCacheEntry).token == s.token {
+			stats.Entries++
+		}
+	}
+	return stats
+}
+
+// Forget drops the statistics of a session that ended.
+func (c *GraphQLCache) Forget(session string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, session)
+}
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string          `json:"query"`
+	Variables json.RawMessage `json:"variables"`
+}
+
+// operation returns the type of the operation of the query, and the first field it selects.
+func (r graphQLRequest) operation() (operation, field string) {
+	query := strings.TrimSpace(r.Query)
+	start := strings.Index(query, "{")
+	if start < 0 {
+		return "", ""
+	}
+	// The operation type is left out of shorthand queries
+	operation = "query"
+	if end := strings.IndexFunc(query, func(r rune) bool { return !isGraphQLNameRune(r) }); end > 0 {
+		operation = query[:end]
+	}
+
+	// The first name in the selection set, after an alias if there is one
+	selection := query[start+1:]
+	for {
+		selection = strings.TrimSpace(selection)
+		end := strings.IndexFunc(selection, func(r rune) bool { return !isGraphQLNameRune(r) })
+		if end < 0 {
+			return operation, selection
+		}
+		field, selection = selection[:end], strings.TrimSpace(selection[end:])
+		if !strings.HasPrefix(selection, ":") {
+			return operation, field
+		}
+		selection = selection[1:]
+	}
+}
+
+func isGraphQLNameRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// GraphQLCacheTransport is an http.RoundTripper that answers GraphQL queries from Cache while their
+// responses are fresh, and otherwise makes the request and keeps successful responses in Cache.
+// Any GraphQL mutation, and any REST request that is not a GET, drops the responses kept for its
+// token that it may have changed: for REST requests to a repository, those about that repository,
+// and otherwise all of them, since the repository a mutation changes cannot be told from its node
+// IDs.
+type GraphQLCacheTransport struct {
+	Transport http.RoundTripper
+	Cache     *GraphQLCache
+	// GraphQLURL is the URL of the GraphQL API.
+	GraphQLURL *url.URL
+}
+
+func (t *GraphQLCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if t.Cache == nil {
+		return transport.RoundTrip(req)
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+		return transport.RoundTrip(req)
+	}
+	token := graphQLCacheToken(req.Header.Get("Authorization"))
+	session := sessionIDFromContext(req.Context())
+	if req.URL.Host != t.GraphQLURL.Host || req.URL.Path != t.GraphQLURL.Path {
+		// The change is counted even if the request fails, as it may have been made regardless
+		resp, err := transport.RoundTrip(req)
+		if match := repoAPIPath.FindStringSubmatch(req.URL.EscapedPath()); match != nil {
+			t.Cache.invalidate(token, session, match[1], match[2])
+		} else {
+			t.Cache.invalidate(token, session, "", "")
+		}
+		return resp, err
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+
+	var request graphQLRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return transport.RoundTrip(req)
+	}
+	operation, field := request.operation()
+	switch {
+	case operation == "mutation":
+		resp, err := transport.RoundTrip(req)
+		t.Cache.invalidate(token, session, "", "")
+		return resp, err
+	case operation != "query" || t.Cache.ttl(field) <= 0:
+		return transport.RoundTrip(req)
+	}
+
+	// Variables are compacted so that their formatting does not matter
+	var variables bytes.Buffer
+	if len(request.Variables) > 0 {
+		if err := json.Compact(&variables, request.Variables); err != nil {
+			return transport.RoundTrip(req)
+		}
+	}
+	query := sha256.Sum256([]byte(request.Query + "\x00" + variables.String()))
+	key := token + string(query[:])
+
+	entry, generation, ok := t.Cache.get(key, token, session)
+	if ok {
+		return &http.Response{
+			Status:        http.StatusText(http.StatusOK),
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxGraphQLCacheEntryBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(respBody) > maxGraphQLCacheEntryBytes {
+		// Too large to keep, so the rest is read as usual
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(respBody), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	// Responses with errors, which may be partial, are not kept
+	var result struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(respBody, &result) != nil || len(result.Errors) > 0 {
+		return resp, nil
+	}
+
+	var scope struct {
+		Owner any `json:"owner"`
+		Repo  any `json:"repo"`
+	}
+	_ = json.Unmarshal(request.Variables, &scope)
+	owner, _ := scope.Owner.(string)
+	repo, _ := scope.Repo.(string)
+	t.Cache.add(&graphQLCacheEntry{
+		key:     key,
+		token:   token,
+		owner:   strings.ToLower(owner),
+		repo:    strings.ToLower(repo),
+		expires: t.Cache.now().Add(t.Cache.ttl(field)),
+		header:  withoutRateLimitHeaders(resp.Header),
+		body:    respBody,
+	}, generation)
+	return resp, nil
+}
@@ -0,0 +1,285 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	cacheTestRepoQuery   = `query($owner:String!$repo:String!){repository(owner: $owner, name: $repo){stargazerCount}}`
+	cacheTestSearchQuery = `query($query:String!){search(query: $query, type: ISSUE, first: 10){issueCount}}`
+	cacheTestMutation    = `mutation($input:AddCommentInput!){addComment(input: $input){clientMutationId}}`
+)
+
+// graphQLCacheTestServer answers each request with the number of requests it got so far, so that
+// a response served from the cache can be told apart from a fresh one.
+type graphQLCacheTestServer struct {
+	*httptest.Server
+	requests atomic.Int32
+	// respond, if set, writes the response instead.
+	respond func(w http.ResponseWriter, r *http.Request)
+
+	cache  *GraphQLCache
+	client *http.Client
+	now    time.Time
+}
+
+func newGraphQLCacheTestServer(t *testing.T, ttl time.Duration, ttls map[string]time.Duration) *graphQLCacheTestServer {
+	s := &graphQLCacheTestServer{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.requests.Add(1)
+		if s.respond != nil {
+			s.respond(w, r)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		_, _ = fmt.Fprintf(w, `{"data": {"request": %d}}`, n)
+	}))
+	t.Cleanup(s.Close)
+
+	s.cache = NewGraphQLCache(ttl, ttls, DefaultGraphQLCacheEntries)
+	require.NotNil(t, s.cache)
+	s.cache.now = func() time.Time { return s.now }
+	graphQLURL, err := url.Parse(s.URL + "/api/graphql")
+	require.NoError(t, err)
+	s.client = &http.Client{Transport: &GraphQLCacheTransport{Cache: s.cache, GraphQLURL: graphQLURL}}
+	return s
+}
+
+// query sends a GraphQL request with token and returns the response body.
+func (s *graphQLCacheTestServer) query(t *testing.T, token, query string, variables map[string]any) string {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, s.URL+"/api/graphql", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := s.client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(respBody)
+}
+
+// rest sends a REST request with token and method to path.
+func (s *graphQLCacheTestServer) rest(t *testing.T, token, method, path string) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := s.client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+}
+
+func Test_GraphQLCacheTransport(t *testing.T) {
+	repoVars := map[string]any{"owner": "octo-org", "repo": "hello-world"}
+
+	t.Run("repeated queries are answered from the cache per token and variables", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+
+		first := s.query(t, "token-a", cacheTestRepoQuery, repoVars)
+		assert.Equal(t, first, s.query(t, "token-a", cacheTestRepoQuery, repoVars))
+		assert.Equal(t, int32(1), s.requests.Load())
+
+		assert.NotEqual(t, first, s.query(t, "token-b", cacheTestRepoQuery, repoVars))
+		assert.NotEqual(t, first, s.query(t, "token-a", cacheTestRepoQuery, map[string]any{"owner": "octo-org", "repo": "other"}))
+		assert.Equal(t, int32(3), s.requests.Load())
+
+		// The entries are those of the token used last
+		assert.Equal(t, GraphQLCacheStats{Entries: 2, Hits: 1, Misses: 3}, s.cache.Stats(""))
+	})
+
+	t.Run("responses expire after their TTL", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, map[string]time.Duration{"search": 10 * time.Second})
+		searchVars := map[string]any{"query": "is:open"}
+
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		s.query(t, "token", cacheTestSearchQuery, searchVars)
+		s.now = s.now.Add(30 * time.Second)
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		s.query(t, "token", cacheTestSearchQuery, searchVars)
+		assert.Equal(t, int32(3), s.requests.Load(), "only the search should have expired")
+
+		s.now = s.now.Add(time.Minute)
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		assert.Equal(t, int32(4), s.requests.Load())
+	})
+
+	t.Run("queries with a TTL of 0 are not cached", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, map[string]time.Duration{"search": 0})
+		searchVars := map[string]any{"query": "is:open"}
+
+		s.query(t, "token", cacheTestSearchQuery, searchVars)
+		s.query(t, "token", cacheTestSearchQuery, searchVars)
+		assert.Equal(t, int32(2), s.requests.Load())
+		assert.Zero(t, s.cache.Stats("").Misses)
+	})
+
+	t.Run("mutations drop all responses", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		s.query(t, "token", cacheTestMutation, map[string]any{"input": map[string]any{"subjectId": "I_1", "body": "Hi"}})
+		s.query(t, "token", cacheTestMutation, map[string]any{"input": map[string]any{"subjectId": "I_1", "body": "Hi"}})
+		assert.Equal(t, int32(3), s.requests.Load(), "mutations should never be answered from the cache")
+
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		assert.Equal(t, int32(4), s.requests.Load())
+		assert.Equal(t, 2, s.cache.Stats("").Invalidations)
+	})
+
+	t.Run("changes drop only the responses of their token", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+		searchVars := map[string]any{"query": "is:open"}
+
+		s.query(t, "token-a", cacheTestRepoQuery, repoVars)
+		s.query(t, "token-b", cacheTestRepoQuery, repoVars)
+		s.query(t, "token-b", cacheTestSearchQuery, searchVars)
+		s.query(t, "token-a", cacheTestMutation, map[string]any{"input": map[string]any{"subjectId": "I_1", "body": "Hi"}})
+		s.rest(t, "token-a", http.MethodPut, "/api/v3/user/following/octocat")
+		s.rest(t, "token-a", http.MethodPost, "/api/v3/repos/octo-org/hello-world/issues")
+		s.requests.Store(0)
+
+		s.query(t, "token-b", cacheTestRepoQuery, repoVars)
+		s.query(t, "token-b", cacheTestSearchQuery, searchVars)
+		assert.Zero(t, s.requests.Load(), "the responses of token-b should be kept")
+		s.query(t, "token-a", cacheTestRepoQuery, repoVars)
+		assert.Equal(t, int32(1), s.requests.Load())
+	})
+
+	t.Run("REST changes drop the responses about their repository", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+		otherRepoVars := map[string]any{"owner": "octo-org", "repo": "other"}
+		ownerVars := map[string]any{"owner": "octo-org", "name": "hello-world"}
+		otherOwnerVars := map[string]any{"owner": "monalisa", "repo": "hello-world"}
+		searchVars := map[string]any{"query": "is:open"}
+
+		queries := []struct {
+			query     string
+			variables map[string]any
+			dropped   bool
+		}{
+			{cacheTestRepoQuery, repoVars, true},
+			{cacheTestRepoQuery, otherRepoVars, false},
+			// The repository may be named by another variable than repo
+			{cacheTestRepoQuery, ownerVars, true},
+			{cacheTestRepoQuery, otherOwnerVars, false},
+			// A search may find anything
+			{cacheTestSearchQuery, searchVars, true},
+		}
+		for _, q := range queries {
+			s.query(t, "token", q.query, q.variables)
+		}
+
+		// Reads change nothing
+		s.rest(t, "token", http.MethodGet, "/api/v3/repos/octo-org/hello-world/issues")
+		s.requests.Store(0)
+		s.rest(t, "token", http.MethodPost, "/api/v3/repos/Octo-Org/Hello-World/issues")
+
+		for _, q := range queries {
+			s.query(t, "token", q.query, q.variables)
+		}
+		assert.Equal(t, int32(1+3), s.requests.Load())
+
+		// Changes outside of a repository drop everything
+		s.requests.Store(0)
+		s.rest(t, "token", http.MethodPut, "/api/v3/user/following/octocat")
+		for _, q := range queries {
+			s.query(t, "token", q.query, q.variables)
+		}
+		assert.Equal(t, int32(1+len(queries)), s.requests.Load())
+	})
+
+	t.Run("responses to queries sent before a change are not kept", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+		s.respond = func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "repository") {
+				// The mutation completes while the query is in flight
+				s.cache.invalidate(graphQLCacheToken("Bearer token"), "", "", "")
+			}
+			_, _ = w.Write([]byte(`{"data": {}}`))
+		}
+
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		assert.Zero(t, s.cache.Stats("").Entries)
+	})
+
+	t.Run("responses with errors are not kept", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+		s.respond = func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"data": {"repository": null}, "errors": [{"message": "Could not resolve to a Repository"}]}`))
+		}
+
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+		assert.Equal(t, int32(2), s.requests.Load())
+	})
+
+	t.Run("rate limit headers are not served from the cache", func(t *testing.T) {
+		s := newGraphQLCacheTestServer(t, time.Minute, nil)
+		s.query(t, "token", cacheTestRepoQuery, repoVars)
+
+		body, err := json.Marshal(map[string]any{"query": cacheTestRepoQuery, "variables": repoVars})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/api/graphql", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		resp, err := s.client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, int32(1), s.requests.Load())
+		assert.Empty(t, resp.Header.Get("X-RateLimit-Remaining"))
+	})
+}
+
+func Test_GraphQLRequestOperation(t *testing.T) {
+	tests := []struct {
+		query     string
+		operation string
+		field     string
+	}{
+		{query: cacheTestRepoQuery, operation: "query", field: "repository"},
+		{query: cacheTestMutation, operation: "mutation", field: "addComment"},
+		{query: `{viewer{login}}`, operation: "query", field: "viewer"},
+		{query: `query { me: viewer { login } }`, operation: "query", field: "viewer"},
+		{query: `not graphql`, operation: "", field: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			operation, field := graphQLRequest{Query: tc.query}.operation()
+			assert.Equal(t, tc.operation, operation)
+			assert.Equal(t, tc.field, field)
+		})
+	}
+}
+
+func Test_ParseGraphQLCacheTTLs(t *testing.T) {
+	ttls, err := ParseGraphQLCacheTTLs([]string{"search=10s", " repository=2m"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"search": 10 * time.Second, "repository": 2 * time.Minute}, ttls)
+
+	for _, spec := range []string{"search", "=10s", "search=soon", "search=-1s"} {
+		_, err := ParseGraphQLCacheTTLs([]string{spec})
+		assert.Error(t, err, spec)
+	}
+
+	assert.Nil(t, NewGraphQLCache(0, nil, DefaultGraphQLCacheEntries))
+	assert.Nil(t, NewGraphQLCache(0, ttls, 0))
+	assert.NotNil(t, NewGraphQLCache(0, ttls, DefaultGraphQLCacheEntries))
+}
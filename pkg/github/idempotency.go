@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// idempotencyTTL is how long the result of a create call is replayed for calls with the same key.
+	idempotencyTTL = 10 * time.Minute
+	// maxIdempotencyEntries bounds the results kept at once. The oldest are dropped first.
+	maxIdempotencyEntries = 1000
+)
+
+// idempotencyKeyParam is the parameter create tools accept an idempotency key in.
+const idempotencyKeyParam = "idempotency_key"
+
+// createIdempotency is the cache shared by the create tools.
+var createIdempotency = newIdempotencyCache(idempotencyTTL, maxIdempotencyEntries)
+
+// idempotencyCache remembers the results of successful create calls by their idempotency key, so
+// that a call retried after a network error returns the original result instead of creating a duplicate.
+type idempotencyCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	// arguments are the other arguments of the call, which replays must match.
+	arguments string
+	// done is closed once the first call finished. result is only set if it succeeded.
+	done    chan struct{}
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+func newIdempotencyCache(ttl time.Duration, maxEntries int) *idempotencyCache {
+	return &idempotencyCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*idempotencyEntry),
+	}
+}
+
+// withIdempotencyKey adds the idempotency_key parameter to a create tool, and makes its handler
+// return the result of the first successful call with the same key, tool and MCP session instead of
+// calling GitHub again. Calls made while the first one is in flight wait for it.
+func withIdempotencyKey(cache *idempotencyCache, tool mcp.Tool, handler server.ToolHandlerFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	mcp.WithString(idempotencyKeyParam,
+		mcp.Description(fmt.Sprintf("Unique key for this call, such as a UUID. Calls retried with the same key and arguments within %d minutes return the result of the first successful call instead of creating a duplicate", int(cache.ttl.Minutes()))),
+	)(&tool)
+
+	return tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, err := OptionalParam[string](request, idempotencyKeyParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if key == "" {
+			return handler(ctx, request)
+		}
+
+		arguments := make(map[string]any)
+		for name, value := range request.GetArguments() {
+			if name != idempotencyKeyParam {
+				arguments[name] = value
+			}
+		}
+		fingerprint, err := json.Marshal(arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+		}
+
+		// Keys are scoped to the session, so that clients sharing a server cannot see each other's results
+		scope := tool.Name + "\x00" + key
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			scope = session.SessionID() + "\x00" + scope
+		}
+
+		for {
+			entry, first := cache.claim(scope, string(fingerprint))
+			if entry.arguments != string(fingerprint) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s %q was already used for a %s call with different arguments", idempotencyKeyParam, key, tool.Name)), nil
+			}
+			if first {
+				result, err := handler(ctx, request)
+				cache.finish(scope, entry, result, err)
+				return result, err
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-entry.done:
+			}
+			if entry.result != nil {
+				return entry.result, nil
+			}
+			// The first call failed, so this one may try again
+		}
+	}
+}
+
+// claim returns the entry of scope, creating it if there is none or it expired. first reports
+// whether it was created, in which case the caller must make the call and finish the entry.
+func (c *idempotencyCache) claim(scope, arguments string) (entry *idempotencyEntry, first bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if entry, ok := c.entries[scope]; ok && (entry.expires.IsZero() || now.Before(entry.expires)) {
+		return entry, false
+	}
+
+	c.evict(now)
+	entry = &idempotencyEntry{arguments: arguments, done: make(chan struct{})}
+	c.entries[scope] = entry
+	return entry, true
+}
+
+// finish records the outcome of the call of entry. Only successful results are kept; otherwise the
+// entry is removed so that the call can be retried with the same key.
+func (c *idempotencyCache) finish(scope string, entry *idempotencyEntry, result *mcp.CallToolResult, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil && result != nil && !result.IsError {
+		entry.result = result
+		entry.expires = c.now().Add(c.ttl)
+	} else if c.entries[scope] == entry {
+		delete(c.entries, scope)
+	}
+	close(entry.done)
+}
+
+// evict removes expired entries and, if the cache is still full, the entries that expire first.
+// Calls in flight are never removed. c.mu must be held.
+func (c *idempotencyCache) evict(now time.Time) {
+	for scope, entry := range c.entries {
+		if !entry.expires.IsZero() && !now.Before(entry.expires) {
+			delete(c.entries, scope)
+		}
+	}
+	for len(c.entries) >= c.maxEntries {
+		oldest := ""
+		for scope, entry := range c.entries {
+			if entry.expires.IsZero() {
+				continue
+			}
+			if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+				oldest = scope
+			}
+		}
+		if oldest == "" {
+			return
+		}
+		delete(c.entries, oldest)
+	}
+}
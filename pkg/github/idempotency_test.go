@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateIssue_IdempotencyKey(t *testing.T) {
+	var calls atomic.Int32
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				number := int(calls.Add(1))
+				mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(number), Title: github.Ptr("Flaky upload")})(w, r)
+			}),
+		),
+	))
+	tool, handler := CreateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+	assert.Contains(t, tool.InputSchema.Properties, "idempotency_key")
+
+	args := map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"title":           "Flaky upload",
+		"idempotency_key": "create-issue-replay-test",
+	}
+	first, err := handler(context.Background(), createMCPRequest(args))
+	require.NoError(t, err)
+	require.False(t, first.IsError)
+
+	replayed, err := handler(context.Background(), createMCPRequest(args))
+	require.NoError(t, err)
+	require.False(t, replayed.IsError)
+
+	assert.Equal(t, int32(1), calls.Load(), "the replayed call should not create a second issue")
+	var issue github.Issue
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, replayed).Text), &issue))
+	assert.Equal(t, 1, issue.GetNumber())
+
+	// Without a key, every call creates an issue
+	delete(args, "idempotency_key")
+	_, err = handler(context.Background(), createMCPRequest(args))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_WithIdempotencyKey(t *testing.T) {
+	// countingTool returns a tool that succeeds unless its fail argument is set, and counts its calls
+	countingTool := func(cache *idempotencyCache, calls *atomic.Int32) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		_, handler := withIdempotencyKey(cache, mcp.NewTool("create_thing"), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			n := calls.Add(1)
+			if fail, _ := OptionalParam[bool](request, "fail"); fail {
+				return mcp.NewToolResultError("failed"), nil
+			}
+			return MarshalledTextResult(map[string]any{"call": n}), nil
+		})
+		return handler
+	}
+
+	t.Run("rejects a key reused with other arguments", func(t *testing.T) {
+		var calls atomic.Int32
+		handler := countingTool(newIdempotencyCache(time.Minute, 10), &calls)
+
+		_, err := handler(context.Background(), createMCPRequest(map[string]any{"name": "a", "idempotency_key": "k"}))
+		require.NoError(t, err)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"name": "b", "idempotency_key": "k"}))
+		require.NoError(t, err)
+
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, `idempotency_key "k" was already used for a create_thing call with different arguments`)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("does not keep failures", func(t *testing.T) {
+		var calls atomic.Int32
+		handler := countingTool(newIdempotencyCache(time.Minute, 10), &calls)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"fail": true, "idempotency_key": "k"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		result, err = handler(context.Background(), createMCPRequest(map[string]any{"fail": true, "idempotency_key": "k"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		assert.Equal(t, int32(2), calls.Load(), "a failed call should be retried")
+	})
+
+	t.Run("forgets results after the TTL", func(t *testing.T) {
+		var calls atomic.Int32
+		cache := newIdempotencyCache(time.Minute, 10)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		handler := countingTool(cache, &calls)
+
+		args := map[string]any{"name": "a", "idempotency_key": "k"}
+		_, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		now = now.Add(30 * time.Second)
+		_, err = handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+
+		now = now.Add(time.Minute)
+		_, err = handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("drops the oldest results when full", func(t *testing.T) {
+		var calls atomic.Int32
+		cache := newIdempotencyCache(time.Minute, 2)
+		handler := countingTool(cache, &calls)
+
+		for _, key := range []string{"a", "b", "c"} {
+			_, err := handler(context.Background(), createMCPRequest(map[string]any{"idempotency_key": key}))
+			require.NoError(t, err)
+		}
+		assert.Len(t, cache.entries, 2)
+
+		_, err := handler(context.Background(), createMCPRequest(map[string]any{"idempotency_key": "c"}))
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load(), "the newest result should still be replayed")
+	})
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultImmutableCacheEntries is the number of responses ImmutableCache keeps by default.
+	DefaultImmutableCacheEntries = 1000
+	// maxImmutableCacheEntryBytes bounds the size of the responses ImmutableCache keeps, so that a
+	// few large blobs cannot take up the memory of the whole cache.
+	maxImmutableCacheEntryBytes = 1 << 20
+)
+
+// fullSHA matches a complete SHA-1 or SHA-256 object name. Abbreviated SHAs are not cached, as they
+// can become ambiguous.
+const fullSHA = `(?:[0-9a-fA-F]{40}|[0-9a-fA-F]{64})`
+
+var (
+	// immutableAPIPath matches the REST API paths of Git objects and commits addressed by SHA.
+	immutableAPIPath = regexp.MustCompile(`/repos/[^/]+/[^/]+/(?:git/(?:blobs|commits|trees)|commits)/` + fullSHA + `$`)
+	// contentsAPIPath matches the REST API paths of file contents, which are immutable when their
+	// ref is a SHA.
+	contentsAPIPath = regexp.MustCompile(`/repos/[^/]+/[^/]+/contents(?:/.*)?$`)
+	// rawContentPath matches the paths of raw file contents at a SHA, relative to the raw URL.
+	rawContentPath = regexp.MustCompile(`^[^/]+/[^/]+/` + fullSHA + `/.+`)
+	fullSHARef     = regexp.MustCompile(`^` + fullSHA + `$`)
+)
+
+// ImmutableCache keeps GitHub API responses that cannot change, such as commits and blobs addressed
+// by SHA, and drops the least recently used when full. It is safe for concurrent use.
+type ImmutableCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type immutableCacheEntry struct {
+	key    string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// NewImmutableCache returns an ImmutableCache that keeps at most maxEntries responses.
+func NewImmutableCache(maxEntries int) *ImmutableCache {
+	return &ImmutableCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (c *ImmutableCache) get(key string) (*immutableCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*immutableCacheEntry), true
+}
+
+func (c *ImmutableCache) add(entry *immutableCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*immutableCacheEntry).key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (c *ImmutableCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// ImmutableCacheTransport is an http.RoundTripper that answers GET requests for commits, Git objects
+// and file contents addressed by a full SHA from Cache, and otherwise makes the request and keeps
+// successful responses in Cache. Requests by branch, tag or abbreviated SHA are never cached.
+// Responses are cached per token, so a token can never see what only another token may read.
+type ImmutableCacheTransport struct {
+	Transport http.RoundTripper
+	Cache     *ImmutableCache
+	// RawURL is the URL raw file contents are requested from. If nil, they are not cached.
+	RawURL *url.URL
+}
+
+func (t *ImmutableCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if t.Cache == nil || !t.immutable(req) {
+		return transport.RoundTrip(req)
+	}
+
+	// The token is hashed so that the cache does not keep tokens
+	token := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	key := string(token[:]) + "\x00" + req.Header.Get("Accept") + "\x00" + req.URL.String()
+	if entry, ok := t.Cache.get(key); ok {
+		return &http.Response{
+			Status:        http.StatusText(entry.status),
+			StatusCode:    entry.status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImmutableCacheEntryBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxImmutableCacheEntryBytes {
+		// Too large to keep, so the rest is read as usual
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.Cache.add(&immutableCacheEntry{key: key, status: resp.StatusCode, header: withoutRateLimitHeaders(resp.Header), body: body})
+	return resp, nil
+}
+
+// withoutRateLimitHeaders returns a copy of header without the rate limits it reports, which are
+// outdated when a response is served from a cache.
+func withoutRateLimitHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	for name := range header {
+		if strings.HasPrefix(strings.ToLower(name), "x-ratelimit-") {
+			header.Del(name)
+		}
+	}
+	return header
+}
+
+// immutable reports whether the response to req cannot change.
+func (t *ImmutableCacheTransport) immutable(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+	path := req.URL.EscapedPath()
+	if t.RawURL != nil && req.URL.Host == t.RawURL.Host && strings.HasPrefix(path, t.RawURL.EscapedPath()) {
+		return rawContentPath.MatchString(strings.TrimPrefix(strings.TrimPrefix(path, t.RawURL.EscapedPath()), "/"))
+	}
+	if immutableAPIPath.MatchString(path) {
+		return true
+	}
+	return contentsAPIPath.MatchString(path) && fullSHARef.MatchString(req.URL.Query().Get("ref"))
+}
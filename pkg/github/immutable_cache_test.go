@@ -0,0 +1,159 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cacheTestSHA = "3f786850e387550fdab836ed7e6dc881de23001b"
+
+// countingServer counts the requests it gets per path and query.
+type countingServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newCountingServer(t *testing.T, handler http.HandlerFunc) *countingServer {
+	s := &countingServer{counts: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.counts[r.URL.RequestURI()]++
+		s.mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *countingServer) count(requestURI string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[requestURI]
+}
+
+func (s *countingServer) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts = make(map[string]int)
+}
+
+func Test_ImmutableCacheTransport(t *testing.T) {
+	ts := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"path": "` + r.URL.Path + `"}`))
+	})
+	rawURL, err := url.Parse(ts.URL + "/raw/")
+	require.NoError(t, err)
+
+	get := func(t *testing.T, transport http.RoundTripper, token, requestURI string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+requestURI, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	tests := []struct {
+		name         string
+		requestURI   string
+		expectCached bool
+	}{
+		{name: "blob by SHA", requestURI: "/repos/owner/repo/git/blobs/" + cacheTestSHA, expectCached: true},
+		{name: "tree by SHA", requestURI: "/repos/owner/repo/git/trees/" + cacheTestSHA + "?recursive=1", expectCached: true},
+		{name: "Git commit by SHA", requestURI: "/repos/owner/repo/git/commits/" + cacheTestSHA, expectCached: true},
+		{name: "commit by SHA", requestURI: "/repos/owner/repo/commits/" + cacheTestSHA + "?page=1&per_page=30", expectCached: true},
+		{name: "contents at a SHA", requestURI: "/repos/owner/repo/contents/docs/README.md?ref=" + cacheTestSHA, expectCached: true},
+		{name: "raw contents at a SHA", requestURI: "/raw/owner/repo/" + cacheTestSHA + "/docs/README.md", expectCached: true},
+		{name: "commit by branch", requestURI: "/repos/owner/repo/commits/main"},
+		{name: "commit by abbreviated SHA", requestURI: "/repos/owner/repo/commits/" + cacheTestSHA[:7]},
+		{name: "contents at a branch", requestURI: "/repos/owner/repo/contents/docs/README.md?ref=main"},
+		{name: "contents at the default branch", requestURI: "/repos/owner/repo/contents/docs/README.md"},
+		{name: "raw contents at a branch", requestURI: "/raw/owner/repo/main/docs/README.md"},
+		{name: "failed request", requestURI: "/repos/owner/missing/git/blobs/" + cacheTestSHA},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &ImmutableCacheTransport{Cache: NewImmutableCache(10), RawURL: rawURL}
+
+			first, firstBody := get(t, transport, "token-a", tc.requestURI)
+			second, secondBody := get(t, transport, "token-a", tc.requestURI)
+			assert.Equal(t, first.StatusCode, second.StatusCode)
+			assert.Equal(t, firstBody, secondBody)
+
+			if !tc.expectCached {
+				assert.Equal(t, 2, ts.count(tc.requestURI))
+				assert.Equal(t, 0, transport.Cache.Len())
+				ts.reset()
+				return
+			}
+			assert.Equal(t, 1, ts.count(tc.requestURI), "the second fetch should be served from the cache")
+			assert.Empty(t, second.Header.Get("X-RateLimit-Remaining"), "cached responses should not report outdated rate limits")
+
+			// Other tokens do not see what was cached for this one
+			_, _ = get(t, transport, "token-b", tc.requestURI)
+			assert.Equal(t, 2, ts.count(tc.requestURI))
+			ts.reset()
+		})
+	}
+}
+
+func Test_ImmutableCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewImmutableCache(2)
+	for _, key := range []string{"a", "b"} {
+		cache.add(&immutableCacheEntry{key: key, status: http.StatusOK})
+	}
+	_, ok := cache.get("a")
+	require.True(t, ok)
+
+	cache.add(&immutableCacheEntry{key: "c", status: http.StatusOK})
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.get("b")
+	assert.False(t, ok, "b was used least recently")
+	_, ok = cache.get("a")
+	assert.True(t, ok)
+	_, ok = cache.get("c")
+	assert.True(t, ok)
+}
+
+func Test_GetCommit_ImmutableCache(t *testing.T) {
+	ts := newCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mockResponse(t, http.StatusOK, &github.RepositoryCommit{SHA: github.Ptr(cacheTestSHA)})(w, r)
+	})
+	client := github.NewClient(&http.Client{Transport: &ImmutableCacheTransport{Cache: NewImmutableCache(10)}})
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	_, handler := GetCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	for i := 0; i < 2; i++ {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"sha":   cacheTestSHA,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, cacheTestSHA)
+	}
+
+	assert.Equal(t, 1, ts.count("/repos/owner/repo/commits/"+cacheTestSHA+"?page=1&per_page=30"))
+}
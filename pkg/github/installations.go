@@ -0,0 +1,132 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OrgInstallations is the output type of the list_org_installations tool.
+type OrgInstallations struct {
+	TotalCount    int               `json:"total_count"`
+	Installations []OrgInstallation `json:"installations"`
+}
+
+// OrgInstallation is a GitHub App installed in an organization.
+type OrgInstallation struct {
+	ID      int64  `json:"id"`
+	AppID   int64  `json:"app_id"`
+	AppSlug string `json:"app_slug"`
+	// Permissions maps the permissions granted to the app to their access level, read, write or admin.
+	Permissions map[string]string `json:"permissions"`
+	// RepositorySelection is all if the app can access all repositories of the organization, and
+	// selected if it can only access some.
+	RepositorySelection string   `json:"repository_selection"`
+	Events              []string `json:"events,omitempty"`
+	CreatedAt           string   `json:"created_at,omitempty"`
+	UpdatedAt           string   `json:"updated_at,omitempty"`
+	SuspendedAt         string   `json:"suspended_at,omitempty"`
+	SuspendedBy         string   `json:"suspended_by,omitempty"`
+}
+
+// installationPermissions flattens the permissions of an installation into a map of the permissions
+// that are granted.
+func installationPermissions(permissions *github.InstallationPermissions) (map[string]string, error) {
+	result := map[string]string{}
+	if permissions == nil {
+		return result, nil
+	}
+	// The permissions are a struct of optional fields, one for each permission
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListOrgInstallations creates a tool to list the GitHub Apps installed in an organization.
+func ListOrgInstallations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_installations",
+			mcp.WithDescription(t("TOOL_LIST_ORG_INSTALLATIONS_DESCRIPTION", "List the GitHub Apps installed in a GitHub organization, with the permissions each was granted and whether it can access all or only selected repositories, to audit third-party access. Requires an owner of the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_INSTALLATIONS_USER_TITLE", "List organization app installations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			installations, resp, err := client.Organizations.ListInstallations(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				message := "failed to list organization installations"
+				if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+					message += ": this requires the token of an owner of the organization, with the admin:read scope or read access to the organization's administration"
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := OrgInstallations{
+				TotalCount:    installations.GetTotalCount(),
+				Installations: make([]OrgInstallation, 0, len(installations.Installations)),
+			}
+			for _, installation := range installations.Installations {
+				permissions, err := installationPermissions(installation.Permissions)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read installation permissions: %w", err)
+				}
+				entry := OrgInstallation{
+					ID:                  installation.GetID(),
+					AppID:               installation.GetAppID(),
+					AppSlug:             installation.GetAppSlug(),
+					Permissions:         permissions,
+					RepositorySelection: installation.GetRepositorySelection(),
+					Events:              installation.Events,
+					SuspendedBy:         installation.GetSuspendedBy().GetLogin(),
+				}
+				if installation.CreatedAt != nil {
+					entry.CreatedAt = installation.CreatedAt.Format(time.RFC3339)
+				}
+				if installation.UpdatedAt != nil {
+					entry.UpdatedAt = installation.UpdatedAt.Format(time.RFC3339)
+				}
+				if installation.SuspendedAt != nil {
+					entry.SuspendedAt = installation.SuspendedAt.Format(time.RFC3339)
+				}
+				result.Installations = append(result.Installations, entry)
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
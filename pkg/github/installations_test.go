@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgInstallations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgInstallations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_installations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	suspended := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockInstallations := &github.OrganizationInstallations{
+		TotalCount: github.Ptr(2),
+		Installations: []*github.Installation{
+			{
+				ID:                  github.Ptr(int64(1)),
+				AppID:               github.Ptr(int64(10)),
+				AppSlug:             github.Ptr("ci-bot"),
+				RepositorySelection: github.Ptr("all"),
+				Events:              []string{"push", "pull_request"},
+				Permissions: &github.InstallationPermissions{
+					Contents: github.Ptr("write"),
+					Metadata: github.Ptr("read"),
+				},
+				CreatedAt: &github.Timestamp{Time: created},
+				UpdatedAt: &github.Timestamp{Time: created},
+			},
+			{
+				ID:                  github.Ptr(int64(2)),
+				AppID:               github.Ptr(int64(20)),
+				AppSlug:             github.Ptr("old-app"),
+				RepositorySelection: github.Ptr("selected"),
+				Permissions: &github.InstallationPermissions{
+					Issues: github.Ptr("read"),
+				},
+				CreatedAt:   &github.Timestamp{Time: created},
+				SuspendedAt: &github.Timestamp{Time: suspended},
+				SuspendedBy: &github.User{Login: github.Ptr("admin")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       OrgInstallations
+	}{
+		{
+			name: "successful listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInstallationsByOrg,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, mockInstallations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expected: OrgInstallations{
+				TotalCount: 2,
+				Installations: []OrgInstallation{
+					{
+						ID:                  1,
+						AppID:               10,
+						AppSlug:             "ci-bot",
+						Permissions:         map[string]string{"contents": "write", "metadata": "read"},
+						RepositorySelection: "all",
+						Events:              []string{"push", "pull_request"},
+						CreatedAt:           "2026-03-01T12:00:00Z",
+						UpdatedAt:           "2026-03-01T12:00:00Z",
+					},
+					{
+						ID:                  2,
+						AppID:               20,
+						AppSlug:             "old-app",
+						Permissions:         map[string]string{"issues": "read"},
+						RepositorySelection: "selected",
+						CreatedAt:           "2026-03-01T12:00:00Z",
+						SuspendedAt:         "2026-06-01T12:00:00Z",
+						SuspendedBy:         "admin",
+					},
+				},
+			},
+		},
+		{
+			name: "not an organization owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInstallationsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Organization."}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{"org": "octo-org"},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization installations: this requires the token of an owner of the organization",
+		},
+		{
+			name:           "missing org",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgInstallations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned OrgInstallations
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// maxIssueDependencies bounds the number of issues listed for each direction of tracking.
+const maxIssueDependencies = 100
+
+// IssueDependency is an issue related to another by tracking.
+type IssueDependency struct {
+	// Relationship is "tracks" for an issue the issue tracks, and "tracked_in" for an issue that tracks it.
+	Relationship string `json:"relationship"`
+	Repository   string `json:"repository"`
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+}
+
+// IssueDependencies is the output type of the get_issue_dependencies tool.
+type IssueDependencies struct {
+	Issue        string            `json:"issue"`
+	Dependencies []IssueDependency `json:"dependencies"`
+	// Truncated is set if the issue tracks or is tracked in more issues than are listed.
+	Truncated bool   `json:"truncated,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// trackedIssueConnection is a page of the issues an issue tracks or is tracked in.
+type trackedIssueConnection struct {
+	Nodes []struct {
+		Number     githubv4.Int
+		Title      githubv4.String
+		State      githubv4.String
+		Repository struct {
+			NameWithOwner githubv4.String
+		}
+	}
+	TotalCount githubv4.Int
+}
+
+// trackedIssuesUnavailable reports whether a query failed because the tracked issues fields are not
+// available, as they are part of a beta that is not enabled everywhere.
+func trackedIssuesUnavailable(err error) bool {
+	return strings.Contains(err.Error(), "trackedIssues") || strings.Contains(err.Error(), "trackedInIssues")
+}
+
+// GetIssueDependencies creates a tool to get the issues an issue tracks and is tracked in.
+func GetIssueDependencies(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_dependencies",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_DEPENDENCIES_DESCRIPTION", "Get the dependencies of an issue: the issues it tracks and the issues it is tracked in, as a flat list with the repository and state of each and how it relates to the issue. Tracking is a beta feature, so the list is empty where it is not enabled.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_DEPENDENCIES_USER_TITLE", "Get issue dependencies"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Repository struct {
+					Issue struct {
+						TrackedIssues   trackedIssueConnection `graphql:"trackedIssues(first: $first)"`
+						TrackedInIssues trackedIssueConnection `graphql:"trackedInIssues(first: $first)"`
+					} `graphql:"issue(number: $issueNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":       githubv4.String(owner),
+				"repo":        githubv4.String(repo),
+				"issueNumber": githubv4.Int(int32(issueNumber)), // #nosec G115 - issue numbers are always small positive integers
+				"first":       githubv4.Int(maxIssueDependencies),
+			}
+
+			result := IssueDependencies{
+				Issue:        fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber),
+				Dependencies: []IssueDependency{},
+			}
+			if err := gqlClient.Query(ctx, &q, vars); err != nil {
+				if !trackedIssuesUnavailable(err) {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get issue dependencies", err), nil
+				}
+				result.Note = "issue tracking is not enabled for this repository, so the issue has no dependencies"
+				return MarshalledTextResult(result), nil
+			}
+
+			issue := q.Repository.Issue
+			for _, relationship := range []struct {
+				name       string
+				connection trackedIssueConnection
+			}{
+				{"tracks", issue.TrackedIssues},
+				{"tracked_in", issue.TrackedInIssues},
+			} {
+				for _, node := range relationship.connection.Nodes {
+					result.Dependencies = append(result.Dependencies, IssueDependency{
+						Relationship: relationship.name,
+						Repository:   string(node.Repository.NameWithOwner),
+						Number:       int(node.Number),
+						Title:        string(node.Title),
+						State:        strings.ToLower(string(node.State)),
+					})
+				}
+				if int(relationship.connection.TotalCount) > len(relationship.connection.Nodes) {
+					result.Truncated = true
+				}
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
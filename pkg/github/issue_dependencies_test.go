@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueDependencies(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetIssueDependencies(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_dependencies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	query := "query($first:Int!$issueNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){issue(number: $issueNumber){trackedIssues(first: $first){nodes{number,title,state,repository{nameWithOwner}},totalCount},trackedInIssues(first: $first){nodes{number,title,state,repository{nameWithOwner}},totalCount}}}}"
+	vars := map[string]any{"owner": "owner", "repo": "repo", "issueNumber": float64(42), "first": float64(100)}
+	trackedIssue := func(repository string, number int, title, state string) map[string]any {
+		return map[string]any{"number": number, "title": title, "state": state, "repository": map[string]any{"nameWithOwner": repository}}
+	}
+
+	tests := []struct {
+		name           string
+		response       githubv4mock.GQLResponse
+		expectError    bool
+		expectedErrMsg string
+		expected       IssueDependencies
+	}{
+		{
+			name: "tracked and tracking issues",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"issue": map[string]any{
+					"trackedIssues": map[string]any{
+						"nodes": []any{
+							trackedIssue("owner/repo", 43, "Design the schema", "CLOSED"),
+							trackedIssue("owner/api", 7, "Expose the endpoint", "OPEN"),
+						},
+						"totalCount": 2,
+					},
+					"trackedInIssues": map[string]any{
+						"nodes":      []any{trackedIssue("owner/roadmap", 1, "Q3 roadmap", "OPEN")},
+						"totalCount": 3,
+					},
+				}},
+			}),
+			expected: IssueDependencies{
+				Issue: "owner/repo#42",
+				Dependencies: []IssueDependency{
+					{Relationship: "tracks", Repository: "owner/repo", Number: 43, Title: "Design the schema", State: "closed"},
+					{Relationship: "tracks", Repository: "owner/api", Number: 7, Title: "Expose the endpoint", State: "open"},
+					{Relationship: "tracked_in", Repository: "owner/roadmap", Number: 1, Title: "Q3 roadmap", State: "open"},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name:     "tracking not enabled",
+			response: githubv4mock.ErrorResponse("Field 'trackedIssues' doesn't exist on type 'Issue'"),
+			expected: IssueDependencies{
+				Issue:        "owner/repo#42",
+				Dependencies: []IssueDependency{},
+				Note:         "issue tracking is not enabled for this repository, so the issue has no dependencies",
+			},
+		},
+		{
+			name:           "issue not found",
+			response:       githubv4mock.ErrorResponse("Could not resolve to an Issue with the number of 42."),
+			expectError:    true,
+			expectedErrMsg: "failed to get issue dependencies",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query, vars, tc.response))
+			_, handler := GetIssueDependencies(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var dependencies IssueDependencies
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &dependencies))
+			assert.Equal(t, tc.expected, dependencies)
+		})
+	}
+}
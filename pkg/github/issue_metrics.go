@@ -0,0 +1,324 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// issueMetricsDefaultWindow is the time window covered when since is not set.
+	issueMetricsDefaultWindow = 30 * 24 * time.Hour
+	// issueMetricsMaxWindow is the longest time window the metrics are computed for.
+	issueMetricsMaxWindow = 90 * 24 * time.Hour
+	// issueMetricsMaxIssues bounds the number of opened and of closed issues fetched.
+	issueMetricsMaxIssues = 2000
+	// issueMetricsMaxComments bounds the number of comments fetched to find first responses.
+	issueMetricsMaxComments = 5000
+	// issueMetricsTopN is the number of labels and authors reported.
+	issueMetricsTopN = 10
+)
+
+// NameCount is a name and the number of issues it occurs on.
+type NameCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// IssueMetricsLimits reports the caps applied while computing issue metrics.
+type IssueMetricsLimits struct {
+	MaxWindowDays int `json:"max_window_days"`
+	MaxIssues     int `json:"max_issues"`
+	MaxComments   int `json:"max_comments"`
+	// WindowCapped is set when the requested window was longer than MaxWindowDays and since was moved forward.
+	WindowCapped bool `json:"window_capped"`
+	// Truncated is set when more than MaxIssues issues or MaxComments comments matched, so the metrics
+	// only cover the most recently created issues and the oldest comments.
+	Truncated bool `json:"truncated"`
+}
+
+// IssueMetrics are the triage metrics of a repository's issues over a time window.
+type IssueMetrics struct {
+	Repository string    `json:"repository"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+	Opened     int       `json:"opened"`
+	Closed     int       `json:"closed"`
+	// MedianTimeToFirstResponseHours is computed over the issues opened in the window that received a
+	// comment from someone other than their author. It is null when none did.
+	MedianTimeToFirstResponseHours *float64 `json:"median_time_to_first_response_hours"`
+	IssuesWithFirstResponse        int      `json:"issues_with_first_response"`
+	// MedianTimeToCloseHours is computed over the issues closed in the window. It is null when none were.
+	MedianTimeToCloseHours *float64           `json:"median_time_to_close_hours"`
+	TopLabels              []NameCount        `json:"top_labels"`
+	TopAuthors             []NameCount        `json:"top_authors"`
+	Limits                 IssueMetricsLimits `json:"limits"`
+}
+
+// medianHours returns the median of durations in hours, rounded to one decimal, or nil if there are none.
+func medianHours(durations []time.Duration) *float64 {
+	if len(durations) == 0 {
+		return nil
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	hours := math.Round(median.Hours()*10) / 10
+	return &hours
+}
+
+// topCounts returns the n names with the highest counts, breaking ties by name.
+func topCounts(counts map[string]int, n int) []NameCount {
+	top := make([]NameCount, 0, len(counts))
+	for name, count := range counts {
+		top = append(top, NameCount{Name: name, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Name < top[j].Name
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// issueNumberFromURL returns the issue number at the end of an issue API URL, or 0 if there is none.
+func issueNumberFromURL(issueURL string) int {
+	number, err := strconv.Atoi(path.Base(issueURL))
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// withoutPullRequests drops the pull requests the issues API lists along with issues.
+func withoutPullRequests(issues []*github.Issue) []*github.Issue {
+	filtered := issues[:0]
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// computeIssueMetrics aggregates the issues opened in the window, the closed issues updated since the
+// start of the window and the comments on the repository's issues into metrics.
+func computeIssueMetrics(metrics *IssueMetrics, opened, closed []*github.Issue, comments []*github.IssueComment) {
+	inWindow := func(t time.Time) bool {
+		return !t.Before(metrics.Since) && !t.After(metrics.Until)
+	}
+
+	openedByNumber := make(map[int]*github.Issue, len(opened))
+	labels := map[string]int{}
+	authors := map[string]int{}
+	for _, issue := range opened {
+		if !inWindow(issue.GetCreatedAt().Time) {
+			continue
+		}
+		metrics.Opened++
+		openedByNumber[issue.GetNumber()] = issue
+		authors[issue.GetUser().GetLogin()]++
+		for _, label := range issue.Labels {
+			labels[label.GetName()]++
+		}
+	}
+	metrics.TopLabels = topCounts(labels, issueMetricsTopN)
+	metrics.TopAuthors = topCounts(authors, issueMetricsTopN)
+
+	// Comments are listed oldest first, so the first one from someone other than the author is the first response.
+	responded := map[int]bool{}
+	var firstResponses []time.Duration
+	for _, comment := range comments {
+		number := issueNumberFromURL(comment.GetIssueURL())
+		issue, ok := openedByNumber[number]
+		if !ok || responded[number] || comment.GetUser().GetLogin() == issue.GetUser().GetLogin() {
+			continue
+		}
+		responded[number] = true
+		firstResponses = append(firstResponses, comment.GetCreatedAt().Sub(issue.GetCreatedAt().Time))
+	}
+	metrics.IssuesWithFirstResponse = len(firstResponses)
+	metrics.MedianTimeToFirstResponseHours = medianHours(firstResponses)
+
+	var timesToClose []time.Duration
+	for _, issue := range closed {
+		if !inWindow(issue.GetClosedAt().Time) {
+			continue
+		}
+		metrics.Closed++
+		timesToClose = append(timesToClose, issue.GetClosedAt().Sub(issue.GetCreatedAt().Time))
+	}
+	metrics.MedianTimeToCloseHours = medianHours(timesToClose)
+}
+
+// GetIssueMetrics creates a tool to compute triage metrics of a repository's issues over a time window.
+func GetIssueMetrics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_metrics",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_METRICS_DESCRIPTION", fmt.Sprintf("Compute triage metrics of a repository's issues over a time window in a single call: the number of issues opened and closed, the median time to first response (first comment by someone other than the author), the median time to close, and the top labels and authors of the opened issues. Pull requests are not counted. Windows longer than %d days are shortened, and at most %d opened and %d closed issues are considered; the output reports when either cap applied.", int(issueMetricsMaxWindow.Hours()/24), issueMetricsMaxIssues, issueMetricsMaxIssues))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_METRICS_USER_TITLE", "Get issue metrics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the time window in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to 30 days before until"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the time window in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to now"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			untilParam, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			until := time.Now().UTC()
+			if untilParam != "" {
+				if until, err = parseISOTimestamp(untilParam); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse until: %s", err.Error())), nil
+				}
+			}
+			since := until.Add(-issueMetricsDefaultWindow)
+			if sinceParam != "" {
+				if since, err = parseISOTimestamp(sinceParam); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err.Error())), nil
+				}
+			}
+			if !since.Before(until) {
+				return mcp.NewToolResultError("since must be before until"), nil
+			}
+
+			metrics := IssueMetrics{
+				Repository: fmt.Sprintf("%s/%s", owner, repo),
+				Since:      since,
+				Until:      until,
+				Limits: IssueMetricsLimits{
+					MaxWindowDays: int(issueMetricsMaxWindow.Hours() / 24),
+					MaxIssues:     issueMetricsMaxIssues,
+					MaxComments:   issueMetricsMaxComments,
+				},
+			}
+			if until.Sub(since) > issueMetricsMaxWindow {
+				metrics.Since = until.Add(-issueMetricsMaxWindow)
+				metrics.Limits.WindowCapped = true
+			}
+			since = metrics.Since
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var (
+				opened, closed                       []*github.Issue
+				comments                             []*github.IssueComment
+				openedTruncated, closedTruncated     bool
+				commentsTruncated                    bool
+				openedResp, closedResp, commentsResp *github.Response
+			)
+			errs := runBounded(ctx, maxConcurrentRequests,
+				func(ctx context.Context) error {
+					// Newest first, so that listing stops once issues were created before the window.
+					var err error
+					opened, openedTruncated, openedResp, err = collectPages(issueMetricsMaxIssues,
+						func(opts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+							issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+								State:       "all",
+								Sort:        "created",
+								Direction:   "desc",
+								ListOptions: opts,
+							})
+							return withoutPullRequests(issues), resp, err
+						},
+						func(issue *github.Issue) bool { return issue.GetCreatedAt().Before(since) },
+					)
+					return err
+				},
+				func(ctx context.Context) error {
+					// Issues closed in the window were updated in it, whenever they were created.
+					var err error
+					closed, closedTruncated, closedResp, err = collectPages(issueMetricsMaxIssues,
+						func(opts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+							issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+								State:       "closed",
+								Sort:        "updated",
+								Direction:   "desc",
+								Since:       since,
+								ListOptions: opts,
+							})
+							return withoutPullRequests(issues), resp, err
+						},
+						nil,
+					)
+					return err
+				},
+				func(ctx context.Context) error {
+					// An issue number of 0 lists the comments on all issues of the repository.
+					var err error
+					comments, commentsTruncated, commentsResp, err = collectPages(issueMetricsMaxComments,
+						func(opts github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+							return client.Issues.ListComments(ctx, owner, repo, 0, &github.IssueListCommentsOptions{
+								Sort:        github.Ptr("created"),
+								Direction:   github.Ptr("asc"),
+								Since:       &since,
+								ListOptions: opts,
+							})
+						},
+						nil,
+					)
+					return err
+				},
+			)
+			if errs[0] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issues", openedResp, errs[0]), nil
+			}
+			if errs[1] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list closed issues", closedResp, errs[1]), nil
+			}
+			if errs[2] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue comments", commentsResp, errs[2]), nil
+			}
+
+			metrics.Limits.Truncated = openedTruncated || closedTruncated || commentsTruncated
+			computeIssueMetrics(&metrics, opened, closed, comments)
+			return MarshalledTextResult(metrics), nil
+		}
+}
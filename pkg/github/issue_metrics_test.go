@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueMetrics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueMetrics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_metrics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	at := func(s string) *github.Timestamp {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &github.Timestamp{Time: ts}
+	}
+	issue := func(number int, author, created string, labels ...string) *github.Issue {
+		i := &github.Issue{
+			Number:    github.Ptr(number),
+			User:      &github.User{Login: github.Ptr(author)},
+			CreatedAt: at(created),
+		}
+		for _, label := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(label)})
+		}
+		return i
+	}
+	closedIssue := func(number int, created, closed string) *github.Issue {
+		i := issue(number, "alice", created)
+		i.ClosedAt = at(closed)
+		return i
+	}
+	comment := func(number int, author, created string) *github.IssueComment {
+		return &github.IssueComment{
+			IssueURL:  github.Ptr(fmt.Sprintf("https://api.github.com/repos/owner/repo/issues/%d", number)),
+			User:      &github.User{Login: github.Ptr(author)},
+			CreatedAt: at(created),
+		}
+	}
+
+	pullRequest := issue(6, "alice", "2025-01-25T00:00:00Z")
+	pullRequest.PullRequestLinks = &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/6")}
+
+	openedFirstPage := []*github.Issue{
+		pullRequest,
+		issue(5, "alice", "2025-01-20T00:00:00Z", "bug"),
+		issue(4, "bob", "2025-01-10T00:00:00Z", "bug", "triage"),
+	}
+	openedSecondPage := []*github.Issue{
+		issue(3, "alice", "2025-01-05T00:00:00Z", "question"),
+		issue(2, "carol", "2024-12-20T00:00:00Z", "bug"),
+		issue(1, "carol", "2024-12-01T00:00:00Z", "bug"),
+	}
+	closedIssues := []*github.Issue{
+		closedIssue(7, "2025-01-01T00:00:00Z", "2025-02-05T00:00:00Z"),
+		closedIssue(4, "2025-01-10T00:00:00Z", "2025-01-12T00:00:00Z"),
+		closedIssue(1, "2024-12-01T00:00:00Z", "2025-01-01T12:00:00Z"),
+	}
+	comments := []*github.IssueComment{
+		comment(4, "carol", "2025-01-10T12:00:00Z"),
+		comment(5, "alice", "2025-01-20T01:00:00Z"),
+		comment(5, "carol", "2025-01-20T06:00:00Z"),
+		comment(4, "dave", "2025-01-21T00:00:00Z"),
+		comment(6, "carol", "2025-01-25T01:00:00Z"),
+	}
+
+	issuesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("state") == "closed":
+			assert.Equal(t, "updated", query.Get("sort"))
+			assert.Equal(t, "2025-01-01T00:00:00Z", query.Get("since"))
+			mockResponse(t, http.StatusOK, closedIssues)(w, r)
+		case query.Get("page") == "2":
+			mockResponse(t, http.StatusOK, openedSecondPage)(w, r)
+		default:
+			assert.Equal(t, "all", query.Get("state"))
+			assert.Equal(t, "created", query.Get("sort"))
+			assert.Equal(t, "desc", query.Get("direction"))
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues?state=all&page=2>; rel="next"`)
+			mockResponse(t, http.StatusOK, openedFirstPage)(w, r)
+		}
+	})
+	commentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "created", r.URL.Query().Get("sort"))
+		assert.Equal(t, "asc", r.URL.Query().Get("direction"))
+		mockResponse(t, http.StatusOK, comments)(w, r)
+	})
+
+	float := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       *IssueMetrics
+	}{
+		{
+			name: "computes metrics over the window",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposIssuesByOwnerByRepo, issuesHandler),
+				mock.WithRequestMatchHandler(mock.GetReposIssuesCommentsByOwnerByRepo, commentsHandler),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2025-01-01",
+				"until": "2025-01-31T00:00:00Z",
+			},
+			expected: &IssueMetrics{
+				Repository:                     "owner/repo",
+				Since:                          at("2025-01-01T00:00:00Z").Time,
+				Until:                          at("2025-01-31T00:00:00Z").Time,
+				Opened:                         3,
+				Closed:                         2,
+				MedianTimeToFirstResponseHours: float(9),
+				IssuesWithFirstResponse:        2,
+				MedianTimeToCloseHours:         float(402),
+				TopLabels:                      []NameCount{{Name: "bug", Count: 2}, {Name: "question", Count: 1}, {Name: "triage", Count: 1}},
+				TopAuthors:                     []NameCount{{Name: "alice", Count: 2}, {Name: "bob", Count: 1}},
+				Limits: IssueMetricsLimits{
+					MaxWindowDays: 90,
+					MaxIssues:     2000,
+					MaxComments:   5000,
+				},
+			},
+		},
+		{
+			name: "caps long windows",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo, []*github.Issue{}, []*github.Issue{}),
+				mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepo, []*github.IssueComment{}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2024-01-01",
+				"until": "2025-01-31T00:00:00Z",
+			},
+			expected: &IssueMetrics{
+				Repository: "owner/repo",
+				Since:      at("2024-11-02T00:00:00Z").Time,
+				Until:      at("2025-01-31T00:00:00Z").Time,
+				TopLabels:  []NameCount{},
+				TopAuthors: []NameCount{},
+				Limits: IssueMetricsLimits{
+					MaxWindowDays: 90,
+					MaxIssues:     2000,
+					MaxComments:   5000,
+					WindowCapped:  true,
+				},
+			},
+		},
+		{
+			name:         "since after until",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2025-02-01",
+				"until": "2025-01-31T00:00:00Z",
+			},
+			expectError:    true,
+			expectedErrMsg: "since must be before until",
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "last week",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to parse since",
+		},
+		{
+			name: "listing issues fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+				mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepo, []*github.IssueComment{}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssueMetrics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var metrics IssueMetrics
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &metrics))
+			assert.Equal(t, *tc.expected, metrics)
+		})
+	}
+}
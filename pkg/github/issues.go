@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
+	"gopkg.in/yaml.v3"
 )
 
 // IssueFragment represents a fragment of an issue node in the GraphQL API.
@@ -206,6 +208,108 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 		}
 }
 
+// maxIssuesBatchSize bounds the number of issues get_issues_batch fetches in one call.
+const maxIssuesBatchSize = 50
+
+// IssuesBatchItem is an issue fetched by get_issues_batch, or why it could not be fetched.
+type IssuesBatchItem struct {
+	Issue *github.Issue `json:"issue,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// GetIssuesBatch creates a tool to get several issues or pull requests of a repository at once.
+func GetIssuesBatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issues_batch",
+			mcp.WithDescription(t("TOOL_GET_ISSUES_BATCH_DESCRIPTION", fmt.Sprintf("Get the details of up to %d issues or pull requests of a GitHub repository in one call. Returns an object keyed by number; numbers that could not be fetched, such as missing ones, have an error instead of failing the whole call.", maxIssuesBatchSize))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUES_BATCH_USER_TITLE", "Get several issues"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description("The numbers of the issues or pull requests"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumbers, err := OptionalInt64ArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(issueNumbers) == 0 {
+				return mcp.NewToolResultError("missing required parameter: issue_numbers"), nil
+			}
+			if len(issueNumbers) > maxIssuesBatchSize {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d issue_numbers can be fetched at once, got %d", maxIssuesBatchSize, len(issueNumbers))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Each number is fetched once, even if it is listed several times
+			var numbers []int
+			items := make(map[int]IssuesBatchItem, len(issueNumbers))
+			for _, number := range issueNumbers {
+				if _, ok := items[int(number)]; !ok {
+					items[int(number)] = IssuesBatchItem{}
+					numbers = append(numbers, int(number))
+				}
+			}
+
+			fetched := make([]IssuesBatchItem, len(numbers))
+			tasks := make([]func(context.Context) error, len(numbers))
+			for i, number := range numbers {
+				tasks[i] = func(ctx context.Context) error {
+					issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+					if err != nil {
+						if resp != nil && resp.StatusCode == http.StatusNotFound {
+							fetched[i].Error = "issue not found"
+						} else {
+							fetched[i].Error = fmt.Sprintf("failed to get issue: %v", err)
+						}
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get issue", resp, err)
+						return nil
+					}
+					_ = resp.Body.Close()
+					fetched[i].Issue = issue
+					return nil
+				}
+			}
+			for i, err := range runBounded(ctx, maxConcurrentRequests, tasks...) {
+				if err != nil {
+					fetched[i].Error = err.Error()
+				}
+			}
+			for i, number := range numbers {
+				items[number] = fetched[i]
+			}
+
+			return MarshalledTextResult(items), nil
+		}
+}
+
 // ListIssueTypes creates a tool to list defined issue types for an organization. This can be used to understand supported issue type values for creating or updating issues.
 func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 
@@ -253,9 +357,186 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
+// issueTemplatesDir is the directory GitHub reads issue templates from.
+const issueTemplatesDir = ".github/ISSUE_TEMPLATE"
+
+// IssueTemplate is the parsed metadata of an issue template.
+type IssueTemplate struct {
+	Path      string   `json:"path"`
+	Type      string   `json:"type"`
+	Name      string   `json:"name,omitempty"`
+	About     string   `json:"about,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// templateStringList is a YAML value that may be either a comma separated string or a list of strings,
+// as both forms are accepted for labels and assignees in issue templates.
+type templateStringList []string
+
+func (l *templateStringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, item := range strings.Split(value.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*l = append(*l, item)
+			}
+		}
+		return nil
+	}
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// parseIssueTemplate parses the metadata of a markdown issue template (from its YAML front matter)
+// or of an issue form (a YAML file).
+func parseIssueTemplate(filePath, content string) IssueTemplate {
+	template := IssueTemplate{Path: filePath, Type: "form"}
+
+	metadata := content
+	if strings.EqualFold(path.Ext(filePath), ".md") {
+		template.Type = "markdown"
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+		rest, ok := strings.CutPrefix(content, "---\n")
+		if !ok {
+			template.Error = "missing YAML front matter"
+			return template
+		}
+		end := strings.Index(rest, "\n---")
+		if end < 0 {
+			template.Error = "unterminated YAML front matter"
+			return template
+		}
+		metadata = rest[:end]
+	}
+
+	var fields struct {
+		Name        string             `yaml:"name"`
+		About       string             `yaml:"about"`
+		Description string             `yaml:"description"`
+		Title       string             `yaml:"title"`
+		Labels      templateStringList `yaml:"labels"`
+		Assignees   templateStringList `yaml:"assignees"`
+	}
+	if err := yaml.Unmarshal([]byte(metadata), &fields); err != nil {
+		template.Error = fmt.Sprintf("failed to parse template metadata: %s", err)
+		return template
+	}
+
+	template.Name = fields.Name
+	template.About = fields.About
+	if template.About == "" {
+		// Issue forms use description rather than about.
+		template.About = fields.Description
+	}
+	template.Title = fields.Title
+	template.Labels = fields.Labels
+	template.Assignees = fields.Assignees
+	return template
+}
+
+// ListIssueTemplates creates a tool to list the issue templates of a repository.
+func ListIssueTemplates(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_templates",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_TEMPLATES_DESCRIPTION", "List the issue templates of a GitHub repository, including their name, description, default title, labels and assignees. Use this before creating an issue to follow the repository's conventions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_TEMPLATES_USER_TITLE", "List issue templates"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Git ref to read templates from. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.RepositoryContentGetOptions{Ref: ref}
+			_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, issueTemplatesDir, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					// The repository has no issue templates.
+					return MarshalledTextResult([]IssueTemplate{}), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list issue templates",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			templates := []IssueTemplate{}
+			for _, entry := range entries {
+				if entry.GetType() != "file" {
+					continue
+				}
+				name := strings.ToLower(entry.GetName())
+				switch path.Ext(name) {
+				case ".md", ".yml", ".yaml":
+				default:
+					continue
+				}
+				// config.yml configures the template chooser and is not a template itself.
+				if name == "config.yml" || name == "config.yaml" {
+					continue
+				}
+
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, entry.GetPath(), opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get issue template %s", entry.GetPath()),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				content, err := file.GetContent()
+				if err != nil {
+					templates = append(templates, IssueTemplate{Path: entry.GetPath(), Error: fmt.Sprintf("failed to decode content: %s", err)})
+					continue
+				}
+				templates = append(templates, parseIssueTemplate(entry.GetPath(), content))
+			}
+
+			return MarshalledTextResult(templates), nil
+		}
+}
+
 // AddIssueComment creates a tool to add a comment to an issue.
 func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("add_issue_comment",
+	return withIdempotencyKey(createIdempotency,
+		mcp.NewTool("add_issue_comment",
 			mcp.WithDescription(t("TOOL_ADD_ISSUE_COMMENT_DESCRIPTION", "Add a comment to a specific issue in a GitHub repository.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_ADD_ISSUE_COMMENT_USER_TITLE", "Add comment to issue"),
@@ -306,7 +587,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 			}
 			createdComment, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, comment)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create comment: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create comment", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -324,7 +605,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 			}
 
 			return mcp.NewToolResultText(string(r)), nil
-		}
+		})
 }
 
 // AddSubIssue creates a tool to add a sub-issue to a parent issue.
@@ -436,11 +717,12 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Description("Issue number"),
 			),
 			mcp.WithNumber("page",
-				mcp.Description("Page number for pagination (default: 1)"),
+				mcp.Description("Deprecated, use page_token instead. Page number for pagination (default: 1)"),
 			),
 			mcp.WithNumber("per_page",
 				mcp.Description("Number of results per page (max 100, default: 30)"),
 			),
+			withPageToken(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -463,6 +745,19 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			token, err := optionalPageToken(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if token != nil {
+				if token.Page == 0 {
+					return mcp.NewToolResultError("invalid page_token: it is the token of a cursor, not of a numbered page"), nil
+				}
+				page = token.Page
+				if token.PerPage > 0 {
+					perPage = token.PerPage
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -500,7 +795,7 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, perPage)), nil
 		}
 
 }
@@ -748,7 +1043,8 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 
 // CreateIssue creates a tool to create a new issue in a GitHub repository.
 func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_issue",
+	return withIdempotencyKey(createIdempotency,
+		mcp.NewTool("create_issue",
 			mcp.WithDescription(t("TOOL_CREATE_ISSUE_DESCRIPTION", "Create a new issue in a GitHub repository.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_ISSUE_USER_TITLE", "Open new issue"),
@@ -860,7 +1156,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			}
 			issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create issue: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create issue", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -878,7 +1174,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			}
 
 			return mcp.NewToolResultText(string(r)), nil
-		}
+		})
 }
 
 // ListIssues creates a tool to list and filter repository issues
@@ -1089,7 +1385,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal issues: %w", err)
 			}
-			return mcp.NewToolResultText(string(out)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(out)), nextGraphQLPageToken(bool(pageInfo.HasNextPage), string(pageInfo.EndCursor), int(*paginationParams.First))), nil
 		}
 }
 
@@ -1230,7 +1526,7 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			}
 			updatedIssue, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, issueRequest)
 			if err != nil {
-				return nil, fmt.Errorf("failed to update issue: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update issue", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -1321,7 +1617,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
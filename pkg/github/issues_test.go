@@ -122,6 +122,111 @@ func Test_GetIssue(t *testing.T) {
 	}
 }
 
+func Test_GetIssuesBatch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssuesBatch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issues_batch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	// Issues 1 and 3 exist, 2 does not and 4 fails
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				number := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				switch number {
+				case "1", "3":
+					n := int(number[0] - '0')
+					mockResponse(t, http.StatusOK, &github.Issue{
+						Number: github.Ptr(n),
+						Title:  github.Ptr(fmt.Sprintf("Issue %d", n)),
+					})(w, r)
+				case "2":
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				default:
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`)(w, r)
+				}
+			}),
+		),
+	)
+
+	tooMany := make([]any, maxIssuesBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = float64(i + 1)
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       map[int]IssuesBatchItem
+	}{
+		{
+			name: "isolates missing and failing issues",
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2), float64(3), float64(4), float64(1)},
+			},
+			expected: map[int]IssuesBatchItem{
+				1: {Issue: &github.Issue{Number: github.Ptr(1), Title: github.Ptr("Issue 1")}},
+				2: {Error: "issue not found"},
+				3: {Issue: &github.Issue{Number: github.Ptr(3), Title: github.Ptr("Issue 3")}},
+			},
+		},
+		{
+			name: "no issue numbers",
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: issue_numbers",
+		},
+		{
+			name: "too many issue numbers",
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": tooMany,
+			},
+			expectError:    true,
+			expectedErrMsg: "at most 50 issue_numbers can be fetched at once, got 51",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := GetIssuesBatch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned map[int]IssuesBatchItem
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 4)
+			assert.Contains(t, returned[4].Error, "failed to get issue")
+			delete(returned, 4)
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
 func Test_AddIssueComment(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -681,6 +786,35 @@ func Test_CreateIssue(t *testing.T) {
 			expectError:    false,
 			expectedErrMsg: "missing required parameter: title",
 		},
+		{
+			name: "issue creation fails validation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{
+							"message": "Validation Failed",
+							"errors": [
+								{"resource": "Issue", "field": "milestone", "code": "invalid"},
+								{"resource": "Issue", "field": "assignees", "code": "custom", "message": "ghost cannot be assigned"}
+							]
+						}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"title":     "Test Issue",
+				"milestone": float64(99),
+				"assignees": []any{"ghost"},
+			},
+			expectError: false,
+			expectedErrMsg: "GitHub rejected these fields:\n" +
+				"- Issue.milestone: invalid (the value is not formatted correctly)\n" +
+				"- Issue.assignees: custom: ghost cannot be assigned",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2989,3 +3123,200 @@ func Test_ListIssueTypes(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseIssueTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected IssueTemplate
+	}{
+		{
+			name:    "markdown template with comma separated labels",
+			path:    ".github/ISSUE_TEMPLATE/bug_report.md",
+			content: "---\nname: Bug report\nabout: Create a report to help us improve\ntitle: \"[BUG] \"\nlabels: bug, triage\nassignees: ''\n---\n\n**Describe the bug**\n",
+			expected: IssueTemplate{
+				Path:   ".github/ISSUE_TEMPLATE/bug_report.md",
+				Type:   "markdown",
+				Name:   "Bug report",
+				About:  "Create a report to help us improve",
+				Title:  "[BUG] ",
+				Labels: []string{"bug", "triage"},
+			},
+		},
+		{
+			name:    "issue form with label list",
+			path:    ".github/ISSUE_TEMPLATE/feature.yml",
+			content: "name: Feature request\ndescription: Suggest an idea\nlabels: [\"enhancement\"]\nassignees:\n  - octocat\nbody:\n  - type: textarea\n    attributes:\n      label: Idea\n",
+			expected: IssueTemplate{
+				Path:      ".github/ISSUE_TEMPLATE/feature.yml",
+				Type:      "form",
+				Name:      "Feature request",
+				About:     "Suggest an idea",
+				Labels:    []string{"enhancement"},
+				Assignees: []string{"octocat"},
+			},
+		},
+		{
+			name:    "markdown template without front matter",
+			path:    ".github/ISSUE_TEMPLATE/plain.md",
+			content: "Just describe the problem.\n",
+			expected: IssueTemplate{
+				Path:  ".github/ISSUE_TEMPLATE/plain.md",
+				Type:  "markdown",
+				Error: "missing YAML front matter",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseIssueTemplate(tc.path, tc.content))
+		})
+	}
+}
+
+func Test_ListIssueTemplates(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueTemplates(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_issue_templates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockDirectory := []*github.RepositoryContent{
+		{Type: github.Ptr("file"), Name: github.Ptr("bug_report.md"), Path: github.Ptr(".github/ISSUE_TEMPLATE/bug_report.md")},
+		{Type: github.Ptr("file"), Name: github.Ptr("config.yml"), Path: github.Ptr(".github/ISSUE_TEMPLATE/config.yml")},
+		{Type: github.Ptr("file"), Name: github.Ptr("feature.yml"), Path: github.Ptr(".github/ISSUE_TEMPLATE/feature.yml")},
+	}
+	mockFiles := map[string]*github.RepositoryContent{
+		"/repos/owner/repo/contents/.github/ISSUE_TEMPLATE/bug_report.md": {
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr(""),
+			Content:  github.Ptr("---\nname: Bug report\nabout: Report a bug\nlabels: bug\n---\n\nDescribe the bug.\n"),
+		},
+		"/repos/owner/repo/contents/.github/ISSUE_TEMPLATE/feature.yml": {
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr(""),
+			Content:  github.Ptr("name: Feature request\ndescription: Suggest an idea\nlabels:\n  - enhancement\nbody: []\n"),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedTemplates []IssueTemplate
+		expectedErrMsg    string
+	}{
+		{
+			name: "lists markdown and form templates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/contents/.github/ISSUE_TEMPLATE" {
+							mockResponse(t, http.StatusOK, mockDirectory)(w, r)
+							return
+						}
+						file, ok := mockFiles[r.URL.Path]
+						if !ok {
+							t.Errorf("unexpected request for %s", r.URL.Path)
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						mockResponse(t, http.StatusOK, file)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedTemplates: []IssueTemplate{
+				{
+					Path:   ".github/ISSUE_TEMPLATE/bug_report.md",
+					Type:   "markdown",
+					Name:   "Bug report",
+					About:  "Report a bug",
+					Labels: []string{"bug"},
+				},
+				{
+					Path:   ".github/ISSUE_TEMPLATE/feature.yml",
+					Type:   "form",
+					Name:   "Feature request",
+					About:  "Suggest an idea",
+					Labels: []string{"enhancement"},
+				},
+			},
+		},
+		{
+			name: "repository without templates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedTemplates: []IssueTemplate{},
+		},
+		{
+			name: "listing templates fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list issue templates",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListIssueTemplates(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedTemplates []IssueTemplate
+			err = json.Unmarshal([]byte(textContent.Text), &returnedTemplates)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTemplates, returnedTemplates)
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// closingKeywordReference matches the issues a pull request body closes with a keyword, such as
+// "Fixes #12" or "closes octo-org/octo-repo#34".
+var closingKeywordReference = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+([\w.-]+/[\w.-]+)?#(\d+)\b`)
+
+// maxTimelinePages bounds how many pages of an issue timeline get_linked_prs_for_issue reads.
+const maxTimelinePages = 10
+
+// LinkedIssue is an issue a pull request closes when merged.
+type LinkedIssue struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title,omitempty"`
+	State      string `json:"state,omitempty"`
+	// Source is "closing_reference" for issues GitHub links, "body" for issues only referenced with
+	// a closing keyword in the body, or "both".
+	Source string `json:"source"`
+}
+
+// LinkedPullRequest is a pull request that references an issue.
+type LinkedPullRequest struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	// State is "open", "closed" or "merged".
+	State   string `json:"state"`
+	Draft   bool   `json:"draft"`
+	Author  string `json:"author,omitempty"`
+	HTMLURL string `json:"html_url"`
+}
+
+// IssueLinkResult is the output type of the link_issue_to_pr and unlink_issue_from_pr tools.
+type IssueLinkResult struct {
+	PullNumber int    `json:"pull_number"`
+	Issue      string `json:"issue"`
+	// Linked is whether GitHub links the issue to the pull request after the change.
+	Linked bool   `json:"linked"`
+	Note   string `json:"note,omitempty"`
+}
+
+// closingIssueReference is an issue GitHub links to a pull request as closed by it.
+type closingIssueReference struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	State      githubv4.String
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+// closingIssuesQuery gets the body of a pull request and the issues GitHub links to it as closed by it.
+type closingIssuesQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ID                      githubv4.ID
+			Body                    githubv4.String
+			ClosingIssuesReferences struct {
+				Nodes []closingIssueReference
+			} `graphql:"closingIssuesReferences(first: 25)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// updatePullRequestBodyMutation updates the body of a pull request and gets the issues GitHub links
+// to it afterwards.
+type updatePullRequestBodyMutation struct {
+	UpdatePullRequest struct {
+		PullRequest struct {
+			ClosingIssuesReferences struct {
+				Nodes []closingIssueReference
+			} `graphql:"closingIssuesReferences(first: 25)"`
+		}
+	} `graphql:"updatePullRequest(input: $input)"`
+}
+
+// closingKeyword is a closing keyword reference in a pull request body.
+type closingKeyword struct {
+	// start and end are the offsets of the whole match, and refStart the offset of the issue
+	// reference after the keyword.
+	start, end, refStart int
+	repository           string
+	number               int
+}
+
+// closingKeywords finds the closing keyword references in the body of a pull request of owner/repo.
+// References without a repository are resolved to owner/repo.
+func closingKeywords(body, owner, repo string) []closingKeyword {
+	var keywords []closingKeyword
+	for _, loc := range closingKeywordReference.FindAllStringSubmatchIndex(body, -1) {
+		number, err := strconv.Atoi(body[loc[4]:loc[5]])
+		if err != nil {
+			continue
+		}
+		keyword := closingKeyword{start: loc[0], end: loc[1], refStart: loc[4] - 1, repository: owner + "/" + repo, number: number}
+		if loc[2] >= 0 {
+			keyword.refStart = loc[2]
+			keyword.repository = body[loc[2]:loc[3]]
+		}
+		keywords = append(keywords, keyword)
+	}
+	return keywords
+}
+
+// issueKey identifies an issue regardless of the case of its repository.
+func issueKey(repository string, number int) string {
+	return strings.ToLower(repository) + "#" + strconv.Itoa(number)
+}
+
+func queryClosingIssues(ctx context.Context, gqlClient *githubv4.Client, owner, repo string, pullNumber int) (*closingIssuesQuery, error) {
+	var query closingIssuesQuery
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+	}
+	if err := gqlClient.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+	return &query, nil
+}
+
+// linkedIssuesOfPullRequest returns the issues GitHub links to a pull request, followed by those only
+// referenced with a closing keyword in its body, such as when the pull request does not target the
+// default branch.
+func linkedIssuesOfPullRequest(ctx context.Context, gqlClient *githubv4.Client, owner, repo string, pullNumber int) ([]LinkedIssue, error) {
+	query, err := queryClosingIssues(ctx, gqlClient, owner, repo, pullNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked issues: %w", err)
+	}
+
+	issues := []LinkedIssue{}
+	linked := make(map[string]int)
+	for _, node := range query.Repository.PullRequest.ClosingIssuesReferences.Nodes {
+		issue := LinkedIssue{
+			Repository: string(node.Repository.NameWithOwner),
+			Number:     int(node.Number),
+			Title:      string(node.Title),
+			State:      strings.ToLower(string(node.State)),
+			Source:     "closing_reference",
+		}
+		linked[issueKey(issue.Repository, issue.Number)] = len(issues)
+		issues = append(issues, issue)
+	}
+
+	for _, keyword := range closingKeywords(string(query.Repository.PullRequest.Body), owner, repo) {
+		key := issueKey(keyword.repository, keyword.number)
+		if i, ok := linked[key]; ok {
+			if issues[i].Source == "closing_reference" {
+				issues[i].Source = "both"
+			}
+			continue
+		}
+		linked[key] = len(issues)
+		issues = append(issues, LinkedIssue{Repository: keyword.repository, Number: keyword.number, Source: "body"})
+	}
+	return issues, nil
+}
+
+// GetLinkedIssuesForPR creates a tool to get the issues a pull request closes.
+func GetLinkedIssuesForPR(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_linked_issues_for_pr",
+			mcp.WithDescription(t("TOOL_GET_LINKED_ISSUES_FOR_PR_DESCRIPTION", "Get the issues a pull request closes when merged, as shown in its Development section, and the issues its body references with a closing keyword such as \"Fixes #12\". The source of each issue tells which of the two it comes from.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LINKED_ISSUES_FOR_PR_USER_TITLE", "Get linked issues for pull request"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			issues, err := linkedIssuesOfPullRequest(ctx, gqlClient, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get linked issues", err), nil
+			}
+
+			return MarshalledTextResult(issues), nil
+		}
+}
+
+// GetLinkedPRsForIssue creates a tool to get the pull requests that reference an issue.
+func GetLinkedPRsForIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_linked_prs_for_issue",
+			mcp.WithDescription(t("TOOL_GET_LINKED_PRS_FOR_ISSUE_DESCRIPTION", "Get the pull requests that reference an issue, from the cross-references on its timeline. This includes the pull requests that close it, as shown in its Development section, and those that only mention it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LINKED_PRS_FOR_ISSUE_USER_TITLE", "Get linked pull requests for issue"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pullRequests := []LinkedPullRequest{}
+			seen := make(map[string]bool)
+			opts := &github.ListOptions{PerPage: 100}
+			for page := 0; page < maxTimelinePages; page++ {
+				events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list issue timeline",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, event := range events {
+					if event.GetEvent() != "cross-referenced" || event.GetSource().GetIssue() == nil {
+						continue
+					}
+					issue := event.GetSource().GetIssue()
+					if !issue.IsPullRequest() {
+						continue
+					}
+					repository := issue.GetRepository().GetFullName()
+					if repository == "" {
+						repository = owner + "/" + repo
+					}
+					if seen[issueKey(repository, issue.GetNumber())] {
+						continue
+					}
+					seen[issueKey(repository, issue.GetNumber())] = true
+
+					state := issue.GetState()
+					if issue.GetPullRequestLinks().GetMergedAt() != (github.Timestamp{}) {
+						state = "merged"
+					}
+					pullRequests = append(pullRequests, LinkedPullRequest{
+						Repository: repository,
+						Number:     issue.GetNumber(),
+						Title:      issue.GetTitle(),
+						State:      state,
+						Draft:      issue.GetDraft(),
+						Author:     issue.GetUser().GetLogin(),
+						HTMLURL:    issue.GetHTMLURL(),
+					})
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			return MarshalledTextResult(pullRequests), nil
+		}
+}
+
+// LinkIssueToPR creates a tool to link an issue to a pull request so that merging it closes the issue.
+func LinkIssueToPR(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return issueLinkTool("link_issue_to_pr",
+			t("TOOL_LINK_ISSUE_TO_PR_DESCRIPTION", "Link an issue to a pull request, so that it appears in the Development section of both and is closed when the pull request is merged. This adds a closing keyword such as \"Closes #12\" to the pull request body. GitHub only links issues this way for pull requests that target the default branch."),
+			t("TOOL_LINK_ISSUE_TO_PR_USER_TITLE", "Link issue to pull request"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return updateIssueLink(ctx, getGQLClient, request, true)
+		}
+}
+
+// UnlinkIssueFromPR creates a tool to stop a pull request from closing an issue.
+func UnlinkIssueFromPR(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return issueLinkTool("unlink_issue_from_pr",
+			t("TOOL_UNLINK_ISSUE_FROM_PR_DESCRIPTION", "Unlink an issue from a pull request, so that merging the pull request no longer closes it. This removes the closing keywords for the issue from the pull request body, leaving a plain reference. Issues linked by hand in the Development section can only be unlinked on GitHub."),
+			t("TOOL_UNLINK_ISSUE_FROM_PR_USER_TITLE", "Unlink issue from pull request"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return updateIssueLink(ctx, getGQLClient, request, false)
+		}
+}
+
+// issueLinkTool returns the definition of link_issue_to_pr or unlink_issue_from_pr, which take the
+// same parameters.
+func issueLinkTool(name, description, title string) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        title,
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner of the pull request"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name of the pull request"),
+		),
+		mcp.WithNumber("pullNumber",
+			mcp.Required(),
+			mcp.Description("Pull request number"),
+		),
+		mcp.WithNumber("issue_number",
+			mcp.Required(),
+			mcp.Description("Issue number"),
+		),
+		mcp.WithString("issue_owner",
+			mcp.Description("Repository owner of the issue, if it is not in the repository of the pull request"),
+		),
+		mcp.WithString("issue_repo",
+			mcp.Description("Repository name of the issue, if it is not in the repository of the pull request"),
+		),
+	)
+}
+
+// updateIssueLink is the handler of link_issue_to_pr and unlink_issue_from_pr.
+func updateIssueLink(ctx context.Context, getGQLClient GetGQLClientFn, request mcp.CallToolRequest, link bool) (*mcp.CallToolResult, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pullNumber, err := RequiredInt(request, "pullNumber")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueNumber, err := RequiredInt(request, "issue_number")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueOwner, err := OptionalParam[string](request, "issue_owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueRepo, err := OptionalParam[string](request, "issue_repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if (issueOwner == "") != (issueRepo == "") {
+		return mcp.NewToolResultError("issue_owner and issue_repo must be set together"), nil
+	}
+
+	issueRepository := owner + "/" + repo
+	reference := fmt.Sprintf("#%d", issueNumber)
+	if issueOwner != "" && !strings.EqualFold(issueOwner+"/"+issueRepo, issueRepository) {
+		issueRepository = issueOwner + "/" + issueRepo
+		reference = fmt.Sprintf("%s#%d", issueRepository, issueNumber)
+	}
+	key := issueKey(issueRepository, issueNumber)
+
+	gqlClient, err := getGQLClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+	query, err := queryClosingIssues(ctx, gqlClient, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil
+	}
+	pullRequest := query.Repository.PullRequest
+	body := string(pullRequest.Body)
+
+	result := IssueLinkResult{PullNumber: pullNumber, Issue: fmt.Sprintf("%s#%d", issueRepository, issueNumber)}
+	var newBody string
+	if link {
+		for _, keyword := range closingKeywords(body, owner, repo) {
+			if issueKey(keyword.repository, keyword.number) == key {
+				result.Linked = linkedTo(pullRequest.ClosingIssuesReferences.Nodes, key)
+				result.Note = "the pull request body already has a closing keyword for the issue"
+				return MarshalledTextResult(result), nil
+			}
+		}
+		newBody = "Closes " + reference
+		if trimmed := strings.TrimRight(body, " \t\r\n"); trimmed != "" {
+			newBody = trimmed + "\n\n" + newBody
+		}
+	} else {
+		var b strings.Builder
+		last := 0
+		for _, keyword := range closingKeywords(body, owner, repo) {
+			if issueKey(keyword.repository, keyword.number) != key {
+				continue
+			}
+			// Keep the reference so that the issue is still mentioned, only without the keyword
+			b.WriteString(body[last:keyword.start])
+			b.WriteString(body[keyword.refStart:keyword.end])
+			last = keyword.end
+		}
+		if last == 0 {
+			result.Linked = linkedTo(pullRequest.ClosingIssuesReferences.Nodes, key)
+			if result.Linked {
+				result.Note = "the issue was linked in the Development section rather than with a closing keyword, unlink it there on GitHub"
+			} else {
+				result.Note = "the issue is not linked to the pull request"
+			}
+			return MarshalledTextResult(result), nil
+		}
+		b.WriteString(body[last:])
+		newBody = b.String()
+	}
+
+	var mutation updatePullRequestBodyMutation
+	input := githubv4.UpdatePullRequestInput{
+		PullRequestID: pullRequest.ID,
+		Body:          githubv4.NewString(githubv4.String(newBody)),
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update pull request body", err), nil
+	}
+
+	result.Linked = linkedTo(mutation.UpdatePullRequest.PullRequest.ClosingIssuesReferences.Nodes, key)
+	switch {
+	case link && !result.Linked:
+		result.Note = "the closing keyword was added, but GitHub did not link the issue, which happens when the pull request does not target the default branch"
+	case !link && result.Linked:
+		result.Note = "the closing keywords were removed, but the issue is still linked in the Development section, unlink it there on GitHub"
+	}
+	return MarshalledTextResult(result), nil
+}
+
+// linkedTo reports whether the issue identified by key is among the closing issue references of a
+// pull request.
+func linkedTo(references []closingIssueReference, key string) bool {
+	for _, reference := range references {
+		if issueKey(string(reference.Repository.NameWithOwner), int(reference.Number)) == key {
+			return true
+		}
+	}
+	return false
+}
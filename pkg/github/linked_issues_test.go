@@ -0,0 +1,403 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closingIssuesResponse is the response to closingIssuesQuery for a pull request with body that
+// GitHub links to the issues of owner/repo numbered in linked.
+func closingIssuesResponse(body string, linked ...int) githubv4mock.GQLResponse {
+	nodes := []any{}
+	for _, number := range linked {
+		nodes = append(nodes, map[string]any{"number": number, "title": "Linked issue", "state": "OPEN", "repository": map[string]any{"nameWithOwner": "owner/repo"}})
+	}
+	return githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"pullRequest": map[string]any{
+			"id":                      "PR_42",
+			"body":                    body,
+			"closingIssuesReferences": map[string]any{"nodes": nodes},
+		}},
+	})
+}
+
+// updatePullRequestBodyResponse is the response to updatePullRequestBodyMutation when GitHub links
+// the issues of owner/repo numbered in linked afterwards.
+func updatePullRequestBodyResponse(linked ...int) githubv4mock.GQLResponse {
+	nodes := []any{}
+	for _, number := range linked {
+		nodes = append(nodes, map[string]any{"number": number, "title": "Linked issue", "state": "OPEN", "repository": map[string]any{"nameWithOwner": "owner/repo"}})
+	}
+	return githubv4mock.DataResponse(map[string]any{
+		"updatePullRequest": map[string]any{"pullRequest": map[string]any{
+			"closingIssuesReferences": map[string]any{"nodes": nodes},
+		}},
+	})
+}
+
+var closingIssuesVars = map[string]any{
+	"owner": githubv4.String("owner"),
+	"repo":  githubv4.String("repo"),
+	"prNum": githubv4.Int(42),
+}
+
+func Test_GetLinkedIssuesForPR(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetLinkedIssuesForPR(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_linked_issues_for_pr", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	t.Run("merges closing references and closing keywords", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars,
+				closingIssuesResponse("Fixes #10 and closes octo-org/infra#7", 10, 11)),
+		)
+		_, handler := GetLinkedIssuesForPR(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var issues []LinkedIssue
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &issues))
+		assert.Equal(t, []LinkedIssue{
+			{Repository: "owner/repo", Number: 10, Title: "Linked issue", State: "open", Source: "both"},
+			{Repository: "owner/repo", Number: 11, Title: "Linked issue", State: "open", Source: "closing_reference"},
+			{Repository: "octo-org/infra", Number: 7, Source: "body"},
+		}, issues)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars,
+				githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 42.")),
+		)
+		_, handler := GetLinkedIssuesForPR(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get linked issues")
+	})
+}
+
+func Test_GetLinkedPRsForIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLinkedPRsForIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_linked_prs_for_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	crossReference := func(number int, repository, state string, mergedAt *time.Time) *github.Timeline {
+		issue := &github.Issue{
+			Number:           github.Ptr(number),
+			Title:            github.Ptr("Fix the uploader"),
+			State:            github.Ptr(state),
+			HTMLURL:          github.Ptr("https://github.com/" + repository + "/pull/1"),
+			User:             &github.User{Login: github.Ptr("author")},
+			Repository:       &github.Repository{FullName: github.Ptr(repository)},
+			PullRequestLinks: &github.PullRequestLinks{},
+		}
+		if mergedAt != nil {
+			issue.PullRequestLinks.MergedAt = &github.Timestamp{Time: *mergedAt}
+		}
+		return &github.Timeline{Event: github.Ptr("cross-referenced"), Source: &github.Source{Issue: issue}}
+	}
+	merged := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mentioningIssue := crossReference(3, "owner/repo", "open", nil)
+	mentioningIssue.Source.Issue.PullRequestLinks = nil
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedPRs    []LinkedPullRequest
+	}{
+		{
+			name: "lists cross-referencing pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/10/timeline").andThen(
+						mockResponse(t, http.StatusOK, []*github.Timeline{
+							{Event: github.Ptr("labeled")},
+							crossReference(12, "owner/repo", "open", nil),
+							mentioningIssue,
+							crossReference(5, "octo-org/infra", "closed", &merged),
+							crossReference(12, "owner/repo", "open", nil),
+						}),
+					),
+				),
+			),
+			expectedPRs: []LinkedPullRequest{
+				{Repository: "owner/repo", Number: 12, Title: "Fix the uploader", State: "open", Author: "author", HTMLURL: "https://github.com/owner/repo/pull/1"},
+				{Repository: "octo-org/infra", Number: 5, Title: "Fix the uploader", State: "merged", Author: "author", HTMLURL: "https://github.com/octo-org/infra/pull/1"},
+			},
+		},
+		{
+			name: "no pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber, []*github.Timeline{}),
+			),
+			expectedPRs: []LinkedPullRequest{},
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list issue timeline",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLinkedPRsForIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(10),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var pullRequests []LinkedPullRequest
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pullRequests))
+			assert.Equal(t, tc.expectedPRs, pullRequests)
+		})
+	}
+}
+
+func Test_LinkIssueToPR(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := LinkIssueToPR(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "link_issue_to_pr", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "issue_number"})
+
+	updateBody := func(body string, linked ...int) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			updatePullRequestBodyMutation{},
+			githubv4.UpdatePullRequestInput{
+				PullRequestID: githubv4.ID("PR_42"),
+				Body:          githubv4.NewString(githubv4.String(body)),
+			},
+			nil,
+			updatePullRequestBodyResponse(linked...),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult IssueLinkResult
+	}{
+		{
+			name: "adds a closing keyword",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars, closingIssuesResponse("Retry uploads.\n")),
+				updateBody("Retry uploads.\n\nCloses #10", 10),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"issue_number": float64(10),
+			},
+			expectedResult: IssueLinkResult{PullNumber: 42, Issue: "owner/repo#10", Linked: true},
+		},
+		{
+			name: "issue in another repository",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars, closingIssuesResponse("")),
+				updateBody("Closes octo-org/infra#7"),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"issue_number": float64(7),
+				"issue_owner":  "octo-org",
+				"issue_repo":   "infra",
+			},
+			expectedResult: IssueLinkResult{
+				PullNumber: 42,
+				Issue:      "octo-org/infra#7",
+				Note:       "the closing keyword was added, but GitHub did not link the issue, which happens when the pull request does not target the default branch",
+			},
+		},
+		{
+			name: "already linked",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars, closingIssuesResponse("Fixes #10", 10)),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"issue_number": float64(10),
+			},
+			expectedResult: IssueLinkResult{
+				PullNumber: 42,
+				Issue:      "owner/repo#10",
+				Linked:     true,
+				Note:       "the pull request body already has a closing keyword for the issue",
+			},
+		},
+		{
+			name:         "issue owner without issue repo",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"issue_number": float64(7),
+				"issue_owner":  "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "issue_owner and issue_repo must be set together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := LinkIssueToPR(stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var returned IssueLinkResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_UnlinkIssueFromPR(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UnlinkIssueFromPR(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unlink_issue_from_pr", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "issue_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedResult IssueLinkResult
+	}{
+		{
+			name: "removes the closing keywords",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars,
+					closingIssuesResponse("Fixes #10, closes #11.\n\nResolves owner/repo#10", 10, 11)),
+				githubv4mock.NewMutationMatcher(
+					updatePullRequestBodyMutation{},
+					githubv4.UpdatePullRequestInput{
+						PullRequestID: githubv4.ID("PR_42"),
+						Body:          githubv4.NewString(githubv4.String("#10, closes #11.\n\nowner/repo#10")),
+					},
+					nil,
+					updatePullRequestBodyResponse(11),
+				),
+			),
+			expectedResult: IssueLinkResult{PullNumber: 42, Issue: "owner/repo#10"},
+		},
+		{
+			name: "linked in the Development section",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars, closingIssuesResponse("Retry uploads", 10)),
+			),
+			expectedResult: IssueLinkResult{
+				PullNumber: 42,
+				Issue:      "owner/repo#10",
+				Linked:     true,
+				Note:       "the issue was linked in the Development section rather than with a closing keyword, unlink it there on GitHub",
+			},
+		},
+		{
+			name: "not linked",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingIssuesQuery{}, closingIssuesVars, closingIssuesResponse("Mentions #10")),
+			),
+			expectedResult: IssueLinkResult{
+				PullNumber: 42,
+				Issue:      "owner/repo#10",
+				Note:       "the issue is not linked to the pull request",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := UnlinkIssueFromPR(stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"issue_number": float64(10),
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var returned IssueLinkResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMaxHunkLines is the default number of patch lines returned per side of a conflicting file.
+	defaultMaxHunkLines = 100
+
+	// maxCompareFiles is the most files the compare API returns for a comparison.
+	maxCompareFiles = 300
+
+	conflictHeuristicNote = "The GitHub API does not expose conflict markers. These files were changed on both the head and the base branch since the merge base, so they are the likely, but not certain, source of the conflicts."
+)
+
+// mergeableStateNotes explains the mergeable_state values that are not caused by conflicts.
+var mergeableStateNotes = map[string]string{
+	"clean":     "The pull request has no merge conflicts and can be merged.",
+	"unstable":  "The pull request has no merge conflicts, but some status checks are failing.",
+	"blocked":   "The pull request has no merge conflicts, but merging is blocked, usually by required reviews or status checks.",
+	"behind":    "The pull request has no merge conflicts, but the head branch is behind the base branch and must be updated before merging.",
+	"has_hooks": "The pull request has no merge conflicts and can be merged, pre-receive hooks will run on merge.",
+	"draft":     "The pull request is a draft and cannot be merged until it is marked ready for review.",
+	"unknown":   "GitHub is still computing whether the pull request can be merged. Try again in a few seconds.",
+}
+
+// MergeConflictReport describes why a pull request cannot be merged and which files likely conflict.
+type MergeConflictReport struct {
+	Mergeable        *bool             `json:"mergeable"`
+	MergeableState   string            `json:"mergeable_state"`
+	Base             string            `json:"base"`
+	Head             string            `json:"head"`
+	MergeBase        string            `json:"merge_base,omitempty"`
+	Heuristic        bool              `json:"heuristic"`
+	Incomplete       bool              `json:"incomplete,omitempty"`
+	Note             string            `json:"note"`
+	ConflictingFiles []ConflictingFile `json:"conflicting_files"`
+}
+
+// ConflictingFile is a file changed on both sides of a pull request since the merge base.
+type ConflictingFile struct {
+	Path       string `json:"path"`
+	HeadStatus string `json:"head_status"`
+	BaseStatus string `json:"base_status"`
+	HeadPatch  string `json:"head_patch,omitempty"`
+	BasePatch  string `json:"base_patch,omitempty"`
+}
+
+// GetMergeConflicts creates a tool to inspect why a pull request cannot be merged.
+func GetMergeConflicts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_merge_conflicts",
+			mcp.WithDescription(t("TOOL_GET_MERGE_CONFLICTS_DESCRIPTION", "Explain why a pull request cannot be merged. When it has merge conflicts, list the files changed on both the head and base branch since the merge base, which is a heuristic for the conflicting files, optionally with both sides' changes to help propose a resolution.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MERGE_CONFLICTS_USER_TITLE", "Get pull request merge conflicts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("include_hunks",
+				mcp.Description("Include the head and base branch patches of each conflicting file"),
+			),
+			mcp.WithNumber("max_hunk_lines",
+				mcp.Description("Maximum number of patch lines to return per side of each conflicting file"),
+				mcp.Min(1),
+				mcp.DefaultNumber(defaultMaxHunkLines),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeHunks, err := OptionalParam[bool](request, "include_hunks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxHunkLines, err := OptionalIntParamWithDefault(request, "max_hunk_lines", defaultMaxHunkLines)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxHunkLines < 1 {
+				return mcp.NewToolResultError("max_hunk_lines must be at least 1"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			report := MergeConflictReport{
+				Mergeable:        pr.Mergeable,
+				MergeableState:   pr.GetMergeableState(),
+				Base:             pr.GetBase().GetRef(),
+				Head:             pr.GetHead().GetLabel(),
+				ConflictingFiles: []ConflictingFile{},
+			}
+
+			if report.MergeableState != "dirty" {
+				report.Note = mergeableStateNotes[report.MergeableState]
+				if pr.GetMerged() {
+					report.Note = "The pull request has already been merged."
+				} else if pr.GetState() == "closed" {
+					report.Note = "The pull request is closed."
+				}
+				return MarshalledTextResult(report), nil
+			}
+
+			// Files the head branch changed since the merge base
+			headComparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, pr.GetBase().GetRef(), pr.GetHead().GetSHA(), nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to compare head with base",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			mergeBase := headComparison.GetMergeBaseCommit().GetSHA()
+			report.MergeBase = mergeBase
+
+			// Files the base branch changed since the merge base
+			baseComparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, mergeBase, pr.GetBase().GetRef(), nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to compare base with merge base",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			report.Heuristic = true
+			report.Note = conflictHeuristicNote
+			report.Incomplete = len(headComparison.Files) >= maxCompareFiles || len(baseComparison.Files) >= maxCompareFiles
+			report.ConflictingFiles = overlappingFiles(headComparison.Files, baseComparison.Files, includeHunks, maxHunkLines)
+
+			return MarshalledTextResult(report), nil
+		}
+}
+
+// overlappingFiles returns the files changed in both head and base, matching renamed files by either name.
+func overlappingFiles(headFiles, baseFiles []*github.CommitFile, includeHunks bool, maxHunkLines int) []ConflictingFile {
+	baseByPath := make(map[string]*github.CommitFile, len(baseFiles))
+	for _, file := range baseFiles {
+		baseByPath[file.GetFilename()] = file
+		if previous := file.GetPreviousFilename(); previous != "" {
+			baseByPath[previous] = file
+		}
+	}
+
+	conflicts := []ConflictingFile{}
+	seen := map[string]bool{}
+	for _, headFile := range headFiles {
+		baseFile, ok := baseByPath[headFile.GetFilename()]
+		if !ok && headFile.GetPreviousFilename() != "" {
+			baseFile, ok = baseByPath[headFile.GetPreviousFilename()]
+		}
+		if !ok || seen[headFile.GetFilename()] {
+			continue
+		}
+		seen[headFile.GetFilename()] = true
+
+		conflict := ConflictingFile{
+			Path:       headFile.GetFilename(),
+			HeadStatus: headFile.GetStatus(),
+			BaseStatus: baseFile.GetStatus(),
+		}
+		if includeHunks {
+			conflict.HeadPatch = truncatePatch(headFile.GetPatch(), maxHunkLines)
+			conflict.BasePatch = truncatePatch(baseFile.GetPatch(), maxHunkLines)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Path < conflicts[j].Path })
+	return conflicts
+}
@@ -0,0 +1,219 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMergeConflicts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMergeConflicts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_merge_conflicts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "include_hunks")
+	assert.Contains(t, tool.InputSchema.Properties, "max_hunk_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	dirtyPR := &github.PullRequest{
+		Number:         github.Ptr(42),
+		State:          github.Ptr("open"),
+		Mergeable:      github.Ptr(false),
+		MergeableState: github.Ptr("dirty"),
+		Base:           &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("base111")},
+		Head:           &github.PullRequestBranch{Ref: github.Ptr("feature"), Label: github.Ptr("contributor:feature"), SHA: github.Ptr("head222")},
+	}
+
+	compareHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/compare/main...head222":
+			mockResponse(t, http.StatusOK, &github.CommitsComparison{
+				MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("mb333")},
+				Files: []*github.CommitFile{
+					{Filename: github.Ptr("go.mod"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1,3 +1,3 @@\n-go 1.22\n+go 1.23")},
+					{Filename: github.Ptr("pkg/new.go"), PreviousFilename: github.Ptr("pkg/old.go"), Status: github.Ptr("renamed")},
+					{Filename: github.Ptr("docs/feature.md"), Status: github.Ptr("added")},
+				},
+			})(w, r)
+		case "/repos/owner/repo/compare/mb333...main":
+			mockResponse(t, http.StatusOK, &github.CommitsComparison{
+				Files: []*github.CommitFile{
+					{Filename: github.Ptr("go.mod"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1,3 +1,3 @@\n-go 1.22\n+go 1.24")},
+					{Filename: github.Ptr("pkg/old.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -10 +10 @@\n-a\n+b")},
+					{Filename: github.Ptr("README.md"), Status: github.Ptr("modified")},
+				},
+			})(w, r)
+		default:
+			t.Errorf("unexpected compare request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedReport MergeConflictReport
+	}{
+		{
+			name: "dirty pull request lists overlapping files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					dirtyPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					compareHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedReport: MergeConflictReport{
+				Mergeable:      github.Ptr(false),
+				MergeableState: "dirty",
+				Base:           "main",
+				Head:           "contributor:feature",
+				MergeBase:      "mb333",
+				Heuristic:      true,
+				Note:           conflictHeuristicNote,
+				ConflictingFiles: []ConflictingFile{
+					{Path: "go.mod", HeadStatus: "modified", BaseStatus: "modified"},
+					{Path: "pkg/new.go", HeadStatus: "renamed", BaseStatus: "modified"},
+				},
+			},
+		},
+		{
+			name: "dirty pull request with truncated hunks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					dirtyPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					compareHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"include_hunks":  true,
+				"max_hunk_lines": float64(2),
+			},
+			expectedReport: MergeConflictReport{
+				Mergeable:      github.Ptr(false),
+				MergeableState: "dirty",
+				Base:           "main",
+				Head:           "contributor:feature",
+				MergeBase:      "mb333",
+				Heuristic:      true,
+				Note:           conflictHeuristicNote,
+				ConflictingFiles: []ConflictingFile{
+					{
+						Path:       "go.mod",
+						HeadStatus: "modified",
+						BaseStatus: "modified",
+						HeadPatch:  "@@ -1,3 +1,3 @@\n-go 1.22\n[PATCH TRUNCATED] 1 lines omitted",
+						BasePatch:  "@@ -1,3 +1,3 @@\n-go 1.22\n[PATCH TRUNCATED] 1 lines omitted",
+					},
+					{
+						Path:       "pkg/new.go",
+						HeadStatus: "renamed",
+						BaseStatus: "modified",
+						BasePatch:  "@@ -10 +10 @@\n-a\n[PATCH TRUNCATED] 1 lines omitted",
+					},
+				},
+			},
+		},
+		{
+			name: "blocked pull request has no conflicts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{
+						State:          github.Ptr("open"),
+						Mergeable:      github.Ptr(true),
+						MergeableState: github.Ptr("blocked"),
+						Base:           &github.PullRequestBranch{Ref: github.Ptr("main")},
+						Head:           &github.PullRequestBranch{Label: github.Ptr("owner:fix")},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(7),
+			},
+			expectedReport: MergeConflictReport{
+				Mergeable:        github.Ptr(true),
+				MergeableState:   "blocked",
+				Base:             "main",
+				Head:             "owner:fix",
+				Note:             mergeableStateNotes["blocked"],
+				ConflictingFiles: []ConflictingFile{},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMergeConflicts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var report MergeConflictReport
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+			assert.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
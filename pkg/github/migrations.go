@@ -0,0 +1,538 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// migrationArchiveRetention is how long GitHub keeps the archive of a migration after it is exported.
+const migrationArchiveRetention = 7 * 24 * time.Hour
+
+// MigrationStatus is the state of an organization migration.
+type MigrationStatus struct {
+	ID                 int64    `json:"id"`
+	GUID               string   `json:"guid"`
+	State              string   `json:"state"`
+	Done               bool     `json:"done"`
+	Repositories       []string `json:"repositories"`
+	LockRepositories   bool     `json:"lock_repositories"`
+	ExcludeAttachments bool     `json:"exclude_attachments"`
+	CreatedAt          string   `json:"created_at"`
+	UpdatedAt          string   `json:"updated_at"`
+	// Elapsed is how long the migration took if it is done, and how long it has been running otherwise.
+	Elapsed        string `json:"elapsed,omitempty"`
+	ElapsedSeconds int64  `json:"elapsed_seconds,omitempty"`
+	// ArchiveExpiresAt is when the archive of an exported migration is deleted.
+	ArchiveExpiresAt string `json:"archive_expires_at,omitempty"`
+}
+
+// deprecatedAlias returns tool under the name it had before it was renamed, so that clients that
+// call it by that name keep working for a release. The description points to the current name.
+func deprecatedAlias(alias string, tool server.ServerTool) server.ServerTool {
+	current := tool.Tool.Name
+	tool.Tool.Name = alias
+	tool.Tool.Description = fmt.Sprintf("Deprecated, use %s instead. %s", current, tool.Tool.Description)
+	return tool
+}
+
+// newMigrationStatus converts a migration into its status as of now.
+func newMigrationStatus(migration *github.Migration, now time.Time) MigrationStatus {
+	status := MigrationStatus{
+		ID:                 migration.GetID(),
+		GUID:               migration.GetGUID(),
+		State:              migration.GetState(),
+		Repositories:       []string{},
+		LockRepositories:   migration.GetLockRepositories(),
+		ExcludeAttachments: migration.GetExcludeAttachments(),
+		CreatedAt:          migration.GetCreatedAt(),
+		UpdatedAt:          migration.GetUpdatedAt(),
+	}
+	for _, repo := range migration.Repositories {
+		status.Repositories = append(status.Repositories, repo.GetFullName())
+	}
+
+	// The state only changes to exported or failed once the migration is done, so it last changed then
+	status.Done = status.State == "exported" || status.State == "failed"
+	createdAt, err := time.Parse(time.RFC3339, status.CreatedAt)
+	if err != nil {
+		return status
+	}
+	end := now
+	if status.Done {
+		if updatedAt, err := time.Parse(time.RFC3339, status.UpdatedAt); err == nil {
+			end = updatedAt
+		}
+	}
+	elapsed := max(end.Sub(createdAt), 0).Round(time.Second)
+	status.Elapsed = elapsed.String()
+	status.ElapsedSeconds = int64(elapsed.Seconds())
+	if status.State == "exported" {
+		status.ArchiveExpiresAt = end.Add(migrationArchiveRetention).UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// startMigrationRequest is the body of the request to start an organization migration.
+type startMigrationRequest struct {
+	Repositories         []string `json:"repositories"`
+	LockRepositories     bool     `json:"lock_repositories,omitempty"`
+	ExcludeAttachments   bool     `json:"exclude_attachments,omitempty"`
+	ExcludeReleases      bool     `json:"exclude_releases,omitempty"`
+	ExcludeOwnerProjects bool     `json:"exclude_owner_projects,omitempty"`
+	ExcludeMetadata      bool     `json:"exclude_metadata,omitempty"`
+	ExcludeGitData       bool     `json:"exclude_git_data,omitempty"`
+}
+
+// StartRepoMigration creates a tool to start exporting repositories of an organization.
+func StartRepoMigration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("start_repo_migration",
+			mcp.WithDescription(t("TOOL_START_REPO_MIGRATION_DESCRIPTION", "Start exporting repositories of an organization into a migration archive, such as to move them to another organization or GitHub instance. Poll get_repo_migration until it is done, then download the archive with download_repo_migration_archive. Requires an organization owner.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_START_REPO_MIGRATION_USER_TITLE", "Start repository migration"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization that owns the repositories"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description("Names of the repositories to export, without the organization"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("lock_repositories",
+				mcp.Description("Lock the repositories while they are exported so that they cannot change, which is needed to move them. Unlock them with unlock_repo_for_migration. Defaults to false"),
+			),
+			mcp.WithBoolean("exclude_attachments",
+				mcp.Description("Leave the attachments of issues and pull requests out of the archive. Defaults to false"),
+			),
+			mcp.WithBoolean("exclude_releases",
+				mcp.Description("Leave the releases out of the archive. Defaults to false"),
+			),
+			mcp.WithBoolean("exclude_owner_projects",
+				mcp.Description("Leave the projects owned by the organization or users out of the archive. Defaults to false"),
+			),
+			mcp.WithBoolean("exclude_metadata",
+				mcp.Description("Only export the git data of the repositories, leaving out metadata such as issues and pull requests. Defaults to false"),
+			),
+			mcp.WithBoolean("exclude_git_data",
+				mcp.Description("Only export the metadata of the repositories, leaving out their git data. Defaults to false"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repos, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repos) == 0 {
+				return mcp.NewToolResultError("repositories must name at least one repository"), nil
+			}
+			body := startMigrationRequest{Repositories: repos}
+			for name, option := range map[string]*bool{
+				"lock_repositories":      &body.LockRepositories,
+				"exclude_attachments":    &body.ExcludeAttachments,
+				"exclude_releases":       &body.ExcludeReleases,
+				"exclude_owner_projects": &body.ExcludeOwnerProjects,
+				"exclude_metadata":       &body.ExcludeMetadata,
+				"exclude_git_data":       &body.ExcludeGitData,
+			} {
+				if *option, err = OptionalParam[bool](request, name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			if body.ExcludeMetadata && body.ExcludeGitData {
+				return mcp.NewToolResultError("exclude_metadata and exclude_git_data cannot both be set, as nothing would be exported"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not support all the options of a migration, so the request is made directly
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("orgs/%s/migrations", org), body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var migration github.Migration
+			resp, err := client.Do(ctx, req, &migration)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to start migration", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(newMigrationStatus(&migration, time.Now())), nil
+		}
+}
+
+// GetRepoMigration creates a tool to get the state of an organization migration.
+func GetRepoMigration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_migration",
+			mcp.WithDescription(t("TOOL_GET_REPO_MIGRATION_DESCRIPTION", "Get the state of an organization migration (pending, exporting, exported or failed), whether it is done, and how long it took or has been running. Once exported, the archive can be downloaded with download_repo_migration_archive until archive_expires_at.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_MIGRATION_USER_TITLE", "Get repository migration"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization the migration was started in"),
+			),
+			mcp.WithNumber("migration_id",
+				mcp.Required(),
+				mcp.Description("ID of the migration, as returned by start_repo_migration"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			migrationID, err := RequiredInt(request, "migration_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			migration, resp, err := client.Migrations.MigrationStatus(ctx, org, int64(migrationID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get migration status", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(newMigrationStatus(migration, time.Now())), nil
+		}
+}
+
+// migrationStates are the states of an organization migration.
+var migrationStates = []string{"pending", "exporting", "exported", "failed"}
+
+// ListRepoMigrations creates a tool to list the migrations of an organization.
+func ListRepoMigrations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_migrations",
+			mcp.WithDescription(t("TOOL_LIST_REPO_MIGRATIONS_DESCRIPTION", "List the migrations of an organization, most recent first, with their state (pending, exporting, exported or failed) and the repositories they export.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPO_MIGRATIONS_USER_TITLE", "List repository migrations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("state",
+				mcp.Description("Only list migrations in this state. The API does not filter migrations, so pages can have fewer migrations than perPage"),
+				mcp.Enum(migrationStates...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "" && !slices.Contains(migrationStates, state) {
+				return mcp.NewToolResultError(fmt.Sprintf("state must be one of %s, got %q", strings.Join(migrationStates, ", "), state)), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			migrations, resp, err := client.Migrations.ListMigrations(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list migrations", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			now := time.Now()
+			result := make([]MigrationStatus, 0, len(migrations))
+			for _, migration := range migrations {
+				if state == "" || migration.GetState() == state {
+					result = append(result, newMigrationStatus(migration, now))
+				}
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// MigrationRepository is a repository exported by an organization migration.
+type MigrationRepository struct {
+	FullName string `json:"full_name"`
+	Private  bool   `json:"private"`
+	Archived bool   `json:"archived"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// ListReposForMigration creates a tool to list the repositories exported by an organization migration.
+func ListReposForMigration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repos_for_migration",
+			mcp.WithDescription(t("TOOL_LIST_REPOS_FOR_MIGRATION_DESCRIPTION", "List the repositories exported by an organization migration, such as to unlock each of them with unlock_repo_for_migration once they were moved.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOS_FOR_MIGRATION_USER_TITLE", "List repositories of migration"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization the migration was started in"),
+			),
+			mcp.WithNumber("migration_id",
+				mcp.Required(),
+				mcp.Description("ID of the migration, as returned by start_repo_migration"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			migrationID, err := RequiredInt(request, "migration_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method to list the repositories of a migration
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%s/migrations/%d/repositories?page=%d&per_page=%d", org, migrationID, pagination.Page, pagination.PerPage), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var repos []*github.Repository
+			resp, err := client.Do(ctx, req, &repos)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list migration repositories", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MigrationRepository, 0, len(repos))
+			for _, repo := range repos {
+				result = append(result, MigrationRepository{
+					FullName: repo.GetFullName(),
+					Private:  repo.GetPrivate(),
+					Archived: repo.GetArchived(),
+					HTMLURL:  repo.GetHTMLURL(),
+				})
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// MigrationArchive is the output type of the download_repo_migration_archive tool.
+type MigrationArchive struct {
+	URL string `json:"url"`
+	// ExpiresAt is when the URL stops working, if it says so.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	Note      string `json:"note"`
+}
+
+// migrationArchiveURLExpiry returns when a signed archive URL expires, or false if it does not say.
+func migrationArchiveURLExpiry(archiveURL string) (time.Time, bool) {
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+	query := u.Query()
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return signedAt.Add(time.Duration(seconds) * time.Second), true
+}
+
+// DownloadRepoMigrationArchive creates a tool to get the URL of the archive of an organization migration.
+func DownloadRepoMigrationArchive(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_repo_migration_archive",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_REPO_MIGRATION_ARCHIVE_DESCRIPTION", "Get the download URL of the archive of an exported organization migration. The URL is short-lived, so download it right away and call this again for a new one if it expired. Returns the URL rather than the archive itself.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_REPO_MIGRATION_ARCHIVE_USER_TITLE", "Get migration archive URL"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization the migration was started in"),
+			),
+			mcp.WithNumber("migration_id",
+				mcp.Required(),
+				mcp.Description("ID of the migration, as returned by start_repo_migration"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			migrationID, err := RequiredInt(request, "migration_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			archiveURL, err := client.Migrations.MigrationArchiveURL(ctx, org, int64(migrationID))
+			if err != nil {
+				// go-github does not return the response, which errors from the API still carry
+				var resp *github.Response
+				var errResponse *github.ErrorResponse
+				if errors.As(err, &errResponse) {
+					resp = &github.Response{Response: errResponse.Response}
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get migration archive URL", resp, err), nil
+			}
+
+			archive := MigrationArchive{
+				URL:  archiveURL,
+				Note: "The URL is short-lived and needs no token, so do not share it. The archive itself is deleted 7 days after the migration was exported.",
+			}
+			if expiresAt, ok := migrationArchiveURLExpiry(archiveURL); ok {
+				archive.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+				archive.Note = fmt.Sprintf("The URL expires at %s and needs no token, so do not share it. The archive itself is deleted 7 days after the migration was exported.", archive.ExpiresAt)
+			}
+			return MarshalledTextResult(archive), nil
+		}
+}
+
+// DeleteRepoMigrationArchive creates a tool to delete the archive of an organization migration.
+func DeleteRepoMigrationArchive(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repo_migration_archive",
+			mcp.WithDescription(t("TOOL_DELETE_REPO_MIGRATION_ARCHIVE_DESCRIPTION", "Delete the archive of an exported organization migration before it expires, such as once it was imported elsewhere. The migration itself remains listed, but its archive can no longer be downloaded.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPO_MIGRATION_ARCHIVE_USER_TITLE", "Delete migration archive"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization the migration was started in"),
+			),
+			mcp.WithNumber("migration_id",
+				mcp.Required(),
+				mcp.Description("ID of the migration, as returned by start_repo_migration"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			migrationID, err := RequiredInt(request, "migration_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Migrations.DeleteMigration(ctx, org, int64(migrationID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete migration archive", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("The archive of migration %d was deleted", migrationID)), nil
+		}
+}
+
+// UnlockRepoForMigration creates a tool to unlock a repository locked by a migration.
+func UnlockRepoForMigration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unlock_repo_for_migration",
+			mcp.WithDescription(t("TOOL_UNLOCK_REPO_FOR_MIGRATION_DESCRIPTION", "Unlock a repository that was locked by a migration started with lock_repositories, so that it can be used again. Only do this once the repository is not going to be moved, or was deleted after it was moved.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNLOCK_REPO_FOR_MIGRATION_USER_TITLE", "Unlock repository after migration"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization the migration was started in"),
+			),
+			mcp.WithNumber("migration_id",
+				mcp.Required(),
+				mcp.Description("ID of the migration, as returned by start_repo_migration"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to unlock, without the organization"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			migrationID, err := RequiredInt(request, "migration_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Migrations.UnlockRepo(ctx, org, int64(migrationID), repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to unlock repository", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Repository %s/%s was unlocked", org, repo)), nil
+		}
+}
@@ -0,0 +1,686 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StartRepoMigration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StartRepoMigration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "start_repo_migration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "repositories"})
+
+	migration := &github.Migration{
+		ID:               github.Ptr(int64(79)),
+		GUID:             github.Ptr("0b989ba4-242f-11e5-81e1-c7b6966d2516"),
+		State:            github.Ptr("pending"),
+		LockRepositories: github.Ptr(true),
+		Repositories:     []*github.Repository{{FullName: github.Ptr("octo-org/hello-world")}},
+		CreatedAt:        github.Ptr(time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)),
+		UpdatedAt:        github.Ptr(time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful start with locked repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsMigrationsByOrg,
+					expectRequestBody(t, map[string]any{
+						"repositories":      []any{"hello-world"},
+						"lock_repositories": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, migration),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":               "octo-org",
+				"repositories":      []any{"hello-world"},
+				"lock_repositories": true,
+			},
+		},
+		{
+			name: "successful start of a metadata only export",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsMigrationsByOrg,
+					expectRequestBody(t, map[string]any{
+						"repositories":           []any{"hello-world"},
+						"lock_repositories":      true,
+						"exclude_git_data":       true,
+						"exclude_releases":       true,
+						"exclude_owner_projects": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, migration),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":                    "octo-org",
+				"repositories":           []any{"hello-world"},
+				"lock_repositories":      true,
+				"exclude_git_data":       true,
+				"exclude_releases":       true,
+				"exclude_owner_projects": true,
+			},
+		},
+		{
+			name:         "nothing to export",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":              "octo-org",
+				"repositories":     []any{"hello-world"},
+				"exclude_metadata": true,
+				"exclude_git_data": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "exclude_metadata and exclude_git_data cannot both be set",
+		},
+		{
+			name:         "no repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"repositories": []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "repositories must name at least one repository",
+		},
+		{
+			name: "not an organization owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsMigrationsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"repositories": []any{"hello-world"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to start migration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := StartRepoMigration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var status MigrationStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, int64(79), status.ID)
+			assert.Equal(t, "pending", status.State)
+			assert.False(t, status.Done)
+			assert.True(t, status.LockRepositories)
+			assert.Equal(t, []string{"octo-org/hello-world"}, status.Repositories)
+			assert.GreaterOrEqual(t, status.ElapsedSeconds, int64(60))
+		})
+	}
+}
+
+func Test_GetRepoMigration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoMigration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_migration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "migration_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus MigrationStatus
+	}{
+		{
+			name: "exported migration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsMigrationsByOrgByMigrationId,
+					&github.Migration{
+						ID:           github.Ptr(int64(79)),
+						GUID:         github.Ptr("0b989ba4-242f-11e5-81e1-c7b6966d2516"),
+						State:        github.Ptr("exported"),
+						Repositories: []*github.Repository{{FullName: github.Ptr("octo-org/hello-world")}},
+						CreatedAt:    github.Ptr("2026-10-18T09:00:00Z"),
+						UpdatedAt:    github.Ptr("2026-10-18T09:12:30Z"),
+					},
+				),
+			),
+			expectedStatus: MigrationStatus{
+				ID:               79,
+				GUID:             "0b989ba4-242f-11e5-81e1-c7b6966d2516",
+				State:            "exported",
+				Done:             true,
+				Repositories:     []string{"octo-org/hello-world"},
+				CreatedAt:        "2026-10-18T09:00:00Z",
+				UpdatedAt:        "2026-10-18T09:12:30Z",
+				Elapsed:          "12m30s",
+				ElapsedSeconds:   750,
+				ArchiveExpiresAt: "2026-10-25T09:12:30Z",
+			},
+		},
+		{
+			name: "failed migration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsMigrationsByOrgByMigrationId,
+					&github.Migration{
+						ID:        github.Ptr(int64(79)),
+						State:     github.Ptr("failed"),
+						CreatedAt: github.Ptr("2026-10-18T09:00:00Z"),
+						UpdatedAt: github.Ptr("2026-10-18T09:00:05Z"),
+					},
+				),
+			),
+			expectedStatus: MigrationStatus{
+				ID:             79,
+				State:          "failed",
+				Done:           true,
+				Repositories:   []string{},
+				CreatedAt:      "2026-10-18T09:00:00Z",
+				UpdatedAt:      "2026-10-18T09:00:05Z",
+				Elapsed:        "5s",
+				ElapsedSeconds: 5,
+			},
+		},
+		{
+			name: "migration not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsByOrgByMigrationId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get migration status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoMigration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":          "octo-org",
+				"migration_id": float64(79),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var status MigrationStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func Test_DownloadRepoMigrationArchive(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadRepoMigrationArchive(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_repo_migration_archive", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "migration_id"})
+
+	signedURL := "https://github-cloud.s3.amazonaws.com/migrations/79/archive.tar.gz?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Date=20261018T091500Z&X-Amz-Expires=300&X-Amz-Signature=abc"
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedArchive MigrationArchive
+	}{
+		{
+			name: "signed URL",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsArchiveByOrgByMigrationId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", signedURL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+			expectedArchive: MigrationArchive{
+				URL:       signedURL,
+				ExpiresAt: "2026-10-18T09:20:00Z",
+				Note:      "The URL expires at 2026-10-18T09:20:00Z and needs no token, so do not share it. The archive itself is deleted 7 days after the migration was exported.",
+			},
+		},
+		{
+			name: "URL without expiry",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsArchiveByOrgByMigrationId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", "https://example.com/archive.tar.gz?token=abc")
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+			expectedArchive: MigrationArchive{
+				URL:  "https://example.com/archive.tar.gz?token=abc",
+				Note: "The URL is short-lived and needs no token, so do not share it. The archive itself is deleted 7 days after the migration was exported.",
+			},
+		},
+		{
+			name: "archive deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsArchiveByOrgByMigrationId,
+					mockResponse(t, http.StatusGone, `{"message": "Gone"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get migration archive URL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DownloadRepoMigrationArchive(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":          "octo-org",
+				"migration_id": float64(79),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var archive MigrationArchive
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &archive))
+			assert.Equal(t, tc.expectedArchive, archive)
+		})
+	}
+}
+
+func Test_UnlockRepoForMigration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnlockRepoForMigration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unlock_repo_for_migration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "migration_id", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful unlock",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMigrationsReposLockByOrgByMigrationIdByRepoName,
+					expectPath(t, "/orgs/octo-org/migrations/79/repos/hello-world/lock").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "repository not locked",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMigrationsReposLockByOrgByMigrationIdByRepoName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to unlock repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UnlockRepoForMigration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":          "octo-org",
+				"migration_id": float64(79),
+				"repo":         "hello-world",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Repository octo-org/hello-world was unlocked", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_ListRepoMigrations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoMigrations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repo_migrations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	migrations := []*github.Migration{
+		{
+			ID:           github.Ptr(int64(80)),
+			State:        github.Ptr("exporting"),
+			Repositories: []*github.Repository{{FullName: github.Ptr("octo-org/hello-world")}},
+			CreatedAt:    github.Ptr("2026-10-18T09:00:00Z"),
+			UpdatedAt:    github.Ptr("2026-10-18T09:00:00Z"),
+		},
+		{
+			ID:           github.Ptr(int64(79)),
+			State:        github.Ptr("exported"),
+			Repositories: []*github.Repository{{FullName: github.Ptr("octo-org/octo-repo")}},
+			CreatedAt:    github.Ptr("2026-10-17T09:00:00Z"),
+			UpdatedAt:    github.Ptr("2026-10-17T09:10:00Z"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedIDs    []int64
+	}{
+		{
+			name: "lists all migrations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsByOrg,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, migrations),
+					),
+				),
+			),
+			requestArgs: map[string]any{"org": "octo-org", "page": float64(2), "perPage": float64(10)},
+			expectedIDs: []int64{80, 79},
+		},
+		{
+			name: "filters by state",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsMigrationsByOrg, migrations),
+			),
+			requestArgs: map[string]any{"org": "octo-org", "state": "exported"},
+			expectedIDs: []int64{79},
+		},
+		{
+			name:           "invalid state",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"org": "octo-org", "state": "done"},
+			expectError:    true,
+			expectedErrMsg: `state must be one of pending, exporting, exported, failed, got "done"`,
+		},
+		{
+			name: "not an organization owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"org": "octo-org"},
+			expectError:    true,
+			expectedErrMsg: "failed to list migrations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoMigrations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var statuses []MigrationStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &statuses))
+			ids := make([]int64, 0, len(statuses))
+			for _, status := range statuses {
+				ids = append(ids, status.ID)
+				assert.NotEmpty(t, status.State)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func Test_ListReposForMigration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListReposForMigration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repos_for_migration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "migration_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       []MigrationRepository
+	}{
+		{
+			name: "lists the repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsRepositoriesByOrgByMigrationId,
+					expectPath(t, "/orgs/octo-org/migrations/79/repositories").andThen(
+						expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+							mockResponse(t, http.StatusOK, []*github.Repository{
+								{
+									FullName: github.Ptr("octo-org/hello-world"),
+									Private:  github.Ptr(true),
+									HTMLURL:  github.Ptr("https://github.com/octo-org/hello-world"),
+								},
+							}),
+						),
+					),
+				),
+			),
+			expected: []MigrationRepository{
+				{FullName: "octo-org/hello-world", Private: true, HTMLURL: "https://github.com/octo-org/hello-world"},
+			},
+		},
+		{
+			name: "migration not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMigrationsRepositoriesByOrgByMigrationId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list migration repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListReposForMigration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":          "octo-org",
+				"migration_id": float64(79),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var repos []MigrationRepository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repos))
+			assert.Equal(t, tc.expected, repos)
+		})
+	}
+}
+
+func Test_DeleteRepoMigrationArchive(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepoMigrationArchive(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repo_migration_archive", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "migration_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMigrationsArchiveByOrgByMigrationId,
+					expectPath(t, "/orgs/octo-org/migrations/79/archive").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "archive already deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMigrationsArchiveByOrgByMigrationId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete migration archive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRepoMigrationArchive(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":          "octo-org",
+				"migration_id": float64(79),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "The archive of migration 79 was deleted", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_DeprecatedMigrationToolAliases(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), stubGetSCIMClientFn(nil), translations.NullTranslationHelper, AssetsConfig{}, TokenPermissionsConfig{}, nil, nil, nil)
+	tools := map[string]mcp.Tool{}
+	for _, name := range []string{"orgs", "migrations"} {
+		toolset, err := tsg.GetToolset(name)
+		require.NoError(t, err)
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = tool.Tool
+		}
+	}
+
+	for alias, current := range map[string]string{
+		"start_repository_migration":        "start_repo_migration",
+		"get_migration_status":              "get_repo_migration",
+		"download_migration_archive":        "download_repo_migration_archive",
+		"unlock_repository_after_migration": "unlock_repo_for_migration",
+	} {
+		require.Contains(t, tools, alias)
+		require.Contains(t, tools, current)
+		assert.True(t, strings.HasPrefix(tools[alias].Description, "Deprecated, use "+current+" instead. "), alias)
+		assert.Equal(t, tools[current].InputSchema, tools[alias].InputSchema, alias)
+		assert.Equal(t, tools[current].Annotations, tools[alias].Annotations, alias)
+	}
+}
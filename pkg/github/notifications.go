@@ -141,7 +141,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, paginationParams.PerPage)), nil
 		}
 }
 
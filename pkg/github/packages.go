@@ -0,0 +1,464 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	DescriptionPackageOrg  = "Organization that owns the package. Leave org and user empty for the packages of the authenticated user"
+	DescriptionPackageUser = "User that owns the package. Leave org and user empty for the packages of the authenticated user"
+	DescriptionPackageName = "Name of the package. For container images this is the image name without the registry, e.g. my-app for ghcr.io/octo-org/my-app"
+)
+
+// packageTypes are the package types of GitHub Packages.
+var packageTypes = []string{"npm", "maven", "rubygems", "docker", "nuget", "container"}
+
+// MinimalPackage is the output type for packages.
+type MinimalPackage struct {
+	ID           int64             `json:"id"`
+	Name         string            `json:"name"`
+	PackageType  string            `json:"package_type"`
+	Visibility   string            `json:"visibility,omitempty"`
+	HTMLURL      string            `json:"html_url,omitempty"`
+	Owner        string            `json:"owner,omitempty"`
+	Repository   string            `json:"repository,omitempty"`
+	VersionCount int64             `json:"version_count"`
+	CreatedAt    *github.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt    *github.Timestamp `json:"updated_at,omitempty"`
+}
+
+// MinimalPackageVersion is the output type for package versions. For container images the name of
+// a version is its manifest digest, which is also returned as Digest, and Tags are its image tags.
+type MinimalPackageVersion struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Digest      string            `json:"digest,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	HTMLURL     string            `json:"html_url,omitempty"`
+	License     string            `json:"license,omitempty"`
+	Description string            `json:"description,omitempty"`
+	CreatedAt   *github.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt   *github.Timestamp `json:"updated_at,omitempty"`
+	DeletedAt   *github.Timestamp `json:"deleted_at,omitempty"`
+}
+
+func convertToMinimalPackage(pkg *github.Package) MinimalPackage {
+	return MinimalPackage{
+		ID:           pkg.GetID(),
+		Name:         pkg.GetName(),
+		PackageType:  pkg.GetPackageType(),
+		Visibility:   pkg.GetVisibility(),
+		HTMLURL:      pkg.GetHTMLURL(),
+		Owner:        pkg.GetOwner().GetLogin(),
+		Repository:   pkg.GetRepository().GetFullName(),
+		VersionCount: pkg.GetVersionCount(),
+		CreatedAt:    pkg.CreatedAt,
+		UpdatedAt:    pkg.UpdatedAt,
+	}
+}
+
+func convertToMinimalPackageVersion(version *github.PackageVersion) MinimalPackageVersion {
+	minimal := MinimalPackageVersion{
+		ID:          version.GetID(),
+		Name:        version.GetName(),
+		HTMLURL:     version.GetHTMLURL(),
+		License:     version.GetLicense(),
+		Description: version.GetDescription(),
+		CreatedAt:   version.CreatedAt,
+		UpdatedAt:   version.UpdatedAt,
+		DeletedAt:   version.DeletedAt,
+	}
+	if metadata, ok := version.GetMetadata(); ok && metadata.GetPackageType() == "container" {
+		minimal.Digest = version.GetName()
+		minimal.Tags = metadata.GetContainer().Tags
+	}
+	if minimal.HTMLURL == "" {
+		minimal.HTMLURL = version.GetPackageHTMLURL()
+	}
+	return minimal
+}
+
+// withPackageOwner adds the org and user parameters that select who owns a package.
+func withPackageOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("org", mcp.Description(DescriptionPackageOrg))(tool)
+		mcp.WithString("user", mcp.Description(DescriptionPackageUser))(tool)
+	}
+}
+
+// packageOwnerParams returns the org and user parameters. At most one of them is set; when neither
+// is, the package belongs to the authenticated user.
+func packageOwnerParams(request mcp.CallToolRequest) (org, user string, err error) {
+	org, err = OptionalParam[string](request, "org")
+	if err != nil {
+		return "", "", err
+	}
+	user, err = OptionalParam[string](request, "user")
+	if err != nil {
+		return "", "", err
+	}
+	if org != "" && user != "" {
+		return "", "", errors.New("only one of org and user can be set")
+	}
+	return org, user, nil
+}
+
+// packageRef returns the parameters that identify a package: its owner, type and name.
+func packageRef(request mcp.CallToolRequest) (org, user, packageType, packageName string, err error) {
+	org, user, err = packageOwnerParams(request)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	packageType, err = RequiredParam[string](request, "package_type")
+	if err != nil {
+		return "", "", "", "", err
+	}
+	packageName, err = RequiredParam[string](request, "package_name")
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return org, user, packageType, packageName, nil
+}
+
+// ListPackages creates a tool to list the packages of an organization or user.
+func ListPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_packages",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGES_DESCRIPTION", "List the GitHub Packages of one type owned by an organization or user, including container images on ghcr.io.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGES_USER_TITLE", "List packages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Type of the packages to list. Container images on ghcr.io are container"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Only list packages with this visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, user, err := packageOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := RequiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if visibility != "" {
+				opts.Visibility = github.Ptr(visibility)
+			}
+
+			var packages []*github.Package
+			var resp *github.Response
+			if org != "" {
+				packages, resp, err = client.Organizations.ListPackages(ctx, org, opts)
+			} else {
+				packages, resp, err = client.Users.ListPackages(ctx, user, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list packages", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalPackages := make([]MinimalPackage, 0, len(packages))
+			for _, pkg := range packages {
+				minimalPackages = append(minimalPackages, convertToMinimalPackage(pkg))
+			}
+
+			return withNextPageToken(MarshalledTextResult(minimalPackages), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// GetPackage creates a tool to get a package of an organization or user.
+func GetPackage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_package",
+			mcp.WithDescription(t("TOOL_GET_PACKAGE_DESCRIPTION", "Get a GitHub Package owned by an organization or user, including its visibility, repository and number of versions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PACKAGE_USER_TITLE", "Get package"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Type of the package"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description(DescriptionPackageName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, user, packageType, packageName, err := packageRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var pkg *github.Package
+			var resp *github.Response
+			if org != "" {
+				pkg, resp, err = client.Organizations.GetPackage(ctx, org, packageType, packageName)
+			} else {
+				pkg, resp, err = client.Users.GetPackage(ctx, user, packageType, packageName)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get package", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalPackage(pkg)), nil
+		}
+}
+
+// ListPackageVersions creates a tool to list the versions of a package.
+func ListPackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_package_versions",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGE_VERSIONS_DESCRIPTION", "List the versions of a GitHub Package, newest first. For container images each version is an image manifest: its name is the manifest digest (sha256:...) and tags lists the image tags pointing at it, so this answers which tag corresponds to a digest and the other way around.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGE_VERSIONS_USER_TITLE", "List package versions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Type of the package"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description(DescriptionPackageName),
+			),
+			mcp.WithString("state",
+				mcp.Description("Whether to list active or deleted versions. Deleted versions can be restored for 30 days"),
+				mcp.Enum("active", "deleted"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, user, packageType, packageName, err := packageRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.PackageListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if state != "" {
+				opts.State = github.Ptr(state)
+			}
+
+			var versions []*github.PackageVersion
+			var resp *github.Response
+			if org != "" {
+				versions, resp, err = client.Organizations.PackageGetAllVersions(ctx, org, packageType, packageName, opts)
+			} else {
+				versions, resp, err = client.Users.PackageGetAllVersions(ctx, user, packageType, packageName, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list package versions", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalVersions := make([]MinimalPackageVersion, 0, len(versions))
+			for _, version := range versions {
+				minimalVersions = append(minimalVersions, convertToMinimalPackageVersion(version))
+			}
+
+			return withNextPageToken(MarshalledTextResult(minimalVersions), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// isLastTaggedVersionError reports whether err is GitHub refusing to delete the last tagged version of a package.
+func isLastTaggedVersionError(resp *github.Response, err error) bool {
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && strings.Contains(strings.ToLower(errResp.Message), "last tagged version")
+}
+
+// DeletePackageVersion creates a tool to delete a version of a package.
+func DeletePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_package_version",
+			mcp.WithDescription(t("TOOL_DELETE_PACKAGE_VERSION_DESCRIPTION", "Delete a version of a GitHub Package, such as a container image manifest. Deleted versions can be restored with restore_package_version for 30 days. GitHub does not allow deleting the last tagged version of a container image.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_PACKAGE_VERSION_USER_TITLE", "Delete package version"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Type of the package"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description(DescriptionPackageName),
+			),
+			mcp.WithNumber("package_version_id",
+				mcp.Required(),
+				mcp.Description("ID of the version to delete, as returned by list_package_versions"),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Required(),
+				mcp.Description("Must be true to confirm that the version should be deleted"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, user, packageType, packageName, err := packageRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirm, err := OptionalParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !confirm {
+				return mcp.NewToolResultError("deleting a package version must be confirmed, set confirm to true"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if org != "" {
+				resp, err = client.Organizations.PackageDeleteVersion(ctx, org, packageType, packageName, int64(versionID))
+			} else {
+				resp, err = client.Users.PackageDeleteVersion(ctx, user, packageType, packageName, int64(versionID))
+			}
+			if err != nil {
+				if isLastTaggedVersionError(resp, err) {
+					return mcp.NewToolResultError(fmt.Sprintf("version %d is the last tagged version of %s, which GitHub does not allow deleting. Tag another version first, or delete the whole package on GitHub", versionID, packageName)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete package version", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":            fmt.Sprintf("Version %d of package %s has been deleted", versionID, packageName),
+				"package_version_id": versionID,
+				"status_code":        resp.StatusCode,
+			}), nil
+		}
+}
+
+// RestorePackageVersion creates a tool to restore a deleted version of a package.
+func RestorePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("restore_package_version",
+			mcp.WithDescription(t("TOOL_RESTORE_PACKAGE_VERSION_DESCRIPTION", "Restore a version of a GitHub Package that was deleted in the last 30 days. List deleted versions with list_package_versions and state deleted.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESTORE_PACKAGE_VERSION_USER_TITLE", "Restore package version"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Type of the package"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description(DescriptionPackageName),
+			),
+			mcp.WithNumber("package_version_id",
+				mcp.Required(),
+				mcp.Description("ID of the deleted version to restore"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, user, packageType, packageName, err := packageRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if org != "" {
+				resp, err = client.Organizations.PackageRestoreVersion(ctx, org, packageType, packageName, int64(versionID))
+			} else {
+				resp, err = client.Users.PackageRestoreVersion(ctx, user, packageType, packageName, int64(versionID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to restore package version", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":            fmt.Sprintf("Version %d of package %s has been restored", versionID, packageName),
+				"package_version_id": versionID,
+				"status_code":        resp.StatusCode,
+			}), nil
+		}
+}
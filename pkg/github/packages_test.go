@@ -0,0 +1,576 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_packages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "user")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type"})
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockPackages := []*github.Package{
+		{
+			ID:           github.Ptr(int64(101)),
+			Name:         github.Ptr("my-app"),
+			PackageType:  github.Ptr("container"),
+			Visibility:   github.Ptr("private"),
+			HTMLURL:      github.Ptr("https://github.com/orgs/octo-org/packages/container/package/my-app"),
+			Owner:        &github.User{Login: github.Ptr("octo-org")},
+			Repository:   &github.Repository{FullName: github.Ptr("octo-org/my-app")},
+			VersionCount: github.Ptr(int64(12)),
+			CreatedAt:    &github.Timestamp{Time: createdAt},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedPackages []MinimalPackage
+	}{
+		{
+			name: "organization packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					expect(t, expectations{
+						path: "/orgs/octo-org/packages",
+						queryParams: map[string]string{
+							"package_type": "container",
+							"visibility":   "private",
+							"page":         "1",
+							"per_page":     "30",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPackages),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"package_type": "container",
+				"visibility":   "private",
+			},
+			expectedPackages: []MinimalPackage{
+				{
+					ID:           101,
+					Name:         "my-app",
+					PackageType:  "container",
+					Visibility:   "private",
+					HTMLURL:      "https://github.com/orgs/octo-org/packages/container/package/my-app",
+					Owner:        "octo-org",
+					Repository:   "octo-org/my-app",
+					VersionCount: 12,
+					CreatedAt:    &github.Timestamp{Time: createdAt},
+				},
+			},
+		},
+		{
+			name: "user packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesByUsername,
+					expectPath(t, "/users/octocat/packages").andThen(
+						mockResponse(t, http.StatusOK, []*github.Package{}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"user":         "octocat",
+				"package_type": "npm",
+			},
+			expectedPackages: []MinimalPackage{},
+		},
+		{
+			name: "authenticated user packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserPackages,
+					expectPath(t, "/user/packages").andThen(
+						mockResponse(t, http.StatusOK, []*github.Package{}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"package_type": "npm",
+			},
+			expectedPackages: []MinimalPackage{},
+		},
+		{
+			name:         "org and user are exclusive",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"user":         "octocat",
+				"package_type": "npm",
+			},
+			expectError:    true,
+			expectedErrMsg: "only one of org and user can be set",
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"package_type": "container",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list packages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var packages []MinimalPackage
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &packages))
+			assert.Equal(t, tc.expectedPackages, packages)
+		})
+	}
+}
+
+func Test_GetPackage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPackage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_package", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organization package",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrgByPackageTypeByPackageName,
+					expectPath(t, "/orgs/octo-org/packages/container/my-app").andThen(
+						mockResponse(t, http.StatusOK, &github.Package{
+							ID:          github.Ptr(int64(101)),
+							Name:        github.Ptr("my-app"),
+							PackageType: github.Ptr("container"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"package_type": "container",
+				"package_name": "my-app",
+			},
+		},
+		{
+			name: "package not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesByUsernameByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Package not found."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"user":         "octocat",
+				"package_type": "npm",
+				"package_name": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get package",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPackage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var pkg MinimalPackage
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pkg))
+			assert.Equal(t, int64(101), pkg.ID)
+			assert.Equal(t, "my-app", pkg.Name)
+			assert.Equal(t, "container", pkg.PackageType)
+		})
+	}
+}
+
+func Test_ListPackageVersions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_package_versions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name"})
+
+	mockVersions := []*github.PackageVersion{
+		{
+			ID:             github.Ptr(int64(9001)),
+			Name:           github.Ptr("sha256:4f2d8f4b8b5a"),
+			PackageHTMLURL: github.Ptr("https://github.com/orgs/octo-org/packages/container/package/my-app"),
+			HTMLURL:        github.Ptr("https://github.com/orgs/octo-org/packages/container/my-app/9001"),
+			Metadata:       json.RawMessage(`{"package_type": "container", "container": {"tags": ["v1.2.0", "latest"]}}`),
+		},
+		{
+			ID:       github.Ptr(int64(9000)),
+			Name:     github.Ptr("sha256:0c9a3e1f77d2"),
+			Metadata: json.RawMessage(`{"package_type": "container", "container": {"tags": []}}`),
+		},
+	}
+	mockNPMVersions := []*github.PackageVersion{
+		{
+			ID:             github.Ptr(int64(42)),
+			Name:           github.Ptr("1.0.0"),
+			PackageHTMLURL: github.Ptr("https://github.com/octocat/hello/packages/42"),
+			Metadata:       json.RawMessage(`{"package_type": "npm"}`),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedVersions []MinimalPackageVersion
+	}{
+		{
+			name: "container versions include digests and tags",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					expect(t, expectations{
+						path: "/orgs/octo-org/packages/container/my-app/versions",
+						queryParams: map[string]string{
+							"state":    "active",
+							"page":     "1",
+							"per_page": "30",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockVersions),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"package_type": "container",
+				"package_name": "my-app",
+				"state":        "active",
+			},
+			expectedVersions: []MinimalPackageVersion{
+				{
+					ID:      9001,
+					Name:    "sha256:4f2d8f4b8b5a",
+					Digest:  "sha256:4f2d8f4b8b5a",
+					Tags:    []string{"v1.2.0", "latest"},
+					HTMLURL: "https://github.com/orgs/octo-org/packages/container/my-app/9001",
+				},
+				{
+					ID:     9000,
+					Name:   "sha256:0c9a3e1f77d2",
+					Digest: "sha256:0c9a3e1f77d2",
+				},
+			},
+		},
+		{
+			name: "other package types have no digests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserPackagesVersionsByPackageTypeByPackageName,
+					expectPath(t, "/user/packages/npm/hello/versions").andThen(
+						mockResponse(t, http.StatusOK, mockNPMVersions),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"package_type": "npm",
+				"package_name": "hello",
+			},
+			expectedVersions: []MinimalPackageVersion{
+				{
+					ID:      42,
+					Name:    "1.0.0",
+					HTMLURL: "https://github.com/octocat/hello/packages/42",
+				},
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Package not found."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"package_type": "container",
+				"package_name": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list package versions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var versions []MinimalPackageVersion
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &versions))
+			assert.Equal(t, tc.expectedVersions, versions)
+		})
+	}
+}
+
+func Test_DeletePackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeletePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name", "package_version_id", "confirm"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "delete organization package version",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					expectPath(t, "/orgs/octo-org/packages/container/my-app/versions/9000").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":                "octo-org",
+				"package_type":       "container",
+				"package_name":       "my-app",
+				"package_version_id": float64(9000),
+				"confirm":            true,
+			},
+		},
+		{
+			name:         "requires confirmation",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":                "octo-org",
+				"package_type":       "container",
+				"package_name":       "my-app",
+				"package_version_id": float64(9000),
+				"confirm":            false,
+			},
+			expectError:    true,
+			expectedErrMsg: "deleting a package version must be confirmed, set confirm to true",
+		},
+		{
+			name: "last tagged version",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserPackagesVersionsByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusBadRequest, `{"message": "You cannot delete the last tagged version of a package. You must delete the package instead."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"package_type":       "container",
+				"package_name":       "my-app",
+				"package_version_id": float64(9001),
+				"confirm":            true,
+			},
+			expectError:    true,
+			expectedErrMsg: "version 9001 is the last tagged version of my-app, which GitHub does not allow deleting",
+		},
+		{
+			name: "delete fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":                "octo-org",
+				"package_type":       "container",
+				"package_name":       "my-app",
+				"package_version_id": float64(9000),
+				"confirm":            true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeletePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Version 9000 of package my-app has been deleted", response["message"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
+
+func Test_RestorePackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RestorePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "restore_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name", "package_version_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "restore user package version",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostUsersPackagesVersionsRestoreByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					expectPath(t, "/users/octocat/packages/npm/hello/versions/42/restore").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"user":               "octocat",
+				"package_type":       "npm",
+				"package_name":       "hello",
+				"package_version_id": float64(42),
+			},
+		},
+		{
+			name: "restore fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPackagesVersionsRestoreByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":                "octo-org",
+				"package_type":       "container",
+				"package_name":       "my-app",
+				"package_version_id": float64(9000),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to restore package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RestorePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Version 42 of package hello has been restored", response["message"])
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// pageToken is what the opaque page_token and next_page_token of list tools encode: the page of a
+// REST API list or the cursor of a GraphQL connection or cursor-paginated REST API list, with the
+// page size it was computed for. Skip is the number of results of the page that were already
+// returned, for tools that stop in the middle of a page.
+type pageToken struct {
+	Page    int    `json:"p,omitempty"`
+	Cursor  string `json:"c,omitempty"`
+	PerPage int    `json:"n,omitempty"`
+	Skip    int    `json:"s,omitempty"`
+}
+
+func (t pageToken) encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken decodes a page_token returned as next_page_token.
+func decodePageToken(token string) (pageToken, error) {
+	var decoded pageToken
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &decoded)
+	}
+	if err != nil || (decoded.Page <= 0 && decoded.Cursor == "") || decoded.PerPage < 0 || decoded.Skip < 0 {
+		return pageToken{}, fmt.Errorf("invalid page_token %q, pass the next_page_token of the previous call unchanged", token)
+	}
+	return decoded, nil
+}
+
+// nextRESTPageToken returns the token of the page after resp, as the REST API's Link header tells
+// it with a page number or an after cursor, or "" if resp is the last page.
+func nextRESTPageToken(resp *github.Response, perPage int) string {
+	switch {
+	case resp == nil:
+		return ""
+	case resp.NextPage != 0:
+		return pageToken{Page: resp.NextPage, PerPage: perPage}.encode()
+	case resp.After != "":
+		return pageToken{Cursor: resp.After, PerPage: perPage}.encode()
+	}
+	return ""
+}
+
+// nextGraphQLPageToken returns the token of the page of a connection after endCursor, or "" if there
+// is no next page.
+func nextGraphQLPageToken(hasNextPage bool, endCursor string, perPage int) string {
+	if !hasNextPage || endCursor == "" {
+		return ""
+	}
+	return pageToken{Cursor: endCursor, PerPage: perPage}.encode()
+}
+
+// withNextPageToken puts the token of the next page in the JSON result of a list tool, so that all
+// list tools page the same way whatever their output looks like: an object gets a next_page_token
+// field, and an array becomes the items of an object with the next_page_token. The last page is left
+// as it is, so a list that fits in one page looks like it did before there were page tokens.
+func withNextPageToken(result *mcp.CallToolResult, nextPageToken string) *mcp.CallToolResult {
+	if result == nil || result.IsError || len(result.Content) == 0 || nextPageToken == "" {
+		return result
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return result
+	}
+	data := bytes.TrimSpace([]byte(text.Text))
+	token, _ := json.Marshal(nextPageToken)
+	field := append([]byte(`"next_page_token":`), token...)
+	switch {
+	case len(data) > 0 && data[0] == '[':
+		text.Text = `{"items":` + string(data) + "," + string(field) + "}"
+	case len(data) > 1 && data[0] == '{' && data[len(data)-1] == '}':
+		body := bytes.TrimSpace(data[:len(data)-1])
+		if len(body) > 1 {
+			body = append(body, ',')
+		}
+		text.Text = string(append(append(body, field...), '}'))
+	default:
+		return result
+	}
+	result.Content[0] = text
+	return result
+}
+
+// nextPageToken returns the token of the page of a connection after p, for pages of first nodes.
+func (p PageInfoFragment) nextPageToken(first int) string {
+	return nextGraphQLPageToken(p.HasNextPage, string(p.EndCursor), first)
+}
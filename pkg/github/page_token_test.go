@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextPageTokenOf returns the next_page_token of a list tool result, or "" if there is none.
+func nextPageTokenOf(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	text := getTextResult(t, result).Text
+	if strings.HasPrefix(text, "[") {
+		return ""
+	}
+	var next struct {
+		NextPageToken string `json:"next_page_token"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &next))
+	return next.NextPageToken
+}
+
+func Test_PageTokenREST(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTagsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("page") {
+				case "1":
+					assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+					w.Header().Set("Link", `<https://api.github.com/repositories/1/tags?per_page=2&page=2>; rel="next", <https://api.github.com/repositories/1/tags?per_page=2&page=3>; rel="last"`)
+					mockResponse(t, http.StatusOK, []*github.RepositoryTag{{Name: github.Ptr("v3")}, {Name: github.Ptr("v2")}})(w, r)
+				case "2":
+					// The page size of the first call is kept
+					assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+					mockResponse(t, http.StatusOK, []*github.RepositoryTag{{Name: github.Ptr("v1")}})(w, r)
+				default:
+					t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+				}
+			}),
+		),
+	)
+	_, handler := ListTags(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "perPage": float64(2)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	token := nextPageTokenOf(t, result)
+	assert.Equal(t, pageToken{Page: 2, PerPage: 2}, mustDecodePageToken(t, token))
+	// A page with more after it has the tags as its items
+	var page struct {
+		Items []*github.RepositoryTag `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &page))
+	assert.Len(t, page.Items, 2)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "page_token": token}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	// The last page is the list of tags, as it was before there were page tokens
+	var tags []*github.RepositoryTag
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tags))
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v1", tags[0].GetName())
+	assert.Empty(t, nextPageTokenOf(t, result), "the last page should have no next page token")
+}
+
+func Test_PageTokenRESTCursor(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposDependabotAlertsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+				switch r.URL.Query().Get("after") {
+				case "":
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/dependabot/alerts?per_page=1&after=Y3Vyc29yOjE>; rel="next"`)
+					mockResponse(t, http.StatusOK, []*github.DependabotAlert{{Number: github.Ptr(2)}})(w, r)
+				case "Y3Vyc29yOjE":
+					mockResponse(t, http.StatusOK, []*github.DependabotAlert{{Number: github.Ptr(1)}})(w, r)
+				default:
+					t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+				}
+			}),
+		),
+	)
+	_, handler := ListDependabotAlerts(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "perPage": float64(1)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	token := nextPageTokenOf(t, result)
+	assert.Equal(t, pageToken{Cursor: "Y3Vyc29yOjE", PerPage: 1}, mustDecodePageToken(t, token))
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "page_token": token}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var alerts []*github.DependabotAlert
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &alerts))
+	require.Len(t, alerts, 1)
+	assert.Equal(t, 1, alerts[0].GetNumber())
+}
+
+func Test_PageTokenGraphQL(t *testing.T) {
+	query := "query($after:String$first:Int!$login:String!){user(login: $login){followers(first: $first, after: $after){nodes{login,name,bio},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	followers := func(login string, hasNextPage bool, endCursor string) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"user": map[string]any{
+				"followers": map[string]any{
+					"nodes":      []map[string]any{{"login": login}},
+					"pageInfo":   map[string]any{"hasNextPage": hasNextPage, "endCursor": endCursor},
+					"totalCount": 2,
+				},
+			},
+		})
+	}
+	// The mocked client matches requests by query only, so each page has a client of its own
+	handler := func(vars map[string]any, response githubv4mock.GQLResponse) server.ToolHandlerFunc {
+		httpClient := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query, vars, response))
+		_, handler := ListFollowers(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+		return handler
+	}
+
+	result, err := handler(
+		map[string]any{"login": "octocat", "first": float64(1), "after": (*string)(nil)},
+		followers("hubot", true, "Y3Vyc29yOjE="),
+	)(context.Background(), createMCPRequest(map[string]any{"username": "octocat", "perPage": float64(1)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].(mcp.TextContent).Text)
+	token := nextPageTokenOf(t, result)
+	assert.Equal(t, pageToken{Cursor: "Y3Vyc29yOjE=", PerPage: 1}, mustDecodePageToken(t, token))
+
+	result, err = handler(
+		map[string]any{"login": "octocat", "first": float64(1), "after": "Y3Vyc29yOjE="},
+		followers("monalisa", false, "Y3Vyc29yOjI="),
+	)(context.Background(), createMCPRequest(map[string]any{"username": "octocat", "page_token": token}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].(mcp.TextContent).Text)
+	var list SocialUserList
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &list))
+	assert.Equal(t, []SocialUser{{Login: "monalisa"}}, list.Users)
+	assert.Empty(t, nextPageTokenOf(t, result), "the last page should have no next page token")
+}
+
+func Test_InvalidPageToken(t *testing.T) {
+	_, restHandler := ListTags(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	_, graphQLHandler := ListFollowers(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	for _, token := range []string{"not a token", pageToken{}.encode()} {
+		result, err := restHandler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "page_token": token}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "invalid page_token")
+	}
+
+	// The token of a REST API page is no cursor
+	result, err := graphQLHandler(context.Background(), createMCPRequest(map[string]any{"username": "octocat", "page_token": pageToken{Page: 2}.encode()}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "invalid page_token")
+}
+
+func Test_WithNextPageToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		token    string
+		expected string
+	}{
+		{name: "object", text: `{"total_count":3,"items":[1]}`, token: "abc", expected: `{"total_count":3,"items":[1],"next_page_token":"abc"}`},
+		{name: "empty object", text: `{}`, token: "abc", expected: `{"next_page_token":"abc"}`},
+		{name: "array", text: `[1,2]`, token: "abc", expected: `{"items":[1,2],"next_page_token":"abc"}`},
+		{name: "last page", text: `[1,2]`, token: "", expected: `[1,2]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := withNextPageToken(mcp.NewToolResultText(tc.text), tc.token)
+			assert.Equal(t, tc.expected, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func mustDecodePageToken(t *testing.T, token string) pageToken {
+	t.Helper()
+	decoded, err := decodePageToken(token)
+	require.NoError(t, err)
+	return decoded
+}
@@ -0,0 +1,41 @@
+package github
+
+import (
+	"github.com/google/go-github/v74/github"
+)
+
+// maxPerPage is the largest page size the GitHub REST API accepts.
+const maxPerPage = 100
+
+// collectPages pages through a REST list endpoint, starting at the first page, and collects up to
+// maxItems items. list fetches a single page. If stop is not nil, collection ends at the first item
+// for which it returns true, and that item is not collected; this is how callers cut off listings
+// sorted by a date once they leave a time window.
+//
+// truncated reports whether items were left uncollected because maxItems was reached. On error, the
+// items collected so far are returned along with the response of the failed request.
+func collectPages[T any](maxItems int, list func(opts github.ListOptions) ([]T, *github.Response, error), stop func(T) bool) (items []T, truncated bool, resp *github.Response, err error) {
+	opts := github.ListOptions{Page: 1, PerPage: min(maxItems+1, maxPerPage)}
+	for {
+		var page []T
+		page, resp, err = list(opts)
+		if err != nil {
+			return items, false, resp, err
+		}
+		_ = resp.Body.Close()
+
+		for _, item := range page {
+			if stop != nil && stop(item) {
+				return items, false, resp, nil
+			}
+			if len(items) == maxItems {
+				return items, true, resp, nil
+			}
+			items = append(items, item)
+		}
+		if resp.NextPage == 0 {
+			return items, false, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CollectPages(t *testing.T) {
+	// pages serves items in pages of two, the way the REST API reports them.
+	pages := func(items ...int) (func(opts github.ListOptions) ([]int, *github.Response, error), *[]int) {
+		var requested []int
+		return func(opts github.ListOptions) ([]int, *github.Response, error) {
+			requested = append(requested, opts.Page)
+			start := (opts.Page - 1) * 2
+			end := min(start+2, len(items))
+			resp := &github.Response{Response: &http.Response{Body: io.NopCloser(strings.NewReader(""))}}
+			if end < len(items) {
+				resp.NextPage = opts.Page + 1
+			}
+			return items[start:end], resp, nil
+		}, &requested
+	}
+
+	t.Run("collects all pages", func(t *testing.T) {
+		list, requested := pages(1, 2, 3, 4, 5)
+		items, truncated, _, err := collectPages(10, list, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+		assert.False(t, truncated)
+		assert.Equal(t, []int{1, 2, 3}, *requested)
+	})
+
+	t.Run("truncates at max items", func(t *testing.T) {
+		list, requested := pages(1, 2, 3, 4, 5)
+		items, truncated, _, err := collectPages(3, list, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, items)
+		assert.True(t, truncated)
+		assert.Equal(t, []int{1, 2}, *requested)
+	})
+
+	t.Run("exactly max items is not truncated", func(t *testing.T) {
+		list, _ := pages(1, 2, 3)
+		items, truncated, _, err := collectPages(3, list, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, items)
+		assert.False(t, truncated)
+	})
+
+	t.Run("stops at the first matching item", func(t *testing.T) {
+		list, requested := pages(5, 4, 3, 2, 1)
+		items, truncated, _, err := collectPages(10, list, func(i int) bool { return i < 4 })
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 4}, items)
+		assert.False(t, truncated)
+		assert.Equal(t, []int{1, 2}, *requested)
+	})
+
+	t.Run("returns the items collected before an error", func(t *testing.T) {
+		list, _ := pages(1, 2, 3)
+		failing := func(opts github.ListOptions) ([]int, *github.Response, error) {
+			if opts.Page == 2 {
+				return nil, nil, errors.New("boom")
+			}
+			return list(opts)
+		}
+		items, _, _, err := collectPages(10, failing, nil)
+		require.EqualError(t, err, "boom")
+		assert.Equal(t, []int{1, 2}, items)
+	})
+}
@@ -0,0 +1,281 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	// taskListItem matches a Markdown task list item, such as "- [ ] item" or "  1. [x] item". The
+	// groups are the text up to the box, the box's mark, and the text of the item.
+	taskListItem = regexp.MustCompile(`^(\s*(?:[-*+]|\d{1,9}[.)])\s+\[)([ xX])\]\s+(.*)$`)
+	// listItem matches any Markdown list item, so that nested items that are not tasks do not end a task list.
+	listItem = regexp.MustCompile(`^\s*(?:[-*+]|\d{1,9}[.)])\s`)
+)
+
+// ChecklistItem is an item of a Markdown task list in a pull request description.
+type ChecklistItem struct {
+	// Index is the 1-based position of the item among all task list items in the description.
+	Index int `json:"index"`
+	// List is the 1-based position of the task list the item belongs to, for descriptions with several.
+	List      int    `json:"list"`
+	Text      string `json:"text"`
+	Completed bool   `json:"completed"`
+
+	// line is the index of the line of the description the item is on.
+	line int
+}
+
+// PRChecklist is the output type of the list_pr_checklist_items tool.
+type PRChecklist struct {
+	Total     int             `json:"total"`
+	Completed int             `json:"completed"`
+	Items     []ChecklistItem `json:"items"`
+}
+
+// ChecklistItemCompletion is the output type of the complete_pr_checklist_item tool.
+type ChecklistItemCompletion struct {
+	Item ChecklistItem `json:"item"`
+	// AlreadyCompleted is set when the item was complete before, in which case the description was not updated.
+	AlreadyCompleted bool        `json:"already_completed"`
+	Checklist        PRChecklist `json:"checklist"`
+}
+
+// parseChecklist returns the task list items of a Markdown document, skipping those in code blocks
+// and HTML comments, which pull request templates use for instructions.
+func parseChecklist(body string) PRChecklist {
+	checklist := PRChecklist{Items: []ChecklistItem{}}
+	var fence string
+	inComment, inList, list := false, false, 0
+	for i, line := range strings.Split(body, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		case inComment:
+			inComment = !strings.Contains(trimmed, "-->")
+			continue
+		case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+			fence, inList = trimmed[:3], false
+			continue
+		case strings.HasPrefix(trimmed, "<!--"):
+			inComment, inList = !strings.Contains(trimmed, "-->"), false
+			continue
+		}
+
+		match := taskListItem.FindStringSubmatch(line)
+		if match == nil {
+			// Blank lines and other list items do not end a task list
+			if trimmed != "" && !listItem.MatchString(line) {
+				inList = false
+			}
+			continue
+		}
+		if !inList {
+			list++
+			inList = true
+		}
+		item := ChecklistItem{
+			Index:     len(checklist.Items) + 1,
+			List:      list,
+			Text:      strings.TrimSpace(match[3]),
+			Completed: match[2] != " ",
+			line:      i,
+		}
+		if item.Completed {
+			checklist.Completed++
+		}
+		checklist.Items = append(checklist.Items, item)
+	}
+	checklist.Total = len(checklist.Items)
+	return checklist
+}
+
+// completeChecklistItem returns body with the box of item checked.
+func completeChecklistItem(body string, item ChecklistItem) string {
+	lines := strings.Split(body, "\n")
+	match := taskListItem.FindStringSubmatchIndex(strings.TrimSuffix(lines[item.line], "\r"))
+	// match[4] is the start of the box's mark
+	lines[item.line] = lines[item.line][:match[4]] + "x" + lines[item.line][match[5]:]
+	return strings.Join(lines, "\n")
+}
+
+// findChecklistItem returns the item at the 1-based index, or if index is 0, the only item whose
+// text contains text, ignoring case.
+func findChecklistItem(checklist PRChecklist, index int, text string) (ChecklistItem, error) {
+	if index != 0 {
+		if index < 1 || index > checklist.Total {
+			return ChecklistItem{}, fmt.Errorf("index %d is out of range, the description has %d checklist items", index, checklist.Total)
+		}
+		return checklist.Items[index-1], nil
+	}
+
+	var matches []ChecklistItem
+	for _, item := range checklist.Items {
+		if strings.Contains(strings.ToLower(item.Text), strings.ToLower(text)) {
+			matches = append(matches, item)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ChecklistItem{}, fmt.Errorf("no checklist item contains %q", text)
+	case 1:
+		return matches[0], nil
+	}
+	candidates := make([]string, 0, len(matches))
+	for _, item := range matches {
+		candidates = append(candidates, fmt.Sprintf("%d: %s", item.Index, item.Text))
+	}
+	return ChecklistItem{}, fmt.Errorf("%d checklist items contain %q, pass the index of one instead: %s", len(matches), text, strings.Join(candidates, "; "))
+}
+
+// ListPRChecklistItems creates a tool to list the task list items in a pull request description.
+func ListPRChecklistItems(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pr_checklist_items",
+			mcp.WithDescription(t("TOOL_LIST_PR_CHECKLIST_ITEMS_DESCRIPTION", "List the Markdown task list items (- [ ] item) in the description of a pull request, with their index, text and whether they are completed. Items in code blocks and HTML comments are ignored.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PR_CHECKLIST_ITEMS_USER_TITLE", "List pull request checklist items"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(parseChecklist(pr.GetBody())), nil
+		}
+}
+
+// CompletePRChecklistItem creates a tool to check an item of a task list in a pull request description.
+func CompletePRChecklistItem(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("complete_pr_checklist_item",
+			mcp.WithDescription(t("TOOL_COMPLETE_PR_CHECKLIST_ITEM_DESCRIPTION", "Mark a Markdown task list item (- [ ] item) in the description of a pull request as completed, by its index from list_pr_checklist_items or by a part of its text. The rest of the description is left as is.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPLETE_PR_CHECKLIST_ITEM_USER_TITLE", "Complete pull request checklist item"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("index",
+				mcp.Description("1-based index of the item among all checklist items of the description. Either index or text is required"),
+				mcp.Min(1),
+			),
+			mcp.WithString("text",
+				mcp.Description("Part of the text of the item, matched ignoring case. It must match a single item. Either index or text is required"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			index, err := OptionalIntParam(request, "index")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			text, err := OptionalParam[string](request, "text")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (index == 0) == (text == "") {
+				return mcp.NewToolResultError("exactly one of index or text is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			body := pr.GetBody()
+			checklist := parseChecklist(body)
+			if checklist.Total == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("the description of pull request #%d has no checklist items", pullNumber)), nil
+			}
+			item, err := findChecklistItem(checklist, index, text)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if item.Completed {
+				return MarshalledTextResult(ChecklistItemCompletion{Item: item, AlreadyCompleted: true, Checklist: checklist}), nil
+			}
+
+			updated, resp, err := client.PullRequests.Edit(ctx, owner, repo, pullNumber, &github.PullRequest{
+				Body: github.Ptr(completeChecklistItem(body, item)),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update pull request", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			checklist = parseChecklist(updated.GetBody())
+			item.Completed = true
+			return MarshalledTextResult(ChecklistItemCompletion{Item: item, Checklist: checklist}), nil
+		}
+}
@@ -0,0 +1,250 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checklistBody has two task lists, with a nested item, and tasks in a code block and a comment
+// that are not part of them.
+const checklistBody = "## Summary\r\n" +
+	"Adds the thing.\r\n" +
+	"\r\n" +
+	"<!-- Check the boxes:\r\n" +
+	"- [ ] not an item\r\n" +
+	"-->\r\n" +
+	"## Checklist\r\n" +
+	"- [x] Tests added\r\n" +
+	"- [ ] Docs updated\r\n" +
+	"  - [ ] README\r\n" +
+	"  - not a task\r\n" +
+	"\r\n" +
+	"```md\r\n" +
+	"- [ ] example\r\n" +
+	"```\r\n" +
+	"## Before merging\r\n" +
+	"1. [ ] Changelog entry\r\n" +
+	"2. [X] Reviewed by docs team"
+
+func Test_ParseChecklist(t *testing.T) {
+	checklist := parseChecklist(checklistBody)
+	assert.Equal(t, 5, checklist.Total)
+	assert.Equal(t, 2, checklist.Completed)
+
+	var items []ChecklistItem
+	for _, item := range checklist.Items {
+		item.line = 0
+		items = append(items, item)
+	}
+	assert.Equal(t, []ChecklistItem{
+		{Index: 1, List: 1, Text: "Tests added", Completed: true},
+		{Index: 2, List: 1, Text: "Docs updated"},
+		{Index: 3, List: 1, Text: "README"},
+		{Index: 4, List: 2, Text: "Changelog entry"},
+		{Index: 5, List: 2, Text: "Reviewed by docs team", Completed: true},
+	}, items)
+
+	assert.Equal(t, PRChecklist{Items: []ChecklistItem{}}, parseChecklist("No checklist here"))
+}
+
+func Test_ListPRChecklistItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPRChecklistItems(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_pr_checklist_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedTotal  int
+	}{
+		{
+			name: "lists the items",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{Body: github.Ptr(checklistBody)}),
+			),
+			expectedTotal: 5,
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPRChecklistItems(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner", "repo": "repo", "pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var checklist PRChecklist
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &checklist))
+			assert.Equal(t, tc.expectedTotal, checklist.Total)
+			assert.Len(t, checklist.Items, tc.expectedTotal)
+		})
+	}
+}
+
+func Test_CompletePRChecklistItem(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompletePRChecklistItem(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "complete_pr_checklist_item", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "index")
+	assert.Contains(t, tool.InputSchema.Properties, "text")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	getPR := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{Body: github.Ptr(checklistBody)})
+	}
+	// editPR expects the description with only the box of the line at from checked, and echoes it back
+	editPR := func(from, to string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PatchReposPullsByOwnerByRepoByPullNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var update struct {
+					Body string `json:"body"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				assert.Equal(t, replaceOnce(t, checklistBody, from, to), update.Body)
+				mockResponse(t, http.StatusOK, &github.PullRequest{Body: github.Ptr(update.Body)})(w, r)
+			}),
+		)
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedItem     ChecklistItem
+		alreadyCompleted bool
+		expectedDone     int
+	}{
+		{
+			name:         "completes an item by index",
+			mockedClient: mock.NewMockedHTTPClient(getPR(), editPR("1. [ ] Changelog", "1. [x] Changelog")),
+			requestArgs:  map[string]any{"index": float64(4)},
+			expectedItem: ChecklistItem{Index: 4, List: 2, Text: "Changelog entry", Completed: true},
+			expectedDone: 3,
+		},
+		{
+			name:         "completes a nested item by text",
+			mockedClient: mock.NewMockedHTTPClient(getPR(), editPR("  - [ ] README", "  - [x] README")),
+			requestArgs:  map[string]any{"text": "readme"},
+			expectedItem: ChecklistItem{Index: 3, List: 1, Text: "README", Completed: true},
+			expectedDone: 3,
+		},
+		{
+			name:             "item already completed",
+			mockedClient:     mock.NewMockedHTTPClient(getPR()),
+			requestArgs:      map[string]any{"text": "tests"},
+			expectedItem:     ChecklistItem{Index: 1, List: 1, Text: "Tests added", Completed: true},
+			alreadyCompleted: true,
+			expectedDone:     2,
+		},
+		{
+			name:           "text matches several items",
+			mockedClient:   mock.NewMockedHTTPClient(getPR()),
+			requestArgs:    map[string]any{"text": "d"},
+			expectError:    true,
+			expectedErrMsg: `checklist items contain "d", pass the index of one instead`,
+		},
+		{
+			name:           "text matches no item",
+			mockedClient:   mock.NewMockedHTTPClient(getPR()),
+			requestArgs:    map[string]any{"text": "example"},
+			expectError:    true,
+			expectedErrMsg: `no checklist item contains "example"`,
+		},
+		{
+			name:           "index out of range",
+			mockedClient:   mock.NewMockedHTTPClient(getPR()),
+			requestArgs:    map[string]any{"index": float64(6)},
+			expectError:    true,
+			expectedErrMsg: "index 6 is out of range, the description has 5 checklist items",
+		},
+		{
+			name:           "neither index nor text",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "exactly one of index or text is required",
+		},
+		{
+			name: "no checklist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{Body: github.Ptr("Just a description")}),
+			),
+			requestArgs:    map[string]any{"index": float64(1)},
+			expectError:    true,
+			expectedErrMsg: "the description of pull request #42 has no checklist items",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompletePRChecklistItem(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			tc.requestArgs["owner"], tc.requestArgs["repo"], tc.requestArgs["pullNumber"] = "owner", "repo", float64(42)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var completion ChecklistItemCompletion
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &completion))
+			assert.Equal(t, tc.expectedItem, completion.Item)
+			assert.Equal(t, tc.alreadyCompleted, completion.AlreadyCompleted)
+			assert.Equal(t, 5, completion.Checklist.Total)
+			assert.Equal(t, tc.expectedDone, completion.Checklist.Completed)
+		})
+	}
+}
+
+// replaceOnce replaces the only occurrence of old in s with replacement.
+func replaceOnce(t *testing.T, s, old, replacement string) string {
+	t.Helper()
+	require.Equal(t, 1, strings.Count(s, old))
+	return strings.Replace(s, old, replacement, 1)
+}
@@ -0,0 +1,609 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// defaultPacketMaxLength is the default number of characters a review packet is capped at.
+	defaultPacketMaxLength = 60000
+	// defaultPacketPatchLines is the default number of patch lines included per changed file.
+	defaultPacketPatchLines = 50
+	// maxPacketFiles is the number of changed files a review packet lists at most.
+	maxPacketFiles = 300
+)
+
+// reviewPacketSections are the sections of a review packet, in the order they are listed.
+var reviewPacketSections = []string{"metadata", "files", "reviews", "checks", "linked_issues", "codeowners"}
+
+// ReviewPacket is the output type of the get_pull_request_review_packet tool. Sections that were
+// not requested are left out.
+type ReviewPacket struct {
+	Metadata     *ReviewPacketMetadata   `json:"metadata,omitempty"`
+	Files        *ReviewPacketFiles      `json:"files,omitempty"`
+	Reviews      *ReviewPacketReviews    `json:"reviews,omitempty"`
+	Checks       *ChecksRollup           `json:"checks,omitempty"`
+	LinkedIssues *ReviewPacketIssues     `json:"linked_issues,omitempty"`
+	CodeOwners   *ReviewPacketCodeOwners `json:"codeowners,omitempty"`
+	// Truncated explains, per section, what was left out to keep the packet within its maximum length.
+	Truncated map[string]string `json:"truncated,omitempty"`
+	Warnings  []string          `json:"warnings,omitempty"`
+}
+
+// ReviewPacketMetadata describes the pull request itself.
+type ReviewPacketMetadata struct {
+	Number       int      `json:"number"`
+	Title        string   `json:"title"`
+	State        string   `json:"state"`
+	Draft        bool     `json:"draft"`
+	Author       string   `json:"author"`
+	BaseRef      string   `json:"base_ref"`
+	HeadRef      string   `json:"head_ref"`
+	HeadSHA      string   `json:"head_sha"`
+	Mergeable    *bool    `json:"mergeable,omitempty"`
+	Labels       []string `json:"labels"`
+	Additions    int      `json:"additions"`
+	Deletions    int      `json:"deletions"`
+	ChangedFiles int      `json:"changed_files"`
+	HTMLURL      string   `json:"html_url"`
+	Body         string   `json:"body,omitempty"`
+}
+
+// ReviewPacketFiles lists the files a pull request changes.
+type ReviewPacketFiles struct {
+	TotalCount int                `json:"total_count"`
+	Files      []ReviewPacketFile `json:"files"`
+}
+
+// ReviewPacketFile is a file a pull request changes, with its patch truncated.
+type ReviewPacketFile struct {
+	Filename         string `json:"filename"`
+	Status           string `json:"status"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Patch            string `json:"patch,omitempty"`
+}
+
+// ReviewPacketReviews holds the submitted reviews of a pull request and its unresolved review threads.
+type ReviewPacketReviews struct {
+	Reviews           []ReviewPacketReview `json:"reviews"`
+	UnresolvedThreads []ReviewPacketThread `json:"unresolved_threads"`
+}
+
+// ReviewPacketReview is a submitted review.
+type ReviewPacketReview struct {
+	Author      string `json:"author"`
+	State       string `json:"state"`
+	SubmittedAt string `json:"submitted_at,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// ReviewPacketThread is an unresolved review thread with its first comments.
+type ReviewPacketThread struct {
+	Path         string                      `json:"path"`
+	Line         int                         `json:"line,omitempty"`
+	IsOutdated   bool                        `json:"is_outdated"`
+	CommentCount int                         `json:"comment_count"`
+	Comments     []ReviewPacketThreadComment `json:"comments"`
+}
+
+// ReviewPacketThreadComment is a comment of a review thread.
+type ReviewPacketThreadComment struct {
+	Author string `json:"author"`
+	Body   string `json:"body"`
+}
+
+// ReviewPacketIssues lists the issues a pull request is linked to.
+type ReviewPacketIssues struct {
+	Issues []LinkedIssue `json:"issues"`
+}
+
+// ReviewPacketCodeOwners lists the code owners of the changed files.
+type ReviewPacketCodeOwners struct {
+	// File is the path of the CODEOWNERS file, empty if the repository has none.
+	File string `json:"file,omitempty"`
+	// Owners are all owners of the changed files.
+	Owners []string               `json:"owners"`
+	Paths  []ReviewPacketPathOwns `json:"paths"`
+}
+
+// ReviewPacketPathOwns are the code owners of a changed file.
+type ReviewPacketPathOwns struct {
+	Path   string   `json:"path"`
+	Owners []string `json:"owners"`
+}
+
+// GetPullRequestReviewPacket creates a tool that gathers everything needed to review a pull request in a single call.
+func GetPullRequestReviewPacket(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_review_packet",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEW_PACKET_DESCRIPTION", "Get everything needed to review a pull request in a single call: its metadata, changed files with truncated patches, reviews and unresolved review threads, a summary of the checks, linked issues, and the code owners of the changed files. Use this first when asked to review a pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REVIEW_PACKET_USER_TITLE", "Get pull request review packet"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("sections",
+				mcp.Description("Sections to include. Defaults to all of them"),
+				mcp.Items(map[string]any{
+					"type": "string",
+					"enum": reviewPacketSections,
+				}),
+			),
+			mcp.WithNumber("max_patch_lines",
+				mcp.Description("Maximum number of patch lines to include per changed file, 0 to leave patches out"),
+				mcp.Min(0),
+				mcp.DefaultNumber(defaultPacketPatchLines),
+			),
+			mcp.WithNumber("max_length",
+				mcp.Description("Maximum number of characters of the packet. Sections that do not fit are truncated, which is noted in 'truncated'"),
+				mcp.Min(1000),
+				mcp.DefaultNumber(defaultPacketMaxLength),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sections, err := OptionalStringArrayParam(request, "sections")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(sections) == 0 {
+				sections = reviewPacketSections
+			}
+			include := make(map[string]bool, len(sections))
+			for _, section := range sections {
+				if !slices.Contains(reviewPacketSections, section) {
+					return mcp.NewToolResultError(fmt.Sprintf("unknown section %q, must be one of %s", section, strings.Join(reviewPacketSections, ", "))), nil
+				}
+				include[section] = true
+			}
+			maxPatchLines, err := OptionalIntParamWithDefault(request, "max_patch_lines", defaultPacketPatchLines)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxPatchLines < 0 {
+				return mcp.NewToolResultError("max_patch_lines must not be negative"), nil
+			}
+			maxLength, err := OptionalIntParamWithDefault(request, "max_length", defaultPacketMaxLength)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxLength < 1000 {
+				return mcp.NewToolResultError("max_length must be at least 1000"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var (
+				packet ReviewPacket
+				pr     *github.PullRequest
+				prResp *github.Response
+				files  []*github.CommitFile
+			)
+
+			// The other sections need the head commit, base branch, body and changed files of the pull request
+			getPullRequest := func(ctx context.Context) error {
+				var err error
+				pr, prResp, err = client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return err
+				}
+				return prResp.Body.Close()
+			}
+			listFiles := func(ctx context.Context) error {
+				if !include["files"] && !include["codeowners"] {
+					return nil
+				}
+				opts := &github.ListOptions{PerPage: 100}
+				for len(files) < maxPacketFiles {
+					page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+					if err != nil {
+						return fmt.Errorf("failed to list files: %w", err)
+					}
+					_ = resp.Body.Close()
+					files = append(files, page...)
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+				return nil
+			}
+			errs := runBounded(ctx, maxConcurrentRequests, getPullRequest, listFiles)
+			if errs[0] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					prResp,
+					errs[0],
+				), nil
+			}
+			filesErr := errs[1]
+			if filesErr != nil {
+				packet.Warnings = append(packet.Warnings, filesErr.Error())
+			}
+
+			if include["metadata"] {
+				packet.Metadata = reviewPacketMetadata(pr)
+			}
+			if include["files"] && filesErr == nil {
+				packet.Files = &ReviewPacketFiles{TotalCount: pr.GetChangedFiles(), Files: make([]ReviewPacketFile, 0, len(files))}
+				for _, file := range files {
+					packetFile := ReviewPacketFile{
+						Filename:         file.GetFilename(),
+						Status:           file.GetStatus(),
+						PreviousFilename: file.GetPreviousFilename(),
+						Additions:        file.GetAdditions(),
+						Deletions:        file.GetDeletions(),
+					}
+					if maxPatchLines > 0 && file.GetPatch() != "" {
+						packetFile.Patch = truncatePatch(file.GetPatch(), maxPatchLines)
+					}
+					packet.Files.Files = append(packet.Files.Files, packetFile)
+				}
+			}
+
+			var tasks []func(context.Context) error
+			if include["reviews"] {
+				tasks = append(tasks, func(ctx context.Context) error {
+					reviews, err := reviewPacketReviews(ctx, client, getGQLClient, owner, repo, pullNumber)
+					if err != nil {
+						return err
+					}
+					packet.Reviews = reviews
+					return nil
+				})
+			}
+			if include["checks"] {
+				tasks = append(tasks, func(ctx context.Context) error {
+					checks, err := getChecksRollup(ctx, client, owner, repo, pr.GetHead().GetSHA())
+					if err != nil {
+						return err
+					}
+					packet.Checks = checks
+					return nil
+				})
+			}
+			if include["linked_issues"] {
+				tasks = append(tasks, func(ctx context.Context) error {
+					issues, err := reviewPacketLinkedIssues(ctx, getGQLClient, owner, repo, pullNumber)
+					if err != nil {
+						return err
+					}
+					packet.LinkedIssues = issues
+					return nil
+				})
+			}
+			if include["codeowners"] && filesErr == nil {
+				tasks = append(tasks, func(ctx context.Context) error {
+					codeOwners, err := reviewPacketCodeOwners(ctx, client, owner, repo, pr.GetBase().GetRef(), files)
+					if err != nil {
+						return err
+					}
+					packet.CodeOwners = codeOwners
+					return nil
+				})
+			}
+			for _, err := range runBounded(ctx, maxConcurrentRequests, tasks...) {
+				if err != nil {
+					packet.Warnings = append(packet.Warnings, err.Error())
+				}
+			}
+
+			packet.Truncated = fitReviewPacket(&packet, maxLength)
+
+			return MarshalledTextResult(packet), nil
+		}
+}
+
+func reviewPacketMetadata(pr *github.PullRequest) *ReviewPacketMetadata {
+	metadata := &ReviewPacketMetadata{
+		Number:       pr.GetNumber(),
+		Title:        pr.GetTitle(),
+		State:        pr.GetState(),
+		Draft:        pr.GetDraft(),
+		Author:       pr.GetUser().GetLogin(),
+		BaseRef:      pr.GetBase().GetRef(),
+		HeadRef:      pr.GetHead().GetRef(),
+		HeadSHA:      pr.GetHead().GetSHA(),
+		Mergeable:    pr.Mergeable,
+		Labels:       []string{},
+		Additions:    pr.GetAdditions(),
+		Deletions:    pr.GetDeletions(),
+		ChangedFiles: pr.GetChangedFiles(),
+		HTMLURL:      pr.GetHTMLURL(),
+		Body:         pr.GetBody(),
+	}
+	for _, label := range pr.Labels {
+		metadata.Labels = append(metadata.Labels, label.GetName())
+	}
+	return metadata
+}
+
+// reviewThreadsQuery gets the review threads of a pull request with their first comments.
+type reviewThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []struct {
+					IsResolved githubv4.Boolean
+					IsOutdated githubv4.Boolean
+					Path       githubv4.String
+					Line       *githubv4.Int
+					Comments   struct {
+						TotalCount githubv4.Int
+						Nodes      []struct {
+							Author struct {
+								Login githubv4.String
+							}
+							Body githubv4.String
+						}
+					} `graphql:"comments(first: 3)"`
+				}
+			} `graphql:"reviewThreads(first: 100)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+func reviewPacketReviews(ctx context.Context, client *github.Client, getGQLClient GetGQLClientFn, owner, repo string, pullNumber int) (*ReviewPacketReviews, error) {
+	reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	result := &ReviewPacketReviews{
+		Reviews:           make([]ReviewPacketReview, 0, len(reviews)),
+		UnresolvedThreads: []ReviewPacketThread{},
+	}
+	for _, review := range reviews {
+		packetReview := ReviewPacketReview{
+			Author: review.GetUser().GetLogin(),
+			State:  review.GetState(),
+			Body:   review.GetBody(),
+		}
+		if review.SubmittedAt != nil {
+			packetReview.SubmittedAt = review.GetSubmittedAt().Format(time.RFC3339)
+		}
+		result.Reviews = append(result.Reviews, packetReview)
+	}
+
+	gqlClient, err := getGQLClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+	var query reviewThreadsQuery
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+	}
+	if err := gqlClient.Query(ctx, &query, vars); err != nil {
+		return nil, fmt.Errorf("failed to get review threads: %w", err)
+	}
+	for _, thread := range query.Repository.PullRequest.ReviewThreads.Nodes {
+		if thread.IsResolved {
+			continue
+		}
+		packetThread := ReviewPacketThread{
+			Path:         string(thread.Path),
+			IsOutdated:   bool(thread.IsOutdated),
+			CommentCount: int(thread.Comments.TotalCount),
+			Comments:     make([]ReviewPacketThreadComment, 0, len(thread.Comments.Nodes)),
+		}
+		if thread.Line != nil {
+			packetThread.Line = int(*thread.Line)
+		}
+		for _, comment := range thread.Comments.Nodes {
+			packetThread.Comments = append(packetThread.Comments, ReviewPacketThreadComment{
+				Author: string(comment.Author.Login),
+				Body:   string(comment.Body),
+			})
+		}
+		result.UnresolvedThreads = append(result.UnresolvedThreads, packetThread)
+	}
+	return result, nil
+}
+
+func reviewPacketLinkedIssues(ctx context.Context, getGQLClient GetGQLClientFn, owner, repo string, pullNumber int) (*ReviewPacketIssues, error) {
+	gqlClient, err := getGQLClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+	issues, err := linkedIssuesOfPullRequest(ctx, gqlClient, owner, repo, pullNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewPacketIssues{Issues: issues}, nil
+}
+
+func reviewPacketCodeOwners(ctx context.Context, client *github.Client, owner, repo, ref string, files []*github.CommitFile) (*ReviewPacketCodeOwners, error) {
+	result := &ReviewPacketCodeOwners{Owners: []string{}, Paths: []ReviewPacketPathOwns{}}
+
+	path, content, _, err := getCodeownersFile(ctx, client, owner, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return result, nil
+	}
+	result.File = path
+
+	rules := parseCodeowners(content)
+	allOwners := make(map[string]bool)
+	for _, file := range files {
+		owners := codeownersFor(rules, file.GetFilename())
+		if len(owners) == 0 {
+			continue
+		}
+		result.Paths = append(result.Paths, ReviewPacketPathOwns{Path: file.GetFilename(), Owners: owners})
+		for _, o := range owners {
+			allOwners[o] = true
+		}
+	}
+	for o := range allOwners {
+		result.Owners = append(result.Owners, o)
+	}
+	sort.Strings(result.Owners)
+	return result, nil
+}
+
+// fitReviewPacket truncates the sections of a packet so that it fits in maxLength characters, and
+// returns what was left out of each truncated section. Smaller sections are kept whole, and the
+// remaining length is shared equally by the larger ones.
+func fitReviewPacket(packet *ReviewPacket, maxLength int) map[string]string {
+	type section struct {
+		name string
+		size func() int
+		trim func(limit int) string
+	}
+	var sections []section
+	if packet.Metadata != nil {
+		m := packet.Metadata
+		sections = append(sections, section{"metadata", func() int { return jsonLength(m) }, func(limit int) string {
+			body := m.Body
+			m.Body = ""
+			available := limit - jsonLength(m)
+			if available <= 0 {
+				return "the body was left out"
+			}
+			m.Body, _ = truncateText(body, available)
+			return "the body was truncated"
+		}})
+	}
+	if packet.Files != nil {
+		f := packet.Files
+		sections = append(sections, section{"files", func() int { return jsonLength(f) }, func(limit int) string {
+			total := len(f.Files)
+			f.Files = f.Files[:keepWithin(limit, total, func(n int) int { return jsonLength(ReviewPacketFiles{f.TotalCount, f.Files[:n]}) })]
+			return fmt.Sprintf("%d of %d files were left out, use get_pull_request_files to list them", total-len(f.Files), total)
+		}})
+	}
+	if packet.Reviews != nil {
+		r := packet.Reviews
+		sections = append(sections, section{"reviews", func() int { return jsonLength(r) }, func(limit int) string {
+			totalReviews, totalThreads := len(r.Reviews), len(r.UnresolvedThreads)
+			threads := r.UnresolvedThreads
+			r.UnresolvedThreads = []ReviewPacketThread{}
+			r.Reviews = r.Reviews[:keepWithin(limit, totalReviews, func(n int) int {
+				return jsonLength(ReviewPacketReviews{r.Reviews[:n], r.UnresolvedThreads})
+			})]
+			reviews := r.Reviews
+			r.UnresolvedThreads = threads[:keepWithin(limit, totalThreads, func(n int) int {
+				return jsonLength(ReviewPacketReviews{reviews, threads[:n]})
+			})]
+			return fmt.Sprintf("%d of %d reviews and %d of %d unresolved threads were left out, use get_pull_request_reviews and get_pull_request_comments to list them",
+				totalReviews-len(r.Reviews), totalReviews, totalThreads-len(r.UnresolvedThreads), totalThreads)
+		}})
+	}
+	if packet.Checks != nil {
+		c := packet.Checks
+		sections = append(sections, section{"checks", func() int { return jsonLength(c) }, func(limit int) string {
+			total := len(c.Failing)
+			pending := c.Pending
+			c.Pending = []string{}
+			c.Failing = c.Failing[:keepWithin(limit, total, func(n int) int {
+				return jsonLength(ChecksRollup{c.State, c.TotalCount, c.Counts, c.Failing[:n], c.Pending})
+			})]
+			failing := c.Failing
+			c.Pending = pending[:keepWithin(limit, len(pending), func(n int) int {
+				return jsonLength(ChecksRollup{c.State, c.TotalCount, c.Counts, failing, pending[:n]})
+			})]
+			return fmt.Sprintf("%d failing and %d pending checks were left out", total-len(c.Failing), len(pending)-len(c.Pending))
+		}})
+	}
+	if packet.LinkedIssues != nil {
+		l := packet.LinkedIssues
+		sections = append(sections, section{"linked_issues", func() int { return jsonLength(l) }, func(limit int) string {
+			total := len(l.Issues)
+			l.Issues = l.Issues[:keepWithin(limit, total, func(n int) int { return jsonLength(ReviewPacketIssues{l.Issues[:n]}) })]
+			return fmt.Sprintf("%d of %d linked issues were left out", total-len(l.Issues), total)
+		}})
+	}
+	if packet.CodeOwners != nil {
+		c := packet.CodeOwners
+		sections = append(sections, section{"codeowners", func() int { return jsonLength(c) }, func(limit int) string {
+			total := len(c.Paths)
+			c.Paths = c.Paths[:keepWithin(limit, total, func(n int) int { return jsonLength(ReviewPacketCodeOwners{c.File, c.Owners, c.Paths[:n]}) })]
+			return fmt.Sprintf("the owners of %d of %d paths were left out, all owners are still listed", total-len(c.Paths), total)
+		}})
+	}
+
+	// Leave room for the warnings and the truncation notices
+	remaining := maxLength - jsonLength(packet.Warnings) - 200*len(sections)
+	sizes := make(map[string]int, len(sections))
+	for _, s := range sections {
+		sizes[s.name] = s.size()
+	}
+	sort.SliceStable(sections, func(i, j int) bool { return sizes[sections[i].name] < sizes[sections[j].name] })
+
+	truncated := make(map[string]string)
+	for i, s := range sections {
+		share := remaining / (len(sections) - i)
+		if sizes[s.name] <= share {
+			remaining -= sizes[s.name]
+			continue
+		}
+		truncated[s.name] = s.trim(share)
+		remaining -= s.size()
+	}
+	if len(truncated) == 0 {
+		return nil
+	}
+	return truncated
+}
+
+// keepWithin returns the largest n, up to total, for which size(n) is at most limit.
+func keepWithin(limit, total int, size func(n int) int) int {
+	n := sort.Search(total+1, func(n int) bool { return size(n) > limit }) - 1
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// jsonLength returns the length of v marshalled to JSON.
+func jsonLength(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,305 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestReviewPacket(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestReviewPacket(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_review_packet", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sections")
+	assert.Contains(t, tool.InputSchema.Properties, "max_patch_lines")
+	assert.Contains(t, tool.InputSchema.Properties, "max_length")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockPR := &github.PullRequest{
+		Number:       github.Ptr(42),
+		Title:        github.Ptr("Add retries to the uploader"),
+		State:        github.Ptr("open"),
+		User:         &github.User{Login: github.Ptr("author")},
+		Body:         github.Ptr("Retry uploads.\n\nFixes #10, resolves octo-org/infra#7"),
+		Base:         &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Head:         &github.PullRequestBranch{Ref: github.Ptr("retries"), SHA: github.Ptr("abc123")},
+		Labels:       []*github.Label{{Name: github.Ptr("enhancement")}},
+		Additions:    github.Ptr(12),
+		Deletions:    github.Ptr(3),
+		ChangedFiles: github.Ptr(2),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/pull/42"),
+	}
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("uploader/retry.go"), Status: github.Ptr("added"), Additions: github.Ptr(10), Patch: github.Ptr("@@ -0,0 +1,3 @@\n+package uploader\n+\n+func retry() {}")},
+		{Filename: github.Ptr("docs/uploader.md"), Status: github.Ptr("modified"), Additions: github.Ptr(2), Deletions: github.Ptr(3), Patch: github.Ptr("@@ -1 +1 @@\n-old\n+new")},
+	}
+	mockReviews := []*github.PullRequestReview{
+		{User: &github.User{Login: github.Ptr("reviewer")}, State: github.Ptr("CHANGES_REQUESTED"), Body: github.Ptr("Please add tests")},
+	}
+	codeowners := "# Owners\n*       @owner/maintainers\n/uploader/ @owner/uploads # upload code\ndocs/   @docs-team\n"
+
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+	threadsMatcher := githubv4mock.NewQueryMatcher(reviewThreadsQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"pullRequest": map[string]any{"reviewThreads": map[string]any{"nodes": []any{
+			map[string]any{"isResolved": true, "isOutdated": false, "path": "uploader/retry.go", "line": 1, "comments": map[string]any{"totalCount": 1, "nodes": []any{
+				map[string]any{"author": map[string]any{"login": "reviewer"}, "body": "Resolved already"},
+			}}},
+			map[string]any{"isResolved": false, "isOutdated": false, "path": "uploader/retry.go", "line": 3, "comments": map[string]any{"totalCount": 4, "nodes": []any{
+				map[string]any{"author": map[string]any{"login": "reviewer"}, "body": "Should this back off?"},
+			}}},
+		}}}},
+	}))
+	closingIssuesMatcher := githubv4mock.NewQueryMatcher(closingIssuesQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"pullRequest": map[string]any{
+			"id":   "PR_1",
+			"body": "Retry uploads.\n\nFixes #10, resolves octo-org/infra#7",
+			"closingIssuesReferences": map[string]any{"nodes": []any{
+				map[string]any{"number": 10, "title": "Uploads fail on flaky networks", "state": "OPEN", "repository": map[string]any{"nameWithOwner": "owner/repo"}},
+			}},
+		}},
+	}))
+
+	getPR := mock.WithRequestMatchHandler(
+		mock.GetReposPullsByOwnerByRepoByPullNumber,
+		expectPath(t, "/repos/owner/repo/pulls/42").andThen(mockResponse(t, http.StatusOK, mockPR)),
+	)
+
+	t.Run("full packet", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			getPR,
+			mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, mockFiles),
+			mock.WithRequestMatch(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, mockReviews),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/commits/abc123/check-runs").andThen(
+					mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+						Total: github.Ptr(3),
+						CheckRuns: []*github.CheckRun{
+							{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+							{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/2")},
+							{Name: github.Ptr("lint"), Status: github.Ptr("in_progress")},
+						},
+					}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{{Context: github.Ptr("ci/legacy"), State: github.Ptr("success")}},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/repos/owner/repo/contents/CODEOWNERS" {
+						mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+						return
+					}
+					assert.Equal(t, "main", r.URL.Query().Get("ref"))
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(codeowners))),
+					})(w, r)
+				}),
+			),
+		))
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(threadsMatcher, closingIssuesMatcher))
+		_, handler := GetPullRequestReviewPacket(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var packet ReviewPacket
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &packet))
+		assert.Empty(t, packet.Warnings)
+		assert.Empty(t, packet.Truncated)
+
+		require.NotNil(t, packet.Metadata)
+		assert.Equal(t, "Add retries to the uploader", packet.Metadata.Title)
+		assert.Equal(t, "abc123", packet.Metadata.HeadSHA)
+		assert.Equal(t, []string{"enhancement"}, packet.Metadata.Labels)
+
+		require.NotNil(t, packet.Files)
+		assert.Equal(t, 2, packet.Files.TotalCount)
+		require.Len(t, packet.Files.Files, 2)
+		assert.Equal(t, "uploader/retry.go", packet.Files.Files[0].Filename)
+		assert.NotEmpty(t, packet.Files.Files[0].Patch)
+
+		require.NotNil(t, packet.Reviews)
+		assert.Equal(t, []ReviewPacketReview{{Author: "reviewer", State: "CHANGES_REQUESTED", Body: "Please add tests"}}, packet.Reviews.Reviews)
+		assert.Equal(t, []ReviewPacketThread{{
+			Path:         "uploader/retry.go",
+			Line:         3,
+			CommentCount: 4,
+			Comments:     []ReviewPacketThreadComment{{Author: "reviewer", Body: "Should this back off?"}},
+		}}, packet.Reviews.UnresolvedThreads)
+
+		assert.Equal(t, &ChecksRollup{
+			State:      "failure",
+			TotalCount: 4,
+			Counts:     map[string]int{"success": 2, "failure": 1, "pending": 1},
+			Failing:    []FailingCheck{{Name: "test", Conclusion: "failure", URL: "https://github.com/owner/repo/runs/2"}},
+			Pending:    []string{"lint"},
+		}, packet.Checks)
+
+		assert.Equal(t, &ReviewPacketIssues{Issues: []LinkedIssue{
+			{Repository: "owner/repo", Number: 10, Title: "Uploads fail on flaky networks", State: "open", Source: "both"},
+			{Repository: "octo-org/infra", Number: 7, Source: "body"},
+		}}, packet.LinkedIssues)
+
+		assert.Equal(t, &ReviewPacketCodeOwners{
+			File:   "CODEOWNERS",
+			Owners: []string{"@docs-team", "@owner/uploads"},
+			Paths: []ReviewPacketPathOwns{
+				{Path: "uploader/retry.go", Owners: []string{"@owner/uploads"}},
+				{Path: "docs/uploader.md", Owners: []string{"@docs-team"}},
+			},
+		}, packet.CodeOwners)
+	})
+
+	t.Run("selected sections only", func(t *testing.T) {
+		// Only the pull request itself is requested from GitHub
+		client := github.NewClient(mock.NewMockedHTTPClient(getPR))
+		_, handler := GetPullRequestReviewPacket(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"sections":   []any{"metadata"},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var packet ReviewPacket
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &packet))
+		assert.NotNil(t, packet.Metadata)
+		assert.Nil(t, packet.Files)
+		assert.Nil(t, packet.Reviews)
+		assert.Nil(t, packet.Checks)
+		assert.Nil(t, packet.LinkedIssues)
+		assert.Nil(t, packet.CodeOwners)
+	})
+
+	t.Run("failed sections are reported as warnings", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			getPR,
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+				mockResponse(t, http.StatusInternalServerError, `{"message": "Something went wrong"}`),
+			),
+		))
+		_, handler := GetPullRequestReviewPacket(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"sections":   []any{"metadata", "checks"},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var packet ReviewPacket
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &packet))
+		assert.NotNil(t, packet.Metadata)
+		assert.Nil(t, packet.Checks)
+		require.Len(t, packet.Warnings, 1)
+		assert.Contains(t, packet.Warnings[0], "failed to list check runs")
+	})
+
+	t.Run("sections that do not fit are truncated", func(t *testing.T) {
+		manyFiles := make([]*github.CommitFile, 0, 100)
+		for i := range 100 {
+			manyFiles = append(manyFiles, &github.CommitFile{
+				Filename: github.Ptr(fmt.Sprintf("pkg/file%d.go", i)),
+				Status:   github.Ptr("modified"),
+				Patch:    github.Ptr("@@ -1 +1 @@\n-" + strings.Repeat("old ", 20) + "\n+" + strings.Repeat("new ", 20)),
+			})
+		}
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			getPR,
+			mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, manyFiles),
+		))
+		_, handler := GetPullRequestReviewPacket(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"sections":   []any{"metadata", "files"},
+			"max_length": float64(5000),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		text := getTextResult(t, result).Text
+		assert.LessOrEqual(t, len(text), 5000)
+		var packet ReviewPacket
+		require.NoError(t, json.Unmarshal([]byte(text), &packet))
+		// The small metadata section is kept whole
+		assert.Equal(t, mockPR.GetBody(), packet.Metadata.Body)
+		assert.NotContains(t, packet.Truncated, "metadata")
+		require.NotNil(t, packet.Files)
+		assert.Less(t, len(packet.Files.Files), 100)
+		assert.Equal(t, fmt.Sprintf("%d of 100 files were left out, use get_pull_request_files to list them", 100-len(packet.Files.Files)), packet.Truncated["files"])
+	})
+
+	t.Run("unknown section", func(t *testing.T) {
+		_, handler := GetPullRequestReviewPacket(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"sections":   []any{"diff"},
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, `unknown section "diff", must be one of metadata, files, reviews, checks, linked_issues, codeowners`, getErrorResult(t, result).Text)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+			mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, mockFiles),
+		))
+		_, handler := GetPullRequestReviewPacket(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(999),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get pull request")
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -85,7 +88,8 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 
 // CreatePullRequest creates a tool to create a new pull request.
 func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	return mcp.NewTool("create_pull_request",
+	return withIdempotencyKey(createIdempotency,
+		mcp.NewTool("create_pull_request",
 			mcp.WithDescription(t("TOOL_CREATE_PULL_REQUEST_DESCRIPTION", "Create a new pull request in a GitHub repository.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_PULL_REQUEST_USER_TITLE", "Open new pull request"),
@@ -199,7 +203,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			}
 
 			return mcp.NewToolResultText(string(r)), nil
-		}
+		})
 }
 
 // UpdatePullRequest creates a tool to update an existing pull request.
@@ -581,7 +585,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -743,6 +747,14 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithNumber("max_patch_size",
+				mcp.Description("Maximum number of patch lines to return per file. Longer patches are truncated and marked with [PATCH TRUNCATED] and the number of omitted lines"),
+				mcp.Min(1),
+				mcp.DefaultNumber(defaultMaxPatchLines),
+			),
+			mcp.WithString("file_filter",
+				mcp.Description("Glob pattern to only return matching files, e.g. '**/*.go' for Go files only. Supports '*', '?', character classes and '**' to match any number of directories"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -758,6 +770,22 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			maxPatchSize, err := OptionalIntParamWithDefault(request, "max_patch_size", defaultMaxPatchLines)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxPatchSize < 1 {
+				return mcp.NewToolResultError("max_patch_size must be at least 1"), nil
+			}
+			fileFilter, err := OptionalParam[string](request, "file_filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if fileFilter != "" {
+				if _, err := matchGlob(fileFilter, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid file_filter: %s", err)), nil
+				}
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -789,13 +817,85 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(files)
+			filtered := make([]*github.CommitFile, 0, len(files))
+			for _, file := range files {
+				if fileFilter != "" {
+					// The pattern was validated above, so matching cannot fail here.
+					if matched, _ := matchGlob(fileFilter, file.GetFilename()); !matched {
+						continue
+					}
+				}
+				if file.Patch != nil {
+					file.Patch = github.Ptr(truncatePatch(file.GetPatch(), maxPatchSize))
+				}
+				filtered = append(filtered, file)
+			}
+
+			r, err := json.Marshal(filtered)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// defaultMaxPatchLines is the default number of patch lines returned per file by get_pull_request_files.
+const defaultMaxPatchLines = 500
+
+// truncatePatch limits a patch to maxLines lines, appending a marker with the number of omitted lines.
+func truncatePatch(patch string, maxLines int) string {
+	lines := strings.Split(patch, "\n")
+	if len(lines) <= maxLines {
+		return patch
+	}
+	omitted := len(lines) - maxLines
+	return strings.Join(lines[:maxLines], "\n") + fmt.Sprintf("\n[PATCH TRUNCATED] %d lines omitted", omitted)
+}
+
+// matchGlob reports whether name matches the glob pattern.
+// In addition to the syntax supported by path.Match, a "**" path segment matches
+// zero or more directories.
+// Malformed patterns are always reported, regardless of name.
+func matchGlob(pattern, name string) (bool, error) {
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false, err
+		}
+	}
+	return matchGlobSegments(segments, strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse consecutive "**" segments and try every possible number of skipped directories.
+			for len(pattern) > 0 && pattern[0] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true, nil
+			}
+			for i := 0; i <= len(name); i++ {
+				matched, err := matchGlobSegments(pattern, name[i:])
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
 		}
+
+		if len(name) == 0 {
+			return false, nil
+		}
+		matched, err := path.Match(pattern[0], name[0])
+		if err != nil || !matched {
+			return false, err
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
 }
 
 // GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
@@ -965,6 +1065,162 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 		}
 }
 
+// mergeablePollInterval and mergeablePollTimeout control how update_pull_request_base waits for
+// GitHub to compute whether a pull request can be merged into its new base. They are variables so
+// that tests can shorten them.
+var (
+	mergeablePollInterval = time.Second
+	mergeablePollTimeout  = 10 * time.Second
+)
+
+// PullRequestBaseChange is the output type of the update_pull_request_base tool.
+type PullRequestBaseChange struct {
+	Number       int    `json:"number"`
+	URL          string `json:"url"`
+	PreviousBase string `json:"previous_base"`
+	Base         string `json:"base"`
+	Changed      bool   `json:"changed"`
+	// Mergeable is null if GitHub had not computed it yet.
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state"`
+	Warning        string `json:"warning,omitempty"`
+	Note           string `json:"note,omitempty"`
+}
+
+// UpdatePullRequestBase creates a tool to change the base branch of a pull request.
+func UpdatePullRequestBase(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_pull_request_base",
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BASE_DESCRIPTION", "Change the base branch of a pull request, the branch it will be merged into, such as when the target of a feature branch changes. Returns whether the pull request can be merged into the new base, and warns when it has merge conflicts with it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UPDATE_PULL_REQUEST_BASE_USER_TITLE", "Change pull request base branch"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Name of the new base branch, which must exist in the repository"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Repositories.GetBranch(ctx, owner, repo, base, 0)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s does not exist in %s/%s", base, owner, repo)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get base branch", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			if pr.GetState() != "open" {
+				return mcp.NewToolResultError(fmt.Sprintf("pull request #%d is %s, only the base of open pull requests can be changed", pullNumber, pr.GetState())), nil
+			}
+			if pr.GetHead().GetRepo().GetFullName() == pr.GetBase().GetRepo().GetFullName() && pr.GetHead().GetRef() == base {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is the head branch of pull request #%d, it cannot be its base too", base, pullNumber)), nil
+			}
+
+			result := PullRequestBaseChange{
+				Number:       pr.GetNumber(),
+				URL:          pr.GetHTMLURL(),
+				PreviousBase: pr.GetBase().GetRef(),
+				Base:         base,
+			}
+			if result.PreviousBase != base {
+				// Only send the base, the other fields of the pull request are left as they are
+				pr, resp, err = client.PullRequests.Edit(ctx, owner, repo, pullNumber, &github.PullRequest{
+					Base: &github.PullRequestBranch{Ref: github.Ptr(base)},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to change pull request base", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				result.Changed = true
+
+				// GitHub computes whether the pull request can be merged into its new base in the background
+				pr, resp, err = waitForMergeable(ctx, client, owner, repo, pullNumber, pr)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+				}
+			}
+
+			result.Mergeable = pr.Mergeable
+			result.MergeableState = pr.GetMergeableState()
+			if result.MergeableState == "dirty" {
+				result.Warning = fmt.Sprintf("The pull request has merge conflicts with %s. Use get_merge_conflicts to find the conflicting files.", base)
+			} else {
+				result.Note = mergeableStateNotes[result.MergeableState]
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// waitForMergeable polls a pull request until GitHub has computed whether it can be merged. It
+// returns the last pull request it got if that takes longer than mergeablePollTimeout.
+func waitForMergeable(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pr *github.PullRequest) (*github.PullRequest, *github.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, mergeablePollTimeout)
+	defer cancel()
+
+	for pr.Mergeable == nil {
+		timer := time.NewTimer(mergeablePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return pr, nil, nil
+		case <-timer.C:
+		}
+
+		latest, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+		if err != nil {
+			if ctx.Err() != nil {
+				return pr, nil, nil
+			}
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		pr = latest
+	}
+	return pr, nil, nil
+}
+
 // GetPullRequestComments creates a tool to get the review comments on a pull request.
 func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_comments",
@@ -1205,6 +1461,147 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 		}
 }
 
+// SubmitPullRequestReview creates a tool to submit a review of a pull request together with its line
+// comments, in a single mutation.
+func SubmitPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("submit_pull_request_review",
+			mcp.WithDescription(t("TOOL_SUBMIT_PULL_REQUEST_REVIEW_DESCRIPTION", "Submit a review for a pull request together with comments on lines of its diff, in a single request. The review and all of its comments are created at once or not at all. Comments are placed by their position in the diff of the file, use can_comment_on_line to find it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBMIT_PULL_REQUEST_REVIEW_USER_TITLE", "Submit a pull request review with comments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Review comment text"),
+			),
+			mcp.WithString("event",
+				mcp.Required(),
+				mcp.Description("Review action to perform"),
+				mcp.Enum("APPROVE", "REQUEST_CHANGES", "COMMENT"),
+			),
+			mcp.WithArray("comments",
+				mcp.Description("Comments on lines of the diff to submit with the review"),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"path": map[string]any{
+							"type":        "string",
+							"description": "Path of the file to comment on, relative to the repository root",
+						},
+						"position": map[string]any{
+							"type":        "number",
+							"description": "Position of the line in the diff of the file, counting from 1 at the line below its first @@ hunk header",
+						},
+						"body": map[string]any{
+							"type":        "string",
+							"description": "Text of the comment",
+						},
+					},
+					"required": []string{"path", "position", "body"},
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+				Body       *string
+				Event      string
+				Comments   []struct {
+					Path     string
+					Position int32
+					Body     string
+				}
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.PullNumber < 1 {
+				return mcp.NewToolResultError("missing required parameter: pullNumber"), nil
+			}
+			switch githubv4.PullRequestReviewEvent(params.Event) {
+			case githubv4.PullRequestReviewEventApprove, githubv4.PullRequestReviewEventRequestChanges, githubv4.PullRequestReviewEventComment:
+			default:
+				return mcp.NewToolResultError("event must be one of APPROVE, REQUEST_CHANGES or COMMENT"), nil
+			}
+
+			comments := make([]*githubv4.DraftPullRequestReviewComment, len(params.Comments))
+			for i, comment := range params.Comments {
+				if comment.Path == "" || comment.Body == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("comments[%d] must have a path and a body", i)), nil
+				}
+				if comment.Position < 1 {
+					return mcp.NewToolResultError(fmt.Sprintf("comments[%d].position must be a positive number", i)), nil
+				}
+				comments[i] = &githubv4.DraftPullRequestReviewComment{
+					Path:     githubv4.String(comment.Path),
+					Position: githubv4.Int(comment.Position),
+					Body:     githubv4.String(comment.Body),
+				}
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var getPullRequestQuery struct {
+				Repository struct {
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			if err := client.Query(ctx, &getPullRequestQuery, map[string]any{
+				"owner": githubv4.String(params.Owner),
+				"repo":  githubv4.String(params.Repo),
+				"prNum": githubv4.Int(params.PullNumber),
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get pull request",
+					err,
+				), nil
+			}
+
+			input := githubv4.AddPullRequestReviewInput{
+				PullRequestID: getPullRequestQuery.Repository.PullRequest.ID,
+				Body:          newGQLStringlikePtr[githubv4.String](params.Body),
+				Event:         newGQLStringlike[githubv4.PullRequestReviewEvent](params.Event),
+			}
+			if len(comments) > 0 {
+				input.Comments = &comments
+			}
+
+			var addPullRequestReviewMutation struct {
+				AddPullRequestReview struct {
+					PullRequestReview struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"addPullRequestReview(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &addPullRequestReviewMutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to submit pull request review",
+					err,
+				), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("pull request review submitted successfully with %d comments", len(comments))), nil
+		}
+}
+
 // CreatePendingPullRequestReview creates a tool to create a pending review on a pull request.
 func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("create_pending_pull_request_review",
@@ -1298,9 +1695,9 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 }
 
 // AddCommentToPendingReview creates a tool to add a comment to a pull request review.
-func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func AddCommentToPendingReview(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("add_comment_to_pending_review",
-			mcp.WithDescription(t("TOOL_ADD_COMMENT_TO_PENDING_REVIEW_DESCRIPTION", "Add review comment to the requester's latest pending pull request review. A pending review needs to already exist to call this (check with the user if not sure).")),
+			mcp.WithDescription(t("TOOL_ADD_COMMENT_TO_PENDING_REVIEW_DESCRIPTION", "Add review comment to the requester's latest pending pull request review. A pending review needs to already exist to call this (check with the user if not sure). Line comments must be on lines of the current pull request diff.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_ADD_COMMENT_TO_PENDING_REVIEW_USER_TITLE", "Add review comment to the requester's latest pending pull request review"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -1371,6 +1768,33 @@ func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.Trans
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			if params.SubjectType == "LINE" && params.Line != nil {
+				restClient, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+
+				// Check the line is in the diff up front, so that we can suggest the nearest line that is. When the
+				// diff cannot be fetched, for example because it is too large, we leave the validation to GitHub.
+				if diff, _, err := getPullRequestDiff(ctx, restClient, params.Owner, params.Repo, int(params.PullNumber)); err == nil {
+					var side, startSide string
+					if params.Side != nil {
+						side = *params.Side
+					}
+					if params.StartSide != nil {
+						startSide = *params.StartSide
+					}
+					var startLine int
+					if params.StartLine != nil {
+						startLine = int(*params.StartLine)
+					}
+					check := parseUnifiedDiff(diff).checkCommentable(params.Path, int(*params.Line), side, startLine, startSide)
+					if !check.Commentable {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot comment on this line: %s", check.Reason)), nil
+					}
+				}
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
@@ -1174,6 +1174,8 @@ func Test_GetPullRequestFiles(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "max_patch_size")
+	assert.Contains(t, tool.InputSchema.Properties, "file_filter")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
@@ -1240,6 +1242,75 @@ func Test_GetPullRequestFiles(t *testing.T) {
 			expectError:   false,
 			expectedFiles: mockFiles,
 		},
+		{
+			name: "long patches are truncated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{
+						{
+							Filename:  github.Ptr("big.go"),
+							Status:    github.Ptr("modified"),
+							Additions: github.Ptr(4),
+							Deletions: github.Ptr(0),
+							Patch:     github.Ptr("@@ -1,0 +1,4 @@\n+a\n+b\n+c\n+d"),
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"max_patch_size": float64(2),
+			},
+			expectError: false,
+			expectedFiles: []*github.CommitFile{
+				{
+					Filename:  github.Ptr("big.go"),
+					Status:    github.Ptr("modified"),
+					Additions: github.Ptr(4),
+					Deletions: github.Ptr(0),
+					Patch:     github.Ptr("@@ -1,0 +1,4 @@\n+a\n[PATCH TRUNCATED] 3 lines omitted"),
+				},
+			},
+		},
+		{
+			name: "files filtered by glob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{
+						{Filename: github.Ptr("README.md"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(0)},
+						{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(0)},
+						{Filename: github.Ptr("pkg/github/tools.go"), Status: github.Ptr("added"), Additions: github.Ptr(2), Deletions: github.Ptr(1)},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"file_filter": "**/*.go",
+			},
+			expectError: false,
+			expectedFiles: []*github.CommitFile{
+				{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(0)},
+				{Filename: github.Ptr("pkg/github/tools.go"), Status: github.Ptr("added"), Additions: github.Ptr(2), Deletions: github.Ptr(1)},
+			},
+		},
+		{
+			name:         "invalid file filter",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"file_filter": "[",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid file_filter",
+		},
 		{
 			name: "files fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1298,11 +1369,41 @@ func Test_GetPullRequestFiles(t *testing.T) {
 				assert.Equal(t, *tc.expectedFiles[i].Status, *file.Status)
 				assert.Equal(t, *tc.expectedFiles[i].Additions, *file.Additions)
 				assert.Equal(t, *tc.expectedFiles[i].Deletions, *file.Deletions)
+				assert.Equal(t, tc.expectedFiles[i].GetPatch(), file.GetPatch())
 			}
 		})
 	}
 }
 
+func Test_MatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{pattern: "*.go", name: "main.go", expected: true},
+		{pattern: "*.go", name: "pkg/main.go", expected: false},
+		{pattern: "**/*.go", name: "main.go", expected: true},
+		{pattern: "**/*.go", name: "pkg/github/tools.go", expected: true},
+		{pattern: "**/*.go", name: "README.md", expected: false},
+		{pattern: "pkg/**", name: "pkg/github/tools.go", expected: true},
+		{pattern: "pkg/**/tools.go", name: "pkg/tools.go", expected: true},
+		{pattern: "pkg/**/tools.go", name: "cmd/tools.go", expected: false},
+		{pattern: "docs/?.md", name: "docs/a.md", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.name, func(t *testing.T) {
+			matched, err := matchGlob(tc.pattern, tc.name)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+
+	_, err := matchGlob("src/[", "")
+	assert.Error(t, err)
+}
+
 func Test_GetPullRequestStatus(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1468,6 +1569,228 @@ func Test_GetPullRequestStatus(t *testing.T) {
 	}
 }
 
+func Test_UpdatePullRequestBase(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdatePullRequestBase(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_pull_request_base", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "base"})
+
+	interval := mergeablePollInterval
+	mergeablePollInterval = time.Millisecond
+	t.Cleanup(func() { mergeablePollInterval = interval })
+
+	repository := &github.Repository{FullName: github.Ptr("owner/repo")}
+	pullRequest := func(base string, mergeable *bool, mergeableState string) *github.PullRequest {
+		return &github.PullRequest{
+			Number:         github.Ptr(42),
+			State:          github.Ptr("open"),
+			HTMLURL:        github.Ptr("https://github.com/owner/repo/pull/42"),
+			Head:           &github.PullRequestBranch{Ref: github.Ptr("feature"), Repo: repository},
+			Base:           &github.PullRequestBranch{Ref: github.Ptr(base), Repo: repository},
+			Mergeable:      mergeable,
+			MergeableState: github.Ptr(mergeableState),
+		}
+	}
+	branch := &github.Branch{Name: github.Ptr("release")}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedResult PullRequestBaseChange
+		expectedErrMsg string
+	}{
+		{
+			name: "base changed with conflicts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					branch,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					pullRequest("main", github.Ptr(true), "clean"),
+					pullRequest("release", nil, "unknown"),
+					pullRequest("release", github.Ptr(false), "dirty"),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPullsByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"base": "release",
+					}).andThen(
+						mockResponse(t, http.StatusOK, pullRequest("release", nil, "unknown")),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"base":       "release",
+			},
+			expectedResult: PullRequestBaseChange{
+				Number:         42,
+				URL:            "https://github.com/owner/repo/pull/42",
+				PreviousBase:   "main",
+				Base:           "release",
+				Changed:        true,
+				Mergeable:      github.Ptr(false),
+				MergeableState: "dirty",
+				Warning:        "The pull request has merge conflicts with release. Use get_merge_conflicts to find the conflicting files.",
+			},
+		},
+		{
+			name: "base changed without conflicts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					branch,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					pullRequest("main", github.Ptr(true), "clean"),
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposPullsByOwnerByRepoByPullNumber,
+					pullRequest("release", github.Ptr(true), "clean"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"base":       "release",
+			},
+			expectedResult: PullRequestBaseChange{
+				Number:         42,
+				URL:            "https://github.com/owner/repo/pull/42",
+				PreviousBase:   "main",
+				Base:           "release",
+				Changed:        true,
+				Mergeable:      github.Ptr(true),
+				MergeableState: "clean",
+				Note:           mergeableStateNotes["clean"],
+			},
+		},
+		{
+			name: "base already set",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					branch,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					pullRequest("release", github.Ptr(true), "blocked"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"base":       "release",
+			},
+			expectedResult: PullRequestBaseChange{
+				Number:         42,
+				URL:            "https://github.com/owner/repo/pull/42",
+				PreviousBase:   "release",
+				Base:           "release",
+				Mergeable:      github.Ptr(true),
+				MergeableState: "blocked",
+				Note:           mergeableStateNotes["blocked"],
+			},
+		},
+		{
+			name: "base branch does not exist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"base":       "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch missing does not exist in owner/repo",
+		},
+		{
+			name: "base is the head branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					&github.Branch{Name: github.Ptr("feature")},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					pullRequest("main", github.Ptr(true), "clean"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"base":       "feature",
+			},
+			expectError:    true,
+			expectedErrMsg: "feature is the head branch of pull request #42, it cannot be its base too",
+		},
+		{
+			name: "closed pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					branch,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Number: github.Ptr(42), State: github.Ptr("closed")},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"base":       "release",
+			},
+			expectError:    true,
+			expectedErrMsg: "pull request #42 is closed, only the base of open pull requests can be changed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdatePullRequestBase(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var returned PullRequestBaseChange
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
 func Test_UpdatePullRequestBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -2199,6 +2522,193 @@ func TestCreateAndSubmitPullRequestReview(t *testing.T) {
 	}
 }
 
+func TestSubmitPullRequestReview(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := SubmitPullRequestReview(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "submit_pull_request_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "comments")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "event"})
+
+	getPullRequestMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"pullRequest(number: $prNum)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"id": "PR_kwDODKw3uc6WYN1T",
+				},
+			},
+		}),
+	)
+	addReviewMutation := struct {
+		AddPullRequestReview struct {
+			PullRequestReview struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addPullRequestReview(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedText       string
+	}{
+		{
+			name: "submits review with comments",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				getPullRequestMatcher,
+				githubv4mock.NewMutationMatcher(
+					addReviewMutation,
+					githubv4.AddPullRequestReviewInput{
+						PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+						Body:          githubv4.NewString("Looks good with two nits"),
+						Event:         githubv4mock.Ptr(githubv4.PullRequestReviewEventRequestChanges),
+						Comments: &[]*githubv4.DraftPullRequestReviewComment{
+							{Path: "main.go", Position: 3, Body: "Handle this error"},
+							{Path: "README.md", Position: 1, Body: "Typo"},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"body":       "Looks good with two nits",
+				"event":      "REQUEST_CHANGES",
+				"comments": []any{
+					map[string]any{"path": "main.go", "position": float64(3), "body": "Handle this error"},
+					map[string]any{"path": "README.md", "position": float64(1), "body": "Typo"},
+				},
+			},
+			expectedText: "pull request review submitted successfully with 2 comments",
+		},
+		{
+			name: "submits review without comments",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				getPullRequestMatcher,
+				githubv4mock.NewMutationMatcher(
+					addReviewMutation,
+					githubv4.AddPullRequestReviewInput{
+						PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+						Event:         githubv4mock.Ptr(githubv4.PullRequestReviewEventApprove),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "APPROVE",
+			},
+			expectedText: "pull request review submitted successfully with 0 comments",
+		},
+		{
+			name:         "invalid event",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "MERGE",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "event must be one of APPROVE, REQUEST_CHANGES or COMMENT",
+		},
+		{
+			name:         "comment without position",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "COMMENT",
+				"comments": []any{
+					map[string]any{"path": "main.go", "body": "Handle this error"},
+				},
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "comments[0].position must be a positive number",
+		},
+		{
+			name: "failure to submit review",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				getPullRequestMatcher,
+				githubv4mock.NewMutationMatcher(
+					addReviewMutation,
+					githubv4.AddPullRequestReviewInput{
+						PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+						Event:         githubv4mock.Ptr(githubv4.PullRequestReviewEventComment),
+						Comments: &[]*githubv4.DraftPullRequestReviewComment{
+							{Path: "main.go", Position: 99, Body: "Out of the diff"},
+						},
+					},
+					nil,
+					githubv4mock.ErrorResponse("Pull request review thread position is invalid"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "COMMENT",
+				"comments": []any{
+					map[string]any{"path": "main.go", "position": float64(99), "body": "Out of the diff"},
+				},
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "Pull request review thread position is invalid",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := SubmitPullRequestReview(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
 func Test_RequestCopilotReview(t *testing.T) {
 	t.Parallel()
 
@@ -2500,7 +3010,7 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 
 	// Verify tool definition once
 	mockClient := githubv4.NewClient(nil)
-	tool, _ := AddCommentToPendingReview(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := AddCommentToPendingReview(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "add_comment_to_pending_review", tool.Name)
@@ -2517,8 +3027,23 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "startSide")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "path", "body", "subjectType"})
 
+	stubbedDiff := `diff --git a/file.go b/file.go
+index 5d6e7b2..8a4f5c3 100644
+--- a/file.go
++++ b/file.go
+@@ -4,6 +4,8 @@ import "fmt"
+ func main() {
+ 	fmt.Println("hello")
+ 	fmt.Println("world")
++	fmt.Println("from")
++	fmt.Println("the pull request")
+ }
+ 
+ func helper() {`
+
 	tests := []struct {
 		name               string
+		mockedRESTClient   *http.Client
 		mockedClient       *http.Client
 		requestArgs        map[string]any
 		expectToolError    bool
@@ -2526,6 +3051,14 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 	}{
 		{
 			name: "successful line comment addition",
+			mockedRESTClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+						mockResponse(t, http.StatusOK, stubbedDiff),
+					),
+				),
+			),
 			requestArgs: map[string]any{
 				"owner":       "owner",
 				"repo":        "repo",
@@ -2577,6 +3110,73 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 				),
 			),
 		},
+		{
+			name: "line outside the diff is rejected with the nearest line",
+			mockedRESTClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, stubbedDiff),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"path":        "file.go",
+				"body":        "This is a test comment",
+				"subjectType": "LINE",
+				"line":        float64(20),
+				"side":        "RIGHT",
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "cannot comment on this line: line 20 on the RIGHT side of file.go is not part of the pull request diff, the nearest commentable line is 11",
+		},
+		{
+			name: "file comment is not validated against the diff",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"path":        "other.go",
+				"body":        "This is a test comment",
+				"subjectType": "FILE",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+					author: "williammartin",
+					owner:  "owner",
+					repo:   "repo",
+					prNum:  42,
+
+					reviews: []getLatestPendingReviewQueryReview{
+						{
+							id:    "PR_kwDODKw3uc6WYN1T",
+							state: "PENDING",
+							url:   "https://github.com/owner/repo/pull/42",
+						},
+					},
+				}),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddPullRequestReviewThread struct {
+							Thread struct {
+								ID githubv4.String // We don't need this, but a selector is required or GQL complains.
+							}
+						} `graphql:"addPullRequestReviewThread(input: $input)"`
+					}{},
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String("other.go"),
+						Body:                githubv4.String("This is a test comment"),
+						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeFile),
+						PullRequestReviewID: githubv4.NewID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -2584,8 +3184,9 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 			t.Parallel()
 
 			// Setup client with mock
+			restClient := github.NewClient(tc.mockedRESTClient)
 			client := githubv4.NewClient(tc.mockedClient)
-			_, handler := AddCommentToPendingReview(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+			_, handler := AddCommentToPendingReview(stubGetClientFn(restClient), stubGetGQLClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRateLimitResource is the category assumed for responses that carry rate limit headers
+// but no x-ratelimit-resource header, as older GitHub Enterprise Server versions do.
+const defaultRateLimitResource = "core"
+
+// RateLimit is the most recently observed rate limit of a resource category, such as "core",
+// "search" or "graphql".
+type RateLimit struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+}
+
+// RateLimitState tracks the rate limits reported by GitHub per resource category. It is safe for
+// concurrent use.
+type RateLimitState struct {
+	mu     sync.RWMutex
+	limits map[string]RateLimit
+}
+
+// NewRateLimitState returns an empty RateLimitState.
+func NewRateLimitState() *RateLimitState {
+	return &RateLimitState{limits: make(map[string]RateLimit)}
+}
+
+// defaultRateLimitState is the state updated by transports that are not given one of their own.
+var defaultRateLimitState = NewRateLimitState()
+
+// GetRateLimitState returns the rate limits observed by the server's GitHub clients, keyed by
+// resource category.
+func GetRateLimitState() map[string]RateLimit {
+	return defaultRateLimitState.Get()
+}
+
+// Get returns a copy of the tracked rate limits, keyed by resource category.
+func (s *RateLimitState) Get() map[string]RateLimit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limits := make(map[string]RateLimit, len(s.limits))
+	for resource, limit := range s.limits {
+		limits[resource] = limit
+	}
+	return limits
+}
+
+// Update records the rate limit reported by resp, if any. Responses to concurrent requests can
+// arrive out of order, so a report from an older window, or one with more requests remaining in
+// the same window, does not replace what is already known.
+func (s *RateLimitState) Update(resp *http.Response) {
+	limit, ok := parseRateLimit(resp)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.limits[limit.Resource]; ok {
+		if limit.Reset.Before(current.Reset) {
+			return
+		}
+		if limit.Reset.Equal(current.Reset) && limit.Remaining > current.Remaining {
+			return
+		}
+	}
+	s.limits[limit.Resource] = limit
+}
+
+// parseRateLimit reads the x-ratelimit-* headers of resp. It reports false when resp carries no
+// rate limit, as is the case when rate limiting is disabled on GitHub Enterprise Server.
+func parseRateLimit(resp *http.Response) (RateLimit, bool) {
+	if resp == nil {
+		return RateLimit{}, false
+	}
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rateLimit := RateLimit{
+		Resource:  resp.Header.Get("X-RateLimit-Resource"),
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      limit - remaining,
+	}
+	if rateLimit.Resource == "" {
+		rateLimit.Resource = defaultRateLimitResource
+	}
+	if used, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Used")); err == nil {
+		rateLimit.Used = used
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0).UTC()
+	}
+	return rateLimit, true
+}
+
+// RateLimitTrackingTransport is an http.RoundTripper that records the rate limit reported with
+// each response in State, or in the server-wide state returned by GetRateLimitState if State is nil.
+type RateLimitTrackingTransport struct {
+	Transport http.RoundTripper
+	State     *RateLimitState
+}
+
+func (t *RateLimitTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	state := t.State
+	if state == nil {
+		state = defaultRateLimitState
+	}
+	state.Update(resp)
+	return resp, nil
+}
+
+// defaultSecondaryRateLimitWait is how long GitHub asks clients to wait after exceeding a secondary
+// rate limit when the response does not say.
+const defaultSecondaryRateLimitWait = time.Minute
+
+// maxSecondaryRateLimitRetries bounds how often SecondaryRateLimitTransport retries a request.
+const maxSecondaryRateLimitRetries = 2
+
+// secondaryRateLimitWait reports whether resp is GitHub refusing a request for exceeding a secondary
+// rate limit, and how long to wait before retrying. It reads the body of resp and restores it.
+func secondaryRateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	// Exhausting the primary rate limit is not a secondary rate limit
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return defaultSecondaryRateLimitWait, true
+	}
+
+	// Forbidden is also returned for missing permissions, which the body tells apart
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 0, false
+	}
+	message := strings.ToLower(string(body))
+	if strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection") {
+		return defaultSecondaryRateLimitWait, true
+	}
+	return 0, false
+}
+
+// SecondaryRateLimitTransport is an http.RoundTripper that waits and retries requests GitHub refuses
+// for exceeding a secondary rate limit, when it asks to wait at most MaxWait. Other responses, and
+// all responses when MaxWait is 0, are returned as is, for tools to report.
+type SecondaryRateLimitTransport struct {
+	Transport http.RoundTripper
+	MaxWait   time.Duration
+}
+
+func (t *SecondaryRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := transport.RoundTrip(req)
+		if err != nil || t.MaxWait <= 0 || attempt == maxSecondaryRateLimitRetries {
+			return resp, err
+		}
+		wait, ok := secondaryRateLimitWait(resp)
+		if !ok || wait > t.MaxWait {
+			return resp, nil
+		}
+		// Requests whose body cannot be sent again are not retried
+		hasBody := req.Body != nil && req.Body != http.NoBody
+		if hasBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if hasBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
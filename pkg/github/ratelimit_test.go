@@ -0,0 +1,265 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rateLimitResponse(resource string, limit, remaining int, reset time.Time) *http.Response {
+	header := http.Header{}
+	if resource != "" {
+		header.Set("X-RateLimit-Resource", resource)
+	}
+	header.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Used", strconv.Itoa(limit-remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return &http.Response{Header: header}
+}
+
+func Test_RateLimitState(t *testing.T) {
+	reset := time.Unix(1700000000, 0).UTC()
+
+	tests := []struct {
+		name      string
+		responses []*http.Response
+		expected  map[string]RateLimit
+	}{
+		{
+			name: "tracks each resource category separately",
+			responses: []*http.Response{
+				rateLimitResponse("core", 5000, 4990, reset),
+				rateLimitResponse("graphql", 5000, 4900, reset),
+				rateLimitResponse("search", 30, 29, reset),
+			},
+			expected: map[string]RateLimit{
+				"core":    {Resource: "core", Limit: 5000, Remaining: 4990, Used: 10, Reset: reset},
+				"graphql": {Resource: "graphql", Limit: 5000, Remaining: 4900, Used: 100, Reset: reset},
+				"search":  {Resource: "search", Limit: 30, Remaining: 29, Used: 1, Reset: reset},
+			},
+		},
+		{
+			name: "keeps the lowest remaining count within a window",
+			responses: []*http.Response{
+				rateLimitResponse("core", 5000, 4980, reset),
+				rateLimitResponse("core", 5000, 4990, reset),
+			},
+			expected: map[string]RateLimit{
+				"core": {Resource: "core", Limit: 5000, Remaining: 4980, Used: 20, Reset: reset},
+			},
+		},
+		{
+			name: "replaces the limit when a new window starts",
+			responses: []*http.Response{
+				rateLimitResponse("core", 5000, 10, reset),
+				rateLimitResponse("core", 5000, 4999, reset.Add(time.Hour)),
+				rateLimitResponse("core", 5000, 5, reset),
+			},
+			expected: map[string]RateLimit{
+				"core": {Resource: "core", Limit: 5000, Remaining: 4999, Used: 1, Reset: reset.Add(time.Hour)},
+			},
+		},
+		{
+			name: "assumes core when the resource is not reported",
+			responses: []*http.Response{
+				rateLimitResponse("", 5000, 4999, reset),
+			},
+			expected: map[string]RateLimit{
+				"core": {Resource: "core", Limit: 5000, Remaining: 4999, Used: 1, Reset: reset},
+			},
+		},
+		{
+			name: "ignores responses without rate limit headers",
+			responses: []*http.Response{
+				{Header: http.Header{}},
+				nil,
+			},
+			expected: map[string]RateLimit{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			state := NewRateLimitState()
+			for _, resp := range tc.responses {
+				state.Update(resp)
+			}
+			assert.Equal(t, tc.expected, state.Get())
+		})
+	}
+}
+
+func Test_RateLimitStateGetReturnsCopy(t *testing.T) {
+	state := NewRateLimitState()
+	state.Update(rateLimitResponse("core", 5000, 4999, time.Unix(1700000000, 0)))
+
+	limits := state.Get()
+	delete(limits, "core")
+
+	assert.Contains(t, state.Get(), "core")
+}
+
+func Test_RateLimitTrackingTransport(t *testing.T) {
+	reset := time.Unix(1700000000, 0).UTC()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := "core"
+		if r.URL.Path == "/graphql" {
+			resource = "graphql"
+		}
+		w.Header().Set("X-RateLimit-Resource", resource)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		w.Header().Set("X-RateLimit-Used", "1000")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	state := NewRateLimitState()
+	client := &http.Client{Transport: &RateLimitTrackingTransport{State: state}}
+
+	var wg sync.WaitGroup
+	for _, path := range []string{"/user", "/graphql", "/user", "/graphql"} {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			resp, err := client.Get(ts.URL + path)
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+			}
+		}(path)
+	}
+	wg.Wait()
+
+	limits := state.Get()
+	require.Len(t, limits, 2)
+	assert.Equal(t, RateLimit{Resource: "core", Limit: 5000, Remaining: 4000, Used: 1000, Reset: reset}, limits["core"])
+	assert.Equal(t, RateLimit{Resource: "graphql", Limit: 5000, Remaining: 4000, Used: 1000, Reset: reset}, limits["graphql"])
+}
+
+func Test_SecondaryRateLimitTransport(t *testing.T) {
+	secondaryRateLimitBody := `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`
+
+	// newServer returns a server that refuses the first limited requests with respond, then succeeds.
+	// It records the bodies of the requests it receives.
+	newServer := func(t *testing.T, limited int, respond func(http.ResponseWriter)) (*httptest.Server, *[]string) {
+		var bodies []string
+		var mu sync.Mutex
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, string(body))
+			count := len(bodies)
+			mu.Unlock()
+			if count <= limited {
+				respond(w)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+		t.Cleanup(ts.Close)
+		return ts, &bodies
+	}
+	retryAfter := func(seconds string) func(http.ResponseWriter) {
+		return func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", seconds)
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(secondaryRateLimitBody))
+		}
+	}
+
+	t.Run("waits and retries with the request body", func(t *testing.T) {
+		ts, bodies := newServer(t, 2, retryAfter("0"))
+		client := &http.Client{Transport: &SecondaryRateLimitTransport{MaxWait: time.Second}}
+
+		resp, err := client.Post(ts.URL+"/repos/owner/repo/issues", "application/json", strings.NewReader(`{"title":"Bug"}`))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, []string{`{"title":"Bug"}`, `{"title":"Bug"}`, `{"title":"Bug"}`}, *bodies)
+	})
+
+	t.Run("gives up after the last retry", func(t *testing.T) {
+		ts, bodies := newServer(t, 5, retryAfter("0"))
+		client := &http.Client{Transport: &SecondaryRateLimitTransport{MaxWait: time.Second}}
+
+		resp, err := client.Get(ts.URL + "/user")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Len(t, *bodies, maxSecondaryRateLimitRetries+1)
+	})
+
+	t.Run("does not wait longer than allowed", func(t *testing.T) {
+		// Without Retry-After, GitHub asks to wait a minute
+		ts, bodies := newServer(t, 1, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(secondaryRateLimitBody))
+		})
+		client := &http.Client{Transport: &SecondaryRateLimitTransport{MaxWait: time.Second}}
+
+		resp, err := client.Get(ts.URL + "/user")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+		// The body read to recognize the limit is still there for the caller
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, secondaryRateLimitBody, string(body))
+	})
+
+	t.Run("does not retry when waiting is disabled", func(t *testing.T) {
+		ts, bodies := newServer(t, 1, retryAfter("0"))
+		client := &http.Client{Transport: &SecondaryRateLimitTransport{}}
+
+		resp, err := client.Get(ts.URL + "/user")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("does not retry other failures", func(t *testing.T) {
+		ts, bodies := newServer(t, 1, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Resource not accessible by personal access token"}`))
+		})
+		client := &http.Client{Transport: &SecondaryRateLimitTransport{MaxWait: time.Minute}}
+
+		resp, err := client.Get(ts.URL + "/user")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("tools report the wait when not retrying", func(t *testing.T) {
+		ts, _ := newServer(t, 1, retryAfter("30"))
+		client := github.NewClient(&http.Client{Transport: &SecondaryRateLimitTransport{MaxWait: time.Second}})
+		client.BaseURL, _ = url.Parse(ts.URL + "/")
+		_, handler := CreateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"title": "Bug",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "A GitHub secondary rate limit was exceeded, retry after 30 seconds.")
+	})
+}
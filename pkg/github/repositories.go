@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -89,7 +92,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -179,14 +182,21 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, perPage)), nil
 		}
 }
 
+// MinimalBranch is the output type for repository branches.
+type MinimalBranch struct {
+	Name      string `json:"name"`
+	SHA       string `json:"sha"`
+	Protected bool   `json:"protected"`
+}
+
 // ListBranches creates a tool to list branches in a GitHub repository.
 func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_branches",
-			mcp.WithDescription(t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository")),
+			mcp.WithDescription(t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository, with the SHA of their head commit and whether they are protected. Protected branches may not accept direct pushes.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -199,6 +209,9 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			mcp.WithBoolean("protected",
+				mcp.Description("If true, list only protected branches; if false, only unprotected ones. Lists all branches if omitted."),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -210,6 +223,10 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			protected, protectedProvided, err := OptionalParamOK[bool](request, "protected")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -221,6 +238,9 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 					PerPage: pagination.PerPage,
 				},
 			}
+			if protectedProvided {
+				opts.Protected = github.Ptr(protected)
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -245,19 +265,56 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(branches)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			result := make([]MinimalBranch, 0, len(branches))
+			for _, branch := range branches {
+				result = append(result, MinimalBranch{
+					Name:      branch.GetName(),
+					SHA:       branch.GetCommit().GetSHA(),
+					Protected: branch.GetProtected(),
+				})
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
+// Modes of the create_or_update_file tool.
+const (
+	fileUpdateModeReplace          = "replace"
+	fileUpdateModeAppend           = "append"
+	fileUpdateModePrepend          = "prepend"
+	fileUpdateModeReplaceRange     = "replace_range"
+	fileUpdateModeApplyUnifiedDiff = "apply_unified_diff"
+)
+
+var fileUpdateModes = []string{
+	fileUpdateModeReplace,
+	fileUpdateModeAppend,
+	fileUpdateModePrepend,
+	fileUpdateModeReplaceRange,
+	fileUpdateModeApplyUnifiedDiff,
+}
+
+// patchFileContent applies the edit of a create_or_update_file mode other than replace to current.
+func patchFileContent(mode, current, content string, startLine, endLine int) (string, error) {
+	switch mode {
+	case fileUpdateModeAppend:
+		return appendContent(current, content), nil
+	case fileUpdateModePrepend:
+		return prependContent(current, content), nil
+	case fileUpdateModeReplaceRange:
+		return replaceLineRange(current, startLine, endLine, content)
+	case fileUpdateModeApplyUnifiedDiff:
+		return applyUnifiedDiff(current, content)
+	default:
+		return "", fmt.Errorf("invalid mode %q, must be one of %s", mode, strings.Join(fileUpdateModes, ", "))
+	}
+}
+
 // CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
 func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_or_update_file",
-			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations.")),
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations. Rather than rewriting a large file, edit it with the append, prepend, replace_range or apply_unified_diff modes, which apply the edit to the file as of the given SHA and fail if it has changed since.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -276,7 +333,18 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			),
 			mcp.WithString("content",
 				mcp.Required(),
-				mcp.Description("Content of the file"),
+				mcp.Description("Content of the file. For the append and prepend modes, the lines to add, for replace_range the lines replacing the range, which deletes it if empty, and for apply_unified_diff a unified diff of the file"),
+			),
+			mcp.WithString("mode",
+				mcp.Description("How content changes the file: replace writes the whole file, append and prepend add lines to its end or start, replace_range replaces the lines from start_line to end_line, and apply_unified_diff applies a diff. All but replace require the sha of the file"),
+				mcp.Enum(fileUpdateModes...),
+				mcp.DefaultString(fileUpdateModeReplace),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to replace in the replace_range mode, starting at 1"),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to replace in the replace_range mode, inclusive"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
@@ -315,6 +383,67 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			mode, err := OptionalParam[string](request, "mode")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if mode == "" {
+				mode = fileUpdateModeReplace
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// If SHA is provided, set it (for updates)
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if mode != fileUpdateModeReplace && sha == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("sha is required for the %s mode, it is the blob SHA of the file the edit is based on", mode)), nil
+			}
+			if mode == fileUpdateModeReplaceRange && (startLine == 0 || endLine == 0) {
+				return mcp.NewToolResultError("start_line and end_line are required for the replace_range mode"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if mode != fileUpdateModeReplace {
+				// Apply the edit to the file as of sha, so that it is not applied to other changes
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("%s does not exist on %s, create it with the replace mode", path, branch)), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get the current file", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				if file == nil || file.GetType() != "file" {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is not a file", path)), nil
+				}
+				if file.GetSHA() != sha {
+					return mcp.NewToolResultError(fmt.Sprintf("%s has changed since blob %s, it is blob %s on %s now. Get its current content and try again", path, sha, file.GetSHA(), branch)), nil
+				}
+				if file.GetEncoding() == "none" {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is too large to be edited with the %s mode, use the replace mode", path, mode)), nil
+				}
+				current, err := file.GetContent()
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode file content: %w", err)
+				}
+				content, err = patchFileContent(mode, current, content, startLine, endLine)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
 
 			// json.Marshal encodes byte arrays with base64, which is required for the API.
 			contentBytes := []byte(content)
@@ -325,23 +454,20 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				Content: contentBytes,
 				Branch:  github.Ptr(branch),
 			}
-
-			// If SHA is provided, set it (for updates)
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
 			if sha != "" {
 				opts.SHA = github.Ptr(sha)
 			}
 
 			// Create or update the file
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
 			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create/update file: %s has changed since blob %s, get its current content and try again", path, sha),
+						resp,
+						err,
+					), nil
+				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create/update file",
 					resp,
@@ -369,7 +495,8 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 
 // CreateRepository creates a tool to create a new GitHub repository.
 func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_repository",
+	return withIdempotencyKey(createIdempotency,
+		mcp.NewTool("create_repository",
 			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
@@ -442,7 +569,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			}
 
 			return mcp.NewToolResultText(string(r)), nil
-		}
+		})
 }
 
 // GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
@@ -778,10 +905,123 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			// The contents API needs the blob SHA of the file it deletes
+			file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to delete file: %s does not exist on branch %s", path, branch),
+						resp,
+						err,
+					), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get file",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if file == nil || file.GetType() != "file" {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete file: %s is not a file", path)), nil
+			}
+
+			deleted, resp, err := client.Repositories.DeleteFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				SHA:     file.SHA,
+				Branch:  github.Ptr(branch),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to delete file",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(deleted), nil
+		}
+}
+
+// RenameFile creates a tool to rename or move a file in a repository in a single commit.
+func RenameFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rename_file",
+			mcp.WithDescription(t("TOOL_RENAME_FILE_DESCRIPTION", "Rename or move a file in a GitHub repository in a single commit. The file content, history and mode are preserved.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RENAME_FILE_USER_TITLE", "Rename or move file"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to rename the file on"),
+			),
+			mcp.WithString("old_path",
+				mcp.Required(),
+				mcp.Description("Current path of the file"),
+			),
+			mcp.WithString("new_path",
+				mcp.Required(),
+				mcp.Description("New path of the file. Must not already exist"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			oldPath, err := RequiredParam[string](request, "old_path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newPath, err := RequiredParam[string](request, "new_path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			oldPath = strings.Trim(oldPath, "/")
+			newPath = strings.Trim(newPath, "/")
+			if oldPath == newPath {
+				return mcp.NewToolResultError("old_path and new_path must be different"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
 			// Get the reference for the branch
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get branch reference",
+					resp,
+					err,
+				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -796,26 +1036,55 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			// Get the full tree to find the file and make sure the new path is free
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, *baseCommit.Tree.SHA, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			var file *github.TreeEntry
+			for _, entry := range tree.Entries {
+				switch entryPath := entry.GetPath(); {
+				case entryPath == oldPath:
+					file = entry
+				case entryPath == newPath, strings.HasPrefix(entryPath, newPath+"/"):
+					return mcp.NewToolResultError(fmt.Sprintf("new_path %s already exists on branch %s", newPath, branch)), nil
+				case strings.HasPrefix(newPath, entryPath+"/") && entry.GetType() != "tree":
+					return mcp.NewToolResultError(fmt.Sprintf("new_path %s is inside %s, which is a file", newPath, entryPath)), nil
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+			}
+			if file == nil {
+				if tree.GetTruncated() {
+					return mcp.NewToolResultError(fmt.Sprintf("file %s was not found, the repository tree is too large to be searched completely", oldPath)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("file %s not found on branch %s", oldPath, branch)), nil
+			}
+			if file.GetType() != "blob" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is not a file", oldPath)), nil
 			}
 
-			// Create a tree entry for the file deletion by setting SHA to nil
-			treeEntries := []*github.TreeEntry{
+			// Remove the old path and add the same blob at the new path
+			entries := []*github.TreeEntry{
 				{
-					Path: github.Ptr(path),
-					Mode: github.Ptr("100644"), // Regular file mode
+					Path: github.Ptr(oldPath),
+					Mode: file.Mode,
 					Type: github.Ptr("blob"),
 					SHA:  nil, // Setting SHA to nil deletes the file
 				},
+				{
+					Path: github.Ptr(newPath),
+					Mode: file.Mode,
+					Type: github.Ptr("blob"),
+					SHA:  file.SHA,
+				},
 			}
 
-			// Create a new tree with the deletion
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create tree",
@@ -825,14 +1094,6 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil
-			}
-
 			// Create a new commit with the new tree
 			commit := &github.Commit{
 				Message: github.Ptr(message),
@@ -849,14 +1110,6 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil
-			}
-
 			// Update the branch reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
 			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
@@ -869,18 +1122,10 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil
-			}
-
-			// Create a response similar to what the DeleteFile API would return
 			response := map[string]interface{}{
-				"commit":  newCommit,
-				"content": nil,
+				"commit":   newCommit,
+				"old_path": oldPath,
+				"new_path": newPath,
 			}
 
 			r, err := json.Marshal(response)
@@ -1230,7 +1475,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -1382,7 +1627,7 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 		}
 }
 
@@ -1575,3 +1820,523 @@ func resolveGitReference(ctx context.Context, githubClient *github.Client, owner
 	sha = reference.GetObject().GetSHA()
 	return &raw.ContentOpts{Ref: ref, SHA: sha}, nil
 }
+
+// CommunityProfileFile describes whether a community health file is present in a repository.
+type CommunityProfileFile struct {
+	Present bool   `json:"present"`
+	URL     string `json:"url,omitempty"`
+}
+
+// CommunityProfile is the output type for the get_community_profile tool.
+type CommunityProfile struct {
+	HealthPercentage    int                  `json:"health_percentage"`
+	Description         string               `json:"description,omitempty"`
+	Documentation       string               `json:"documentation,omitempty"`
+	Readme              CommunityProfileFile `json:"readme"`
+	CodeOfConduct       CommunityProfileFile `json:"code_of_conduct"`
+	Contributing        CommunityProfileFile `json:"contributing"`
+	License             CommunityProfileFile `json:"license"`
+	IssueTemplate       CommunityProfileFile `json:"issue_template"`
+	PullRequestTemplate CommunityProfileFile `json:"pull_request_template"`
+	UpdatedAt           *time.Time           `json:"updated_at,omitempty"`
+}
+
+// communityProfileFile converts a community health metric into a CommunityProfileFile.
+func communityProfileFile(metrics ...*github.Metric) CommunityProfileFile {
+	for _, m := range metrics {
+		if m != nil {
+			return CommunityProfileFile{
+				Present: true,
+				URL:     m.GetHTMLURL(),
+			}
+		}
+	}
+	return CommunityProfileFile{}
+}
+
+// GetCommunityProfile creates a tool to get the community health metrics of a GitHub repository.
+func GetCommunityProfile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_community_profile",
+			mcp.WithDescription(t("TOOL_GET_COMMUNITY_PROFILE_DESCRIPTION", "Get the community health metrics of a GitHub repository, including the health percentage and whether a README, code of conduct, contributing guide, license, issue templates and pull request template are present")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMUNITY_PROFILE_USER_TITLE", "Get community profile"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			metrics, resp, err := client.Repositories.GetCommunityHealthMetrics(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get community profile",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get community profile: %s", string(body))), nil
+			}
+
+			profile := CommunityProfile{
+				HealthPercentage: metrics.GetHealthPercentage(),
+				Description:      metrics.GetDescription(),
+				Documentation:    metrics.GetDocumentation(),
+			}
+			if files := metrics.Files; files != nil {
+				profile.Readme = communityProfileFile(files.Readme)
+				profile.CodeOfConduct = communityProfileFile(files.CodeOfConductFile, files.CodeOfConduct)
+				profile.Contributing = communityProfileFile(files.Contributing)
+				profile.License = communityProfileFile(files.License)
+				profile.IssueTemplate = communityProfileFile(files.IssueTemplate)
+				profile.PullRequestTemplate = communityProfileFile(files.PullRequestTemplate)
+			}
+			if metrics.UpdatedAt != nil {
+				updatedAt := metrics.GetUpdatedAt().Time
+				profile.UpdatedAt = &updatedAt
+			}
+
+			return MarshalledTextResult(profile), nil
+		}
+}
+
+// MinimalContributor is the output type for repository contributors.
+type MinimalContributor struct {
+	Login         string  `json:"login,omitempty"`
+	Email         string  `json:"email,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Type          string  `json:"type,omitempty"`
+	AvatarURL     string  `json:"avatar_url,omitempty"`
+	Contributions int     `json:"contributions"`
+	Percentage    float64 `json:"percentage"`
+}
+
+// ListContributorsResult is the output type for the list_contributors tool.
+type ListContributorsResult struct {
+	TotalContributions int                  `json:"total_contributions"`
+	Contributors       []MinimalContributor `json:"contributors"`
+	Note               string               `json:"note,omitempty"`
+}
+
+// ListContributors creates a tool to list contributors of a GitHub repository along with their share of contributions.
+func ListContributors(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_contributors",
+			mcp.WithDescription(t("TOOL_LIST_CONTRIBUTORS_DESCRIPTION", "List contributors of a GitHub repository with their number of contributions and percentage of the total contributions to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CONTRIBUTORS_USER_TITLE", "List contributors"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("anon",
+				mcp.Description("Include anonymous contributors, which are identified by email instead of login"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction by number of contributions"),
+				mcp.Enum("desc", "asc"),
+				mcp.DefaultString("desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			anon, err := OptionalParam[bool](request, "anon")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if direction != "" && direction != "desc" && direction != "asc" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid direction: %s, must be one of desc or asc", direction)), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListContributorsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if anon {
+				opts.Anon = "1"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			contributors, resp, err := client.Repositories.ListContributors(ctx, owner, repo, opts)
+			if err != nil {
+				// GitHub refuses to compute the contributor list for repositories with a very large history.
+				if resp != nil && resp.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(err.Error()), "too large") {
+					return MarshalledTextResult(ListContributorsResult{
+						Contributors: []MinimalContributor{},
+						Note:         "Contributor statistics are unavailable because the repository history is too large for the GitHub API to list contributors.",
+					}), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list contributors",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list contributors: %s", string(body))), nil
+			}
+
+			result := ListContributorsResult{
+				Contributors: make([]MinimalContributor, 0, len(contributors)),
+			}
+			for _, c := range contributors {
+				result.TotalContributions += c.GetContributions()
+			}
+			// Percentages are of the contributions of all contributors, not only of those of the page
+			if resp.NextPage != 0 || resp.PrevPage != 0 {
+				total, complete, totalResp, err := sumContributions(ctx, client, owner, repo, opts.Anon)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to count contributions",
+						totalResp,
+						err,
+					), nil
+				}
+				result.TotalContributions = total
+				if !complete {
+					result.Note = fmt.Sprintf("The repository has more than %d contributors, total_contributions and the percentages only count the contributions of the first %d.", maxContributorPages*100, maxContributorPages*100)
+				}
+			}
+			for _, c := range contributors {
+				contributor := MinimalContributor{
+					Login:         c.GetLogin(),
+					Email:         c.GetEmail(),
+					Name:          c.GetName(),
+					Type:          c.GetType(),
+					AvatarURL:     c.GetAvatarURL(),
+					Contributions: c.GetContributions(),
+				}
+				if result.TotalContributions > 0 {
+					contributor.Percentage = math.Round(float64(c.GetContributions())/float64(result.TotalContributions)*10000) / 100
+				}
+				result.Contributors = append(result.Contributors, contributor)
+			}
+
+			// The API already returns contributors sorted by contributions in descending order.
+			sort.SliceStable(result.Contributors, func(i, j int) bool {
+				if direction == "asc" {
+					return result.Contributors[i].Contributions < result.Contributors[j].Contributions
+				}
+				return result.Contributors[i].Contributions > result.Contributors[j].Contributions
+			})
+
+			if resp.StatusCode == http.StatusNoContent {
+				result.Note = "The repository is empty and has no contributors."
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// maxContributorPages bounds how many pages of 100 contributors list_contributors reads to sum the
+// contributions to a repository.
+const maxContributorPages = 10
+
+// sumContributions sums the contributions of the contributors of a repository, reading at most
+// maxContributorPages pages of them. It reports whether all contributors were counted.
+func sumContributions(ctx context.Context, client *github.Client, owner, repo, anon string) (int, bool, *github.Response, error) {
+	total := 0
+	opts := &github.ListContributorsOptions{Anon: anon, ListOptions: github.ListOptions{PerPage: 100}}
+	for page := 0; page < maxContributorPages; page++ {
+		contributors, resp, err := client.Repositories.ListContributors(ctx, owner, repo, opts)
+		if err != nil {
+			return 0, false, resp, err
+		}
+		_ = resp.Body.Close()
+
+		for _, c := range contributors {
+			total += c.GetContributions()
+		}
+		if resp.NextPage == 0 {
+			return total, true, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+	return total, false, nil, nil
+}
+
+// MinimalRepositoryLicense is the output type for the license of a repository.
+type MinimalRepositoryLicense struct {
+	SPDXID  string `json:"spdx_id"`
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	HTMLURL string `json:"html_url"`
+	Content string `json:"content"`
+}
+
+// GetRepositoryLicense creates a tool to get the license of a GitHub repository.
+func GetRepositoryLicense(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_license",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_LICENSE_DESCRIPTION", "Get the license GitHub detected for a repository, including its SPDX ID, name and the full license text")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_LICENSE_USER_TITLE", "Get repository license"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			license, resp, err := client.Repositories.License(ctx, owner, repo)
+			if err != nil {
+				// The license endpoint also returns 404 when no license was detected, so check the repository exists
+				// to tell the two apart.
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					_, repoResp, repoErr := client.Repositories.Get(ctx, owner, repo)
+					if repoErr == nil {
+						_ = repoResp.Body.Close()
+						return mcp.NewToolResultText(fmt.Sprintf("No license was detected for %s/%s.", owner, repo)), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						repoResp,
+						repoErr,
+					), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository license",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			content := license.GetContent()
+			if license.GetEncoding() == "base64" {
+				decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content, "\n", ""))
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode license content: %w", err)
+				}
+				content = string(decoded)
+			}
+
+			return MarshalledTextResult(MinimalRepositoryLicense{
+				SPDXID:  license.GetLicense().GetSPDXID(),
+				Key:     license.GetLicense().GetKey(),
+				Name:    license.GetLicense().GetName(),
+				Path:    license.GetPath(),
+				HTMLURL: license.GetHTMLURL(),
+				Content: content,
+			}), nil
+		}
+}
+
+// MinimalLicense is the output type for a license known to GitHub.
+type MinimalLicense struct {
+	SPDXID string `json:"spdx_id"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+}
+
+// ListKnownLicenses creates a tool to list the licenses GitHub recognizes.
+func ListKnownLicenses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_known_licenses",
+			mcp.WithDescription(t("TOOL_LIST_KNOWN_LICENSES_DESCRIPTION", "List the licenses GitHub recognizes, with their SPDX IDs and names")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_KNOWN_LICENSES_USER_TITLE", "List known licenses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			licenses, resp, err := client.Licenses.List(ctx)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list licenses",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalLicense, 0, len(licenses))
+			for _, license := range licenses {
+				result = append(result, MinimalLicense{
+					SPDXID: license.GetSPDXID(),
+					Key:    license.GetKey(),
+					Name:   license.GetName(),
+				})
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// maxPermissionLogins bounds the number of users get_repository_permissions checks in one call.
+const maxPermissionLogins = 100
+
+// RepositoryPermission is the permission a user has on a repository, or why it could not be checked.
+type RepositoryPermission struct {
+	Login string `json:"login"`
+	// Permission is admin, write, read or none.
+	Permission string `json:"permission,omitempty"`
+	// RoleName is the role of the user, which can be a custom repository role.
+	RoleName string `json:"role_name,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GetRepositoryPermissions creates a tool to check the permissions of several users on a repository.
+func GetRepositoryPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_permissions",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_PERMISSIONS_DESCRIPTION", fmt.Sprintf("Get the permission level (admin, write, read or none) and role of up to %d users on a GitHub repository, such as to audit who has access. Users who are not collaborators, or do not exist, have the permission none.", maxPermissionLogins))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_PERMISSIONS_USER_TITLE", "Get repository permissions of users"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("logins",
+				mcp.Required(),
+				mcp.Description("GitHub usernames to check"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			logins, err := OptionalStringArrayParam(request, "logins")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(logins) == 0 {
+				return mcp.NewToolResultError("missing required parameter: logins"), nil
+			}
+			if len(logins) > maxPermissionLogins {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d logins can be checked at once, got %d", maxPermissionLogins, len(logins))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			permissions := make([]RepositoryPermission, len(logins))
+			tasks := make([]func(context.Context) error, len(logins))
+			for i, login := range logins {
+				permissions[i].Login = login
+				tasks[i] = func(ctx context.Context) error {
+					level, resp, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, login)
+					if err != nil {
+						// GitHub reports users that do not exist as not found
+						if resp != nil && resp.StatusCode == http.StatusNotFound {
+							permissions[i].Permission = "none"
+							return nil
+						}
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get permission level", resp, err)
+						permissions[i].Error = fmt.Sprintf("failed to get permission level: %v", err)
+						return nil
+					}
+					_ = resp.Body.Close()
+					permissions[i].Permission = level.GetPermission()
+					permissions[i].RoleName = level.GetRoleName()
+					return nil
+				}
+			}
+			for i, err := range runBounded(ctx, maxConcurrentRequests, tasks...) {
+				if err != nil {
+					permissions[i].Error = err.Error()
+				}
+			}
+
+			return MarshalledTextResult(permissions), nil
+		}
+}
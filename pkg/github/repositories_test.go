@@ -929,6 +929,15 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		},
 	}
 
+	// The file as the patch modes get it
+	currentFile := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr("docs/example.md"),
+		SHA:      github.Ptr("abc123def456"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("# Example\n\nThis is an example file.\n"))),
+	}
+
 	tests := []struct {
 		name            string
 		mockedClient    *http.Client
@@ -1011,6 +1020,204 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to create/update file",
 		},
+		{
+			name: "append to file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+						mockResponse(t, http.StatusOK, currentFile),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Append to example file",
+						"content": base64.StdEncoding.EncodeToString([]byte("# Example\n\nThis is an example file.\nMore.\n")),
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "More.\n",
+				"message": "Append to example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+				"mode":    "append",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "replace line range",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					currentFile,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": base64.StdEncoding.EncodeToString([]byte("# Example\n\nThis is the example file.\n")),
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "docs/example.md",
+				"content":    "This is the example file.",
+				"message":    "Update example file",
+				"branch":     "main",
+				"sha":        "abc123def456",
+				"mode":       "replace_range",
+				"start_line": float64(3),
+				"end_line":   float64(3),
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "apply unified diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					currentFile,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": base64.StdEncoding.EncodeToString([]byte("# Example\n\nThis is an updated file.\n")),
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "--- a/docs/example.md\n+++ b/docs/example.md\n@@ -2,2 +2,2 @@\n \n-This is an example file.\n+This is an updated file.\n",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+				"mode":    "apply_unified_diff",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "unified diff does not apply",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					currentFile,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "@@ -3 +3 @@\n-This was an example file.\n+This is an updated file.\n",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+				"mode":    "apply_unified_diff",
+			},
+			expectError:    true,
+			expectedErrMsg: "hunk 1 does not apply at line 3, the file has changed or the diff is wrong. The hunk expects:\n  This was an example file.\nbut lines 3 to 3 of the file are:\n  This is an example file.\n",
+		},
+		{
+			name: "file changed since sha",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					currentFile,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "More.\n",
+				"message": "Append to example file",
+				"branch":  "main",
+				"sha":     "stale-sha",
+				"mode":    "append",
+			},
+			expectError:    true,
+			expectedErrMsg: "docs/example.md has changed since blob stale-sha, it is blob abc123def456 on main now. Get its current content and try again",
+		},
+		{
+			name: "file changed before the commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					currentFile,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusConflict, `{"message": "docs/example.md does not match abc123def456"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "More.\n",
+				"message": "Append to example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+				"mode":    "prepend",
+			},
+			expectError:    true,
+			expectedErrMsg: "docs/example.md has changed since blob abc123def456, get its current content and try again",
+		},
+		{
+			name:         "patch mode requires sha",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "More.\n",
+				"message": "Append to example file",
+				"branch":  "main",
+				"mode":    "append",
+			},
+			expectError:    true,
+			expectedErrMsg: "sha is required for the append mode, it is the blob SHA of the file the edit is based on",
+		},
+		{
+			name:         "replace_range requires the range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "More.\n",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+				"mode":    "replace_range",
+			},
+			expectError:    true,
+			expectedErrMsg: "start_line and end_line are required for the replace_range mode",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1560,17 +1767,20 @@ func Test_ListBranches(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "protected")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock branches for success case
 	mockBranches := []*github.Branch{
 		{
-			Name:   github.Ptr("main"),
-			Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+			Name:      github.Ptr("main"),
+			Commit:    &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+			Protected: github.Ptr(true),
 		},
 		{
-			Name:   github.Ptr("develop"),
-			Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
+			Name:      github.Ptr("develop"),
+			Commit:    &github.RepositoryCommit{SHA: github.Ptr("def456")},
+			Protected: github.Ptr(false),
 		},
 	}
 
@@ -1581,6 +1791,7 @@ func Test_ListBranches(t *testing.T) {
 		mockResponses []mock.MockBackendOption
 		wantErr       bool
 		errContains   string
+		expected      []MinimalBranch
 	}{
 		{
 			name: "success",
@@ -1596,6 +1807,34 @@ func Test_ListBranches(t *testing.T) {
 				),
 			},
 			wantErr: false,
+			expected: []MinimalBranch{
+				{Name: "main", SHA: "abc123", Protected: true},
+				{Name: "develop", SHA: "def456", Protected: false},
+			},
+		},
+		{
+			name: "only protected branches",
+			args: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"protected": true,
+			},
+			mockResponses: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"protected": "true",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockBranches[:1]),
+					),
+				),
+			},
+			wantErr: false,
+			expected: []MinimalBranch{
+				{Name: "main", SHA: "abc123", Protected: true},
+			},
 		},
 		{
 			name: "missing owner",
@@ -1649,12 +1888,10 @@ func Test_ListBranches(t *testing.T) {
 			require.NotEmpty(t, textContent.Text)
 
 			// Verify response
-			var branches []*github.Branch
+			var branches []MinimalBranch
 			err = json.Unmarshal([]byte(textContent.Text), &branches)
 			require.NoError(t, err)
-			assert.Len(t, branches, 2)
-			assert.Equal(t, "main", *branches[0].Name)
-			assert.Equal(t, "develop", *branches[1].Name)
+			assert.Equal(t, tt.expected, branches)
 		})
 	}
 }
@@ -1672,34 +1909,9 @@ func Test_DeleteFile(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "message")
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	// SHA is no longer required since we're using Git Data API
+	// The SHA of the file is looked up
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "message", "branch"})
 
-	// Setup mock objects for Git Data API
-	mockRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123"),
-		},
-	}
-
-	mockCommit := &github.Commit{
-		SHA: github.Ptr("abc123"),
-		Tree: &github.Tree{
-			SHA: github.Ptr("def456"),
-		},
-	}
-
-	mockTree := &github.Tree{
-		SHA: github.Ptr("ghi789"),
-	}
-
-	mockNewCommit := &github.Commit{
-		SHA:     github.Ptr("jkl012"),
-		Message: github.Ptr("Delete example file"),
-		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
-	}
-
 	tests := []struct {
 		name              string
 		mockedClient      *http.Client
@@ -1709,57 +1921,34 @@ func Test_DeleteFile(t *testing.T) {
 		expectedErrMsg    string
 	}{
 		{
-			name: "successful file deletion using Git Data API",
+			name: "successful file deletion",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
-				),
-				// Create tree
 				mock.WithRequestMatchHandler(
-					mock.PostReposGitTreesByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"base_tree": "def456",
-						"tree": []interface{}{
-							map[string]interface{}{
-								"path": "docs/example.md",
-								"mode": "100644",
-								"type": "blob",
-								"sha":  nil,
-							},
-						},
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/contents/docs/example.md",
+						queryParams: map[string]string{"ref": "main"},
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockTree),
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{
+							Type: github.Ptr("file"),
+							Path: github.Ptr("docs/example.md"),
+							SHA:  github.Ptr("abc123"),
+						}),
 					),
 				),
-				// Create commit
 				mock.WithRequestMatchHandler(
-					mock.PostReposGitCommitsByOwnerByRepo,
+					mock.DeleteReposContentsByOwnerByRepoByPath,
 					expectRequestBody(t, map[string]interface{}{
 						"message": "Delete example file",
-						"tree":    "ghi789",
-						"parents": []interface{}{"abc123"},
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockNewCommit),
-					),
-				),
-				// Update reference
-				mock.WithRequestMatchHandler(
-					mock.PatchReposGitRefsByOwnerByRepoByRef,
-					expectRequestBody(t, map[string]interface{}{
-						"sha":   "jkl012",
-						"force": false,
+						"content": nil,
+						"sha":     "abc123",
+						"branch":  "main",
 					}).andThen(
-						mockResponse(t, http.StatusOK, &github.Reference{
-							Ref: github.Ptr("refs/heads/main"),
-							Object: &github.GitObject{
-								SHA: github.Ptr("jkl012"),
+						mockResponse(t, http.StatusOK, &github.RepositoryContentResponse{
+							Commit: github.Commit{
+								SHA:     github.Ptr("jkl012"),
+								Message: github.Ptr("Delete example file"),
+								HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
 							},
 						}),
 					),
@@ -1776,14 +1965,11 @@ func Test_DeleteFile(t *testing.T) {
 			expectedCommitSHA: "jkl012",
 		},
 		{
-			name: "file deletion fails - branch not found",
+			name: "file deletion fails - file not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
-					}),
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -1791,10 +1977,28 @@ func Test_DeleteFile(t *testing.T) {
 				"repo":    "repo",
 				"path":    "docs/nonexistent.md",
 				"message": "Delete nonexistent file",
-				"branch":  "nonexistent-branch",
+				"branch":  "main",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get branch reference",
+			expectedErrMsg: "docs/nonexistent.md does not exist on branch main",
+		},
+		{
+			name: "file deletion fails - path is a directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					[]*github.RepositoryContent{{Type: github.Ptr("file"), Path: github.Ptr("docs/example.md")}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs",
+				"message": "Delete docs",
+				"branch":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "docs is not a file",
 		},
 	}
 
@@ -1809,16 +2013,15 @@ func Test_DeleteFile(t *testing.T) {
 
 			// Call handler
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
 			// Verify results
 			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
-
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
@@ -1837,16 +2040,275 @@ func Test_DeleteFile(t *testing.T) {
 	}
 }
 
-func Test_ListTags(t *testing.T) {
+func Test_RenameFile(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := RenameFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "list_tags", tool.Name)
+	assert.Equal(t, "rename_file", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "old_path")
+	assert.Contains(t, tool.InputSchema.Properties, "new_path")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "old_path", "new_path", "commit_message"})
+
+	mockRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123"),
+		},
+	}
+
+	mockCommit := &github.Commit{
+		SHA: github.Ptr("abc123"),
+		Tree: &github.Tree{
+			SHA: github.Ptr("def456"),
+		},
+	}
+
+	mockBaseTree := &github.Tree{
+		SHA: github.Ptr("def456"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("readme1")},
+			{Path: github.Ptr("docs"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("docs1")},
+			{Path: github.Ptr("docs/guide.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("guide1")},
+			{Path: github.Ptr("scripts"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("scripts1")},
+			{Path: github.Ptr("scripts/build.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("build1")},
+		},
+	}
+
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		Message: github.Ptr("Move build script"),
+	}
+
+	// Mocked responses are consumed, so every test case needs its own set
+	baseMocks := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				mockRef,
+			),
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				mockCommit,
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expect(t, expectations{
+					path:        "/repos/owner/repo/git/trees/def456",
+					queryParams: map[string]string{"recursive": "1"},
+				}).andThen(
+					mockResponse(t, http.StatusOK, mockBaseTree),
+				),
+			),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedCommitSHA string
+		expectedErrMsg    string
+	}{
+		{
+			name: "successful rename preserves blob and mode",
+			mockedClient: mock.NewMockedHTTPClient(append(baseMocks(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path": "scripts/build.sh",
+								"mode": "100755",
+								"type": "blob",
+								"sha":  nil,
+							},
+							map[string]interface{}{
+								"path": "tools/build.sh",
+								"mode": "100755",
+								"type": "blob",
+								"sha":  "build1",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Move build script",
+						"tree":    "ghi789",
+						"parents": []interface{}{"abc123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "jkl012",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/main"),
+							Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+						}),
+					),
+				),
+			)...),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"old_path":       "scripts/build.sh",
+				"new_path":       "tools/build.sh",
+				"commit_message": "Move build script",
+			},
+			expectedCommitSHA: "jkl012",
+		},
+		{
+			name:         "new path already exists",
+			mockedClient: mock.NewMockedHTTPClient(baseMocks()...),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"old_path":       "README.md",
+				"new_path":       "docs/guide.md",
+				"commit_message": "Overwrite guide",
+			},
+			expectError:    true,
+			expectedErrMsg: "new_path docs/guide.md already exists on branch main",
+		},
+		{
+			name:         "new path is an existing directory",
+			mockedClient: mock.NewMockedHTTPClient(baseMocks()...),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"old_path":       "README.md",
+				"new_path":       "docs",
+				"commit_message": "Replace docs",
+			},
+			expectError:    true,
+			expectedErrMsg: "new_path docs already exists on branch main",
+		},
+		{
+			name:         "old path not found",
+			mockedClient: mock.NewMockedHTTPClient(baseMocks()...),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"old_path":       "CHANGELOG.md",
+				"new_path":       "docs/CHANGELOG.md",
+				"commit_message": "Move changelog",
+			},
+			expectError:    true,
+			expectedErrMsg: "file CHANGELOG.md not found on branch main",
+		},
+		{
+			name:         "old path is a directory",
+			mockedClient: mock.NewMockedHTTPClient(baseMocks()...),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"old_path":       "scripts",
+				"new_path":       "tools",
+				"commit_message": "Rename scripts",
+			},
+			expectError:    true,
+			expectedErrMsg: "scripts is not a file",
+		},
+		{
+			name:         "same old and new path",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"old_path":       "README.md",
+				"new_path":       "/README.md",
+				"commit_message": "No-op",
+			},
+			expectError:    true,
+			expectedErrMsg: "old_path and new_path must be different",
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Reference not found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "missing",
+				"old_path":       "README.md",
+				"new_path":       "README.rst",
+				"commit_message": "Rename readme",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RenameFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+
+			commit, ok := response["commit"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedCommitSHA, commit["sha"])
+			assert.Equal(t, tc.requestArgs["old_path"], response["old_path"])
+			assert.Equal(t, tc.requestArgs["new_path"], response["new_path"])
+		})
+	}
+}
+
+func Test_ListTags(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_tags", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock tags for success case
@@ -2629,3 +3091,668 @@ func Test_resolveGitReference(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCommunityProfile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommunityProfile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_community_profile", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockMetrics := &github.CommunityHealthMetrics{
+		HealthPercentage: github.Ptr(71),
+		Description:      github.Ptr("A test repository"),
+		Files: &github.CommunityHealthFiles{
+			Readme: &github.Metric{
+				HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/README.md"),
+			},
+			License: &github.Metric{
+				Name:    github.Ptr("MIT License"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/LICENSE"),
+			},
+			Contributing: &github.Metric{
+				HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/CONTRIBUTING.md"),
+			},
+			IssueTemplate: &github.Metric{
+				HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/.github/ISSUE_TEMPLATE"),
+			},
+		},
+		UpdatedAt: &github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedProfile CommunityProfile
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful community profile fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommunityProfileByOwnerByRepo,
+					expectPath(
+						t,
+						"/repos/owner/repo/community/profile",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockMetrics),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedProfile: CommunityProfile{
+				HealthPercentage: 71,
+				Description:      "A test repository",
+				Readme:           CommunityProfileFile{Present: true, URL: "https://github.com/owner/repo/blob/main/README.md"},
+				Contributing:     CommunityProfileFile{Present: true, URL: "https://github.com/owner/repo/blob/main/CONTRIBUTING.md"},
+				License:          CommunityProfileFile{Present: true, URL: "https://github.com/owner/repo/blob/main/LICENSE"},
+				IssueTemplate:    CommunityProfileFile{Present: true, URL: "https://github.com/owner/repo/blob/main/.github/ISSUE_TEMPLATE"},
+			},
+		},
+		{
+			name: "community profile fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommunityProfileByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get community profile",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommunityProfile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returnedProfile CommunityProfile
+			err = json.Unmarshal([]byte(textContent.Text), &returnedProfile)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedProfile.HealthPercentage, returnedProfile.HealthPercentage)
+			assert.Equal(t, tc.expectedProfile.Description, returnedProfile.Description)
+			assert.Equal(t, tc.expectedProfile.Readme, returnedProfile.Readme)
+			assert.Equal(t, tc.expectedProfile.CodeOfConduct, returnedProfile.CodeOfConduct)
+			assert.Equal(t, tc.expectedProfile.Contributing, returnedProfile.Contributing)
+			assert.Equal(t, tc.expectedProfile.License, returnedProfile.License)
+			assert.Equal(t, tc.expectedProfile.IssueTemplate, returnedProfile.IssueTemplate)
+			assert.Equal(t, tc.expectedProfile.PullRequestTemplate, returnedProfile.PullRequestTemplate)
+			require.NotNil(t, returnedProfile.UpdatedAt)
+		})
+	}
+}
+
+func Test_ListContributors(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListContributors(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_contributors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "anon")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockContributors := []*github.Contributor{
+		{
+			Login:         github.Ptr("octocat"),
+			AvatarURL:     github.Ptr("https://avatars.githubusercontent.com/u/1"),
+			Type:          github.Ptr("User"),
+			Contributions: github.Ptr(75),
+		},
+		{
+			Email:         github.Ptr("anon@example.com"),
+			Name:          github.Ptr("Anonymous"),
+			Type:          github.Ptr("Anonymous"),
+			Contributions: github.Ptr(25),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult ListContributorsResult
+		expectedErrMsg string
+	}{
+		{
+			name: "successful contributors list with anonymous contributors",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"anon":     "1",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockContributors),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"anon":  true,
+			},
+			expectError: false,
+			expectedResult: ListContributorsResult{
+				TotalContributions: 100,
+				Contributors: []MinimalContributor{
+					{Login: "octocat", Type: "User", AvatarURL: "https://avatars.githubusercontent.com/u/1", Contributions: 75, Percentage: 75},
+					{Email: "anon@example.com", Name: "Anonymous", Type: "Anonymous", Contributions: 25, Percentage: 25},
+				},
+			},
+		},
+		{
+			name: "contributors sorted ascending",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContributorsByOwnerByRepo,
+					mockContributors,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"direction": "asc",
+			},
+			expectError: false,
+			expectedResult: ListContributorsResult{
+				TotalContributions: 100,
+				Contributors: []MinimalContributor{
+					{Email: "anon@example.com", Name: "Anonymous", Type: "Anonymous", Contributions: 25, Percentage: 25},
+					{Login: "octocat", Type: "User", AvatarURL: "https://avatars.githubusercontent.com/u/1", Contributions: 75, Percentage: 75},
+				},
+			},
+		},
+		{
+			name: "percentages are of the contributions of all pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("per_page") == "100" {
+							// The contributions of all contributors are summed
+							mockResponse(t, http.StatusOK, append(mockContributors, &github.Contributor{
+								Login:         github.Ptr("hubot"),
+								Type:          github.Ptr("User"),
+								Contributions: github.Ptr(100),
+							}))(w, r)
+							return
+						}
+						w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/contributors?page=1&per_page=2>; rel="prev"`)
+						mockResponse(t, http.StatusOK, mockContributors)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(2),
+			},
+			expectError: false,
+			expectedResult: ListContributorsResult{
+				TotalContributions: 200,
+				Contributors: []MinimalContributor{
+					{Login: "octocat", Type: "User", AvatarURL: "https://avatars.githubusercontent.com/u/1", Contributions: 75, Percentage: 37.5},
+					{Email: "anon@example.com", Name: "Anonymous", Type: "Anonymous", Contributions: 25, Percentage: 12.5},
+				},
+			},
+		},
+		{
+			name: "repository too large for contributor statistics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "The history or contributor list is too large to list contributors for this repository via the API."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: ListContributorsResult{
+				Contributors: []MinimalContributor{},
+				Note:         "Contributor statistics are unavailable because the repository history is too large for the GitHub API to list contributors.",
+			},
+		},
+		{
+			name: "list contributors fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list contributors",
+		},
+		{
+			name:         "invalid direction",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"direction": "sideways",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid direction: sideways",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListContributors(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returnedResult ListContributorsResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
+
+func Test_GetRepositoryLicense(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryLicense(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_license", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	licenseText := "MIT License\n\nCopyright (c) 2024 Owner\n"
+	mockLicense := &github.RepositoryLicense{
+		Name:     github.Ptr("LICENSE"),
+		Path:     github.Ptr("LICENSE"),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/blob/main/LICENSE"),
+		Encoding: github.Ptr("base64"),
+		// The API wraps the encoded content across several lines.
+		Content: github.Ptr(base64.StdEncoding.EncodeToString([]byte(licenseText))[:40] + "\n" + base64.StdEncoding.EncodeToString([]byte(licenseText))[40:] + "\n"),
+		License: &github.License{
+			Key:    github.Ptr("mit"),
+			Name:   github.Ptr("MIT License"),
+			SPDXID: github.Ptr("MIT"),
+		},
+	}
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedText    string
+		expectedLicense MinimalRepositoryLicense
+	}{
+		{
+			name: "successful license fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/license").andThen(
+						mockResponse(t, http.StatusOK, mockLicense),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedLicense: MinimalRepositoryLicense{
+				SPDXID:  "MIT",
+				Key:     "mit",
+				Name:    "MIT License",
+				Path:    "LICENSE",
+				HTMLURL: "https://github.com/owner/repo/blob/main/LICENSE",
+				Content: licenseText,
+			},
+		},
+		{
+			name: "repository without a license",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					notFound,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{Name: github.Ptr("repo")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedText: "No license was detected for owner/repo.",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					notFound,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryLicense(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var returnedLicense MinimalRepositoryLicense
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedLicense))
+			assert.Equal(t, tc.expectedLicense, returnedLicense)
+		})
+	}
+}
+
+func Test_ListKnownLicenses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListKnownLicenses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_known_licenses", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedLicenses []MinimalLicense
+	}{
+		{
+			name: "successful licenses list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetLicenses,
+					expectPath(t, "/licenses").andThen(
+						mockResponse(t, http.StatusOK, []*github.License{
+							{Key: github.Ptr("apache-2.0"), Name: github.Ptr("Apache License 2.0"), SPDXID: github.Ptr("Apache-2.0")},
+							{Key: github.Ptr("mit"), Name: github.Ptr("MIT License"), SPDXID: github.Ptr("MIT")},
+						}),
+					),
+				),
+			),
+			expectedLicenses: []MinimalLicense{
+				{SPDXID: "Apache-2.0", Key: "apache-2.0", Name: "Apache License 2.0"},
+				{SPDXID: "MIT", Key: "mit", Name: "MIT License"},
+			},
+		},
+		{
+			name: "licenses list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetLicenses,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list licenses",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListKnownLicenses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedLicenses []MinimalLicense
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedLicenses))
+			assert.Equal(t, tc.expectedLicenses, returnedLicenses)
+		})
+	}
+}
+
+func Test_GetRepositoryPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "logins")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "logins"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				login := strings.Split(r.URL.Path, "/")[5]
+				switch login {
+				case "admin-user":
+					mockResponse(t, http.StatusOK, &github.RepositoryPermissionLevel{
+						Permission: github.Ptr("admin"),
+						RoleName:   github.Ptr("admin"),
+					})(w, r)
+				case "triager":
+					mockResponse(t, http.StatusOK, &github.RepositoryPermissionLevel{
+						Permission: github.Ptr("read"),
+						RoleName:   github.Ptr("triage"),
+					})(w, r)
+				case "outsider":
+					mockResponse(t, http.StatusOK, &github.RepositoryPermissionLevel{
+						Permission: github.Ptr("none"),
+						RoleName:   github.Ptr("none"),
+					})(w, r)
+				case "ghost-user":
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				default:
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to view collaborator permission."}`)(w, r)
+				}
+			}),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       []RepositoryPermission
+	}{
+		{
+			name: "permissions in the order of the logins",
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"logins": []any{"admin-user", "ghost-user", "triager", "outsider"},
+			},
+			expected: []RepositoryPermission{
+				{Login: "admin-user", Permission: "admin", RoleName: "admin"},
+				{Login: "ghost-user", Permission: "none"},
+				{Login: "triager", Permission: "read", RoleName: "triage"},
+				{Login: "outsider", Permission: "none", RoleName: "none"},
+			},
+		},
+		{
+			name: "no logins",
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"logins": []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: logins",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := GetRepositoryPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []RepositoryPermission
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+
+	t.Run("failures are reported per login", func(t *testing.T) {
+		client := github.NewClient(mockedClient)
+		_, handler := GetRepositoryPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":  "owner",
+			"repo":   "repo",
+			"logins": []any{"admin-user", "forbidden-user"},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned []RepositoryPermission
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		require.Len(t, returned, 2)
+		assert.Equal(t, "admin", returned[0].Permission)
+		assert.Empty(t, returned[1].Permission)
+		assert.Contains(t, returned[1].Error, "Must have push access")
+	})
+}
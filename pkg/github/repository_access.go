@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repositoryAccessMaxUsers bounds the number of users collected from each listing of the access report.
+const repositoryAccessMaxUsers = 1000
+
+// repositoryPermissions are the base permissions on a repository, from lowest to highest.
+var repositoryPermissions = []string{"read", "triage", "write", "maintain", "admin"}
+
+// repositoryPermissionRank orders permissions from lowest to highest. Custom roles rank below read,
+// as what they grant is not known.
+func repositoryPermissionRank(permission string) int {
+	return slices.Index(repositoryPermissions, permission) + 1
+}
+
+// normalizeRepositoryPermission maps the legacy permission names that teams and organizations
+// report to the names of repository roles.
+func normalizeRepositoryPermission(permission string) string {
+	switch permission {
+	case "pull":
+		return "read"
+	case "push":
+		return "write"
+	}
+	return permission
+}
+
+// RepositoryAccess is the effective permission of a user on a repository.
+type RepositoryAccess struct {
+	Login      string `json:"login"`
+	Permission string `json:"permission"`
+	// Source is what grants the permission: direct, team:{slug} or org.
+	Source              string `json:"source"`
+	OutsideCollaborator bool   `json:"outside_collaborator,omitempty"`
+}
+
+// RepositoryAccessReport is the output type of the get_repository_access_report tool.
+type RepositoryAccessReport struct {
+	Repository string             `json:"repository"`
+	Users      []RepositoryAccess `json:"users"`
+	// Truncated is set if a listing had more users than were collected.
+	Truncated bool     `json:"truncated,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// collaboratorPermission returns the role of a collaborator, or the highest of their permissions if
+// the role is not reported.
+func collaboratorPermission(user *github.User) string {
+	if role := user.GetRoleName(); role != "" {
+		return role
+	}
+	return highestRepositoryPermission(user.GetPermissions())
+}
+
+// highestRepositoryPermission returns the highest of the permissions set in a permissions map of
+// the API, or "" if none is.
+func highestRepositoryPermission(permissions map[string]bool) string {
+	for _, permission := range []string{"admin", "maintain", "push", "triage", "pull"} {
+		if permissions[permission] {
+			return normalizeRepositoryPermission(permission)
+		}
+	}
+	return ""
+}
+
+// listCollaborators lists the collaborators of a repository of the given affiliation.
+func listCollaborators(ctx context.Context, client *github.Client, owner, repo, affiliation string) ([]*github.User, bool, *github.Response, error) {
+	return collectPages(repositoryAccessMaxUsers, func(opts github.ListOptions) ([]*github.User, *github.Response, error) {
+		return client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{Affiliation: affiliation, ListOptions: opts})
+	}, nil)
+}
+
+// listOrgMembers lists the members of an organization of the given role.
+func listOrgMembers(ctx context.Context, client *github.Client, org, role string) ([]*github.User, bool, *github.Response, error) {
+	return collectPages(repositoryAccessMaxUsers, func(opts github.ListOptions) ([]*github.User, *github.Response, error) {
+		return client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{Role: role, ListOptions: opts})
+	}, nil)
+}
+
+// GetRepositoryAccessReport creates a tool to report who has access to a repository, and through what.
+func GetRepositoryAccessReport(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_access_report",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_ACCESS_REPORT_DESCRIPTION", "Report who has access to a repository: direct and outside collaborators, members of teams with access, and members of the owning organization through its base permission. Users are listed once, with their highest permission and what grants it (direct, team:{slug} or org), highest permissions first. Requires push access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_ACCESS_REPORT_USER_TITLE", "Report repository access"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			report := RepositoryAccessReport{Repository: owner + "/" + repo, Users: []RepositoryAccess{}}
+			var (
+				direct, outside, orgAdmins, orgMembers []*github.User
+				teams                                  []*github.Team
+				defaultPermission                      string
+				truncated                              [5]bool
+				directResp                             *github.Response
+			)
+			errs := runBounded(ctx, maxConcurrentRequests,
+				func(ctx context.Context) (err error) {
+					direct, truncated[0], directResp, err = listCollaborators(ctx, client, owner, repo, "direct")
+					return err
+				},
+				func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					outside, truncated[1], resp, err = listCollaborators(ctx, client, owner, repo, "outside")
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list outside collaborators", resp, err)
+					}
+					return err
+				},
+				func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					teams, truncated[2], resp, err = collectPages(repositoryAccessMaxUsers, func(opts github.ListOptions) ([]*github.Team, *github.Response, error) {
+						return client.Repositories.ListTeams(ctx, owner, repo, &opts)
+					}, nil)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list teams", resp, err)
+					}
+					return err
+				},
+				func(ctx context.Context) error {
+					org, resp, err := client.Organizations.Get(ctx, owner)
+					if err != nil {
+						// Repositories of users have no organization to inherit permissions from
+						if resp != nil && resp.StatusCode == http.StatusNotFound {
+							return nil
+						}
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get organization", resp, err)
+						return err
+					}
+					_ = resp.Body.Close()
+
+					// Owners of the organization are admins of all its repositories
+					orgAdmins, truncated[3], resp, err = listOrgMembers(ctx, client, owner, "admin")
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list organization owners", resp, err)
+						return err
+					}
+					defaultPermission = normalizeRepositoryPermission(org.GetDefaultRepoPermission())
+					if defaultPermission == "" || defaultPermission == "none" {
+						return nil
+					}
+					orgMembers, truncated[4], resp, err = listOrgMembers(ctx, client, owner, "all")
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list organization members", resp, err)
+					}
+					return err
+				},
+			)
+			if errs[0] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list collaborators", directResp, errs[0]), nil
+			}
+			for i, category := range []string{"outside collaborators", "teams", "organization members"} {
+				if err := errs[i+1]; err != nil {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("%s unavailable: %s", category, err))
+				}
+			}
+
+			teamMembers := make([][]*github.User, len(teams))
+			teamTruncated := make([]bool, len(teams))
+			tasks := make([]func(context.Context) error, len(teams))
+			for i, team := range teams {
+				tasks[i] = func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					teamMembers[i], teamTruncated[i], resp, err = collectPages(repositoryAccessMaxUsers, func(opts github.ListOptions) ([]*github.User, *github.Response, error) {
+						return client.Teams.ListTeamMembersBySlug(ctx, owner, team.GetSlug(), &github.TeamListTeamMembersOptions{ListOptions: opts})
+					}, nil)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list team members", resp, err)
+					}
+					return err
+				}
+			}
+			for i, err := range runBounded(ctx, maxConcurrentRequests, tasks...) {
+				if err != nil {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("members of team %s unavailable: %s", teams[i].GetSlug(), err))
+				}
+			}
+
+			// Grants are merged in this order, so that of equal permissions the most specific source is kept
+			access := map[string]*RepositoryAccess{}
+			grant := func(user *github.User, permission, source string) {
+				login := user.GetLogin()
+				current, ok := access[login]
+				if !ok {
+					access[login] = &RepositoryAccess{Login: login, Permission: permission, Source: source}
+					return
+				}
+				if repositoryPermissionRank(permission) > repositoryPermissionRank(current.Permission) {
+					current.Permission, current.Source = permission, source
+				}
+			}
+			for _, user := range direct {
+				grant(user, collaboratorPermission(user), "direct")
+			}
+			for _, user := range outside {
+				grant(user, collaboratorPermission(user), "direct")
+			}
+			for i, team := range teams {
+				for _, user := range teamMembers[i] {
+					grant(user, normalizeRepositoryPermission(team.GetPermission()), "team:"+team.GetSlug())
+				}
+			}
+			for _, user := range orgAdmins {
+				grant(user, "admin", "org")
+			}
+			for _, user := range orgMembers {
+				grant(user, defaultPermission, "org")
+			}
+			for _, user := range outside {
+				access[user.GetLogin()].OutsideCollaborator = true
+			}
+
+			for _, user := range access {
+				report.Users = append(report.Users, *user)
+			}
+			sort.Slice(report.Users, func(i, j int) bool {
+				ri, rj := repositoryPermissionRank(report.Users[i].Permission), repositoryPermissionRank(report.Users[j].Permission)
+				if ri != rj {
+					return ri > rj
+				}
+				return report.Users[i].Login < report.Users[j].Login
+			})
+			report.Truncated = slices.Contains(truncated[:], true) || slices.Contains(teamTruncated, true)
+
+			return MarshalledTextResult(report), nil
+		}
+}
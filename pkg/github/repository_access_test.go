@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryAccessReport(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryAccessReport(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_access_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	user := func(login, role string) *github.User {
+		return &github.User{Login: github.Ptr(login), RoleName: github.Ptr(role)}
+	}
+	// collaborators answers the listings of direct and outside collaborators
+	collaborators := func(direct, outside []*github.User) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposCollaboratorsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("affiliation") == "outside" {
+					mockResponse(t, http.StatusOK, outside)(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, direct)(w, r)
+			}),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       RepositoryAccessReport
+		// expectedWarnings are contained in the warnings of the report, in order
+		expectedWarnings []string
+	}{
+		{
+			name: "merges collaborators, teams and organization members",
+			mockedClient: mock.NewMockedHTTPClient(
+				collaborators(
+					[]*github.User{
+						user("alice", "write"),
+						user("bob", "read"),
+						// Without a role, the highest permission is used
+						{Login: github.Ptr("carol"), Permissions: map[string]bool{"admin": true, "push": true, "pull": true}},
+					},
+					[]*github.User{user("bob", "read")},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTeamsByOwnerByRepo,
+					[]*github.Team{
+						{Slug: github.Ptr("core"), Permission: github.Ptr("push")},
+						{Slug: github.Ptr("leads"), Permission: github.Ptr("maintain")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/orgs/octo-org/teams/leads/members" {
+							mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("dave")}})(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("alice")}, {Login: github.Ptr("dave")}})(w, r)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsByOrg,
+					&github.Organization{Login: github.Ptr("octo-org"), DefaultRepoPermission: github.Ptr("read")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("role") == "admin" {
+							mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("erin")}})(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, []*github.User{
+							{Login: github.Ptr("alice")},
+							{Login: github.Ptr("dave")},
+							{Login: github.Ptr("erin")},
+							{Login: github.Ptr("frank")},
+						})(w, r)
+					}),
+				),
+			),
+			expected: RepositoryAccessReport{
+				Repository: "octo-org/octo-repo",
+				Users: []RepositoryAccess{
+					{Login: "carol", Permission: "admin", Source: "direct"},
+					{Login: "erin", Permission: "admin", Source: "org"},
+					{Login: "dave", Permission: "maintain", Source: "team:leads"},
+					{Login: "alice", Permission: "write", Source: "direct"},
+					{Login: "bob", Permission: "read", Source: "direct", OutsideCollaborator: true},
+					{Login: "frank", Permission: "read", Source: "org"},
+				},
+			},
+		},
+		{
+			name: "repository of a user",
+			mockedClient: mock.NewMockedHTTPClient(
+				collaborators([]*github.User{user("alice", "admin")}, []*github.User{}),
+				mock.WithRequestMatch(mock.GetReposTeamsByOwnerByRepo, []*github.Team{}),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expected: RepositoryAccessReport{
+				Repository: "octo-org/octo-repo",
+				Users:      []RepositoryAccess{{Login: "alice", Permission: "admin", Source: "direct"}},
+			},
+		},
+		{
+			name: "reports the categories that could not be listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				collaborators([]*github.User{user("alice", "write")}, []*github.User{}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTeamsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsByOrg,
+					&github.Organization{Login: github.Ptr("octo-org"), DefaultRepoPermission: github.Ptr("none")},
+				),
+				mock.WithRequestMatch(mock.GetOrgsMembersByOrg, []*github.User{}),
+			),
+			expected: RepositoryAccessReport{
+				Repository: "octo-org/octo-repo",
+				Users:      []RepositoryAccess{{Login: "alice", Permission: "write", Source: "direct"}},
+			},
+			expectedWarnings: []string{"teams unavailable: GET"},
+		},
+		{
+			name: "collaborators cannot be listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to view repository collaborators."}`),
+				),
+				mock.WithRequestMatch(mock.GetReposTeamsByOwnerByRepo, []*github.Team{}),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list collaborators",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryAccessReport(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "octo-org",
+				"repo":  "octo-repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var report RepositoryAccessReport
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+			require.Len(t, report.Warnings, len(tc.expectedWarnings))
+			for i, warning := range tc.expectedWarnings {
+				assert.Contains(t, report.Warnings[i], warning)
+			}
+			report.Warnings = nil
+			assert.Equal(t, tc.expected, report)
+		})
+	}
+}
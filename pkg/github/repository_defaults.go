@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryDefaults are the owner and repository tools operate on when they are called without
+// them, for users who always work on the same repository.
+type RepositoryDefaults struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo,omitempty"`
+
+	// defaulted are the parameters given a default, by the name of their tool.
+	defaulted map[string][]string
+}
+
+// NewRepositoryDefaults returns the defaults for owner and repo, or nil if neither is set. A default
+// repository needs a default owner, as repository names are only unique for an owner.
+func NewRepositoryDefaults(owner, repo string) (*RepositoryDefaults, error) {
+	if owner == "" && repo == "" {
+		return nil, nil
+	}
+	if owner == "" {
+		return nil, fmt.Errorf("a default repository requires a default owner")
+	}
+	return &RepositoryDefaults{Owner: owner, Repo: repo, defaulted: map[string][]string{}}, nil
+}
+
+// Apply makes the required owner and repo parameters of the tools of toolset optional, with the
+// defaults described in their schema. Tools without those parameters are left as they are. It must
+// be called before the toolset is registered, and does nothing if d is nil.
+func (d *RepositoryDefaults) Apply(toolset *toolsets.Toolset) {
+	if d == nil {
+		return
+	}
+	toolset.UpdateTools(func(tool server.ServerTool) server.ServerTool {
+		schema := tool.Tool.InputSchema
+		var defaulted []string
+		if slices.Contains(schema.Required, "owner") {
+			defaulted = append(defaulted, "owner")
+		}
+		// The repository is only defaulted along with its owner
+		if d.Repo != "" && defaulted != nil && slices.Contains(schema.Required, "repo") {
+			defaulted = append(defaulted, "repo")
+		}
+		if defaulted == nil {
+			return tool
+		}
+
+		// The schema is copied so that tools sharing property maps are not changed
+		schema.Properties = maps.Clone(schema.Properties)
+		schema.Required = slices.DeleteFunc(slices.Clone(schema.Required), func(name string) bool {
+			return slices.Contains(defaulted, name)
+		})
+		for _, name := range defaulted {
+			property, ok := schema.Properties[name].(map[string]any)
+			if !ok {
+				continue
+			}
+			property = maps.Clone(property)
+			description, _ := property["description"].(string)
+			if description = strings.TrimSuffix(description, "."); description != "" {
+				description += ". "
+			}
+			property["description"] = fmt.Sprintf("%sDefaults to %s", description, d.value(name))
+			schema.Properties[name] = property
+		}
+		tool.Tool.InputSchema = schema
+		d.defaulted[tool.Tool.Name] = defaulted
+		return tool
+	})
+}
+
+func (d *RepositoryDefaults) value(name string) string {
+	if name == "repo" {
+		return d.Repo
+	}
+	return d.Owner
+}
+
+// AddHooks adds the hook that sets the defaulted parameters of tool calls that omit them. Values
+// passed explicitly are kept, and the repository is not defaulted for another owner. It does nothing
+// if d is nil.
+func (d *RepositoryDefaults) AddHooks(hooks *server.Hooks) {
+	if d == nil {
+		return
+	}
+	hooks.AddBeforeCallTool(func(_ context.Context, _ any, request *mcp.CallToolRequest) {
+		defaulted, ok := d.defaulted[request.Params.Name]
+		if !ok {
+			return
+		}
+		args := request.GetArguments()
+		if args == nil {
+			args = map[string]any{}
+		}
+		for _, name := range defaulted {
+			if value, ok := args[name]; ok && value != nil && value != "" {
+				continue
+			}
+			if name == "repo" && args["owner"] != d.Owner {
+				continue
+			}
+			args[name] = d.value(name)
+		}
+		request.Params.Arguments = args
+	})
+}
@@ -0,0 +1,196 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoArgumentsTool creates a read-only tool that returns the arguments it was called with.
+func echoArgumentsTool(name string, opts ...mcp.ToolOption) server.ServerTool {
+	opts = append(opts, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}))
+	return toolsets.NewServerTool(mcp.NewTool(name, opts...), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return MarshalledTextResult(request.GetArguments()), nil
+	})
+}
+
+func Test_NewRepositoryDefaults(t *testing.T) {
+	defaults, err := NewRepositoryDefaults("", "")
+	require.NoError(t, err)
+	assert.Nil(t, defaults)
+
+	_, err = NewRepositoryDefaults("", "repo")
+	assert.EqualError(t, err, "a default repository requires a default owner")
+
+	defaults, err = NewRepositoryDefaults("octo-org", "")
+	require.NoError(t, err)
+	assert.Equal(t, "octo-org", defaults.Owner)
+}
+
+func Test_RepositoryDefaults(t *testing.T) {
+	defaults, err := NewRepositoryDefaults("octo-org", "octo-repo")
+	require.NoError(t, err)
+
+	noParams := echoArgumentsTool("no_params")
+	otherParams := echoArgumentsTool("other_params", mcp.WithString("query", mcp.Required(), mcp.Description("Search query")))
+	toolset := toolsets.NewToolset("test", "Test tools").AddReadTools(
+		echoArgumentsTool("repo_tool",
+			mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner")),
+			mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name")),
+			mcp.WithNumber("number", mcp.Required(), mcp.Description("Issue number")),
+		),
+		echoArgumentsTool("owner_tool", mcp.WithString("owner", mcp.Required(), mcp.Description("Organization or user"))),
+		echoArgumentsTool("optional_owner_tool", mcp.WithString("owner", mcp.Description("Repository owner"))),
+		noParams,
+		otherParams,
+	)
+	toolset.Enabled = true
+	defaults.Apply(toolset)
+
+	tools := map[string]mcp.Tool{}
+	for _, tool := range toolset.GetAvailableTools() {
+		tools[tool.Tool.Name] = tool.Tool
+	}
+	assert.Equal(t, []string{"number"}, tools["repo_tool"].InputSchema.Required)
+	assert.Equal(t, "Repository owner. Defaults to octo-org", tools["repo_tool"].InputSchema.Properties["owner"].(map[string]any)["description"])
+	assert.Equal(t, "Repository name. Defaults to octo-repo", tools["repo_tool"].InputSchema.Properties["repo"].(map[string]any)["description"])
+	assert.Empty(t, tools["owner_tool"].InputSchema.Required)
+	// Tools without required owner or repo parameters are left as they are
+	assert.Equal(t, "Repository owner", tools["optional_owner_tool"].InputSchema.Properties["owner"].(map[string]any)["description"])
+	assert.Equal(t, noParams.Tool, tools["no_params"])
+	assert.Equal(t, otherParams.Tool, tools["other_params"])
+
+	hooks := &server.Hooks{}
+	defaults.AddHooks(hooks)
+	s := server.NewMCPServer("test", "1.0", server.WithHooks(hooks))
+	toolset.RegisterTools(s)
+
+	call := func(name string, args map[string]any) map[string]any {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+			"params": map[string]any{"name": name, "arguments": args},
+		})
+		require.NoError(t, err)
+		response, ok := s.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		result := response.Result.(mcp.CallToolResult)
+		require.False(t, result.IsError)
+		var returned map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &returned))
+		return returned
+	}
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "omitted owner and repo are defaulted",
+			tool:     "repo_tool",
+			args:     map[string]any{"number": float64(1)},
+			expected: map[string]any{"owner": "octo-org", "repo": "octo-repo", "number": float64(1)},
+		},
+		{
+			name:     "explicit values win",
+			tool:     "repo_tool",
+			args:     map[string]any{"owner": "octo-org", "repo": "other-repo", "number": float64(1)},
+			expected: map[string]any{"owner": "octo-org", "repo": "other-repo", "number": float64(1)},
+		},
+		{
+			name:     "repository is not defaulted for another owner",
+			tool:     "repo_tool",
+			args:     map[string]any{"owner": "someone-else", "number": float64(1)},
+			expected: map[string]any{"owner": "someone-else", "number": float64(1)},
+		},
+		{
+			name:     "empty owner is defaulted",
+			tool:     "owner_tool",
+			args:     map[string]any{"owner": ""},
+			expected: map[string]any{"owner": "octo-org"},
+		},
+		{
+			name:     "optional owner is not defaulted",
+			tool:     "optional_owner_tool",
+			args:     map[string]any{},
+			expected: map[string]any{},
+		},
+		{
+			name:     "tools without the parameters are called as is",
+			tool:     "other_params",
+			args:     map[string]any{"query": "bug"},
+			expected: map[string]any{"query": "bug"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, call(tc.tool, tc.args))
+		})
+	}
+}
+
+func Test_RepositoryDefaultsAppliedToDefaultToolsets(t *testing.T) {
+	defaults, err := NewRepositoryDefaults("octo-org", "octo-repo")
+	require.NoError(t, err)
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), stubGetSCIMClientFn(nil), translations.NullTranslationHelper, AssetsConfig{}, TokenPermissionsConfig{}, nil, nil, defaults)
+	for _, toolset := range tsg.Toolsets {
+		defaults.Apply(toolset)
+		for _, tool := range toolset.GetAvailableTools() {
+			// The schemas of all tools remain valid
+			_, err := json.Marshal(tool.Tool)
+			require.NoError(t, err, tool.Tool.Name)
+			assert.NotContains(t, tool.Tool.InputSchema.Required, "owner", tool.Tool.Name)
+		}
+	}
+}
+
+func Test_GetMeReportsRepositoryDefaults(t *testing.T) {
+	defaults, err := NewRepositoryDefaults("octo-org", "octo-repo")
+	require.NoError(t, err)
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetUser, &github.User{Login: github.Ptr("octocat")}),
+	))
+	_, handler := GetMe(stubGetClientFn(client), TokenPermissionsConfig{SkipProbe: true}, defaults, translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var returned struct {
+		Login              string              `json:"login"`
+		RepositoryDefaults *RepositoryDefaults `json:"repository_defaults"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "octocat", returned.Login)
+	require.NotNil(t, returned.RepositoryDefaults)
+	assert.Equal(t, "octo-org", returned.RepositoryDefaults.Owner)
+	assert.Equal(t, "octo-repo", returned.RepositoryDefaults.Repo)
+}
+
+func Test_GetRepoOverviewReportsRepositoryDefaults(t *testing.T) {
+	defaults, err := NewRepositoryDefaults("octo-org", "octo-repo")
+	require.NoError(t, err)
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{FullName: github.Ptr("octo-org/octo-repo")}),
+	))
+	_, handler := GetRepoOverview(stubGetClientFn(client), defaults, translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "repo": "octo-repo"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var returned RepositoryOverview
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "octo-org/octo-repo", returned.FullName)
+	require.NotNil(t, returned.RepositoryDefaults)
+	assert.Equal(t, "octo-org", returned.RepositoryDefaults.Owner)
+	assert.Equal(t, "octo-repo", returned.RepositoryDefaults.Repo)
+}
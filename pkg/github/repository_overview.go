@@ -0,0 +1,302 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultReadmeMaxLength is the default number of characters of the README returned in a repository overview.
+const defaultReadmeMaxLength = 2000
+
+// LanguageShare is the share of a repository's code written in a language.
+type LanguageShare struct {
+	Language   string  `json:"language"`
+	Bytes      int     `json:"bytes"`
+	Percentage float64 `json:"percentage"`
+}
+
+// RepositoryOverview is the output type for the get_repo_overview tool.
+type RepositoryOverview struct {
+	FullName        string          `json:"full_name"`
+	Description     string          `json:"description,omitempty"`
+	HTMLURL         string          `json:"html_url"`
+	Homepage        string          `json:"homepage,omitempty"`
+	Topics          []string        `json:"topics"`
+	Stars           int             `json:"stars"`
+	Forks           int             `json:"forks"`
+	OpenIssues      int             `json:"open_issues"`
+	DefaultBranch   string          `json:"default_branch"`
+	Visibility      string          `json:"visibility,omitempty"`
+	Archived        bool            `json:"archived,omitempty"`
+	Fork            bool            `json:"fork,omitempty"`
+	Languages       []LanguageShare `json:"languages"`
+	LatestRelease   string          `json:"latest_release,omitempty"`
+	Readme          string          `json:"readme,omitempty"`
+	ReadmeTruncated bool            `json:"readme_truncated,omitempty"`
+	TopLevel        []string        `json:"top_level"`
+	Warnings        []string        `json:"warnings,omitempty"`
+	// RepositoryDefaults are the owner and repository tools use when they are called without them.
+	RepositoryDefaults *RepositoryDefaults `json:"repository_defaults,omitempty"`
+}
+
+// GetRepoOverview creates a tool that summarizes a repository in a single call. Like get_me, it
+// reports the repository defaults, if any.
+func GetRepoOverview(getClient GetClientFn, repoDefaults *RepositoryDefaults, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_overview",
+			mcp.WithDescription(t("TOOL_GET_REPO_OVERVIEW_DESCRIPTION", "Get an overview of a GitHub repository in a single call: its description, topics, stars and default branch, language breakdown, README as plain text, latest release and top-level files and directories. Use this first when asked to look at a repository. Also reports the default owner and repository that tools use when called without them, if set.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_OVERVIEW_USER_TITLE", "Get repository overview"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("readme_max_length",
+				mcp.Description("Maximum number of characters of the README to return, 0 to leave the README out"),
+				mcp.Min(0),
+				mcp.DefaultNumber(defaultReadmeMaxLength),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			readmeMaxLength, err := OptionalIntParamWithDefault(request, "readme_max_length", defaultReadmeMaxLength)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if readmeMaxLength < 0 {
+				return mcp.NewToolResultError("readme_max_length must not be negative"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			overview := RepositoryOverview{
+				Topics:             []string{},
+				Languages:          []LanguageShare{},
+				TopLevel:           []string{},
+				RepositoryDefaults: repoDefaults,
+			}
+
+			var (
+				repository *github.Repository
+				repoResp   *github.Response
+			)
+			getRepository := func(ctx context.Context) error {
+				var err error
+				repository, repoResp, err = client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return err
+				}
+				return repoResp.Body.Close()
+			}
+
+			getLanguages := func(ctx context.Context) error {
+				languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+				if err != nil {
+					return fmt.Errorf("failed to get languages: %w", err)
+				}
+				_ = resp.Body.Close()
+				overview.Languages = languageShares(languages)
+				return nil
+			}
+
+			getReadme := func(ctx context.Context) error {
+				if readmeMaxLength == 0 {
+					return nil
+				}
+				readme, resp, err := client.Repositories.GetReadme(ctx, owner, repo, nil)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return nil
+					}
+					return fmt.Errorf("failed to get README: %w", err)
+				}
+				_ = resp.Body.Close()
+				content, err := readme.GetContent()
+				if err != nil {
+					return fmt.Errorf("failed to decode README: %w", err)
+				}
+				overview.Readme, overview.ReadmeTruncated = truncateText(markdownToPlainText(content), readmeMaxLength)
+				return nil
+			}
+
+			getLatestRelease := func(ctx context.Context) error {
+				release, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+				if err != nil {
+					// Repositories without releases have no latest release.
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return nil
+					}
+					return fmt.Errorf("failed to get latest release: %w", err)
+				}
+				_ = resp.Body.Close()
+				overview.LatestRelease = release.GetTagName()
+				return nil
+			}
+
+			getTopLevel := func(ctx context.Context) error {
+				_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, "", nil)
+				if err != nil {
+					// Empty repositories have no contents.
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return nil
+					}
+					return fmt.Errorf("failed to list top-level contents: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, entry := range entries {
+					name := entry.GetName()
+					if entry.GetType() == "dir" {
+						name += "/"
+					}
+					overview.TopLevel = append(overview.TopLevel, name)
+				}
+				return nil
+			}
+
+			errs := runBounded(ctx, maxConcurrentRequests, getRepository, getLanguages, getReadme, getLatestRelease, getTopLevel)
+
+			// Without the repository itself there is nothing to give an overview of.
+			if errs[0] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					repoResp,
+					errs[0],
+				), nil
+			}
+			for _, err := range errs[1:] {
+				if err != nil {
+					overview.Warnings = append(overview.Warnings, err.Error())
+				}
+			}
+
+			overview.FullName = repository.GetFullName()
+			overview.Description = repository.GetDescription()
+			overview.HTMLURL = repository.GetHTMLURL()
+			overview.Homepage = repository.GetHomepage()
+			if repository.Topics != nil {
+				overview.Topics = repository.Topics
+			}
+			overview.Stars = repository.GetStargazersCount()
+			overview.Forks = repository.GetForksCount()
+			overview.OpenIssues = repository.GetOpenIssuesCount()
+			overview.DefaultBranch = repository.GetDefaultBranch()
+			overview.Visibility = repository.GetVisibility()
+			overview.Archived = repository.GetArchived()
+			overview.Fork = repository.GetFork()
+
+			return MarshalledTextResult(overview), nil
+		}
+}
+
+// languageShares converts the bytes of code per language into shares, largest first.
+func languageShares(languages map[string]int) []LanguageShare {
+	total := 0
+	for _, bytes := range languages {
+		total += bytes
+	}
+
+	shares := make([]LanguageShare, 0, len(languages))
+	for language, bytes := range languages {
+		share := LanguageShare{Language: language, Bytes: bytes}
+		if total > 0 {
+			share.Percentage = math.Round(float64(bytes)*1000/float64(total)) / 10
+		}
+		shares = append(shares, share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Bytes != shares[j].Bytes {
+			return shares[i].Bytes > shares[j].Bytes
+		}
+		return shares[i].Language < shares[j].Language
+	})
+	return shares
+}
+
+var (
+	markdownHTMLComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	markdownHTMLTag     = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	markdownImage       = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink        = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownRefLink     = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	markdownRefDef      = regexp.MustCompile(`(?m)^\s*\[[^\]]+\]:\s*\S+.*$`)
+	markdownHeading     = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	markdownBlockquote  = regexp.MustCompile(`(?m)^\s{0,3}>\s?`)
+	markdownListBullet  = regexp.MustCompile(`(?m)^(\s*)[*+-]\s+`)
+	markdownRule        = regexp.MustCompile(`(?m)^\s{0,3}([-*_]\s*){3,}$`)
+	markdownFence       = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	markdownEmphasis    = regexp.MustCompile(`(\*\*|\*|~~)([^*~\n]+)(\*\*|\*|~~)`)
+	markdownUnderscores = regexp.MustCompile(`(^|\W)__?([^_\n]+)__?(\W|$)`)
+	markdownInlineCode  = regexp.MustCompile("`([^`]*)`")
+	markdownBlankLines  = regexp.MustCompile(`\n{3,}`)
+)
+
+// markdownToPlainText strips the markup of a Markdown document, keeping its text. It is a best effort
+// for giving an overview of a README, not a full Markdown renderer.
+func markdownToPlainText(markdown string) string {
+	text := strings.ReplaceAll(markdown, "\r\n", "\n")
+	text = markdownHTMLComment.ReplaceAllString(text, "")
+	text = markdownImage.ReplaceAllString(text, "$1")
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = markdownRefLink.ReplaceAllString(text, "$1")
+	text = markdownRefDef.ReplaceAllString(text, "")
+	text = markdownHTMLTag.ReplaceAllString(text, "")
+	text = markdownFence.ReplaceAllString(text, "")
+	text = markdownRule.ReplaceAllString(text, "")
+	text = markdownHeading.ReplaceAllString(text, "")
+	text = markdownBlockquote.ReplaceAllString(text, "")
+	text = markdownListBullet.ReplaceAllString(text, "$1- ")
+	text = markdownInlineCode.ReplaceAllString(text, "$1")
+	text = markdownEmphasis.ReplaceAllString(text, "$2")
+	// Underscores only mark emphasis around whole words, so snake_case identifiers are left alone.
+	text = markdownUnderscores.ReplaceAllString(text, "${1}${2}${3}")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text = strings.Join(lines, "\n")
+	text = markdownBlankLines.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// truncateText truncates text to at most maxLength characters, preferring to cut at a word boundary.
+func truncateText(text string, maxLength int) (string, bool) {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text, false
+	}
+	truncated := string(runes[:maxLength])
+	if !unicode.IsSpace(runes[maxLength]) {
+		if i := strings.LastIndexAny(truncated, " \n"); i > len(truncated)/2 {
+			truncated = truncated[:i]
+		}
+	}
+	return strings.TrimSpace(truncated) + "…", true
+}
@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepoOverview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoOverview(stubGetClientFn(mockClient), nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_overview", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "readme_max_length")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		FullName:        github.Ptr("owner/repo"),
+		Description:     github.Ptr("A test repository"),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo"),
+		Topics:          []string{"mcp", "go"},
+		StargazersCount: github.Ptr(120),
+		ForksCount:      github.Ptr(8),
+		OpenIssuesCount: github.Ptr(3),
+		DefaultBranch:   github.Ptr("main"),
+		Visibility:      github.Ptr("public"),
+	}
+	readme := "# Repo\n\nA **fast** server, see the [docs](https://example.com/docs).\n\n![logo](logo.png)\n"
+	mockReadme := &github.RepositoryContent{
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(readme))),
+	}
+	mockContents := []*github.RepositoryContent{
+		{Name: github.Ptr("README.md"), Type: github.Ptr("file")},
+		{Name: github.Ptr("cmd"), Type: github.Ptr("dir")},
+	}
+
+	failing := func(status int) http.HandlerFunc {
+		return mockResponse(t, status, `{"message": "Something went wrong"}`)
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedOverview RepositoryOverview
+		expectedWarnings []string
+	}{
+		{
+			name: "full overview",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, map[string]int{"Go": 7500, "Shell": 2000, "Dockerfile": 500}),
+				mock.WithRequestMatch(mock.GetReposReadmeByOwnerByRepo, mockReadme),
+				mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, &github.RepositoryRelease{TagName: github.Ptr("v1.2.0")}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/").andThen(
+						mockResponse(t, http.StatusOK, mockContents),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedOverview: RepositoryOverview{
+				FullName:      "owner/repo",
+				Description:   "A test repository",
+				HTMLURL:       "https://github.com/owner/repo",
+				Topics:        []string{"mcp", "go"},
+				Stars:         120,
+				Forks:         8,
+				OpenIssues:    3,
+				DefaultBranch: "main",
+				Visibility:    "public",
+				Languages: []LanguageShare{
+					{Language: "Go", Bytes: 7500, Percentage: 75},
+					{Language: "Shell", Bytes: 2000, Percentage: 20},
+					{Language: "Dockerfile", Bytes: 500, Percentage: 5},
+				},
+				LatestRelease: "v1.2.0",
+				Readme:        "Repo\n\nA fast server, see the docs.\n\nlogo",
+				TopLevel:      []string{"README.md", "cmd/"},
+			},
+		},
+		{
+			name: "partial overview with warnings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+				mock.WithRequestMatchHandler(mock.GetReposLanguagesByOwnerByRepo, failing(http.StatusInternalServerError)),
+				mock.WithRequestMatch(mock.GetReposReadmeByOwnerByRepo, mockReadme),
+				// Repositories without releases and READMEs are not warned about.
+				mock.WithRequestMatchHandler(mock.GetReposReleasesLatestByOwnerByRepo, failing(http.StatusNotFound)),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, failing(http.StatusForbidden)),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"readme_max_length": float64(12),
+			},
+			expectedOverview: RepositoryOverview{
+				FullName:        "owner/repo",
+				Description:     "A test repository",
+				HTMLURL:         "https://github.com/owner/repo",
+				Topics:          []string{"mcp", "go"},
+				Stars:           120,
+				Forks:           8,
+				OpenIssues:      3,
+				DefaultBranch:   "main",
+				Visibility:      "public",
+				Languages:       []LanguageShare{},
+				Readme:          "Repo\n\nA fast…",
+				ReadmeTruncated: true,
+				TopLevel:        []string{},
+			},
+			expectedWarnings: []string{
+				"failed to get languages: ",
+				"failed to list top-level contents: ",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposByOwnerByRepo, failing(http.StatusNotFound)),
+				mock.WithRequestMatchHandler(mock.GetReposLanguagesByOwnerByRepo, failing(http.StatusNotFound)),
+				mock.WithRequestMatchHandler(mock.GetReposReadmeByOwnerByRepo, failing(http.StatusNotFound)),
+				mock.WithRequestMatchHandler(mock.GetReposReleasesLatestByOwnerByRepo, failing(http.StatusNotFound)),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, failing(http.StatusNotFound)),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoOverview(stubGetClientFn(client), nil, translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var overview RepositoryOverview
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &overview))
+
+			require.Len(t, overview.Warnings, len(tc.expectedWarnings))
+			for i, prefix := range tc.expectedWarnings {
+				assert.True(t, strings.HasPrefix(overview.Warnings[i], prefix), "warning %q should start with %q", overview.Warnings[i], prefix)
+			}
+			overview.Warnings = nil
+			assert.Equal(t, tc.expectedOverview, overview)
+		})
+	}
+}
+
+func Test_MarkdownToPlainText(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "headings, emphasis and links",
+			markdown: "# Title\n\nSome *emphasis*, __strong__ and `code` with a [link](https://example.com).\n",
+			expected: "Title\n\nSome emphasis, strong and code with a link.",
+		},
+		{
+			name:     "snake_case identifiers are kept",
+			markdown: "Call get_repo_overview or _the_ tool.",
+			expected: "Call get_repo_overview or the tool.",
+		},
+		{
+			name:     "badges, html and comments",
+			markdown: "<!-- badges -->\n[![CI](https://example.com/ci.svg)](https://example.com/ci)\n<p align=\"center\">Centered</p>\n\n\n\nText",
+			expected: "CI\nCentered\n\nText",
+		},
+		{
+			name:     "lists, quotes and code fences",
+			markdown: "* one\n+ two\n> quoted\n\n```bash\nmake build\n```\n---\n[ref]: https://example.com",
+			expected: "- one\n- two\nquoted\n\nmake build",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, markdownToPlainText(tc.markdown))
+		})
+	}
+}
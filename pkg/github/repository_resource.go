@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -129,7 +130,7 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 		}
 		//  if it's a directory
 		if path == "" || strings.HasSuffix(path, "/") {
-			return nil, fmt.Errorf("directories are not supported: %s", path)
+			return repositoryDirectoryContents(ctx, getClient, owner, repo, path, opts, request.Params.URI)
 		}
 		rawClient, err := getRawClient(ctx)
 
@@ -185,8 +186,63 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			}
 			return nil, fmt.Errorf("failed to fetch raw content: %s", string(body))
 		default:
-			// This should be unreachable because GetContents should return an error if neither file nor directory content is found.
-			return nil, errors.New("404 Not Found")
+			// The path may be a directory, which the raw content endpoint does not serve.
+			return repositoryDirectoryContents(ctx, getClient, owner, repo, path, opts, request.Params.URI)
 		}
 	}
 }
+
+// RepositoryDirectoryEntry describes a file or directory listed in a directory resource.
+type RepositoryDirectoryEntry struct {
+	URI  string `json:"uri"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+}
+
+// repositoryDirectoryContents lists a repository directory as a single JSON resource, with the
+// resource URI of every entry so clients can read or subscribe to the files it contains.
+func repositoryDirectoryContents(ctx context.Context, getClient GetClientFn, owner, repo, path string, opts *github.RepositoryContentGetOptions, uri string) ([]mcp.ResourceContents, error) {
+	githubClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	fileContent, directoryContent, resp, err := githubClient.Repositories.GetContents(ctx, owner, repo, strings.TrimSuffix(path, "/"), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get directory contents: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if fileContent != nil {
+		// The raw content endpoint only misses files that do not exist at this ref.
+		return nil, errors.New("404 Not Found")
+	}
+
+	entries := make([]RepositoryDirectoryEntry, 0, len(directoryContent))
+	for _, entry := range directoryContent {
+		entries = append(entries, RepositoryDirectoryEntry{
+			URI:  strings.TrimSuffix(uri, "/") + "/" + entry.GetName(),
+			Name: entry.GetName(),
+			Path: entry.GetPath(),
+			Type: entry.GetType(),
+			Size: entry.GetSize(),
+			SHA:  entry.GetSHA(),
+		})
+	}
+
+	listing, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory listing: %w", err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(listing),
+		},
+	}, nil
+}
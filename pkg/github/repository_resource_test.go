@@ -19,6 +19,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
+		requestURI     string
 		requestArgs    map[string]any
 		expectError    string
 		expectedResult any
@@ -207,6 +208,64 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 				URI:      "",
 			}},
 		},
+		{
+			name: "successful directory listing (root)",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/").andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+							{Name: github.Ptr("README.md"), Path: github.Ptr("README.md"), Type: github.Ptr("file"), Size: github.Ptr(42), SHA: github.Ptr("abc123")},
+							{Name: github.Ptr("src"), Path: github.Ptr("src"), Type: github.Ptr("dir"), SHA: github.Ptr("def456")},
+						}),
+					),
+				),
+			),
+			requestURI: "repo://owner/repo/contents",
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+			},
+			expectedResult: []mcp.ResourceContents{mcp.TextResourceContents{
+				URI:      "repo://owner/repo/contents",
+				MIMEType: "application/json",
+				Text:     `[{"uri":"repo://owner/repo/contents/README.md","name":"README.md","path":"README.md","type":"file","size":42,"sha":"abc123"},{"uri":"repo://owner/repo/contents/src","name":"src","path":"src","type":"dir","sha":"def456"}]`,
+			}},
+		},
+		{
+			name: "successful directory listing (branch, no trailing slash)",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/contents/src",
+						queryParams: map[string]string{"ref": "refs/heads/main"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+							{Name: github.Ptr("main.go"), Path: github.Ptr("src/main.go"), Type: github.Ptr("file"), Size: github.Ptr(7), SHA: github.Ptr("aaa111")},
+						}),
+					),
+				),
+			),
+			requestURI: "repo://owner/repo/refs/heads/main/contents/src",
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"path":   []string{"src"},
+				"branch": []string{"main"},
+			},
+			expectedResult: []mcp.ResourceContents{mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/src",
+				MIMEType: "application/json",
+				Text:     `[{"uri":"repo://owner/repo/refs/heads/main/contents/src/main.go","name":"main.go","path":"src/main.go","type":"file","size":7,"sha":"aaa111"}]`,
+			}},
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -239,6 +298,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 					URI       string         `json:"uri"`
 					Arguments map[string]any `json:"arguments,omitempty"`
 				}{
+					URI:       tc.requestURI,
 					Arguments: tc.requestArgs,
 				},
 			}
@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// templateRepoPollInterval and templateRepoPollTimeout control how create_repo_from_template waits
+// for GitHub to finish copying the template. They are variables so that tests can shorten them.
+var (
+	templateRepoPollInterval = time.Second
+	templateRepoPollTimeout  = 30 * time.Second
+)
+
+// CreateRepoFromTemplate creates a tool to create a repository from a template repository.
+func CreateRepoFromTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repo_from_template",
+			mcp.WithDescription(t("TOOL_CREATE_REPO_FROM_TEMPLATE_DESCRIPTION", "Create a new GitHub repository from a template repository. Returns once the new repository is ready to use.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPO_FROM_TEMPLATE_USER_TITLE", "Create repository from template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("template_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the template repository"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Required(),
+				mcp.Description("Name of the template repository"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("User or organization to create the repository in. Defaults to the authenticated user"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new repository"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the new repository"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether the new repository should be private"),
+			),
+			mcp.WithBoolean("include_all_branches",
+				mcp.Description("Copy all branches of the template instead of only the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			templateOwner, err := RequiredParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := RequiredParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAllBranches, err := OptionalParam[bool](request, "include_all_branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			templateRequest := &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				Private:            github.Ptr(private),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+			}
+			if owner != "" {
+				templateRequest.Owner = github.Ptr(owner)
+			}
+			if description != "" {
+				templateRequest.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdRepo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, templateRequest)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create repository from template",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// GitHub copies the template in the background, and the repository is not found until it is done
+			readyRepo, err := waitForRepository(ctx, client, createdRepo.GetOwner().GetLogin(), createdRepo.GetName())
+			if err != nil {
+				return nil, err
+			}
+			if readyRepo == nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Repository %s was created but is still being initialized from the template. Try again shortly.", createdRepo.GetFullName())), nil
+			}
+
+			return MarshalledTextResult(readyRepo), nil
+		}
+}
+
+// waitForRepository polls for a repository until it can be fetched. It returns nil without an error
+// if it still cannot be fetched after templateRepoPollTimeout.
+func waitForRepository(ctx context.Context, client *github.Client, owner, repo string) (*github.Repository, error) {
+	ctx, cancel := context.WithTimeout(ctx, templateRepoPollTimeout)
+	defer cancel()
+
+	for {
+		repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+		if err == nil {
+			_ = resp.Body.Close()
+			return repository, nil
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			if ctx.Err() != nil {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get created repository: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(templateRepoPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil
+		case <-timer.C:
+		}
+	}
+}
+
+// SetRepoAsTemplate creates a tool to make a repository a template repository.
+func SetRepoAsTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repo_as_template",
+			mcp.WithDescription(t("TOOL_SET_REPO_AS_TEMPLATE_DESCRIPTION", "Make a GitHub repository a template repository, so that new repositories can be created from it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPO_AS_TEMPLATE_USER_TITLE", "Set repository as template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		repoTemplateHandler(getClient, true)
+}
+
+// UnsetRepoAsTemplate creates a tool to stop a repository from being a template repository.
+func UnsetRepoAsTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unset_repo_as_template",
+			mcp.WithDescription(t("TOOL_UNSET_REPO_AS_TEMPLATE_DESCRIPTION", "Stop a GitHub repository from being a template repository. Repositories already created from it are not affected")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNSET_REPO_AS_TEMPLATE_USER_TITLE", "Unset repository as template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		repoTemplateHandler(getClient, false)
+}
+
+// repoTemplateHandler returns the handler of set_repo_as_template and unset_repo_as_template.
+func repoTemplateHandler(getClient GetClientFn, isTemplate bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		owner, err := RequiredParam[string](request, "owner")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		repo, err := RequiredParam[string](request, "repo")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		updatedRepo, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{IsTemplate: github.Ptr(isTemplate)})
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to update repository",
+				resp,
+				err,
+			), nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		return MarshalledTextResult(updatedRepo), nil
+	}
+}
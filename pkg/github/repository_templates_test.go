@@ -0,0 +1,226 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateRepoFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepoFromTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repo_from_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "template_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "template_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "private")
+	assert.Contains(t, tool.InputSchema.Properties, "include_all_branches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "name"})
+
+	interval, timeout := templateRepoPollInterval, templateRepoPollTimeout
+	templateRepoPollInterval, templateRepoPollTimeout = time.Millisecond, 100*time.Millisecond
+	t.Cleanup(func() { templateRepoPollInterval, templateRepoPollTimeout = interval, timeout })
+
+	createdRepo := &github.Repository{
+		Name:     github.Ptr("new-service"),
+		FullName: github.Ptr("acme/new-service"),
+		Owner:    &github.User{Login: github.Ptr("acme")},
+	}
+	readyRepo := &github.Repository{
+		Name:          github.Ptr("new-service"),
+		FullName:      github.Ptr("acme/new-service"),
+		Owner:         &github.User{Login: github.Ptr("acme")},
+		DefaultBranch: github.Ptr("main"),
+		Private:       github.Ptr(true),
+	}
+	// notFoundTimes answers with 404 the first n times, as GitHub does while copying the template
+	notFoundTimes := func(n int32) http.HandlerFunc {
+		var calls atomic.Int32
+		return func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= n {
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				return
+			}
+			expectPath(t, "/repos/acme/new-service").andThen(mockResponse(t, http.StatusOK, readyRepo))(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepo   *github.Repository
+		expectedText   string
+	}{
+		{
+			name: "waits for the repository to be initialized",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectPath(t, "/repos/octo/service-template/generate").andThen(
+						expectRequestBody(t, map[string]any{
+							"name":                 "new-service",
+							"owner":                "acme",
+							"description":          "A new service",
+							"private":              true,
+							"include_all_branches": false,
+						}).andThen(
+							mockResponse(t, http.StatusCreated, createdRepo),
+						),
+					),
+				),
+				mock.WithRequestMatchHandler(mock.GetReposByOwnerByRepo, notFoundTimes(2)),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "octo",
+				"template_repo":  "service-template",
+				"owner":          "acme",
+				"name":           "new-service",
+				"description":    "A new service",
+				"private":        true,
+			},
+			expectedRepo: readyRepo,
+		},
+		{
+			name: "repository still being initialized",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.PostReposGenerateByTemplateOwnerByTemplateRepo, mockResponse(t, http.StatusCreated, createdRepo)),
+				mock.WithRequestMatchHandler(mock.GetReposByOwnerByRepo, notFoundTimes(1000)),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "octo",
+				"template_repo":  "service-template",
+				"name":           "new-service",
+			},
+			expectedText: "Repository acme/new-service was created but is still being initialized from the template. Try again shortly.",
+		},
+		{
+			name: "template not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "octo",
+				"template_repo":  "missing",
+				"name":           "new-service",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository from template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepoFromTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+			var returnedRepo github.Repository
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedRepo))
+			assert.Equal(t, tc.expectedRepo.GetFullName(), returnedRepo.GetFullName())
+			assert.Equal(t, tc.expectedRepo.GetDefaultBranch(), returnedRepo.GetDefaultBranch())
+			assert.Equal(t, tc.expectedRepo.GetPrivate(), returnedRepo.GetPrivate())
+		})
+	}
+}
+
+func Test_SetRepoAsTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		isTemplate bool
+	}{
+		{name: "set_repo_as_template", isTemplate: true},
+		{name: "unset_repo_as_template", isTemplate: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			newTool := SetRepoAsTemplate
+			if !tc.isTemplate {
+				newTool = UnsetRepoAsTemplate
+			}
+
+			// Verify tool definition once
+			tool, _ := newTool(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+			require.NoError(t, toolsnaps.Test(tool.Name, tool))
+			assert.Equal(t, tc.name, tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo").andThen(
+						expectRequestBody(t, map[string]any{"is_template": tc.isTemplate}).andThen(
+							mockResponse(t, http.StatusOK, &github.Repository{
+								FullName:   github.Ptr("owner/repo"),
+								IsTemplate: github.Ptr(tc.isTemplate),
+							}),
+						),
+					),
+				),
+			))
+			_, handler := newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returnedRepo github.Repository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedRepo))
+			assert.Equal(t, tc.isTemplate, returnedRepo.GetIsTemplate())
+		})
+	}
+
+	t.Run("repository not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.PatchReposByOwnerByRepo, mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)),
+		))
+		_, handler := SetRepoAsTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "missing",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to update repository")
+	})
+}
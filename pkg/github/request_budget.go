@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestBudgetWindow is the window MaxPerMinute applies to.
+const requestBudgetWindow = time.Minute
+
+// RequestBudget limits the GitHub API requests each MCP session makes, so that an agent stuck in a
+// loop cannot use up the rate limit of the token it shares with others. Requests made outside of a
+// session share one budget. It is safe for concurrent use, and a nil RequestBudget allows everything.
+type RequestBudget struct {
+	// MaxPerSession is the number of requests a session may make in total. If 0, it is not limited.
+	MaxPerSession int
+	// MaxPerMinute is the number of requests a session may make in any minute. If 0, it is not limited.
+	MaxPerMinute int
+
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*sessionBudget
+}
+
+type sessionBudget struct {
+	used int
+	// recent are the times of the requests made in the last minute, oldest first.
+	recent []time.Time
+}
+
+// NewRequestBudget returns a RequestBudget with the given limits, or nil if neither is set.
+func NewRequestBudget(maxPerSession, maxPerMinute int) *RequestBudget {
+	if maxPerSession <= 0 && maxPerMinute <= 0 {
+		return nil
+	}
+	return &RequestBudget{
+		MaxPerSession: max(maxPerSession, 0),
+		MaxPerMinute:  max(maxPerMinute, 0),
+		now:           time.Now,
+		sessions:      make(map[string]*sessionBudget),
+	}
+}
+
+// RequestBudgetError is returned instead of making a request when the budget of its session is
+// exhausted.
+type RequestBudgetError struct {
+	// Limit is the budget that is exhausted, "session" or "minute".
+	Limit string
+	Max   int
+	// RetryAfter is when the per-minute budget allows another request. It is 0 for the session budget,
+	// which does not recover.
+	RetryAfter time.Duration
+}
+
+func (e *RequestBudgetError) Error() string {
+	if e.Limit == "minute" {
+		return fmt.Sprintf("request budget exhausted: this session made its %d GitHub API requests allowed per minute, another is allowed in %d seconds",
+			e.Max, int(e.RetryAfter.Round(time.Second).Seconds()))
+	}
+	return fmt.Sprintf("request budget exhausted: this session made all %d GitHub API requests it is allowed, start a new session to make more", e.Max)
+}
+
+// ToolError describes the exhausted budget for the structured content of tool error results.
+func (e *RequestBudgetError) ToolError() ghErrors.ToolError {
+	return ghErrors.ToolError{
+		Code:              ghErrors.CodeBudgetExhausted,
+		Message:           e.Error(),
+		Retryable:         e.Limit == "minute",
+		RetryAfterSeconds: int(e.RetryAfter.Round(time.Second).Seconds()),
+	}
+}
+
+// take counts a request of session against its budget, or returns a *RequestBudgetError without
+// counting it if the budget is exhausted.
+func (b *RequestBudget) take(session string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	s := b.session(session, now)
+	if b.MaxPerSession > 0 && s.used >= b.MaxPerSession {
+		return &RequestBudgetError{Limit: "session", Max: b.MaxPerSession}
+	}
+	if b.MaxPerMinute > 0 && len(s.recent) >= b.MaxPerMinute {
+		return &RequestBudgetError{Limit: "minute", Max: b.MaxPerMinute, RetryAfter: s.recent[0].Add(requestBudgetWindow).Sub(now)}
+	}
+
+	s.used++
+	if b.MaxPerMinute > 0 {
+		s.recent = append(s.recent, now)
+	}
+	return nil
+}
+
+// session returns the budget of session, without the requests that left the window by now. b.mu
+// must be held.
+func (b *RequestBudget) session(session string, now time.Time) *sessionBudget {
+	s, ok := b.sessions[session]
+	if !ok {
+		s = &sessionBudget{}
+		b.sessions[session] = s
+	}
+	expired := 0
+	for expired < len(s.recent) && !now.Before(s.recent[expired].Add(requestBudgetWindow)) {
+		expired++
+	}
+	s.recent = s.recent[expired:]
+	return s
+}
+
+// Forget drops the budget of a session that ended.
+func (b *RequestBudget) Forget(session string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.sessions, session)
+}
+
+// RequestBudgetStatus is how much of its request budget a session has used.
+type RequestBudgetStatus struct {
+	MaxPerSession       int `json:"max_per_session,omitempty"`
+	UsedInSession       int `json:"used_in_session"`
+	RemainingInSession  int `json:"remaining_in_session,omitempty"`
+	MaxPerMinute        int `json:"max_per_minute,omitempty"`
+	UsedInLastMinute    int `json:"used_in_last_minute,omitempty"`
+	RemainingThisMinute int `json:"remaining_this_minute,omitempty"`
+}
+
+// Status returns how much of its budget session has used.
+func (b *RequestBudget) Status(session string) RequestBudgetStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := b.session(session, b.now())
+	status := RequestBudgetStatus{
+		MaxPerSession: b.MaxPerSession,
+		UsedInSession: s.used,
+		MaxPerMinute:  b.MaxPerMinute,
+	}
+	if b.MaxPerSession > 0 {
+		status.RemainingInSession = max(b.MaxPerSession-s.used, 0)
+	}
+	if b.MaxPerMinute > 0 {
+		status.UsedInLastMinute = len(s.recent)
+		status.RemainingThisMinute = max(b.MaxPerMinute-len(s.recent), 0)
+	}
+	return status
+}
+
+// sessionIDFromContext returns the ID of the MCP session of ctx, or "" outside of a session.
+func sessionIDFromContext(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// RequestBudgetTransport is an http.RoundTripper that counts each request against the budget of the
+// MCP session of its context, and fails requests without sending them once the budget is exhausted.
+type RequestBudgetTransport struct {
+	Transport http.RoundTripper
+	Budget    *RequestBudget
+}
+
+func (t *RequestBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Budget.take(sessionIDFromContext(req.Context())); err != nil {
+		return nil, err
+	}
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(req)
+}
+
+// RateLimitStatus is the output type of the get_rate_limit_status tool.
+type RateLimitStatus struct {
+	// RateLimits are the rate limits GitHub last reported, keyed by resource category.
+	RateLimits map[string]RateLimit `json:"rate_limits"`
+	// RequestBudget is the budget of the session, if the server limits the requests of sessions.
+	RequestBudget *RequestBudgetStatus `json:"request_budget,omitempty"`
+	// GraphQLCache counts the GraphQL queries of the session answered from the cache, if the server
+	// caches them.
+	GraphQLCache *GraphQLCacheStats `json:"graphql_cache,omitempty"`
+}
+
+// GetRateLimitStatus creates a tool to report the GitHub rate limits, the request budget of the
+// session and the GraphQL cache statistics, without making any requests.
+func GetRateLimitStatus(budget *RequestBudget, graphQLCache *GraphQLCache, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_rate_limit_status",
+			mcp.WithDescription(t("TOOL_GET_RATE_LIMIT_STATUS_DESCRIPTION", "Get the GitHub API rate limits as last reported by GitHub, per resource category such as core, search and graphql, and how much of its request budget this session has used, if the server sets one, and how many of its GraphQL queries were answered from the cache, if the server caches them. Makes no GitHub API requests, so it can be used to decide how to spend the remaining requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RATE_LIMIT_STATUS_USER_TITLE", "Get rate limit status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			status := RateLimitStatus{RateLimits: GetRateLimitState()}
+			if budget != nil {
+				budgetStatus := budget.Status(sessionIDFromContext(ctx))
+				status.RequestBudget = &budgetStatus
+			}
+			if graphQLCache != nil {
+				cacheStats := graphQLCache.Stats(sessionIDFromContext(ctx))
+				status.GraphQLCache = &cacheStats
+			}
+			return MarshalledTextResult(status), nil
+		}
+}
@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetTestSession is an MCP session whose requests are counted against a budget.
+type budgetTestSession struct {
+	id string
+}
+
+func (s budgetTestSession) SessionID() string                                   { return s.id }
+func (s budgetTestSession) Initialize()                                         {}
+func (s budgetTestSession) Initialized() bool                                   { return true }
+func (s budgetTestSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+
+func contextWithSession(id string) context.Context {
+	return server.NewMCPServer("test", "0.0.1").WithContext(context.Background(), budgetTestSession{id: id})
+}
+
+func Test_RequestBudget(t *testing.T) {
+	t.Run("concurrent requests of a session race for its budget", func(t *testing.T) {
+		budget := NewRequestBudget(20, 0)
+
+		var allowed atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if budget.take("a") == nil {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(20), allowed.Load())
+		var budgetErr *RequestBudgetError
+		require.ErrorAs(t, budget.take("a"), &budgetErr)
+		assert.Equal(t, "session", budgetErr.Limit)
+		assert.Equal(t, time.Duration(0), budgetErr.RetryAfter)
+
+		// Other sessions have budgets of their own
+		assert.NoError(t, budget.take("b"))
+		assert.Equal(t, RequestBudgetStatus{MaxPerSession: 20, UsedInSession: 20}, budget.Status("a"))
+		assert.Equal(t, RequestBudgetStatus{MaxPerSession: 20, UsedInSession: 1, RemainingInSession: 19}, budget.Status("b"))
+	})
+
+	t.Run("the per-minute budget recovers as requests leave the window", func(t *testing.T) {
+		budget := NewRequestBudget(0, 2)
+		now := time.Now()
+		budget.now = func() time.Time { return now }
+
+		require.NoError(t, budget.take("a"))
+		now = now.Add(20 * time.Second)
+		require.NoError(t, budget.take("a"))
+
+		var budgetErr *RequestBudgetError
+		require.ErrorAs(t, budget.take("a"), &budgetErr)
+		assert.Equal(t, "minute", budgetErr.Limit)
+		assert.Equal(t, 40*time.Second, budgetErr.RetryAfter)
+		assert.Equal(t, "request budget exhausted: this session made its 2 GitHub API requests allowed per minute, another is allowed in 40 seconds", budgetErr.Error())
+		assert.Equal(t, RequestBudgetStatus{MaxPerMinute: 2, UsedInSession: 2, UsedInLastMinute: 2}, budget.Status("a"))
+
+		now = now.Add(40 * time.Second)
+		require.NoError(t, budget.take("a"))
+		assert.Equal(t, RequestBudgetStatus{MaxPerMinute: 2, UsedInSession: 3, UsedInLastMinute: 2}, budget.Status("a"))
+	})
+
+	t.Run("forgets sessions", func(t *testing.T) {
+		budget := NewRequestBudget(1, 0)
+		require.NoError(t, budget.take("a"))
+		require.Error(t, budget.take("a"))
+
+		budget.Forget("a")
+		assert.NoError(t, budget.take("a"))
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		budget := NewRequestBudget(0, 0)
+		assert.Nil(t, budget)
+		assert.NoError(t, budget.take("a"))
+	})
+}
+
+func Test_RequestBudgetTransport(t *testing.T) {
+	var sent atomic.Int32
+	budget := NewRequestBudget(5, 0)
+	client := github.NewClient(&http.Client{Transport: &RequestBudgetTransport{
+		Transport: mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					sent.Add(1)
+					mockResponse(t, http.StatusOK, &github.Commit{SHA: github.Ptr("c0ffee")})(w, r)
+				}),
+			),
+		).Transport,
+		Budget: budget,
+	}})
+	_, handler := GetGitCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	// Tool calls of one session race for its budget
+	ctx := contextWithSession("agent")
+	results := make([]*mcp.CallToolResult, 20)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := handler(ctx, createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "sha": "c0ffee"}))
+			require.NoError(t, err)
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, result := range results {
+		if !result.IsError {
+			succeeded++
+			continue
+		}
+		assert.Contains(t, getErrorResult(t, result).Text, "request budget exhausted: this session made all 5 GitHub API requests it is allowed")
+		toolErr, ok := result.Meta["error"].(ghErrors.ToolError)
+		require.True(t, ok)
+		assert.Equal(t, ghErrors.CodeBudgetExhausted, toolErr.Code)
+		assert.False(t, toolErr.Retryable)
+	}
+	assert.Equal(t, 5, succeeded)
+	assert.Equal(t, int32(5), sent.Load(), "requests over the budget should not be sent")
+
+	// Another session is not affected
+	result, err := handler(contextWithSession("other"), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "sha": "c0ffee"}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func Test_RequestBudgetErrorToolError(t *testing.T) {
+	err := &RequestBudgetError{Limit: "minute", Max: 60, RetryAfter: 12 * time.Second}
+	// go-github returns transport errors wrapped in a *url.Error
+	toolErr := ghErrors.NewToolError(nil, &url.Error{Op: "Get", URL: "https://api.github.com/user", Err: err})
+	assert.Equal(t, ghErrors.ToolError{
+		Code:              ghErrors.CodeBudgetExhausted,
+		Message:           "request budget exhausted: this session made its 60 GitHub API requests allowed per minute, another is allowed in 12 seconds",
+		Retryable:         true,
+		RetryAfterSeconds: 12,
+	}, toolErr)
+}
+
+func Test_GetRateLimitStatus(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetRateLimitStatus(nil, nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rate_limit_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	budget := NewRequestBudget(100, 10)
+	require.NoError(t, budget.take("agent"))
+	require.NoError(t, budget.take("agent"))
+	_, handler := GetRateLimitStatus(budget, nil, translations.NullTranslationHelper)
+
+	result, err := handler(contextWithSession("agent"), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var status RateLimitStatus
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+	assert.NotNil(t, status.RateLimits)
+	assert.Equal(t, &RequestBudgetStatus{
+		MaxPerSession:       100,
+		UsedInSession:       2,
+		RemainingInSession:  98,
+		MaxPerMinute:        10,
+		UsedInLastMinute:    2,
+		RemainingThisMinute: 8,
+	}, status.RequestBudget)
+
+	// Without a budget, only the rate limits are reported
+	_, handler = GetRateLimitStatus(nil, nil, translations.NullTranslationHelper)
+	result, err = handler(contextWithSession("agent"), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.NotContains(t, getTextResult(t, result).Text, "request_budget")
+	assert.NotContains(t, getTextResult(t, result).Text, "graphql_cache")
+
+	// With a GraphQL cache, the statistics of the session are reported
+	cache := NewGraphQLCache(time.Minute, nil, DefaultGraphQLCacheEntries)
+	_, _, _ = cache.get("query", "token", "agent")
+	_, _, _ = cache.get("query", "token", "other-agent")
+	_, handler = GetRateLimitStatus(nil, cache, translations.NullTranslationHelper)
+	result, err = handler(contextWithSession("agent"), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+	assert.Equal(t, &GraphQLCacheStats{Misses: 1}, status.GraphQLCache)
+}
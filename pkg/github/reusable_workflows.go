@@ -0,0 +1,432 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowInput is an input declared by a workflow_call or workflow_dispatch trigger.
+type WorkflowInput struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Default     any      `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// WorkflowOutput is an output declared by a workflow_call trigger.
+type WorkflowOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+}
+
+// WorkflowSecret is a secret declared by a workflow_call trigger.
+type WorkflowSecret struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+// ReusableWorkflow is a workflow with a workflow_call trigger and the interface it declares.
+type ReusableWorkflow struct {
+	Name string `json:"name,omitempty"`
+	File string `json:"file"`
+	Path string `json:"path"`
+	// Uses is how other workflows call it, to be followed by @ and a ref.
+	Uses    string           `json:"uses"`
+	Inputs  []WorkflowInput  `json:"inputs"`
+	Outputs []WorkflowOutput `json:"outputs"`
+	Secrets []WorkflowSecret `json:"secrets"`
+}
+
+// ReusableWorkflowsResult is the output of list_reusable_workflows.
+type ReusableWorkflowsResult struct {
+	Repository string             `json:"repository"`
+	Workflows  []ReusableWorkflow `json:"workflows"`
+	Errors     []string           `json:"errors,omitempty"`
+}
+
+// parseWorkflowEvents returns the name of a workflow and the configuration of each event that
+// triggers it. Events listed without configuration have a nil configuration.
+func parseWorkflowEvents(content []byte) (string, map[string]*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return "", nil, err
+	}
+	if len(doc.Content) == 0 {
+		return "", nil, errors.New("workflow is empty")
+	}
+	root := resolveNode(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return "", nil, errors.New("workflow must be a mapping")
+	}
+
+	var name string
+	if n := mappingValue(root, "name"); n != nil && n.Kind == yaml.ScalarNode {
+		name = n.Value
+	}
+
+	events := map[string]*yaml.Node{}
+	on := mappingValue(root, "on")
+	if on == nil {
+		return name, events, nil
+	}
+	switch on.Kind {
+	case yaml.ScalarNode:
+		events[on.Value] = nil
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event = resolveNode(event); event.Kind == yaml.ScalarNode {
+				events[event.Value] = nil
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			config := resolveNode(on.Content[i+1])
+			if config.Kind != yaml.MappingNode {
+				config = nil
+			}
+			events[on.Content[i].Value] = config
+		}
+	}
+	return name, events, nil
+}
+
+// mappingEntries calls fn with the key and mapping value of each entry of the key mapping of config,
+// in the order they are declared. Entries without a mapping value are passed an empty mapping.
+func mappingEntries(config *yaml.Node, key string, fn func(name string, value *yaml.Node)) {
+	if config == nil {
+		return
+	}
+	entries := mappingValue(config, key)
+	if entries == nil || entries.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(entries.Content); i += 2 {
+		value := resolveNode(entries.Content[i+1])
+		if value.Kind != yaml.MappingNode {
+			value = &yaml.Node{Kind: yaml.MappingNode}
+		}
+		fn(entries.Content[i].Value, value)
+	}
+}
+
+// scalarValue returns the value of key in a mapping node if it is a scalar, or an empty string.
+func scalarValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
+
+// workflowInputs returns the inputs declared in the configuration of a workflow_call or workflow_dispatch trigger.
+func workflowInputs(config *yaml.Node) []WorkflowInput {
+	inputs := []WorkflowInput{}
+	mappingEntries(config, "inputs", func(name string, value *yaml.Node) {
+		input := WorkflowInput{
+			Name:        name,
+			Type:        scalarValue(value, "type"),
+			Description: scalarValue(value, "description"),
+			Required:    scalarValue(value, "required") == "true",
+		}
+		if def := mappingValue(value, "default"); def != nil {
+			_ = def.Decode(&input.Default)
+		}
+		if options := mappingValue(value, "options"); options != nil && options.Kind == yaml.SequenceNode {
+			for _, option := range options.Content {
+				input.Options = append(input.Options, resolveNode(option).Value)
+			}
+		}
+		inputs = append(inputs, input)
+	})
+	return inputs
+}
+
+// reusableWorkflow returns the interface a workflow_call trigger declares.
+func reusableWorkflow(owner, repo, filePath, name string, config *yaml.Node) ReusableWorkflow {
+	workflow := ReusableWorkflow{
+		Name:    name,
+		File:    path.Base(filePath),
+		Path:    filePath,
+		Uses:    fmt.Sprintf("%s/%s/%s", owner, repo, filePath),
+		Inputs:  workflowInputs(config),
+		Outputs: []WorkflowOutput{},
+		Secrets: []WorkflowSecret{},
+	}
+	mappingEntries(config, "outputs", func(name string, value *yaml.Node) {
+		workflow.Outputs = append(workflow.Outputs, WorkflowOutput{
+			Name:        name,
+			Description: scalarValue(value, "description"),
+			Value:       scalarValue(value, "value"),
+		})
+	})
+	mappingEntries(config, "secrets", func(name string, value *yaml.Node) {
+		workflow.Secrets = append(workflow.Secrets, WorkflowSecret{
+			Name:        name,
+			Description: scalarValue(value, "description"),
+			Required:    scalarValue(value, "required") == "true",
+		})
+	})
+	return workflow
+}
+
+// validateDispatchInputs checks the inputs of a workflow dispatch against those the workflow declares.
+func validateDispatchInputs(declared []WorkflowInput, inputs map[string]any) error {
+	var problems []string
+	known := make(map[string]bool, len(declared))
+	for _, input := range declared {
+		known[input.Name] = true
+		value, ok := inputs[input.Name]
+		if !ok {
+			if input.Required && input.Default == nil {
+				problems = append(problems, fmt.Sprintf("missing required input %q", input.Name))
+			}
+			continue
+		}
+		switch input.Type {
+		case "boolean":
+			if s, isString := value.(string); !isString && value != true && value != false || isString && s != "true" && s != "false" {
+				problems = append(problems, fmt.Sprintf("input %q must be true or false", input.Name))
+			}
+		case "choice":
+			if s, isString := value.(string); !isString || !slices.Contains(input.Options, s) {
+				problems = append(problems, fmt.Sprintf("input %q must be one of %s", input.Name, strings.Join(input.Options, ", ")))
+			}
+		}
+	}
+
+	var unknown []string
+	for name := range inputs {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		problems = append(problems, fmt.Sprintf("unknown input %q", name))
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ListReusableWorkflows creates a tool to list the reusable workflows of a repository and their interfaces.
+func ListReusableWorkflows(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reusable_workflows",
+			mcp.WithDescription(t("TOOL_LIST_REUSABLE_WORKFLOWS_DESCRIPTION", "List the reusable workflows of a repository, the workflow files with a workflow_call trigger, with the inputs, outputs and secrets they declare and the uses: reference to call them with.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REUSABLE_WORKFLOWS_USER_TITLE", "List reusable workflows"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to read the workflows at, defaults to the default branch"),
+			),
+			WithPageTokenPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPageTokenParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if pagination.After != "" {
+				return mcp.NewToolResultError("invalid page_token: it is the token of a cursor, not of a page"), nil
+			}
+			page, perPage := max(pagination.Page, 1), pagination.PerPage
+			if perPage == 0 {
+				perPage = 30
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+
+			files, resp, err := listWorkflowFiles(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get git tree",
+					resp,
+					err,
+				), nil
+			}
+
+			// Pages are of workflow files, each of which is read, whether or not it is reusable
+			var nextPageToken string
+			if end := page * perPage; end < len(files) {
+				nextPageToken = pageToken{Page: page + 1, PerPage: perPage}.encode()
+				files = files[:end]
+			}
+			files = files[min((page-1)*perPage, len(files)):]
+
+			result := ReusableWorkflowsResult{
+				Repository: owner + "/" + repo,
+				Workflows:  []ReusableWorkflow{},
+			}
+			for _, filePath := range files {
+				content, err := getRawFile(ctx, rawClient, owner, repo, filePath, ref)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", filePath, err))
+					continue
+				}
+				name, events, err := parseWorkflowEvents(content)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to parse workflow: %s", filePath, err))
+					continue
+				}
+				if config, ok := events["workflow_call"]; ok {
+					result.Workflows = append(result.Workflows, reusableWorkflow(owner, repo, filePath, name, config))
+				}
+			}
+			return withNextPageToken(MarshalledTextResult(result), nextPageToken), nil
+		}
+}
+
+// CallWorkflow creates a tool to dispatch a workflow with a workflow_dispatch trigger, after checking
+// the inputs against those it declares.
+func CallWorkflow(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("call_workflow",
+			mcp.WithDescription(t("TOOL_CALL_WORKFLOW_DESCRIPTION", "Dispatch a workflow that has a workflow_dispatch trigger. The inputs are checked against those the workflow declares at ref before the run is queued. Reusable workflows with only a workflow_call trigger cannot be dispatched; they run when another workflow calls them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CALL_WORKFLOW_USER_TITLE", "Call workflow"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow",
+				mcp.Required(),
+				mcp.Description("The workflow file name (e.g., deploy.yml) or its path in the repository"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The branch or tag to run the workflow on"),
+			),
+			mcp.WithObject("inputs",
+				mcp.Description("Inputs for the workflow_dispatch trigger"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflow, err := RequiredParam[string](request, "workflow")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var inputs map[string]any
+			if requestInputs, ok := request.GetArguments()["inputs"]; ok {
+				if inputs, ok = requestInputs.(map[string]any); !ok {
+					return mcp.NewToolResultError("inputs must be an object"), nil
+				}
+			}
+
+			filePath := workflow
+			if !strings.Contains(workflow, "/") {
+				filePath = workflowsDir + "/" + workflow
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+
+			content, err := getRawFile(ctx, rawClient, owner, repo, filePath, ref)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read workflow %s at %s: %s", filePath, ref, err)), nil
+			}
+			_, events, err := parseWorkflowEvents(content)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse workflow %s: %s", filePath, err)), nil
+			}
+			config, ok := events["workflow_dispatch"]
+			if !ok {
+				if _, reusable := events["workflow_call"]; reusable {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow %s is a reusable workflow without a workflow_dispatch trigger, it runs when another workflow calls it with jobs.<job_id>.uses", filePath)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("workflow %s does not have a workflow_dispatch trigger", filePath)), nil
+			}
+			if err := validateDispatchInputs(workflowInputs(config), inputs); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid inputs for workflow %s: %s", filePath, err)), nil
+			}
+
+			resp, err := client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, path.Base(filePath), github.CreateWorkflowDispatchEventRequest{
+				Ref:    ref,
+				Inputs: inputs,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to dispatch workflow",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":     "Workflow run has been queued",
+				"workflow":    filePath,
+				"ref":         ref,
+				"inputs":      inputs,
+				"status_code": resp.StatusCode,
+			}), nil
+		}
+}
@@ -0,0 +1,309 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockWorkflowFiles = map[string]string{
+	"build.yml": `name: Build
+on:
+  workflow_call:
+    inputs:
+      go-version:
+        description: Go version to build with
+        type: string
+        default: "1.23"
+      race:
+        type: boolean
+        required: true
+    outputs:
+      artifact:
+        description: Name of the uploaded artifact
+        value: ${{ jobs.build.outputs.artifact }}
+    secrets:
+      token:
+        required: true
+      signing-key:
+  push:
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: go build ./...
+`,
+	"deploy.yml": `name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        type: choice
+        required: true
+        options: [staging, production]
+      dry-run:
+        type: boolean
+        default: true
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: ./deploy.sh
+`,
+	"lint.yml": `on: workflow_call
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`,
+	"broken.yml": "on: [push\n",
+}
+
+// workflowFileHandler serves the files in mockWorkflowFiles by their base name.
+func workflowFileHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		content, ok := mockWorkflowFiles[path.Base(r.URL.Path)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+}
+
+func Test_ListReusableWorkflows(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := ListReusableWorkflows(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_reusable_workflows", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			expectPath(t, "/repos/owner/repo/git/trees/release").andThen(
+				mockResponse(t, http.StatusOK, &github.Tree{
+					Entries: []*github.TreeEntry{
+						{Path: github.Ptr(".github/workflows/broken.yml"), Type: github.Ptr("blob")},
+						{Path: github.Ptr(".github/workflows/build.yml"), Type: github.Ptr("blob")},
+						{Path: github.Ptr(".github/workflows/deploy.yml"), Type: github.Ptr("blob")},
+						{Path: github.Ptr(".github/workflows/lint.yml"), Type: github.Ptr("blob")},
+						{Path: github.Ptr("README.md"), Type: github.Ptr("blob")},
+					},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(raw.GetRawReposContentsByOwnerByRepoBySHAByPath, workflowFileHandler(t)),
+	))
+	rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+	_, handler := ListReusableWorkflows(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+		"ref":   "release",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned ReusableWorkflowsResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+
+	assert.Equal(t, "owner/repo", returned.Repository)
+	require.Len(t, returned.Errors, 1)
+	assert.Contains(t, returned.Errors[0], ".github/workflows/broken.yml: failed to parse workflow")
+
+	require.Len(t, returned.Workflows, 2)
+	build := returned.Workflows[0]
+	assert.Equal(t, "Build", build.Name)
+	assert.Equal(t, "build.yml", build.File)
+	assert.Equal(t, ".github/workflows/build.yml", build.Path)
+	assert.Equal(t, "owner/repo/.github/workflows/build.yml", build.Uses)
+	assert.Equal(t, []WorkflowInput{
+		{Name: "go-version", Type: "string", Description: "Go version to build with", Default: "1.23"},
+		{Name: "race", Type: "boolean", Required: true},
+	}, build.Inputs)
+	assert.Equal(t, []WorkflowOutput{
+		{Name: "artifact", Description: "Name of the uploaded artifact", Value: "${{ jobs.build.outputs.artifact }}"},
+	}, build.Outputs)
+	assert.Equal(t, []WorkflowSecret{{Name: "token", Required: true}, {Name: "signing-key"}}, build.Secrets)
+
+	lint := returned.Workflows[1]
+	assert.Equal(t, "lint.yml", lint.File)
+	assert.Empty(t, lint.Inputs)
+	assert.Empty(t, lint.Outputs)
+	assert.Empty(t, lint.Secrets)
+	assert.Empty(t, nextPageTokenOf(t, result))
+
+	// Pages are of workflow files, whether or not they are reusable
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "owner",
+		"repo":    "repo",
+		"ref":     "release",
+		"perPage": float64(3),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	token := nextPageTokenOf(t, result)
+	assert.Equal(t, pageToken{Page: 2, PerPage: 3}, mustDecodePageToken(t, token))
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ref":        "release",
+		"page_token": token,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	returned = ReusableWorkflowsResult{}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Empty(t, returned.Errors)
+	require.Len(t, returned.Workflows, 1)
+	assert.Equal(t, "lint.yml", returned.Workflows[0].File)
+	assert.Empty(t, nextPageTokenOf(t, result))
+}
+
+func Test_CallWorkflow(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := CallWorkflow(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "call_workflow", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow", "ref"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "dispatches workflow with valid inputs",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"workflow": "deploy.yml",
+				"ref":      "main",
+				"inputs":   map[string]any{"environment": "staging"},
+			},
+		},
+		{
+			name: "accepts workflow paths",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"workflow": ".github/workflows/deploy.yml",
+				"ref":      "main",
+				"inputs":   map[string]any{"environment": "production", "dry-run": false},
+			},
+		},
+		{
+			name: "rejects invalid inputs",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"workflow": "deploy.yml",
+				"ref":      "main",
+				"inputs":   map[string]any{"environment": "qa", "dry-run": "maybe", "region": "eu"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid inputs for workflow .github/workflows/deploy.yml: input "environment" must be one of staging, production; input "dry-run" must be true or false; unknown input "region"`,
+		},
+		{
+			name: "requires required inputs",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"workflow": "deploy.yml",
+				"ref":      "main",
+			},
+			expectError:    true,
+			expectedErrMsg: `missing required input "environment"`,
+		},
+		{
+			name: "reusable workflows cannot be dispatched",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"workflow": "lint.yml",
+				"ref":      "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow .github/workflows/lint.yml is a reusable workflow without a workflow_dispatch trigger",
+		},
+		{
+			name: "workflow not found",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"workflow": "missing.yml",
+				"ref":      "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to read workflow .github/workflows/missing.yml at main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var dispatched *github.CreateWorkflowDispatchEventRequest
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(raw.GetRawReposContentsByOwnerByRepoBySHAByPath, workflowFileHandler(t)),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsWorkflowsDispatchesByOwnerByRepoByWorkflowId,
+					expectPath(t, "/repos/owner/repo/actions/workflows/deploy.yml/dispatches").andThen(
+						func(w http.ResponseWriter, r *http.Request) {
+							dispatched = &github.CreateWorkflowDispatchEventRequest{}
+							require.NoError(t, json.NewDecoder(r.Body).Decode(dispatched))
+							w.WriteHeader(http.StatusNoContent)
+						},
+					),
+				),
+			))
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := CallWorkflow(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Nil(t, dispatched)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Workflow run has been queued", response["message"])
+			assert.Equal(t, ".github/workflows/deploy.yml", response["workflow"])
+
+			require.NotNil(t, dispatched)
+			assert.Equal(t, "main", dispatched.Ref)
+			assert.Equal(t, tc.requestArgs["inputs"], dispatched.Inputs)
+		})
+	}
+}
@@ -0,0 +1,508 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/scim"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	DescriptionSCIMEnterprise = "Enterprise slug. Required on GitHub.com and GHE.com, leave empty on GitHub Enterprise Server where SCIM is configured for the whole instance"
+	DescriptionSCIMUserID     = "SCIM identifier of the provisioned user, as returned by list_scim_provisioned_identities"
+)
+
+// withSCIMUserAttributes adds the parameters describing a SCIM user to a tool.
+func withSCIMUserAttributes() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("user_name",
+			mcp.Required(),
+			mcp.Description("Username for the user, usually their identity provider login or email address"),
+		)(tool)
+		mcp.WithArray("emails",
+			mcp.Required(),
+			mcp.Description("Email addresses of the user"),
+			mcp.Items(
+				map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"value"},
+					"properties": map[string]any{
+						"value": map[string]any{
+							"type":        "string",
+							"description": "email address",
+						},
+						"type": map[string]any{
+							"type":        "string",
+							"description": "type of email address, e.g. work",
+						},
+						"primary": map[string]any{
+							"type":        "boolean",
+							"description": "whether this is the user's primary email address",
+						},
+					},
+				}),
+		)(tool)
+		mcp.WithString("given_name",
+			mcp.Required(),
+			mcp.Description("First name of the user"),
+		)(tool)
+		mcp.WithString("family_name",
+			mcp.Required(),
+			mcp.Description("Last name of the user"),
+		)(tool)
+		mcp.WithString("display_name",
+			mcp.Description("Name of the user suitable for display"),
+		)(tool)
+		mcp.WithString("external_id",
+			mcp.Description("Identifier of the user in the identity provider"),
+		)(tool)
+		mcp.WithBoolean("active",
+			mcp.Description("Whether the user is active. Defaults to true"),
+		)(tool)
+		mcp.WithArray("schemas",
+			mcp.Description("SCIM schemas of the user. Defaults to the core user schema"),
+			mcp.Items(
+				map[string]any{
+					"type": "string",
+				},
+			),
+		)(tool)
+	}
+}
+
+// scimUserFromRequest builds SCIM user attributes from the parameters added by withSCIMUserAttributes.
+func scimUserFromRequest(request mcp.CallToolRequest) (*github.SCIMUserAttributes, error) {
+	userName, err := RequiredParam[string](request, "user_name")
+	if err != nil {
+		return nil, err
+	}
+	givenName, err := RequiredParam[string](request, "given_name")
+	if err != nil {
+		return nil, err
+	}
+	familyName, err := RequiredParam[string](request, "family_name")
+	if err != nil {
+		return nil, err
+	}
+	displayName, err := OptionalParam[string](request, "display_name")
+	if err != nil {
+		return nil, err
+	}
+	externalID, err := OptionalParam[string](request, "external_id")
+	if err != nil {
+		return nil, err
+	}
+	active, hasActive, err := OptionalParamOK[bool](request, "active")
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := OptionalStringArrayParam(request, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	if len(schemas) == 0 {
+		schemas = []string{scim.UserSchema}
+	}
+
+	emailsObj, ok := request.GetArguments()["emails"].([]any)
+	if !ok || len(emailsObj) == 0 {
+		return nil, errors.New("emails must be a non-empty array of objects with value, type and primary")
+	}
+	emails := make([]*github.SCIMUserEmail, 0, len(emailsObj))
+	for _, e := range emailsObj {
+		emailMap, ok := e.(map[string]any)
+		if !ok {
+			return nil, errors.New("each email must be an object with value, type and primary")
+		}
+		value, ok := emailMap["value"].(string)
+		if !ok || value == "" {
+			return nil, errors.New("each email must have a value")
+		}
+		email := &github.SCIMUserEmail{Value: value}
+		if emailType, ok := emailMap["type"].(string); ok && emailType != "" {
+			email.Type = github.Ptr(emailType)
+		}
+		if primary, ok := emailMap["primary"].(bool); ok {
+			email.Primary = github.Ptr(primary)
+		}
+		emails = append(emails, email)
+	}
+
+	user := &github.SCIMUserAttributes{
+		UserName: userName,
+		Name: github.SCIMUserName{
+			GivenName:  givenName,
+			FamilyName: familyName,
+		},
+		Emails:  emails,
+		Schemas: schemas,
+		Active:  github.Ptr(true),
+	}
+	if displayName != "" {
+		user.DisplayName = github.Ptr(displayName)
+	}
+	if externalID != "" {
+		user.ExternalID = github.Ptr(externalID)
+	}
+	if hasActive {
+		user.Active = github.Ptr(active)
+	}
+	return user, nil
+}
+
+// ListSCIMProvisionedIdentities creates a tool to list the users provisioned with SCIM.
+func ListSCIMProvisionedIdentities(getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_scim_provisioned_identities",
+			mcp.WithDescription(t("TOOL_LIST_SCIM_PROVISIONED_IDENTITIES_DESCRIPTION", "List the users provisioned in an enterprise with SCIM, optionally filtered with a SCIM filter expression.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SCIM_PROVISIONED_IDENTITIES_USER_TITLE", "List SCIM provisioned identities"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Description(DescriptionSCIMEnterprise),
+			),
+			mcp.WithString("filter",
+				mcp.Description(`SCIM filter expression, e.g. userName eq "octocat" or externalId eq "9138790-10932-109120392-12321"`),
+			),
+			mcp.WithNumber("start_index",
+				mcp.Description("Deprecated, use page_token instead. 1-based index of the first result to return (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("count",
+				mcp.Description("Number of results to return (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			withPageToken(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startIndex, err := OptionalIntParamWithDefault(request, "start_index", 1)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			count, err := OptionalIntParamWithDefault(request, "count", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// The page of a SCIM list is the index of its first result
+			token, err := optionalPageToken(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if token != nil {
+				if token.Page == 0 {
+					return mcp.NewToolResultError("invalid page_token: it is the token of a cursor, not of a start index"), nil
+				}
+				startIndex = token.Page
+				if token.PerPage > 0 {
+					count = token.PerPage
+				}
+			}
+
+			client, err := getSCIMClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub SCIM client: %w", err)
+			}
+
+			identities, resp, err := client.ListProvisionedIdentities(ctx, enterprise, &scim.ListOptions{
+				StartIndex: startIndex,
+				Count:      count,
+				Filter:     filter,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list SCIM provisioned identities", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			var nextPageToken string
+			if next := startIndex + len(identities.Resources); len(identities.Resources) > 0 && next <= identities.GetTotalResults() {
+				nextPageToken = pageToken{Page: next, PerPage: count}.encode()
+			}
+			return withNextPageToken(MarshalledTextResult(identities), nextPageToken), nil
+		}
+}
+
+// GetSCIMProvisionedIdentity creates a tool to get a user provisioned with SCIM.
+func GetSCIMProvisionedIdentity(getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_scim_provisioned_identity",
+			mcp.WithDescription(t("TOOL_GET_SCIM_PROVISIONED_IDENTITY_DESCRIPTION", "Get a user provisioned in an enterprise with SCIM.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SCIM_PROVISIONED_IDENTITY_USER_TITLE", "Get SCIM provisioned identity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Description(DescriptionSCIMEnterprise),
+			),
+			mcp.WithString("scim_user_id",
+				mcp.Required(),
+				mcp.Description(DescriptionSCIMUserID),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scimUserID, err := RequiredParam[string](request, "scim_user_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getSCIMClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub SCIM client: %w", err)
+			}
+
+			user, resp, err := client.GetProvisionedIdentity(ctx, enterprise, scimUserID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get SCIM provisioned identity", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(user), nil
+		}
+}
+
+// ProvisionSCIMUser creates a tool to provision a new user with SCIM.
+func ProvisionSCIMUser(getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("provision_scim_user",
+			mcp.WithDescription(t("TOOL_PROVISION_SCIM_USER_DESCRIPTION", "Provision a new user in an enterprise with SCIM.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PROVISION_SCIM_USER_USER_TITLE", "Provision SCIM user"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Description(DescriptionSCIMEnterprise),
+			),
+			withSCIMUserAttributes(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			user, err := scimUserFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getSCIMClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub SCIM client: %w", err)
+			}
+
+			created, resp, err := client.ProvisionUser(ctx, enterprise, user)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to provision SCIM user", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(created), nil
+		}
+}
+
+// UpdateSCIMUserAttributes creates a tool to update individual attributes of a SCIM user.
+func UpdateSCIMUserAttributes(getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_scim_user_attributes",
+			mcp.WithDescription(t("TOOL_UPDATE_SCIM_USER_ATTRIBUTES_DESCRIPTION", "Update individual attributes of a user provisioned with SCIM using SCIM PATCH operations. To suspend a user, replace the active attribute with false.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_SCIM_USER_ATTRIBUTES_USER_TITLE", "Update SCIM user attributes"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Description(DescriptionSCIMEnterprise),
+			),
+			mcp.WithString("scim_user_id",
+				mcp.Required(),
+				mcp.Description(DescriptionSCIMUserID),
+			),
+			mcp.WithArray("operations",
+				mcp.Required(),
+				mcp.Description("SCIM PATCH operations to apply, in order"),
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"op"},
+						"properties": map[string]any{
+							"op": map[string]any{
+								"type":        "string",
+								"enum":        []string{"add", "remove", "replace"},
+								"description": "operation to perform",
+							},
+							"path": map[string]any{
+								"type":        "string",
+								"description": "attribute path, e.g. active or emails[type eq \"work\"].value",
+							},
+							"value": map[string]any{
+								"description": "new value of the attribute, not used for remove",
+							},
+						},
+					}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scimUserID, err := RequiredParam[string](request, "scim_user_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			operationsObj, ok := request.GetArguments()["operations"].([]any)
+			if !ok || len(operationsObj) == 0 {
+				return mcp.NewToolResultError("operations must be a non-empty array of objects with op, path and value"), nil
+			}
+			operations := make([]github.UpdateAttributeForSCIMUserOperations, 0, len(operationsObj))
+			for _, o := range operationsObj {
+				operationMap, ok := o.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("each operation must be an object with op, path and value"), nil
+				}
+				op, _ := operationMap["op"].(string)
+				switch op {
+				case "add", "remove", "replace":
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("invalid operation %q, must be add, remove or replace", op)), nil
+				}
+				operation := github.UpdateAttributeForSCIMUserOperations{Op: op}
+				if path, ok := operationMap["path"].(string); ok && path != "" {
+					operation.Path = github.Ptr(path)
+				}
+				if value, ok := operationMap["value"]; ok {
+					raw, err := json.Marshal(value)
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("invalid value for %s operation: %s", op, err)), nil
+					}
+					operation.Value = raw
+				}
+				operations = append(operations, operation)
+			}
+
+			client, err := getSCIMClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub SCIM client: %w", err)
+			}
+
+			updated, resp, err := client.UpdateUserAttributes(ctx, enterprise, scimUserID, &scim.PatchOptions{
+				Schemas:    []string{scim.PatchOpSchema},
+				Operations: operations,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update SCIM user attributes", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(updated), nil
+		}
+}
+
+// ReplaceSCIMUser creates a tool to replace all attributes of a SCIM user.
+func ReplaceSCIMUser(getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("replace_scim_user",
+			mcp.WithDescription(t("TOOL_REPLACE_SCIM_USER_DESCRIPTION", "Replace all attributes of a user provisioned with SCIM. Attributes that are not provided are cleared, use update_scim_user_attributes to change individual attributes.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPLACE_SCIM_USER_USER_TITLE", "Replace SCIM user"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Description(DescriptionSCIMEnterprise),
+			),
+			mcp.WithString("scim_user_id",
+				mcp.Required(),
+				mcp.Description(DescriptionSCIMUserID),
+			),
+			withSCIMUserAttributes(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scimUserID, err := RequiredParam[string](request, "scim_user_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			user, err := scimUserFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getSCIMClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub SCIM client: %w", err)
+			}
+
+			replaced, resp, err := client.ReplaceUser(ctx, enterprise, scimUserID, user)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to replace SCIM user", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(replaced), nil
+		}
+}
+
+// DeleteSCIMUser creates a tool to permanently delete a SCIM user.
+func DeleteSCIMUser(getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_scim_user",
+			mcp.WithDescription(t("TOOL_DELETE_SCIM_USER_DESCRIPTION", "Permanently delete a user provisioned with SCIM. This cannot be undone, to suspend a user instead set active to false with update_scim_user_attributes.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_SCIM_USER_USER_TITLE", "Delete SCIM user"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Description(DescriptionSCIMEnterprise),
+			),
+			mcp.WithString("scim_user_id",
+				mcp.Required(),
+				mcp.Description(DescriptionSCIMUserID),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scimUserID, err := RequiredParam[string](request, "scim_user_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getSCIMClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub SCIM client: %w", err)
+			}
+
+			resp, err := client.DeleteUser(ctx, enterprise, scimUserID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete SCIM user", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":      fmt.Sprintf("SCIM user %s has been deleted", scimUserID),
+				"scim_user_id": scimUserID,
+				"status_code":  resp.StatusCode,
+			}), nil
+		}
+}
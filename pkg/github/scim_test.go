@@ -0,0 +1,427 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/scim"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var scimBaseURL, _ = url.Parse("https://scim.example.com/")
+
+func newMockSCIMClient(httpClient *http.Client) *scim.Client {
+	return scim.NewClient(github.NewClient(httpClient), scimBaseURL)
+}
+
+func Test_ListSCIMProvisionedIdentities(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListSCIMProvisionedIdentities(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_scim_provisioned_identities", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.Contains(t, tool.InputSchema.Properties, "start_index")
+	assert.Contains(t, tool.InputSchema.Properties, "count")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockIdentities := &github.SCIMProvisionedIdentities{
+		TotalResults: github.Ptr(1),
+		Resources: []*github.SCIMUserAttributes{
+			{ID: github.Ptr("7fce0092"), UserName: "octocat@example.com"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedNext   string
+	}{
+		{
+			name: "list with filter and pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					scim.GetSCIMEnterprisesUsersByEnterprise,
+					expect(t, expectations{
+						path: "/enterprises/octo-corp/Users",
+						queryParams: map[string]string{
+							"startIndex": "31",
+							"count":      "30",
+							"filter":     `userName eq "octocat@example.com"`,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockIdentities),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"enterprise":  "octo-corp",
+				"filter":      `userName eq "octocat@example.com"`,
+				"start_index": float64(31),
+			},
+		},
+		{
+			name: "enterprise server",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					scim.GetSCIMUsers,
+					expectQueryParams(t, map[string]string{"startIndex": "1", "count": "30"}).andThen(
+						mockResponse(t, http.StatusOK, mockIdentities),
+					),
+				),
+			),
+			requestArgs: map[string]any{},
+		},
+		{
+			name: "page token",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					scim.GetSCIMUsers,
+					expectQueryParams(t, map[string]string{"startIndex": "11", "count": "10"}).andThen(
+						mockResponse(t, http.StatusOK, &github.SCIMProvisionedIdentities{
+							TotalResults: github.Ptr(25),
+							Resources:    mockIdentities.Resources,
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"page_token": pageToken{Page: 11, PerPage: 10}.encode(),
+			},
+			expectedNext: pageToken{Page: 12, PerPage: 10}.encode(),
+		},
+		{
+			name: "scim not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					scim.GetSCIMEnterprisesUsersByEnterprise,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"enterprise": "octo-corp",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list SCIM provisioned identities",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListSCIMProvisionedIdentities(stubGetSCIMClientFn(newMockSCIMClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.SCIMProvisionedIdentities
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Resources, 1)
+			assert.Equal(t, "octocat@example.com", response.Resources[0].UserName)
+			assert.Equal(t, tc.expectedNext, nextPageTokenOf(t, result))
+		})
+	}
+}
+
+func Test_GetSCIMProvisionedIdentity(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetSCIMProvisionedIdentity(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_scim_provisioned_identity", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"scim_user_id"})
+
+	client := newMockSCIMClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			scim.GetSCIMEnterprisesUsersByEnterpriseByScimUserID,
+			expectPath(t, "/enterprises/octo-corp/Users/7fce0092").andThen(
+				mockResponse(t, http.StatusOK, &github.SCIMUserAttributes{ID: github.Ptr("7fce0092"), UserName: "octocat@example.com", Active: github.Ptr(true)}),
+			),
+		),
+	))
+	_, handler := GetSCIMProvisionedIdentity(stubGetSCIMClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"enterprise":   "octo-corp",
+		"scim_user_id": "7fce0092",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var user github.SCIMUserAttributes
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &user))
+	assert.Equal(t, "7fce0092", user.GetID())
+	assert.True(t, user.GetActive())
+}
+
+func Test_ProvisionSCIMUser(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ProvisionSCIMUser(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "provision_scim_user", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"user_name", "emails", "given_name", "family_name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "provisions user with default schema",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					scim.PostSCIMEnterprisesUsersByEnterprise,
+					expect(t, expectations{
+						path: "/enterprises/octo-corp/Users",
+						requestBody: map[string]any{
+							"schemas":  []any{scim.UserSchema},
+							"userName": "mona@example.com",
+							"name": map[string]any{
+								"givenName":  "Mona",
+								"familyName": "Octocat",
+							},
+							"emails": []any{
+								map[string]any{"value": "mona@example.com", "type": "work", "primary": true},
+							},
+							"externalId": "a7d0f98382",
+							"active":     true,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.SCIMUserAttributes{ID: github.Ptr("7fce0092"), UserName: "mona@example.com"}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"enterprise":  "octo-corp",
+				"user_name":   "mona@example.com",
+				"given_name":  "Mona",
+				"family_name": "Octocat",
+				"external_id": "a7d0f98382",
+				"emails": []any{
+					map[string]any{"value": "mona@example.com", "type": "work", "primary": true},
+				},
+			},
+		},
+		{
+			name:         "missing emails",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"enterprise":  "octo-corp",
+				"user_name":   "mona@example.com",
+				"given_name":  "Mona",
+				"family_name": "Octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "emails must be a non-empty array",
+		},
+		{
+			name:         "email without value",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"enterprise":  "octo-corp",
+				"user_name":   "mona@example.com",
+				"given_name":  "Mona",
+				"family_name": "Octocat",
+				"emails":      []any{map[string]any{"type": "work"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "each email must have a value",
+		},
+		{
+			name: "user already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					scim.PostSCIMEnterprisesUsersByEnterprise,
+					mockResponse(t, http.StatusConflict, `{"message": "User already exists"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"enterprise":  "octo-corp",
+				"user_name":   "mona@example.com",
+				"given_name":  "Mona",
+				"family_name": "Octocat",
+				"emails":      []any{map[string]any{"value": "mona@example.com"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to provision SCIM user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ProvisionSCIMUser(stubGetSCIMClientFn(newMockSCIMClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var user github.SCIMUserAttributes
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &user))
+			assert.Equal(t, "7fce0092", user.GetID())
+		})
+	}
+}
+
+func Test_UpdateSCIMUserAttributes(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UpdateSCIMUserAttributes(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_scim_user_attributes", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"scim_user_id", "operations"})
+
+	t.Run("suspends user", func(t *testing.T) {
+		client := newMockSCIMClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				scim.PatchSCIMEnterprisesUsersByEnterpriseByScimUserID,
+				expect(t, expectations{
+					path: "/enterprises/octo-corp/Users/7fce0092",
+					requestBody: map[string]any{
+						"schemas": []any{scim.PatchOpSchema},
+						"Operations": []any{
+							map[string]any{"op": "replace", "path": "active", "value": false},
+						},
+					},
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.SCIMUserAttributes{ID: github.Ptr("7fce0092"), Active: github.Ptr(false)}),
+				),
+			),
+		))
+		_, handler := UpdateSCIMUserAttributes(stubGetSCIMClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"enterprise":   "octo-corp",
+			"scim_user_id": "7fce0092",
+			"operations": []any{
+				map[string]any{"op": "replace", "path": "active", "value": false},
+			},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `"active":false`)
+	})
+
+	t.Run("rejects unknown operation", func(t *testing.T) {
+		_, handler := UpdateSCIMUserAttributes(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"scim_user_id": "7fce0092",
+			"operations": []any{
+				map[string]any{"op": "move", "path": "active"},
+			},
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, `invalid operation "move"`)
+	})
+}
+
+func Test_ReplaceSCIMUser(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ReplaceSCIMUser(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "replace_scim_user", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"scim_user_id", "user_name", "emails", "given_name", "family_name"})
+
+	client := newMockSCIMClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			scim.PutSCIMEnterprisesUsersByEnterpriseByScimUserID,
+			expect(t, expectations{
+				path: "/enterprises/octo-corp/Users/7fce0092",
+				requestBody: map[string]any{
+					"schemas":     []any{scim.UserSchema},
+					"userName":    "mona@example.com",
+					"displayName": "Mona Lisa Octocat",
+					"name": map[string]any{
+						"givenName":  "Mona",
+						"familyName": "Octocat",
+					},
+					"emails": []any{
+						map[string]any{"value": "mona@example.com"},
+					},
+					"active": false,
+				},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.SCIMUserAttributes{ID: github.Ptr("7fce0092"), UserName: "mona@example.com"}),
+			),
+		),
+	))
+	_, handler := ReplaceSCIMUser(stubGetSCIMClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"enterprise":   "octo-corp",
+		"scim_user_id": "7fce0092",
+		"user_name":    "mona@example.com",
+		"display_name": "Mona Lisa Octocat",
+		"given_name":   "Mona",
+		"family_name":  "Octocat",
+		"active":       false,
+		"emails":       []any{map[string]any{"value": "mona@example.com"}},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+}
+
+func Test_DeleteSCIMUser(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := DeleteSCIMUser(stubGetSCIMClientFn(newMockSCIMClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_scim_user", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"scim_user_id"})
+
+	client := newMockSCIMClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			scim.DeleteSCIMEnterprisesUsersByEnterpriseByScimUserID,
+			expectPath(t, "/enterprises/octo-corp/Users/7fce0092").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteSCIMUser(stubGetSCIMClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"enterprise":   "octo-corp",
+		"scim_user_id": "7fce0092",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+}
@@ -0,0 +1,374 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultOrgSearchMaxResults = 100
+	maxOrgSearchMaxResults     = 500
+	defaultOrgSearchPerRepo    = 3
+	maxOrgSearchPerRepo        = 20
+
+	// archivedReposTTL is how long the archived repositories of an organization are remembered.
+	archivedReposTTL = 10 * time.Minute
+)
+
+// archivedRepos is the cache of the archived repositories of organizations shared by search_across_org calls.
+var archivedRepos = newArchivedReposCache(archivedReposTTL)
+
+// OrgSearchResult is the output type of the search_across_org tool.
+type OrgSearchResult struct {
+	Query string `json:"query"`
+	// TotalCount is the number of results GitHub found, of which Scanned were grouped.
+	TotalCount int `json:"total_count"`
+	Scanned    int `json:"scanned"`
+	// ExcludedArchived is the number of scanned results in archived repositories that were left out.
+	ExcludedArchived int                   `json:"excluded_archived,omitempty"`
+	Repositories     []OrgSearchRepository `json:"repositories"`
+	Warnings         []string              `json:"warnings,omitempty"`
+}
+
+// OrgSearchRepository groups the scanned results of a repository.
+type OrgSearchRepository struct {
+	Repository string           `json:"repository"`
+	Hits       int              `json:"hits"`
+	Matches    []OrgSearchMatch `json:"matches"`
+}
+
+// OrgSearchMatch is a code search result, with a path, or an issues search result, with a number.
+type OrgSearchMatch struct {
+	Path          string `json:"path,omitempty"`
+	Number        int    `json:"number,omitempty"`
+	Title         string `json:"title,omitempty"`
+	State         string `json:"state,omitempty"`
+	IsPullRequest bool   `json:"is_pull_request,omitempty"`
+	HTMLURL       string `json:"html_url"`
+}
+
+// orgSearchHit is a search result before it is grouped by repository.
+type orgSearchHit struct {
+	repository string
+	match      OrgSearchMatch
+}
+
+// SearchAcrossOrg creates a tool to search the code or issues of an organization, grouped by repository.
+func SearchAcrossOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_across_org",
+			mcp.WithDescription(t("TOOL_SEARCH_ACROSS_ORG_DESCRIPTION", "Search the code or the issues and pull requests of all repositories of an organization, and group the results by repository. Returns the number of hits per repository, most hits first, with the top matches of each, for an overview of where something is used or discussed across an organization in one call.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_ACROSS_ORG_USER_TITLE", "Search across organization"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization to search in"),
+			),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query, using the syntax of search_code or search_issues. It is limited to the organization, so do not add an org: qualifier"),
+			),
+			mcp.WithString("type",
+				mcp.Description("What to search"),
+				mcp.Enum("code", "issues"),
+				mcp.DefaultString("code"),
+			),
+			mcp.WithNumber("per_repo",
+				mcp.Description(fmt.Sprintf("Number of matches to return per repository (default %d, max %d)", defaultOrgSearchPerRepo, maxOrgSearchPerRepo)),
+				mcp.Min(1),
+				mcp.Max(maxOrgSearchPerRepo),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description(fmt.Sprintf("Number of search results to scan and group (default %d, max %d). Each 100 results is one search request", defaultOrgSearchMaxResults, maxOrgSearchMaxResults)),
+				mcp.Min(1),
+				mcp.Max(maxOrgSearchMaxResults),
+			),
+			mcp.WithBoolean("exclude_archived",
+				mcp.Description("Leave out results in archived repositories (default true)"),
+				mcp.DefaultBool(true),
+			),
+			withPageToken(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			searchType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if searchType == "" {
+				searchType = "code"
+			}
+			if searchType != "code" && searchType != "issues" {
+				return mcp.NewToolResultError("type must be code or issues"), nil
+			}
+			perRepo, err := OptionalIntParamWithDefault(request, "per_repo", defaultOrgSearchPerRepo)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if perRepo < 1 || perRepo > maxOrgSearchPerRepo {
+				return mcp.NewToolResultError(fmt.Sprintf("per_repo must be between 1 and %d", maxOrgSearchPerRepo)), nil
+			}
+			maxResults, err := OptionalIntParamWithDefault(request, "max_results", defaultOrgSearchMaxResults)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxResults < 1 || maxResults > maxOrgSearchMaxResults {
+				return mcp.NewToolResultError(fmt.Sprintf("max_results must be between 1 and %d", maxOrgSearchMaxResults)), nil
+			}
+			excludeArchived, ok, err := OptionalParamOK[bool](request, "exclude_archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				excludeArchived = true
+			}
+			token, err := optionalPageToken(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// The next page continues the scan where the previous one stopped, in the middle of a
+			// search page if max_results was reached there
+			start := pageToken{PerPage: min(maxResults, 100)}
+			if token != nil {
+				if token.Page == 0 {
+					return mcp.NewToolResultError("invalid page_token: it is the token of a cursor, not of a page"), nil
+				}
+				start.Page, start.Skip = token.Page, token.Skip
+				if token.PerPage > 0 {
+					start.PerPage = token.PerPage
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := OrgSearchResult{
+				Query:        fmt.Sprintf("%s org:%s", query, org),
+				Repositories: []OrgSearchRepository{},
+			}
+
+			var archived map[string]bool
+			if excludeArchived {
+				archived, err = archivedRepos.get(ctx, client, org)
+				if err != nil {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("archived repositories were not excluded: %v", err))
+				}
+			}
+
+			var hits []orgSearchHit
+			var next pageToken
+			opts := &github.SearchOptions{ListOptions: github.ListOptions{Page: start.Page, PerPage: start.PerPage}}
+			skip := start.Skip
+			for len(hits) < maxResults {
+				var page []orgSearchHit
+				var resp *github.Response
+				if searchType == "code" {
+					page, resp, err = searchOrgCode(ctx, client, result.Query, opts, &result.TotalCount)
+				} else {
+					page, resp, err = searchOrgIssues(ctx, client, result.Query, opts, &result.TotalCount)
+				}
+				if err != nil {
+					if len(hits) == 0 {
+						return orgSearchErrorResponse(ctx, searchType, resp, err), nil
+					}
+					// Keep what was found so far rather than failing the whole search
+					result.Warnings = append(result.Warnings, fmt.Sprintf("stopped after %d results: %s", len(hits), orgSearchErrorMessage(searchType, err)))
+					next = pageToken{Page: max(opts.Page, 1), PerPage: opts.PerPage, Skip: skip}
+					break
+				}
+				found := page[min(skip, len(page)):]
+				if room := maxResults - len(hits); len(found) > room {
+					hits = append(hits, found[:room]...)
+					next = pageToken{Page: max(opts.Page, 1), PerPage: opts.PerPage, Skip: skip + room}
+					break
+				}
+				hits = append(hits, found...)
+				skip = 0
+				if resp.NextPage == 0 || len(page) == 0 {
+					next = pageToken{}
+					break
+				}
+				opts.Page = resp.NextPage
+				next = pageToken{Page: resp.NextPage, PerPage: opts.PerPage}
+			}
+
+			result.Scanned = len(hits)
+			byRepository := make(map[string]*OrgSearchRepository)
+			for _, hit := range hits {
+				if archived[strings.ToLower(hit.repository)] {
+					result.ExcludedArchived++
+					continue
+				}
+				repository, ok := byRepository[hit.repository]
+				if !ok {
+					repository = &OrgSearchRepository{Repository: hit.repository, Matches: []OrgSearchMatch{}}
+					byRepository[hit.repository] = repository
+				}
+				repository.Hits++
+				if len(repository.Matches) < perRepo {
+					repository.Matches = append(repository.Matches, hit.match)
+				}
+			}
+			for _, repository := range byRepository {
+				result.Repositories = append(result.Repositories, *repository)
+			}
+			sort.Slice(result.Repositories, func(i, j int) bool {
+				if result.Repositories[i].Hits != result.Repositories[j].Hits {
+					return result.Repositories[i].Hits > result.Repositories[j].Hits
+				}
+				return result.Repositories[i].Repository < result.Repositories[j].Repository
+			})
+
+			var nextPageToken string
+			if next.Page != 0 {
+				nextPageToken = next.encode()
+			}
+			return withNextPageToken(MarshalledTextResult(result), nextPageToken), nil
+		}
+}
+
+func searchOrgCode(ctx context.Context, client *github.Client, query string, opts *github.SearchOptions, total *int) ([]orgSearchHit, *github.Response, error) {
+	found, resp, err := client.Search.Code(ctx, query, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	*total = found.GetTotal()
+	hits := make([]orgSearchHit, 0, len(found.CodeResults))
+	for _, code := range found.CodeResults {
+		hits = append(hits, orgSearchHit{
+			repository: code.GetRepository().GetFullName(),
+			match:      OrgSearchMatch{Path: code.GetPath(), HTMLURL: code.GetHTMLURL()},
+		})
+	}
+	return hits, resp, nil
+}
+
+func searchOrgIssues(ctx context.Context, client *github.Client, query string, opts *github.SearchOptions, total *int) ([]orgSearchHit, *github.Response, error) {
+	found, resp, err := client.Search.Issues(ctx, query, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	*total = found.GetTotal()
+	hits := make([]orgSearchHit, 0, len(found.Issues))
+	for _, issue := range found.Issues {
+		// Search results only carry the API URL of the repository, which ends with its full name
+		repository := strings.TrimPrefix(issue.GetRepositoryURL(), client.BaseURL.String()+"repos/")
+		hits = append(hits, orgSearchHit{
+			repository: repository,
+			match: OrgSearchMatch{
+				Number:        issue.GetNumber(),
+				Title:         issue.GetTitle(),
+				State:         issue.GetState(),
+				IsPullRequest: issue.IsPullRequest(),
+				HTMLURL:       issue.GetHTMLURL(),
+			},
+		})
+	}
+	return hits, resp, nil
+}
+
+// orgSearchErrorMessage describes a failed search, with when the rate limit resets if it was exceeded.
+// Code search has a much lower rate limit than other searches, so it is reported separately.
+func orgSearchErrorMessage(searchType string, err error) string {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		limit := "search"
+		if searchType == "code" {
+			limit = "code search"
+		}
+		reset := rateLimitErr.Rate.Reset.Time
+		return fmt.Sprintf("the GitHub %s rate limit of %d requests per minute was exceeded, it resets at %s (in %d seconds)",
+			limit, rateLimitErr.Rate.Limit, reset.UTC().Format(time.RFC3339), max(0, int(time.Until(reset).Seconds()+0.5)))
+	}
+	return fmt.Sprintf("failed to search %s: %v", searchType, err)
+}
+
+func orgSearchErrorResponse(ctx context.Context, searchType string, resp *github.Response, err error) *mcp.CallToolResult {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, fmt.Sprintf("failed to search %s", searchType), resp, err)
+		return mcp.NewToolResultError(orgSearchErrorMessage(searchType, err))
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to search %s", searchType), resp, err)
+}
+
+// archivedReposCache remembers the archived repositories of organizations, so that repeated searches
+// across an organization do not list them each time. It is safe for concurrent use.
+type archivedReposCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu   sync.Mutex
+	orgs map[string]archivedReposEntry
+}
+
+type archivedReposEntry struct {
+	repos   map[string]bool
+	expires time.Time
+}
+
+func newArchivedReposCache(ttl time.Duration) *archivedReposCache {
+	return &archivedReposCache{ttl: ttl, now: time.Now, orgs: make(map[string]archivedReposEntry)}
+}
+
+// maxArchivedRepoPages bounds how many pages of archived repositories are listed per organization.
+const maxArchivedRepoPages = 10
+
+// get returns the lowercase full names of the archived repositories of org, from the cache if they
+// were listed recently.
+func (c *archivedReposCache) get(ctx context.Context, client *github.Client, org string) (map[string]bool, error) {
+	key := strings.ToLower(org)
+	c.mu.Lock()
+	entry, ok := c.orgs[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.repos, nil
+	}
+
+	repos := make(map[string]bool)
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for page := 0; page < maxArchivedRepoPages; page++ {
+		found, resp, err := client.Search.Repositories(ctx, fmt.Sprintf("org:%s archived:true", org), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list archived repositories: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, repo := range found.Repositories {
+			repos[strings.ToLower(repo.GetFullName())] = true
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	c.mu.Lock()
+	c.orgs[key] = archivedReposEntry{repos: repos, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return repos, nil
+}
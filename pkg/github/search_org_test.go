@@ -0,0 +1,314 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SearchAcrossOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchAcrossOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_across_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "per_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "max_results")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude_archived")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "query"})
+
+	codeResult := func(repo, path string) *github.CodeResult {
+		return &github.CodeResult{
+			Path:       github.Ptr(path),
+			HTMLURL:    github.Ptr("https://github.com/" + repo + "/blob/main/" + path),
+			Repository: &github.Repository{FullName: github.Ptr(repo)},
+		}
+	}
+	archivedSearch := mock.WithRequestMatchHandler(
+		mock.GetSearchRepositories,
+		expectQueryParams(t, map[string]string{
+			"q":        "org:octo-org archived:true",
+			"per_page": "100",
+		}).andThen(
+			mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+				Total:        github.Ptr(1),
+				Repositories: []*github.Repository{{FullName: github.Ptr("octo-org/Legacy")}},
+			}),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       OrgSearchResult
+	}{
+		{
+			name: "groups code results by repository and excludes archived repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				archivedSearch,
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCode,
+					expectQueryParams(t, map[string]string{
+						"q":        "uploadArtifact org:octo-org",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.CodeSearchResult{
+							Total: github.Ptr(5),
+							CodeResults: []*github.CodeResult{
+								codeResult("octo-org/web", "src/upload.ts"),
+								codeResult("octo-org/cli", "cmd/upload.go"),
+								codeResult("octo-org/web", "src/retry.ts"),
+								codeResult("octo-org/legacy", "upload.js"),
+								codeResult("octo-org/web", "src/queue.ts"),
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "octo-org",
+				"query":    "uploadArtifact",
+				"per_repo": float64(2),
+			},
+			expected: OrgSearchResult{
+				Query:            "uploadArtifact org:octo-org",
+				TotalCount:       5,
+				Scanned:          5,
+				ExcludedArchived: 1,
+				Repositories: []OrgSearchRepository{
+					{
+						Repository: "octo-org/web",
+						Hits:       3,
+						Matches: []OrgSearchMatch{
+							{Path: "src/upload.ts", HTMLURL: "https://github.com/octo-org/web/blob/main/src/upload.ts"},
+							{Path: "src/retry.ts", HTMLURL: "https://github.com/octo-org/web/blob/main/src/retry.ts"},
+						},
+					},
+					{
+						Repository: "octo-org/cli",
+						Hits:       1,
+						Matches: []OrgSearchMatch{
+							{Path: "cmd/upload.go", HTMLURL: "https://github.com/octo-org/cli/blob/main/cmd/upload.go"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "groups issues by repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "is:open flaky org:octo-org",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+							Total: github.Ptr(2),
+							Issues: []*github.Issue{
+								{
+									Number:        github.Ptr(7),
+									Title:         github.Ptr("Flaky upload test"),
+									State:         github.Ptr("open"),
+									HTMLURL:       github.Ptr("https://github.com/octo-org/web/issues/7"),
+									RepositoryURL: github.Ptr("https://api.github.com/repos/octo-org/web"),
+								},
+								{
+									Number:           github.Ptr(12),
+									Title:            github.Ptr("Retry flaky uploads"),
+									State:            github.Ptr("open"),
+									HTMLURL:          github.Ptr("https://github.com/octo-org/web/pull/12"),
+									RepositoryURL:    github.Ptr("https://api.github.com/repos/octo-org/web"),
+									PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/octo-org/web/pulls/12")},
+								},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":              "octo-org",
+				"query":            "is:open flaky",
+				"type":             "issues",
+				"max_results":      float64(10),
+				"exclude_archived": false,
+			},
+			expected: OrgSearchResult{
+				Query:      "is:open flaky org:octo-org",
+				TotalCount: 2,
+				Scanned:    2,
+				Repositories: []OrgSearchRepository{
+					{
+						Repository: "octo-org/web",
+						Hits:       2,
+						Matches: []OrgSearchMatch{
+							{Number: 7, Title: "Flaky upload test", State: "open", HTMLURL: "https://github.com/octo-org/web/issues/7"},
+							{Number: 12, Title: "Retry flaky uploads", State: "open", IsPullRequest: true, HTMLURL: "https://github.com/octo-org/web/pull/12"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "code search rate limit exceeded",
+			mockedClient: mock.NewMockedHTTPClient(
+				archivedSearch,
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCode,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("X-RateLimit-Limit", "10")
+						w.Header().Set("X-RateLimit-Remaining", "0")
+						w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC).Unix(), 10))
+						w.Header().Set("X-RateLimit-Resource", "code_search")
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "octo-org",
+				"query": "uploadArtifact",
+			},
+			expectError:    true,
+			expectedErrMsg: "the GitHub code search rate limit of 10 requests per minute was exceeded, it resets at 2030-01-02T03:04:05Z",
+		},
+		{
+			name:         "invalid type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":   "octo-org",
+				"query": "uploadArtifact",
+				"type":  "commits",
+			},
+			expectError:    true,
+			expectedErrMsg: "type must be code or issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Each case lists the archived repositories afresh
+			previous := archivedRepos
+			archivedRepos = newArchivedReposCache(archivedReposTTL)
+			t.Cleanup(func() { archivedRepos = previous })
+
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchAcrossOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned OrgSearchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ArchivedReposCache(t *testing.T) {
+	calls := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchRepositories,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+					Repositories: []*github.Repository{{FullName: github.Ptr(fmt.Sprintf("octo-org/old-%d", calls))}},
+				})(w, r)
+			}),
+		),
+	))
+
+	cache := newArchivedReposCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	repos, err := cache.get(context.Background(), client, "octo-org")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"octo-org/old-1": true}, repos)
+
+	// Organization names are case insensitive
+	repos, err = cache.get(context.Background(), client, "Octo-Org")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"octo-org/old-1": true}, repos)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(2 * time.Minute)
+	repos, err = cache.get(context.Background(), client, "octo-org")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"octo-org/old-2": true}, repos)
+	assert.Equal(t, 2, calls)
+}
+
+func Test_SearchAcrossOrgPageToken(t *testing.T) {
+	codeResults := []*github.CodeResult{
+		{Path: github.Ptr("a.go"), Repository: &github.Repository{FullName: github.Ptr("octo-org/web")}},
+		{Path: github.Ptr("b.go"), Repository: &github.Repository{FullName: github.Ptr("octo-org/web")}},
+		{Path: github.Ptr("c.go"), Repository: &github.Repository{FullName: github.Ptr("octo-org/cli")}},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchCode,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Both calls read the first page, the second one from where the first stopped
+				page := r.URL.Query().Get("page")
+				assert.Contains(t, []string{"", "1"}, page)
+				assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+				mockResponse(t, http.StatusOK, &github.CodeSearchResult{Total: github.Ptr(3), CodeResults: codeResults})(w, r)
+			}),
+		),
+	))
+	_, handler := SearchAcrossOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":              "octo-org",
+		"query":            "upload",
+		"max_results":      float64(2),
+		"exclude_archived": false,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	token := nextPageTokenOf(t, result)
+	assert.Equal(t, pageToken{Page: 1, PerPage: 2, Skip: 2}, mustDecodePageToken(t, token))
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"org":              "octo-org",
+		"query":            "upload",
+		"max_results":      float64(2),
+		"exclude_archived": false,
+		"page_token":       token,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var returned OrgSearchResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned.Repositories, 1)
+	assert.Equal(t, "octo-org/cli", returned.Repositories[0].Repository)
+	assert.Empty(t, nextPageTokenOf(t, result))
+}
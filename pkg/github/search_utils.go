@@ -111,5 +111,5 @@ func searchHandler(
 		return nil, fmt.Errorf("%s: failed to marshal response: %w", errorPrefix, err)
 	}
 
-	return mcp.NewToolResultText(string(r)), nil
+	return withNextPageToken(mcp.NewToolResultText(string(r)), nextRESTPageToken(resp, pagination.PerPage)), nil
 }
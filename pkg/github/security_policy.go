@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxSecurityPolicyAdvisories bounds the number of published advisories listed.
+	maxSecurityPolicyAdvisories = 20
+	securityPolicyDocsURL       = "https://docs.github.com/en/code-security/getting-started/adding-a-security-policy-to-your-repository"
+	securityAdvisoriesDocsURL   = "https://docs.github.com/en/code-security/security-advisories/working-with-repository-security-advisories/about-repository-security-advisories"
+)
+
+// securityPolicyPaths are the paths GitHub looks for the security policy of a repository at, in order.
+var securityPolicyPaths = []string{"SECURITY.md", ".github/SECURITY.md", "docs/SECURITY.md"}
+
+// SecurityPolicy is the output type of the get_security_policy tool.
+type SecurityPolicy struct {
+	// Source is where the policy came from: security_advisories, file, or none if there is no policy.
+	Source string `json:"source"`
+	// Advisories are the published security advisories of the repository, for the security_advisories source.
+	Advisories []PublishedSecurityAdvisory `json:"advisories,omitempty"`
+	// Path, URL and Content are the policy file, for the file source.
+	Path      string `json:"path,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+	// Message explains how to add a policy, for the none source.
+	Message  string   `json:"message,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PublishedSecurityAdvisory is a security advisory published by a repository.
+type PublishedSecurityAdvisory struct {
+	GHSAID      string `json:"ghsa_id"`
+	CVEID       string `json:"cve_id,omitempty"`
+	Summary     string `json:"summary"`
+	Severity    string `json:"severity,omitempty"`
+	PublishedAt string `json:"published_at,omitempty"`
+	URL         string `json:"url"`
+}
+
+// GetSecurityPolicy creates a tool to get the security policy of a repository.
+func GetSecurityPolicy(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_security_policy",
+			mcp.WithDescription(t("TOOL_GET_SECURITY_POLICY_DESCRIPTION", "Get the security policy of a GitHub repository. Returns the security advisories the repository published through GitHub if there are any, and otherwise its SECURITY.md file from the default branch, with where the policy came from. If there is neither, says how to add a security policy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SECURITY_POLICY_USER_TITLE", "Get security policy"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var policy SecurityPolicy
+			advisories, resp, err := client.SecurityAdvisories.ListRepositorySecurityAdvisories(ctx, owner, repo, &github.ListRepositorySecurityAdvisoriesOptions{
+				ListCursorOptions: github.ListCursorOptions{PerPage: maxSecurityPolicyAdvisories},
+				Sort:              "published",
+				Direction:         "desc",
+				State:             "published",
+			})
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// Repositories without advisories that the token cannot administer answer 404
+			default:
+				_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list security advisories", resp, err)
+				policy.Warnings = append(policy.Warnings, fmt.Sprintf("failed to list security advisories: %v", err))
+			}
+			if len(advisories) > 0 {
+				policy.Source = "security_advisories"
+				for _, advisory := range advisories {
+					published := PublishedSecurityAdvisory{
+						GHSAID:   advisory.GetGHSAID(),
+						CVEID:    advisory.GetCVEID(),
+						Summary:  advisory.GetSummary(),
+						Severity: advisory.GetSeverity(),
+						URL:      advisory.GetHTMLURL(),
+					}
+					if advisory.PublishedAt != nil {
+						published.PublishedAt = advisory.PublishedAt.Format(time.RFC3339)
+					}
+					policy.Advisories = append(policy.Advisories, published)
+				}
+				return MarshalledTextResult(policy), nil
+			}
+
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
+			}
+			for _, path := range securityPolicyPaths {
+				rawResp, err := rawClient.GetRawContent(ctx, owner, repo, path, nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get raw content: %w", err)
+				}
+				if rawResp.StatusCode == http.StatusNotFound {
+					_ = rawResp.Body.Close()
+					continue
+				}
+				if rawResp.StatusCode != http.StatusOK {
+					_ = rawResp.Body.Close()
+					policy.Warnings = append(policy.Warnings, fmt.Sprintf("failed to get %s: unexpected status %d", path, rawResp.StatusCode))
+					continue
+				}
+				content, err := io.ReadAll(io.LimitReader(rawResp.Body, raw.MaxRawContentBytes+1))
+				_ = rawResp.Body.Close()
+				if err != nil {
+					return nil, fmt.Errorf("failed to read raw content: %w", err)
+				}
+
+				policy.Source = "file"
+				policy.Path = path
+				policy.URL = rawClient.URLFromOpts(nil, owner, repo, path)
+				if len(content) > raw.MaxRawContentBytes {
+					content, policy.Truncated = content[:raw.MaxRawContentBytes], true
+				}
+				// A character cut in half by the limit is dropped
+				policy.Content = strings.ToValidUTF8(string(content), "")
+				return MarshalledTextResult(policy), nil
+			}
+
+			policy.Source = "none"
+			policy.Message = fmt.Sprintf("%s/%s has no security policy: it published no security advisories and has no SECURITY.md file in its root, .github or docs directory. To add a security policy, see %s; to publish security advisories, see %s.",
+				owner, repo, securityPolicyDocsURL, securityAdvisoriesDocsURL)
+			return MarshalledTextResult(policy), nil
+		}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetSecurityPolicy(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GetSecurityPolicy(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_security_policy", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	noAdvisories := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposSecurityAdvisoriesByOwnerByRepo, []*github.SecurityAdvisory{})
+	}
+	notFound := mockResponse(t, http.StatusNotFound, "404: Not Found")
+	policy := "# Security Policy\n\nReport vulnerabilities to security@example.com.\n"
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		expected     SecurityPolicy
+	}{
+		{
+			name: "published advisories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecurityAdvisoriesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "published",
+						"sort":      "published",
+						"direction": "desc",
+						"per_page":  "20",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.SecurityAdvisory{
+							{
+								GHSAID:      github.Ptr("GHSA-xxxx-yyyy-zzzz"),
+								CVEID:       github.Ptr("CVE-2025-0001"),
+								Summary:     github.Ptr("Path traversal in the file server"),
+								Severity:    github.Ptr("high"),
+								PublishedAt: &github.Timestamp{Time: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+								HTMLURL:     github.Ptr("https://github.com/owner/repo/security/advisories/GHSA-xxxx-yyyy-zzzz"),
+							},
+						}),
+					),
+				),
+			),
+			expected: SecurityPolicy{
+				Source: "security_advisories",
+				Advisories: []PublishedSecurityAdvisory{
+					{
+						GHSAID:      "GHSA-xxxx-yyyy-zzzz",
+						CVEID:       "CVE-2025-0001",
+						Summary:     "Path traversal in the file server",
+						Severity:    "high",
+						PublishedAt: "2025-05-01T00:00:00Z",
+						URL:         "https://github.com/owner/repo/security/advisories/GHSA-xxxx-yyyy-zzzz",
+					},
+				},
+			},
+		},
+		{
+			name: "SECURITY.md in the .github directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				noAdvisories(),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path != "/owner/repo/HEAD/.github/SECURITY.md" {
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						_, _ = w.Write([]byte(policy))
+					}),
+				),
+			),
+			expected: SecurityPolicy{
+				Source:  "file",
+				Path:    ".github/SECURITY.md",
+				URL:     "https://raw.example.com/owner/repo/HEAD/.github/SECURITY.md",
+				Content: policy,
+			},
+		},
+		{
+			name: "advisories cannot be listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecurityAdvisoriesByOwnerByRepo,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/owner/repo/HEAD/SECURITY.md").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							_, _ = w.Write([]byte(policy))
+						},
+					),
+				),
+			),
+			expected: SecurityPolicy{
+				Source:  "file",
+				Path:    "SECURITY.md",
+				URL:     "https://raw.example.com/owner/repo/HEAD/SECURITY.md",
+				Content: policy,
+			},
+		},
+		{
+			name: "no policy",
+			mockedClient: mock.NewMockedHTTPClient(
+				noAdvisories(),
+				mock.WithRequestMatchHandler(raw.GetRawReposContentsByOwnerByRepoByPath, notFound),
+			),
+			expected: SecurityPolicy{
+				Source: "none",
+				Message: "owner/repo has no security policy: it published no security advisories and has no SECURITY.md file in its root, .github or docs directory. To add a security policy, see " +
+					securityPolicyDocsURL + "; to publish security advisories, see " + securityAdvisoriesDocsURL + ".",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetSecurityPolicy(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var policy SecurityPolicy
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &policy))
+			assert.Equal(t, tc.expected, policy)
+		})
+	}
+}
@@ -17,6 +17,7 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 	defaultOpts := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(true),
 		server.WithLogging(),
 	}
 	opts = append(defaultOpts, opts...)
@@ -174,12 +175,52 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// OptionalInt64ArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
+// 2. If it is present, iterates the elements and checks each is a whole number
+func OptionalInt64ArrayParam(r mcp.CallToolRequest, p string) ([]int64, error) {
+	// Check if the parameter is present in the request
+	if _, ok := r.GetArguments()[p]; !ok {
+		return []int64{}, nil
+	}
+
+	switch v := r.GetArguments()[p].(type) {
+	case nil:
+		return []int64{}, nil
+	case []int64:
+		return v, nil
+	case []any:
+		intSlice := make([]int64, len(v))
+		for i, v := range v {
+			f, ok := v.(float64)
+			if !ok {
+				return []int64{}, fmt.Errorf("parameter %s is not of type number, is %T", p, v)
+			}
+			if f != float64(int64(f)) {
+				return []int64{}, fmt.Errorf("parameter %s contains %v, which is not a whole number", p, f)
+			}
+			intSlice[i] = int64(f)
+		}
+		return intSlice, nil
+	default:
+		return []int64{}, fmt.Errorf("parameter %s could not be coerced to []int64, is %T", p, r.GetArguments()[p])
+	}
+}
+
+// withPageToken adds the page_token parameter that all list tools page with, whatever API they use.
+func withPageToken() mcp.ToolOption {
+	return mcp.WithString("page_token",
+		mcp.Description("Token of the page of results to get, from the next_page_token of the previous page. There are more results only if the result has a next_page_token field, and then a list of results is in its items field."),
+	)
+}
+
 // WithPagination adds REST API pagination parameters to a tool.
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
 func WithPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("page",
-			mcp.Description("Page number for pagination (min 1)"),
+			mcp.Description("Deprecated, use page_token instead. Page number for pagination (min 1)"),
 			mcp.Min(1),
 		)(tool)
 
@@ -188,6 +229,8 @@ func WithPagination() mcp.ToolOption {
 			mcp.Min(1),
 			mcp.Max(100),
 		)(tool)
+
+		withPageToken()(tool)
 	}
 }
 
@@ -196,7 +239,7 @@ func WithPagination() mcp.ToolOption {
 func WithUnifiedPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("page",
-			mcp.Description("Page number for pagination (min 1)"),
+			mcp.Description("Deprecated, use page_token instead. Page number for pagination (min 1)"),
 			mcp.Min(1),
 		)(tool)
 
@@ -206,8 +249,10 @@ func WithUnifiedPagination() mcp.ToolOption {
 			mcp.Max(100),
 		)(tool)
 
+		withPageToken()(tool)
+
 		mcp.WithString("after",
-			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+			mcp.Description("Deprecated, use page_token instead. Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
 		)(tool)
 	}
 }
@@ -221,9 +266,25 @@ func WithCursorPagination() mcp.ToolOption {
 			mcp.Max(100),
 		)(tool)
 
+		withPageToken()(tool)
+
 		mcp.WithString("after",
-			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+			mcp.Description("Deprecated, use page_token instead. Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+		)(tool)
+	}
+}
+
+// WithPageTokenPagination adds pagination parameters to a list tool that did not page before, and so
+// has no deprecated page or after parameter to keep.
+func WithPageTokenPagination() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber("perPage",
+			mcp.Description("Results per page for pagination (min 1, max 100)"),
+			mcp.Min(1),
+			mcp.Max(100),
 		)(tool)
+
+		withPageToken()(tool)
 	}
 }
 
@@ -251,11 +312,60 @@ func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	return PaginationParams{
+	params := PaginationParams{
 		Page:    page,
 		PerPage: perPage,
 		After:   after,
-	}, nil
+	}
+
+	// A page token takes precedence over the deprecated page and after parameters
+	token, err := optionalPageToken(r)
+	if err != nil || token == nil {
+		return params, err
+	}
+	if token.Page > 0 {
+		params.Page, params.After = token.Page, ""
+	} else {
+		params.Page, params.After = 1, token.Cursor
+	}
+	if token.PerPage > 0 {
+		params.PerPage = token.PerPage
+	}
+	return params, nil
+}
+
+// OptionalPageTokenParams returns the "perPage" and "page_token" parameters of a tool paginated with
+// WithPageTokenPagination, as the page, or the after cursor, and the page size to list. Those that
+// are not given are zero, and left to the API's defaults.
+func OptionalPageTokenParams(r mcp.CallToolRequest) (PaginationParams, error) {
+	perPage, err := OptionalIntParam(r, "perPage")
+	if err != nil {
+		return PaginationParams{}, err
+	}
+	params := PaginationParams{PerPage: perPage}
+
+	token, err := optionalPageToken(r)
+	if err != nil || token == nil {
+		return params, err
+	}
+	params.Page, params.After = token.Page, token.Cursor
+	if token.PerPage > 0 {
+		params.PerPage = token.PerPage
+	}
+	return params, nil
+}
+
+// optionalPageToken returns the decoded "page_token" parameter, or nil if it is not present.
+func optionalPageToken(r mcp.CallToolRequest) (*pageToken, error) {
+	encoded, err := OptionalParam[string](r, "page_token")
+	if err != nil || encoded == "" {
+		return nil, err
+	}
+	token, err := decodePageToken(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
 }
 
 // OptionalCursorPaginationParams returns the "perPage" and "after" parameters from the request,
@@ -269,10 +379,23 @@ func OptionalCursorPaginationParams(r mcp.CallToolRequest) (CursorPaginationPara
 	if err != nil {
 		return CursorPaginationParams{}, err
 	}
-	return CursorPaginationParams{
+	params := CursorPaginationParams{
 		PerPage: perPage,
 		After:   after,
-	}, nil
+	}
+
+	token, err := optionalPageToken(r)
+	if err != nil || token == nil {
+		return params, err
+	}
+	if token.Cursor == "" {
+		return CursorPaginationParams{}, fmt.Errorf("invalid page_token: it is the token of a numbered page, not of a cursor")
+	}
+	params.After = token.Cursor
+	if token.PerPage > 0 {
+		params.PerPage = token.PerPage
+	}
+	return params, nil
 }
 
 type CursorPaginationParams struct {
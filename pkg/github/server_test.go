@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/scim"
 	"github.com/google/go-github/v74/github"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +45,12 @@ func stubGetRawClientFn(client *raw.Client) raw.GetRawClientFn {
 	}
 }
 
+func stubGetSCIMClientFn(client *scim.Client) scim.GetSCIMClientFn {
+	return func(_ context.Context) (*scim.Client, error) {
+		return client, nil
+	}
+}
+
 func badRequestHandler(msg string) http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		structuredErrorResponse := github.ErrorResponse{
@@ -478,6 +485,74 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestOptionalInt64ArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		paramName   string
+		expected    []int64
+		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: false,
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), float64(42)},
+			},
+			paramName:   "ids",
+			expected:    []int64{1, 42},
+			expectError: false,
+		},
+		{
+			name: "wrong type parameter",
+			params: map[string]any{
+				"ids": "1,2",
+			},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), "2"},
+			},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: true,
+		},
+		{
+			name: "fractional number",
+			params: map[string]any{
+				"ids": []any{float64(1.5)},
+			},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalInt64ArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,298 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// UserGPGKey is a GPG key of the authenticated user, without the key itself.
+type UserGPGKey struct {
+	ID        int64            `json:"id"`
+	KeyID     string           `json:"key_id"`
+	Emails    []UserGPGKeyMail `json:"emails"`
+	SubkeyIDs []string         `json:"subkey_ids,omitempty"`
+	CanSign   bool             `json:"can_sign"`
+	CreatedAt string           `json:"created_at,omitempty"`
+	ExpiresAt string           `json:"expires_at,omitempty"`
+}
+
+// UserGPGKeyMail is an email address of a GPG key, which signed commits must be committed with.
+type UserGPGKeyMail struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+}
+
+// ListUserGPGKeys creates a tool to list the GPG keys of the authenticated user.
+func ListUserGPGKeys(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_gpg_keys",
+			mcp.WithDescription(t("TOOL_LIST_USER_GPG_KEYS_DESCRIPTION", "List the GPG keys of the authenticated user that GitHub verifies commit signatures with, with their key IDs, email addresses and expiry. Requires the read:gpg_key scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_GPG_KEYS_USER_TITLE", "List my GPG keys"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Users.ListGPGKeys(ctx, "", &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list GPG keys", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]UserGPGKey, 0, len(keys))
+			for _, key := range keys {
+				userKey := UserGPGKey{
+					ID:      key.GetID(),
+					KeyID:   key.GetKeyID(),
+					Emails:  []UserGPGKeyMail{},
+					CanSign: key.GetCanSign(),
+				}
+				for _, email := range key.Emails {
+					userKey.Emails = append(userKey.Emails, UserGPGKeyMail{Email: email.GetEmail(), Verified: email.GetVerified()})
+				}
+				for _, subkey := range key.Subkeys {
+					userKey.SubkeyIDs = append(userKey.SubkeyIDs, subkey.GetKeyID())
+					// Commits are usually signed with a subkey
+					userKey.CanSign = userKey.CanSign || subkey.GetCanSign()
+				}
+				if key.CreatedAt != nil {
+					userKey.CreatedAt = key.CreatedAt.Format(time.RFC3339)
+				}
+				if key.ExpiresAt != nil {
+					userKey.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+				}
+				result = append(result, userKey)
+			}
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// ListUserSSHSigningKeys creates a tool to list the SSH signing keys of the authenticated user.
+func ListUserSSHSigningKeys(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_ssh_signing_keys",
+			mcp.WithDescription(t("TOOL_LIST_USER_SSH_SIGNING_KEYS_DESCRIPTION", "List the SSH keys of the authenticated user that GitHub verifies commit signatures with. These are separate from the SSH keys used to authenticate. Requires the read:ssh_signing_key scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_SSH_SIGNING_KEYS_USER_TITLE", "List my SSH signing keys"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Users.ListSSHSigningKeys(ctx, "", &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list SSH signing keys", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withNextPageToken(MarshalledTextResult(keys), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+const (
+	defaultSigningRequirementRepos = 100
+	maxSigningRequirementRepos     = 500
+)
+
+// CommitSigningRequirementReport is the output type of the get_commit_signing_requirement tool.
+type CommitSigningRequirementReport struct {
+	Org         string `json:"org"`
+	Scanned     int    `json:"scanned"`
+	Enforced    int    `json:"enforced"`
+	NotEnforced int    `json:"not_enforced"`
+	// Truncated is set if the organization has more repositories than were scanned.
+	Truncated    bool                                 `json:"truncated,omitempty"`
+	Repositories []RepositoryCommitSigningRequirement `json:"repositories"`
+}
+
+// RepositoryCommitSigningRequirement reports whether a repository requires signed commits on its
+// default branch.
+type RepositoryCommitSigningRequirement struct {
+	Repository    string `json:"repository"`
+	DefaultBranch string `json:"default_branch"`
+	Enforced      bool   `json:"enforced"`
+	// Sources are what require signatures: branch_protection, ruleset or both.
+	Sources []string `json:"sources,omitempty"`
+	// Error is set if it could not be told whether signatures are required.
+	Error string `json:"error,omitempty"`
+}
+
+// commitSigningRequirement checks the branch protection and rulesets of the default branch of repo
+// for required signatures.
+func commitSigningRequirement(ctx context.Context, client *github.Client, repo *github.Repository) RepositoryCommitSigningRequirement {
+	owner, name, branch := repo.GetOwner().GetLogin(), repo.GetName(), repo.GetDefaultBranch()
+	result := RepositoryCommitSigningRequirement{Repository: repo.GetFullName(), DefaultBranch: branch}
+
+	var errs []error
+	rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, name, branch, nil)
+	if err != nil {
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get branch rules", resp, err)
+		errs = append(errs, fmt.Errorf("failed to get rules: %w", err))
+	} else {
+		_ = resp.Body.Close()
+		if len(rules.RequiredSignatures) > 0 {
+			result.Sources = append(result.Sources, "ruleset")
+		}
+	}
+
+	signatures, resp, err := client.Repositories.GetSignaturesProtectedBranch(ctx, owner, name, branch)
+	switch {
+	case err == nil:
+		_ = resp.Body.Close()
+		if signatures.GetEnabled() {
+			result.Sources = append(result.Sources, "branch_protection")
+		}
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		// The branch is not protected
+	default:
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get branch protection", resp, err)
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			err = errors.New("reading branch protection requires admin access to the repository")
+		}
+		errs = append(errs, fmt.Errorf("failed to get branch protection: %w", err))
+	}
+
+	result.Enforced = len(result.Sources) > 0
+	// Signatures are known to be required even if the other check failed
+	if !result.Enforced && len(errs) > 0 {
+		result.Error = errors.Join(errs...).Error()
+	}
+	return result
+}
+
+// GetCommitSigningRequirement creates a tool to report which repositories of an organization require
+// signed commits on their default branch.
+func GetCommitSigningRequirement(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_signing_requirement",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_SIGNING_REQUIREMENT_DESCRIPTION", "Report which repositories of an organization require signed commits on their default branch, through branch protection or rulesets, and which do not. Archived repositories are skipped. Reading branch protection requires admin access to the repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_SIGNING_REQUIREMENT_USER_TITLE", "Report commit signing requirements"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization to report on"),
+			),
+			mcp.WithNumber("max_repos",
+				mcp.Description(fmt.Sprintf("Maximum number of repositories to check, most recently pushed first (default %d, max %d)", defaultSigningRequirementRepos, maxSigningRequirementRepos)),
+				mcp.Min(1),
+				mcp.Max(maxSigningRequirementRepos),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos, err := OptionalIntParamWithDefault(request, "max_repos", defaultSigningRequirementRepos)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos = min(max(maxRepos, 1), maxSigningRequirementRepos)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			report := CommitSigningRequirementReport{Org: org, Repositories: []RepositoryCommitSigningRequirement{}}
+			var repos []*github.Repository
+			opts := &github.RepositoryListByOrgOptions{
+				Sort:        "pushed",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for {
+				page, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repositories", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				for _, repo := range page {
+					if repo.GetArchived() {
+						continue
+					}
+					if len(repos) == maxRepos {
+						report.Truncated = true
+						break
+					}
+					repos = append(repos, repo)
+				}
+				if report.Truncated || resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			results := make([]RepositoryCommitSigningRequirement, len(repos))
+			tasks := make([]func(context.Context) error, len(repos))
+			for i, repo := range repos {
+				tasks[i] = func(ctx context.Context) error {
+					results[i] = commitSigningRequirement(ctx, client, repo)
+					return nil
+				}
+			}
+			for i, err := range runBounded(ctx, maxConcurrentRequests, tasks...) {
+				if err != nil {
+					results[i] = RepositoryCommitSigningRequirement{
+						Repository:    repos[i].GetFullName(),
+						DefaultBranch: repos[i].GetDefaultBranch(),
+						Error:         err.Error(),
+					}
+				}
+			}
+
+			// Repositories that do not enforce signatures come first, as they are what needs attention
+			sort.SliceStable(results, func(i, j int) bool {
+				if results[i].Enforced != results[j].Enforced {
+					return !results[i].Enforced
+				}
+				return results[i].Repository < results[j].Repository
+			})
+			for _, result := range results {
+				switch {
+				case result.Enforced:
+					report.Enforced++
+				case result.Error == "":
+					report.NotEnforced++
+				}
+			}
+			report.Scanned = len(results)
+			report.Repositories = results
+			return MarshalledTextResult(report), nil
+		}
+}
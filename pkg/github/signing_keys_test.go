@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListUserGPGKeys(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserGPGKeys(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_gpg_keys", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUserGpgKeys,
+			expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.GPGKey{
+					{
+						ID:        github.Ptr(int64(3)),
+						KeyID:     github.Ptr("9AC3516F9FF66AC1"),
+						CanSign:   github.Ptr(false),
+						CreatedAt: &github.Timestamp{Time: createdAt},
+						Emails: []*github.GPGEmail{
+							{Email: github.Ptr("octocat@github.com"), Verified: github.Ptr(true)},
+						},
+						Subkeys: []*github.GPGKey{
+							{KeyID: github.Ptr("4A1E2B3C4D5E6F70"), CanSign: github.Ptr(true)},
+						},
+					},
+				}),
+			),
+		),
+	)
+	_, handler := ListUserGPGKeys(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"page":    float64(2),
+		"perPage": float64(10),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var keys []UserGPGKey
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &keys))
+	assert.Equal(t, []UserGPGKey{
+		{
+			ID:        3,
+			KeyID:     "9AC3516F9FF66AC1",
+			Emails:    []UserGPGKeyMail{{Email: "octocat@github.com", Verified: true}},
+			SubkeyIDs: []string{"4A1E2B3C4D5E6F70"},
+			CanSign:   true,
+			CreatedAt: "2024-01-02T03:04:05Z",
+		},
+	}, keys)
+}
+
+func Test_ListUserSSHSigningKeys(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserSSHSigningKeys(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_ssh_signing_keys", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lists keys",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUserSshSigningKeys,
+					[]*github.SSHSigningKey{
+						{ID: github.Ptr(int64(1)), Key: github.Ptr("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5"), Title: github.Ptr("laptop")},
+					},
+				),
+			),
+		},
+		{
+			name: "missing scope",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserSshSigningKeys,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list SSH signing keys",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListUserSSHSigningKeys(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var keys []*github.SSHSigningKey
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &keys))
+			require.Len(t, keys, 1)
+			assert.Equal(t, "laptop", keys[0].GetTitle())
+		})
+	}
+}
+
+func Test_GetCommitSigningRequirement(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitSigningRequirement(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_signing_requirement", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	repo := func(name string, archived bool) *github.Repository {
+		return &github.Repository{
+			Name:          github.Ptr(name),
+			FullName:      github.Ptr("org/" + name),
+			Owner:         &github.User{Login: github.Ptr("org")},
+			DefaultBranch: github.Ptr("main"),
+			Archived:      github.Ptr(archived),
+		}
+	}
+	repoName := func(r *http.Request) string {
+		return strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/org/"), "/")[0]
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsReposByOrg,
+			expectQueryParams(t, map[string]string{"sort": "pushed", "per_page": "100"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Repository{
+					repo("ruleset", false),
+					repo("protected", false),
+					repo("old", true),
+					repo("unprotected", false),
+					repo("forbidden", false),
+					repo("beyond", false),
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rules := []map[string]any{}
+				if repoName(r) == "ruleset" {
+					rules = append(rules, map[string]any{"type": "required_signatures", "ruleset_id": 1})
+				}
+				mockResponse(t, http.StatusOK, rules)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesProtectionRequiredSignaturesByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch repoName(r) {
+				case "protected":
+					mockResponse(t, http.StatusOK, &github.SignaturesProtectedBranch{Enabled: github.Ptr(true)})(w, r)
+				case "forbidden":
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`)(w, r)
+				case "ruleset":
+					mockResponse(t, http.StatusOK, &github.SignaturesProtectedBranch{Enabled: github.Ptr(false)})(w, r)
+				default:
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`)(w, r)
+				}
+			}),
+		),
+	)
+	_, handler := GetCommitSigningRequirement(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":       "org",
+		"max_repos": float64(4),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var report CommitSigningRequirementReport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+	assert.Equal(t, "org", report.Org)
+	assert.Equal(t, 4, report.Scanned)
+	assert.Equal(t, 2, report.Enforced)
+	assert.Equal(t, 1, report.NotEnforced)
+	assert.True(t, report.Truncated)
+
+	require.Len(t, report.Repositories, 4)
+	assert.Equal(t, "org/forbidden", report.Repositories[0].Repository)
+	assert.False(t, report.Repositories[0].Enforced)
+	assert.Contains(t, report.Repositories[0].Error, "requires admin access")
+	assert.Equal(t, RepositoryCommitSigningRequirement{Repository: "org/unprotected", DefaultBranch: "main"}, report.Repositories[1])
+	assert.Equal(t, RepositoryCommitSigningRequirement{Repository: "org/protected", DefaultBranch: "main", Enforced: true, Sources: []string{"branch_protection"}}, report.Repositories[2])
+	assert.Equal(t, RepositoryCommitSigningRequirement{Repository: "org/ruleset", DefaultBranch: "main", Enforced: true, Sources: []string{"ruleset"}}, report.Repositories[3])
+}
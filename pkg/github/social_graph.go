@@ -0,0 +1,351 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// maxSocialBioLength bounds the bio of users listed by the social graph tools.
+const maxSocialBioLength = 120
+
+// SocialUser is a compact profile of a user listed by the social graph tools.
+type SocialUser struct {
+	Login string `json:"login"`
+	Name  string `json:"name,omitempty"`
+	// Bio is the first line of the bio of the user, truncated.
+	Bio string `json:"bio,omitempty"`
+}
+
+// SocialUserList is the output type of the list_followers and list_following tools.
+type SocialUserList struct {
+	Users       []SocialUser `json:"users"`
+	TotalCount  int          `json:"total_count"`
+	HasNextPage bool         `json:"has_next_page"`
+	EndCursor   string       `json:"end_cursor,omitempty"`
+}
+
+// socialUserNode is a user as queried by the social graph tools.
+type socialUserNode struct {
+	Login githubv4.String
+	Name  githubv4.String
+	Bio   githubv4.String
+}
+
+// socialUserConnection is a page of followers or followed users.
+type socialUserConnection struct {
+	Nodes      []socialUserNode
+	PageInfo   PageInfoFragment
+	TotalCount githubv4.Int
+}
+
+// toSocialUserList converts a page of users to the output of the tools.
+func toSocialUserList(connection socialUserConnection) SocialUserList {
+	list := SocialUserList{
+		Users:       make([]SocialUser, 0, len(connection.Nodes)),
+		TotalCount:  int(connection.TotalCount),
+		HasNextPage: connection.PageInfo.HasNextPage,
+	}
+	if list.HasNextPage {
+		list.EndCursor = string(connection.PageInfo.EndCursor)
+	}
+	for _, node := range connection.Nodes {
+		bio, _, _ := strings.Cut(strings.TrimSpace(string(node.Bio)), "\n")
+		bio, _ = truncateText(strings.TrimSpace(bio), maxSocialBioLength)
+		list.Users = append(list.Users, SocialUser{
+			Login: string(node.Login),
+			Name:  string(node.Name),
+			Bio:   bio,
+		})
+	}
+	return list
+}
+
+// socialUserListVars returns the variables of a query for a page of the users related to login.
+func socialUserListVars(request mcp.CallToolRequest, login string) (map[string]any, error) {
+	pagination, err := OptionalCursorPaginationParams(request)
+	if err != nil {
+		return nil, err
+	}
+	params, err := pagination.ToGraphQLParams()
+	if err != nil {
+		return nil, err
+	}
+	vars := map[string]any{
+		"login": githubv4.String(login),
+		"first": githubv4.Int(*params.First),
+		"after": (*githubv4.String)(nil),
+	}
+	if params.After != nil {
+		vars["after"] = githubv4.NewString(githubv4.String(*params.After))
+	}
+	return vars, nil
+}
+
+// ListFollowers creates a tool to list the followers of a user.
+func ListFollowers(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_followers",
+			mcp.WithDescription(t("TOOL_LIST_FOLLOWERS_DESCRIPTION", "List the users who follow a GitHub user, with their name and the first line of their bio.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FOLLOWERS_USER_TITLE", "List followers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user whose followers to list"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			vars, err := socialUserListVars(request, username)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				User struct {
+					Followers socialUserConnection `graphql:"followers(first: $first, after: $after)"`
+				} `graphql:"user(login: $login)"`
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list followers", err), nil
+			}
+
+			return withNextPageToken(MarshalledTextResult(toSocialUserList(q.User.Followers)), q.User.Followers.PageInfo.nextPageToken(int(vars["first"].(githubv4.Int)))), nil
+		}
+}
+
+// ListFollowing creates a tool to list the users a user follows.
+func ListFollowing(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_following",
+			mcp.WithDescription(t("TOOL_LIST_FOLLOWING_DESCRIPTION", "List the users a GitHub user follows, with their name and the first line of their bio.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FOLLOWING_USER_TITLE", "List followed users"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user whose followed users to list"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			vars, err := socialUserListVars(request, username)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				User struct {
+					Following socialUserConnection `graphql:"following(first: $first, after: $after)"`
+				} `graphql:"user(login: $login)"`
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list followed users", err), nil
+			}
+
+			return withNextPageToken(MarshalledTextResult(toSocialUserList(q.User.Following)), q.User.Following.PageInfo.nextPageToken(int(vars["first"].(githubv4.Int)))), nil
+		}
+}
+
+// CheckUserFollows creates a tool to check whether a user follows another.
+func CheckUserFollows(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_user_follows",
+			mcp.WithDescription(t("TOOL_CHECK_USER_FOLLOWS_DESCRIPTION", "Check whether a GitHub user follows another user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_USER_FOLLOWS_USER_TITLE", "Check if a user follows another"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username of the follower. Defaults to the authenticated user."),
+			),
+			mcp.WithString("target",
+				mcp.Required(),
+				mcp.Description("Username of the user who may be followed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			target, err := RequiredParam[string](request, "target")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub answers 404 when the user does not follow the target, which IsFollowing reports as false
+			follows, resp, err := client.Users.IsFollowing(ctx, username, target)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to check if the user follows the target", resp, err), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"username": username,
+				"target":   target,
+				"follows":  follows,
+			}), nil
+		}
+}
+
+// FollowUser creates a tool to follow a user as the authenticated user.
+func FollowUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("follow_user",
+			mcp.WithDescription(t("TOOL_FOLLOW_USER_DESCRIPTION", "Follow a GitHub user as the authenticated user. Requires the user:follow scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FOLLOW_USER_USER_TITLE", "Follow user"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user to follow"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Users.Follow(ctx, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to follow user", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to follow user: unexpected status %d", resp.StatusCode)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Now following %s", username)), nil
+		}
+}
+
+// UnfollowUser creates a tool to unfollow a user as the authenticated user.
+func UnfollowUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unfollow_user",
+			mcp.WithDescription(t("TOOL_UNFOLLOW_USER_DESCRIPTION", "Unfollow a GitHub user as the authenticated user. Requires the user:follow scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UNFOLLOW_USER_USER_TITLE", "Unfollow user"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user to unfollow"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Users.Unfollow(ctx, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to unfollow user", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to unfollow user: unexpected status %d", resp.StatusCode)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("No longer following %s", username)), nil
+		}
+}
+
+// UserOrganization is an organization listed by the list_user_organizations tool.
+type UserOrganization struct {
+	Login       string `json:"login"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListUserOrganizations creates a tool to list the organizations of a user.
+func ListUserOrganizations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_organizations",
+			mcp.WithDescription(t("TOOL_LIST_USER_ORGANIZATIONS_DESCRIPTION", "List the organizations of a GitHub user. For other users, only organizations they made their membership of public are listed; for the authenticated user, all of them, which requires the read:org scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_ORGANIZATIONS_USER_TITLE", "List organizations of a user"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username of the user. Defaults to the authenticated user."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			orgs, resp, err := client.Organizations.List(ctx, username, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list organizations", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]UserOrganization, 0, len(orgs))
+			for _, org := range orgs {
+				result = append(result, UserOrganization{
+					Login:       org.GetLogin(),
+					Description: org.GetDescription(),
+				})
+			}
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
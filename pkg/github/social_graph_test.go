@@ -0,0 +1,327 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListFollowers(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListFollowers(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_followers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	query := "query($after:String$first:Int!$login:String!){user(login: $login){followers(first: $first, after: $after){nodes{login,name,bio},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		vars     map[string]any
+		response githubv4mock.GQLResponse
+		expected SocialUserList
+	}{
+		{
+			name: "first page",
+			args: map[string]any{"username": "octocat", "perPage": float64(2)},
+			vars: map[string]any{"login": "octocat", "first": float64(2), "after": (*string)(nil)},
+			response: githubv4mock.DataResponse(map[string]any{
+				"user": map[string]any{
+					"followers": map[string]any{
+						"nodes": []map[string]any{
+							{"login": "hubot", "name": "Hubot", "bio": "I am a robot.\nBeep boop."},
+							{"login": "monalisa", "name": "", "bio": ""},
+						},
+						"pageInfo":   map[string]any{"hasNextPage": true, "endCursor": "Y3Vyc29yOjI="},
+						"totalCount": 5,
+					},
+				},
+			}),
+			expected: SocialUserList{
+				Users: []SocialUser{
+					{Login: "hubot", Name: "Hubot", Bio: "I am a robot."},
+					{Login: "monalisa"},
+				},
+				TotalCount:  5,
+				HasNextPage: true,
+				EndCursor:   "Y3Vyc29yOjI=",
+			},
+		},
+		{
+			name: "last page",
+			args: map[string]any{"username": "octocat", "perPage": float64(2), "after": "Y3Vyc29yOjQ="},
+			vars: map[string]any{"login": "octocat", "first": float64(2), "after": "Y3Vyc29yOjQ="},
+			response: githubv4mock.DataResponse(map[string]any{
+				"user": map[string]any{
+					"followers": map[string]any{
+						"nodes":      []map[string]any{{"login": "octodog", "name": "Octodog", "bio": ""}},
+						"pageInfo":   map[string]any{"hasNextPage": false, "endCursor": "Y3Vyc29yOjU="},
+						"totalCount": 5,
+					},
+				},
+			}),
+			expected: SocialUserList{
+				Users:      []SocialUser{{Login: "octodog", Name: "Octodog"}},
+				TotalCount: 5,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query, tc.vars, tc.response))
+			_, handler := ListFollowers(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expected.HasNextPage, nextPageTokenOf(t, result) != "")
+
+			var list SocialUserList
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &list))
+			assert.Equal(t, tc.expected, list)
+		})
+	}
+}
+
+func Test_ListFollowing(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListFollowing(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_following", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	query := "query($after:String$first:Int!$login:String!){user(login: $login){following(first: $first, after: $after){nodes{login,name,bio},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	httpClient := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query,
+		map[string]any{"login": "octocat", "first": float64(30), "after": (*string)(nil)},
+		githubv4mock.DataResponse(map[string]any{
+			"user": map[string]any{
+				"following": map[string]any{
+					"nodes":      []map[string]any{{"login": "hubot", "name": "Hubot", "bio": "  Beep boop.  "}},
+					"pageInfo":   map[string]any{"hasNextPage": false},
+					"totalCount": 1,
+				},
+			},
+		}),
+	))
+	_, handler := ListFollowing(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"username": "octocat"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var list SocialUserList
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &list))
+	assert.Equal(t, SocialUserList{
+		Users:      []SocialUser{{Login: "hubot", Name: "Hubot", Bio: "Beep boop."}},
+		TotalCount: 1,
+	}, list)
+}
+
+func Test_CheckUserFollows(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckUserFollows(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_user_follows", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"target"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		args            map[string]any
+		expectedFollows bool
+	}{
+		{
+			name: "authenticated user follows the target",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserFollowingByUsername,
+					expectPath(t, "/user/following/hubot").andThen(mockResponse(t, http.StatusNoContent, nil)),
+				),
+			),
+			args:            map[string]any{"target": "hubot"},
+			expectedFollows: true,
+		},
+		{
+			name: "user does not follow the target",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersFollowingByUsernameByTargetUser,
+					expectPath(t, "/users/octocat/following/hubot").andThen(mockResponse(t, http.StatusNotFound, nil)),
+				),
+			),
+			args:            map[string]any{"username": "octocat", "target": "hubot"},
+			expectedFollows: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := CheckUserFollows(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedFollows, response["follows"])
+		})
+	}
+}
+
+func Test_FollowUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FollowUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "follow_user", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "follows the user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserFollowingByUsername,
+					expectPath(t, "/user/following/hubot").andThen(mockResponse(t, http.StatusNoContent, nil)),
+				),
+			),
+		},
+		{
+			name: "missing scope",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserFollowingByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to follow user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := FollowUser(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"username": "hubot"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Now following hubot", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_UnfollowUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnfollowUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unfollow_user", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteUserFollowingByUsername,
+			expectPath(t, "/user/following/hubot").andThen(mockResponse(t, http.StatusNoContent, nil)),
+		),
+	)
+	_, handler := UnfollowUser(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"username": "hubot"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "No longer following hubot", getTextResult(t, result).Text)
+}
+
+func Test_ListUserOrganizations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserOrganizations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_organizations", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	orgs := []*github.Organization{
+		{Login: github.Ptr("github"), Description: github.Ptr("How people build software.")},
+		{Login: github.Ptr("octo-org")},
+	}
+	expected := []UserOrganization{
+		{Login: "github", Description: "How people build software."},
+		{Login: "octo-org"},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		args         map[string]any
+	}{
+		{
+			name: "all organizations of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserOrgs,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(mockResponse(t, http.StatusOK, orgs)),
+				),
+			),
+			args: map[string]any{},
+		},
+		{
+			name: "public organizations of another user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersOrgsByUsername,
+					expectPath(t, "/users/octocat/orgs").andThen(mockResponse(t, http.StatusOK, orgs)),
+				),
+			),
+			args: map[string]any{"username": "octocat"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListUserOrganizations(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var organizations []UserOrganization
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &organizations))
+			assert.Equal(t, expected, organizations)
+		})
+	}
+}
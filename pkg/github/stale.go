@@ -0,0 +1,402 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultStaleMaxItems is the number of stale items returned when max_items is not set.
+	defaultStaleMaxItems = 30
+	// maxStaleMaxItems bounds max_items, as every item costs up to two requests to find its last actor.
+	maxStaleMaxItems = 100
+
+	defaultStaleLabel   = "stale"
+	defaultStaleComment = "This {type} has had no activity for {days_idle} days and has been marked as stale. Comment or remove the `{label}` label to keep it open."
+)
+
+// StaleItem is an open issue or pull request without recent activity.
+type StaleItem struct {
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Type         string    `json:"type"`
+	URL          string    `json:"url"`
+	Author       string    `json:"author"`
+	Labels       []string  `json:"labels,omitempty"`
+	Milestone    string    `json:"milestone,omitempty"`
+	LastActivity time.Time `json:"last_activity"`
+	// LastActor is who caused the last event on the item. It is empty if it could not be determined.
+	LastActor string `json:"last_actor,omitempty"`
+	DaysIdle  int    `json:"days_idle"`
+}
+
+// StaleItemsResult is the output of find_stale_items.
+type StaleItemsResult struct {
+	Query string `json:"query"`
+	// TotalCount is the number of items matching the query, of which at most MaxItems are returned.
+	TotalCount int         `json:"total_count"`
+	MaxItems   int         `json:"max_items"`
+	Truncated  bool        `json:"truncated"`
+	Items      []StaleItem `json:"items"`
+}
+
+// StaleSweepItem is a stale item and what mark_stale_items did to it.
+type StaleSweepItem struct {
+	StaleItem
+	// Action is "marked", or "would_mark" in a dry run, or "failed".
+	Action  string `json:"action"`
+	Comment string `json:"comment"`
+	Error   string `json:"error,omitempty"`
+}
+
+// StaleSweepResult is the output of mark_stale_items.
+type StaleSweepResult struct {
+	Query      string           `json:"query"`
+	DryRun     bool             `json:"dry_run"`
+	StaleLabel string           `json:"stale_label"`
+	Marked     int              `json:"marked"`
+	Failed     int              `json:"failed"`
+	MaxItems   int              `json:"max_items"`
+	Truncated  bool             `json:"truncated"`
+	Items      []StaleSweepItem `json:"items"`
+}
+
+// staleFilters are the parameters that select stale items.
+type staleFilters struct {
+	owner, repo       string
+	days              int
+	itemType          string
+	excludeLabels     []string
+	excludeMilestones []string
+	maxItems          int
+}
+
+// withStaleFilters adds the parameters that select stale items.
+func withStaleFilters() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner"))(tool)
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name"))(tool)
+		mcp.WithNumber("days",
+			mcp.Required(),
+			mcp.Description("Number of days without activity after which an item is stale"),
+			mcp.Min(1),
+		)(tool)
+		mcp.WithString("type",
+			mcp.Description("Kind of items to find, defaults to all"),
+			mcp.Enum("issue", "pr", "all"),
+		)(tool)
+		mcp.WithArray("exclude_labels",
+			mcp.Description("Labels whose items are never stale, such as pinned or security"),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+		mcp.WithArray("exclude_milestones",
+			mcp.Description("Milestone titles whose items are never stale"),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+		mcp.WithNumber("max_items",
+			mcp.Description(fmt.Sprintf("Maximum number of items, %d by default and at most %d", defaultStaleMaxItems, maxStaleMaxItems)),
+			mcp.Min(1),
+			mcp.Max(maxStaleMaxItems),
+		)(tool)
+	}
+}
+
+func staleFilterParams(request mcp.CallToolRequest) (staleFilters, error) {
+	var f staleFilters
+	var err error
+	if f.owner, err = RequiredParam[string](request, "owner"); err != nil {
+		return f, err
+	}
+	if f.repo, err = RequiredParam[string](request, "repo"); err != nil {
+		return f, err
+	}
+	if f.days, err = RequiredInt(request, "days"); err != nil {
+		return f, err
+	}
+	if f.days < 1 {
+		return f, fmt.Errorf("days must be at least 1")
+	}
+	if f.itemType, err = OptionalParam[string](request, "type"); err != nil {
+		return f, err
+	}
+	if f.excludeLabels, err = OptionalStringArrayParam(request, "exclude_labels"); err != nil {
+		return f, err
+	}
+	if f.excludeMilestones, err = OptionalStringArrayParam(request, "exclude_milestones"); err != nil {
+		return f, err
+	}
+	if f.maxItems, err = OptionalIntParamWithDefault(request, "max_items", defaultStaleMaxItems); err != nil {
+		return f, err
+	}
+	if f.maxItems < 1 || f.maxItems > maxStaleMaxItems {
+		return f, fmt.Errorf("max_items must be between 1 and %d", maxStaleMaxItems)
+	}
+	return f, nil
+}
+
+// quoteQualifier quotes a search qualifier value that contains spaces.
+func quoteQualifier(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// query returns the search query for the open items of the repository last updated before cutoff.
+func (f staleFilters) query(cutoff time.Time) string {
+	parts := []string{
+		fmt.Sprintf("repo:%s/%s", f.owner, f.repo),
+		"is:open",
+		"updated:<" + cutoff.UTC().Format(time.RFC3339),
+	}
+	switch f.itemType {
+	case "issue":
+		parts = append(parts, "is:issue")
+	case "pr":
+		parts = append(parts, "is:pr")
+	}
+	for _, label := range f.excludeLabels {
+		parts = append(parts, "-label:"+quoteQualifier(label))
+	}
+	for _, milestone := range f.excludeMilestones {
+		parts = append(parts, "-milestone:"+quoteQualifier(milestone))
+	}
+	return strings.Join(parts, " ")
+}
+
+// findStaleItems searches for the items matching query, least recently updated first, and looks up
+// the last actor of each.
+func findStaleItems(ctx context.Context, client *github.Client, owner, repo, query string, maxItems int) (items []StaleItem, total int, truncated bool, resp *github.Response, err error) {
+	issues, truncated, resp, err := collectPages(maxItems, func(opts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+			Sort:        "updated",
+			Order:       "asc",
+			ListOptions: opts,
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		total = result.GetTotal()
+		return result.Issues, resp, nil
+	}, nil)
+	if err != nil {
+		return nil, 0, false, resp, err
+	}
+
+	now := time.Now()
+	items = make([]StaleItem, len(issues))
+	tasks := make([]func(context.Context) error, len(issues))
+	for i, issue := range issues {
+		item := StaleItem{
+			Number:       issue.GetNumber(),
+			Title:        issue.GetTitle(),
+			Type:         "issue",
+			URL:          issue.GetHTMLURL(),
+			Author:       issue.GetUser().GetLogin(),
+			Milestone:    issue.GetMilestone().GetTitle(),
+			LastActivity: issue.GetUpdatedAt().Time,
+			DaysIdle:     int(now.Sub(issue.GetUpdatedAt().Time).Hours() / 24),
+		}
+		if issue.IsPullRequest() {
+			item.Type = "pull_request"
+		}
+		for _, label := range issue.Labels {
+			item.Labels = append(item.Labels, label.GetName())
+		}
+		items[i] = item
+
+		tasks[i] = func(ctx context.Context) error {
+			items[i].LastActor = lastTimelineActor(ctx, client, owner, repo, item.Number)
+			return nil
+		}
+	}
+	runBounded(ctx, maxConcurrentRequests, tasks...)
+	return items, total, truncated, resp, nil
+}
+
+// lastTimelineActor returns who caused the last timeline event of an issue or pull request, or an
+// empty string if it cannot be determined. The timeline is listed oldest first, so the first page
+// reports how many pages there are and the last event is on the last one.
+func lastTimelineActor(ctx context.Context, client *github.Client, owner, repo string, number int) string {
+	opts := &github.ListOptions{PerPage: 1}
+	events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, number, opts)
+	if err != nil {
+		return ""
+	}
+	_ = resp.Body.Close()
+	if resp.LastPage > 1 {
+		opts.Page = resp.LastPage
+		if events, resp, err = client.Issues.ListIssueTimeline(ctx, owner, repo, number, opts); err != nil {
+			return ""
+		}
+		_ = resp.Body.Close()
+	}
+	if len(events) == 0 {
+		return ""
+	}
+	if login := events[len(events)-1].GetActor().GetLogin(); login != "" {
+		return login
+	}
+	return events[len(events)-1].GetUser().GetLogin()
+}
+
+// FindStaleItems creates a tool to find open issues and pull requests without recent activity.
+func FindStaleItems(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_stale_items",
+			mcp.WithDescription(t("TOOL_FIND_STALE_ITEMS_DESCRIPTION", "Find open issues and pull requests in a repository with no activity for a number of days, least recently active first. Each item includes its last activity, the last actor and the number of days it has been idle. Items with excluded labels or milestones are skipped.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_STALE_ITEMS_USER_TITLE", "Find stale issues and pull requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withStaleFilters(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			filters, err := staleFilterParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			query := filters.query(time.Now().AddDate(0, 0, -filters.days))
+			items, total, truncated, resp, err := findStaleItems(ctx, client, filters.owner, filters.repo, query, filters.maxItems)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search for stale items", resp, err), nil
+			}
+
+			return MarshalledTextResult(StaleItemsResult{
+				Query:      query,
+				TotalCount: total,
+				MaxItems:   filters.maxItems,
+				Truncated:  truncated,
+				Items:      items,
+			}), nil
+		}
+}
+
+// MarkStaleItems creates a tool to label open issues and pull requests without recent activity as stale
+// and comment on them.
+func MarkStaleItems(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_stale_items",
+			mcp.WithDescription(t("TOOL_MARK_STALE_ITEMS_DESCRIPTION", "Mark open issues and pull requests in a repository with no activity for a number of days as stale: add a stale label and post a comment on each. Items that already have the stale label are skipped. Runs as a dry run by default, reporting what would be marked; set dry_run to false to apply the changes.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_STALE_ITEMS_USER_TITLE", "Mark stale issues and pull requests"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withStaleFilters(),
+			mcp.WithString("stale_label",
+				mcp.Description(fmt.Sprintf("Label to add to stale items, defaults to %q", defaultStaleLabel)),
+			),
+			mcp.WithString("comment",
+				mcp.Description("Comment to post on stale items. {days_idle}, {type}, {author} and {label} are replaced with the item's values. Defaults to a notice about the stale label"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Report what would be marked without changing anything, true by default"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			filters, err := staleFilterParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			staleLabel, err := OptionalParam[string](request, "stale_label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if staleLabel == "" {
+				staleLabel = defaultStaleLabel
+			}
+			commentTemplate, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if commentTemplate == "" {
+				commentTemplate = defaultStaleComment
+			}
+			dryRun, ok, err := OptionalParamOK[bool](request, "dry_run")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				dryRun = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Skipping items that are already stale keeps repeated sweeps from commenting again.
+			filters.excludeLabels = append(filters.excludeLabels, staleLabel)
+			query := filters.query(time.Now().AddDate(0, 0, -filters.days))
+			items, _, truncated, resp, err := findStaleItems(ctx, client, filters.owner, filters.repo, query, filters.maxItems)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search for stale items", resp, err), nil
+			}
+
+			result := StaleSweepResult{
+				Query:      query,
+				DryRun:     dryRun,
+				StaleLabel: staleLabel,
+				MaxItems:   filters.maxItems,
+				Truncated:  truncated,
+				Items:      make([]StaleSweepItem, 0, len(items)),
+			}
+			for _, item := range items {
+				itemType := "issue"
+				if item.Type == "pull_request" {
+					itemType = "pull request"
+				}
+				swept := StaleSweepItem{
+					StaleItem: item,
+					Action:    "would_mark",
+					Comment: strings.NewReplacer(
+						"{days_idle}", strconv.Itoa(item.DaysIdle),
+						"{type}", itemType,
+						"{author}", item.Author,
+						"{label}", staleLabel,
+					).Replace(commentTemplate),
+				}
+				if !dryRun {
+					swept.Action = "marked"
+					if err := markStale(ctx, client, filters.owner, filters.repo, item.Number, staleLabel, swept.Comment); err != nil {
+						swept.Action = "failed"
+						swept.Error = err.Error()
+						result.Failed++
+					} else {
+						result.Marked++
+					}
+				}
+				result.Items = append(result.Items, swept)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// markStale adds the stale label to an issue or pull request and posts comment on it.
+func markStale(ctx context.Context, client *github.Client, owner, repo string, number int, label, comment string) error {
+	_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, []string{label})
+	if err != nil {
+		return fmt.Errorf("failed to add label: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	_, resp, err = client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.Ptr(comment)})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,271 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	staleIssue = &github.Issue{
+		Number:    github.Ptr(1),
+		Title:     github.Ptr("Crash on startup"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/1"),
+		User:      &github.User{Login: github.Ptr("alice")},
+		Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		Milestone: &github.Milestone{Title: github.Ptr("Backlog")},
+		UpdatedAt: &github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	stalePullRequest = &github.Issue{
+		Number:           github.Ptr(2),
+		Title:            github.Ptr("Add caching"),
+		HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/2"),
+		User:             &github.User{Login: github.Ptr("bob")},
+		UpdatedAt:        &github.Timestamp{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/2")},
+	}
+)
+
+// staleSearchHandler serves the stale items and checks the search query contains expectedQualifiers.
+func staleSearchHandler(t *testing.T, expectedQualifiers ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.True(t, strings.HasPrefix(query.Get("q"), "repo:owner/repo is:open updated:<"), "unexpected query %q", query.Get("q"))
+		for _, qualifier := range expectedQualifiers {
+			assert.Contains(t, query.Get("q"), qualifier)
+		}
+		assert.Equal(t, "updated", query.Get("sort"))
+		assert.Equal(t, "asc", query.Get("order"))
+		mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+			Total:  github.Ptr(2),
+			Issues: []*github.Issue{staleIssue, stalePullRequest},
+		})(w, r)
+	}
+}
+
+// staleTimelineHandler serves a three page timeline for issue 1, whose last event is by carol, and a
+// single comment by dave for pull request 2.
+func staleTimelineHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/issues/2/"):
+			mockResponse(t, http.StatusOK, []*github.Timeline{{Event: github.Ptr("commented"), User: &github.User{Login: github.Ptr("dave")}}})(w, r)
+		case r.URL.Query().Get("page") == "3":
+			mockResponse(t, http.StatusOK, []*github.Timeline{{Event: github.Ptr("labeled"), Actor: &github.User{Login: github.Ptr("carol")}}})(w, r)
+		default:
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/1/timeline?per_page=1&page=2>; rel="next", <https://api.github.com/repos/owner/repo/issues/1/timeline?per_page=1&page=3>; rel="last"`)
+			mockResponse(t, http.StatusOK, []*github.Timeline{{Event: github.Ptr("commented"), Actor: &github.User{Login: github.Ptr("alice")}}})(w, r)
+		}
+	}
+}
+
+func Test_FindStaleItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindStaleItems(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_stale_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "days"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "finds stale items with their last actor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, staleSearchHandler(t, "is:issue", "-label:pinned", `-milestone:"Next release"`)),
+				mock.WithRequestMatchHandler(mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber, staleTimelineHandler(t)),
+			),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"days":               float64(30),
+				"type":               "issue",
+				"exclude_labels":     []any{"pinned"},
+				"exclude_milestones": []any{"Next release"},
+			},
+		},
+		{
+			name:         "days must be positive",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"days":  float64(-7),
+			},
+			expectError:    true,
+			expectedErrMsg: "days must be at least 1",
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"days":  float64(30),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search for stale items",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := FindStaleItems(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned StaleItemsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+
+			assert.Equal(t, 2, returned.TotalCount)
+			assert.False(t, returned.Truncated)
+			require.Len(t, returned.Items, 2)
+
+			issue := returned.Items[0]
+			assert.Equal(t, 1, issue.Number)
+			assert.Equal(t, "issue", issue.Type)
+			assert.Equal(t, "alice", issue.Author)
+			assert.Equal(t, []string{"bug"}, issue.Labels)
+			assert.Equal(t, "Backlog", issue.Milestone)
+			assert.Equal(t, "carol", issue.LastActor)
+			assert.Equal(t, int(time.Since(staleIssue.GetUpdatedAt().Time).Hours()/24), issue.DaysIdle)
+
+			pullRequest := returned.Items[1]
+			assert.Equal(t, "pull_request", pullRequest.Type)
+			assert.Equal(t, "dave", pullRequest.LastActor)
+		})
+	}
+}
+
+func Test_MarkStaleItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MarkStaleItems(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "mark_stale_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "days"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	t.Run("dry run by default", func(t *testing.T) {
+		// Labeling or commenting would fail, as those endpoints are not mocked
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetSearchIssues, staleSearchHandler(t, "-label:stale")),
+			mock.WithRequestMatchHandler(mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber, staleTimelineHandler(t)),
+		))
+		_, handler := MarkStaleItems(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"days":  float64(30),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned StaleSweepResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.True(t, returned.DryRun)
+		assert.Equal(t, "stale", returned.StaleLabel)
+		assert.Zero(t, returned.Marked)
+		require.Len(t, returned.Items, 2)
+		for _, item := range returned.Items {
+			assert.Equal(t, "would_mark", item.Action)
+		}
+		assert.Contains(t, returned.Items[1].Comment, "This pull request has had no activity for")
+		assert.Contains(t, returned.Items[1].Comment, "`stale` label")
+	})
+
+	t.Run("labels and comments", func(t *testing.T) {
+		var labeled []string
+		var comments []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetSearchIssues, staleSearchHandler(t, "-label:inactive")),
+			mock.WithRequestMatchHandler(mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber, staleTimelineHandler(t)),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var labels []string
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+					labeled = append(labeled, r.URL.Path+" "+strings.Join(labels, ","))
+					mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("inactive")}})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.URL.Path, "/issues/2/") {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible"}`))
+						return
+					}
+					var comment github.IssueComment
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+					comments = append(comments, comment.GetBody())
+					mockResponse(t, http.StatusCreated, &comment)(w, r)
+				}),
+			),
+		))
+		_, handler := MarkStaleItems(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"days":        float64(30),
+			"stale_label": "inactive",
+			"comment":     "@{author}, closing soon.",
+			"dry_run":     false,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned StaleSweepResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.False(t, returned.DryRun)
+		assert.Equal(t, 1, returned.Marked)
+		assert.Equal(t, 1, returned.Failed)
+		require.Len(t, returned.Items, 2)
+		assert.Equal(t, "marked", returned.Items[0].Action)
+		assert.Equal(t, "failed", returned.Items[1].Action)
+		assert.Contains(t, returned.Items[1].Error, "failed to add comment")
+
+		assert.ElementsMatch(t, []string{"/repos/owner/repo/issues/1/labels inactive", "/repos/owner/repo/issues/2/labels inactive"}, labeled)
+		assert.Equal(t, []string{"@alice, closing soon."}, comments)
+	})
+}
@@ -0,0 +1,322 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OrgTeam is a team of an organization, as listed by the list_teams tool.
+type OrgTeam struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Privacy is secret, visible only to its members and organization owners, or closed, visible
+	// to all members of the organization.
+	Privacy string `json:"privacy"`
+	Parent  string `json:"parent,omitempty"`
+}
+
+// TeamRepository is a repository a team has access to, with the permission the team has on it.
+type TeamRepository struct {
+	FullName   string `json:"full_name"`
+	Permission string `json:"permission"`
+	Private    bool   `json:"private"`
+	Archived   bool   `json:"archived,omitempty"`
+}
+
+// TeamRepoAccess is the access of a team to a repository, after it was granted or revoked.
+type TeamRepoAccess struct {
+	Team       string `json:"team"`
+	Repository string `json:"repository"`
+	// Permission is read, triage, write, maintain or admin, or none once access is revoked.
+	Permission string `json:"permission"`
+}
+
+// legacyRepositoryPermission maps the names of repository roles to the permission names the teams
+// API expects.
+func legacyRepositoryPermission(permission string) string {
+	switch permission {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	}
+	return permission
+}
+
+// ListTeams creates a tool to list the teams of an organization.
+func ListTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_teams",
+			mcp.WithDescription(t("TOOL_LIST_TEAMS_DESCRIPTION", "List the teams of a GitHub organization that are visible to the authenticated user, with their slug, name and privacy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAMS_USER_TITLE", "List organization teams"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			teams, resp, err := client.Teams.ListTeams(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list teams", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]OrgTeam, 0, len(teams))
+			for _, team := range teams {
+				result = append(result, OrgTeam{
+					Slug:        team.GetSlug(),
+					Name:        team.GetName(),
+					Description: team.GetDescription(),
+					Privacy:     team.GetPrivacy(),
+					Parent:      team.GetParent().GetSlug(),
+				})
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// ListTeamRepos creates a tool to list the repositories a team has access to.
+func ListTeamRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_repos",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REPOS_DESCRIPTION", "List the repositories a team of a GitHub organization has access to, with the permission the team has on each: read, triage, write, maintain, admin, or the name of a custom repository role.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_REPOS_USER_TITLE", "List team repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team, as returned by list_teams"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Teams.ListTeamReposBySlug(ctx, org, teamSlug, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list team repositories", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]TeamRepository, 0, len(repos))
+			for _, repo := range repos {
+				permission := repo.GetRoleName()
+				if permission == "" {
+					permission = highestRepositoryPermission(repo.GetPermissions())
+				}
+				result = append(result, TeamRepository{
+					FullName:   repo.GetFullName(),
+					Permission: permission,
+					Private:    repo.GetPrivate(),
+					Archived:   repo.GetArchived(),
+				})
+			}
+
+			return withNextPageToken(MarshalledTextResult(result), nextRESTPageToken(resp, pagination.PerPage)), nil
+		}
+}
+
+// AddTeamRepo creates a tool to grant a team access to a repository, or change the access it has.
+func AddTeamRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_repo",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_REPO_DESCRIPTION", "Grant a team of a GitHub organization access to a repository, or change the permission it has on it. The repository must belong to the organization. Returns the resulting access of the team.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_TEAM_REPO_USER_TITLE", "Grant team access to repository"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team, as returned by list_teams"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("permission",
+				mcp.Required(),
+				mcp.Description("Permission to grant the team on the repository"),
+				mcp.Enum(repositoryPermissions...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := RequiredParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(repositoryPermissions, permission) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid permission %q, must be one of %s", permission, strings.Join(repositoryPermissions, ", "))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.AddTeamRepoBySlug(ctx, org, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{
+				Permission: legacyRepositoryPermission(permission),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add team repository", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			// The grant returns no content, so the resulting access is read back
+			repository, resp, err := client.Teams.IsTeamRepoBySlug(ctx, org, teamSlug, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get team repository access", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			granted := repository.GetRoleName()
+			if granted == "" {
+				granted = highestRepositoryPermission(repository.GetPermissions())
+			}
+			return MarshalledTextResult(TeamRepoAccess{
+				Team:       teamSlug,
+				Repository: repository.GetFullName(),
+				Permission: granted,
+			}), nil
+		}
+}
+
+// RemoveTeamRepo creates a tool to revoke the access of a team to a repository.
+func RemoveTeamRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_repo",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_REPO_DESCRIPTION", "Revoke the access a team of a GitHub organization has to a repository. Members of the team keep any access they have through other teams or as collaborators.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_REPO_USER_TITLE", "Revoke team access to repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+				IdempotentHint:  ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description(DescriptionOrganization),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team, as returned by list_teams"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.RemoveTeamRepoBySlug(ctx, org, teamSlug, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove team repository", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(TeamRepoAccess{
+				Team:       teamSlug,
+				Repository: fmt.Sprintf("%s/%s", owner, repo),
+				Permission: "none",
+			}), nil
+		}
+}
@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       []OrgTeam
+	}{
+		{
+			name: "lists teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Team{
+							{
+								Slug:        github.Ptr("platform"),
+								Name:        github.Ptr("Platform"),
+								Description: github.Ptr("Keeps the lights on"),
+								Privacy:     github.Ptr("closed"),
+							},
+							{
+								Slug:    github.Ptr("platform-security"),
+								Name:    github.Ptr("Platform Security"),
+								Privacy: github.Ptr("secret"),
+								Parent:  &github.Team{Slug: github.Ptr("platform")},
+							},
+						}),
+					),
+				),
+			),
+			expected: []OrgTeam{
+				{Slug: "platform", Name: "Platform", Description: "Keeps the lights on", Privacy: "closed"},
+				{Slug: "platform-security", Name: "Platform Security", Privacy: "secret", Parent: "platform"},
+			},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list teams",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var teams []OrgTeam
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &teams))
+			assert.Equal(t, tc.expected, teams)
+		})
+	}
+}
+
+func Test_ListTeamRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsTeamsReposByOrgByTeamSlug,
+			expectPath(t, "/orgs/octo-org/teams/platform/repos").andThen(
+				mockResponse(t, http.StatusOK, []*github.Repository{
+					{
+						FullName:    github.Ptr("octo-org/api"),
+						Private:     github.Ptr(true),
+						Permissions: map[string]bool{"admin": false, "maintain": false, "push": true, "triage": true, "pull": true},
+					},
+					{
+						FullName:    github.Ptr("octo-org/deploy"),
+						Private:     github.Ptr(true),
+						Permissions: map[string]bool{"admin": true, "maintain": true, "push": true, "triage": true, "pull": true},
+						RoleName:    github.Ptr("admin"),
+					},
+					{
+						FullName:    github.Ptr("octo-org/docs"),
+						Archived:    github.Ptr(true),
+						Permissions: map[string]bool{"pull": true},
+						RoleName:    github.Ptr("docs-reviewer"),
+					},
+				}),
+			),
+		),
+	)
+	_, handler := ListTeamRepos(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org", "team_slug": "platform"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var repos []TeamRepository
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repos))
+	assert.Equal(t, []TeamRepository{
+		{FullName: "octo-org/api", Permission: "write", Private: true},
+		{FullName: "octo-org/deploy", Permission: "admin", Private: true},
+		// Custom repository roles are reported by name
+		{FullName: "octo-org/docs", Permission: "docs-reviewer", Archived: true},
+	}, repos)
+
+	// A missing team slug is reported before any request is made
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: team_slug")
+}
+
+func Test_AddTeamRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_team_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "owner", "repo", "permission"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		permission     string
+		expectError    bool
+		expectedErrMsg string
+		expected       TeamRepoAccess
+	}{
+		{
+			name: "grants write access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"permission": "push"}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectPath(t, "/orgs/octo-org/teams/platform/repos/octo-org/api").andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							FullName:    github.Ptr("octo-org/api"),
+							Permissions: map[string]bool{"admin": false, "maintain": false, "push": true, "triage": true, "pull": true},
+						}),
+					),
+				),
+			),
+			permission: "write",
+			expected:   TeamRepoAccess{Team: "platform", Repository: "octo-org/api", Permission: "write"},
+		},
+		{
+			name:           "invalid permission",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			permission:     "owner",
+			expectError:    true,
+			expectedErrMsg: `invalid permission "owner"`,
+		},
+		{
+			name: "repository not owned by the organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			permission:     "read",
+			expectError:    true,
+			expectedErrMsg: "failed to add team repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":        "octo-org",
+				"team_slug":  "platform",
+				"owner":      "octo-org",
+				"repo":       "api",
+				"permission": tc.permission,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var access TeamRepoAccess
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &access))
+			assert.Equal(t, tc.expected, access)
+		})
+	}
+}
+
+func Test_RemoveTeamRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_team_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "owner", "repo"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+			expectPath(t, "/orgs/octo-org/teams/platform/repos/octo-org/api").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	)
+	_, handler := RemoveTeamRepo(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":       "octo-org",
+		"team_slug": "platform",
+		"owner":     "octo-org",
+		"repo":      "api",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var access TeamRepoAccess
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &access))
+	assert.Equal(t, TeamRepoAccess{Team: "platform", Repository: "octo-org/api", Permission: "none"}, access)
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/tokens"
+	"github.com/google/go-github/v74/github"
+)
+
+// TokenPermissionsConfig controls how get_me introspects the repository permissions of fine-grained
+// personal access tokens, which do not report scopes.
+type TokenPermissionsConfig struct {
+	// SkipProbe disables probing, so that get_me makes no requests beyond getting the user.
+	SkipProbe bool
+	// EnabledToolsets returns the names of the enabled toolsets, whose permissions are probed. If nil,
+	// all permissions are probed.
+	EnabledToolsets func() []string
+}
+
+// permissionProbe checks a repository permission with a cheap read-only request.
+type permissionProbe struct {
+	// permission is named as in the X-Accepted-GitHub-Permissions header.
+	permission string
+	// toolset is the toolset whose tools need the permission.
+	toolset string
+	probe   func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error)
+}
+
+var permissionProbes = []permissionProbe{
+	{
+		permission: "contents",
+		toolset:    "repos",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+	{
+		permission: "issues",
+		toolset:    "issues",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+	{
+		permission: "pull_requests",
+		toolset:    "pull_requests",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+	{
+		permission: "actions",
+		toolset:    "actions",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+	{
+		permission: "security_events",
+		toolset:    "code_security",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+	{
+		permission: "secret_scanning_alerts",
+		toolset:    "secret_protection",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, &github.SecretScanningAlertListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+	{
+		permission: "vulnerability_alerts",
+		toolset:    "dependabot",
+		probe: func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{ListOptions: github.ListOptions{PerPage: 1}})
+			return resp, err
+		},
+	},
+}
+
+// probeTokenPermissions finds which repository permissions the token of client has, for the enabled
+// toolsets, on the repository it can access that was most recently updated. Fine-grained tokens are
+// usually granted the same permissions on all of their repositories, so one is representative.
+// All requests are reads.
+func probeTokenPermissions(ctx context.Context, client *github.Client, enabledToolsets []string) *tokens.Permissions {
+	permissions := &tokens.Permissions{
+		Permissions: []tokens.Permission{},
+		ProbedAt:    time.Now(),
+	}
+
+	repos, _, err := client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+		Sort:        "updated",
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil || len(repos) == 0 {
+		return permissions
+	}
+	owner, repo := repos[0].GetOwner().GetLogin(), repos[0].GetName()
+	permissions.Repository = repos[0].GetFullName()
+
+	var probes []permissionProbe
+	for _, probe := range permissionProbes {
+		if enabledToolsets == nil || slices.Contains(enabledToolsets, probe.toolset) {
+			probes = append(probes, probe)
+		}
+	}
+
+	results := make([]tokens.Permission, len(probes))
+	tasks := make([]func(context.Context) error, len(probes))
+	for i, probe := range probes {
+		// Probes skipped when ctx is cancelled stay unknown.
+		results[i] = tokens.Permission{Name: probe.permission, Access: tokens.AccessUnknown}
+		tasks[i] = func(ctx context.Context) error {
+			resp, err := probe.probe(ctx, client, owner, repo)
+			results[i] = probedPermission(probe.permission, resp, err)
+			return nil
+		}
+	}
+	_ = runBounded(ctx, maxConcurrentRequests, tasks...)
+
+	// Metadata is granted to every fine-grained token on the repositories it can access.
+	permissions.Permissions = append(permissions.Permissions, tokens.Permission{Name: "metadata", Access: tokens.AccessRead})
+	permissions.Permissions = append(permissions.Permissions, results...)
+	return permissions
+}
+
+// probedPermission interprets the response to a probe. A 403 means the token lacks the permission,
+// other failures, such as a 404 for a feature that is not enabled, leave it unknown.
+func probedPermission(name string, resp *github.Response, err error) tokens.Permission {
+	switch {
+	case err == nil:
+		return tokens.Permission{Name: name, Access: tokens.AccessRead}
+	case resp != nil && resp.StatusCode == http.StatusForbidden:
+		return tokens.Permission{Name: name, Access: tokens.AccessNone}
+	case resp != nil:
+		return tokens.Permission{Name: name, Access: tokens.AccessUnknown, Status: resp.StatusCode}
+	default:
+		return tokens.Permission{Name: name, Access: tokens.AccessUnknown}
+	}
+}
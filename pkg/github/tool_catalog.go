@@ -0,0 +1,91 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+)
+
+// CatalogTool describes a tool offered by the server, for clients to generate validation and
+// documentation from.
+type CatalogTool struct {
+	Name        string `json:"name"`
+	Toolset     string `json:"toolset"`
+	Description string `json:"description"`
+	// ReadOnly is whether the tool is annotated as only reading, so that it is offered in read-only mode.
+	ReadOnly    bool            `json:"read_only"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+// ToolCatalog lists the tools of the toolsets added to it that are enabled, so that it reflects
+// the toolsets enabled and disabled at runtime.
+type ToolCatalog struct {
+	mu       sync.Mutex
+	toolsets []*toolsets.Toolset
+	// group is the toolset group that enables and disables the toolsets at runtime.
+	group *toolsets.ToolsetGroup
+}
+
+// NewToolCatalog creates an empty tool catalog.
+func NewToolCatalog() *ToolCatalog {
+	return &ToolCatalog{}
+}
+
+// Add adds toolsets to the catalog, which are enabled and disabled at runtime through group.
+func (c *ToolCatalog) Add(group *toolsets.ToolsetGroup, ts ...*toolsets.Toolset) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.group = group
+	c.toolsets = append(c.toolsets, ts...)
+}
+
+// Tools returns the active tools of the toolsets in the catalog, sorted by name so that catalogs
+// can be diffed.
+func (c *ToolCatalog) Tools() ([]CatalogTool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tools := []CatalogTool{}
+	for _, toolset := range c.toolsets {
+		for _, serverTool := range c.group.ActiveTools(toolset) {
+			tool := serverTool.Tool
+			schema := tool.RawInputSchema
+			if schema == nil {
+				var err error
+				if schema, err = json.Marshal(tool.InputSchema); err != nil {
+					return nil, fmt.Errorf("failed to marshal input schema of %s: %w", tool.Name, err)
+				}
+			}
+			tools = append(tools, CatalogTool{
+				Name:        tool.Name,
+				Toolset:     toolset.Name,
+				Description: tool.Description,
+				ReadOnly:    tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint,
+				InputSchema: schema,
+			})
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Name != tools[j].Name {
+			return tools[i].Name < tools[j].Name
+		}
+		return tools[i].Toolset < tools[j].Toolset
+	})
+	return tools, nil
+}
+
+// MarshalIndent marshals the tools of the catalog as an indented JSON array, followed by a newline.
+func (c *ToolCatalog) MarshalIndent() ([]byte, error) {
+	tools, err := c.Tools()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(tools, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool catalog: %w", err)
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ToolCatalog(t *testing.T) {
+	getClient := stubGetClientFn(nil)
+	repos := toolsets.NewToolset("repos", "Repositories").
+		AddReadTools(toolsets.NewServerTool(ListBranches(getClient, translations.NullTranslationHelper))).
+		AddWriteTools(toolsets.NewServerTool(CreateBranch(getClient, translations.NullTranslationHelper)))
+	issues := toolsets.NewToolset("issues", "Issues").
+		AddReadTools(toolsets.NewServerTool(GetIssue(getClient, translations.NullTranslationHelper)))
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(repos)
+	tsg.AddToolset(issues)
+	require.NoError(t, tsg.EnableToolset("repos"))
+
+	catalog := NewToolCatalog()
+	catalog.Add(tsg, repos, issues)
+
+	tools, err := catalog.Tools()
+	require.NoError(t, err)
+	require.Len(t, tools, 2)
+	assert.Equal(t, "create_branch", tools[0].Name)
+	assert.Equal(t, "repos", tools[0].Toolset)
+	assert.False(t, tools[0].ReadOnly)
+	assert.Equal(t, "list_branches", tools[1].Name)
+	assert.True(t, tools[1].ReadOnly)
+
+	var schema struct {
+		Type     string         `json:"type"`
+		Required []string       `json:"required"`
+		Props    map[string]any `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(tools[1].InputSchema, &schema))
+	assert.Equal(t, "object", schema.Type)
+	assert.ElementsMatch(t, []string{"owner", "repo"}, schema.Required)
+	assert.Contains(t, schema.Props, "protected")
+
+	// Tools of toolsets enabled later are listed
+	require.NoError(t, tsg.EnableToolset("issues"))
+	tools, err = catalog.Tools()
+	require.NoError(t, err)
+	require.Len(t, tools, 3)
+	assert.Equal(t, "get_issue", tools[1].Name)
+	assert.Equal(t, "issues", tools[1].Toolset)
+}
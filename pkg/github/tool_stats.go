@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolStatsMaxLatencies bounds the number of latencies kept per tool to compute percentiles over, so
+// that a long running server does not grow without bound. The most recent calls are kept.
+const toolStatsMaxLatencies = 1000
+
+// ToolUsage is the usage of a tool since the server started.
+type ToolUsage struct {
+	Name   string `json:"name"`
+	Calls  int    `json:"calls"`
+	Errors int    `json:"errors"`
+	// P50Ms and P95Ms are latency percentiles in milliseconds, over the most recent calls.
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+}
+
+// ToolStatsReport is the JSON report of the tool usage recorded by ToolStats.
+type ToolStatsReport struct {
+	Since time.Time   `json:"since"`
+	Until time.Time   `json:"until"`
+	Tools []ToolUsage `json:"tools"`
+}
+
+// toolCounters are the counters of a tool. latencies is a ring buffer of the most recent latencies.
+type toolCounters struct {
+	calls     int
+	errors    int
+	latencies []time.Duration
+	next      int
+}
+
+func (c *toolCounters) record(latency time.Duration, failed bool) {
+	c.calls++
+	if failed {
+		c.errors++
+	}
+	if len(c.latencies) < toolStatsMaxLatencies {
+		c.latencies = append(c.latencies, latency)
+		return
+	}
+	c.latencies[c.next] = latency
+	c.next = (c.next + 1) % toolStatsMaxLatencies
+}
+
+// ToolStats records, in memory only, how often each tool is called, how often it fails and how long
+// it takes, so that operators can see which tools are used. It records tool calls through server hooks.
+type ToolStats struct {
+	mu       sync.Mutex
+	since    time.Time
+	started  map[string]time.Time
+	counters map[string]*toolCounters
+	now      func() time.Time
+}
+
+// NewToolStats creates an empty tool usage recorder.
+func NewToolStats() *ToolStats {
+	return &ToolStats{
+		since:    time.Now(),
+		started:  map[string]time.Time{},
+		counters: map[string]*toolCounters{},
+		now:      time.Now,
+	}
+}
+
+// callKey identifies a tool call by its session and request ID, as request IDs are only unique
+// within a session.
+func callKey(ctx context.Context, id any) string {
+	return sessionIDFromContext(ctx) + "\x00" + fmt.Sprint(id)
+}
+
+// AddHooks adds the hooks that record tool calls to hooks.
+func (s *ToolStats) AddHooks(hooks *server.Hooks) {
+	hooks.AddBeforeCallTool(func(ctx context.Context, id any, _ *mcp.CallToolRequest) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.started[callKey(ctx, id)] = s.now()
+	})
+	hooks.AddAfterCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest, result *mcp.CallToolResult) {
+		s.finish(ctx, id, message.Params.Name, result == nil || result.IsError)
+	})
+	hooks.AddOnError(func(ctx context.Context, id any, method mcp.MCPMethod, message any, _ error) {
+		if method != mcp.MethodToolsCall {
+			return
+		}
+		if request, ok := message.(*mcp.CallToolRequest); ok {
+			s.finish(ctx, id, request.Params.Name, true)
+		}
+	})
+}
+
+// finish records the end of a tool call. Calls that were not seen starting, such as those that
+// could not be parsed, are not recorded.
+func (s *ToolStats) finish(ctx context.Context, id any, name string, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := callKey(ctx, id)
+	start, ok := s.started[key]
+	if !ok {
+		return
+	}
+	delete(s.started, key)
+
+	counters, ok := s.counters[name]
+	if !ok {
+		counters = &toolCounters{}
+		s.counters[name] = counters
+	}
+	counters.record(s.now().Sub(start), failed)
+}
+
+// percentileMs returns the pth percentile of sorted latencies in milliseconds, by the nearest rank
+// method, rounded to one decimal.
+func percentileMs(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	latency := sorted[max(rank-1, 0)]
+	return math.Round(float64(latency)/float64(time.Millisecond)*10) / 10
+}
+
+// Report returns the usage of the tools called so far, the most called first.
+func (s *ToolStats) Report() ToolStatsReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := ToolStatsReport{Since: s.since, Until: s.now(), Tools: make([]ToolUsage, 0, len(s.counters))}
+	for name, counters := range s.counters {
+		sorted := append([]time.Duration(nil), counters.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		report.Tools = append(report.Tools, ToolUsage{
+			Name:   name,
+			Calls:  counters.calls,
+			Errors: counters.errors,
+			P50Ms:  percentileMs(sorted, 50),
+			P95Ms:  percentileMs(sorted, 95),
+		})
+	}
+	sort.Slice(report.Tools, func(i, j int) bool {
+		if report.Tools[i].Calls != report.Tools[j].Calls {
+			return report.Tools[i].Calls > report.Tools[j].Calls
+		}
+		return report.Tools[i].Name < report.Tools[j].Name
+	})
+	return report
+}
+
+// WriteTable writes the usage of the tools called so far to w as a table.
+func (s *ToolStats) WriteTable(w io.Writer) error {
+	report := s.Report()
+	if len(report.Tools) == 0 {
+		_, err := fmt.Fprintln(w, "No tools were called")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TOOL\tCALLS\tERRORS\tP50 MS\tP95 MS")
+	for _, tool := range report.Tools {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.1f\n", tool.Name, tool.Calls, tool.Errors, tool.P50Ms, tool.P95Ms)
+	}
+	return tw.Flush()
+}
+
+// MarshalIndent marshals the usage of the tools called so far as indented JSON, followed by a newline.
+func (s *ToolStats) MarshalIndent() ([]byte, error) {
+	data, err := json.MarshalIndent(s.Report(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool stats: %w", err)
+	}
+	return append(data, '\n'), nil
+}
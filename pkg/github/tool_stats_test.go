@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ToolStats(t *testing.T) {
+	stats := NewToolStats()
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats.now = func() time.Time { return clock }
+
+	hooks := &server.Hooks{}
+	stats.AddHooks(hooks)
+	s := server.NewMCPServer("test", "1.0", server.WithHooks(hooks))
+	// The tool takes as many milliseconds as asked, and fails as asked
+	s.AddTool(mcp.NewTool("work", mcp.WithNumber("ms"), mcp.WithString("fail")), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ms, _ := OptionalIntParam(request, "ms")
+		clock = clock.Add(time.Duration(ms) * time.Millisecond)
+		switch fail, _ := OptionalParam[string](request, "fail"); fail {
+		case "result":
+			return mcp.NewToolResultError("failed"), nil
+		case "error":
+			return nil, errors.New("failed")
+		}
+		return mcp.NewToolResultText("done"), nil
+	})
+	s.AddTool(mcp.NewTool("other"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	call := func(id int, name string, args map[string]any) {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": id, "method": "tools/call",
+			"params": map[string]any{"name": name, "arguments": args},
+		})
+		require.NoError(t, err)
+		s.HandleMessage(context.Background(), message)
+	}
+	for i := 1; i <= 18; i++ {
+		call(i, "work", map[string]any{"ms": float64(i * 10)})
+	}
+	call(19, "work", map[string]any{"ms": float64(1000), "fail": "result"})
+	call(20, "work", map[string]any{"ms": float64(2000), "fail": "error"})
+	call(21, "other", nil)
+	// Malformed calls are not recorded
+	s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":22,"method":"tools/call","params":"oops"}`))
+
+	report := stats.Report()
+	assert.Equal(t, clock, report.Until)
+	assert.Equal(t, []ToolUsage{
+		{Name: "work", Calls: 20, Errors: 2, P50Ms: 100, P95Ms: 1000},
+		{Name: "other", Calls: 1},
+	}, report.Tools)
+
+	var table strings.Builder
+	require.NoError(t, stats.WriteTable(&table))
+	assert.Equal(t, strings.Join([]string{
+		"TOOL   CALLS  ERRORS  P50 MS  P95 MS",
+		"work   20     2       100.0   1000.0",
+		"other  1      0       0.0     0.0",
+		"",
+	}, "\n"), table.String())
+
+	data, err := stats.MarshalIndent()
+	require.NoError(t, err)
+	var decoded ToolStatsReport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report.Tools, decoded.Tools)
+}
+
+func Test_ToolStatsKeepsRecentLatencies(t *testing.T) {
+	counters := &toolCounters{}
+	for i := 0; i < toolStatsMaxLatencies+10; i++ {
+		counters.record(time.Duration(i), false)
+	}
+	assert.Equal(t, toolStatsMaxLatencies+10, counters.calls)
+	require.Len(t, counters.latencies, toolStatsMaxLatencies)
+	// The oldest latencies were replaced
+	assert.Equal(t, time.Duration(toolStatsMaxLatencies), counters.latencies[0])
+	assert.Equal(t, time.Duration(10), counters.latencies[10])
+}
+
+func Test_ToolStatsWithoutCalls(t *testing.T) {
+	var table strings.Builder
+	require.NoError(t, NewToolStats().WriteTable(&table))
+	assert.Equal(t, "No tools were called\n", table.String())
+	assert.Equal(t, []ToolUsage{}, NewToolStats().Report().Tools)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/scim"
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
@@ -16,8 +17,11 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, getSCIMClient scim.GetSCIMClientFn, t translations.TranslationHelperFunc, assets AssetsConfig, tokenPermissions TokenPermissionsConfig, requestBudget *RequestBudget, graphQLCache *GraphQLCache, repoDefaults *RepositoryDefaults) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
+	if tokenPermissions.EnabledToolsets == nil {
+		tokenPermissions.EnabledToolsets = tsg.EnabledToolsets
+	}
 
 	// Define all available features with their default state (disabled)
 	// Create toolsets
@@ -25,22 +29,48 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetRawContentURL(getClient, getRawClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(GetCommitCount(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(GetGitBlob(getClient, t)),
+			toolsets.NewServerTool(GetGitTree(getClient, t)),
+			toolsets.NewServerTool(GetGitCommit(getClient, t)),
+			toolsets.NewServerTool(VerifyCommitSignature(getClient, t)),
+			toolsets.NewServerTool(GetCommitVerification(getClient, t)),
+			toolsets.NewServerTool(GetGitRef(getClient, t)),
+			toolsets.NewServerTool(ListGitRefs(getClient, t)),
 			toolsets.NewServerTool(ListReleases(getClient, t)),
 			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
+			toolsets.NewServerTool(GenerateChangelog(getClient, t)),
+			toolsets.NewServerTool(GetCommunityProfile(getClient, t)),
+			toolsets.NewServerTool(GetSecurityPolicy(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetCodeowners(getClient, t)),
+			toolsets.NewServerTool(ListContributors(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryPermissions(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryAccessReport(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryLicense(getClient, t)),
+			toolsets.NewServerTool(ListKnownLicenses(getClient, t)),
+			toolsets.NewServerTool(GetRepoOverview(getClient, repoDefaults, t)),
+			toolsets.NewServerTool(ListRepoEvents(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
+			toolsets.NewServerTool(PutFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
+			toolsets.NewServerTool(CreateRepoFromTemplate(getClient, t)),
+			toolsets.NewServerTool(SetRepoAsTemplate(getClient, t)),
+			toolsets.NewServerTool(UnsetRepoAsTemplate(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(RenameFile(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -48,15 +78,23 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceCommitContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceTagContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourcePrContent(getClient, getRawClient, t)),
-		)
+		).AddPrompts(
+		toolsets.NewServerPrompt(DraftReleaseNotesPrompt(t)),
+	)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
+			toolsets.NewServerTool(GetIssuesBatch(getClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getGQLClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
 			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
 			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(GetLinkedPRsForIssue(getClient, t)),
+			toolsets.NewServerTool(GetIssueDependencies(getGQLClient, t)),
+			toolsets.NewServerTool(ListIssueTemplates(getClient, t)),
+			toolsets.NewServerTool(GetIssueMetrics(getClient, t)),
+			toolsets.NewServerTool(FindStaleItems(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
@@ -66,21 +104,68 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+			toolsets.NewServerTool(UploadIssueAttachment(getClient, getRawClient, assets, t)),
+			toolsets.NewServerTool(MarkStaleItems(getClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
+		toolsets.NewServerPrompt(TriageIssuePrompt(t)),
 	)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(ListUserGPGKeys(getClient, t)),
+			toolsets.NewServerTool(ListUserSSHSigningKeys(getClient, t)),
+			toolsets.NewServerTool(ListFollowers(getGQLClient, t)),
+			toolsets.NewServerTool(ListFollowing(getGQLClient, t)),
+			toolsets.NewServerTool(CheckUserFollows(getClient, t)),
+			toolsets.NewServerTool(ListUserOrganizations(getClient, t)),
+			toolsets.NewServerTool(ListUserEvents(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(FollowUser(getClient, t)),
+			toolsets.NewServerTool(UnfollowUser(getClient, t)),
 		)
 	orgs := toolsets.NewToolset("orgs", "GitHub Organization related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(SearchAcrossOrg(getClient, t)),
+			toolsets.NewServerTool(ListOrgEvents(getClient, t)),
+			toolsets.NewServerTool(ListTeams(getClient, t)),
+			toolsets.NewServerTool(ListTeamRepos(getClient, t)),
+			toolsets.NewServerTool(ListOrgInstallations(getClient, t)),
+			toolsets.NewServerTool(GetCommitSigningRequirement(getClient, t)),
+			toolsets.NewServerTool(GetActionsBillingOrg(getClient, t)),
+			toolsets.NewServerTool(GetPackagesBillingOrg(getClient, t)),
+			toolsets.NewServerTool(GetStorageBillingOrg(getClient, t)),
+			toolsets.NewServerTool(GetOrgBillingSummary(getClient, t)),
+			// The migration tools were here under these names before they moved to the migrations toolset
+			deprecatedAlias("get_migration_status", toolsets.NewServerTool(GetRepoMigration(getClient, t))),
+			deprecatedAlias("download_migration_archive", toolsets.NewServerTool(DownloadRepoMigrationArchive(getClient, t))),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddTeamRepo(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamRepo(getClient, t)),
+			deprecatedAlias("start_repository_migration", toolsets.NewServerTool(StartRepoMigration(getClient, t))),
+			deprecatedAlias("unlock_repository_after_migration", toolsets.NewServerTool(UnlockRepoForMigration(getClient, t))),
+		)
+	migrations := toolsets.NewToolset("migrations", "Organization migration tools, for exporting repositories into archives to move them to another organization or GitHub instance").
+		AddReadTools(
+			toolsets.NewServerTool(ListRepoMigrations(getClient, t)),
+			toolsets.NewServerTool(GetRepoMigration(getClient, t)),
+			toolsets.NewServerTool(ListReposForMigration(getClient, t)),
+			toolsets.NewServerTool(DownloadRepoMigrationArchive(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(StartRepoMigration(getClient, t)),
+			toolsets.NewServerTool(DeleteRepoMigrationArchive(getClient, t)),
+			toolsets.NewServerTool(UnlockRepoForMigration(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetPullRequest(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestReviewPacket(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(GetLinkedIssuesForPR(getGQLClient, t)),
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
@@ -88,21 +173,31 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(GetMergeConflicts(getClient, t)),
+			toolsets.NewServerTool(CanCommentOnLine(getClient, t)),
+			toolsets.NewServerTool(ListPRChecklistItems(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, t)),
+			toolsets.NewServerTool(UpdatePullRequestBase(getClient, t)),
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(LinkIssueToPR(getGQLClient, t)),
+			toolsets.NewServerTool(UnlinkIssueFromPR(getGQLClient, t)),
+			toolsets.NewServerTool(CompletePRChecklistItem(getClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
+			toolsets.NewServerTool(SubmitPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(CreatePendingPullRequestReview(getGQLClient, t)),
-			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
+			toolsets.NewServerTool(AddCommentToPendingReview(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
-		)
+		).AddPrompts(
+		toolsets.NewServerPrompt(SummarizePullRequestPrompt(t)),
+	)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
@@ -118,6 +213,10 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetDependabotAlert(getClient, t)),
 			toolsets.NewServerTool(ListDependabotAlerts(getClient, t)),
 		)
+	dependencies := toolsets.NewToolset("dependencies", "Dependency graph tools, such as submitting the dependencies found by a build").
+		AddWriteTools(
+			toolsets.NewServerTool(SubmitDependencySnapshot(getClient, t)),
+		)
 
 	notifications := toolsets.NewToolset("notifications", "GitHub Notifications related tools").
 		AddReadTools(
@@ -142,21 +241,93 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
 		AddReadTools(
 			toolsets.NewServerTool(ListWorkflows(getClient, t)),
+			toolsets.NewServerTool(GetWorkflow(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowFile(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRuns(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(WaitForWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(WaitForChecks(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
+			toolsets.NewServerTool(ListWorkflowRunJobs(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(ValidateWorkflowFile(getClient, t)),
+			toolsets.NewServerTool(AuditWorkflowActions(getClient, getRawClient, t)),
+			toolsets.NewServerTool(ListReusableWorkflows(getClient, getRawClient, t)),
+			toolsets.NewServerTool(ListOrgSecrets(getClient, t)),
+			toolsets.NewServerTool(GetOrgSecret(getClient, t)),
+			toolsets.NewServerTool(ListSelectedReposForOrgSecret(getClient, t)),
+			toolsets.NewServerTool(ListOrgVariables(getClient, t)),
+			toolsets.NewServerTool(GetOrgVariable(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
+			toolsets.NewServerTool(EnableWorkflow(getClient, t)),
+			toolsets.NewServerTool(DisableWorkflow(getClient, t)),
+			toolsets.NewServerTool(CallWorkflow(getClient, getRawClient, t)),
 			toolsets.NewServerTool(RerunWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateOrgSecret(getClient, t)),
+			toolsets.NewServerTool(DeleteOrgSecret(getClient, t)),
+			toolsets.NewServerTool(SetSelectedReposForOrgSecret(getClient, t)),
+			toolsets.NewServerTool(AddSelectedRepoToOrgSecret(getClient, t)),
+			toolsets.NewServerTool(RemoveSelectedRepoFromOrgSecret(getClient, t)),
+			toolsets.NewServerTool(CreateOrgVariable(getClient, t)),
+			toolsets.NewServerTool(UpdateOrgVariable(getClient, t)),
+			toolsets.NewServerTool(DeleteOrgVariable(getClient, t)),
+		)
+
+	scimTools := toolsets.NewToolset("scim", "Enterprise user provisioning with SCIM, for GitHub Enterprise Cloud and Server").
+		AddReadTools(
+			toolsets.NewServerTool(ListSCIMProvisionedIdentities(getSCIMClient, t)),
+			toolsets.NewServerTool(GetSCIMProvisionedIdentity(getSCIMClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ProvisionSCIMUser(getSCIMClient, t)),
+			toolsets.NewServerTool(UpdateSCIMUserAttributes(getSCIMClient, t)),
+			toolsets.NewServerTool(ReplaceSCIMUser(getSCIMClient, t)),
+			toolsets.NewServerTool(DeleteSCIMUser(getSCIMClient, t)),
+		)
+
+	packages := toolsets.NewToolset("packages", "GitHub Packages related tools, including container images on ghcr.io").
+		AddReadTools(
+			toolsets.NewServerTool(ListPackages(getClient, t)),
+			toolsets.NewServerTool(GetPackage(getClient, t)),
+			toolsets.NewServerTool(ListPackageVersions(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DeletePackageVersion(getClient, t)),
+			toolsets.NewServerTool(RestorePackageVersion(getClient, t)),
+		)
+
+	environments := toolsets.NewToolset("environments", "GitHub deployment environments, their secrets, variables and protection rules").
+		AddReadTools(
+			toolsets.NewServerTool(ListEnvironmentSecrets(getClient, t)),
+			toolsets.NewServerTool(ListEnvironmentVariables(getClient, t)),
+			toolsets.NewServerTool(GetEnvironmentProtectionRules(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateEnvironmentProtection(getClient, t)),
+			toolsets.NewServerTool(CreateEnvironmentVariable(getClient, t)),
+			toolsets.NewServerTool(UpdateEnvironmentVariable(getClient, t)),
+		)
+
+	enterprise := toolsets.NewToolset(EnterpriseToolsetName, "GitHub Enterprise Server administration, such as instance statistics and license, for site administrators").
+		AddReadTools(
+			toolsets.NewServerTool(GetEnterpriseAdminStats(getClient, t)),
+			toolsets.NewServerTool(GetGHESLicenseInfo(getClient, t)),
+			toolsets.NewServerTool(GetAllOrgsOnInstance(getClient, t)),
+		)
+	enterprise.OffByDefault = true
+
+	meta := toolsets.NewToolset("meta", "Tools about GitHub itself, such as its status").
+		AddReadTools(
+			toolsets.NewServerTool(GetGitHubStatus(t)),
 		)
 
 	// Keep experiments alive so the system doesn't error out when it's always enabled
@@ -164,9 +335,10 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 
 	contextTools := toolsets.NewToolset("context", "Tools that provide context about the current user and GitHub context you are operating in").
 		AddReadTools(
-			toolsets.NewServerTool(GetMe(getClient, t)),
+			toolsets.NewServerTool(GetMe(getClient, tokenPermissions, repoDefaults, t)),
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
+			toolsets.NewServerTool(GetRateLimitStatus(requestBudget, graphQLCache, t)),
 		)
 
 	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
@@ -183,16 +355,26 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(repos)
 	tsg.AddToolset(issues)
 	tsg.AddToolset(orgs)
+	tsg.AddToolset(migrations)
 	tsg.AddToolset(users)
 	tsg.AddToolset(pullRequests)
 	tsg.AddToolset(actions)
 	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
 	tsg.AddToolset(dependabot)
+	tsg.AddToolset(dependencies)
 	tsg.AddToolset(notifications)
 	tsg.AddToolset(experiments)
 	tsg.AddToolset(discussions)
 	tsg.AddToolset(gists)
+	tsg.AddToolset(scimTools)
+	tsg.AddToolset(packages)
+	tsg.AddToolset(environments)
+	tsg.AddToolset(enterprise)
+	tsg.AddToolset(meta)
+
+	// Check the arguments of all tool calls against the schema of the tool before its handler runs
+	tsg.AddToolHandlerWrapper(ValidateArguments)
 
 	return tsg
 }
@@ -0,0 +1,499 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultMaxWatchesPerSession is the number of watches a session may have at once.
+	DefaultMaxWatchesPerSession = 10
+
+	// minWatchInterval is the shortest interval watched objects are polled at, so that watches stay
+	// cheap on the rate limit.
+	minWatchInterval = time.Minute
+
+	// watchLoggerName is the logger of the logging notifications watches send.
+	watchLoggerName = "github-watch"
+)
+
+// watchKinds are the kinds of objects that can be watched.
+var watchKinds = []string{"pull_request_checks", "issue_comments", "workflow_run"}
+
+// SessionNotifier sends notifications to a given MCP session. It is implemented by *server.MCPServer.
+type SessionNotifier interface {
+	SendNotificationToSpecificClient(sessionID string, method string, params map[string]any) error
+}
+
+// WatchInfo describes a watch, as returned by the watch tools.
+type WatchInfo struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// Resource is the watched object, owner/repo#number for pull requests and issues, and
+	// owner/repo/actions/runs/id for workflow runs.
+	Resource        string         `json:"resource"`
+	IntervalSeconds int            `json:"interval_seconds"`
+	State           map[string]any `json:"state"`
+	CreatedAt       string         `json:"created_at"`
+}
+
+// watch polls an object for a session and notifies it when the state of the object changes.
+type watch struct {
+	info      WatchInfo
+	sessionID string
+	owner     string
+	repo      string
+	number    int64
+	interval  time.Duration
+	client    *github.Client
+	cancel    context.CancelFunc
+
+	// etags and bodies are the ETags and bodies of the last responses to each URL, so that requests
+	// are conditional and unchanged objects cost no rate limit.
+	etags  map[string]string
+	bodies map[string][]byte
+	// lastError is the last polling error the session was told about, so it is not told again.
+	lastError string
+}
+
+// Watches polls GitHub objects on behalf of MCP sessions, for clients that cannot receive webhooks,
+// and notifies the sessions when the objects change. Watches stop when their object reaches a
+// terminal state, and when their session ends. It is safe for concurrent use.
+type Watches struct {
+	notifier      SessionNotifier
+	maxPerSession int
+
+	mu       sync.Mutex
+	nextID   int
+	sessions map[string]map[string]*watch
+}
+
+// NewWatches creates Watches that notify sessions through notifier.
+func NewWatches(notifier SessionNotifier) *Watches {
+	return &Watches{
+		notifier:      notifier,
+		maxPerSession: DefaultMaxWatchesPerSession,
+		sessions:      make(map[string]map[string]*watch),
+	}
+}
+
+// add registers a watch whose state was polled once, and starts polling it.
+func (ws *Watches) add(ctx context.Context, w *watch) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if len(ws.sessions[w.sessionID]) >= ws.maxPerSession {
+		return fmt.Errorf("this session already has %d watches, the most it may have; cancel one with cancel_watch first", ws.maxPerSession)
+	}
+	if ws.sessions[w.sessionID] == nil {
+		ws.sessions[w.sessionID] = make(map[string]*watch)
+	}
+	ws.nextID++
+	w.info.ID = "watch-" + strconv.Itoa(ws.nextID)
+	ws.sessions[w.sessionID][w.info.ID] = w
+
+	// Polling outlives the tool call, but keeps the values of its context, such as the session
+	ctx, w.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go ws.run(ctx, w)
+	return nil
+}
+
+// list returns the watches of a session, oldest first.
+func (ws *Watches) list(sessionID string) []WatchInfo {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	infos := make([]WatchInfo, 0, len(ws.sessions[sessionID]))
+	for _, w := range ws.sessions[sessionID] {
+		infos = append(infos, w.info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimPrefix(infos[i].ID, "watch-"))
+		b, _ := strconv.Atoi(strings.TrimPrefix(infos[j].ID, "watch-"))
+		return a < b
+	})
+	return infos
+}
+
+// remove stops a watch of a session, and reports whether it existed.
+func (ws *Watches) remove(sessionID, id string) bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	w, ok := ws.sessions[sessionID][id]
+	if !ok {
+		return false
+	}
+	w.cancel()
+	delete(ws.sessions[sessionID], id)
+	if len(ws.sessions[sessionID]) == 0 {
+		delete(ws.sessions, sessionID)
+	}
+	return true
+}
+
+// Forget stops the watches of a session, when it ends.
+func (ws *Watches) Forget(sessionID string) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, w := range ws.sessions[sessionID] {
+		w.cancel()
+	}
+	delete(ws.sessions, sessionID)
+}
+
+// run polls a watch at its interval until it is stopped.
+func (ws *Watches) run(ctx context.Context, w *watch) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ws.check(ctx, w)
+		}
+	}
+}
+
+// check polls a watch once, notifies its session if the state of the object changed, and stops the
+// watch once the object reaches a terminal state.
+func (ws *Watches) check(ctx context.Context, w *watch) {
+	state, stopReason, resp, err := w.poll(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			ws.notify(w, mcp.LoggingLevelWarning, map[string]any{"stopped": true, "reason": "the watched object no longer exists or is no longer accessible"})
+			ws.remove(w.sessionID, w.info.ID)
+			return
+		}
+		if err.Error() != w.lastError {
+			w.lastError = err.Error()
+			ws.notify(w, mcp.LoggingLevelWarning, map[string]any{"error": fmt.Sprintf("failed to poll: %v", err)})
+		}
+		return
+	}
+	w.lastError = ""
+
+	if state != nil && !reflect.DeepEqual(state, w.info.State) {
+		data := map[string]any{"state": state, "previous_state": w.info.State}
+		if stopReason != "" {
+			data["stopped"], data["reason"] = true, stopReason
+		}
+		ws.mu.Lock()
+		w.info.State = state
+		ws.mu.Unlock()
+		ws.notify(w, mcp.LoggingLevelInfo, data)
+	}
+	if stopReason != "" {
+		ws.remove(w.sessionID, w.info.ID)
+	}
+}
+
+// notify sends a logging notification about a watch to its session. Watches of sessions that are
+// gone are stopped.
+func (ws *Watches) notify(w *watch, level mcp.LoggingLevel, data map[string]any) {
+	data["watch_id"], data["kind"], data["resource"] = w.info.ID, w.info.Kind, w.info.Resource
+	err := ws.notifier.SendNotificationToSpecificClient(w.sessionID, "notifications/message", map[string]any{
+		"level":  level,
+		"logger": watchLoggerName,
+		"data":   data,
+	})
+	if errors.Is(err, server.ErrSessionNotFound) {
+		ws.Forget(w.sessionID)
+	}
+}
+
+// poll gets the state of the watched object. It returns a nil state if nothing changed since the
+// last poll, and the reason to stop watching if the object reached a terminal state.
+func (w *watch) poll(ctx context.Context) (map[string]any, string, *github.Response, error) {
+	base := fmt.Sprintf("repos/%s/%s", w.owner, w.repo)
+	switch w.info.Kind {
+	case "pull_request_checks":
+		var pr github.PullRequest
+		prChanged, resp, err := w.get(ctx, fmt.Sprintf("%s/pulls/%d", base, w.number), &pr)
+		if err != nil {
+			return nil, "", resp, err
+		}
+		var checks github.ListCheckRunsResults
+		checksURL := fmt.Sprintf("%s/commits/%s/check-runs?per_page=100", base, pr.GetHead().GetSHA())
+		checksChanged, resp, err := w.get(ctx, checksURL, &checks)
+		if err != nil {
+			return nil, "", resp, err
+		}
+		w.forgetOtherURLs(fmt.Sprintf("%s/pulls/%d", base, w.number), checksURL)
+		if !prChanged && !checksChanged {
+			return nil, "", nil, nil
+		}
+
+		state := map[string]any{"state": pr.GetState(), "head_sha": pr.GetHead().GetSHA(), "checks": checkRunStates(checks.CheckRuns)}
+		switch {
+		case pr.GetMerged():
+			state["state"] = "merged"
+			return state, "the pull request was merged", nil, nil
+		case pr.GetState() == "closed":
+			return state, "the pull request was closed", nil, nil
+		}
+		return state, "", nil, nil
+	case "issue_comments":
+		var issue github.Issue
+		changed, resp, err := w.get(ctx, fmt.Sprintf("%s/issues/%d", base, w.number), &issue)
+		if err != nil || !changed {
+			return nil, "", resp, err
+		}
+		state := map[string]any{"state": issue.GetState(), "comments": issue.GetComments()}
+		if issue.GetState() == "closed" {
+			return state, "the issue was closed", nil, nil
+		}
+		return state, "", nil, nil
+	case "workflow_run":
+		var run github.WorkflowRun
+		changed, resp, err := w.get(ctx, fmt.Sprintf("%s/actions/runs/%d", base, w.number), &run)
+		if err != nil || !changed {
+			return nil, "", resp, err
+		}
+		state := map[string]any{"status": run.GetStatus(), "conclusion": run.GetConclusion()}
+		if run.GetStatus() == "completed" {
+			return state, "the workflow run completed", nil, nil
+		}
+		return state, "", nil, nil
+	default:
+		return nil, "", nil, fmt.Errorf("unknown watch kind %q", w.info.Kind)
+	}
+}
+
+// get decodes the object at url into v with a conditional request, and reports whether it changed
+// since the last request. Unchanged objects are decoded from the last response.
+func (w *watch) get(ctx context.Context, url string, v any) (bool, *github.Response, error) {
+	req, err := w.client.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	if etag, ok := w.etags[url]; ok {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var body bytes.Buffer
+	resp, err := w.client.Do(ctx, req, &body)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return false, resp, json.Unmarshal(w.bodies[url], v)
+	}
+	if err != nil {
+		return false, resp, err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		w.etags[url] = etag
+		w.bodies[url] = body.Bytes()
+	}
+	return true, resp, json.Unmarshal(body.Bytes(), v)
+}
+
+// forgetOtherURLs drops the cached responses of URLs other than urls, such as the check runs of a
+// previous head commit.
+func (w *watch) forgetOtherURLs(urls ...string) {
+	for url := range w.etags {
+		if !slices.Contains(urls, url) {
+			delete(w.etags, url)
+			delete(w.bodies, url)
+		}
+	}
+}
+
+// checkRunStates maps the names of check runs to their conclusion, or their status while they run.
+func checkRunStates(runs []*github.CheckRun) map[string]any {
+	states := make(map[string]any, len(runs))
+	for _, run := range runs {
+		if run.GetStatus() == "completed" {
+			states[run.GetName()] = run.GetConclusion()
+		} else {
+			states[run.GetName()] = run.GetStatus()
+		}
+	}
+	return states
+}
+
+// WatchResource creates a tool to watch a GitHub object for changes.
+func WatchResource(watches *Watches, getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("watch_resource",
+			mcp.WithDescription(t("TOOL_WATCH_RESOURCE_DESCRIPTION", "Watch a GitHub object for changes without receiving webhooks: the checks of a pull request, the comments of an issue, or the status of a workflow run. The server polls the object and sends a logging notification from the github-watch logger each time its state changes. Watches stop by themselves once the pull request is merged or closed, the issue is closed or the workflow run completes, and when the session ends. Returns the watch with the current state.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WATCH_RESOURCE_USER_TITLE", "Watch resource"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("kind",
+				mcp.Required(),
+				mcp.Description("What to watch: pull_request_checks, issue_comments or workflow_run"),
+				mcp.Enum(watchKinds...),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("number",
+				mcp.Required(),
+				mcp.Description("Pull request or issue number, or workflow run ID"),
+			),
+			mcp.WithNumber("interval_seconds",
+				mcp.Description("How often to poll, in seconds"),
+				mcp.Min(minWatchInterval.Seconds()),
+				mcp.DefaultNumber(minWatchInterval.Seconds()),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			kind, err := RequiredParam[string](request, "kind")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(watchKinds, kind) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid kind %q, must be one of %s", kind, strings.Join(watchKinds, ", "))), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			intervalSeconds, err := OptionalIntParamWithDefault(request, "interval_seconds", int(minWatchInterval.Seconds()))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if interval := time.Duration(intervalSeconds) * time.Second; interval < minWatchInterval {
+				return mcp.NewToolResultError(fmt.Sprintf("interval_seconds must be at least %d", int(minWatchInterval.Seconds()))), nil
+			}
+
+			sessionID := sessionIDFromContext(ctx)
+			if sessionID == "" {
+				return mcp.NewToolResultError("watching requires a session to notify"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resource := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+			if kind == "workflow_run" {
+				resource = fmt.Sprintf("%s/%s/actions/runs/%d", owner, repo, number)
+			}
+			w := &watch{
+				info: WatchInfo{
+					Kind:            kind,
+					Resource:        resource,
+					IntervalSeconds: intervalSeconds,
+					CreatedAt:       time.Now().UTC().Format(time.RFC3339),
+				},
+				sessionID: sessionID,
+				owner:     owner,
+				repo:      repo,
+				number:    int64(number),
+				interval:  time.Duration(intervalSeconds) * time.Second,
+				client:    client,
+				etags:     make(map[string]string),
+				bodies:    make(map[string][]byte),
+			}
+
+			// The state is polled once now, so that missing objects are reported to the caller
+			state, stopReason, resp, err := w.poll(ctx)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get the state of "+resource, resp, err), nil
+			}
+			if stopReason != "" {
+				return mcp.NewToolResultError(fmt.Sprintf("there is nothing to watch, as %s", stopReason)), nil
+			}
+			w.info.State = state
+
+			if err := watches.add(ctx, w); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return MarshalledTextResult(w.info), nil
+		}
+}
+
+// ListWatches creates a tool to list the watches of the session.
+func ListWatches(watches *Watches, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_watches",
+			mcp.WithDescription(t("TOOL_LIST_WATCHES_DESCRIPTION", "List the watches of this session created with watch_resource, with the last known state of each watched object.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WATCHES_USER_TITLE", "List watches"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			sessionID := sessionIDFromContext(ctx)
+			if sessionID == "" {
+				return mcp.NewToolResultError("watching requires a session to notify"), nil
+			}
+			return MarshalledTextResult(watches.list(sessionID)), nil
+		}
+}
+
+// CancelWatch creates a tool to stop a watch of the session.
+func CancelWatch(watches *Watches, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cancel_watch",
+			mcp.WithDescription(t("TOOL_CANCEL_WATCH_DESCRIPTION", "Stop a watch of this session created with watch_resource.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_CANCEL_WATCH_USER_TITLE", "Cancel watch"),
+				ReadOnlyHint:   ToBoolPtr(true),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("watch_id",
+				mcp.Required(),
+				mcp.Description("ID of the watch, as returned by watch_resource or list_watches"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id, err := RequiredParam[string](request, "watch_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sessionID := sessionIDFromContext(ctx)
+			if sessionID == "" {
+				return mcp.NewToolResultError("watching requires a session to notify"), nil
+			}
+			if !watches.remove(sessionID, id) {
+				return mcp.NewToolResultError(fmt.Sprintf("this session has no watch %q", id)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("watch %s cancelled", id)), nil
+		}
+}
+
+// InitWatchToolset creates the toolset of the watch tools, which poll objects for sessions that
+// cannot receive webhooks. It is kept out of the toolset group, as the watches belong to the server.
+func InitWatchToolset(watches *Watches, getClient GetClientFn, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	watch := toolsets.NewToolset("watches", "Watch GitHub objects for changes and get notified of them").
+		AddReadTools(
+			toolsets.NewServerTool(WatchResource(watches, getClient, t)),
+			toolsets.NewServerTool(ListWatches(watches, t)),
+			toolsets.NewServerTool(CancelWatch(watches, t)),
+		)
+
+	watch.Enabled = true
+	return watch
+}
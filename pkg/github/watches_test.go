@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchNotification struct {
+	sessionID string
+	params    map[string]any
+}
+
+type recordingSessionNotifier struct {
+	notifications []watchNotification
+}
+
+func (n *recordingSessionNotifier) SendNotificationToSpecificClient(sessionID string, _ string, params map[string]any) error {
+	n.notifications = append(n.notifications, watchNotification{sessionID: sessionID, params: params})
+	return nil
+}
+
+// conditionalResponse answers with 304 Not Modified when the request carries etag in If-None-Match,
+// and otherwise with body and etag.
+func conditionalResponse(t *testing.T, etag string, body any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		mockResponse(t, http.StatusOK, body)(w, r)
+	}
+}
+
+func Test_WatchResource(t *testing.T) {
+	// Verify tool definitions once
+	watches := NewWatches(&recordingSessionNotifier{})
+	tool, _ := WatchResource(watches, stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"kind", "owner", "repo", "number"})
+	listTool, _ := ListWatches(watches, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(listTool.Name, listTool))
+	cancelTool, _ := CancelWatch(watches, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(cancelTool.Name, cancelTool))
+	assert.ElementsMatch(t, cancelTool.InputSchema.Required, []string{"watch_id"})
+
+	run := func(status, conclusion string) http.HandlerFunc {
+		return mockResponse(t, http.StatusOK, &github.WorkflowRun{Status: github.Ptr(status), Conclusion: github.Ptr(conclusion)})
+	}
+	tests := []struct {
+		name           string
+		response       http.HandlerFunc
+		args           map[string]any
+		expectedErrMsg string
+		expectedState  map[string]any
+	}{
+		{
+			name:          "watches a running workflow run",
+			response:      run("in_progress", ""),
+			args:          map[string]any{},
+			expectedState: map[string]any{"status": "in_progress", "conclusion": ""},
+		},
+		{
+			name:           "interval below the minimum",
+			args:           map[string]any{"interval_seconds": float64(30)},
+			expectedErrMsg: "interval_seconds must be at least 60",
+		},
+		{
+			name:           "already completed",
+			response:       run("completed", "success"),
+			args:           map[string]any{},
+			expectedErrMsg: "there is nothing to watch, as the workflow run completed",
+		},
+		{
+			name:           "run not found",
+			response:       mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			args:           map[string]any{},
+			expectedErrMsg: "failed to get the state of owner/repo/actions/runs/42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			watches := NewWatches(&recordingSessionNotifier{})
+			defer watches.Forget("session")
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, tc.response),
+			))
+			_, handler := WatchResource(watches, stubGetClientFn(client), translations.NullTranslationHelper)
+
+			tc.args["kind"], tc.args["owner"], tc.args["repo"], tc.args["number"] = "workflow_run", "owner", "repo", float64(42)
+			result, err := handler(contextWithSession("session"), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Empty(t, watches.list("session"))
+				return
+			}
+
+			var info WatchInfo
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &info))
+			assert.Equal(t, "watch-1", info.ID)
+			assert.Equal(t, "owner/repo/actions/runs/42", info.Resource)
+			assert.Equal(t, 60, info.IntervalSeconds)
+			assert.Equal(t, tc.expectedState, info.State)
+			assert.Len(t, watches.list("session"), 1)
+		})
+	}
+
+	t.Run("requires a session", func(t *testing.T) {
+		_, handler := WatchResource(NewWatches(&recordingSessionNotifier{}), stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"kind": "workflow_run", "owner": "owner", "repo": "repo", "number": float64(42),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "requires a session")
+	})
+}
+
+func Test_WatchPullRequestChecks(t *testing.T) {
+	pr := &github.PullRequest{State: github.Ptr("open"), Head: &github.PullRequestBranch{SHA: github.Ptr("abc")}}
+	checks := &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{{Name: github.Ptr("build"), Status: github.Ptr("in_progress")}}}
+	prETag, checksETag := `"pr-1"`, `"checks-1"`
+	requests, conditionalRequests := 0, 0
+	count := func(r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") != "" {
+			conditionalRequests++
+		}
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count(r)
+			conditionalResponse(t, prETag, pr)(w, r)
+		})),
+		mock.WithRequestMatchHandler(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count(r)
+			assert.Equal(t, "/repos/owner/repo/commits/abc/check-runs", r.URL.Path)
+			conditionalResponse(t, checksETag, checks)(w, r)
+		})),
+	))
+
+	notifier := &recordingSessionNotifier{}
+	watches := NewWatches(notifier)
+	defer watches.Forget("session")
+	_, handler := WatchResource(watches, stubGetClientFn(client), translations.NullTranslationHelper)
+	ctx := contextWithSession("session")
+	result, err := handler(ctx, createMCPRequest(map[string]any{
+		"kind": "pull_request_checks", "owner": "owner", "repo": "repo", "number": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+	w := watches.sessions["session"]["watch-1"]
+	require.NotNil(t, w)
+
+	// Nothing changed, so both requests are answered with 304 Not Modified and nothing is sent
+	watches.check(ctx, w)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, 2, conditionalRequests)
+	assert.Empty(t, notifier.notifications)
+
+	// A check completes
+	checks.CheckRuns[0].Status, checks.CheckRuns[0].Conclusion, checksETag = github.Ptr("completed"), github.Ptr("failure"), `"checks-2"`
+	watches.check(ctx, w)
+	require.Len(t, notifier.notifications, 1)
+	assert.Equal(t, "session", notifier.notifications[0].sessionID)
+	assert.Equal(t, map[string]any{
+		"watch_id":       "watch-1",
+		"kind":           "pull_request_checks",
+		"resource":       "owner/repo#7",
+		"state":          map[string]any{"state": "open", "head_sha": "abc", "checks": map[string]any{"build": "failure"}},
+		"previous_state": map[string]any{"state": "open", "head_sha": "abc", "checks": map[string]any{"build": "in_progress"}},
+	}, notifier.notifications[0].params["data"])
+
+	// Merging the pull request stops the watch
+	pr.Merged, pr.State, prETag = github.Ptr(true), github.Ptr("closed"), `"pr-2"`
+	watches.check(ctx, w)
+	require.Len(t, notifier.notifications, 2)
+	data := notifier.notifications[1].params["data"].(map[string]any)
+	assert.Equal(t, true, data["stopped"])
+	assert.Equal(t, "the pull request was merged", data["reason"])
+	assert.Empty(t, watches.list("session"))
+}
+
+func Test_ListAndCancelWatches(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("open"), Comments: github.Ptr(2)}),
+		),
+	))
+	watches := NewWatches(&recordingSessionNotifier{})
+	watches.maxPerSession = 2
+	_, watchHandler := WatchResource(watches, stubGetClientFn(client), translations.NullTranslationHelper)
+	_, listHandler := ListWatches(watches, translations.NullTranslationHelper)
+	_, cancelHandler := CancelWatch(watches, translations.NullTranslationHelper)
+	ctx := contextWithSession("session")
+
+	for _, number := range []float64{1, 2, 3} {
+		result, err := watchHandler(ctx, createMCPRequest(map[string]any{
+			"kind": "issue_comments", "owner": "owner", "repo": "repo", "number": number,
+		}))
+		require.NoError(t, err)
+		if number == 3 {
+			assert.Contains(t, getErrorResult(t, result).Text, "this session already has 2 watches")
+		}
+	}
+
+	listed := func(ctx context.Context) []WatchInfo {
+		result, err := listHandler(ctx, createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		var infos []WatchInfo
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &infos))
+		return infos
+	}
+	infos := listed(ctx)
+	require.Len(t, infos, 2)
+	assert.Equal(t, "owner/repo#1", infos[0].Resource)
+	assert.Equal(t, map[string]any{"state": "open", "comments": float64(2)}, infos[0].State)
+	assert.Equal(t, "owner/repo#2", infos[1].Resource)
+
+	// Watches belong to their session
+	assert.Empty(t, listed(contextWithSession("other-session")))
+	result, err := cancelHandler(contextWithSession("other-session"), createMCPRequest(map[string]any{"watch_id": "watch-1"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, `this session has no watch "watch-1"`)
+
+	result, err = cancelHandler(ctx, createMCPRequest(map[string]any{"watch_id": "watch-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "watch watch-1 cancelled", getTextResult(t, result).Text)
+	assert.Len(t, listed(ctx), 1)
+
+	// Ending the session stops its watches
+	watches.Forget("session")
+	assert.Empty(t, listed(ctx))
+}
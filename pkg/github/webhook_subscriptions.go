@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhook"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetTokenIDFn returns an identifier of the GitHub token requests with ctx are made with, which is
+// the same for the same token without revealing it.
+type GetTokenIDFn func(ctx context.Context) string
+
+// SubscribeToRepoEvents creates a tool to subscribe the calling session to the webhook events of a
+// repository, which the server receives at its webhook endpoint. The session is only subscribed if
+// its token can read the repository, and the subscription is kept with the identifier of that token.
+func SubscribeToRepoEvents(getClient GetClientFn, getTokenID GetTokenIDFn, subscriptions *webhook.Subscriptions, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("subscribe_to_repo_events",
+			mcp.WithDescription(t("TOOL_SUBSCRIBE_TO_REPO_EVENTS_DESCRIPTION", "Subscribe this session to the GitHub webhook events of a repository, such as CI runs completing on a pull request, so that they are pushed to it as logging notifications and resource updates instead of being polled for. Only events of repositories whose webhooks are delivered to this server are received. Returns the repositories the session is subscribed to.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBSCRIBE_TO_REPO_EVENTS_USER_TITLE", "Subscribe to repository events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("events",
+				mcp.Description("Event types to receive. Defaults to all the event types the server receives"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+						"enum": webhook.SupportedEvents,
+					},
+				),
+			),
+			mcp.WithBoolean("unsubscribe",
+				mcp.Description("Unsubscribe from the events of the repository instead"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			unsubscribe, err := OptionalParam[bool](request, "unsubscribe")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			session := server.ClientSessionFromContext(ctx)
+			if session == nil {
+				return mcp.NewToolResultError("subscribing to repository events requires a session"), nil
+			}
+
+			repository := fmt.Sprintf("%s/%s", owner, repo)
+			if unsubscribe {
+				return MarshalledTextResult(subscriptions.Unsubscribe(session.SessionID(), repository)), nil
+			}
+
+			// The events of a repository must only reach sessions that can read it
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			_, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s was not found or cannot be read with this token", repository)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			subscribed, err := subscriptions.Subscribe(session.SessionID(), getTokenID(ctx), repository, events)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return MarshalledTextResult(subscribed), nil
+		}
+}
+
+// InitWebhookToolset creates the toolset of subscribe_to_repo_events. It is only registered when the
+// server receives webhooks, so it is kept out of the toolset group.
+func InitWebhookToolset(getClient GetClientFn, getTokenID GetTokenIDFn, subscriptions *webhook.Subscriptions, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	webhooks := toolsets.NewToolset("webhooks", "Receive GitHub webhook events of repositories as notifications").
+		AddReadTools(
+			toolsets.NewServerTool(SubscribeToRepoEvents(getClient, getTokenID, subscriptions, t)),
+		)
+
+	webhooks.Enabled = true
+	return webhooks
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhook"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SubscribeToRepoEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SubscribeToRepoEvents(stubGetClientFn(mockClient), func(context.Context) string { return "" }, webhook.NewSubscriptions(0), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "subscribe_to_repo_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Only octo/private cannot be read with the token
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/octo/private") {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&github.Repository{Name: github.Ptr("repo")})
+			}),
+		),
+	)
+	token := "token"
+	getTokenID := func(context.Context) string { return token }
+	_, handler := SubscribeToRepoEvents(stubGetClientFn(github.NewClient(mockedClient)), getTokenID, webhook.NewSubscriptions(0), translations.NullTranslationHelper)
+	ctx := contextWithSession("session")
+
+	subscriptionsOf := func(args map[string]any) []webhook.Subscription {
+		t.Helper()
+		result, err := handler(ctx, createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		var subscriptions []webhook.Subscription
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &subscriptions))
+		return subscriptions
+	}
+
+	assert.Equal(t, []webhook.Subscription{
+		{Repository: "octo/repo", Events: []string{"check_suite", "workflow_run"}},
+	}, subscriptionsOf(map[string]any{"owner": "octo", "repo": "repo", "events": []any{"workflow_run", "check_suite"}}))
+
+	assert.Equal(t, []webhook.Subscription{
+		{Repository: "octo/api"},
+		{Repository: "octo/repo", Events: []string{"check_suite", "workflow_run"}},
+	}, subscriptionsOf(map[string]any{"owner": "octo", "repo": "api"}))
+
+	assert.Equal(t, []webhook.Subscription{
+		{Repository: "octo/api"},
+	}, subscriptionsOf(map[string]any{"owner": "octo", "repo": "repo", "unsubscribe": true}))
+
+	// Repositories the token cannot read are not subscribed to
+	result, err := handler(ctx, createMCPRequest(map[string]any{"owner": "octo", "repo": "private"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "repository octo/private was not found or cannot be read with this token")
+	assert.Equal(t, []webhook.Subscription{
+		{Repository: "octo/api"},
+	}, subscriptionsOf(map[string]any{"owner": "octo", "repo": "api", "unsubscribe": false}))
+
+	// Subscribing with another token drops the subscriptions made with the previous one
+	token = "other-token"
+	assert.Equal(t, []webhook.Subscription{
+		{Repository: "octo/repo"},
+	}, subscriptionsOf(map[string]any{"owner": "octo", "repo": "repo"}))
+
+	// Unsupported events are rejected
+	result, err = handler(ctx, createMCPRequest(map[string]any{"owner": "octo", "repo": "repo", "events": []any{"star"}}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, `unsupported event "star"`)
+
+	// Subscriptions belong to a session
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo", "repo": "repo"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "requires a session")
+}
@@ -0,0 +1,401 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	workflowsDir = ".github/workflows"
+
+	// defaultAuditMaxRepos is the number of repositories audited in organization mode when max_repos is not set.
+	defaultAuditMaxRepos = 30
+)
+
+var fullSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// ActionReference is a single uses: reference found in a workflow file.
+type ActionReference struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Uses string `json:"uses"`
+	// Type is one of "action", "reusable_workflow", "local" or "docker".
+	Type string `json:"type"`
+	// Pin is how the reference is pinned: "sha", "tag", "branch", "digest" or "unresolved".
+	// It is empty for local references, which always use the calling repository's version.
+	Pin         string `json:"pin,omitempty"`
+	Mutable     bool   `json:"mutable"`
+	ResolvedSHA string `json:"resolved_sha,omitempty"`
+	// PinnedUses is the reference rewritten to the resolved SHA, with the original ref kept as a comment.
+	PinnedUses string `json:"pinned_uses,omitempty"`
+}
+
+// RepositoryActionsAudit is the audit result for a single repository.
+type RepositoryActionsAudit struct {
+	Repository        string            `json:"repository"`
+	FilesScanned      int               `json:"files_scanned"`
+	TotalReferences   int               `json:"total_references"`
+	MutableReferences int               `json:"mutable_references"`
+	References        []ActionReference `json:"references"`
+	Errors            []string          `json:"errors,omitempty"`
+}
+
+// OrganizationActionsAudit aggregates the audit results of the repositories of an organization.
+type OrganizationActionsAudit struct {
+	Organization        string                   `json:"organization"`
+	RepositoriesScanned int                      `json:"repositories_scanned"`
+	TotalReferences     int                      `json:"total_references"`
+	MutableReferences   int                      `json:"mutable_references"`
+	Truncated           bool                     `json:"truncated"`
+	Repositories        []RepositoryActionsAudit `json:"repositories"`
+}
+
+// workflowUses is a uses: value and the line it was found on.
+type workflowUses struct {
+	value string
+	line  int
+}
+
+// extractWorkflowUses returns every job and step uses: reference in a workflow file.
+func extractWorkflowUses(content []byte) ([]workflowUses, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := resolveNode(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	jobs := mappingValue(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var uses []workflowUses
+	for i := 1; i < len(jobs.Content); i += 2 {
+		job := resolveNode(jobs.Content[i])
+		if job.Kind != yaml.MappingNode {
+			continue
+		}
+		if u := mappingValue(job, "uses"); u != nil && u.Kind == yaml.ScalarNode {
+			uses = append(uses, workflowUses{value: u.Value, line: u.Line})
+		}
+		steps := mappingValue(job, "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, step := range steps.Content {
+			if step = resolveNode(step); step.Kind != yaml.MappingNode {
+				continue
+			}
+			if u := mappingValue(step, "uses"); u != nil && u.Kind == yaml.ScalarNode {
+				uses = append(uses, workflowUses{value: u.Value, line: u.Line})
+			}
+		}
+	}
+	return uses, nil
+}
+
+// refResolution is the outcome of resolving an action's ref to a commit SHA.
+type refResolution struct {
+	pin string
+	sha string
+}
+
+// actionsAuditor audits uses: references, caching ref resolutions across files and repositories.
+type actionsAuditor struct {
+	client      *github.Client
+	rawClient   *raw.Client
+	resolved    map[string]refResolution
+	onlyMutable bool
+}
+
+// classify determines the type and pinning of a uses: reference, resolving tags and branches to commit SHAs.
+func (a *actionsAuditor) classify(ctx context.Context, ref ActionReference) ActionReference {
+	switch {
+	case strings.HasPrefix(ref.Uses, "./"):
+		ref.Type = "local"
+		return ref
+	case strings.HasPrefix(ref.Uses, "docker://"):
+		ref.Type = "docker"
+		if strings.Contains(ref.Uses, "@sha256:") {
+			ref.Pin = "digest"
+		} else {
+			ref.Pin = "tag"
+			ref.Mutable = true
+		}
+		return ref
+	}
+
+	ref.Type = "action"
+	if strings.Contains(ref.Uses, "/.github/workflows/") {
+		ref.Type = "reusable_workflow"
+	}
+
+	name, version, ok := strings.Cut(ref.Uses, "@")
+	parts := strings.SplitN(name, "/", 3)
+	if !ok || version == "" || len(parts) < 2 {
+		ref.Pin = "unresolved"
+		ref.Mutable = true
+		return ref
+	}
+	if fullSHAPattern.MatchString(version) {
+		ref.Pin = "sha"
+		ref.ResolvedSHA = version
+		return ref
+	}
+
+	key := parts[0] + "/" + parts[1] + "@" + version
+	resolution, ok := a.resolved[key]
+	if !ok {
+		resolution = a.resolveRef(ctx, parts[0], parts[1], version)
+		a.resolved[key] = resolution
+	}
+	ref.Pin = resolution.pin
+	ref.Mutable = true
+	ref.ResolvedSHA = resolution.sha
+	if resolution.sha != "" {
+		ref.PinnedUses = fmt.Sprintf("%s@%s # %s", name, resolution.sha, version)
+	}
+	return ref
+}
+
+// resolveRef resolves a tag or branch of a repository to the commit SHA it currently points to.
+// Tags take precedence over branches, matching how GitHub Actions resolves refs.
+func (a *actionsAuditor) resolveRef(ctx context.Context, owner, repo, version string) refResolution {
+	for _, candidate := range []struct{ prefix, pin string }{{"tags/", "tag"}, {"heads/", "branch"}} {
+		reference, resp, err := a.client.Git.GetRef(ctx, owner, repo, candidate.prefix+version)
+		if err != nil {
+			continue
+		}
+		_ = resp.Body.Close()
+
+		sha := reference.GetObject().GetSHA()
+		if reference.GetObject().GetType() == "tag" {
+			// Annotated tags point to a tag object, which in turn points to the commit.
+			tag, resp, err := a.client.Git.GetTag(ctx, owner, repo, sha)
+			if err != nil {
+				return refResolution{pin: candidate.pin}
+			}
+			_ = resp.Body.Close()
+			sha = tag.GetObject().GetSHA()
+		}
+		return refResolution{pin: candidate.pin, sha: sha}
+	}
+	return refResolution{pin: "unresolved"}
+}
+
+// auditRepository audits every workflow file of a repository at ref.
+// Failures to read individual files are recorded in the audit, an error is only returned
+// if the repository's tree could not be read.
+func (a *actionsAuditor) auditRepository(ctx context.Context, owner, repo, ref string) (RepositoryActionsAudit, *github.Response, error) {
+	audit := RepositoryActionsAudit{Repository: owner + "/" + repo, References: []ActionReference{}}
+
+	files, resp, err := listWorkflowFiles(ctx, a.client, owner, repo, ref)
+	if err != nil {
+		return audit, resp, err
+	}
+
+	for _, filePath := range files {
+		content, err := getRawFile(ctx, a.rawClient, owner, repo, filePath, ref)
+		if err != nil {
+			audit.Errors = append(audit.Errors, fmt.Sprintf("%s: %s", filePath, err))
+			continue
+		}
+		uses, err := extractWorkflowUses(content)
+		if err != nil {
+			audit.Errors = append(audit.Errors, fmt.Sprintf("%s: failed to parse workflow: %s", filePath, err))
+			continue
+		}
+		audit.FilesScanned++
+
+		for _, u := range uses {
+			reference := a.classify(ctx, ActionReference{File: filePath, Line: u.line, Uses: u.value})
+			audit.TotalReferences++
+			if reference.Mutable {
+				audit.MutableReferences++
+			} else if a.onlyMutable {
+				continue
+			}
+			audit.References = append(audit.References, reference)
+		}
+	}
+	return audit, nil, nil
+}
+
+// listWorkflowFiles returns the paths of the workflow files of a repository at ref, the default
+// branch if ref is empty.
+func listWorkflowFiles(ctx context.Context, client *github.Client, owner, repo, ref string) ([]string, *github.Response, error) {
+	treeRef := ref
+	if treeRef == "" {
+		treeRef = "HEAD"
+	}
+	tree, resp, err := client.Git.GetTree(ctx, owner, repo, treeRef, true)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	var files []string
+	for _, entry := range tree.Entries {
+		filePath := entry.GetPath()
+		if entry.GetType() != "blob" || path.Dir(filePath) != workflowsDir {
+			continue
+		}
+		if ext := path.Ext(filePath); ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+		files = append(files, filePath)
+	}
+	return files, resp, nil
+}
+
+func getRawFile(ctx context.Context, rawClient *raw.Client, owner, repo, filePath, ref string) ([]byte, error) {
+	resp, err := rawClient.GetRawContent(ctx, owner, repo, filePath, &raw.ContentOpts{Ref: ref})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw content: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get raw content: unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// AuditWorkflowActions creates a tool to find GitHub Actions references that are not pinned to a commit SHA.
+func AuditWorkflowActions(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("audit_workflow_actions",
+			mcp.WithDescription(t("TOOL_AUDIT_WORKFLOW_ACTIONS_DESCRIPTION", "Audit the uses: references of GitHub Actions workflows for actions and reusable workflows that are not pinned to a commit SHA. Tags and branches are resolved to the commit SHA they currently point to, so mutable references can be replaced with pinned ones. Omit repo to audit the repositories of an organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_AUDIT_WORKFLOW_ACTIONS_USER_TITLE", "Audit workflow action pinning"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or the organization to audit when repo is omitted"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name. If omitted, the organization's repositories are audited"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Git ref to audit when auditing a single repository. Defaults to the default branch"),
+			),
+			mcp.WithBoolean("only_mutable",
+				mcp.Description("Only return references that are not pinned to a commit SHA or image digest"),
+			),
+			mcp.WithNumber("max_repos",
+				mcp.Description("Maximum number of repositories to audit in organization mode"),
+				mcp.Min(1),
+				mcp.Max(100),
+				mcp.DefaultNumber(defaultAuditMaxRepos),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			onlyMutable, err := OptionalParam[bool](request, "only_mutable")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos, err := OptionalIntParamWithDefault(request, "max_repos", defaultAuditMaxRepos)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxRepos < 1 || maxRepos > 100 {
+				return mcp.NewToolResultError("max_repos must be between 1 and 100"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+			auditor := &actionsAuditor{
+				client:      client,
+				rawClient:   rawClient,
+				resolved:    map[string]refResolution{},
+				onlyMutable: onlyMutable,
+			}
+
+			if repo != "" {
+				audit, resp, err := auditor.auditRepository(ctx, owner, repo, ref)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get git tree",
+						resp,
+						err,
+					), nil
+				}
+				return MarshalledTextResult(audit), nil
+			}
+
+			// Request one more repository than the cap to find out whether results were truncated.
+			repos, resp, err := client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{
+				Sort:        "pushed",
+				ListOptions: github.ListOptions{PerPage: min(maxRepos+1, 100)},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list organization repositories",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := OrganizationActionsAudit{
+				Organization: owner,
+				Repositories: []RepositoryActionsAudit{},
+				Truncated:    len(repos) > maxRepos || resp.NextPage != 0,
+			}
+			for _, r := range repos {
+				if result.RepositoriesScanned == maxRepos {
+					break
+				}
+				if r.GetArchived() {
+					continue
+				}
+				result.RepositoriesScanned++
+
+				// An empty ref audits the default branch.
+				audit, _, err := auditor.auditRepository(ctx, owner, r.GetName(), "")
+				if err != nil {
+					// Empty repositories have no tree, so record the failure and carry on with the others.
+					audit.Errors = append(audit.Errors, fmt.Sprintf("failed to get git tree: %s", err))
+				}
+				result.TotalReferences += audit.TotalReferences
+				result.MutableReferences += audit.MutableReferences
+				result.Repositories = append(result.Repositories, audit)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,235 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AuditWorkflowActions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := AuditWorkflowActions(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "audit_workflow_actions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "only_mutable")
+	assert.Contains(t, tool.InputSchema.Properties, "max_repos")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	const (
+		checkoutSHA  = "1111111111111111111111111111111111111111"
+		setupGoSHA   = "2222222222222222222222222222222222222222"
+		annotatedSHA = "3333333333333333333333333333333333333333"
+		mainSHA      = "4444444444444444444444444444444444444444"
+		workflowsSHA = "5555555555555555555555555555555555555555"
+	)
+
+	workflow := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@` + setupGoSHA + `
+      - uses: octo/annotated@v1
+      - uses: octo/tool/sub@main
+      - uses: ./local-action
+      - uses: docker://alpine:3.20
+      - uses: octo/gone@v9
+  call:
+    uses: octo/workflows/.github/workflows/ci.yml@v4
+`
+
+	mockTree := &github.Tree{
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr(".github/workflows/ci.yml"), Type: github.Ptr("blob")},
+			{Path: github.Ptr(".github/workflows/nested/other.yml"), Type: github.Ptr("blob")},
+			{Path: github.Ptr(".github/dependabot.yml"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob")},
+		},
+	}
+
+	mockRefs := map[string]*github.Reference{
+		"/repos/actions/checkout/git/ref/tags/v4": {Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(checkoutSHA)}},
+		"/repos/octo/annotated/git/ref/tags/v1":   {Object: &github.GitObject{Type: github.Ptr("tag"), SHA: github.Ptr("tagobject")}},
+		"/repos/octo/tool/git/ref/heads/main":     {Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(mainSHA)}},
+		"/repos/octo/workflows/git/ref/tags/v4":   {Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(workflowsSHA)}},
+	}
+	refsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ref, ok := mockRefs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		mockResponse(t, http.StatusOK, ref)(w, r)
+	})
+
+	expectedReferences := []ActionReference{
+		{File: ".github/workflows/ci.yml", Line: 6, Uses: "actions/checkout@v4", Type: "action", Pin: "tag", Mutable: true, ResolvedSHA: checkoutSHA, PinnedUses: "actions/checkout@" + checkoutSHA + " # v4"},
+		{File: ".github/workflows/ci.yml", Line: 7, Uses: "actions/setup-go@" + setupGoSHA, Type: "action", Pin: "sha", ResolvedSHA: setupGoSHA},
+		{File: ".github/workflows/ci.yml", Line: 8, Uses: "octo/annotated@v1", Type: "action", Pin: "tag", Mutable: true, ResolvedSHA: annotatedSHA, PinnedUses: "octo/annotated@" + annotatedSHA + " # v1"},
+		{File: ".github/workflows/ci.yml", Line: 9, Uses: "octo/tool/sub@main", Type: "action", Pin: "branch", Mutable: true, ResolvedSHA: mainSHA, PinnedUses: "octo/tool/sub@" + mainSHA + " # main"},
+		{File: ".github/workflows/ci.yml", Line: 10, Uses: "./local-action", Type: "local"},
+		{File: ".github/workflows/ci.yml", Line: 11, Uses: "docker://alpine:3.20", Type: "docker", Pin: "tag", Mutable: true},
+		{File: ".github/workflows/ci.yml", Line: 12, Uses: "octo/gone@v9", Type: "action", Pin: "unresolved", Mutable: true},
+		{File: ".github/workflows/ci.yml", Line: 14, Uses: "octo/workflows/.github/workflows/ci.yml@v4", Type: "reusable_workflow", Pin: "tag", Mutable: true, ResolvedSHA: workflowsSHA, PinnedUses: "octo/workflows/.github/workflows/ci.yml@" + workflowsSHA + " # v4"},
+	}
+
+	newMockedClient := func(extra ...mock.MockBackendOption) *http.Client {
+		options := []mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "1", r.URL.Query().Get("recursive"))
+					if r.URL.Path != "/repos/owner/repo/git/trees/HEAD" {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Git Repository is empty."}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, mockTree)(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				raw.GetRawReposContentsByOwnerByRepoByPath,
+				expectPath(t, "/owner/repo/HEAD/.github/workflows/ci.yml").andThen(
+					func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = w.Write([]byte(workflow))
+					},
+				),
+			),
+			mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, refsHandler),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTagsByOwnerByRepoByTagSha,
+				expectPath(t, "/repos/octo/annotated/git/tags/tagobject").andThen(
+					mockResponse(t, http.StatusOK, &github.Tag{Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(annotatedSHA)}}),
+				),
+			),
+		}
+		return mock.NewMockedHTTPClient(append(options, extra...)...)
+	}
+
+	t.Run("audit repository", func(t *testing.T) {
+		client := github.NewClient(newMockedClient())
+		rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+		_, handler := AuditWorkflowActions(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var audit RepositoryActionsAudit
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &audit))
+		assert.Equal(t, RepositoryActionsAudit{
+			Repository:        "owner/repo",
+			FilesScanned:      1,
+			TotalReferences:   8,
+			MutableReferences: 6,
+			References:        expectedReferences,
+		}, audit)
+	})
+
+	t.Run("only mutable references", func(t *testing.T) {
+		client := github.NewClient(newMockedClient())
+		rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+		_, handler := AuditWorkflowActions(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":        "owner",
+			"repo":         "repo",
+			"only_mutable": true,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var audit RepositoryActionsAudit
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &audit))
+		assert.Equal(t, 8, audit.TotalReferences)
+		require.Len(t, audit.References, 6)
+		for _, reference := range audit.References {
+			assert.True(t, reference.Mutable, reference.Uses)
+		}
+	})
+
+	t.Run("audit organization", func(t *testing.T) {
+		client := github.NewClient(newMockedClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsReposByOrg,
+				expect(t, expectations{
+					path:        "/orgs/owner/repos",
+					queryParams: map[string]string{"sort": "pushed", "per_page": "3"},
+				}).andThen(
+					mockResponse(t, http.StatusOK, []*github.Repository{
+						{Name: github.Ptr("repo")},
+						{Name: github.Ptr("old"), Archived: github.Ptr(true)},
+						{Name: github.Ptr("empty")},
+						{Name: github.Ptr("over-the-cap")},
+					}),
+				),
+			),
+		))
+		rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+		_, handler := AuditWorkflowActions(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":     "owner",
+			"max_repos": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var audit OrganizationActionsAudit
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &audit))
+		assert.Equal(t, "owner", audit.Organization)
+		assert.Equal(t, 2, audit.RepositoriesScanned)
+		assert.True(t, audit.Truncated)
+		assert.Equal(t, 8, audit.TotalReferences)
+		assert.Equal(t, 6, audit.MutableReferences)
+		require.Len(t, audit.Repositories, 2)
+		assert.Equal(t, "owner/repo", audit.Repositories[0].Repository)
+		assert.Equal(t, expectedReferences, audit.Repositories[0].References)
+		// The empty repository has no tree, which is recorded without failing the whole audit.
+		assert.Equal(t, "owner/empty", audit.Repositories[1].Repository)
+		require.Len(t, audit.Repositories[1].Errors, 1)
+		assert.Contains(t, audit.Repositories[1].Errors[0], "failed to get git tree")
+	})
+
+	t.Run("tree not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+		_, handler := AuditWorkflowActions(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get git tree")
+	})
+}
@@ -75,3 +75,121 @@ func IssueToFixWorkflowPrompt(t translations.TranslationHelperFunc) (tool mcp.Pr
 			}, nil
 		}
 }
+
+// SummarizePullRequestPrompt provides a guided workflow for summarizing a pull request for a reviewer
+func SummarizePullRequestPrompt(t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("SummarizePullRequest",
+			mcp.WithPromptDescription(t("PROMPT_SUMMARIZE_PULL_REQUEST_DESCRIPTION", "Summarize the changes, status and open discussion of a pull request")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("pullNumber", mcp.ArgumentDescription("Pull request number"), mcp.RequiredArgument()),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			pullNumber := request.Params.Arguments["pullNumber"]
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "system",
+					Content: mcp.NewTextContent("You are a code review assistant summarizing GitHub pull requests for reviewers. Use `get_pull_request` for the description and metadata, `get_pull_request_files` and `get_pull_request_diff` for the changes, `get_pull_request_status` for the checks, and `get_pull_request_reviews` and `get_pull_request_comments` for the discussion. Be concise and do not speculate about code you have not read."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(fmt.Sprintf("Please summarize pull request #%s in %s/%s.", pullNumber, owner, repo)),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll read pull request #%s in %s/%s, its changed files, checks and reviews, and then summarize it.", pullNumber, owner, repo)),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("Structure the summary as:\n1. What the pull request changes and why\n2. The most important files to review\n3. The status of checks and reviews\n4. Open questions or risks raised in the discussion or spotted in the diff"),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		}
+}
+
+// TriageIssuePrompt provides a guided workflow for triaging an issue
+func TriageIssuePrompt(t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("TriageIssue",
+			mcp.WithPromptDescription(t("PROMPT_TRIAGE_ISSUE_DESCRIPTION", "Triage an issue by classifying it, finding duplicates and proposing labels and next steps")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("issue_number", mcp.ArgumentDescription("Issue number"), mcp.RequiredArgument()),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			issueNumber := request.Params.Arguments["issue_number"]
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "system",
+					Content: mcp.NewTextContent("You are an issue triage assistant for GitHub repositories. Use `get_issue` and `get_issue_comments` to read the issue, `search_issues` to look for duplicates and related issues, and `list_issue_types` to find the issue types available in the organization. Only use `update_issue` to change the issue after the user has confirmed your proposal."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(fmt.Sprintf("Please triage issue #%s in %s/%s.", issueNumber, owner, repo)),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll read issue #%s in %s/%s and its comments, and search for related issues.", issueNumber, owner, repo)),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("Then tell me:\n1. Whether it is a bug, feature request, question or something else\n2. Any likely duplicates or related issues\n3. Whether the report has enough information to act on, and what is missing\n4. The labels, issue type and priority you propose\n\nAsk me before changing the issue."),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		}
+}
+
+// DraftReleaseNotesPrompt provides a guided workflow for drafting release notes from the changes since a previous release
+func DraftReleaseNotesPrompt(t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("DraftReleaseNotes",
+			mcp.WithPromptDescription(t("PROMPT_DRAFT_RELEASE_NOTES_DESCRIPTION", "Draft release notes from the changes since a previous release")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("since", mcp.ArgumentDescription("Tag of the previous release (optional, defaults to the latest release)")),
+			mcp.WithArgument("version", mcp.ArgumentDescription("Version of the new release (optional)")),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+
+			since := "the latest release"
+			if s, exists := request.Params.Arguments["since"]; exists && s != "" {
+				since = fmt.Sprintf("the %s tag", s)
+			}
+
+			release := "the next release"
+			if v, exists := request.Params.Arguments["version"]; exists && v != "" {
+				release = v
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "system",
+					Content: mcp.NewTextContent("You are a release manager drafting release notes for a GitHub repository. Use `get_latest_release`, `list_releases` and `list_tags` to find the previous release, `list_commits` to find the changes since then, and `search_pull_requests` and `get_pull_request` to read the merged pull requests behind them. Credit contributors by their GitHub handle."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(fmt.Sprintf("Please draft release notes for %s of %s/%s, covering the changes since %s.", release, owner, repo, since)),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll find the changes merged into %s/%s since %s and group them into release notes.", owner, repo, since)),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("Group the changes under Breaking changes, Features, Fixes and Other, with one line per pull request linking to it. Leave out empty sections, and return the notes as Markdown without publishing a release."),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		}
+}
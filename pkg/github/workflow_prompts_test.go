@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WorkflowPrompts(t *testing.T) {
+	tests := []struct {
+		name             string
+		prompt           func(translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc)
+		expectedName     string
+		requiredArgs     []string
+		optionalArgs     []string
+		arguments        map[string]string
+		expectedContains []string
+	}{
+		{
+			name:             "summarize pull request",
+			prompt:           SummarizePullRequestPrompt,
+			expectedName:     "SummarizePullRequest",
+			requiredArgs:     []string{"owner", "repo", "pullNumber"},
+			arguments:        map[string]string{"owner": "octo", "repo": "hello", "pullNumber": "42"},
+			expectedContains: []string{"pull request #42 in octo/hello", "get_pull_request_diff"},
+		},
+		{
+			name:             "triage issue",
+			prompt:           TriageIssuePrompt,
+			expectedName:     "TriageIssue",
+			requiredArgs:     []string{"owner", "repo", "issue_number"},
+			arguments:        map[string]string{"owner": "octo", "repo": "hello", "issue_number": "7"},
+			expectedContains: []string{"issue #7 in octo/hello", "search_issues", "Ask me before changing the issue"},
+		},
+		{
+			name:             "draft release notes with defaults",
+			prompt:           DraftReleaseNotesPrompt,
+			expectedName:     "DraftReleaseNotes",
+			requiredArgs:     []string{"owner", "repo"},
+			optionalArgs:     []string{"since", "version"},
+			arguments:        map[string]string{"owner": "octo", "repo": "hello"},
+			expectedContains: []string{"the next release of octo/hello", "since the latest release"},
+		},
+		{
+			name:             "draft release notes since tag",
+			prompt:           DraftReleaseNotesPrompt,
+			expectedName:     "DraftReleaseNotes",
+			requiredArgs:     []string{"owner", "repo"},
+			optionalArgs:     []string{"since", "version"},
+			arguments:        map[string]string{"owner": "octo", "repo": "hello", "since": "v1.2.0", "version": "v1.3.0"},
+			expectedContains: []string{"v1.3.0 of octo/hello", "since the v1.2.0 tag"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt, handler := tc.prompt(translations.NullTranslationHelper)
+
+			assert.Equal(t, tc.expectedName, prompt.Name)
+			assert.NotEmpty(t, prompt.Description)
+
+			var required, optional []string
+			for _, arg := range prompt.Arguments {
+				assert.NotEmpty(t, arg.Description)
+				if arg.Required {
+					required = append(required, arg.Name)
+				} else {
+					optional = append(optional, arg.Name)
+				}
+			}
+			assert.ElementsMatch(t, tc.requiredArgs, required)
+			assert.ElementsMatch(t, tc.optionalArgs, optional)
+
+			request := mcp.GetPromptRequest{}
+			request.Params.Name = prompt.Name
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Messages)
+
+			var text string
+			for _, message := range result.Messages {
+				content, ok := message.Content.(mcp.TextContent)
+				require.True(t, ok)
+				text += content.Text + "\n"
+			}
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, text, expected)
+			}
+		})
+	}
+}
+
+func Test_WorkflowPromptsAreListed(t *testing.T) {
+	client := github.NewClient(nil)
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(client), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), stubGetSCIMClientFn(nil), translations.NullTranslationHelper, AssetsConfig{}, TokenPermissionsConfig{}, nil, nil, nil)
+	require.NoError(t, tsg.EnableToolsets([]string{"repos", "issues", "pull_requests"}))
+
+	s := NewServer("test")
+	tsg.RegisterAll(s)
+
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`))
+	rpcResponse, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %T", response)
+	result, ok := rpcResponse.Result.(mcp.ListPromptsResult)
+	require.True(t, ok)
+
+	arguments := map[string][]string{}
+	for _, prompt := range result.Prompts {
+		for _, arg := range prompt.Arguments {
+			arguments[prompt.Name] = append(arguments[prompt.Name], arg.Name)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"owner", "repo", "pullNumber"}, arguments["SummarizePullRequest"])
+	assert.ElementsMatch(t, []string{"owner", "repo", "issue_number"}, arguments["TriageIssue"])
+	assert.ElementsMatch(t, []string{"owner", "repo", "since", "version"}, arguments["DraftReleaseNotes"])
+	assert.Contains(t, arguments, "AssignCodingAgent")
+	assert.Contains(t, arguments, "IssueToFixWorkflow")
+}
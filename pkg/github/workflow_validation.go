@@ -0,0 +1,884 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowFinding is a single problem found in a workflow file.
+// Column is zero when the exact column is not known, for example inside block scalars.
+type WorkflowFinding struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// WorkflowValidationResult is the outcome of validating a workflow file.
+type WorkflowValidationResult struct {
+	Path     string            `json:"path,omitempty"`
+	Valid    bool              `json:"valid"`
+	Errors   []WorkflowFinding `json:"errors"`
+	Warnings []WorkflowFinding `json:"warnings"`
+
+	// environments are the names of the environments jobs deploy to.
+	environments []*yaml.Node
+}
+
+var (
+	workflowTopLevelKeys = stringSet("name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs")
+	workflowJobKeys      = stringSet("name", "needs", "permissions", "runs-on", "environment", "concurrency", "outputs", "env",
+		"defaults", "if", "steps", "timeout-minutes", "strategy", "continue-on-error", "container", "services", "uses", "with", "secrets")
+	workflowStepKeys = stringSet("id", "if", "name", "uses", "run", "shell", "with", "env", "continue-on-error",
+		"timeout-minutes", "working-directory")
+	workflowEvents = stringSet("branch_protection_rule", "check_run", "check_suite", "create", "delete", "deployment",
+		"deployment_status", "discussion", "discussion_comment", "fork", "gollum", "image_version", "issue_comment", "issues",
+		"label", "merge_group", "milestone", "page_build", "project", "project_card", "project_column", "public",
+		"pull_request", "pull_request_review", "pull_request_review_comment", "pull_request_target", "push",
+		"registry_package", "release", "repository_dispatch", "schedule", "status", "watch", "workflow_call",
+		"workflow_dispatch", "workflow_run")
+	workflowContexts = stringSet("github", "env", "vars", "job", "jobs", "steps", "runner", "secrets", "strategy",
+		"matrix", "needs", "inputs")
+	workflowFunctions = stringSet("contains", "startswith", "endswith", "format", "join", "tojson", "fromjson",
+		"hashfiles", "success", "always", "cancelled", "failure")
+	workflowLiterals = stringSet("true", "false", "null", "nan", "infinity")
+	// retiredRunnerLabels are hosted runner images that GitHub no longer provides.
+	retiredRunnerLabels = stringSet("ubuntu-16.04", "ubuntu-18.04", "ubuntu-20.04", "macos-10.15", "macos-11", "macos-12",
+		"windows-2016", "windows-2019")
+
+	workflowIDPattern    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	yamlErrorLinePattern = regexp.MustCompile(`line (\d+):`)
+	deprecatedCommands   = regexp.MustCompile(`::(set-output|save-state|set-env|add-path)\b`)
+	// Reusable workflows are called from the .github/workflows directory of the same or another repository.
+	localReusableWorkflowPattern  = regexp.MustCompile(`^\./\.github/workflows/[^/@\s]+\.ya?ml$`)
+	remoteReusableWorkflowPattern = regexp.MustCompile(`^[^/@\s]+/[^/@\s]+/\.github/workflows/[^/@\s]+\.ya?ml@[^@\s]+$`)
+)
+
+func stringSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// workflowJob holds what the validator needs to know about a job to check references to it.
+type workflowJob struct {
+	key     *yaml.Node
+	node    *yaml.Node
+	needs   []*yaml.Node
+	outputs map[string]bool
+	steps   map[string]bool
+}
+
+// expressionScope is the job an expression is evaluated in, or nil outside of jobs.
+type expressionScope struct {
+	id  string
+	job *workflowJob
+}
+
+type workflowValidator struct {
+	jobs         map[string]*workflowJob
+	environments []*yaml.Node
+	errors       []WorkflowFinding
+	warnings     []WorkflowFinding
+}
+
+// validateWorkflow checks the structure of a GitHub Actions workflow file without any network access.
+func validateWorkflow(content []byte) WorkflowValidationResult {
+	v := &workflowValidator{jobs: map[string]*workflowJob{}}
+	v.validate(content)
+
+	sortWorkflowFindings(v.errors)
+	sortWorkflowFindings(v.warnings)
+
+	result := WorkflowValidationResult{
+		Valid:        len(v.errors) == 0,
+		Errors:       v.errors,
+		Warnings:     v.warnings,
+		environments: v.environments,
+	}
+	if result.Errors == nil {
+		result.Errors = []WorkflowFinding{}
+	}
+	if result.Warnings == nil {
+		result.Warnings = []WorkflowFinding{}
+	}
+	return result
+}
+
+// sortWorkflowFindings sorts findings by their position in the file.
+func sortWorkflowFindings(findings []WorkflowFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+}
+
+func (v *workflowValidator) errorAt(line, column int, format string, args ...any) {
+	v.errors = append(v.errors, WorkflowFinding{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *workflowValidator) warningAt(line, column int, format string, args ...any) {
+	v.warnings = append(v.warnings, WorkflowFinding{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *workflowValidator) errorf(node *yaml.Node, format string, args ...any) {
+	v.errorAt(node.Line, node.Column, format, args...)
+}
+
+func (v *workflowValidator) warningf(node *yaml.Node, format string, args ...any) {
+	v.warningAt(node.Line, node.Column, format, args...)
+}
+
+// resolveNode follows YAML aliases to the node they refer to.
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// mappingValue returns the value of key in a mapping node, or nil if it is not set.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveNode(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func (v *workflowValidator) validate(content []byte) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		line := 0
+		if m := yamlErrorLinePattern.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+		v.errorAt(line, 0, "invalid YAML: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		return
+	}
+	if len(doc.Content) == 0 {
+		v.errorAt(1, 0, "workflow file is empty")
+		return
+	}
+	root := resolveNode(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		v.errorf(root, "workflow must be a mapping of keys such as on and jobs")
+		return
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if key := root.Content[i]; !workflowTopLevelKeys[key.Value] {
+			v.errorf(key, "unknown top-level key %q", key.Value)
+		}
+	}
+
+	if on := mappingValue(root, "on"); on != nil {
+		v.validateEvents(on)
+	} else {
+		v.errorf(root, "missing required key \"on\"")
+	}
+
+	jobs := mappingValue(root, "jobs")
+	if jobs == nil {
+		v.errorf(root, "missing required key \"jobs\"")
+	} else {
+		v.validateJobs(jobs)
+	}
+
+	// Expressions are checked last so references can be resolved against every job.
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "jobs" {
+			continue
+		}
+		v.checkExpressions(root.Content[i+1], nil)
+	}
+	ids := make([]string, 0, len(v.jobs))
+	for id := range v.jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		v.checkExpressions(v.jobs[id].node, &expressionScope{id: id, job: v.jobs[id]})
+	}
+}
+
+func (v *workflowValidator) validateEvents(on *yaml.Node) {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		v.checkEvent(on)
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event = resolveNode(event); event.Kind != yaml.ScalarNode {
+				v.errorf(event, "event must be a string")
+				continue
+			}
+			v.checkEvent(event)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			event, config := on.Content[i], resolveNode(on.Content[i+1])
+			if !v.checkEvent(event) {
+				continue
+			}
+			switch event.Value {
+			case "schedule":
+				v.validateSchedule(event, config)
+			case "push", "pull_request", "pull_request_target":
+				if config.Kind != yaml.MappingNode {
+					continue
+				}
+				for _, filter := range []string{"branches", "tags", "paths"} {
+					if mappingValue(config, filter) != nil && mappingValue(config, filter+"-ignore") != nil {
+						v.errorf(event, "%s event cannot use both %s and %s-ignore", event.Value, filter, filter)
+					}
+				}
+			}
+		}
+	default:
+		v.errorf(on, "on must be an event name, a list of event names or a mapping of events")
+	}
+}
+
+func (v *workflowValidator) checkEvent(event *yaml.Node) bool {
+	if !workflowEvents[event.Value] {
+		v.errorf(event, "unknown event %q", event.Value)
+		return false
+	}
+	return true
+}
+
+func (v *workflowValidator) validateSchedule(event, config *yaml.Node) {
+	if config.Kind != yaml.SequenceNode || len(config.Content) == 0 {
+		v.errorf(event, "schedule must be a list of cron entries")
+		return
+	}
+	for _, entry := range config.Content {
+		entry = resolveNode(entry)
+		if entry.Kind != yaml.MappingNode {
+			v.errorf(entry, "schedule entry must be a mapping with a cron key")
+			continue
+		}
+		cron := mappingValue(entry, "cron")
+		if cron == nil {
+			v.errorf(entry, "schedule entry is missing cron")
+			continue
+		}
+		if fields := strings.Fields(cron.Value); len(fields) != 5 {
+			v.errorf(cron, "cron expression %q must have 5 fields, found %d", cron.Value, len(fields))
+		}
+	}
+}
+
+func (v *workflowValidator) validateJobs(jobs *yaml.Node) {
+	if jobs.Kind != yaml.MappingNode {
+		v.errorf(jobs, "jobs must be a mapping of job IDs to jobs")
+		return
+	}
+	if len(jobs.Content) == 0 {
+		v.errorf(jobs, "workflow must define at least one job")
+		return
+	}
+
+	// Collect all jobs first so needs can refer to jobs defined later in the file.
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		key, node := jobs.Content[i], resolveNode(jobs.Content[i+1])
+		if !workflowIDPattern.MatchString(key.Value) {
+			v.errorf(key, "invalid job ID %q: must start with a letter or _ and contain only alphanumeric characters, - or _", key.Value)
+		}
+		if node.Kind != yaml.MappingNode {
+			v.errorf(key, "job %q must be a mapping", key.Value)
+			continue
+		}
+		job := &workflowJob{key: key, node: node, outputs: map[string]bool{}, steps: map[string]bool{}}
+		if outputs := mappingValue(node, "outputs"); outputs != nil && outputs.Kind == yaml.MappingNode {
+			for j := 0; j < len(outputs.Content); j += 2 {
+				job.outputs[outputs.Content[j].Value] = true
+			}
+		}
+		v.jobs[key.Value] = job
+	}
+
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		if job, ok := v.jobs[jobs.Content[i].Value]; ok {
+			v.validateJob(jobs.Content[i].Value, job)
+		}
+	}
+	v.checkNeedsCycles()
+}
+
+func (v *workflowValidator) validateJob(id string, job *workflowJob) {
+	node := job.node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; !workflowJobKeys[key.Value] {
+			v.errorf(key, "unknown key %q in job %q", key.Value, id)
+		}
+	}
+
+	if needs := mappingValue(node, "needs"); needs != nil {
+		switch needs.Kind {
+		case yaml.ScalarNode:
+			job.needs = []*yaml.Node{needs}
+		case yaml.SequenceNode:
+			for _, need := range needs.Content {
+				job.needs = append(job.needs, resolveNode(need))
+			}
+		default:
+			v.errorf(needs, "needs of job %q must be a job ID or a list of job IDs", id)
+		}
+		for _, need := range job.needs {
+			switch {
+			case need.Kind != yaml.ScalarNode:
+				v.errorf(need, "needs of job %q must be a job ID or a list of job IDs", id)
+			case need.Value == id:
+				v.errorf(need, "job %q cannot depend on itself", id)
+			case v.jobs[need.Value] == nil:
+				v.errorf(need, "job %q needs undefined job %q", id, need.Value)
+			}
+		}
+	}
+
+	if timeout := mappingValue(node, "timeout-minutes"); timeout != nil {
+		v.checkNumber(timeout, "timeout-minutes of job "+strconv.Quote(id))
+	}
+
+	if environment := mappingValue(node, "environment"); environment != nil {
+		// The environment is either its name or a mapping with its name and URL
+		if environment.Kind == yaml.MappingNode {
+			environment = mappingValue(environment, "name")
+		}
+		switch {
+		case environment == nil || environment.Kind != yaml.ScalarNode || environment.Value == "":
+			v.errorf(job.key, "environment of job %q must be a name or have a name", id)
+		case !strings.Contains(environment.Value, "${{"):
+			v.environments = append(v.environments, environment)
+		}
+	}
+
+	if uses := mappingValue(node, "uses"); uses != nil {
+		// Jobs calling a reusable workflow run on the called workflow's runners and steps.
+		for _, key := range []string{"runs-on", "steps"} {
+			if value := mappingValue(node, key); value != nil {
+				v.errorf(value, "job %q calls a reusable workflow and cannot set %s", id, key)
+			}
+		}
+		if !localReusableWorkflowPattern.MatchString(uses.Value) && !remoteReusableWorkflowPattern.MatchString(uses.Value) {
+			v.errorf(uses, "reusable workflow reference %q must be {owner}/{repo}/.github/workflows/{file}@{ref}, or ./.github/workflows/{file} in the same repository", uses.Value)
+		}
+		return
+	}
+
+	for _, key := range []string{"with", "secrets"} {
+		if value := mappingValue(node, key); value != nil {
+			v.errorf(value, "%s is only allowed in jobs that call a reusable workflow", key)
+		}
+	}
+
+	runsOn := mappingValue(node, "runs-on")
+	if runsOn == nil {
+		v.errorf(job.key, "job %q is missing runs-on", id)
+	} else {
+		v.checkRunnerLabels(runsOn)
+	}
+
+	steps := mappingValue(node, "steps")
+	if steps == nil {
+		v.errorf(job.key, "job %q has no steps", id)
+		return
+	}
+	if steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		v.errorf(steps, "steps of job %q must be a non-empty list", id)
+		return
+	}
+	for _, step := range steps.Content {
+		v.validateStep(id, job, resolveNode(step))
+	}
+}
+
+func (v *workflowValidator) checkRunnerLabels(runsOn *yaml.Node) {
+	var labels []*yaml.Node
+	switch runsOn.Kind {
+	case yaml.ScalarNode:
+		labels = []*yaml.Node{runsOn}
+	case yaml.SequenceNode:
+		labels = runsOn.Content
+	case yaml.MappingNode:
+		// Runner groups and labels selected by mapping are not checked.
+		return
+	}
+	for _, label := range labels {
+		if label = resolveNode(label); retiredRunnerLabels[label.Value] {
+			v.warningf(label, "runner image %q has been retired, jobs using it will not run", label.Value)
+		}
+	}
+}
+
+func (v *workflowValidator) validateStep(jobID string, job *workflowJob, step *yaml.Node) {
+	if step.Kind != yaml.MappingNode {
+		v.errorf(step, "step in job %q must be a mapping", jobID)
+		return
+	}
+	for i := 0; i+1 < len(step.Content); i += 2 {
+		if key := step.Content[i]; !workflowStepKeys[key.Value] {
+			v.errorf(key, "unknown key %q in step of job %q", key.Value, jobID)
+		}
+	}
+
+	if id := mappingValue(step, "id"); id != nil {
+		switch {
+		case !workflowIDPattern.MatchString(id.Value):
+			v.errorf(id, "invalid step ID %q: must start with a letter or _ and contain only alphanumeric characters, - or _", id.Value)
+		case job.steps[id.Value]:
+			v.errorf(id, "step ID %q is used more than once in job %q", id.Value, jobID)
+		default:
+			job.steps[id.Value] = true
+		}
+	}
+
+	uses, run := mappingValue(step, "uses"), mappingValue(step, "run")
+	switch {
+	case uses != nil && run != nil:
+		v.errorf(step, "step in job %q cannot have both uses and run", jobID)
+	case uses == nil && run == nil:
+		v.errorf(step, "step in job %q must have either uses or run", jobID)
+	case uses != nil:
+		if !strings.HasPrefix(uses.Value, "./") && !strings.HasPrefix(uses.Value, "docker://") && !strings.Contains(uses.Value, "@") {
+			v.errorf(uses, "action reference %q must include a version, e.g. %s@v4", uses.Value, uses.Value)
+		}
+	case run != nil:
+		if m := deprecatedCommands.FindStringSubmatchIndex(run.Value); m != nil {
+			line, column := scalarPosition(run, m[0])
+			v.warningAt(line, column, "the %s workflow command is deprecated, use environment files instead", run.Value[m[2]:m[3]])
+		}
+	}
+
+	if timeout := mappingValue(step, "timeout-minutes"); timeout != nil {
+		v.checkNumber(timeout, "timeout-minutes of step")
+	}
+}
+
+func (v *workflowValidator) checkNumber(node *yaml.Node, what string) {
+	if node.Kind != yaml.ScalarNode || strings.Contains(node.Value, "${{") {
+		return
+	}
+	if _, err := strconv.ParseFloat(node.Value, 64); err != nil {
+		v.errorf(node, "%s must be a number, got %q", what, node.Value)
+	}
+}
+
+// checkNeedsCycles reports dependency cycles between jobs.
+func (v *workflowValidator) checkNeedsCycles() {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	var path []string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		path = append(path, id)
+		for _, need := range v.jobs[id].needs {
+			if need.Value == id || v.jobs[need.Value] == nil {
+				// Self references and undefined jobs are reported by validateJob.
+				continue
+			}
+			switch state[need.Value] {
+			case visiting:
+				start := 0
+				for i, p := range path {
+					if p == need.Value {
+						start = i
+					}
+				}
+				cycle := append(append([]string{}, path[start:]...), need.Value)
+				v.errorf(need, "job dependency cycle: %s", strings.Join(cycle, " -> "))
+			case unvisited:
+				visit(need.Value)
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+	}
+
+	ids := make([]string, 0, len(v.jobs))
+	for id := range v.jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+}
+
+// scalarPosition returns the source position of the byte at offset in a scalar's value.
+// Block scalars start on the line after their indicator, and their columns are not tracked.
+func scalarPosition(node *yaml.Node, offset int) (line, column int) {
+	newlines := strings.Count(node.Value[:offset], "\n")
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return node.Line + 1 + newlines, 0
+	}
+	if newlines > 0 {
+		return node.Line + newlines, 0
+	}
+	column = node.Column + offset
+	if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+		column++
+	}
+	return node.Line, column
+}
+
+// checkExpressions checks every ${{ }} expression in node, and bare expressions in if conditions.
+func (v *workflowValidator) checkExpressions(node *yaml.Node, scope *expressionScope) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "if" && value.Kind == yaml.ScalarNode && !strings.Contains(value.Value, "${{") {
+				v.checkExpression(value, 0, value.Value, scope)
+				continue
+			}
+			v.checkExpressions(value, scope)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			v.checkExpressions(item, scope)
+		}
+	case yaml.ScalarNode:
+		value := node.Value
+		offset := 0
+		for {
+			start := strings.Index(value[offset:], "${{")
+			if start < 0 {
+				return
+			}
+			start += offset
+			end := strings.Index(value[start+3:], "}}")
+			if end < 0 {
+				line, column := scalarPosition(node, start)
+				v.errorAt(line, column, "unterminated expression: missing closing }}")
+				return
+			}
+			end += start + 3
+			v.checkExpression(node, start, value[start+3:end], scope)
+			offset = end + 2
+		}
+	}
+}
+
+// checkExpression checks the syntax of a single expression and the contexts, functions, jobs and steps it references.
+func (v *workflowValidator) checkExpression(node *yaml.Node, offset int, expr string, scope *expressionScope) {
+	line, column := scalarPosition(node, offset)
+	if strings.TrimSpace(expr) == "" {
+		v.errorAt(line, column, "empty expression")
+		return
+	}
+
+	depth := 0
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'':
+			// Single quotes are escaped by doubling them.
+			i++
+			for {
+				if i >= len(expr) {
+					v.errorAt(line, column, "unterminated string in expression %q", strings.TrimSpace(expr))
+					return
+				}
+				if expr[i] == '\'' {
+					if i+1 < len(expr) && expr[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				i++
+			}
+		case c == '"':
+			v.errorAt(line, column, "strings in expressions must use single quotes: %q", strings.TrimSpace(expr))
+			return
+		case c == '(' || c == '[':
+			depth++
+			i++
+		case c == ')' || c == ']':
+			depth--
+			if depth < 0 {
+				v.errorAt(line, column, "unbalanced parentheses in expression %q", strings.TrimSpace(expr))
+				return
+			}
+			i++
+		case c >= '0' && c <= '9':
+			for i < len(expr) && (isIdentChar(expr[i]) || expr[i] == '.') {
+				i++
+			}
+		case isIdentStart(c):
+			start := i
+			chain := []string{}
+			for {
+				j := i
+				for j < len(expr) && isIdentChar(expr[j]) {
+					j++
+				}
+				chain = append(chain, expr[i:j])
+				i = j
+				if i+1 < len(expr) && expr[i] == '.' && (isIdentStart(expr[i+1]) || expr[i+1] == '*') {
+					i++
+					if expr[i] == '*' {
+						chain = append(chain, "*")
+						i++
+						if i+1 < len(expr) && expr[i] == '.' && isIdentStart(expr[i+1]) {
+							i++
+							continue
+						}
+						break
+					}
+					continue
+				}
+				break
+			}
+			isCall := false
+			for k := i; k < len(expr); k++ {
+				if expr[k] != ' ' {
+					isCall = expr[k] == '('
+					break
+				}
+			}
+			if start > 0 && expr[start-1] == '.' {
+				// Property access following an index, e.g. matrix['os'].name
+				continue
+			}
+			v.checkReference(line, column, chain, isCall, scope)
+		case strings.ContainsRune("!=<>&|.,*-+", rune(c)):
+			i++
+		default:
+			v.errorAt(line, column, "unexpected character %q in expression %q", c, strings.TrimSpace(expr))
+			return
+		}
+	}
+	if depth != 0 {
+		v.errorAt(line, column, "unbalanced parentheses in expression %q", strings.TrimSpace(expr))
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c == '-' || (c >= '0' && c <= '9')
+}
+
+func (v *workflowValidator) checkReference(line, column int, chain []string, isCall bool, scope *expressionScope) {
+	name := strings.ToLower(chain[0])
+	if isCall {
+		if !workflowFunctions[name] {
+			v.errorAt(line, column, "unknown function %q in expression", chain[0])
+		}
+		return
+	}
+	if workflowLiterals[name] {
+		return
+	}
+	if !workflowContexts[name] {
+		v.errorAt(line, column, "unknown context %q in expression", chain[0])
+		return
+	}
+	if len(chain) < 2 {
+		return
+	}
+
+	switch name {
+	case "needs":
+		if scope == nil {
+			return
+		}
+		target, ok := v.jobs[chain[1]]
+		if !ok {
+			v.errorAt(line, column, "expression references undefined job %q", chain[1])
+			return
+		}
+		listed := false
+		for _, need := range scope.job.needs {
+			listed = listed || need.Value == chain[1]
+		}
+		if !listed {
+			v.errorAt(line, column, "job %q must list %q in needs to use needs.%s", scope.id, chain[1], chain[1])
+			return
+		}
+		v.checkOutput(line, column, chain, target)
+	case "jobs":
+		// The jobs context is available in the outputs of reusable workflows.
+		target, ok := v.jobs[chain[1]]
+		if !ok {
+			v.errorAt(line, column, "expression references undefined job %q", chain[1])
+			return
+		}
+		v.checkOutput(line, column, chain, target)
+	case "steps":
+		if scope != nil && chain[1] != "*" && !scope.job.steps[chain[1]] {
+			v.warningAt(line, column, "job %q has no step with ID %q", scope.id, chain[1])
+		}
+	}
+}
+
+func (v *workflowValidator) checkOutput(line, column int, chain []string, target *workflowJob) {
+	if len(chain) < 4 || chain[2] != "outputs" || chain[3] == "*" {
+		return
+	}
+	if !target.outputs[chain[3]] {
+		v.errorAt(line, column, "job %q has no output %q", chain[1], chain[3])
+	}
+}
+
+// checkWorkflowEnvironments warns about the environments jobs deploy to that the repository does
+// not have. GitHub creates them on the first run, without any protection rules or secrets.
+func checkWorkflowEnvironments(ctx context.Context, client *github.Client, owner, repo string, result *WorkflowValidationResult) {
+	if len(result.environments) == 0 {
+		return
+	}
+
+	existing := map[string]bool{}
+	opts := &github.EnvironmentListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		environments, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, opts)
+		if err != nil {
+			_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to list environments", resp, err)
+			result.Warnings = append(result.Warnings, WorkflowFinding{Message: fmt.Sprintf("could not check that the environments exist: %v", err)})
+			return
+		}
+		_ = resp.Body.Close()
+		for _, environment := range environments.Environments {
+			// Environment names are not case sensitive
+			existing[strings.ToLower(environment.GetName())] = true
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for _, environment := range result.environments {
+		if !existing[strings.ToLower(environment.Value)] {
+			result.Warnings = append(result.Warnings, WorkflowFinding{
+				Line:    environment.Line,
+				Column:  environment.Column,
+				Message: fmt.Sprintf("environment %q does not exist in %s/%s and will be created without protection rules on the first run", environment.Value, owner, repo),
+			})
+		}
+	}
+	sortWorkflowFindings(result.Warnings)
+}
+
+// ValidateWorkflowFile creates a tool to validate a GitHub Actions workflow file before it is pushed.
+func ValidateWorkflowFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("validate_workflow_file",
+			mcp.WithDescription(t("TOOL_VALIDATE_WORKFLOW_FILE_DESCRIPTION", "Validate a GitHub Actions workflow file and return errors and warnings with line numbers. Checks required keys, event names, job dependencies and cycles, runs-on, steps, reusable workflow references, and ${{ }} expression syntax and references. Pass the workflow YAML as content, or owner, repo and path to validate a file in a repository. With owner and repo, also checks that the environments jobs deploy to exist in the repository. Use this before committing changes to .github/workflows.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VALIDATE_WORKFLOW_FILE_USER_TITLE", "Validate workflow file"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("content",
+				mcp.Description("Workflow YAML to validate. Takes precedence over path; owner and repo, if both are given, are then only used to check environments"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Repository owner, when validating a file in a repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name, when validating a file in a repository"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path to the workflow file, e.g. .github/workflows/ci.yml"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Git ref to read the workflow file from. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if content == "" && (owner == "" || repo == "" || path == "") {
+				return mcp.NewToolResultError("either content or owner, repo and path must be provided"), nil
+			}
+			// Environments are only checked when the repository is known
+			if content != "" && (owner == "" || repo == "") {
+				result := validateWorkflow([]byte(content))
+				result.Path = path
+				return MarshalledTextResult(result), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if content != "" {
+				result := validateWorkflow([]byte(content))
+				result.Path = path
+				checkWorkflowEnvironments(ctx, client, owner, repo, &result)
+				return MarshalledTextResult(result), nil
+			}
+
+			file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get workflow file",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, not a workflow file", path)), nil
+			}
+
+			fileContent, err := file.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode workflow file: %w", err)
+			}
+
+			result := validateWorkflow([]byte(fileContent))
+			result.Path = path
+			checkWorkflowEnvironments(ctx, client, owner, repo, &result)
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,327 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateWorkflow(t *testing.T) {
+	// Each fixture in testdata/workflows is validated and must produce exactly the expected findings.
+	type finding struct {
+		line    int
+		message string
+	}
+
+	tests := []struct {
+		fixture          string
+		expectedErrors   []finding
+		expectedWarnings []finding
+	}{
+		{
+			fixture: "valid.yml",
+		},
+		{
+			fixture: "structure.yml",
+			expectedErrors: []finding{
+				{1, `missing required key "on"`},
+				{2, `unknown top-level key "trigger"`},
+				{4, `job "build" is missing runs-on`},
+				{7, `job "test" has no steps`},
+				{11, `unknown key "unknown-key" in job "lint"`},
+				{13, `action reference "actions/checkout" must include a version`},
+				{14, `step in job "lint" cannot have both uses and run`},
+				{16, `step in job "lint" must have either uses or run`},
+			},
+		},
+		{
+			fixture: "events.yml",
+			expectedErrors: []finding{
+				{2, "push event cannot use both branches and branches-ignore"},
+				{5, `unknown event "pull_requests"`},
+				{7, `cron expression "0 3 * *" must have 5 fields, found 4`},
+				{11, `timeout-minutes of job "build" must be a number, got "soon"`},
+			},
+			expectedWarnings: []finding{
+				{10, `runner image "ubuntu-20.04" has been retired`},
+				{13, "the set-output workflow command is deprecated"},
+			},
+		},
+		{
+			fixture: "needs.yml",
+			expectedErrors: []finding{
+				{9, "job dependency cycle: a -> c -> b -> a"},
+				{14, `job "c" needs undefined job "missing"`},
+				{19, `job "d" cannot depend on itself`},
+			},
+		},
+		{
+			fixture: "expressions.yml",
+			expectedErrors: []finding{
+				{13, "strings in expressions must use single quotes"},
+				{15, `job "build" has no output "version"`},
+				{16, `expression references undefined job "test"`},
+				{17, `unknown context "secret" in expression`},
+				{18, `unknown function "unknownFn" in expression`},
+				{19, "unbalanced parentheses in expression"},
+				{20, "unterminated expression: missing closing }}"},
+				{24, "empty expression"},
+			},
+			expectedWarnings: []finding{
+				{21, `job "publish" has no step with ID "missing"`},
+			},
+		},
+		{
+			fixture: "reusable.yml",
+			expectedErrors: []finding{
+				{6, `reusable workflow reference "octo-org/shared/.github/workflows/build.yml" must be {owner}/{repo}/.github/workflows/{file}@{ref}`},
+				{8, `reusable workflow reference "octo-org/shared@v1" must be`},
+				{10, `reusable workflow reference "./.github/workflows/deploy/prod.yml" must be`},
+				{16, `environment of job "production" must be a name or have a name`},
+			},
+		},
+		{
+			fixture: "invalid_yaml.yml",
+			expectedErrors: []finding{
+				{3, "invalid YAML: line 3: did not find expected key"},
+			},
+		},
+	}
+
+	assertFindings := func(t *testing.T, expected []finding, actual []WorkflowFinding) {
+		t.Helper()
+		require.Len(t, actual, len(expected), "findings: %+v", actual)
+		for i, e := range expected {
+			assert.Equal(t, e.line, actual[i].Line, "line of %q", actual[i].Message)
+			assert.Contains(t, actual[i].Message, e.message)
+		}
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.fixture, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("testdata", "workflows", tc.fixture))
+			require.NoError(t, err)
+
+			result := validateWorkflow(content)
+
+			assert.Equal(t, len(tc.expectedErrors) == 0, result.Valid)
+			assertFindings(t, tc.expectedErrors, result.Errors)
+			assertFindings(t, tc.expectedWarnings, result.Warnings)
+		})
+	}
+}
+
+func Test_ValidateWorkflowFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ValidateWorkflowFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "validate_workflow_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Empty(t, tool.InputSchema.Required)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	workflow := "on: push\njobs:\n  build:\n    steps:\n      - run: make\n"
+	deployWorkflow := `on: push
+jobs:
+  production:
+    runs-on: ubuntu-latest
+    environment: production
+    steps:
+      - run: ./deploy.sh
+  staging:
+    runs-on: ubuntu-latest
+    environment:
+      name: staging
+    steps:
+      - run: ./deploy.sh
+`
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult WorkflowValidationResult
+		// expectedWarning is contained in the only warning of the result, when expectedResult is not set
+		expectedWarning string
+		expectedErrMsg  string
+	}{
+		{
+			name:         "validate content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"content": workflow,
+			},
+			expectedResult: WorkflowValidationResult{
+				Valid:    false,
+				Errors:   []WorkflowFinding{{Line: 3, Column: 3, Message: `job "build" is missing runs-on`}},
+				Warnings: []WorkflowFinding{},
+			},
+		},
+		{
+			name: "validate file in repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/contents/.github/workflows/ci.yml",
+						queryParams: map[string]string{"ref": "main"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{
+							Type:     github.Ptr("file"),
+							Encoding: github.Ptr("base64"),
+							Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(workflow))),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  ".github/workflows/ci.yml",
+				"ref":   "main",
+			},
+			expectedResult: WorkflowValidationResult{
+				Path:     ".github/workflows/ci.yml",
+				Valid:    false,
+				Errors:   []WorkflowFinding{{Line: 3, Column: 3, Message: `job "build" is missing runs-on`}},
+				Warnings: []WorkflowFinding{},
+			},
+		},
+		{
+			name: "warn about environments missing from the repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/environments").andThen(
+						mockResponse(t, http.StatusOK, &github.EnvResponse{
+							TotalCount:   github.Ptr(1),
+							Environments: []*github.Environment{{Name: github.Ptr("Production")}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"content": deployWorkflow,
+				"owner":   "owner",
+				"repo":    "repo",
+			},
+			expectedResult: WorkflowValidationResult{
+				Valid:  true,
+				Errors: []WorkflowFinding{},
+				Warnings: []WorkflowFinding{
+					{Line: 11, Column: 13, Message: `environment "staging" does not exist in owner/repo and will be created without protection rules on the first run`},
+				},
+			},
+		},
+		{
+			name: "environments cannot be listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"content": deployWorkflow,
+				"owner":   "owner",
+				"repo":    "repo",
+			},
+			expectedWarning: "could not check that the environments exist",
+		},
+		{
+			name:         "content with owner but no repo is validated locally",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"content": deployWorkflow,
+				"owner":   "owner",
+			},
+			expectedResult: WorkflowValidationResult{
+				Valid:    true,
+				Errors:   []WorkflowFinding{},
+				Warnings: []WorkflowFinding{},
+			},
+		},
+		{
+			name:         "neither content nor path",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "either content or owner, repo and path must be provided",
+		},
+		{
+			name: "workflow file not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  ".github/workflows/missing.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ValidateWorkflowFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedResult WorkflowValidationResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			if tc.expectedWarning != "" {
+				require.Len(t, returnedResult.Warnings, 1)
+				assert.Contains(t, returnedResult.Warnings[0].Message, tc.expectedWarning)
+				return
+			}
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
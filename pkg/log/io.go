@@ -2,12 +2,27 @@ package log
 
 import (
 	"io"
+	"regexp"
 
 	"log/slog"
 )
 
+// tokenFieldPattern matches JSON fields named token or ending in _token, such as the GitHub token
+// clients can supply for their session when initializing and the admin_token argument of the admin
+// tools.
+var tokenFieldPattern = regexp.MustCompile(`"((?:[A-Za-z0-9]+_)*token)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// githubTokenPattern matches GitHub tokens by their prefix.
+var githubTokenPattern = regexp.MustCompile(`\b(?:gh[pousr]_|github_pat_)[A-Za-z0-9_]+`)
+
+// redact replaces the tokens in data, so that they do not end up in logs.
+func redact(data string) string {
+	data = tokenFieldPattern.ReplaceAllString(data, `"$1":"[REDACTED]"`)
+	return githubTokenPattern.ReplaceAllString(data, "[REDACTED]")
+}
+
 // IOLogger is a wrapper around io.Reader and io.Writer that can be used
-// to log the data being read and written from the underlying streams
+// to log the data being read and written from the underlying streams. Tokens are redacted from the logged data.
 type IOLogger struct {
 	reader io.Reader
 	writer io.Writer
@@ -30,7 +45,7 @@ func (l *IOLogger) Read(p []byte) (n int, err error) {
 	}
 	n, err = l.reader.Read(p)
 	if n > 0 {
-		l.logger.Info("[stdin]: received bytes", "count", n, "data", string(p[:n]))
+		l.logger.Info("[stdin]: received bytes", "count", n, "data", redact(string(p[:n])))
 	}
 	return n, err
 }
@@ -40,6 +55,6 @@ func (l *IOLogger) Write(p []byte) (n int, err error) {
 	if l.writer == nil {
 		return 0, io.ErrClosedPipe
 	}
-	l.logger.Info("[stdout]: sending bytes", "count", len(p), "data", string(p))
+	l.logger.Info("[stdout]: sending bytes", "count", len(p), "data", redact(string(p)))
 	return l.writer.Write(p)
 }
@@ -9,6 +9,10 @@ import (
 	gogithub "github.com/google/go-github/v74/github"
 )
 
+// MaxRawContentBytes bounds the number of bytes of a file that tools read and return, so that
+// large files do not flood the context of the model.
+const MaxRawContentBytes = 1 << 20
+
 // GetRawClientFn is a function type that returns a RawClient instance.
 type GetRawClientFn func(context.Context) (*Client, error)
 
@@ -20,7 +24,9 @@ type Client struct {
 
 // NewClient creates a new instance of the raw API Client with the provided GitHub client and provided URL.
 func NewClient(client *gogithub.Client, rawURL *url.URL) *Client {
+	userAgent := client.UserAgent
 	client = gogithub.NewClient(client.Client())
+	client.UserAgent = userAgent
 	client.BaseURL = rawURL
 	return &Client{client: client, url: rawURL}
 }
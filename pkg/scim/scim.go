@@ -0,0 +1,146 @@
+// Package scim provides a client for the GitHub SCIM user provisioning API
+package scim
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+// mediaType is the media type SCIM requests and responses are exchanged in.
+const mediaType = "application/scim+json"
+
+// GetSCIMClientFn is a function type that returns a SCIM Client instance.
+type GetSCIMClientFn func(context.Context) (*Client, error)
+
+// Client is a client for interacting with the GitHub SCIM API.
+type Client struct {
+	url    *url.URL
+	client *gogithub.Client
+}
+
+// NewClient creates a new instance of the SCIM API Client with the provided GitHub client and SCIM base URL.
+func NewClient(client *gogithub.Client, scimURL *url.URL) *Client {
+	client = gogithub.NewClient(client.Client())
+	client.BaseURL = scimURL
+	return &Client{client: client, url: scimURL}
+}
+
+// usersURL returns the URL of the SCIM users of an enterprise. On GitHub Enterprise Server,
+// where SCIM is configured for the whole instance, enterprise is empty.
+func (c *Client) usersURL(enterprise string, elem ...string) string {
+	base := c.url
+	if enterprise != "" {
+		base = base.JoinPath("enterprises", enterprise)
+	}
+	return base.JoinPath(append([]string{"Users"}, elem...)...).String()
+}
+
+func (c *Client) do(ctx context.Context, method, urlStr string, body, v any) (*gogithub.Response, error) {
+	req, err := c.client.NewRequest(method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	if body != nil {
+		req.Header.Set("Content-Type", mediaType)
+	}
+	return c.client.Do(ctx, req, v)
+}
+
+// ListOptions are the SCIM pagination and filtering options for listing provisioned identities.
+type ListOptions struct {
+	// StartIndex is the 1-based index of the first result to return.
+	StartIndex int
+	// Count is the number of results to return.
+	Count int
+	// Filter is a SCIM filter expression, e.g. userName eq "octocat".
+	Filter string
+}
+
+// PatchOptions is a SCIM PATCH request body.
+type PatchOptions struct {
+	Schemas    []string                                        `json:"schemas"`
+	Operations []gogithub.UpdateAttributeForSCIMUserOperations `json:"Operations"`
+}
+
+// PatchOpSchema is the schema of SCIM PATCH requests.
+const PatchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+// UserSchema is the core SCIM user schema.
+const UserSchema = "urn:ietf:params:scim:schemas:core:2.0:User"
+
+// ListProvisionedIdentities lists the users provisioned with SCIM.
+func (c *Client) ListProvisionedIdentities(ctx context.Context, enterprise string, opts *ListOptions) (*gogithub.SCIMProvisionedIdentities, *gogithub.Response, error) {
+	u := c.usersURL(enterprise)
+	if opts != nil {
+		query := url.Values{}
+		if opts.StartIndex > 0 {
+			query.Set("startIndex", strconv.Itoa(opts.StartIndex))
+		}
+		if opts.Count > 0 {
+			query.Set("count", strconv.Itoa(opts.Count))
+		}
+		if opts.Filter != "" {
+			query.Set("filter", opts.Filter)
+		}
+		if len(query) > 0 {
+			u += "?" + query.Encode()
+		}
+	}
+
+	identities := new(gogithub.SCIMProvisionedIdentities)
+	resp, err := c.do(ctx, http.MethodGet, u, nil, identities)
+	if err != nil {
+		return nil, resp, err
+	}
+	return identities, resp, nil
+}
+
+// GetProvisionedIdentity gets a user provisioned with SCIM by their SCIM id.
+func (c *Client) GetProvisionedIdentity(ctx context.Context, enterprise, scimUserID string) (*gogithub.SCIMUserAttributes, *gogithub.Response, error) {
+	user := new(gogithub.SCIMUserAttributes)
+	resp, err := c.do(ctx, http.MethodGet, c.usersURL(enterprise, scimUserID), nil, user)
+	if err != nil {
+		return nil, resp, err
+	}
+	return user, resp, nil
+}
+
+// ProvisionUser provisions a new user with SCIM.
+func (c *Client) ProvisionUser(ctx context.Context, enterprise string, user *gogithub.SCIMUserAttributes) (*gogithub.SCIMUserAttributes, *gogithub.Response, error) {
+	created := new(gogithub.SCIMUserAttributes)
+	resp, err := c.do(ctx, http.MethodPost, c.usersURL(enterprise), user, created)
+	if err != nil {
+		return nil, resp, err
+	}
+	return created, resp, nil
+}
+
+// UpdateUserAttributes updates individual attributes of a user provisioned with SCIM.
+func (c *Client) UpdateUserAttributes(ctx context.Context, enterprise, scimUserID string, opts *PatchOptions) (*gogithub.SCIMUserAttributes, *gogithub.Response, error) {
+	updated := new(gogithub.SCIMUserAttributes)
+	resp, err := c.do(ctx, http.MethodPatch, c.usersURL(enterprise, scimUserID), opts, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updated, resp, nil
+}
+
+// ReplaceUser replaces all attributes of a user provisioned with SCIM.
+func (c *Client) ReplaceUser(ctx context.Context, enterprise, scimUserID string, user *gogithub.SCIMUserAttributes) (*gogithub.SCIMUserAttributes, *gogithub.Response, error) {
+	replaced := new(gogithub.SCIMUserAttributes)
+	resp, err := c.do(ctx, http.MethodPut, c.usersURL(enterprise, scimUserID), user, replaced)
+	if err != nil {
+		return nil, resp, err
+	}
+	return replaced, resp, nil
+}
+
+// DeleteUser permanently deletes a user provisioned with SCIM.
+func (c *Client) DeleteUser(ctx context.Context, enterprise, scimUserID string) (*gogithub.Response, error) {
+	return c.do(ctx, http.MethodDelete, c.usersURL(enterprise, scimUserID), nil, nil)
+}
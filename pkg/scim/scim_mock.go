@@ -0,0 +1,32 @@
+package scim
+
+import "github.com/migueleliasweb/go-github-mock/src/mock"
+
+var GetSCIMEnterprisesUsersByEnterprise mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/enterprises/{enterprise}/Users",
+	Method:  "GET",
+}
+var PostSCIMEnterprisesUsersByEnterprise mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/enterprises/{enterprise}/Users",
+	Method:  "POST",
+}
+var GetSCIMEnterprisesUsersByEnterpriseByScimUserID mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/enterprises/{enterprise}/Users/{scim_user_id}",
+	Method:  "GET",
+}
+var PatchSCIMEnterprisesUsersByEnterpriseByScimUserID mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/enterprises/{enterprise}/Users/{scim_user_id}",
+	Method:  "PATCH",
+}
+var PutSCIMEnterprisesUsersByEnterpriseByScimUserID mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/enterprises/{enterprise}/Users/{scim_user_id}",
+	Method:  "PUT",
+}
+var DeleteSCIMEnterprisesUsersByEnterpriseByScimUserID mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/enterprises/{enterprise}/Users/{scim_user_id}",
+	Method:  "DELETE",
+}
+var GetSCIMUsers mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/Users",
+	Method:  "GET",
+}
@@ -0,0 +1,128 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		base       string
+		enterprise string
+		elem       []string
+		want       string
+	}{
+		{
+			name:       "enterprise users",
+			base:       "https://api.github.com/scim/v2/",
+			enterprise: "octo-corp",
+			want:       "https://api.github.com/scim/v2/enterprises/octo-corp/Users",
+		},
+		{
+			name:       "enterprise user",
+			base:       "https://api.github.com/scim/v2/",
+			enterprise: "octo-corp",
+			elem:       []string{"7fce0092-d52e-4f76-b727-3955bd72c939"},
+			want:       "https://api.github.com/scim/v2/enterprises/octo-corp/Users/7fce0092-d52e-4f76-b727-3955bd72c939",
+		},
+		{
+			name: "enterprise server users",
+			base: "https://ghes.example.com/api/v3/scim/v2/",
+			want: "https://ghes.example.com/api/v3/scim/v2/Users",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			base, err := url.Parse(tc.base)
+			require.NoError(t, err)
+			client := NewClient(github.NewClient(nil), base)
+			require.Equal(t, tc.want, client.usersURL(tc.enterprise, tc.elem...))
+		})
+	}
+}
+
+func TestListProvisionedIdentities(t *testing.T) {
+	base, _ := url.Parse("https://scim.example.com/")
+
+	tests := []struct {
+		name          string
+		pattern       mock.EndpointPattern
+		enterprise    string
+		opts          *ListOptions
+		expectedQuery url.Values
+		statusCode    int
+		expectError   bool
+	}{
+		{
+			name:       "enterprise with filter",
+			pattern:    GetSCIMEnterprisesUsersByEnterprise,
+			enterprise: "octo-corp",
+			opts:       &ListOptions{StartIndex: 3, Count: 2, Filter: `userName eq "octocat"`},
+			expectedQuery: url.Values{
+				"startIndex": []string{"3"},
+				"count":      []string{"2"},
+				"filter":     []string{`userName eq "octocat"`},
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:          "enterprise server without options",
+			pattern:       GetSCIMUsers,
+			expectedQuery: url.Values{},
+			statusCode:    http.StatusOK,
+		},
+		{
+			name:          "scim not enabled",
+			pattern:       GetSCIMEnterprisesUsersByEnterprise,
+			enterprise:    "octo-corp",
+			expectedQuery: url.Values{},
+			statusCode:    http.StatusNotFound,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					tc.pattern,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, tc.expectedQuery, r.URL.Query())
+						require.Equal(t, mediaType, r.Header.Get("Accept"))
+
+						w.WriteHeader(tc.statusCode)
+						if tc.statusCode != http.StatusOK {
+							_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+							return
+						}
+						require.NoError(t, json.NewEncoder(w).Encode(&github.SCIMProvisionedIdentities{
+							TotalResults: github.Ptr(1),
+							Resources: []*github.SCIMUserAttributes{
+								{ID: github.Ptr("7fce0092"), UserName: "octocat"},
+							},
+						}))
+					}),
+				),
+			)
+			client := NewClient(github.NewClient(mockedClient), base)
+
+			identities, _, err := client.ListProvisionedIdentities(context.Background(), tc.enterprise, tc.opts)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, identities.Resources, 1)
+			require.Equal(t, "octocat", identities.Resources[0].UserName)
+		})
+	}
+}
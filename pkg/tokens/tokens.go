@@ -0,0 +1,119 @@
+// Package tokens identifies the type of GitHub tokens and remembers the repository permissions
+// probed for fine-grained personal access tokens, which do not report them like classic scopes.
+package tokens
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fineGrainedPrefix is the prefix of fine-grained personal access tokens.
+const fineGrainedPrefix = "github_pat_"
+
+// IsFineGrained reports whether token is a fine-grained personal access token.
+func IsFineGrained(token string) bool {
+	return strings.HasPrefix(token, fineGrainedPrefix)
+}
+
+// FromRequest returns the token a request to the GitHub API was authenticated with, or an empty string.
+func FromRequest(req *http.Request) string {
+	if req == nil {
+		return ""
+	}
+	auth := req.Header.Get("Authorization")
+	for _, scheme := range []string{"Bearer ", "bearer ", "token "} {
+		if token, ok := strings.CutPrefix(auth, scheme); ok {
+			return token
+		}
+	}
+	return ""
+}
+
+// Access levels of a probed permission.
+const (
+	AccessRead    = "read"
+	AccessNone    = "none"
+	AccessUnknown = "unknown"
+)
+
+// Permission is the access a token was found to have to one repository permission, named as in
+// the X-Accepted-GitHub-Permissions header, such as issues or actions.
+type Permission struct {
+	Name   string `json:"name"`
+	Access string `json:"access"`
+	// Status is the status code of the probe when the access could not be determined.
+	Status int `json:"status,omitempty"`
+}
+
+// Permissions are the repository permissions probed for a token on one repository it can access.
+type Permissions struct {
+	// Repository is the repository probed, empty when the token cannot access any repository.
+	Repository  string       `json:"repository,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	ProbedAt    time.Time    `json:"probed_at"`
+}
+
+// Summary lists the permissions as name=access pairs, such as "issues=read, actions=none".
+func (p *Permissions) Summary() string {
+	pairs := make([]string, 0, len(p.Permissions))
+	for _, permission := range p.Permissions {
+		pairs = append(pairs, permission.Name+"="+permission.Access)
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// Cache holds the permissions probed for tokens for a limited time, as they can be changed.
+// Tokens are stored hashed.
+type Cache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*Permissions
+}
+
+// NewCache creates a cache whose entries expire after ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[[sha256.Size]byte]*Permissions),
+	}
+}
+
+// DefaultCache is shared by the get_me tool, which fills it, and the error messages of tools, which
+// state the permissions a token was found to have.
+var DefaultCache = NewCache(10 * time.Minute)
+
+// Get returns the permissions probed for token, if they have not expired.
+func (c *Cache) Get(token string) (*Permissions, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sha256.Sum256([]byte(token))
+	permissions, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(permissions.ProbedAt) > c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return permissions, true
+}
+
+// Set stores the permissions probed for token.
+func (c *Cache) Set(token string, permissions *Permissions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[sha256.Sum256([]byte(token))] = permissions
+}
+
+// Delete removes the permissions probed for token, such as when a session stops using it.
+func (c *Cache) Delete(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, sha256.Sum256([]byte(token)))
+}
@@ -2,6 +2,9 @@ package toolsets
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -9,10 +12,21 @@ import (
 
 type ToolsetDoesNotExistError struct {
 	Name string
+	// ValidNames are the names of the toolsets that do exist, if known.
+	ValidNames []string
+	// Suggestion is the valid name closest to Name, if any is close enough to be a likely misspelling.
+	Suggestion string
 }
 
 func (e *ToolsetDoesNotExistError) Error() string {
-	return fmt.Sprintf("toolset %s does not exist", e.Name)
+	msg := fmt.Sprintf("toolset %s does not exist", e.Name)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %s?", e.Suggestion)
+	}
+	if len(e.ValidNames) > 0 {
+		msg += fmt.Sprintf(" Valid toolsets are: %s", strings.Join(e.ValidNames, ", "))
+	}
+	return msg
 }
 
 func (e *ToolsetDoesNotExistError) Is(target error) bool {
@@ -52,9 +66,12 @@ type Toolset struct {
 	Name        string
 	Description string
 	Enabled     bool
-	readOnly    bool
-	writeTools  []server.ServerTool
-	readTools   []server.ServerTool
+	// OffByDefault keeps the toolset disabled when all toolsets are enabled, so it is only enabled
+	// when asked for by name.
+	OffByDefault bool
+	readOnly     bool
+	writeTools   []server.ServerTool
+	readTools    []server.ServerTool
 	// resources are not tools, but the community seems to be moving towards namespaces as a broader concept
 	// and in order to have multiple servers running concurrently, we want to avoid overlapping resources too.
 	resourceTemplates []server.ServerResourceTemplate
@@ -93,6 +110,17 @@ func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	}
 }
 
+// UpdateTools replaces each tool of the toolset with what update returns for it, such as to change
+// its schema before it is registered.
+func (t *Toolset) UpdateTools(update func(server.ServerTool) server.ServerTool) {
+	for i, tool := range t.readTools {
+		t.readTools[i] = update(tool)
+	}
+	for i, tool := range t.writeTools {
+		t.writeTools[i] = update(tool)
+	}
+}
+
 func (t *Toolset) AddResourceTemplates(templates ...server.ServerResourceTemplate) *Toolset {
 	t.resourceTemplates = append(t.resourceTemplates, templates...)
 	return t
@@ -123,6 +151,10 @@ func (t *Toolset) RegisterResourcesTemplates(s *server.MCPServer) {
 	}
 }
 
+func (t *Toolset) GetAvailablePrompts() []server.ServerPrompt {
+	return t.prompts
+}
+
 func (t *Toolset) RegisterPrompts(s *server.MCPServer) {
 	if !t.Enabled {
 		return
@@ -160,10 +192,18 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// ToolHandlerWrapper returns the handler to register for tool in place of handler, such as one that
+// checks the arguments of calls before handler runs.
+type ToolHandlerWrapper func(tool mcp.Tool, handler server.ToolHandlerFunc) server.ToolHandlerFunc
+
 type ToolsetGroup struct {
+	// mu guards whether the toolsets are enabled, which can change at runtime, such as through the
+	// dynamic and admin tools.
+	mu           sync.RWMutex
 	Toolsets     map[string]*Toolset
 	everythingOn bool
 	readOnly     bool
+	wrappers     []ToolHandlerWrapper
 }
 
 func NewToolsetGroup(readOnly bool) *ToolsetGroup {
@@ -191,12 +231,15 @@ func NewToolset(name string, description string) *Toolset {
 }
 
 func (tg *ToolsetGroup) IsEnabled(name string) bool {
-	// If everythingOn is true, all features are enabled
-	if tg.everythingOn {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
+	feature, exists := tg.Toolsets[name]
+
+	// If everythingOn is true, all features are enabled, except those that are off by default
+	if tg.everythingOn && (!exists || !feature.OffByDefault) {
 		return true
 	}
 
-	feature, exists := tg.Toolsets[name]
 	if !exists {
 		return false
 	}
@@ -204,21 +247,27 @@ func (tg *ToolsetGroup) IsEnabled(name string) bool {
 }
 
 func (tg *ToolsetGroup) EnableToolsets(names []string) error {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
 	// Special case for "all"
 	for _, name := range names {
 		if name == "all" {
 			tg.everythingOn = true
-			break
+			continue
 		}
-		err := tg.EnableToolset(name)
+		err := tg.setEnabledLocked(name, true)
 		if err != nil {
 			return err
 		}
 	}
 	// Do this after to ensure all toolsets are enabled if "all" is present anywhere in list
 	if tg.everythingOn {
-		for name := range tg.Toolsets {
-			err := tg.EnableToolset(name)
+		for name, toolset := range tg.Toolsets {
+			if toolset.OffByDefault {
+				continue
+			}
+			err := tg.setEnabledLocked(name, true)
 			if err != nil {
 				return err
 			}
@@ -229,17 +278,130 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 }
 
 func (tg *ToolsetGroup) EnableToolset(name string) error {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+	return tg.setEnabledLocked(name, true)
+}
+
+// DisableToolset disables a toolset. Once a toolset is disabled, not everything is on anymore.
+func (tg *ToolsetGroup) DisableToolset(name string) error {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+	return tg.setEnabledLocked(name, false)
+}
+
+// SetToolsetEnabled enables or disables a toolset unless it already is, and then calls apply with
+// it before other changes can be made, such as to add its tools to a server or remove them. It
+// reports whether the toolset was changed.
+func (tg *ToolsetGroup) SetToolsetEnabled(name string, enabled bool, apply func(*Toolset)) (bool, error) {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
-		return NewToolsetDoesNotExistError(name)
+		return false, tg.toolsetDoesNotExist(name)
+	}
+	if toolset.Enabled == enabled {
+		return false, nil
+	}
+	if err := tg.setEnabledLocked(name, enabled); err != nil {
+		return false, err
+	}
+	if apply != nil {
+		apply(toolset)
+	}
+	return true, nil
+}
+
+func (tg *ToolsetGroup) setEnabledLocked(name string, enabled bool) error {
+	toolset, exists := tg.Toolsets[name]
+	if !exists {
+		return tg.toolsetDoesNotExist(name)
+	}
+	toolset.Enabled = enabled
+	if !enabled {
+		tg.everythingOn = false
 	}
-	toolset.Enabled = true
-	tg.Toolsets[name] = toolset
 	return nil
 }
 
+// EnabledToolsets returns the names of the enabled toolsets in alphabetical order.
+func (tg *ToolsetGroup) EnabledToolsets() []string {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
+	return tg.enabledToolsetsLocked()
+}
+
+func (tg *ToolsetGroup) enabledToolsetsLocked() []string {
+	names := make([]string, 0, len(tg.Toolsets))
+	for name, toolset := range tg.Toolsets {
+		if toolset.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveTools returns the active tools of toolset, which may belong to the group and so be enabled
+// or disabled concurrently.
+func (tg *ToolsetGroup) ActiveTools(toolset *Toolset) []server.ServerTool {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
+	return toolset.GetActiveTools()
+}
+
+// ToolsetInfo summarizes an enabled toolset for clients.
+type ToolsetInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ToolCount   int    `json:"tool_count"`
+}
+
+// EnabledToolsetsInfo returns the name, description and number of active tools of the enabled
+// toolsets, in alphabetical order of their names.
+func (tg *ToolsetGroup) EnabledToolsetsInfo() []ToolsetInfo {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
+	names := tg.enabledToolsetsLocked()
+	info := make([]ToolsetInfo, 0, len(names))
+	for _, name := range names {
+		toolset := tg.Toolsets[name]
+		info = append(info, ToolsetInfo{
+			Name:        toolset.Name,
+			Description: toolset.Description,
+			ToolCount:   len(toolset.GetActiveTools()),
+		})
+	}
+	return info
+}
+
+// AddToolHandlerWrapper adds a wrapper that RegisterAll applies to the handlers of all tools of the
+// group. Wrappers added first are outermost.
+func (tg *ToolsetGroup) AddToolHandlerWrapper(wrapper ToolHandlerWrapper) {
+	tg.wrappers = append(tg.wrappers, wrapper)
+}
+
+// WrapTools applies the wrappers of the group to the handlers of the tools of toolset. RegisterAll
+// does so for the toolsets of the group, and toolsets registered separately, such as the dynamic
+// toolset, must be wrapped with it before they are registered so that their calls are handled the
+// same way. It must be called only once for a toolset.
+func (tg *ToolsetGroup) WrapTools(toolset *Toolset) {
+	toolset.UpdateTools(func(tool server.ServerTool) server.ServerTool {
+		for i := len(tg.wrappers) - 1; i >= 0; i-- {
+			tool.Handler = tg.wrappers[i](tool.Tool, tool.Handler)
+		}
+		return tool
+	})
+}
+
+// RegisterAll registers the enabled toolsets of the group with s. The handlers of the tools of all
+// toolsets are wrapped first, so that toolsets enabled later register wrapped tools too, which means
+// RegisterAll must only be called once.
 func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
 	for _, toolset := range tg.Toolsets {
+		tg.WrapTools(toolset)
 		toolset.RegisterTools(s)
 		toolset.RegisterResourcesTemplates(s)
 		toolset.RegisterPrompts(s)
@@ -249,7 +411,54 @@ func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
-		return nil, NewToolsetDoesNotExistError(name)
+		return nil, tg.toolsetDoesNotExist(name)
 	}
 	return toolset, nil
 }
+
+// toolsetDoesNotExist returns the error for a toolset name that is not in the group, listing the
+// names that are and suggesting the closest one.
+func (tg *ToolsetGroup) toolsetDoesNotExist(name string) *ToolsetDoesNotExistError {
+	err := NewToolsetDoesNotExistError(name)
+	for validName := range tg.Toolsets {
+		err.ValidNames = append(err.ValidNames, validName)
+	}
+	sort.Strings(err.ValidNames)
+
+	// Only suggest names a few edits away, fewer for short names, as others are unlikely to be what was meant
+	bestDistance := min(maxSuggestionDistance, max(1, len(name)/2)) + 1
+	for _, validName := range err.ValidNames {
+		if distance := levenshtein(strings.ToLower(name), validName); distance < bestDistance {
+			bestDistance = distance
+			err.Suggestion = validName
+		}
+	}
+	return err
+}
+
+// maxSuggestionDistance is the largest edit distance between an unknown toolset name and a valid one
+// for the valid one to be suggested.
+const maxSuggestionDistance = 3
+
+// levenshtein returns the number of single character insertions, deletions and substitutions needed
+// to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -1,10 +1,25 @@
 package toolsets
 
 import (
+	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+func mockTool(name string, readOnly bool) server.ServerTool {
+	return NewServerTool(
+		mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(name), nil
+		},
+	)
+}
+
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 	if len(tsg.Toolsets) != 0 {
@@ -67,6 +82,23 @@ func TestAddToolset(t *testing.T) {
 	}
 }
 
+func TestUpdateTools(t *testing.T) {
+	toolset := NewToolset("test-toolset", "A test toolset").
+		AddReadTools(mockTool("read", true)).
+		AddWriteTools(mockTool("write", false))
+
+	toolset.UpdateTools(func(tool server.ServerTool) server.ServerTool {
+		tool.Tool.Description = "updated " + tool.Tool.Name
+		return tool
+	})
+
+	for _, tool := range toolset.GetAvailableTools() {
+		if tool.Tool.Description != "updated "+tool.Tool.Name {
+			t.Errorf("Expected tool %s to be updated, got description %q", tool.Tool.Name, tool.Tool.Description)
+		}
+	}
+}
+
 func TestIsEnabled(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 
@@ -227,6 +259,32 @@ func TestIsEnabledWithEverythingOn(t *testing.T) {
 	}
 }
 
+func TestEnableEverythingSkipsToolsetsOffByDefault(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("on", "A toolset"))
+	offByDefault := NewToolset("off", "A toolset that is off by default")
+	offByDefault.OffByDefault = true
+	tsg.AddToolset(offByDefault)
+
+	if err := tsg.EnableToolsets([]string{"all"}); err != nil {
+		t.Fatalf("Expected no error when enabling 'all', got: %v", err)
+	}
+	if !tsg.IsEnabled("on") {
+		t.Error("Expected toolset to be enabled by 'all'")
+	}
+	if tsg.IsEnabled("off") {
+		t.Error("Expected toolset that is off by default not to be enabled by 'all'")
+	}
+
+	// It is enabled when asked for by name
+	if err := tsg.EnableToolsets([]string{"all", "off"}); err != nil {
+		t.Fatalf("Expected no error when enabling 'all' and 'off', got: %v", err)
+	}
+	if !tsg.IsEnabled("off") {
+		t.Error("Expected toolset that is off by default to be enabled by name")
+	}
+}
+
 func TestToolsetGroup_GetToolset(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 	toolset := NewToolset("my-toolset", "desc")
@@ -250,3 +308,213 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestDisableToolset(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("toolset1", "Feature 1"))
+	tsg.AddToolset(NewToolset("toolset2", "Feature 2"))
+
+	err := tsg.DisableToolset("non-existent")
+	if !errors.Is(err, NewToolsetDoesNotExistError("non-existent")) {
+		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
+	}
+
+	if err := tsg.EnableToolsets([]string{"all"}); err != nil {
+		t.Fatalf("expected no error when enabling all toolsets, got: %v", err)
+	}
+
+	if err := tsg.DisableToolset("toolset1"); err != nil {
+		t.Errorf("expected no error when disabling toolset, got: %v", err)
+	}
+	if tsg.IsEnabled("toolset1") {
+		t.Error("expected toolset1 to be disabled even though everything was on")
+	}
+	if !tsg.IsEnabled("toolset2") {
+		t.Error("expected toolset2 to stay enabled")
+	}
+
+	// Disabling an already disabled toolset is not an error
+	if err := tsg.DisableToolset("toolset1"); err != nil {
+		t.Errorf("expected no error when disabling already disabled toolset, got: %v", err)
+	}
+}
+
+func TestSetToolsetEnabled(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("toolset1", "Feature 1").AddReadTools(mockTool("tool1", true)))
+
+	applied := 0
+	apply := func(toolset *Toolset) {
+		if toolset.Name != "toolset1" {
+			t.Errorf("expected apply to get toolset1, got %s", toolset.Name)
+		}
+		applied++
+	}
+
+	changed, err := tsg.SetToolsetEnabled("toolset1", true, apply)
+	if err != nil || !changed {
+		t.Fatalf("expected toolset1 to be enabled, got changed %t and error %v", changed, err)
+	}
+	// Enabling it again changes nothing and does not apply
+	changed, err = tsg.SetToolsetEnabled("toolset1", true, apply)
+	if err != nil || changed {
+		t.Errorf("expected toolset1 to stay enabled, got changed %t and error %v", changed, err)
+	}
+	changed, err = tsg.SetToolsetEnabled("toolset1", false, apply)
+	if err != nil || !changed {
+		t.Errorf("expected toolset1 to be disabled, got changed %t and error %v", changed, err)
+	}
+	if applied != 2 {
+		t.Errorf("expected apply to be called twice, got %d", applied)
+	}
+
+	if _, err := tsg.SetToolsetEnabled("non-existent", true, apply); !errors.Is(err, NewToolsetDoesNotExistError("non-existent")) {
+		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
+	}
+}
+
+func TestToolsetChangesAreConcurrencySafe(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("toolset1", "Feature 1").AddReadTools(mockTool("tool1", true))
+	tsg.AddToolset(toolset)
+
+	// Run with -race to check the toolsets can be enabled and disabled while they are read
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = tsg.SetToolsetEnabled("toolset1", i%2 == 0, nil)
+			_ = tsg.EnableToolset("toolset1")
+			_ = tsg.DisableToolset("toolset1")
+			_ = tsg.EnabledToolsets()
+			_ = tsg.EnabledToolsetsInfo()
+			_ = tsg.IsEnabled("toolset1")
+			_ = tsg.ActiveTools(toolset)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEnabledToolsets(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("toolset2", "Feature 2"))
+	tsg.AddToolset(NewToolset("toolset1", "Feature 1"))
+	tsg.AddToolset(NewToolset("toolset3", "Feature 3"))
+
+	if got := tsg.EnabledToolsets(); len(got) != 0 {
+		t.Errorf("expected no enabled toolsets, got %v", got)
+	}
+
+	if err := tsg.EnableToolsets([]string{"toolset3", "toolset1"}); err != nil {
+		t.Fatalf("expected no error when enabling toolsets, got: %v", err)
+	}
+
+	got := tsg.EnabledToolsets()
+	if len(got) != 2 || got[0] != "toolset1" || got[1] != "toolset3" {
+		t.Errorf("expected [toolset1 toolset3], got %v", got)
+	}
+}
+
+func TestEnabledToolsetsInfo(t *testing.T) {
+	tsg := NewToolsetGroup(true)
+	repos := NewToolset("repos", "Repository tools").
+		AddReadTools(mockTool("get_file", true), mockTool("list_commits", true)).
+		AddWriteTools(mockTool("create_branch", false))
+	tsg.AddToolset(repos)
+	tsg.AddToolset(NewToolset("issues", "Issue tools").AddReadTools(mockTool("get_issue", true)))
+	tsg.AddToolset(NewToolset("gists", "Gist tools"))
+
+	if err := tsg.EnableToolsets([]string{"repos", "issues"}); err != nil {
+		t.Fatalf("expected no error when enabling toolsets, got: %v", err)
+	}
+
+	got := tsg.EnabledToolsetsInfo()
+	expected := []ToolsetInfo{
+		{Name: "issues", Description: "Issue tools", ToolCount: 1},
+		// Write tools are not counted in read-only mode
+		{Name: "repos", Description: "Repository tools", ToolCount: 2},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected %v at %d, got %v", expected[i], i, got[i])
+		}
+	}
+}
+
+func TestToolsetDoesNotExistErrorSuggestsClosestName(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("repos", "Repositories"))
+	tsg.AddToolset(NewToolset("issues", "Issues"))
+	tsg.AddToolset(NewToolset("pull_requests", "Pull requests"))
+
+	tests := []struct {
+		name               string
+		toolset            string
+		expectedSuggestion string
+	}{
+		{name: "transposed letters", toolset: "reops", expectedSuggestion: "repos"},
+		{name: "missing letter", toolset: "pull_request", expectedSuggestion: "pull_requests"},
+		{name: "wrong case", toolset: "Issues", expectedSuggestion: "issues"},
+		{name: "nothing close", toolset: "wiki", expectedSuggestion: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tsg.EnableToolsets([]string{tc.toolset})
+
+			var notExist *ToolsetDoesNotExistError
+			if !errors.As(err, &notExist) {
+				t.Fatalf("expected ToolsetDoesNotExistError, got %v", err)
+			}
+			if notExist.Suggestion != tc.expectedSuggestion {
+				t.Errorf("expected suggestion %q, got %q", tc.expectedSuggestion, notExist.Suggestion)
+			}
+			if !strings.Contains(err.Error(), "Valid toolsets are: issues, pull_requests, repos") {
+				t.Errorf("expected error to list the valid toolsets, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestRegisterAllWrapsToolHandlers(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	enabled := NewToolset("enabled", "Enabled toolset").AddReadTools(mockTool("read", true)).AddWriteTools(mockTool("write", false))
+	enabled.Enabled = true
+	tsg.AddToolset(enabled)
+	// Toolsets enabled after registration, such as by the dynamic toolsets, have their tools wrapped too
+	tsg.AddToolset(NewToolset("disabled", "Disabled toolset").AddReadTools(mockTool("later", true)))
+
+	wrapper := func(prefix string) ToolHandlerWrapper {
+		return func(tool mcp.Tool, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				result, err := handler(ctx, request)
+				if err != nil {
+					return nil, err
+				}
+				text := result.Content[0].(mcp.TextContent).Text
+				return mcp.NewToolResultText(prefix + "(" + tool.Name + ":" + text + ")"), nil
+			}
+		}
+	}
+	tsg.AddToolHandlerWrapper(wrapper("outer"))
+	tsg.AddToolHandlerWrapper(wrapper("inner"))
+	tsg.RegisterAll(server.NewMCPServer("test", "1.0"))
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			name := tool.Tool.Name
+			expected := "outer(" + name + ":inner(" + name + ":" + name + "))"
+			if got := result.Content[0].(mcp.TextContent).Text; got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+}
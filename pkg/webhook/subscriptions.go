@@ -0,0 +1,267 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultQueueSize is the number of notifications buffered for each session by default.
+	DefaultQueueSize = 100
+
+	// LoggerName is the logger of the logging notifications events are sent to subscribed sessions as.
+	LoggerName = "github-webhook"
+
+	// retryInterval is how long a queue waits before sending again to a session whose notification
+	// channel was full.
+	retryInterval = 100 * time.Millisecond
+)
+
+// SessionNotifier sends notifications to a given MCP session. It is implemented by *server.MCPServer.
+type SessionNotifier interface {
+	SendNotificationToSpecificClient(sessionID string, method string, params map[string]any) error
+}
+
+// Subscription is a repository a session receives the events of.
+type Subscription struct {
+	Repository string `json:"repository"`
+	// Events are the event types received, or all configured events if empty.
+	Events []string `json:"events,omitempty"`
+}
+
+// message is a notification waiting to be sent to a session.
+type message struct {
+	method string
+	params map[string]any
+}
+
+// sessionQueue holds the subscriptions of a session and the notifications not yet sent to it.
+type sessionQueue struct {
+	// token identifies the GitHub token the subscriptions were made with.
+	token string
+	// repositories maps the lower case full names of the subscribed repositories to their events.
+	repositories map[string]Subscription
+	pending      []message
+	// dropped counts the notifications dropped since the last one sent, because the queue was full.
+	dropped int
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+// Subscriptions keeps track of the repositories MCP sessions subscribed to, and sends the events of
+// those repositories to them. Each session has a bounded queue, so that a session that does not keep
+// up loses its oldest notifications rather than holding up the others.
+type Subscriptions struct {
+	mu        sync.Mutex
+	notifier  SessionNotifier
+	queueSize int
+	sessions  map[string]*sessionQueue
+}
+
+// NewSubscriptions creates Subscriptions that buffer up to queueSize notifications per session. If
+// queueSize is 0 or less, DefaultQueueSize is used. Notifications are only sent once a notifier is
+// attached.
+func NewSubscriptions(queueSize int) *Subscriptions {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &Subscriptions{
+		queueSize: queueSize,
+		sessions:  make(map[string]*sessionQueue),
+	}
+}
+
+// Attach sets the notifier notifications are sent with, which is the MCP server the sessions belong
+// to, and sends the notifications queued until then.
+func (s *Subscriptions) Attach(notifier SessionNotifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = notifier
+	for _, q := range s.sessions {
+		q.notify()
+	}
+}
+
+// Subscribe subscribes a session to the events of repository, in the form owner/repo, replacing the
+// event types it was subscribed to. An empty events subscribes to all configured events. token
+// identifies the GitHub token the caller checked the repository can be read with; subscriptions
+// made with another token are dropped. It returns the subscriptions of the session.
+func (s *Subscriptions) Subscribe(sessionID, token, repository string, events []string) ([]Subscription, error) {
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("repository must be in the form owner/repo, got %q", repository)
+	}
+	if err := ValidateEvents(events); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.sessions[sessionID]
+	if !ok {
+		q = &sessionQueue{
+			repositories: make(map[string]Subscription),
+			wake:         make(chan struct{}, 1),
+			done:         make(chan struct{}),
+		}
+		s.sessions[sessionID] = q
+		go s.run(sessionID, q)
+	}
+	if q.token != token {
+		// What the previous token could read says nothing about the new one
+		clear(q.repositories)
+		q.pending = nil
+		q.dropped = 0
+	}
+	q.token = token
+	events = slices.Clone(events)
+	slices.Sort(events)
+	q.repositories[strings.ToLower(repository)] = Subscription{Repository: repository, Events: slices.Compact(events)}
+	return q.subscriptions(), nil
+}
+
+// Unsubscribe unsubscribes a session from the events of repository. It returns the remaining
+// subscriptions of the session.
+func (s *Subscriptions) Unsubscribe(sessionID, repository string) []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.sessions[sessionID]
+	if !ok {
+		return []Subscription{}
+	}
+	delete(q.repositories, strings.ToLower(repository))
+	if len(q.repositories) == 0 && len(q.pending) == 0 {
+		s.forgetLocked(sessionID)
+	}
+	return q.subscriptions()
+}
+
+// CheckToken drops the subscriptions and pending notifications of a session if they were made with
+// another token than token, as the session may no longer be allowed to read their repositories.
+func (s *Subscriptions) CheckToken(sessionID, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if q, ok := s.sessions[sessionID]; ok && q.token != token {
+		s.forgetLocked(sessionID)
+	}
+}
+
+// Forget drops the subscriptions and pending notifications of a session, when it ends.
+func (s *Subscriptions) Forget(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forgetLocked(sessionID)
+}
+
+func (s *Subscriptions) forgetLocked(sessionID string) {
+	if q, ok := s.sessions[sessionID]; ok {
+		close(q.done)
+		delete(s.sessions, sessionID)
+	}
+}
+
+// deliver queues messages for the sessions subscribed to event on repository, and returns how many
+// sessions they were queued for.
+func (s *Subscriptions) deliver(repository, event string, messages []message) int {
+	key := strings.ToLower(repository)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delivered := 0
+	for _, q := range s.sessions {
+		subscription, ok := q.repositories[key]
+		if !ok || (len(subscription.Events) > 0 && !slices.Contains(subscription.Events, event)) {
+			continue
+		}
+		for _, m := range messages {
+			if len(q.pending) == s.queueSize {
+				q.pending = q.pending[1:]
+				q.dropped++
+			}
+			q.pending = append(q.pending, m)
+		}
+		q.notify()
+		delivered++
+	}
+	return delivered
+}
+
+// run sends the notifications queued for a session until the session is forgotten.
+func (s *Subscriptions) run(sessionID string, q *sessionQueue) {
+	for {
+		select {
+		case <-q.done:
+			return
+		case <-q.wake:
+		}
+
+		for {
+			s.mu.Lock()
+			if len(q.pending) == 0 || s.notifier == nil {
+				s.mu.Unlock()
+				break
+			}
+			next := q.pending[0]
+			if q.dropped > 0 {
+				next = message{method: LoggingNotificationMethod, params: map[string]any{
+					"level":  mcp.LoggingLevelWarning,
+					"logger": LoggerName,
+					"data":   fmt.Sprintf("%d webhook notifications were dropped because the session did not receive them fast enough", q.dropped),
+				}}
+			}
+			notifier := s.notifier
+			s.mu.Unlock()
+
+			err := notifier.SendNotificationToSpecificClient(sessionID, next.method, next.params)
+			switch {
+			case err == nil:
+				s.mu.Lock()
+				if q.dropped > 0 {
+					q.dropped = 0
+				} else if len(q.pending) > 0 {
+					q.pending = q.pending[1:]
+				}
+				s.mu.Unlock()
+			case errors.Is(err, server.ErrSessionNotFound):
+				// The session ended without being forgotten
+				s.Forget(sessionID)
+				return
+			default:
+				// The notification channel of the session is full, try again shortly
+				select {
+				case <-q.done:
+					return
+				case <-time.After(retryInterval):
+				}
+			}
+		}
+	}
+}
+
+// notify wakes the goroutine sending the notifications of the session, unless it is already awake.
+func (q *sessionQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// subscriptions returns the subscriptions of the session, sorted by repository.
+func (q *sessionQueue) subscriptions() []Subscription {
+	subscriptions := make([]Subscription, 0, len(q.repositories))
+	for _, subscription := range q.repositories {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return strings.ToLower(subscriptions[i].Repository) < strings.ToLower(subscriptions[j].Repository)
+	})
+	return subscriptions
+}
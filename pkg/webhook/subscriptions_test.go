@@ -0,0 +1,244 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sessionNotification struct {
+	sessionID string
+	method    string
+	params    map[string]any
+}
+
+// sessionNotifier records the notifications sent to each session. Sending to a session in
+// endedSessions fails as it would for a session that disconnected.
+type sessionNotifier struct {
+	mu            sync.Mutex
+	notifications []sessionNotification
+	endedSessions map[string]bool
+}
+
+func (n *sessionNotifier) SendNotificationToSpecificClient(sessionID string, method string, params map[string]any) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.endedSessions[sessionID] {
+		return server.ErrSessionNotFound
+	}
+	n.notifications = append(n.notifications, sessionNotification{sessionID: sessionID, method: method, params: params})
+	return nil
+}
+
+// waitFor waits until count notifications were sent, and returns them.
+func (n *sessionNotifier) waitFor(t *testing.T, count int) []sessionNotification {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		return len(n.notifications) >= count
+	}, time.Second, time.Millisecond)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]sessionNotification(nil), n.notifications...)
+}
+
+func Test_SubscriptionsBookkeeping(t *testing.T) {
+	subscriptions := NewSubscriptions(0)
+
+	subscribed, err := subscriptions.Subscribe("session-1", "token", "octo/repo", []string{"workflow_run", "check_suite", "workflow_run"})
+	require.NoError(t, err)
+	assert.Equal(t, []Subscription{{Repository: "octo/repo", Events: []string{"check_suite", "workflow_run"}}}, subscribed)
+
+	// Subscribing again replaces the events of the repository
+	subscribed, err = subscriptions.Subscribe("session-1", "token", "Octo/Repo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Subscription{{Repository: "Octo/Repo"}}, subscribed)
+
+	subscribed, err = subscriptions.Subscribe("session-1", "token", "octo/api", []string{"push"})
+	require.NoError(t, err)
+	assert.Equal(t, []Subscription{{Repository: "octo/api", Events: []string{"push"}}, {Repository: "Octo/Repo"}}, subscribed)
+
+	// Sessions are kept apart
+	subscribed, err = subscriptions.Subscribe("session-2", "token", "octo/api", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Subscription{{Repository: "octo/api"}}, subscribed)
+
+	_, err = subscriptions.Subscribe("session-1", "token", "octo", nil)
+	assert.ErrorContains(t, err, "repository must be in the form owner/repo")
+	_, err = subscriptions.Subscribe("session-1", "token", "octo/repo/extra", nil)
+	assert.ErrorContains(t, err, "repository must be in the form owner/repo")
+	_, err = subscriptions.Subscribe("session-1", "token", "octo/repo", []string{"star"})
+	assert.ErrorContains(t, err, `unsupported event "star"`)
+
+	assert.Equal(t, []Subscription{{Repository: "octo/api", Events: []string{"push"}}}, subscriptions.Unsubscribe("session-1", "octo/repo"))
+	assert.Empty(t, subscriptions.Unsubscribe("session-1", "octo/api"))
+	assert.Empty(t, subscriptions.Unsubscribe("unknown-session", "octo/api"))
+
+	subscriptions.Forget("session-2")
+	assert.Zero(t, subscriptions.deliver("octo/api", "push", []message{{method: "test"}}))
+}
+
+func Test_SubscriptionsTokenChange(t *testing.T) {
+	subscriptions := NewSubscriptions(0)
+
+	_, err := subscriptions.Subscribe("session", "token", "octo/repo", nil)
+	require.NoError(t, err)
+
+	// Subscribing with another token drops the subscriptions made with the previous one
+	subscribed, err := subscriptions.Subscribe("session", "other-token", "octo/api", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Subscription{{Repository: "octo/api"}}, subscribed)
+
+	subscriptions.CheckToken("session", "other-token")
+	assert.Equal(t, 1, subscriptions.deliver("octo/api", "push", []message{{method: "push"}}))
+
+	subscriptions.CheckToken("session", "token")
+	assert.Zero(t, subscriptions.deliver("octo/api", "push", []message{{method: "push"}}))
+	assert.Empty(t, subscriptions.Unsubscribe("session", "octo/api"))
+}
+
+func Test_SubscriptionsDeliver(t *testing.T) {
+	notifier := &sessionNotifier{}
+	subscriptions := NewSubscriptions(0)
+	subscriptions.Attach(notifier)
+
+	_, err := subscriptions.Subscribe("all-events", "token", "octo/repo", nil)
+	require.NoError(t, err)
+	_, err = subscriptions.Subscribe("ci-only", "token", "octo/repo", []string{"workflow_run"})
+	require.NoError(t, err)
+	_, err = subscriptions.Subscribe("other-repo", "token", "octo/api", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, subscriptions.deliver("OCTO/REPO", "push", []message{{method: "push"}}))
+	assert.Equal(t, 2, subscriptions.deliver("octo/repo", "workflow_run", []message{{method: "workflow_run"}}))
+
+	notifications := notifier.waitFor(t, 3)
+	received := map[string][]string{}
+	for _, n := range notifications {
+		received[n.sessionID] = append(received[n.sessionID], n.method)
+	}
+	assert.Equal(t, map[string][]string{
+		"all-events": {"push", "workflow_run"},
+		"ci-only":    {"workflow_run"},
+	}, received)
+}
+
+func Test_SubscriptionsBoundedQueue(t *testing.T) {
+	subscriptions := NewSubscriptions(2)
+	_, err := subscriptions.Subscribe("session", "token", "octo/repo", nil)
+	require.NoError(t, err)
+
+	// Nothing is sent until a notifier is attached, so the oldest notifications are dropped
+	for _, method := range []string{"first", "second", "third", "fourth"} {
+		subscriptions.deliver("octo/repo", "push", []message{{method: method}})
+	}
+
+	notifier := &sessionNotifier{}
+	subscriptions.Attach(notifier)
+
+	notifications := notifier.waitFor(t, 3)
+	require.Len(t, notifications, 3)
+	assert.Equal(t, LoggingNotificationMethod, notifications[0].method)
+	assert.Equal(t, mcp.LoggingLevelWarning, notifications[0].params["level"])
+	assert.Contains(t, notifications[0].params["data"], "2 webhook notifications were dropped")
+	assert.Equal(t, "third", notifications[1].method)
+	assert.Equal(t, "fourth", notifications[2].method)
+}
+
+func Test_SubscriptionsForgetEndedSession(t *testing.T) {
+	notifier := &sessionNotifier{endedSessions: map[string]bool{"ended": true}}
+	subscriptions := NewSubscriptions(0)
+	subscriptions.Attach(notifier)
+
+	_, err := subscriptions.Subscribe("ended", "token", "octo/repo", nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, subscriptions.deliver("octo/repo", "push", []message{{method: "push"}}))
+
+	// The session is forgotten once a notification cannot be sent to it
+	assert.Eventually(t, func() bool {
+		return subscriptions.deliver("octo/repo", "push", []message{{method: "push"}}) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func Test_HandlerDeliversToSubscribedSessions(t *testing.T) {
+	checkSuitePayload := `{
+		"action": "completed",
+		"check_suite": {"status": "completed", "conclusion": "failure", "head_branch": "fix-crash", "head_sha": "bbb",
+			"url": "https://api.github.com/repos/octo/repo/check-suites/1", "app": {"name": "CI"}, "pull_requests": [{"number": 7}]},
+		"repository": {"full_name": "octo/repo"},
+		"sender": {"login": "octocat"}
+	}`
+	pushPayload := `{
+		"ref": "refs/heads/main",
+		"commits": [{"modified": ["README.md"]}],
+		"repository": {"full_name": "octo/repo", "name": "repo", "owner": {"name": "octo"}}
+	}`
+
+	broadcast := &recordingNotifier{}
+	notifier := &sessionNotifier{}
+	subscriptions := NewSubscriptions(0)
+	subscriptions.Attach(notifier)
+	_, err := subscriptions.Subscribe("session", "token", "octo/repo", nil)
+	require.NoError(t, err)
+
+	handler := NewHandler(testSecret, []string{"check_suite", "push"}, broadcast, subscriptions)
+	for _, delivery := range []struct{ event, body string }{
+		{"check_suite", checkSuitePayload},
+		{"push", pushPayload},
+	} {
+		req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(delivery.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", delivery.event)
+		req.Header.Set("X-GitHub-Delivery", "delivery-id")
+		req.Header.Set("X-Hub-Signature-256", sign(testSecret, delivery.body))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+
+	notifications := notifier.waitFor(t, 3)
+	require.Len(t, notifications, 3)
+	assert.Equal(t, sessionNotification{
+		sessionID: "session",
+		method:    LoggingNotificationMethod,
+		params: map[string]any{
+			"level":  mcp.LoggingLevelWarning,
+			"logger": LoggerName,
+			"data": map[string]any{
+				"event":         "check_suite",
+				"delivery":      "delivery-id",
+				"action":        "completed",
+				"repository":    "octo/repo",
+				"sender":        "octocat",
+				"app":           "CI",
+				"status":        "completed",
+				"conclusion":    "failure",
+				"head_branch":   "fix-crash",
+				"head_sha":      "bbb",
+				"pull_requests": []int{7},
+				"url":           "https://api.github.com/repos/octo/repo/check-suites/1",
+			},
+		},
+	}, notifications[0])
+	assert.Equal(t, LoggingNotificationMethod, notifications[1].method)
+	assert.Equal(t, mcp.LoggingLevelInfo, notifications[1].params["level"])
+	assert.Equal(t, sessionNotification{
+		sessionID: "session",
+		method:    mcp.MethodNotificationResourceUpdated,
+		params:    map[string]any{"uri": "repo://octo/repo/refs/heads/main/contents/README.md"},
+	}, notifications[2])
+
+	// Events are not broadcast to the sessions that did not subscribe
+	assert.Empty(t, broadcast.notifications)
+}
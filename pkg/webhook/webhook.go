@@ -0,0 +1,297 @@
+// Package webhook receives GitHub webhook deliveries and forwards them to connected MCP clients as notifications.
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// Path is the HTTP path the webhook receiver is served at.
+	Path = "/webhook"
+
+	// NotificationMethod is the MCP notification method webhook events are forwarded with.
+	NotificationMethod = "notifications/github/webhook"
+
+	// LoggingNotificationMethod is the MCP logging notification method events are sent to
+	// subscribed sessions with.
+	LoggingNotificationMethod = "notifications/message"
+
+	// maxPayloadSize is the largest payload GitHub delivers, 25 MB.
+	maxPayloadSize = 25 << 20
+
+	// maxUpdatedResources caps the number of resource update notifications sent for a single push.
+	maxUpdatedResources = 100
+)
+
+// SupportedEvents are the webhook event types forwarded to MCP clients.
+var SupportedEvents = []string{"issues", "pull_request", "push", "workflow_run", "check_suite"}
+
+// ValidateEvents returns an error if events contains an event type that is not supported.
+func ValidateEvents(events []string) error {
+	for _, event := range events {
+		if !slices.Contains(SupportedEvents, event) {
+			return fmt.Errorf("unsupported event %q, supported events are %s", event, strings.Join(SupportedEvents, ", "))
+		}
+	}
+	return nil
+}
+
+// Notifier sends notifications to connected MCP clients. It is implemented by *server.MCPServer.
+type Notifier interface {
+	SendNotificationToAllClients(method string, params map[string]any)
+}
+
+// Handler is an http.Handler that validates GitHub webhook deliveries and forwards
+// issue, pull request, push, workflow run and check suite events to MCP clients.
+type Handler struct {
+	secret        []byte
+	events        []string
+	notifier      Notifier
+	subscriptions *Subscriptions
+}
+
+// NewHandler creates a webhook Handler that validates deliveries against secret and forwards
+// events of the given types, or of all supported types if events is empty. Events are sent to the
+// sessions subscribed to their repository in subscriptions, or broadcast to all clients through
+// notifier if subscriptions is nil.
+func NewHandler(secret string, events []string, notifier Notifier, subscriptions *Subscriptions) *Handler {
+	if len(events) == 0 {
+		events = SupportedEvents
+	}
+	return &Handler{
+		secret:        []byte(secret),
+		events:        events,
+		notifier:      notifier,
+		subscriptions: subscriptions,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Only the SHA-256 signature is accepted, the legacy SHA-1 X-Hub-Signature header is ignored.
+	signature := r.Header.Get(github.SHA256SignatureHeader)
+	if !strings.HasPrefix(signature, "sha256=") {
+		http.Error(w, "missing or malformed "+github.SHA256SignatureHeader+" header", http.StatusUnauthorized)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != "application/json" && contentType != "application/x-www-form-urlencoded" {
+		http.Error(w, "unsupported content type "+r.Header.Get("Content-Type"), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	payload, err := github.ValidatePayloadFromBody(contentType, bytes.NewReader(body), signature, h.secret)
+	if err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventType := github.WebHookType(r)
+	if eventType == "ping" {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("pong"))
+		return
+	}
+
+	if !slices.Contains(h.events, eventType) {
+		// Signed but unwanted events are acknowledged so GitHub does not report failed deliveries.
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("event ignored"))
+		return
+	}
+
+	event, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse %q event: %s", eventType, err), http.StatusBadRequest)
+		return
+	}
+
+	params, resources := eventNotification(event)
+	if params == nil {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("event ignored"))
+		return
+	}
+	params["event"] = eventType
+	params["delivery"] = github.DeliveryID(r)
+
+	if h.subscriptions != nil {
+		h.subscriptions.deliver(params["repository"].(string), eventType, sessionMessages(params, resources))
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	h.notifier.SendNotificationToAllClients(NotificationMethod, params)
+	for _, uri := range resources {
+		h.notifier.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// sessionMessages converts the notification params of an event into the messages sent to the
+// sessions subscribed to its repository: a logging notification carrying the event, followed by the
+// updates of the resources it changed. Failed workflow runs and check suites are logged as warnings.
+func sessionMessages(params map[string]any, resources []string) []message {
+	level := mcp.LoggingLevelInfo
+	if conclusion, _ := params["conclusion"].(string); conclusion == "failure" || conclusion == "timed_out" {
+		level = mcp.LoggingLevelWarning
+	}
+	messages := []message{{
+		method: LoggingNotificationMethod,
+		params: map[string]any{"level": level, "logger": LoggerName, "data": params},
+	}}
+	for _, uri := range resources {
+		messages = append(messages, message{method: mcp.MethodNotificationResourceUpdated, params: map[string]any{"uri": uri}})
+	}
+	return messages
+}
+
+// eventNotification converts a supported webhook event into notification params and the URIs of
+// the repository resources it updated. It returns nil params for unsupported events.
+func eventNotification(event any) (map[string]any, []string) {
+	switch e := event.(type) {
+	case *github.IssuesEvent:
+		return map[string]any{
+			"action":     e.GetAction(),
+			"repository": e.GetRepo().GetFullName(),
+			"sender":     e.GetSender().GetLogin(),
+			"issue": map[string]any{
+				"number": e.GetIssue().GetNumber(),
+				"title":  e.GetIssue().GetTitle(),
+				"state":  e.GetIssue().GetState(),
+				"url":    e.GetIssue().GetHTMLURL(),
+			},
+		}, nil
+	case *github.PullRequestEvent:
+		pr := e.GetPullRequest()
+		return map[string]any{
+			"action":     e.GetAction(),
+			"repository": e.GetRepo().GetFullName(),
+			"sender":     e.GetSender().GetLogin(),
+			"pull_request": map[string]any{
+				"number": pr.GetNumber(),
+				"title":  pr.GetTitle(),
+				"state":  pr.GetState(),
+				"merged": pr.GetMerged(),
+				"draft":  pr.GetDraft(),
+				"head":   pr.GetHead().GetRef(),
+				"base":   pr.GetBase().GetRef(),
+				"url":    pr.GetHTMLURL(),
+			},
+		}, nil
+	case *github.PushEvent:
+		return map[string]any{
+			"repository":  e.GetRepo().GetFullName(),
+			"sender":      e.GetSender().GetLogin(),
+			"ref":         e.GetRef(),
+			"before":      e.GetBefore(),
+			"after":       e.GetAfter(),
+			"created":     e.GetCreated(),
+			"deleted":     e.GetDeleted(),
+			"forced":      e.GetForced(),
+			"commits":     len(e.Commits),
+			"head_commit": e.GetHeadCommit().GetMessage(),
+			"compare_url": e.GetCompare(),
+		}, pushedResources(e)
+	case *github.WorkflowRunEvent:
+		run := e.GetWorkflowRun()
+		return map[string]any{
+			"action":        e.GetAction(),
+			"repository":    e.GetRepo().GetFullName(),
+			"sender":        e.GetSender().GetLogin(),
+			"workflow":      run.GetName(),
+			"status":        run.GetStatus(),
+			"conclusion":    run.GetConclusion(),
+			"head_branch":   run.GetHeadBranch(),
+			"head_sha":      run.GetHeadSHA(),
+			"pull_requests": pullRequestNumbers(run.PullRequests),
+			"url":           run.GetHTMLURL(),
+		}, nil
+	case *github.CheckSuiteEvent:
+		suite := e.GetCheckSuite()
+		return map[string]any{
+			"action":        e.GetAction(),
+			"repository":    e.GetRepo().GetFullName(),
+			"sender":        e.GetSender().GetLogin(),
+			"app":           suite.GetApp().GetName(),
+			"status":        suite.GetStatus(),
+			"conclusion":    suite.GetConclusion(),
+			"head_branch":   suite.GetHeadBranch(),
+			"head_sha":      suite.GetHeadSHA(),
+			"pull_requests": pullRequestNumbers(suite.PullRequests),
+			"url":           suite.GetURL(),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// pullRequestNumbers returns the numbers of the pull requests a workflow run or check suite ran for.
+func pullRequestNumbers(pullRequests []*github.PullRequest) []int {
+	numbers := make([]int, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		numbers = append(numbers, pr.GetNumber())
+	}
+	return numbers
+}
+
+// pushedResources returns the repo:// resource URIs of the files changed on a branch by a push.
+func pushedResources(e *github.PushEvent) []string {
+	branch, ok := strings.CutPrefix(e.GetRef(), "refs/heads/")
+	if !ok || e.GetDeleted() {
+		return nil
+	}
+	owner, repo := e.GetRepo().GetOwner().GetLogin(), e.GetRepo().GetName()
+	if owner == "" {
+		// Push payloads identify the owner by name rather than login.
+		owner = e.GetRepo().GetOwner().GetName()
+	}
+
+	seen := map[string]bool{}
+	var uris []string
+	for _, commit := range e.Commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, file := range files {
+				if seen[file] || len(uris) == maxUpdatedResources {
+					continue
+				}
+				seen[file] = true
+				uri, err := url.JoinPath("repo://", owner, repo, "refs", "heads", branch, "contents", file)
+				if err != nil {
+					continue
+				}
+				uris = append(uris, uri)
+			}
+		}
+	}
+	return uris
+}
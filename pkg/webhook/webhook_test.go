@@ -0,0 +1,315 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cr3t"
+
+type notification struct {
+	method string
+	params map[string]any
+}
+
+type recordingNotifier struct {
+	notifications []notification
+}
+
+func (n *recordingNotifier) SendNotificationToAllClients(method string, params map[string]any) {
+	n.notifications = append(n.notifications, notification{method: method, params: params})
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_Handler(t *testing.T) {
+	issuesPayload := `{
+		"action": "opened",
+		"issue": {"number": 42, "title": "Crash on start", "state": "open", "html_url": "https://github.com/octo/repo/issues/42"},
+		"repository": {"full_name": "octo/repo"},
+		"sender": {"login": "octocat"}
+	}`
+	pullRequestPayload := `{
+		"action": "closed",
+		"pull_request": {"number": 7, "title": "Fix crash", "state": "closed", "merged": true, "html_url": "https://github.com/octo/repo/pull/7",
+			"head": {"ref": "fix-crash"}, "base": {"ref": "main"}},
+		"repository": {"full_name": "octo/repo"},
+		"sender": {"login": "octocat"}
+	}`
+	pushPayload := `{
+		"ref": "refs/heads/main",
+		"before": "aaa",
+		"after": "bbb",
+		"compare": "https://github.com/octo/repo/compare/aaa...bbb",
+		"commits": [
+			{"message": "Update docs", "added": ["docs/new.md"], "modified": ["README.md"], "removed": []},
+			{"message": "Tweak docs", "added": [], "modified": ["README.md"], "removed": ["old.md"]}
+		],
+		"head_commit": {"message": "Tweak docs"},
+		"repository": {"full_name": "octo/repo", "name": "repo", "owner": {"name": "octo"}},
+		"sender": {"login": "octocat"}
+	}`
+
+	workflowRunPayload := `{
+		"action": "completed",
+		"workflow_run": {"name": "CI", "status": "completed", "conclusion": "failure", "head_branch": "fix-crash", "head_sha": "bbb",
+			"html_url": "https://github.com/octo/repo/actions/runs/1", "pull_requests": [{"number": 7}]},
+		"repository": {"full_name": "octo/repo"},
+		"sender": {"login": "octocat"}
+	}`
+
+	tests := []struct {
+		name                  string
+		method                string
+		events                []string
+		event                 string
+		contentType           string
+		body                  string
+		signature             string
+		expectedStatus        int
+		expectedNotifications []notification
+	}{
+		{
+			name:           "signed issues event",
+			event:          "issues",
+			body:           issuesPayload,
+			signature:      sign(testSecret, issuesPayload),
+			expectedStatus: http.StatusAccepted,
+			expectedNotifications: []notification{
+				{
+					method: NotificationMethod,
+					params: map[string]any{
+						"event":      "issues",
+						"delivery":   "delivery-id",
+						"action":     "opened",
+						"repository": "octo/repo",
+						"sender":     "octocat",
+						"issue": map[string]any{
+							"number": 42,
+							"title":  "Crash on start",
+							"state":  "open",
+							"url":    "https://github.com/octo/repo/issues/42",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "signed pull request event",
+			event:          "pull_request",
+			body:           pullRequestPayload,
+			signature:      sign(testSecret, pullRequestPayload),
+			expectedStatus: http.StatusAccepted,
+			expectedNotifications: []notification{
+				{
+					method: NotificationMethod,
+					params: map[string]any{
+						"event":      "pull_request",
+						"delivery":   "delivery-id",
+						"action":     "closed",
+						"repository": "octo/repo",
+						"sender":     "octocat",
+						"pull_request": map[string]any{
+							"number": 7,
+							"title":  "Fix crash",
+							"state":  "closed",
+							"merged": true,
+							"draft":  false,
+							"head":   "fix-crash",
+							"base":   "main",
+							"url":    "https://github.com/octo/repo/pull/7",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "signed push event updates resources",
+			event:          "push",
+			body:           pushPayload,
+			signature:      sign(testSecret, pushPayload),
+			expectedStatus: http.StatusAccepted,
+			expectedNotifications: []notification{
+				{
+					method: NotificationMethod,
+					params: map[string]any{
+						"event":       "push",
+						"delivery":    "delivery-id",
+						"repository":  "octo/repo",
+						"sender":      "octocat",
+						"ref":         "refs/heads/main",
+						"before":      "aaa",
+						"after":       "bbb",
+						"created":     false,
+						"deleted":     false,
+						"forced":      false,
+						"commits":     2,
+						"head_commit": "Tweak docs",
+						"compare_url": "https://github.com/octo/repo/compare/aaa...bbb",
+					},
+				},
+				{method: mcp.MethodNotificationResourceUpdated, params: map[string]any{"uri": "repo://octo/repo/refs/heads/main/contents/docs/new.md"}},
+				{method: mcp.MethodNotificationResourceUpdated, params: map[string]any{"uri": "repo://octo/repo/refs/heads/main/contents/README.md"}},
+				{method: mcp.MethodNotificationResourceUpdated, params: map[string]any{"uri": "repo://octo/repo/refs/heads/main/contents/old.md"}},
+			},
+		},
+		{
+			name:           "unsigned payload is rejected",
+			event:          "issues",
+			body:           issuesPayload,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "payload signed with another secret is rejected",
+			event:          "issues",
+			body:           issuesPayload,
+			signature:      sign("not-the-secret", issuesPayload),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "tampered payload is rejected",
+			event:          "issues",
+			body:           strings.Replace(issuesPayload, "opened", "closed", 1),
+			signature:      sign(testSecret, issuesPayload),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "sha1 signature is rejected",
+			event:          "issues",
+			body:           issuesPayload,
+			signature:      "sha1=" + strings.TrimPrefix(sign(testSecret, issuesPayload), "sha256="),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "signed form encoded payload",
+			event:          "issues",
+			contentType:    "application/x-www-form-urlencoded",
+			body:           "payload=" + url.QueryEscape(`{"action":"closed","issue":{"number":1},"repository":{"full_name":"octo/repo"}}`),
+			signature:      sign(testSecret, "payload="+url.QueryEscape(`{"action":"closed","issue":{"number":1},"repository":{"full_name":"octo/repo"}}`)),
+			expectedStatus: http.StatusAccepted,
+			expectedNotifications: []notification{
+				{
+					method: NotificationMethod,
+					params: map[string]any{
+						"event":      "issues",
+						"delivery":   "delivery-id",
+						"action":     "closed",
+						"repository": "octo/repo",
+						"sender":     "",
+						"issue":      map[string]any{"number": 1, "title": "", "state": "", "url": ""},
+					},
+				},
+			},
+		},
+		{
+			name:           "ping",
+			event:          "ping",
+			body:           `{"zen": "Keep it logically awesome."}`,
+			signature:      sign(testSecret, `{"zen": "Keep it logically awesome."}`),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unsupported event is ignored",
+			event:          "star",
+			body:           `{"action": "created"}`,
+			signature:      sign(testSecret, `{"action": "created"}`),
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "event that is not configured is ignored",
+			events:         []string{"push", "workflow_run"},
+			event:          "issues",
+			body:           issuesPayload,
+			signature:      sign(testSecret, issuesPayload),
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "signed workflow run event",
+			event:          "workflow_run",
+			body:           workflowRunPayload,
+			signature:      sign(testSecret, workflowRunPayload),
+			expectedStatus: http.StatusAccepted,
+			expectedNotifications: []notification{
+				{
+					method: NotificationMethod,
+					params: map[string]any{
+						"event":         "workflow_run",
+						"delivery":      "delivery-id",
+						"action":        "completed",
+						"repository":    "octo/repo",
+						"sender":        "octocat",
+						"workflow":      "CI",
+						"status":        "completed",
+						"conclusion":    "failure",
+						"head_branch":   "fix-crash",
+						"head_sha":      "bbb",
+						"pull_requests": []int{7},
+						"url":           "https://github.com/octo/repo/actions/runs/1",
+					},
+				},
+			},
+		},
+		{
+			name:           "unsupported content type",
+			event:          "issues",
+			contentType:    "text/plain",
+			body:           issuesPayload,
+			signature:      sign(testSecret, issuesPayload),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			event:          "issues",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			handler := NewHandler(testSecret, tc.events, notifier, nil)
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			contentType := tc.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+
+			req := httptest.NewRequest(method, Path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("X-GitHub-Event", tc.event)
+			req.Header.Set("X-GitHub-Delivery", "delivery-id")
+			if tc.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tc.signature)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatus, rec.Code, rec.Body.String())
+			if tc.expectedNotifications == nil {
+				assert.Empty(t, notifier.notifications)
+				return
+			}
+			assert.Equal(t, tc.expectedNotifications, notifier.notifications)
+		})
+	}
+}